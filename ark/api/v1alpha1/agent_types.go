@@ -81,6 +81,92 @@ type AgentSpec struct {
 	OutputSchema *runtime.RawExtension `json:"outputSchema,omitempty"`
 	// +kubebuilder:validation:Optional
 	Overrides []Override `json:"overrides,omitempty"`
+	// +kubebuilder:validation:Optional
+	// FewShot adds past questions with positively rated answers, most similar to the current question, to the prompt
+	FewShot *FewShotConfig `json:"fewShot,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Review critiques the agent's answer and has the agent revise it before the answer is returned
+	Review *AgentReviewConfig `json:"review,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ToolPolicy restricts which tools the agent may call and how often
+	ToolPolicy *AgentToolPolicy `json:"toolPolicy,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ToolSchemas controls when the definitions of the agent's tools are sent to its model
+	ToolSchemas *AgentToolSchemas `json:"toolSchemas,omitempty"`
+}
+
+const (
+	// ToolSchemasAlways sends the definitions of the agent's tools with every call to its model
+	ToolSchemasAlways = "always"
+	// ToolSchemasAdaptive sends the definitions of the agent's tools once the conversation suggests tool use
+	ToolSchemasAdaptive = "adaptive"
+)
+
+// AgentToolSchemas controls when the definitions of the agent's tools are sent to its model. Deferring them saves
+// the prompt tokens of large tool registries on turns that are only chat.
+type AgentToolSchemas struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=always;adaptive
+	// +kubebuilder:default=always
+	// Mode is always, or adaptive to send the definitions only once the conversation used a tool, the user message
+	// mentions a tool or a keyword, or the model asks for them
+	Mode string `json:"mode,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Keywords in the user message that suggest tool use, in addition to the words of the tool names
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// AgentToolPolicy restricts the tool calls of an agent. Calls it blocks fail the agent's execution.
+type AgentToolPolicy struct {
+	// +kubebuilder:validation:Optional
+	// Allow lists glob patterns, e.g. github_*, of the tools the agent may call. All tools are allowed when empty.
+	Allow []string `json:"allow,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Deny lists glob patterns of the tools the agent may not call. Deny takes precedence over allow.
+	Deny []string `json:"deny,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxCallsPerExecution caps the number of tool calls in one execution of the agent
+	MaxCallsPerExecution *int32 `json:"maxCallsPerExecution,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxParallelCalls caps the number of tool calls of the agent running at the same time
+	MaxParallelCalls *int32 `json:"maxParallelCalls,omitempty"`
+}
+
+// FewShotConfig selects few-shot examples from positively rated feedback on the agent's responses.
+type FewShotConfig struct {
+	// +kubebuilder:validation:Required
+	// EmbeddingModelRef is the model used to embed questions for similarity search. It must be an openai or azure model.
+	EmbeddingModelRef AgentModelRef `json:"embeddingModelRef"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=20
+	// +kubebuilder:default=3
+	// TopK is the maximum number of examples added to the prompt
+	TopK int32 `json:"topK,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1000
+	// MaxTokens caps the estimated number of tokens the examples add to the prompt
+	MaxTokens int32 `json:"maxTokens,omitempty"`
+}
+
+// AgentReviewConfig configures the review of the agent's answers by a reviewer model or agent.
+// The reviewer is the agent's own model when neither modelRef nor agent is set.
+type AgentReviewConfig struct {
+	// +kubebuilder:validation:Optional
+	// ModelRef is the model that reviews the answers
+	ModelRef *AgentModelRef `json:"modelRef,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Agent is the name of an agent in the same namespace that reviews the answers
+	Agent string `json:"agent,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=5
+	// +kubebuilder:default=1
+	// MaxRounds is the maximum number of times the agent revises its answer
+	MaxRounds int32 `json:"maxRounds,omitempty"`
 }
 
 type AgentStatus struct {
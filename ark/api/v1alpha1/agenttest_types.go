@@ -0,0 +1,138 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AgentTest phases
+const (
+	AgentTestPhaseRunning = "running"
+	AgentTestPhasePassed  = "passed"
+	AgentTestPhaseFailed  = "failed"
+	AgentTestPhaseError   = "error"
+)
+
+// AgentTestPassed is the condition type of an AgentTest reporting whether it passed
+const AgentTestPassed = "Passed"
+
+// AgentTestSpec defines a test of an agent's answer to an input
+type AgentTestSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Agent is the name of the agent under test, in the namespace of the test
+	Agent string `json:"agent"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Input is the user message sent to the agent
+	Input string `json:"input"`
+	// +kubebuilder:validation:Optional
+	// ModelRef pins the model the agent answers with during the test, such as a mock model or a specific
+	// version of a real model, in place of the agent's own model
+	ModelRef *AgentModelRef `json:"modelRef,omitempty"`
+	// +kubebuilder:validation:Optional
+	// MockTools answer the calls of the agent to these tools with fixed responses instead of running them
+	MockTools []AgentTestMockTool `json:"mockTools,omitempty"`
+	// +kubebuilder:validation:Required
+	// Expect lists the assertions on the agent's response
+	Expect AgentTestExpectations `json:"expect"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="5m"
+	// Timeout of the agent's answer
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// AgentTestMockTool is a fixed response to the calls of a tool
+type AgentTestMockTool struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Name of the tool, as listed in the agent's tools
+	Name string `json:"name"`
+	// +kubebuilder:validation:Optional
+	// Response returned to the agent for every call of the tool
+	Response string `json:"response,omitempty"`
+}
+
+// AgentTestExpectations are the assertions on an agent's response. The test passes when all of them hold.
+type AgentTestExpectations struct {
+	// +kubebuilder:validation:Optional
+	// Patterns are regular expressions the response must match
+	Patterns []string `json:"patterns,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ExcludedPatterns are regular expressions the response must not match
+	ExcludedPatterns []string `json:"excludedPatterns,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Rules are CEL expressions over the query of the test, with the variables of query evaluation rules,
+	// that must return true. Their weights are ignored.
+	Rules []ExpressionRule `json:"rules,omitempty"`
+}
+
+// AgentTestAssertionResult is the outcome of an assertion of a test
+type AgentTestAssertionResult struct {
+	// Name of the assertion: pattern, excludedPattern or the name of the rule
+	Name string `json:"name"`
+	// +kubebuilder:validation:Optional
+	// Expression is the pattern or CEL expression of the assertion
+	Expression string `json:"expression,omitempty"`
+	Passed     bool   `json:"passed"`
+	// +kubebuilder:validation:Optional
+	// Message explains why the assertion failed
+	Message string `json:"message,omitempty"`
+}
+
+// AgentTestStatus defines the observed state of AgentTest
+type AgentTestStatus struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=running;passed;failed;error
+	Phase string `json:"phase,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ObservedGeneration is the generation of the spec the status reports on
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Query is the query the agent answered the test in
+	Query string `json:"query,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Response is the agent's response
+	Response string `json:"response,omitempty"`
+	// +kubebuilder:validation:Optional
+	Assertions []AgentTestAssertionResult `json:"assertions,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Message explains a test that could not be run
+	Message string `json:"message,omitempty"`
+	// +kubebuilder:validation:Optional
+	// CompletionTime is when the test last completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Agent",type=string,JSONPath=`.spec.agent`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Query",type=string,JSONPath=`.status.query`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// AgentTest is the Schema for the agenttests API. It runs an input through an agent, with mocked tools and a
+// pinned model, and asserts on the response.
+type AgentTest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AgentTestSpec   `json:"spec,omitempty"`
+	Status AgentTestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AgentTestList contains a list of AgentTest.
+type AgentTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AgentTest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AgentTest{}, &AgentTestList{})
+}
@@ -0,0 +1,122 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArkInstallStatusName is the name of the one ArkInstallStatus, which the controller creates and maintains
+const ArkInstallStatusName = "ark"
+
+// ArkInstallHealthy is the condition of an ArkInstallStatus that is true when none of its checks found a problem
+const ArkInstallHealthy = "Healthy"
+
+// ArkInstallStatusSpec is empty: the install status is maintained by the controller
+type ArkInstallStatusSpec struct{}
+
+// InstallWebhookStatus reports the certificate the admission webhooks are served with
+type InstallWebhookStatus struct {
+	// Enabled is false when the controller runs without admission webhooks
+	Enabled bool `json:"enabled"`
+	// +kubebuilder:validation:Optional
+	// CertificateNotAfter is when the webhook certificate expires
+	CertificateNotAfter *metav1.Time `json:"certificateNotAfter,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Message describes a certificate that cannot be read, has expired or expires soon
+	Message string `json:"message,omitempty"`
+}
+
+// InstallCRDStatus reports the versions of an Ark CRD installed in the cluster
+type InstallCRDStatus struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Optional
+	ServedVersions []string `json:"servedVersions,omitempty"`
+	// +kubebuilder:validation:Optional
+	// StoredVersions are the versions objects of the CRD have been persisted in
+	StoredVersions []string `json:"storedVersions,omitempty"`
+	Established    bool     `json:"established"`
+}
+
+// InstallTelemetryStatus reports whether the OTEL endpoint telemetry is exported to can be reached
+type InstallTelemetryStatus struct {
+	// +kubebuilder:validation:Optional
+	// Endpoint is the OTEL exporter endpoint, empty when telemetry is not exported
+	Endpoint string `json:"endpoint,omitempty"`
+	// +kubebuilder:validation:Optional
+	Reachable bool `json:"reachable,omitempty"`
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// InstallConfigCheck is the result of validating a configuration
+type InstallConfigCheck struct {
+	Valid bool `json:"valid"`
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// InstallNamespaceStatus reports the validity of the streaming and memory configuration of a namespace
+type InstallNamespaceStatus struct {
+	Namespace string `json:"namespace"`
+	// +kubebuilder:validation:Optional
+	// Streaming validates the ark-config-streaming ConfigMap, when the namespace has one
+	Streaming *InstallConfigCheck `json:"streaming,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Memory validates the memories of the namespace, when it has any
+	Memory *InstallConfigCheck `json:"memory,omitempty"`
+}
+
+// ArkInstallStatusStatus summarizes the health of the Ark install
+type ArkInstallStatusStatus struct {
+	// +kubebuilder:validation:Optional
+	// Version is the version of the controller that checked the install
+	Version string `json:"version,omitempty"`
+	// +kubebuilder:validation:Optional
+	LastCheckedTime *metav1.Time `json:"lastCheckedTime,omitempty"`
+	// +kubebuilder:validation:Optional
+	Webhook InstallWebhookStatus `json:"webhook,omitempty"`
+	// +kubebuilder:validation:Optional
+	CRDs []InstallCRDStatus `json:"crds,omitempty"`
+	// +kubebuilder:validation:Optional
+	Telemetry InstallTelemetryStatus `json:"telemetry,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Namespaces are the namespaces that configure streaming or have memories
+	Namespaces []InstallNamespaceStatus `json:"namespaces,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'ark'",message="the install status is a singleton named ark"
+// +kubebuilder:printcolumn:name="Healthy",type=string,JSONPath=`.status.conditions[?(@.type=="Healthy")].status`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.version`
+// +kubebuilder:printcolumn:name="Checked",type=date,JSONPath=`.status.lastCheckedTime`
+
+// ArkInstallStatus is the singleton, maintained by the controller, that summarizes the health of the Ark install:
+// the webhook certificate, the installed CRD versions, the reachability of the telemetry endpoint and the
+// streaming and memory configuration of each namespace.
+type ArkInstallStatus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ArkInstallStatusSpec   `json:"spec,omitempty"`
+	Status ArkInstallStatusStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ArkInstallStatusList contains a list of ArkInstallStatus.
+type ArkInstallStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ArkInstallStatus `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ArkInstallStatus{}, &ArkInstallStatusList{})
+}
@@ -3,6 +3,11 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -58,6 +63,50 @@ type Parameter struct {
 	// +kubebuilder:validation:Optional
 	// Reference to external sources (mutually exclusive with value)
 	ValueFrom *ValueFromSource `json:"valueFrom,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Schema declares the type of the parameter and a default used when neither value nor valueFrom is set
+	Schema *ParameterSchema `json:"schema,omitempty"`
+}
+
+// Parameter schema types
+const (
+	ParameterTypeString  = "string"
+	ParameterTypeInteger = "integer"
+	ParameterTypeEnum    = "enum"
+)
+
+// ParameterSchema declares the type of a parameter value
+type ParameterSchema struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=string;integer;enum
+	// +kubebuilder:default=string
+	Type string `json:"type,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Enum lists the allowed values of enum parameters
+	Enum []string `json:"enum,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Default is the value used when the parameter has neither value nor valueFrom
+	Default string `json:"default,omitempty"`
+}
+
+// Validate returns an error when the value does not match the schema
+func (s *ParameterSchema) Validate(value string) error {
+	switch s.Type {
+	case "", ParameterTypeString:
+		return nil
+	case ParameterTypeInteger:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("value %q is not an integer", value)
+		}
+		return nil
+	case ParameterTypeEnum:
+		if !slices.Contains(s.Enum, value) {
+			return fmt.Errorf("value %q is not one of %s", value, strings.Join(s.Enum, ", "))
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported parameter type %s", s.Type)
+	}
 }
 
 type HeaderValue struct {
@@ -82,6 +131,29 @@ type Header struct {
 	Value HeaderValue `json:"value"`
 }
 
+// ClientTLS configures mutual TLS for ark's requests to a server. Ark presents a client certificate, such as a
+// SPIFFE X.509 SVID, so that the server can authenticate it, and verifies the certificate of the server.
+// +kubebuilder:validation:XValidation:rule="has(self.secretName) != (has(self.workloadIdentity) && self.workloadIdentity)",message="exactly one of secretName or workloadIdentity must be set"
+type ClientTLS struct {
+	// SecretName is a Secret in the namespace of the resource with the client certificate in tls.crt, its private
+	// key in tls.key and, optionally, the CA certificates trusted for the server in ca.crt
+	// +kubebuilder:validation:Optional
+	SecretName string `json:"secretName,omitempty"`
+	// WorkloadIdentity presents the workload certificate of the controller, which a CSI driver such as the SPIFFE
+	// CSI driver mounts in the directory of the controller's --workload-identity-dir flag. The certificate is read
+	// for each connection, so rotated certificates are used without restarting the controller.
+	// +kubebuilder:validation:Optional
+	WorkloadIdentity bool `json:"workloadIdentity,omitempty"`
+	// ServerName is verified in the server certificate instead of the host of the address
+	// +kubebuilder:validation:Optional
+	ServerName string `json:"serverName,omitempty"`
+	// ServerSPIFFEID is the SPIFFE ID the server certificate must hold, such as spiffe://example.org/ns/tools/sa/mcp.
+	// It is verified instead of the server name.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^spiffe://.+`
+	ServerSPIFFEID string `json:"serverSPIFFEID,omitempty"`
+}
+
 type Override struct {
 	// +kubebuilder:validation:Required
 	Headers []Header `json:"headers"`
@@ -0,0 +1,28 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParameterSchema", func() {
+	It("should accept any value for string parameters", func() {
+		Expect((&ParameterSchema{}).Validate("anything")).To(Succeed())
+		Expect((&ParameterSchema{Type: ParameterTypeString}).Validate("")).To(Succeed())
+	})
+
+	It("should validate integer parameters", func() {
+		schema := &ParameterSchema{Type: ParameterTypeInteger}
+		Expect(schema.Validate("42")).To(Succeed())
+		Expect(schema.Validate("-7")).To(Succeed())
+		Expect(schema.Validate("4.2")).To(MatchError(ContainSubstring("not an integer")))
+	})
+
+	It("should validate enum parameters", func() {
+		schema := &ParameterSchema{Type: ParameterTypeEnum, Enum: []string{"short", "long"}}
+		Expect(schema.Validate("short")).To(Succeed())
+		Expect(schema.Validate("medium")).To(MatchError(ContainSubstring("not one of short, long")))
+	})
+})
@@ -0,0 +1,107 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Concurrency policies for CronQuery
+const (
+	// ConcurrencyPolicyAllow creates queries even if earlier ones are still running
+	ConcurrencyPolicyAllow = "Allow"
+	// ConcurrencyPolicyForbid skips a run while an earlier query is still running
+	ConcurrencyPolicyForbid = "Forbid"
+	// ConcurrencyPolicyReplace cancels running queries before creating the new one
+	ConcurrencyPolicyReplace = "Replace"
+)
+
+// QueryTemplateSpec describes the queries created by a CronQuery.
+type QueryTemplateSpec struct {
+	// +kubebuilder:validation:Optional
+	// Labels and annotations copied to every created query
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:Required
+	Spec QuerySpec `json:"spec"`
+}
+
+// CronQuerySpec defines the desired state of CronQuery.
+type CronQuerySpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Schedule in cron format, e.g. "0 2 * * *"
+	Schedule string `json:"schedule"`
+	// +kubebuilder:validation:Optional
+	// TimeZone name for the schedule, e.g. "Europe/London". Defaults to the controller's time zone.
+	TimeZone *string `json:"timeZone,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// StartingDeadlineSeconds is how late a missed run may still be started
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+	// +kubebuilder:default=Allow
+	// ConcurrencyPolicy controls what happens when a run is due while an earlier query is still running
+	ConcurrencyPolicy string `json:"concurrencyPolicy,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Suspend stops new queries from being created. Running queries are not affected.
+	Suspend bool `json:"suspend,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=3
+	// SuccessfulQueriesHistoryLimit is the number of completed queries to keep
+	SuccessfulQueriesHistoryLimit *int32 `json:"successfulQueriesHistoryLimit,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	// FailedQueriesHistoryLimit is the number of failed or canceled queries to keep
+	FailedQueriesHistoryLimit *int32 `json:"failedQueriesHistoryLimit,omitempty"`
+	// +kubebuilder:validation:Required
+	QueryTemplate QueryTemplateSpec `json:"queryTemplate"`
+}
+
+// CronQueryStatus defines the observed state of CronQuery.
+type CronQueryStatus struct {
+	// +kubebuilder:validation:Optional
+	// Active lists the queries that are still running
+	Active []corev1.ObjectReference `json:"active,omitempty"`
+	// +kubebuilder:validation:Optional
+	// LastScheduleTime is when a query was last scheduled
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// +kubebuilder:validation:Optional
+	// LastSuccessfulTime is when a query last completed successfully
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Message provides additional information about the current status
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+// +kubebuilder:printcolumn:name="Suspend",type=boolean,JSONPath=`.spec.suspend`
+// +kubebuilder:printcolumn:name="Last Schedule",type=date,JSONPath=`.status.lastScheduleTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// CronQuery is the Schema for the cronqueries API.
+type CronQuery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CronQuerySpec   `json:"spec,omitempty"`
+	Status CronQueryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CronQueryList contains a list of CronQuery.
+type CronQueryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CronQuery `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CronQuery{}, &CronQueryList{})
+}
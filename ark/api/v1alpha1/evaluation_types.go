@@ -213,6 +213,15 @@ type ChildEvaluationStatus struct {
 	Message string `json:"message,omitempty"`
 }
 
+// EvaluationJudgement is the score a judge gave a query response against the rubric of the evaluator
+type EvaluationJudgement struct {
+	// +kubebuilder:validation:Pattern=^(0(\.[0-9]+)?|1(\.0+)?)$
+	Score string `json:"score"`
+	// +kubebuilder:validation:Optional
+	// Rationale is the judge's explanation of the score
+	Rationale string `json:"rationale,omitempty"`
+}
+
 // EvaluationStatus defines the observed state of Evaluation
 type EvaluationStatus struct {
 	// +kubebuilder:validation:Optional
@@ -233,6 +242,16 @@ type EvaluationStatus struct {
 	// Batch evaluation progress (only set for batch type evaluations)
 	BatchProgress *BatchEvaluationProgress `json:"batchProgress,omitempty"`
 	// +kubebuilder:validation:Optional
+	// Feedback counts the user feedback on the evaluated queries
+	Feedback *FeedbackSummary `json:"feedback,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=^(0(\.[0-9]+)?|1(\.0+)?)$
+	// RuleScore is the score of the rules of a query evaluation scored in the cluster
+	RuleScore string `json:"ruleScore,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Judgement is the score and rationale of the judge of the evaluator
+	Judgement *EvaluationJudgement `json:"judgement,omitempty"`
+	// +kubebuilder:validation:Optional
 	// Conditions represent the latest available observations of an evaluation's state
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
@@ -29,6 +29,31 @@ type EvaluatorSpec struct {
 	// Parameters to pass to evaluation requests
 	// +kubebuilder:validation:Optional
 	Parameters []Parameter `json:"parameters,omitempty"`
+
+	// Rules are CEL expressions scored by the controller against the queries matched by the selector,
+	// instead of calling the evaluator service
+	// +kubebuilder:validation:Optional
+	Rules []ExpressionRule `json:"rules,omitempty"`
+
+	// Judge scores the responses of the queries matched by the selector with a judge model or agent in the
+	// cluster, instead of calling the evaluator service
+	// +kubebuilder:validation:Optional
+	Judge *EvaluatorJudgeConfig `json:"judge,omitempty"`
+}
+
+// EvaluatorJudgeConfig configures a judge that scores query responses against a rubric. The judge is the
+// default model of the namespace when neither modelRef nor agent is set.
+type EvaluatorJudgeConfig struct {
+	// +kubebuilder:validation:Optional
+	// ModelRef is the model that judges the responses
+	ModelRef *AgentModelRef `json:"modelRef,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Agent is the name of an agent in the namespace of the evaluator that judges the responses
+	Agent string `json:"agent,omitempty"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Rubric describes what a good response is, against which the judge scores the responses
+	Rubric string `json:"rubric"`
 }
 
 type EvaluatorStatus struct {
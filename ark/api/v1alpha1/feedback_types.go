@@ -0,0 +1,101 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Feedback ratings
+const (
+	// FeedbackRatingPositive is a thumbs up on a response
+	FeedbackRatingPositive = "positive"
+	// FeedbackRatingNegative is a thumbs down on a response
+	FeedbackRatingNegative = "negative"
+)
+
+// Feedback phases
+const (
+	FeedbackPhasePending  = "pending"
+	FeedbackPhaseRecorded = "recorded"
+	FeedbackPhaseError    = "error"
+)
+
+// FeedbackSpec defines the desired state of Feedback.
+type FeedbackSpec struct {
+	// +kubebuilder:validation:Required
+	// QueryRef is the query whose response the feedback is about. The query must be in the same namespace.
+	QueryRef QueryRef `json:"queryRef"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=positive;negative
+	// Rating is a thumbs up (positive) or thumbs down (negative)
+	Rating string `json:"rating"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=4096
+	// Comment is free text left by the user
+	Comment string `json:"comment,omitempty"`
+	// +kubebuilder:validation:Optional
+	// UserContext identifies the user who left the feedback
+	UserContext *UserContext `json:"userContext,omitempty"`
+}
+
+// FeedbackStatus defines the observed state of Feedback.
+type FeedbackStatus struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=pending;recorded;error
+	Phase string `json:"phase,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Message provides additional information about the current status
+	Message string `json:"message,omitempty"`
+	// +kubebuilder:validation:Optional
+	// TraceID is the trace of the query the feedback was recorded against
+	TraceID string `json:"traceId,omitempty"`
+	// +kubebuilder:validation:Optional
+	// RecordedTime is when the feedback was recorded to telemetry
+	RecordedTime *metav1.Time `json:"recordedTime,omitempty"`
+}
+
+// FeedbackSummary counts the feedback left on the responses of evaluated queries
+type FeedbackSummary struct {
+	// +kubebuilder:validation:Optional
+	Positive int32 `json:"positive,omitempty"`
+	// +kubebuilder:validation:Optional
+	Negative int32 `json:"negative,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Query",type=string,JSONPath=`.spec.queryRef.name`
+// +kubebuilder:printcolumn:name="Rating",type=string,JSONPath=`.spec.rating`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Feedback is the Schema for the feedbacks API.
+type Feedback struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FeedbackSpec   `json:"spec,omitempty"`
+	Status FeedbackStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FeedbackList contains a list of Feedback.
+type FeedbackList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Feedback `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Feedback{}, &FeedbackList{})
+}
+
+// Score returns the feedback as a score: 1 for positive and 0 for negative feedback
+func (s *FeedbackSpec) Score() float64 {
+	if s.Rating == FeedbackRatingPositive {
+		return 1
+	}
+	return 0
+}
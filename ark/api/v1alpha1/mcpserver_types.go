@@ -7,10 +7,17 @@ import (
 )
 
 type MCPServerSpec struct {
-	// +kubebuilder:validation:Required
+	// Address of the server. Required for the http and sse transports.
+	// +kubebuilder:validation:Optional
 	Address ValueSource `json:"address"`
 	// +kubebuilder:validation:Optional
 	Headers []Header `json:"headers,omitempty"`
+	// Auth configures how requests to the MCP server are authenticated, in addition to the headers
+	// +kubebuilder:validation:Optional
+	Auth *MCPServerAuth `json:"auth,omitempty"`
+	// TLS authenticates ark to the server with a client certificate. Requires an https address.
+	// +kubebuilder:validation:Optional
+	TLS *ClientTLS `json:"tls,omitempty"`
 	// Timeout specifies the maximum duration for MCP tool calls to this server.
 	// Use this to support long-running operations (e.g., "5m", "10m", "30m").
 	// Defaults to "30s" if not specified.
@@ -18,16 +25,47 @@ type MCPServerSpec struct {
 	// +kubebuilder:default="30s"
 	Timeout string `json:"timeout,omitempty"`
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=http;sse
+	// +kubebuilder:validation:Enum=http;sse;stdio
 	// +kubebuilder:default="http"
 	Transport string `json:"transport,omitempty"`
+	// Command run for the stdio transport, with its arguments. It runs in the controller container and exchanges
+	// MCP messages over its stdin and stdout, e.g. a bridge to the socket or named pipe of a sidecar MCP server.
+	// The executable must be allowed with the controller's --mcp-stdio-commands flag.
+	// +kubebuilder:validation:Optional
+	Command []string `json:"command,omitempty"`
 	// +kubebuilder:validation:Optional
 	Description string `json:"description,omitempty"`
+	// ArgumentCoercion is set on the tools discovered from the server, see the Tool's argumentCoercion
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=none;lenient;strict
+	ArgumentCoercion string `json:"argumentCoercion,omitempty"`
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default="1m"
 	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
 }
 
+// MCPServerAuth configures the authentication of requests to an MCP server
+type MCPServerAuth struct {
+	// OAuth2 obtains bearer tokens with the OAuth2 client credentials grant and refreshes them when they expire
+	// +kubebuilder:validation:Optional
+	OAuth2 *OAuth2ClientCredentials `json:"oauth2,omitempty"`
+}
+
+// OAuth2ClientCredentials configures the OAuth2 client credentials grant
+type OAuth2ClientCredentials struct {
+	// TokenURL is the token endpoint of the authorization server
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	TokenURL string `json:"tokenURL"`
+	// +kubebuilder:validation:Required
+	ClientID ValueSource `json:"clientId"`
+	// +kubebuilder:validation:Required
+	ClientSecret ValueSource `json:"clientSecret"`
+	// Scopes requested for the token
+	// +kubebuilder:validation:Optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
 // MCPServerStatus defines the observed state of MCPServer
 type MCPServerStatus struct {
 	// +kubebuilder:validation:Optional
@@ -38,11 +76,81 @@ type MCPServerStatus struct {
 	// +kubebuilder:validation:Optional
 	ToolCount int `json:"toolCount,omitempty"`
 
+	// ProtocolVersion is the MCP protocol version negotiated with the server
+	// +kubebuilder:validation:Optional
+	ProtocolVersion string `json:"protocolVersion,omitempty"`
+
+	// ServerInfo is the name and version the server reported when connecting
+	// +kubebuilder:validation:Optional
+	ServerInfo *MCPServerInfo `json:"serverInfo,omitempty"`
+
+	// Capabilities are the names of the capabilities the server offers, such as tools, prompts,
+	// resources.subscribe or logging
+	// +kubebuilder:validation:Optional
+	Capabilities []string `json:"capabilities,omitempty"`
+
+	// Prompts are the prompt templates offered by the MCP server
+	// +kubebuilder:validation:Optional
+	Prompts []MCPPrompt `json:"prompts,omitempty"`
+
+	// Resources are the resources offered by the MCP server
+	// +kubebuilder:validation:Optional
+	Resources []MCPResource `json:"resources,omitempty"`
+
+	// ResourceTemplates are the parameterized resources offered by the MCP server
+	// +kubebuilder:validation:Optional
+	ResourceTemplates []MCPResourceTemplate `json:"resourceTemplates,omitempty"`
+
 	// Conditions represent the latest available observations of the MCP server's state
 	// +kubebuilder:validation:Optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// MCPServerInfo is the implementation an MCP server reported
+type MCPServerInfo struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+}
+
+// MCPPrompt is a prompt template discovered from an MCP server
+type MCPPrompt struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+	// +kubebuilder:validation:Optional
+	Arguments []MCPPromptArgument `json:"arguments,omitempty"`
+}
+
+// MCPPromptArgument is an argument of an MCP prompt template
+type MCPPromptArgument struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+	// +kubebuilder:validation:Optional
+	Required bool `json:"required,omitempty"`
+}
+
+// MCPResource is a resource discovered from an MCP server
+type MCPResource struct {
+	URI  string `json:"uri"`
+	Name string `json:"name"`
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+	// +kubebuilder:validation:Optional
+	MIMEType string `json:"mimeType,omitempty"`
+}
+
+// MCPResourceTemplate is a parameterized resource discovered from an MCP server, addressed by an RFC 6570 URI template
+type MCPResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+	// +kubebuilder:validation:Optional
+	MIMEType string `json:"mimeType,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Ready status"
@@ -6,10 +6,44 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// Memory truncation strategies
+const (
+	// MemoryTruncationMessageCount keeps the most recent messages
+	MemoryTruncationMessageCount = "message-count"
+	// MemoryTruncationTokenWindow keeps the most recent messages that fit in a token budget
+	MemoryTruncationTokenWindow = "token-window"
+	// MemoryTruncationSummarize replaces older messages with a summary written by a model
+	MemoryTruncationSummarize = "summarize"
+)
+
 // MemorySpec defines the desired state of Memory.
 type MemorySpec struct {
 	// +kubebuilder:validation:Required
 	Address ValueSource `json:"address"`
+	// +kubebuilder:validation:Optional
+	// Truncation limits the history loaded from the memory before agents, teams and models run, so that long
+	// sessions stay within the model's context window. The stored history is not changed.
+	Truncation *MemoryTruncation `json:"truncation,omitempty"`
+}
+
+// MemoryTruncation configures how a long history is shortened.
+type MemoryTruncation struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=message-count;token-window;summarize
+	Strategy string `json:"strategy"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=20
+	// MaxMessages is how many recent messages message-count keeps, and how many summarize keeps verbatim
+	MaxMessages int32 `json:"maxMessages,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=8000
+	// MaxTokens is the estimated token budget token-window keeps, and the history size above which summarize summarizes
+	MaxTokens int32 `json:"maxTokens,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ModelRef is the model that writes summaries for summarize. Defaults to the default model of the memory's namespace.
+	ModelRef *AgentModelRef `json:"modelRef,omitempty"`
 }
 
 // MemoryStatus defines the observed state of Memory.
@@ -14,6 +14,8 @@ type ModelConfig struct {
 	Azure *AzureModelConfig `json:"azure,omitempty"`
 	// +kubebuilder:validation:Optional
 	Bedrock *BedrockModelConfig `json:"bedrock,omitempty"`
+	// +kubebuilder:validation:Optional
+	Gateway *GatewayModelConfig `json:"gateway,omitempty"`
 }
 
 // AzureModelConfig contains Azure OpenAI specific parameters
@@ -67,17 +69,78 @@ type BedrockModelConfig struct {
 	Properties map[string]ValueSource `json:"properties,omitempty"`
 }
 
+// GatewayModelConfig contains the parameters of an in-cluster inference gateway, such as LiteLLM or Envoy AI
+// Gateway, which serves an OpenAI compatible API and routes the model name to a provider itself
+type GatewayModelConfig struct {
+	// +kubebuilder:validation:Required
+	BaseURL ValueSource `json:"baseUrl"`
+	// +kubebuilder:validation:Optional
+	// APIKey authenticates with the gateway, which holds the credentials of the providers
+	APIKey *ValueSource `json:"apiKey,omitempty"`
+	// +kubebuilder:validation:Optional
+	Headers []Header `json:"headers,omitempty"`
+	// +kubebuilder:validation:Optional
+	Properties map[string]ValueSource `json:"properties,omitempty"`
+	// +kubebuilder:validation:Optional
+	// UsageHeaders names the response headers the gateway reports the usage and cost of a call in. Defaults to
+	// the cost header of LiteLLM.
+	UsageHeaders *GatewayUsageHeaders `json:"usageHeaders,omitempty"`
+}
+
+// GatewayUsageHeaders names the response headers of a gateway. Headers that are not named are not read.
+type GatewayUsageHeaders struct {
+	// +kubebuilder:validation:Optional
+	// Cost is the header holding the cost of the call, which replaces the estimate from the pricing of the model
+	Cost string `json:"cost,omitempty"`
+	// +kubebuilder:validation:Optional
+	// PromptTokens is the header holding the prompt tokens of the call, read when the response body has no usage
+	PromptTokens string `json:"promptTokens,omitempty"`
+	// +kubebuilder:validation:Optional
+	// CompletionTokens is the header holding the completion tokens of the call, read when the response body has no usage
+	CompletionTokens string `json:"completionTokens,omitempty"`
+}
+
 type ModelSpec struct {
 	// +kubebuilder:validation:Required
 	Model ValueSource `json:"model"`
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=openai;azure;bedrock
+	// +kubebuilder:validation:Enum=openai;azure;bedrock;gateway
 	Type string `json:"type,omitempty"`
 	// +kubebuilder:validation:Required
 	Config ModelConfig `json:"config"`
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default="1m"
 	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+	// +kubebuilder:validation:Optional
+	// RateLimit bounds the requests and tokens sent to the model by all agents sharing it
+	RateLimit *ModelRateLimit `json:"rateLimit,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Pricing is used to estimate the cost of queries. It takes precedence over the ark-config-pricing ConfigMap.
+	Pricing *ModelPricing `json:"pricing,omitempty"`
+}
+
+// ModelPricing is the price of the tokens of a model, in any currency as long as budgets use the same one
+type ModelPricing struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// InputPerMillionTokens is the price of one million prompt tokens, e.g. 2.50
+	InputPerMillionTokens string `json:"inputPerMillionTokens"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// OutputPerMillionTokens is the price of one million completion tokens, e.g. 10.00
+	OutputPerMillionTokens string `json:"outputPerMillionTokens"`
+}
+
+// ModelRateLimit bounds the calls made to a model per minute. Calls over the limit wait until the model has capacity.
+type ModelRateLimit struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// RequestsPerMinute is the maximum number of model calls started per minute
+	RequestsPerMinute int32 `json:"requestsPerMinute,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// TokensPerMinute is the maximum number of tokens used per minute, as reported by the provider
+	TokensPerMinute int64 `json:"tokensPerMinute,omitempty"`
 }
 
 type ModelStatus struct {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/openai/openai-go"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -17,6 +18,8 @@ type QueryConditionType string
 const (
 	// QueryCompleted indicates that the query has finished (regardless of outcome)
 	QueryCompleted QueryConditionType = "Completed"
+	// QueryNeedsReview indicates that a response of the query has a confidence below the query's threshold
+	QueryNeedsReview QueryConditionType = "NeedsReview"
 )
 
 const (
@@ -26,15 +29,84 @@ const (
 	QueryTypeMessages = "messages"
 )
 
+const (
+	// TargetExecutionParallel runs all targets of a query at the same time
+	TargetExecutionParallel = "parallel"
+	// TargetExecutionSequential runs the targets one after another, in order, so that each target sees the
+	// messages the targets before it added to memory
+	TargetExecutionSequential = "sequential"
+)
+
 type QueryTarget struct {
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=agent;team;model;tool
+	// +kubebuilder:validation:Enum=agent;team;model;tool;session
 	Type string `json:"type"`
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
 	Name string `json:"name"`
 }
 
+const (
+	// RetryBackoffFixed waits initialDelay between every attempt
+	RetryBackoffFixed = "fixed"
+	// RetryBackoffExponential doubles the delay after every attempt, up to maxDelay
+	RetryBackoffExponential = "exponential"
+)
+
+// RetryPolicy configures how the controller retries a target that failed with a transient error.
+// Retries run within the query timeout.
+type RetryPolicy struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +kubebuilder:default=3
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int32 `json:"maxRetries,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=fixed;exponential
+	// +kubebuilder:default=exponential
+	Backoff string `json:"backoff,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="1s"
+	// InitialDelay is the delay before the first retry
+	InitialDelay *metav1.Duration `json:"initialDelay,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="30s"
+	// MaxDelay caps the delay between retries
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
+	// +kubebuilder:validation:Optional
+	// RetryOn lists the error reasons that are retried, for example ProviderRateLimited, ProviderUnavailable,
+	// ConnectionFailed, Timeout or ToolTimeout. Defaults to all of these.
+	RetryOn []string `json:"retryOn,omitempty"`
+}
+
+// Attachment is a file passed to the query's targets. Images are sent to vision-capable models as image
+// content, text is inlined and other media types are sent as file content.
+type Attachment struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// MediaType of the content, e.g. image/png, text/csv or application/pdf
+	MediaType string `json:"mediaType"`
+	// +kubebuilder:validation:Required
+	Source AttachmentSource `json:"source"`
+}
+
+// AttachmentSource is where the content of an attachment is read from. Exactly one field must be set.
+type AttachmentSource struct {
+	// +kubebuilder:validation:Optional
+	// ConfigMapKeyRef reads the content from a ConfigMap key. binaryData keys are supported.
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// +kubebuilder:validation:Optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// URL of the content, e.g. a presigned object store URL. It is passed to models and tools as is.
+	URL string `json:"url,omitempty"`
+}
+
 type MemoryRef struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
@@ -46,13 +118,14 @@ type MemoryRef struct {
 type QuerySpec struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Enum=user;messages
-	// +kubebuilder:default=user
+	// Type is inferred from the input when not set: user for a string, messages for a list of messages
 	Type string `json:"type,omitempty"`
-	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
-	// Input can be a string (type=user) or []openai.ChatCompletionMessageParamUnion (type=messages)
-	Input runtime.RawExtension `json:"input"`
+	// Input can be a string (type=user) or []openai.ChatCompletionMessageParamUnion (type=messages). It is
+	// required unless the query replays another query.
+	Input runtime.RawExtension `json:"input,omitempty"`
 	// +kubebuilder:validation:Optional
 	// Parameters for template processing in the input field
 	Parameters []Parameter `json:"parameters,omitempty"`
@@ -61,6 +134,19 @@ type QuerySpec struct {
 	// +kubebuilder:validation:Optional
 	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 	// +kubebuilder:validation:Optional
+	// SelectorPolicy limits how many of the targets matched by the selector run, and how many must succeed
+	SelectorPolicy *SelectorPolicy `json:"selectorPolicy,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=parallel;sequential
+	// +kubebuilder:default=parallel
+	// TargetExecution runs the targets in parallel, or sequentially in the order of targets followed by the
+	// selector matches
+	TargetExecution string `json:"targetExecution,omitempty"`
+	// +kubebuilder:validation:Optional
+	// FailurePolicy decides the phase of a query some of whose targets fail, and whether the other targets
+	// are canceled when one fails. Without a policy any failed target fails the query.
+	FailurePolicy *FailurePolicy `json:"failurePolicy,omitempty"`
+	// +kubebuilder:validation:Optional
 	Memory *MemoryRef `json:"memory,omitempty"`
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:MinLength=1
@@ -69,16 +155,145 @@ type QuerySpec struct {
 	// +kubebuilder:validation:MinLength=1
 	SessionId string `json:"sessionId,omitempty"`
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:default="720h"
+	// TTL is how long the query is kept. Defaults to the ttl of the namespace's ark-config-queries ConfigMap,
+	// or 720h.
 	TTL *metav1.Duration `json:"ttl,omitempty"`
-	// +kubebuilder:default="5m"
-	// Timeout for query execution (e.g., "30s", "5m", "1h")
+	// +kubebuilder:validation:Optional
+	// Timeout for query execution (e.g., "30s", "5m", "1h"). Defaults to the timeout of the namespace's
+	// ark-config-queries ConfigMap, or 5m.
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// MaxCost is the budget of the query, in the currency of the model pricing. Model calls fail once the
+	// estimated cost exceeds it.
+	MaxCost string `json:"maxCost,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxTokens is the budget of tokens the query may use. Once it is exceeded, the targets still running are
+	// stopped and fail with the BudgetExceeded reason.
+	MaxTokens *int64 `json:"maxTokens,omitempty"`
+	// +kubebuilder:validation:Optional
 	// When true, indicates intent to cancel the query
 	Cancel bool `json:"cancel,omitempty"`
 	// +kubebuilder:validation:Optional
 	Overrides []Override `json:"overrides,omitempty"`
+	// +kubebuilder:validation:Optional
+	// RetryPolicy retries targets that fail with transient errors
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Attachments are files made available to the targets
+	Attachments []Attachment `json:"attachments,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Priority orders queries waiting for an execution slot when the controller limits concurrent
+	// queries per namespace. Higher values run first.
+	Priority int32 `json:"priority,omitempty"`
+	// +kubebuilder:validation:Optional
+	// UserContext identifies the user the query runs for
+	UserContext *UserContext `json:"userContext,omitempty"`
+	// +kubebuilder:validation:Optional
+	// DependsOn lists queries in the same namespace that must complete before this query runs. Their
+	// responses can be used in the input through the outputs template variable.
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Confidence estimates the confidence in each response with a judge model and flags responses below a threshold
+	Confidence *ConfidenceConfig `json:"confidence,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Aggregation combines the responses of the targets into status.aggregatedResponse
+	Aggregation *AggregationConfig `json:"aggregation,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	// ReplayOf is a completed query in the same namespace to replay. Its input, its parameters resolved to
+	// their values and its overrides are cloned into the query, as are its targets when the query has none.
+	// The differences from its responses are recorded in status.replay.
+	ReplayOf string `json:"replayOf,omitempty"`
+}
+
+// SelectorPolicy controls the execution of the targets matched by the selector of a query
+type SelectorPolicy struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Sample runs this many of the matched targets instead of all of them, chosen at random in proportion
+	// to the ark.mckinsey.com/target-weight annotation of each match
+	Sample *int32 `json:"sample,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Quorum is how many of the matched targets must succeed for the query to succeed. Without a quorum
+	// every matched target must succeed.
+	Quorum *int32 `json:"quorum,omitempty"`
+	// +kubebuilder:validation:Optional
+	// StopAfterFirstSuccess cancels the matched targets still running, or not yet started, once one succeeds
+	StopAfterFirstSuccess bool `json:"stopAfterFirstSuccess,omitempty"`
+}
+
+const (
+	// FailurePolicyAllOrNothing fails the query when any target fails and cancels the other targets
+	FailurePolicyAllOrNothing = "allOrNothing"
+	// FailurePolicyBestEffort completes the query when at least one target succeeds
+	FailurePolicyBestEffort = "bestEffort"
+	// FailurePolicyThreshold completes the query when at least minSuccesses targets succeed
+	FailurePolicyThreshold = "threshold"
+)
+
+// FailurePolicy decides how the failures of individual targets affect a query
+type FailurePolicy struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=allOrNothing;bestEffort;threshold
+	Mode string `json:"mode"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MinSuccesses is how many targets must succeed with the threshold mode. The other targets are canceled
+	// once so many have failed that it can no longer be reached.
+	MinSuccesses *int32 `json:"minSuccesses,omitempty"`
+}
+
+// ConfidenceConfig configures the estimation of the confidence in the responses of a query
+type ConfidenceConfig struct {
+	// +kubebuilder:validation:Optional
+	// ModelRef is the judge model that estimates the confidence. Defaults to the default model
+	ModelRef *AgentModelRef `json:"modelRef,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// Threshold is the confidence between 0 and 1, e.g. 0.7, below which a response is flagged for human review
+	Threshold string `json:"threshold,omitempty"`
+}
+
+const (
+	// AggregationConcat joins the responses of the targets, each under a heading naming its target
+	AggregationConcat = "concat"
+	// AggregationVote picks the response given by most targets
+	AggregationVote = "vote"
+	// AggregationJudge has a judge model synthesize one response from the responses of the targets
+	AggregationJudge = "judge"
+)
+
+// AggregationConfig combines the responses of the targets of a query into one response
+type AggregationConfig struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=concat;vote;judge
+	Strategy string `json:"strategy"`
+	// +kubebuilder:validation:Optional
+	// JudgeRef is the model that synthesizes the responses with the judge strategy. Defaults to the default model
+	JudgeRef *AgentModelRef `json:"judgeRef,omitempty"`
+}
+
+// AggregatedResponse is the response combined from the responses of the targets of a query
+type AggregatedResponse struct {
+	Strategy string `json:"strategy"`
+	// +kubebuilder:validation:Optional
+	Content string `json:"content,omitempty"`
+	// Phase is done, or error when the responses could not be aggregated
+	Phase string `json:"phase"`
+	// +kubebuilder:validation:Optional
+	// Message explains why the responses could not be aggregated, or with the vote strategy how many responses agreed
+	Message string `json:"message,omitempty"`
+}
+
+// UserContext identifies the user a query runs for
+type UserContext struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// ID is a stable identifier of the user, used to key the user's profile memory
+	ID string `json:"id"`
 }
 
 // Response defines a response from a query target.
@@ -86,7 +301,52 @@ type Response struct {
 	Target  QueryTarget `json:"target,omitempty"`
 	Content string      `json:"content,omitempty"`
 	Raw     string      `json:"raw,omitempty"`
-	Phase   string      `json:"phase,omitempty"`
+	// Phase is done, error or canceled, or unresolved when the target or its configuration could not be resolved
+	Phase string `json:"phase,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Reason is a machine-readable classification of how the response finished: Completed, MaxTurnsReached or Terminated when it is done, or why it is not done otherwise
+	Reason string `json:"reason,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Attempts is the number of times the target was executed when the query has a retry policy
+	Attempts int32 `json:"attempts,omitempty"`
+	// +kubebuilder:validation:Optional
+	// LastAttemptTime is when the last attempt started
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Confidence is the estimated confidence in the response between 0 and 1, when the query estimates confidence
+	Confidence string `json:"confidence,omitempty"`
+	// +kubebuilder:validation:Optional
+	// NeedsReview is set when the confidence is below the query's threshold
+	NeedsReview bool `json:"needsReview,omitempty"`
+}
+
+// QueryReplay compares the responses of a query with the responses of the query it replays
+type QueryReplay struct {
+	// Of is the replayed query
+	Of string `json:"of"`
+	// +kubebuilder:validation:Optional
+	// Changed is the number of responses that differ from the responses of the replayed query
+	Changed int32 `json:"changed,omitempty"`
+	// +kubebuilder:validation:Optional
+	Responses []ReplayedResponse `json:"responses,omitempty"`
+}
+
+// ReplayedResponse compares a response with the response of the same target in the replayed query
+type ReplayedResponse struct {
+	Target QueryTarget `json:"target"`
+	// +kubebuilder:validation:Optional
+	// Phase is the phase of the response, which is empty when the target has no response
+	Phase string `json:"phase,omitempty"`
+	// +kubebuilder:validation:Optional
+	// OriginalPhase is the phase of the replayed query's response, which is empty when the target had none
+	OriginalPhase string `json:"originalPhase,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Changed is set when the phase or the content differ from the replayed query's response
+	Changed bool `json:"changed,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Diff is the unified diff of the replayed query's response content to the content, truncated to a few
+	// kilobytes
+	Diff string `json:"diff,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -104,12 +364,51 @@ type Query struct {
 	Status QueryStatus `json:"status,omitempty"`
 }
 
+// Artifact is a named output published by an agent during the query
+type Artifact struct {
+	Name string `json:"name"`
+	// MediaType is the content type of the artifact, e.g. text/csv or image/png
+	MediaType string `json:"mediaType"`
+	// Digest is the sha256 digest of the content, e.g. sha256:<hex>
+	Digest string `json:"digest"`
+	Size   int64  `json:"size"`
+	// +kubebuilder:validation:Optional
+	// URL is where the artifact was uploaded in the artifact store
+	URL string `json:"url,omitempty"`
+}
+
 type TokenUsage struct {
 	PromptTokens     int64 `json:"promptTokens,omitempty"`
 	CompletionTokens int64 `json:"completionTokens,omitempty"`
 	TotalTokens      int64 `json:"totalTokens,omitempty"`
 }
 
+// TokenUsageBreakdown attributes the tokens of a query to where they were used. Tokens used outside of any
+// target, such as by the aggregation of the responses, only count toward the models.
+type TokenUsageBreakdown struct {
+	// +kubebuilder:validation:Optional
+	// Targets is the usage of each target of the query
+	Targets []TargetTokenUsage `json:"targets,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Agents is the usage of each agent, including the agents of teams
+	Agents []NamedTokenUsage `json:"agents,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Models is the usage of each model
+	Models []NamedTokenUsage `json:"models,omitempty"`
+}
+
+// TargetTokenUsage is the token usage of a target of a query
+type TargetTokenUsage struct {
+	Target     QueryTarget `json:"target"`
+	TokenUsage `json:",inline"`
+}
+
+// NamedTokenUsage is the token usage of an agent or model
+type NamedTokenUsage struct {
+	Name       string `json:"name"`
+	TokenUsage `json:",inline"`
+}
+
 type QueryStatus struct {
 	// +kubebuilder:default="pending"
 	// +kubebuilder:validation:Enum=pending;running;error;done;canceled
@@ -118,9 +417,35 @@ type QueryStatus struct {
 	// Conditions represent the latest available observations of a query's state
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 	Responses  []Response         `json:"responses,omitempty"`
-	TokenUsage TokenUsage         `json:"tokenUsage,omitempty"`
+	// +kubebuilder:validation:Optional
+	// AggregatedResponse combines the responses of the targets when the query sets an aggregation strategy
+	AggregatedResponse *AggregatedResponse `json:"aggregatedResponse,omitempty"`
+	TokenUsage         TokenUsage          `json:"tokenUsage,omitempty"`
+	// +kubebuilder:validation:Optional
+	// TokenUsageBreakdown attributes the token usage to the targets, agents and models of the query
+	TokenUsageBreakdown *TokenUsageBreakdown `json:"tokenUsageBreakdown,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Cost is the estimated cost of the model calls of the query, for the models that have a price
+	Cost string `json:"cost,omitempty"`
 	// +kubebuilder:validation:Optional
 	Duration *metav1.Duration `json:"duration,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Artifacts are the named outputs published by agents during the query
+	Artifacts []Artifact `json:"artifacts,omitempty"`
+	// +kubebuilder:validation:Optional
+	// TraceID is the telemetry trace of the query execution, used to attach feedback to it
+	TraceID string `json:"traceId,omitempty"`
+	// +kubebuilder:validation:Optional
+	// CorrelationID identifies the query in the X-Ark-Query-Id header of the requests made for it to models,
+	// MCP servers and A2A servers, and in its log lines and spans
+	CorrelationID string `json:"correlationId,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Executor identifies the controller process running the query. A running query whose executor is not the
+	// current controller was interrupted by a restart of the controller.
+	Executor string `json:"executor,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Replay compares the responses with the responses of the replayed query, when the query replays one
+	Replay *QueryReplay `json:"replay,omitempty"`
 }
 
 // +kubebuilder:object:root=true
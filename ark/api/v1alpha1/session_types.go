@@ -0,0 +1,91 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Session phases
+const (
+	SessionPhaseActive  = "active"
+	SessionPhaseExpired = "expired"
+	SessionPhaseError   = "error"
+)
+
+// SessionSpec defines the desired state of Session. The name of a session is the sessionId of its queries.
+type SessionSpec struct {
+	// +kubebuilder:validation:Optional
+	// Memory holds the messages of the session. Defaults to the default memory of the namespace.
+	Memory *MemoryRef `json:"memory,omitempty"`
+	// +kubebuilder:validation:Optional
+	// TTL is how long the session is kept after its last query. When it expires its messages are deleted
+	// from memory. Sessions without a TTL do not expire.
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	// ForkFrom is a session of the namespace whose messages are copied into this session when it is created,
+	// so the conversation continues from there without changing the original
+	ForkFrom string `json:"forkFrom,omitempty"`
+}
+
+// SessionStatus defines the observed state of Session.
+type SessionStatus struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=active;expired;error
+	Phase string `json:"phase,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Message provides additional information about the current status
+	Message string `json:"message,omitempty"`
+	// +kubebuilder:validation:Optional
+	// QueryCount is the number of queries of the session
+	QueryCount int32 `json:"queryCount,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Queries are the most recent queries of the session, oldest first
+	Queries []string `json:"queries,omitempty"`
+	// +kubebuilder:validation:Optional
+	// TokenUsage is the token usage of the queries of the session
+	TokenUsage TokenUsage `json:"tokenUsage,omitempty"`
+	// +kubebuilder:validation:Optional
+	// LastActivityTime is when the last query of the session was created
+	LastActivityTime *metav1.Time `json:"lastActivityTime,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ExpiresAt is when the session expires unless it has another query
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ForkedFrom is the session whose messages were copied into this session
+	ForkedFrom string `json:"forkedFrom,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ForkedMessages is the number of messages copied from the forked session
+	ForkedMessages int32 `json:"forkedMessages,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Queries",type=integer,JSONPath=`.status.queryCount`
+// +kubebuilder:printcolumn:name="Tokens",type=integer,JSONPath=`.status.tokenUsage.totalTokens`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Expires",type=date,JSONPath=`.status.expiresAt`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Session is the Schema for the sessions API.
+type Session struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SessionSpec   `json:"spec,omitempty"`
+	Status SessionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SessionList contains a list of Session.
+type SessionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Session `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Session{}, &SessionList{})
+}
@@ -4,11 +4,41 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 type TeamMember struct {
 	Name string `json:"name"`
 	Type string `json:"type"`
+	// +kubebuilder:validation:Optional
+	// History controls how much of the team conversation is passed to the member
+	History *TeamMemberHistory `json:"history,omitempty"`
+}
+
+const (
+	// TeamMemberHistoryFull passes the whole conversation to the member
+	TeamMemberHistoryFull = "full"
+	// TeamMemberHistorySummary passes a summary of the conversation, followed by its most recent messages
+	TeamMemberHistorySummary = "summary"
+	// TeamMemberHistoryLastN passes only the most recent messages of the conversation
+	TeamMemberHistoryLastN = "last-n"
+)
+
+// TeamMemberHistory configures the history a team passes to a member. Limiting it keeps deeply nested teams
+// within the context limits of their models.
+type TeamMemberHistory struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=full;summary;last-n
+	// +kubebuilder:default=full
+	Policy string `json:"policy,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10
+	// LastN is the number of most recent messages passed as they are by the last-n and summary policies
+	LastN int32 `json:"lastN,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ModelRef is the model that writes the summary. Defaults to the default model
+	ModelRef *AgentModelRef `json:"modelRef,omitempty"`
 }
 
 type TeamSelectorSpec struct {
@@ -16,6 +46,11 @@ type TeamSelectorSpec struct {
 	SelectorPrompt string `json:"selectorPrompt,omitempty"`
 }
 
+// TeamAggregatorSpec configures the agent that merges the outputs of a parallel team into a single final message.
+type TeamAggregatorSpec struct {
+	Agent string `json:"agent"`
+}
+
 type TeamGraphEdge struct {
 	From string `json:"from"`
 	To   string `json:"to"`
@@ -25,19 +60,77 @@ type TeamGraphSpec struct {
 	Edges []TeamGraphEdge `json:"edges"`
 }
 
+const (
+	// TeamFinalizerModel asks a model to write the team's final answer in the output schema
+	TeamFinalizerModel = "model"
+	// TeamFinalizerExtract takes the last JSON object in the conversation that matches the output schema
+	TeamFinalizerExtract = "extract"
+)
+
+// TeamFinalizerSpec configures how the team conversation is coerced into the output schema after the last turn.
+type TeamFinalizerSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=model;extract
+	// +kubebuilder:default=model
+	// Strategy is model to have a model write the final answer, or extract to take it from the conversation without a model call
+	Strategy string `json:"strategy,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ModelRef is the model used by the model strategy. Defaults to the default model
+	ModelRef *AgentModelRef `json:"modelRef,omitempty"`
+}
+
 type TeamSpec struct {
-	Members     []TeamMember      `json:"members"`
+	Members     []TeamMember      `json:"members,omitempty"`
 	Strategy    string            `json:"strategy"`
 	Description string            `json:"description,omitempty"`
 	MaxTurns    *int              `json:"maxTurns,omitempty"`
 	Selector    *TeamSelectorSpec `json:"selector,omitempty"`
 	Graph       *TeamGraphSpec    `json:"graph,omitempty"`
+	// +kubebuilder:validation:Optional
+	// MemberSelector adds the agents matching the label selector as members, resolved each time the team executes
+	MemberSelector *metav1.LabelSelector `json:"memberSelector,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Aggregator merges the outputs of the members of a parallel team into a single final message
+	Aggregator *TeamAggregatorSpec `json:"aggregator,omitempty"`
+	// +kubebuilder:validation:Optional
+	// JSON schema of the team's final answer. When set, a finalizer step adds the answer in this schema after the last turn
+	OutputSchema *runtime.RawExtension `json:"outputSchema,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Finalizer configures the step that produces the final answer in the output schema
+	Finalizer *TeamFinalizerSpec `json:"finalizer,omitempty"`
 }
 
-type TeamStatus struct{}
+// TeamMemberStatus reports whether a member, or an agent the team calls, can execute
+type TeamMemberStatus struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// +kubebuilder:validation:Optional
+	// Role is selector or aggregator for the agents the team calls without them being members
+	Role  string `json:"role,omitempty"`
+	Ready bool   `json:"ready"`
+	// +kubebuilder:validation:Optional
+	// Message explains why the member is not ready
+	Message string `json:"message,omitempty"`
+}
+
+type TeamStatus struct {
+	// +kubebuilder:validation:Optional
+	// Members reports the readiness of the members, including the agents matching the member selector, and of the
+	// selector and aggregator agents
+	Members []TeamMemberStatus `json:"members,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	// Conditions represent the latest available observations of a team's state
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Strategy",type="string",JSONPath=".spec.strategy"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type Team struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -35,6 +35,24 @@ type AgentToolRef struct {
 }
 
 // BuiltinToolRef defines a reference to a Builtin Tool.
+// WasmSpec is a tool whose logic is a WebAssembly module built for WASI, which the controller runs in a sandbox
+// without access to files, the network or its environment. The module reads the arguments of a call as JSON on
+// stdin and writes the result of the call to stdout.
+type WasmSpec struct {
+	// OCI reference of the module, pinned by digest, e.g. ghcr.io/acme/tools/slugify@sha256:<digest>. The
+	// module is the layer of the artifact, as pushed with oras push.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[^/@]+/[^@]+@sha256:[a-f0-9]{64}$`
+	Image string `json:"image"`
+	// Name of a kubernetes.io/dockerconfigjson Secret with the credentials of the registry
+	// +kubebuilder:validation:Optional
+	ImagePullSecret string `json:"imagePullSecret,omitempty"`
+	// How long a call may run before the module is stopped, 30s by default
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=^[0-9]+[smh]?$
+	Timeout string `json:"timeout,omitempty"`
+}
+
 type BuiltinToolRef struct {
 	// Name of the Builtin being referenced.
 	// This must be a non-empty string.
@@ -74,16 +92,27 @@ type ToolAnnotations struct {
 
 type ToolSpec struct {
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=http;mcp;agent;builtin
+	// +kubebuilder:validation:Enum=http;mcp;agent;builtin;wasm
 	Type string `json:"type"`
 	// Tool description
 	Description string `json:"description,omitempty"`
 	// Input schema for the tool
 	InputSchema *runtime.RawExtension `json:"inputSchema,omitempty"`
+	// ArgumentCoercion is how the arguments of calls to the tool are coerced into the types of its input schema
+	// before the tool runs. lenient converts the arguments it can, such as numbers and booleans sent as strings,
+	// drops null optional arguments and fills in the defaults of omitted ones; strict also fails calls whose
+	// arguments are still missing or of the wrong type, without running the tool; none passes the arguments as
+	// the model sent them. Defaults to none.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=none;lenient;strict
+	ArgumentCoercion string `json:"argumentCoercion,omitempty"`
 	// Optional additional tool information
 	Annotations *ToolAnnotations `json:"annotations,omitempty"`
 	// HTTP-specific configuration for HTTP-based tools
 	HTTP *HTTPSpec `json:"http,omitempty"`
+	// OpenAPI document of a REST service, for http tools that generate a tool per operation
+	// +kubebuilder:validation:Optional
+	OpenAPI *OpenAPISpec `json:"openapi,omitempty"`
 	// MCP-specific configuration for MCP server tools
 	// +kubebuilder:validation:Optional
 	MCP *MCPToolRef `json:"mcp,omitempty"`
@@ -95,6 +124,10 @@ type ToolSpec struct {
 	// This field is required only if Type = "builtin".
 	// +kubebuilder:validation:Optional
 	Builtin *BuiltinToolRef `json:"builtin,omitempty"`
+	// WebAssembly module running the logic of the tool.
+	// This field is required only if Type = "wasm".
+	// +kubebuilder:validation:Optional
+	Wasm *WasmSpec `json:"wasm,omitempty"`
 }
 
 type HTTPSpec struct {
@@ -115,17 +148,45 @@ type HTTPSpec struct {
 	BodyParameters []Parameter `json:"bodyParameters,omitempty"`
 }
 
+// OpenAPISpec imports the operations of a REST service from its OpenAPI 3 document. Each operation
+// becomes an http tool owned by the importing tool.
+type OpenAPISpec struct {
+	// The document in JSON or YAML, inline or from a ConfigMap
+	// +kubebuilder:validation:Optional
+	Spec *ValueSource `json:"spec,omitempty"`
+	// URL to fetch the document from
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern="^https?://.*"
+	URL string `json:"url,omitempty"`
+	// Base URL of the service. Defaults to the first server of the document.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern="^https?://.*"
+	BaseURL string `json:"baseURL,omitempty"`
+	// IDs of the operations to import. All operations are imported when empty.
+	// +kubebuilder:validation:Optional
+	Operations []string `json:"operations,omitempty"`
+	// Headers sent with every request, such as authentication. Values may use the tool arguments with
+	// golang template syntax, e.g. {{ .input.tenant }}.
+	// +kubebuilder:validation:Optional
+	Headers []Header `json:"headers,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=^[0-9]+[smh]?$
+	Timeout string `json:"timeout,omitempty"`
+}
+
 // Tool type constants
 const (
 	ToolTypeHTTP    = "http"
 	ToolTypeMCP     = "mcp"
 	ToolTypeAgent   = "agent"
 	ToolTypeBuiltin = "builtin"
+	ToolTypeWasm    = "wasm"
 )
 
 // Tool state constants
 const (
 	ToolStateReady = "Ready"
+	ToolStateError = "Error"
 )
 
 type ToolStatus struct {
@@ -171,6 +232,11 @@ func (in *ToolSpec) DeepCopyInto(out *ToolSpec) {
 		*out = new(HTTPSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OpenAPI != nil {
+		in, out := &in.OpenAPI, &out.OpenAPI
+		*out = new(OpenAPISpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MCP != nil {
 		in, out := &in.MCP, &out.MCP
 		*out = new(MCPToolRef)
@@ -86,6 +86,26 @@ func (in *AgentModelRef) DeepCopy() *AgentModelRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentReviewConfig) DeepCopyInto(out *AgentReviewConfig) {
+	*out = *in
+	if in.ModelRef != nil {
+		in, out := &in.ModelRef, &out.ModelRef
+		*out = new(AgentModelRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentReviewConfig.
+func (in *AgentReviewConfig) DeepCopy() *AgentReviewConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentReviewConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
 	*out = *in
@@ -125,6 +145,26 @@ func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FewShot != nil {
+		in, out := &in.FewShot, &out.FewShot
+		*out = new(FewShotConfig)
+		**out = **in
+	}
+	if in.Review != nil {
+		in, out := &in.Review, &out.Review
+		*out = new(AgentReviewConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ToolPolicy != nil {
+		in, out := &in.ToolPolicy, &out.ToolPolicy
+		*out = new(AgentToolPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ToolSchemas != nil {
+		in, out := &in.ToolSchemas, &out.ToolSchemas
+		*out = new(AgentToolSchemas)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSpec.
@@ -160,143 +200,138 @@ func (in *AgentStatus) DeepCopy() *AgentStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentTool) DeepCopyInto(out *AgentTool) {
+func (in *AgentTest) DeepCopyInto(out *AgentTest) {
 	*out = *in
-	if in.Functions != nil {
-		in, out := &in.Functions, &out.Functions
-		*out = make([]ToolFunction, len(*in))
-		copy(*out, *in)
-	}
-	if in.Partial != nil {
-		in, out := &in.Partial, &out.Partial
-		*out = new(ToolPartial)
-		(*in).DeepCopyInto(*out)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentTool.
-func (in *AgentTool) DeepCopy() *AgentTool {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentTest.
+func (in *AgentTest) DeepCopy() *AgentTest {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentTool)
+	out := new(AgentTest)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AgentTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentToolRef) DeepCopyInto(out *AgentToolRef) {
+func (in *AgentTestAssertionResult) DeepCopyInto(out *AgentTestAssertionResult) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentToolRef.
-func (in *AgentToolRef) DeepCopy() *AgentToolRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentTestAssertionResult.
+func (in *AgentTestAssertionResult) DeepCopy() *AgentTestAssertionResult {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentToolRef)
+	out := new(AgentTestAssertionResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AzureModelConfig) DeepCopyInto(out *AzureModelConfig) {
+func (in *AgentTestExpectations) DeepCopyInto(out *AgentTestExpectations) {
 	*out = *in
-	in.BaseURL.DeepCopyInto(&out.BaseURL)
-	in.APIKey.DeepCopyInto(&out.APIKey)
-	if in.APIVersion != nil {
-		in, out := &in.APIVersion, &out.APIVersion
-		*out = new(ValueSource)
-		(*in).DeepCopyInto(*out)
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Headers != nil {
-		in, out := &in.Headers, &out.Headers
-		*out = make([]Header, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ExcludedPatterns != nil {
+		in, out := &in.ExcludedPatterns, &out.ExcludedPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Properties != nil {
-		in, out := &in.Properties, &out.Properties
-		*out = make(map[string]ValueSource, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ExpressionRule, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureModelConfig.
-func (in *AzureModelConfig) DeepCopy() *AzureModelConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentTestExpectations.
+func (in *AgentTestExpectations) DeepCopy() *AgentTestExpectations {
 	if in == nil {
 		return nil
 	}
-	out := new(AzureModelConfig)
+	out := new(AgentTestExpectations)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BaselineEvaluationConfig) DeepCopyInto(out *BaselineEvaluationConfig) {
+func (in *AgentTestList) DeepCopyInto(out *AgentTestList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AgentTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaselineEvaluationConfig.
-func (in *BaselineEvaluationConfig) DeepCopy() *BaselineEvaluationConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentTestList.
+func (in *AgentTestList) DeepCopy() *AgentTestList {
 	if in == nil {
 		return nil
 	}
-	out := new(BaselineEvaluationConfig)
+	out := new(AgentTestList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AgentTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BatchEvaluationConfig) DeepCopyInto(out *BatchEvaluationConfig) {
+func (in *AgentTestMockTool) DeepCopyInto(out *AgentTestMockTool) {
 	*out = *in
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]BatchEvaluationItem, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(BatchEvaluationTemplate)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.QuerySelector != nil {
-		in, out := &in.QuerySelector, &out.QuerySelector
-		*out = new(QuerySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Evaluations != nil {
-		in, out := &in.Evaluations, &out.Evaluations
-		*out = make([]EvaluationRef, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchEvaluationConfig.
-func (in *BatchEvaluationConfig) DeepCopy() *BatchEvaluationConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentTestMockTool.
+func (in *AgentTestMockTool) DeepCopy() *AgentTestMockTool {
 	if in == nil {
 		return nil
 	}
-	out := new(BatchEvaluationConfig)
+	out := new(AgentTestMockTool)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BatchEvaluationItem) DeepCopyInto(out *BatchEvaluationItem) {
+func (in *AgentTestSpec) DeepCopyInto(out *AgentTestSpec) {
 	*out = *in
-	in.Config.DeepCopyInto(&out.Config)
-	in.Evaluator.DeepCopyInto(&out.Evaluator)
-	if in.TTL != nil {
-		in, out := &in.TTL, &out.TTL
-		*out = new(v1.Duration)
+	if in.ModelRef != nil {
+		in, out := &in.ModelRef, &out.ModelRef
+		*out = new(AgentModelRef)
 		**out = **in
 	}
+	if in.MockTools != nil {
+		in, out := &in.MockTools, &out.MockTools
+		*out = make([]AgentTestMockTool, len(*in))
+		copy(*out, *in)
+	}
+	in.Expect.DeepCopyInto(&out.Expect)
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
 		*out = new(v1.Duration)
@@ -304,282 +339,230 @@ func (in *BatchEvaluationItem) DeepCopyInto(out *BatchEvaluationItem) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchEvaluationItem.
-func (in *BatchEvaluationItem) DeepCopy() *BatchEvaluationItem {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentTestSpec.
+func (in *AgentTestSpec) DeepCopy() *AgentTestSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BatchEvaluationItem)
+	out := new(AgentTestSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BatchEvaluationProgress) DeepCopyInto(out *BatchEvaluationProgress) {
+func (in *AgentTestStatus) DeepCopyInto(out *AgentTestStatus) {
 	*out = *in
-	if in.ChildEvaluations != nil {
-		in, out := &in.ChildEvaluations, &out.ChildEvaluations
-		*out = make([]ChildEvaluationStatus, len(*in))
+	if in.Assertions != nil {
+		in, out := &in.Assertions, &out.Assertions
+		*out = make([]AgentTestAssertionResult, len(*in))
 		copy(*out, *in)
 	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchEvaluationProgress.
-func (in *BatchEvaluationProgress) DeepCopy() *BatchEvaluationProgress {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentTestStatus.
+func (in *AgentTestStatus) DeepCopy() *AgentTestStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(BatchEvaluationProgress)
+	out := new(AgentTestStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BatchEvaluationTemplate) DeepCopyInto(out *BatchEvaluationTemplate) {
+func (in *AgentTool) DeepCopyInto(out *AgentTool) {
 	*out = *in
-	in.Evaluator.DeepCopyInto(&out.Evaluator)
-	in.Config.DeepCopyInto(&out.Config)
-	if in.Parameters != nil {
-		in, out := &in.Parameters, &out.Parameters
-		*out = make([]Parameter, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Functions != nil {
+		in, out := &in.Functions, &out.Functions
+		*out = make([]ToolFunction, len(*in))
+		copy(*out, *in)
+	}
+	if in.Partial != nil {
+		in, out := &in.Partial, &out.Partial
+		*out = new(ToolPartial)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchEvaluationTemplate.
-func (in *BatchEvaluationTemplate) DeepCopy() *BatchEvaluationTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentTool.
+func (in *AgentTool) DeepCopy() *AgentTool {
 	if in == nil {
 		return nil
 	}
-	out := new(BatchEvaluationTemplate)
+	out := new(AgentTool)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BedrockModelConfig) DeepCopyInto(out *BedrockModelConfig) {
+func (in *AgentToolPolicy) DeepCopyInto(out *AgentToolPolicy) {
 	*out = *in
-	if in.Region != nil {
-		in, out := &in.Region, &out.Region
-		*out = new(ValueSource)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.BaseURL != nil {
-		in, out := &in.BaseURL, &out.BaseURL
-		*out = new(ValueSource)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AccessKeyID != nil {
-		in, out := &in.AccessKeyID, &out.AccessKeyID
-		*out = new(ValueSource)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SecretAccessKey != nil {
-		in, out := &in.SecretAccessKey, &out.SecretAccessKey
-		*out = new(ValueSource)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SessionToken != nil {
-		in, out := &in.SessionToken, &out.SessionToken
-		*out = new(ValueSource)
-		(*in).DeepCopyInto(*out)
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.ModelArn != nil {
-		in, out := &in.ModelArn, &out.ModelArn
-		*out = new(ValueSource)
-		(*in).DeepCopyInto(*out)
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.MaxTokens != nil {
-		in, out := &in.MaxTokens, &out.MaxTokens
-		*out = new(int)
+	if in.MaxCallsPerExecution != nil {
+		in, out := &in.MaxCallsPerExecution, &out.MaxCallsPerExecution
+		*out = new(int32)
 		**out = **in
 	}
-	if in.Temperature != nil {
-		in, out := &in.Temperature, &out.Temperature
-		*out = new(string)
+	if in.MaxParallelCalls != nil {
+		in, out := &in.MaxParallelCalls, &out.MaxParallelCalls
+		*out = new(int32)
 		**out = **in
 	}
-	if in.Properties != nil {
-		in, out := &in.Properties, &out.Properties
-		*out = make(map[string]ValueSource, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BedrockModelConfig.
-func (in *BedrockModelConfig) DeepCopy() *BedrockModelConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentToolPolicy.
+func (in *AgentToolPolicy) DeepCopy() *AgentToolPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(BedrockModelConfig)
+	out := new(AgentToolPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BuiltinToolRef) DeepCopyInto(out *BuiltinToolRef) {
+func (in *AgentToolRef) DeepCopyInto(out *AgentToolRef) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuiltinToolRef.
-func (in *BuiltinToolRef) DeepCopy() *BuiltinToolRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentToolRef.
+func (in *AgentToolRef) DeepCopy() *AgentToolRef {
 	if in == nil {
 		return nil
 	}
-	out := new(BuiltinToolRef)
+	out := new(AgentToolRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ChildEvaluationStatus) DeepCopyInto(out *ChildEvaluationStatus) {
+func (in *AgentToolSchemas) DeepCopyInto(out *AgentToolSchemas) {
 	*out = *in
+	if in.Keywords != nil {
+		in, out := &in.Keywords, &out.Keywords
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChildEvaluationStatus.
-func (in *ChildEvaluationStatus) DeepCopy() *ChildEvaluationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentToolSchemas.
+func (in *AgentToolSchemas) DeepCopy() *AgentToolSchemas {
 	if in == nil {
 		return nil
 	}
-	out := new(ChildEvaluationStatus)
+	out := new(AgentToolSchemas)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DirectEvaluationConfig) DeepCopyInto(out *DirectEvaluationConfig) {
+func (in *AggregatedResponse) DeepCopyInto(out *AggregatedResponse) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectEvaluationConfig.
-func (in *DirectEvaluationConfig) DeepCopy() *DirectEvaluationConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AggregatedResponse.
+func (in *AggregatedResponse) DeepCopy() *AggregatedResponse {
 	if in == nil {
 		return nil
 	}
-	out := new(DirectEvaluationConfig)
+	out := new(AggregatedResponse)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Evaluation) DeepCopyInto(out *Evaluation) {
+func (in *AggregationConfig) DeepCopyInto(out *AggregationConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Evaluation.
-func (in *Evaluation) DeepCopy() *Evaluation {
-	if in == nil {
-		return nil
-	}
-	out := new(Evaluation)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Evaluation) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EvaluationConfig) DeepCopyInto(out *EvaluationConfig) {
-	*out = *in
-	if in.DirectEvaluationConfig != nil {
-		in, out := &in.DirectEvaluationConfig, &out.DirectEvaluationConfig
-		*out = new(DirectEvaluationConfig)
-		**out = **in
-	}
-	if in.QueryBasedEvaluationConfig != nil {
-		in, out := &in.QueryBasedEvaluationConfig, &out.QueryBasedEvaluationConfig
-		*out = new(QueryBasedEvaluationConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.BatchEvaluationConfig != nil {
-		in, out := &in.BatchEvaluationConfig, &out.BatchEvaluationConfig
-		*out = new(BatchEvaluationConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.BaselineEvaluationConfig != nil {
-		in, out := &in.BaselineEvaluationConfig, &out.BaselineEvaluationConfig
-		*out = new(BaselineEvaluationConfig)
+	if in.JudgeRef != nil {
+		in, out := &in.JudgeRef, &out.JudgeRef
+		*out = new(AgentModelRef)
 		**out = **in
 	}
-	if in.EventEvaluationConfig != nil {
-		in, out := &in.EventEvaluationConfig, &out.EventEvaluationConfig
-		*out = new(EventEvaluationConfig)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationConfig.
-func (in *EvaluationConfig) DeepCopy() *EvaluationConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AggregationConfig.
+func (in *AggregationConfig) DeepCopy() *AggregationConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(EvaluationConfig)
+	out := new(AggregationConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EvaluationEvaluatorRef) DeepCopyInto(out *EvaluationEvaluatorRef) {
+func (in *ArkInstallStatus) DeepCopyInto(out *ArkInstallStatus) {
 	*out = *in
-	if in.Parameters != nil {
-		in, out := &in.Parameters, &out.Parameters
-		*out = make([]Parameter, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationEvaluatorRef.
-func (in *EvaluationEvaluatorRef) DeepCopy() *EvaluationEvaluatorRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArkInstallStatus.
+func (in *ArkInstallStatus) DeepCopy() *ArkInstallStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(EvaluationEvaluatorRef)
+	out := new(ArkInstallStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArkInstallStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EvaluationList) DeepCopyInto(out *EvaluationList) {
+func (in *ArkInstallStatusList) DeepCopyInto(out *ArkInstallStatusList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Evaluation, len(*in))
+		*out = make([]ArkInstallStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationList.
-func (in *EvaluationList) DeepCopy() *EvaluationList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArkInstallStatusList.
+func (in *ArkInstallStatusList) DeepCopy() *ArkInstallStatusList {
 	if in == nil {
 		return nil
 	}
-	out := new(EvaluationList)
+	out := new(ArkInstallStatusList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EvaluationList) DeepCopyObject() runtime.Object {
+func (in *ArkInstallStatusList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -587,64 +570,42 @@ func (in *EvaluationList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EvaluationRef) DeepCopyInto(out *EvaluationRef) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationRef.
-func (in *EvaluationRef) DeepCopy() *EvaluationRef {
-	if in == nil {
-		return nil
-	}
-	out := new(EvaluationRef)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EvaluationSpec) DeepCopyInto(out *EvaluationSpec) {
+func (in *ArkInstallStatusSpec) DeepCopyInto(out *ArkInstallStatusSpec) {
 	*out = *in
-	in.Config.DeepCopyInto(&out.Config)
-	in.Evaluator.DeepCopyInto(&out.Evaluator)
-	if in.TTL != nil {
-		in, out := &in.TTL, &out.TTL
-		*out = new(v1.Duration)
-		**out = **in
-	}
-	if in.Timeout != nil {
-		in, out := &in.Timeout, &out.Timeout
-		*out = new(v1.Duration)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationSpec.
-func (in *EvaluationSpec) DeepCopy() *EvaluationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArkInstallStatusSpec.
+func (in *ArkInstallStatusSpec) DeepCopy() *ArkInstallStatusSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EvaluationSpec)
+	out := new(ArkInstallStatusSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EvaluationStatus) DeepCopyInto(out *EvaluationStatus) {
+func (in *ArkInstallStatusStatus) DeepCopyInto(out *ArkInstallStatusStatus) {
 	*out = *in
-	if in.TokenUsage != nil {
-		in, out := &in.TokenUsage, &out.TokenUsage
-		*out = new(TokenUsage)
-		**out = **in
+	if in.LastCheckedTime != nil {
+		in, out := &in.LastCheckedTime, &out.LastCheckedTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Duration != nil {
-		in, out := &in.Duration, &out.Duration
-		*out = new(v1.Duration)
-		**out = **in
+	in.Webhook.DeepCopyInto(&out.Webhook)
+	if in.CRDs != nil {
+		in, out := &in.CRDs, &out.CRDs
+		*out = make([]InstallCRDStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.BatchProgress != nil {
-		in, out := &in.BatchProgress, &out.BatchProgress
-		*out = new(BatchEvaluationProgress)
-		(*in).DeepCopyInto(*out)
+	out.Telemetry = in.Telemetry
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]InstallNamespaceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -655,241 +616,360 @@ func (in *EvaluationStatus) DeepCopyInto(out *EvaluationStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationStatus.
-func (in *EvaluationStatus) DeepCopy() *EvaluationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArkInstallStatusStatus.
+func (in *ArkInstallStatusStatus) DeepCopy() *ArkInstallStatusStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(EvaluationStatus)
+	out := new(ArkInstallStatusStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Evaluator) DeepCopyInto(out *Evaluator) {
+func (in *Artifact) DeepCopyInto(out *Artifact) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Evaluator.
-func (in *Evaluator) DeepCopy() *Evaluator {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Artifact.
+func (in *Artifact) DeepCopy() *Artifact {
 	if in == nil {
 		return nil
 	}
-	out := new(Evaluator)
+	out := new(Artifact)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Evaluator) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Attachment) DeepCopyInto(out *Attachment) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Attachment.
+func (in *Attachment) DeepCopy() *Attachment {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(Attachment)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EvaluatorList) DeepCopyInto(out *EvaluatorList) {
+func (in *AttachmentSource) DeepCopyInto(out *AttachmentSource) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Evaluator, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluatorList.
-func (in *EvaluatorList) DeepCopy() *EvaluatorList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttachmentSource.
+func (in *AttachmentSource) DeepCopy() *AttachmentSource {
 	if in == nil {
 		return nil
 	}
-	out := new(EvaluatorList)
+	out := new(AttachmentSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EvaluatorList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EvaluatorSpec) DeepCopyInto(out *EvaluatorSpec) {
+func (in *AzureModelConfig) DeepCopyInto(out *AzureModelConfig) {
 	*out = *in
-	in.Address.DeepCopyInto(&out.Address)
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
-		*out = new(ResourceSelector)
+	in.BaseURL.DeepCopyInto(&out.BaseURL)
+	in.APIKey.DeepCopyInto(&out.APIKey)
+	if in.APIVersion != nil {
+		in, out := &in.APIVersion, &out.APIVersion
+		*out = new(ValueSource)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Parameters != nil {
-		in, out := &in.Parameters, &out.Parameters
-		*out = make([]Parameter, len(*in))
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]ValueSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluatorSpec.
-func (in *EvaluatorSpec) DeepCopy() *EvaluatorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureModelConfig.
+func (in *AzureModelConfig) DeepCopy() *AzureModelConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(EvaluatorSpec)
+	out := new(AzureModelConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EvaluatorStatus) DeepCopyInto(out *EvaluatorStatus) {
+func (in *BaselineEvaluationConfig) DeepCopyInto(out *BaselineEvaluationConfig) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluatorStatus.
-func (in *EvaluatorStatus) DeepCopy() *EvaluatorStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaselineEvaluationConfig.
+func (in *BaselineEvaluationConfig) DeepCopy() *BaselineEvaluationConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(EvaluatorStatus)
+	out := new(BaselineEvaluationConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EventEvaluationConfig) DeepCopyInto(out *EventEvaluationConfig) {
+func (in *BatchEvaluationConfig) DeepCopyInto(out *BatchEvaluationConfig) {
 	*out = *in
-	if in.Rules != nil {
-		in, out := &in.Rules, &out.Rules
-		*out = make([]ExpressionRule, len(*in))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BatchEvaluationItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(BatchEvaluationTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QuerySelector != nil {
+		in, out := &in.QuerySelector, &out.QuerySelector
+		*out = new(QuerySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Evaluations != nil {
+		in, out := &in.Evaluations, &out.Evaluations
+		*out = make([]EvaluationRef, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventEvaluationConfig.
-func (in *EventEvaluationConfig) DeepCopy() *EventEvaluationConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchEvaluationConfig.
+func (in *BatchEvaluationConfig) DeepCopy() *BatchEvaluationConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(EventEvaluationConfig)
+	out := new(BatchEvaluationConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExecutionEngineRef) DeepCopyInto(out *ExecutionEngineRef) {
+func (in *BatchEvaluationItem) DeepCopyInto(out *BatchEvaluationItem) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionEngineRef.
-func (in *ExecutionEngineRef) DeepCopy() *ExecutionEngineRef {
+	in.Config.DeepCopyInto(&out.Config)
+	in.Evaluator.DeepCopyInto(&out.Evaluator)
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchEvaluationItem.
+func (in *BatchEvaluationItem) DeepCopy() *BatchEvaluationItem {
 	if in == nil {
 		return nil
 	}
-	out := new(ExecutionEngineRef)
+	out := new(BatchEvaluationItem)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExpressionRule) DeepCopyInto(out *ExpressionRule) {
+func (in *BatchEvaluationProgress) DeepCopyInto(out *BatchEvaluationProgress) {
 	*out = *in
+	if in.ChildEvaluations != nil {
+		in, out := &in.ChildEvaluations, &out.ChildEvaluations
+		*out = make([]ChildEvaluationStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpressionRule.
-func (in *ExpressionRule) DeepCopy() *ExpressionRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchEvaluationProgress.
+func (in *BatchEvaluationProgress) DeepCopy() *BatchEvaluationProgress {
 	if in == nil {
 		return nil
 	}
-	out := new(ExpressionRule)
+	out := new(BatchEvaluationProgress)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSpec.
-func (in *HTTPSpec) DeepCopy() *HTTPSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BatchEvaluationTemplate) DeepCopyInto(out *BatchEvaluationTemplate) {
+	*out = *in
+	in.Evaluator.DeepCopyInto(&out.Evaluator)
+	in.Config.DeepCopyInto(&out.Config)
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]Parameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchEvaluationTemplate.
+func (in *BatchEvaluationTemplate) DeepCopy() *BatchEvaluationTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPSpec)
+	out := new(BatchEvaluationTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Header) DeepCopyInto(out *Header) {
+func (in *BedrockModelConfig) DeepCopyInto(out *BedrockModelConfig) {
 	*out = *in
-	in.Value.DeepCopyInto(&out.Value)
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BaseURL != nil {
+		in, out := &in.BaseURL, &out.BaseURL
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessKeyID != nil {
+		in, out := &in.AccessKeyID, &out.AccessKeyID
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretAccessKey != nil {
+		in, out := &in.SecretAccessKey, &out.SecretAccessKey
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SessionToken != nil {
+		in, out := &in.SessionToken, &out.SessionToken
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModelArn != nil {
+		in, out := &in.ModelArn, &out.ModelArn
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxTokens != nil {
+		in, out := &in.MaxTokens, &out.MaxTokens
+		*out = new(int)
+		**out = **in
+	}
+	if in.Temperature != nil {
+		in, out := &in.Temperature, &out.Temperature
+		*out = new(string)
+		**out = **in
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]ValueSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Header.
-func (in *Header) DeepCopy() *Header {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BedrockModelConfig.
+func (in *BedrockModelConfig) DeepCopy() *BedrockModelConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(Header)
+	out := new(BedrockModelConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HeaderValue) DeepCopyInto(out *HeaderValue) {
+func (in *BuiltinToolRef) DeepCopyInto(out *BuiltinToolRef) {
 	*out = *in
-	if in.ValueFrom != nil {
-		in, out := &in.ValueFrom, &out.ValueFrom
-		*out = new(HeaderValueSource)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuiltinToolRef.
+func (in *BuiltinToolRef) DeepCopy() *BuiltinToolRef {
+	if in == nil {
+		return nil
 	}
+	out := new(BuiltinToolRef)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderValue.
-func (in *HeaderValue) DeepCopy() *HeaderValue {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChildEvaluationStatus) DeepCopyInto(out *ChildEvaluationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChildEvaluationStatus.
+func (in *ChildEvaluationStatus) DeepCopy() *ChildEvaluationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HeaderValue)
+	out := new(ChildEvaluationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HeaderValueSource) DeepCopyInto(out *HeaderValueSource) {
+func (in *ClientTLS) DeepCopyInto(out *ClientTLS) {
 	*out = *in
-	if in.SecretKeyRef != nil {
-		in, out := &in.SecretKeyRef, &out.SecretKeyRef
-		*out = new(corev1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientTLS.
+func (in *ClientTLS) DeepCopy() *ClientTLS {
+	if in == nil {
+		return nil
 	}
-	if in.ConfigMapKeyRef != nil {
-		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
-		*out = new(corev1.ConfigMapKeySelector)
-		(*in).DeepCopyInto(*out)
+	out := new(ClientTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfidenceConfig) DeepCopyInto(out *ConfidenceConfig) {
+	*out = *in
+	if in.ModelRef != nil {
+		in, out := &in.ModelRef, &out.ModelRef
+		*out = new(AgentModelRef)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderValueSource.
-func (in *HeaderValueSource) DeepCopy() *HeaderValueSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfidenceConfig.
+func (in *ConfidenceConfig) DeepCopy() *ConfidenceConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(HeaderValueSource)
+	out := new(ConfidenceConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MCPServer) DeepCopyInto(out *MCPServer) {
+func (in *CronQuery) DeepCopyInto(out *CronQuery) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -897,18 +977,18 @@ func (in *MCPServer) DeepCopyInto(out *MCPServer) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServer.
-func (in *MCPServer) DeepCopy() *MCPServer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronQuery.
+func (in *CronQuery) DeepCopy() *CronQuery {
 	if in == nil {
 		return nil
 	}
-	out := new(MCPServer)
+	out := new(CronQuery)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MCPServer) DeepCopyObject() runtime.Object {
+func (in *CronQuery) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -916,109 +996,118 @@ func (in *MCPServer) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MCPServerList) DeepCopyInto(out *MCPServerList) {
+func (in *CronQueryList) DeepCopyInto(out *CronQueryList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]MCPServer, len(*in))
+		*out = make([]CronQuery, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerList.
-func (in *MCPServerList) DeepCopy() *MCPServerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronQueryList.
+func (in *CronQueryList) DeepCopy() *CronQueryList {
 	if in == nil {
 		return nil
 	}
-	out := new(MCPServerList)
+	out := new(CronQueryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MCPServerList) DeepCopyObject() runtime.Object {
+func (in *CronQueryList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerRef.
-func (in *MCPServerRef) DeepCopy() *MCPServerRef {
-	if in == nil {
-		return nil
-	}
-	out := new(MCPServerRef)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MCPServerSpec) DeepCopyInto(out *MCPServerSpec) {
+func (in *CronQuerySpec) DeepCopyInto(out *CronQuerySpec) {
 	*out = *in
-	in.Address.DeepCopyInto(&out.Address)
-	if in.Headers != nil {
-		in, out := &in.Headers, &out.Headers
-		*out = make([]Header, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
 	}
-	if in.PollInterval != nil {
-		in, out := &in.PollInterval, &out.PollInterval
-		*out = new(v1.Duration)
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SuccessfulQueriesHistoryLimit != nil {
+		in, out := &in.SuccessfulQueriesHistoryLimit, &out.SuccessfulQueriesHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedQueriesHistoryLimit != nil {
+		in, out := &in.FailedQueriesHistoryLimit, &out.FailedQueriesHistoryLimit
+		*out = new(int32)
 		**out = **in
 	}
+	in.QueryTemplate.DeepCopyInto(&out.QueryTemplate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerSpec.
-func (in *MCPServerSpec) DeepCopy() *MCPServerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronQuerySpec.
+func (in *CronQuerySpec) DeepCopy() *CronQuerySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MCPServerSpec)
+	out := new(CronQuerySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MCPServerStatus) DeepCopyInto(out *MCPServerStatus) {
+func (in *CronQueryStatus) DeepCopyInto(out *CronQueryStatus) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]corev1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerStatus.
-func (in *MCPServerStatus) DeepCopy() *MCPServerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronQueryStatus.
+func (in *CronQueryStatus) DeepCopy() *CronQueryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MCPServerStatus)
+	out := new(CronQueryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolRef.
-func (in *MCPToolRef) DeepCopy() *MCPToolRef {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectEvaluationConfig) DeepCopyInto(out *DirectEvaluationConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectEvaluationConfig.
+func (in *DirectEvaluationConfig) DeepCopy() *DirectEvaluationConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(MCPToolRef)
+	out := new(DirectEvaluationConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Memory) DeepCopyInto(out *Memory) {
+func (in *Evaluation) DeepCopyInto(out *Evaluation) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1026,18 +1115,18 @@ func (in *Memory) DeepCopyInto(out *Memory) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Memory.
-func (in *Memory) DeepCopy() *Memory {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Evaluation.
+func (in *Evaluation) DeepCopy() *Evaluation {
 	if in == nil {
 		return nil
 	}
-	out := new(Memory)
+	out := new(Evaluation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Memory) DeepCopyObject() runtime.Object {
+func (in *Evaluation) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1045,31 +1134,108 @@ func (in *Memory) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MemoryList) DeepCopyInto(out *MemoryList) {
+func (in *EvaluationConfig) DeepCopyInto(out *EvaluationConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Memory, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryList.
-func (in *MemoryList) DeepCopy() *MemoryList {
-	if in == nil {
-		return nil
+	if in.DirectEvaluationConfig != nil {
+		in, out := &in.DirectEvaluationConfig, &out.DirectEvaluationConfig
+		*out = new(DirectEvaluationConfig)
+		**out = **in
 	}
-	out := new(MemoryList)
+	if in.QueryBasedEvaluationConfig != nil {
+		in, out := &in.QueryBasedEvaluationConfig, &out.QueryBasedEvaluationConfig
+		*out = new(QueryBasedEvaluationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BatchEvaluationConfig != nil {
+		in, out := &in.BatchEvaluationConfig, &out.BatchEvaluationConfig
+		*out = new(BatchEvaluationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BaselineEvaluationConfig != nil {
+		in, out := &in.BaselineEvaluationConfig, &out.BaselineEvaluationConfig
+		*out = new(BaselineEvaluationConfig)
+		**out = **in
+	}
+	if in.EventEvaluationConfig != nil {
+		in, out := &in.EventEvaluationConfig, &out.EventEvaluationConfig
+		*out = new(EventEvaluationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationConfig.
+func (in *EvaluationConfig) DeepCopy() *EvaluationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EvaluationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvaluationEvaluatorRef) DeepCopyInto(out *EvaluationEvaluatorRef) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]Parameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationEvaluatorRef.
+func (in *EvaluationEvaluatorRef) DeepCopy() *EvaluationEvaluatorRef {
+	if in == nil {
+		return nil
+	}
+	out := new(EvaluationEvaluatorRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvaluationJudgement) DeepCopyInto(out *EvaluationJudgement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationJudgement.
+func (in *EvaluationJudgement) DeepCopy() *EvaluationJudgement {
+	if in == nil {
+		return nil
+	}
+	out := new(EvaluationJudgement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvaluationList) DeepCopyInto(out *EvaluationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Evaluation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationList.
+func (in *EvaluationList) DeepCopy() *EvaluationList {
+	if in == nil {
+		return nil
+	}
+	out := new(EvaluationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MemoryList) DeepCopyObject() runtime.Object {
+func (in *EvaluationList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1077,426 +1243,1949 @@ func (in *MemoryList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MemoryRef) DeepCopyInto(out *MemoryRef) {
+func (in *EvaluationRef) DeepCopyInto(out *EvaluationRef) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryRef.
-func (in *MemoryRef) DeepCopy() *MemoryRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationRef.
+func (in *EvaluationRef) DeepCopy() *EvaluationRef {
 	if in == nil {
 		return nil
 	}
-	out := new(MemoryRef)
+	out := new(EvaluationRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MemorySpec) DeepCopyInto(out *MemorySpec) {
+func (in *EvaluationSpec) DeepCopyInto(out *EvaluationSpec) {
 	*out = *in
-	in.Address.DeepCopyInto(&out.Address)
+	in.Config.DeepCopyInto(&out.Config)
+	in.Evaluator.DeepCopyInto(&out.Evaluator)
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemorySpec.
-func (in *MemorySpec) DeepCopy() *MemorySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationSpec.
+func (in *EvaluationSpec) DeepCopy() *EvaluationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MemorySpec)
+	out := new(EvaluationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MemoryStatus) DeepCopyInto(out *MemoryStatus) {
+func (in *EvaluationStatus) DeepCopyInto(out *EvaluationStatus) {
 	*out = *in
-	if in.LastResolvedAddress != nil {
-		in, out := &in.LastResolvedAddress, &out.LastResolvedAddress
-		*out = new(string)
+	if in.TokenUsage != nil {
+		in, out := &in.TokenUsage, &out.TokenUsage
+		*out = new(TokenUsage)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.BatchProgress != nil {
+		in, out := &in.BatchProgress, &out.BatchProgress
+		*out = new(BatchEvaluationProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Feedback != nil {
+		in, out := &in.Feedback, &out.Feedback
+		*out = new(FeedbackSummary)
 		**out = **in
 	}
+	if in.Judgement != nil {
+		in, out := &in.Judgement, &out.Judgement
+		*out = new(EvaluationJudgement)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryStatus.
-func (in *MemoryStatus) DeepCopy() *MemoryStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationStatus.
+func (in *EvaluationStatus) DeepCopy() *EvaluationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MemoryStatus)
+	out := new(EvaluationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Model) DeepCopyInto(out *Model) {
+func (in *Evaluator) DeepCopyInto(out *Evaluator) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Model.
-func (in *Model) DeepCopy() *Model {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Evaluator.
+func (in *Evaluator) DeepCopy() *Evaluator {
+	if in == nil {
+		return nil
+	}
+	out := new(Evaluator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Evaluator) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvaluatorJudgeConfig) DeepCopyInto(out *EvaluatorJudgeConfig) {
+	*out = *in
+	if in.ModelRef != nil {
+		in, out := &in.ModelRef, &out.ModelRef
+		*out = new(AgentModelRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluatorJudgeConfig.
+func (in *EvaluatorJudgeConfig) DeepCopy() *EvaluatorJudgeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EvaluatorJudgeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvaluatorList) DeepCopyInto(out *EvaluatorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Evaluator, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluatorList.
+func (in *EvaluatorList) DeepCopy() *EvaluatorList {
+	if in == nil {
+		return nil
+	}
+	out := new(EvaluatorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EvaluatorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvaluatorSpec) DeepCopyInto(out *EvaluatorSpec) {
+	*out = *in
+	in.Address.DeepCopyInto(&out.Address)
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(ResourceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]Parameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ExpressionRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Judge != nil {
+		in, out := &in.Judge, &out.Judge
+		*out = new(EvaluatorJudgeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluatorSpec.
+func (in *EvaluatorSpec) DeepCopy() *EvaluatorSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Model)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Model) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+	out := new(EvaluatorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvaluatorStatus) DeepCopyInto(out *EvaluatorStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluatorStatus.
+func (in *EvaluatorStatus) DeepCopy() *EvaluatorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EvaluatorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventEvaluationConfig) DeepCopyInto(out *EventEvaluationConfig) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ExpressionRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventEvaluationConfig.
+func (in *EventEvaluationConfig) DeepCopy() *EventEvaluationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EventEvaluationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionEngineRef) DeepCopyInto(out *ExecutionEngineRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionEngineRef.
+func (in *ExecutionEngineRef) DeepCopy() *ExecutionEngineRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionEngineRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExpressionRule) DeepCopyInto(out *ExpressionRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpressionRule.
+func (in *ExpressionRule) DeepCopy() *ExpressionRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ExpressionRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailurePolicy) DeepCopyInto(out *FailurePolicy) {
+	*out = *in
+	if in.MinSuccesses != nil {
+		in, out := &in.MinSuccesses, &out.MinSuccesses
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailurePolicy.
+func (in *FailurePolicy) DeepCopy() *FailurePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FailurePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Feedback) DeepCopyInto(out *Feedback) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Feedback.
+func (in *Feedback) DeepCopy() *Feedback {
+	if in == nil {
+		return nil
+	}
+	out := new(Feedback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Feedback) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeedbackList) DeepCopyInto(out *FeedbackList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Feedback, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeedbackList.
+func (in *FeedbackList) DeepCopy() *FeedbackList {
+	if in == nil {
+		return nil
+	}
+	out := new(FeedbackList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FeedbackList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeedbackSpec) DeepCopyInto(out *FeedbackSpec) {
+	*out = *in
+	out.QueryRef = in.QueryRef
+	if in.UserContext != nil {
+		in, out := &in.UserContext, &out.UserContext
+		*out = new(UserContext)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeedbackSpec.
+func (in *FeedbackSpec) DeepCopy() *FeedbackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FeedbackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeedbackStatus) DeepCopyInto(out *FeedbackStatus) {
+	*out = *in
+	if in.RecordedTime != nil {
+		in, out := &in.RecordedTime, &out.RecordedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeedbackStatus.
+func (in *FeedbackStatus) DeepCopy() *FeedbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FeedbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeedbackSummary) DeepCopyInto(out *FeedbackSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeedbackSummary.
+func (in *FeedbackSummary) DeepCopy() *FeedbackSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(FeedbackSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FewShotConfig) DeepCopyInto(out *FewShotConfig) {
+	*out = *in
+	out.EmbeddingModelRef = in.EmbeddingModelRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FewShotConfig.
+func (in *FewShotConfig) DeepCopy() *FewShotConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FewShotConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayModelConfig) DeepCopyInto(out *GatewayModelConfig) {
+	*out = *in
+	in.BaseURL.DeepCopyInto(&out.BaseURL)
+	if in.APIKey != nil {
+		in, out := &in.APIKey, &out.APIKey
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]ValueSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.UsageHeaders != nil {
+		in, out := &in.UsageHeaders, &out.UsageHeaders
+		*out = new(GatewayUsageHeaders)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayModelConfig.
+func (in *GatewayModelConfig) DeepCopy() *GatewayModelConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayModelConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayUsageHeaders) DeepCopyInto(out *GatewayUsageHeaders) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayUsageHeaders.
+func (in *GatewayUsageHeaders) DeepCopy() *GatewayUsageHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayUsageHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSpec.
+func (in *HTTPSpec) DeepCopy() *HTTPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Header) DeepCopyInto(out *Header) {
+	*out = *in
+	in.Value.DeepCopyInto(&out.Value)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Header.
+func (in *Header) DeepCopy() *Header {
+	if in == nil {
+		return nil
+	}
+	out := new(Header)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderValue) DeepCopyInto(out *HeaderValue) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(HeaderValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderValue.
+func (in *HeaderValue) DeepCopy() *HeaderValue {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderValueSource) DeepCopyInto(out *HeaderValueSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderValueSource.
+func (in *HeaderValueSource) DeepCopy() *HeaderValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallCRDStatus) DeepCopyInto(out *InstallCRDStatus) {
+	*out = *in
+	if in.ServedVersions != nil {
+		in, out := &in.ServedVersions, &out.ServedVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StoredVersions != nil {
+		in, out := &in.StoredVersions, &out.StoredVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallCRDStatus.
+func (in *InstallCRDStatus) DeepCopy() *InstallCRDStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallCRDStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallConfigCheck) DeepCopyInto(out *InstallConfigCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallConfigCheck.
+func (in *InstallConfigCheck) DeepCopy() *InstallConfigCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallConfigCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallNamespaceStatus) DeepCopyInto(out *InstallNamespaceStatus) {
+	*out = *in
+	if in.Streaming != nil {
+		in, out := &in.Streaming, &out.Streaming
+		*out = new(InstallConfigCheck)
+		**out = **in
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(InstallConfigCheck)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallNamespaceStatus.
+func (in *InstallNamespaceStatus) DeepCopy() *InstallNamespaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallNamespaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallTelemetryStatus) DeepCopyInto(out *InstallTelemetryStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallTelemetryStatus.
+func (in *InstallTelemetryStatus) DeepCopy() *InstallTelemetryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallTelemetryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallWebhookStatus) DeepCopyInto(out *InstallWebhookStatus) {
+	*out = *in
+	if in.CertificateNotAfter != nil {
+		in, out := &in.CertificateNotAfter, &out.CertificateNotAfter
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallWebhookStatus.
+func (in *InstallWebhookStatus) DeepCopy() *InstallWebhookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallWebhookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPPrompt) DeepCopyInto(out *MCPPrompt) {
+	*out = *in
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = make([]MCPPromptArgument, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPPrompt.
+func (in *MCPPrompt) DeepCopy() *MCPPrompt {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPPrompt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPPromptArgument) DeepCopyInto(out *MCPPromptArgument) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPPromptArgument.
+func (in *MCPPromptArgument) DeepCopy() *MCPPromptArgument {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPPromptArgument)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPResource) DeepCopyInto(out *MCPResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPResource.
+func (in *MCPResource) DeepCopy() *MCPResource {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPResourceTemplate) DeepCopyInto(out *MCPResourceTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPResourceTemplate.
+func (in *MCPResourceTemplate) DeepCopy() *MCPResourceTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPResourceTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServer) DeepCopyInto(out *MCPServer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServer.
+func (in *MCPServer) DeepCopy() *MCPServer {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPServer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerAuth) DeepCopyInto(out *MCPServerAuth) {
+	*out = *in
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2ClientCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerAuth.
+func (in *MCPServerAuth) DeepCopy() *MCPServerAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerInfo) DeepCopyInto(out *MCPServerInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerInfo.
+func (in *MCPServerInfo) DeepCopy() *MCPServerInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerList) DeepCopyInto(out *MCPServerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MCPServer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerList.
+func (in *MCPServerList) DeepCopy() *MCPServerList {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPServerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerRef.
+func (in *MCPServerRef) DeepCopy() *MCPServerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerSpec) DeepCopyInto(out *MCPServerSpec) {
+	*out = *in
+	in.Address.DeepCopyInto(&out.Address)
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(MCPServerAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ClientTLS)
+		**out = **in
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerSpec.
+func (in *MCPServerSpec) DeepCopy() *MCPServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerStatus) DeepCopyInto(out *MCPServerStatus) {
+	*out = *in
+	if in.ServerInfo != nil {
+		in, out := &in.ServerInfo, &out.ServerInfo
+		*out = new(MCPServerInfo)
+		**out = **in
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Prompts != nil {
+		in, out := &in.Prompts, &out.Prompts
+		*out = make([]MCPPrompt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]MCPResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceTemplates != nil {
+		in, out := &in.ResourceTemplates, &out.ResourceTemplates
+		*out = make([]MCPResourceTemplate, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerStatus.
+func (in *MCPServerStatus) DeepCopy() *MCPServerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolRef.
+func (in *MCPToolRef) DeepCopy() *MCPToolRef {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPToolRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Memory) DeepCopyInto(out *Memory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Memory.
+func (in *Memory) DeepCopy() *Memory {
+	if in == nil {
+		return nil
+	}
+	out := new(Memory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Memory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryList) DeepCopyInto(out *MemoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Memory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryList.
+func (in *MemoryList) DeepCopy() *MemoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MemoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryRef) DeepCopyInto(out *MemoryRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryRef.
+func (in *MemoryRef) DeepCopy() *MemoryRef {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemorySpec) DeepCopyInto(out *MemorySpec) {
+	*out = *in
+	in.Address.DeepCopyInto(&out.Address)
+	if in.Truncation != nil {
+		in, out := &in.Truncation, &out.Truncation
+		*out = new(MemoryTruncation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemorySpec.
+func (in *MemorySpec) DeepCopy() *MemorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MemorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryStatus) DeepCopyInto(out *MemoryStatus) {
+	*out = *in
+	if in.LastResolvedAddress != nil {
+		in, out := &in.LastResolvedAddress, &out.LastResolvedAddress
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryStatus.
+func (in *MemoryStatus) DeepCopy() *MemoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryTruncation) DeepCopyInto(out *MemoryTruncation) {
+	*out = *in
+	if in.ModelRef != nil {
+		in, out := &in.ModelRef, &out.ModelRef
+		*out = new(AgentModelRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryTruncation.
+func (in *MemoryTruncation) DeepCopy() *MemoryTruncation {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryTruncation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Model) DeepCopyInto(out *Model) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Model.
+func (in *Model) DeepCopy() *Model {
+	if in == nil {
+		return nil
+	}
+	out := new(Model)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Model) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelConfig) DeepCopyInto(out *ModelConfig) {
+	*out = *in
+	if in.OpenAI != nil {
+		in, out := &in.OpenAI, &out.OpenAI
+		*out = new(OpenAIModelConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureModelConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bedrock != nil {
+		in, out := &in.Bedrock, &out.Bedrock
+		*out = new(BedrockModelConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GatewayModelConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelConfig.
+func (in *ModelConfig) DeepCopy() *ModelConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelList) DeepCopyInto(out *ModelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Model, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelList.
+func (in *ModelList) DeepCopy() *ModelList {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelPricing) DeepCopyInto(out *ModelPricing) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelPricing.
+func (in *ModelPricing) DeepCopy() *ModelPricing {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelPricing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRateLimit) DeepCopyInto(out *ModelRateLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRateLimit.
+func (in *ModelRateLimit) DeepCopy() *ModelRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
+	*out = *in
+	in.Model.DeepCopyInto(&out.Model)
+	in.Config.DeepCopyInto(&out.Config)
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(ModelRateLimit)
+		**out = **in
+	}
+	if in.Pricing != nil {
+		in, out := &in.Pricing, &out.Pricing
+		*out = new(ModelPricing)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
+func (in *ModelSpec) DeepCopy() *ModelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelStatus) DeepCopyInto(out *ModelStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStatus.
+func (in *ModelStatus) DeepCopy() *ModelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamedTokenUsage) DeepCopyInto(out *NamedTokenUsage) {
+	*out = *in
+	out.TokenUsage = in.TokenUsage
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedTokenUsage.
+func (in *NamedTokenUsage) DeepCopy() *NamedTokenUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(NamedTokenUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2ClientCredentials) DeepCopyInto(out *OAuth2ClientCredentials) {
+	*out = *in
+	in.ClientID.DeepCopyInto(&out.ClientID)
+	in.ClientSecret.DeepCopyInto(&out.ClientSecret)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2ClientCredentials.
+func (in *OAuth2ClientCredentials) DeepCopy() *OAuth2ClientCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2ClientCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAIModelConfig) DeepCopyInto(out *OpenAIModelConfig) {
+	*out = *in
+	in.BaseURL.DeepCopyInto(&out.BaseURL)
+	in.APIKey.DeepCopyInto(&out.APIKey)
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]ValueSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAIModelConfig.
+func (in *OpenAIModelConfig) DeepCopy() *OpenAIModelConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAIModelConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPISpec) DeepCopyInto(out *OpenAPISpec) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPISpec.
+func (in *OpenAPISpec) DeepCopy() *OpenAPISpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPISpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Override) DeepCopyInto(out *Override) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Override.
+func (in *Override) DeepCopy() *Override {
+	if in == nil {
+		return nil
+	}
+	out := new(Override)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Parameter) DeepCopyInto(out *Parameter) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(ValueFromSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(ParameterSchema)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Parameter.
+func (in *Parameter) DeepCopy() *Parameter {
+	if in == nil {
+		return nil
+	}
+	out := new(Parameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterSchema) DeepCopyInto(out *ParameterSchema) {
+	*out = *in
+	if in.Enum != nil {
+		in, out := &in.Enum, &out.Enum
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterSchema.
+func (in *ParameterSchema) DeepCopy() *ParameterSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Query) DeepCopyInto(out *Query) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Query.
+func (in *Query) DeepCopy() *Query {
+	if in == nil {
+		return nil
+	}
+	out := new(Query)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Query) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryBasedEvaluationConfig) DeepCopyInto(out *QueryBasedEvaluationConfig) {
+	*out = *in
+	if in.QueryRef != nil {
+		in, out := &in.QueryRef, &out.QueryRef
+		*out = new(QueryRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryBasedEvaluationConfig.
+func (in *QueryBasedEvaluationConfig) DeepCopy() *QueryBasedEvaluationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryBasedEvaluationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryList) DeepCopyInto(out *QueryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Query, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryList.
+func (in *QueryList) DeepCopy() *QueryList {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QueryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryParameterReference) DeepCopyInto(out *QueryParameterReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryParameterReference.
+func (in *QueryParameterReference) DeepCopy() *QueryParameterReference {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryParameterReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryRef) DeepCopyInto(out *QueryRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryRef.
+func (in *QueryRef) DeepCopy() *QueryRef {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryReplay) DeepCopyInto(out *QueryReplay) {
+	*out = *in
+	if in.Responses != nil {
+		in, out := &in.Responses, &out.Responses
+		*out = make([]ReplayedResponse, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryReplay.
+func (in *QueryReplay) DeepCopy() *QueryReplay {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryReplay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuerySelector) DeepCopyInto(out *QuerySelector) {
+	*out = *in
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]v1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuerySelector.
+func (in *QuerySelector) DeepCopy() *QuerySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(QuerySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuerySpec) DeepCopyInto(out *QuerySpec) {
+	*out = *in
+	in.Input.DeepCopyInto(&out.Input)
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]Parameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]QueryTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SelectorPolicy != nil {
+		in, out := &in.SelectorPolicy, &out.SelectorPolicy
+		*out = new(SelectorPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(FailurePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(MemoryRef)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxTokens != nil {
+		in, out := &in.MaxTokens, &out.MaxTokens
+		*out = new(int64)
+		**out = **in
 	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelConfig) DeepCopyInto(out *ModelConfig) {
-	*out = *in
-	if in.OpenAI != nil {
-		in, out := &in.OpenAI, &out.OpenAI
-		*out = new(OpenAIModelConfig)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]Override, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureModelConfig)
+	if in.Attachments != nil {
+		in, out := &in.Attachments, &out.Attachments
+		*out = make([]Attachment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UserContext != nil {
+		in, out := &in.UserContext, &out.UserContext
+		*out = new(UserContext)
+		**out = **in
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Confidence != nil {
+		in, out := &in.Confidence, &out.Confidence
+		*out = new(ConfidenceConfig)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Bedrock != nil {
-		in, out := &in.Bedrock, &out.Bedrock
-		*out = new(BedrockModelConfig)
+	if in.Aggregation != nil {
+		in, out := &in.Aggregation, &out.Aggregation
+		*out = new(AggregationConfig)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelConfig.
-func (in *ModelConfig) DeepCopy() *ModelConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuerySpec.
+func (in *QuerySpec) DeepCopy() *QuerySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelConfig)
+	out := new(QuerySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelList) DeepCopyInto(out *ModelList) {
+func (in *QueryStatus) DeepCopyInto(out *QueryStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Model, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelList.
-func (in *ModelList) DeepCopy() *ModelList {
-	if in == nil {
-		return nil
+	if in.Responses != nil {
+		in, out := &in.Responses, &out.Responses
+		*out = make([]Response, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	out := new(ModelList)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ModelList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+	if in.AggregatedResponse != nil {
+		in, out := &in.AggregatedResponse, &out.AggregatedResponse
+		*out = new(AggregatedResponse)
+		**out = **in
 	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
-	*out = *in
-	in.Model.DeepCopyInto(&out.Model)
-	in.Config.DeepCopyInto(&out.Config)
-	if in.PollInterval != nil {
-		in, out := &in.PollInterval, &out.PollInterval
+	out.TokenUsage = in.TokenUsage
+	if in.TokenUsageBreakdown != nil {
+		in, out := &in.TokenUsageBreakdown, &out.TokenUsageBreakdown
+		*out = new(TokenUsageBreakdown)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]Artifact, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replay != nil {
+		in, out := &in.Replay, &out.Replay
+		*out = new(QueryReplay)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
-func (in *ModelSpec) DeepCopy() *ModelSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryStatus.
+func (in *QueryStatus) DeepCopy() *QueryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelSpec)
+	out := new(QueryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelStatus) DeepCopyInto(out *ModelStatus) {
+func (in *QueryTarget) DeepCopyInto(out *QueryTarget) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStatus.
-func (in *ModelStatus) DeepCopy() *ModelStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryTarget.
+func (in *QueryTarget) DeepCopy() *QueryTarget {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelStatus)
+	out := new(QueryTarget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenAIModelConfig) DeepCopyInto(out *OpenAIModelConfig) {
+func (in *QueryTemplateSpec) DeepCopyInto(out *QueryTemplateSpec) {
 	*out = *in
-	in.BaseURL.DeepCopyInto(&out.BaseURL)
-	in.APIKey.DeepCopyInto(&out.APIKey)
-	if in.Headers != nil {
-		in, out := &in.Headers, &out.Headers
-		*out = make([]Header, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Properties != nil {
-		in, out := &in.Properties, &out.Properties
-		*out = make(map[string]ValueSource, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAIModelConfig.
-func (in *OpenAIModelConfig) DeepCopy() *OpenAIModelConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryTemplateSpec.
+func (in *QueryTemplateSpec) DeepCopy() *QueryTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenAIModelConfig)
+	out := new(QueryTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Override) DeepCopyInto(out *Override) {
+func (in *ReplayedResponse) DeepCopyInto(out *ReplayedResponse) {
 	*out = *in
-	if in.Headers != nil {
-		in, out := &in.Headers, &out.Headers
-		*out = make([]Header, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.LabelSelector != nil {
-		in, out := &in.LabelSelector, &out.LabelSelector
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
+	out.Target = in.Target
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Override.
-func (in *Override) DeepCopy() *Override {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplayedResponse.
+func (in *ReplayedResponse) DeepCopy() *ReplayedResponse {
 	if in == nil {
 		return nil
 	}
-	out := new(Override)
+	out := new(ReplayedResponse)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Parameter) DeepCopyInto(out *Parameter) {
+func (in *ResourceSelector) DeepCopyInto(out *ResourceSelector) {
 	*out = *in
-	if in.ValueFrom != nil {
-		in, out := &in.ValueFrom, &out.ValueFrom
-		*out = new(ValueFromSource)
+	in.LabelSelector.DeepCopyInto(&out.LabelSelector)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Parameter.
-func (in *Parameter) DeepCopy() *Parameter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSelector.
+func (in *ResourceSelector) DeepCopy() *ResourceSelector {
 	if in == nil {
 		return nil
 	}
-	out := new(Parameter)
+	out := new(ResourceSelector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Query) DeepCopyInto(out *Query) {
+func (in *Response) DeepCopyInto(out *Response) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.Target = in.Target
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Query.
-func (in *Query) DeepCopy() *Query {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Response.
+func (in *Response) DeepCopy() *Response {
 	if in == nil {
 		return nil
 	}
-	out := new(Query)
+	out := new(Response)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Query) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryBasedEvaluationConfig) DeepCopyInto(out *QueryBasedEvaluationConfig) {
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
 	*out = *in
-	if in.QueryRef != nil {
-		in, out := &in.QueryRef, &out.QueryRef
-		*out = new(QueryRef)
+	if in.InitialDelay != nil {
+		in, out := &in.InitialDelay, &out.InitialDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxDelay != nil {
+		in, out := &in.MaxDelay, &out.MaxDelay
+		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryBasedEvaluationConfig.
-func (in *QueryBasedEvaluationConfig) DeepCopy() *QueryBasedEvaluationConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryBasedEvaluationConfig)
+	out := new(RetryPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryList) DeepCopyInto(out *QueryList) {
+func (in *SelectorPolicy) DeepCopyInto(out *SelectorPolicy) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Query, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Sample != nil {
+		in, out := &in.Sample, &out.Sample
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Quorum != nil {
+		in, out := &in.Quorum, &out.Quorum
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryList.
-func (in *QueryList) DeepCopy() *QueryList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorPolicy.
+func (in *SelectorPolicy) DeepCopy() *SelectorPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryList)
+	out := new(SelectorPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *QueryList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryParameterReference) DeepCopyInto(out *QueryParameterReference) {
+func (in *ServiceReference) DeepCopyInto(out *ServiceReference) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryParameterReference.
-func (in *QueryParameterReference) DeepCopy() *QueryParameterReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceReference.
+func (in *ServiceReference) DeepCopy() *ServiceReference {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryParameterReference)
+	out := new(ServiceReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryRef) DeepCopyInto(out *QueryRef) {
+func (in *Session) DeepCopyInto(out *Session) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryRef.
-func (in *QueryRef) DeepCopy() *QueryRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Session.
+func (in *Session) DeepCopy() *Session {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryRef)
+	out := new(Session)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Session) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuerySelector) DeepCopyInto(out *QuerySelector) {
+func (in *SessionList) DeepCopyInto(out *SessionList) {
 	*out = *in
-	if in.MatchLabels != nil {
-		in, out := &in.MatchLabels, &out.MatchLabels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.MatchExpressions != nil {
-		in, out := &in.MatchExpressions, &out.MatchExpressions
-		*out = make([]v1.LabelSelectorRequirement, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Session, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuerySelector.
-func (in *QuerySelector) DeepCopy() *QuerySelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionList.
+func (in *SessionList) DeepCopy() *SessionList {
 	if in == nil {
 		return nil
 	}
-	out := new(QuerySelector)
+	out := new(SessionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SessionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuerySpec) DeepCopyInto(out *QuerySpec) {
+func (in *SessionSpec) DeepCopyInto(out *SessionSpec) {
 	*out = *in
-	in.Input.DeepCopyInto(&out.Input)
-	if in.Parameters != nil {
-		in, out := &in.Parameters, &out.Parameters
-		*out = make([]Parameter, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Targets != nil {
-		in, out := &in.Targets, &out.Targets
-		*out = make([]QueryTarget, len(*in))
-		copy(*out, *in)
-	}
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.Memory != nil {
 		in, out := &in.Memory, &out.Memory
 		*out = new(MemoryRef)
@@ -1507,162 +3196,126 @@ func (in *QuerySpec) DeepCopyInto(out *QuerySpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
-	if in.Timeout != nil {
-		in, out := &in.Timeout, &out.Timeout
-		*out = new(v1.Duration)
-		**out = **in
-	}
-	if in.Overrides != nil {
-		in, out := &in.Overrides, &out.Overrides
-		*out = make([]Override, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuerySpec.
-func (in *QuerySpec) DeepCopy() *QuerySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionSpec.
+func (in *SessionSpec) DeepCopy() *SessionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(QuerySpec)
+	out := new(SessionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryStatus) DeepCopyInto(out *QueryStatus) {
+func (in *SessionStatus) DeepCopyInto(out *SessionStatus) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Responses != nil {
-		in, out := &in.Responses, &out.Responses
-		*out = make([]Response, len(*in))
+	if in.Queries != nil {
+		in, out := &in.Queries, &out.Queries
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	out.TokenUsage = in.TokenUsage
-	if in.Duration != nil {
-		in, out := &in.Duration, &out.Duration
-		*out = new(v1.Duration)
-		**out = **in
+	if in.LastActivityTime != nil {
+		in, out := &in.LastActivityTime, &out.LastActivityTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryStatus.
-func (in *QueryStatus) DeepCopy() *QueryStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionStatus.
+func (in *SessionStatus) DeepCopy() *SessionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryStatus)
+	out := new(SessionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryTarget) DeepCopyInto(out *QueryTarget) {
+func (in *TargetTokenUsage) DeepCopyInto(out *TargetTokenUsage) {
 	*out = *in
+	out.Target = in.Target
+	out.TokenUsage = in.TokenUsage
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryTarget.
-func (in *QueryTarget) DeepCopy() *QueryTarget {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetTokenUsage.
+func (in *TargetTokenUsage) DeepCopy() *TargetTokenUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryTarget)
+	out := new(TargetTokenUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceSelector) DeepCopyInto(out *ResourceSelector) {
+func (in *Team) DeepCopyInto(out *Team) {
 	*out = *in
-	in.LabelSelector.DeepCopyInto(&out.LabelSelector)
-	if in.Namespaces != nil {
-		in, out := &in.Namespaces, &out.Namespaces
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.NamespaceSelector != nil {
-		in, out := &in.NamespaceSelector, &out.NamespaceSelector
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSelector.
-func (in *ResourceSelector) DeepCopy() *ResourceSelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Team.
+func (in *Team) DeepCopy() *Team {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceSelector)
+	out := new(Team)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Response) DeepCopyInto(out *Response) {
-	*out = *in
-	out.Target = in.Target
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Response.
-func (in *Response) DeepCopy() *Response {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Team) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(Response)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceReference) DeepCopyInto(out *ServiceReference) {
+func (in *TeamAggregatorSpec) DeepCopyInto(out *TeamAggregatorSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceReference.
-func (in *ServiceReference) DeepCopy() *ServiceReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamAggregatorSpec.
+func (in *TeamAggregatorSpec) DeepCopy() *TeamAggregatorSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceReference)
+	out := new(TeamAggregatorSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Team) DeepCopyInto(out *Team) {
+func (in *TeamFinalizerSpec) DeepCopyInto(out *TeamFinalizerSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	if in.ModelRef != nil {
+		in, out := &in.ModelRef, &out.ModelRef
+		*out = new(AgentModelRef)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Team.
-func (in *Team) DeepCopy() *Team {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamFinalizerSpec.
+func (in *TeamFinalizerSpec) DeepCopy() *TeamFinalizerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Team)
+	out := new(TeamFinalizerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Team) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TeamGraphEdge) DeepCopyInto(out *TeamGraphEdge) {
 	*out = *in
@@ -1733,6 +3386,11 @@ func (in *TeamList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TeamMember) DeepCopyInto(out *TeamMember) {
 	*out = *in
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = new(TeamMemberHistory)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamMember.
@@ -1745,6 +3403,41 @@ func (in *TeamMember) DeepCopy() *TeamMember {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamMemberHistory) DeepCopyInto(out *TeamMemberHistory) {
+	*out = *in
+	if in.ModelRef != nil {
+		in, out := &in.ModelRef, &out.ModelRef
+		*out = new(AgentModelRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamMemberHistory.
+func (in *TeamMemberHistory) DeepCopy() *TeamMemberHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamMemberHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamMemberStatus) DeepCopyInto(out *TeamMemberStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamMemberStatus.
+func (in *TeamMemberStatus) DeepCopy() *TeamMemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamMemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TeamSelectorSpec) DeepCopyInto(out *TeamSelectorSpec) {
 	*out = *in
@@ -1766,7 +3459,9 @@ func (in *TeamSpec) DeepCopyInto(out *TeamSpec) {
 	if in.Members != nil {
 		in, out := &in.Members, &out.Members
 		*out = make([]TeamMember, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.MaxTurns != nil {
 		in, out := &in.MaxTurns, &out.MaxTurns
@@ -1783,6 +3478,26 @@ func (in *TeamSpec) DeepCopyInto(out *TeamSpec) {
 		*out = new(TeamGraphSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MemberSelector != nil {
+		in, out := &in.MemberSelector, &out.MemberSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Aggregator != nil {
+		in, out := &in.Aggregator, &out.Aggregator
+		*out = new(TeamAggregatorSpec)
+		**out = **in
+	}
+	if in.OutputSchema != nil {
+		in, out := &in.OutputSchema, &out.OutputSchema
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Finalizer != nil {
+		in, out := &in.Finalizer, &out.Finalizer
+		*out = new(TeamFinalizerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamSpec.
@@ -1798,6 +3513,18 @@ func (in *TeamSpec) DeepCopy() *TeamSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TeamStatus) DeepCopyInto(out *TeamStatus) {
 	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]TeamMemberStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamStatus.
@@ -1825,6 +3552,36 @@ func (in *TokenUsage) DeepCopy() *TokenUsage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenUsageBreakdown) DeepCopyInto(out *TokenUsageBreakdown) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]TargetTokenUsage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Agents != nil {
+		in, out := &in.Agents, &out.Agents
+		*out = make([]NamedTokenUsage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]NamedTokenUsage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenUsageBreakdown.
+func (in *TokenUsageBreakdown) DeepCopy() *TokenUsageBreakdown {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenUsageBreakdown)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Tool) DeepCopyInto(out *Tool) {
 	*out = *in
@@ -1954,6 +3711,21 @@ func (in *ToolStatus) DeepCopy() *ToolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserContext) DeepCopyInto(out *UserContext) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserContext.
+func (in *UserContext) DeepCopy() *UserContext {
+	if in == nil {
+		return nil
+	}
+	out := new(UserContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueFromSource) DeepCopyInto(out *ValueFromSource) {
 	*out = *in
@@ -2008,3 +3780,18 @@ func (in *ValueSource) DeepCopy() *ValueSource {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WasmSpec) DeepCopyInto(out *WasmSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WasmSpec.
+func (in *WasmSpec) DeepCopy() *WasmSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WasmSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -4,6 +4,8 @@ package v1prealpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 )
 
 // ExecutionEngineSpec defines the configuration for an execution engine that can run agent workloads.
@@ -20,6 +22,10 @@ type ExecutionEngineSpec struct {
 	// +kubebuilder:validation:Required
 	Address ValueSource `json:"address"`
 
+	// TLS authenticates ark to the execution engine with a client certificate. Requires an https address.
+	// +kubebuilder:validation:Optional
+	TLS *arkv1alpha1.ClientTLS `json:"tls,omitempty"`
+
 	// Description provides human-readable information about this execution engine
 	Description string `json:"description,omitempty"`
 }
@@ -10,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"mckinsey.com/ark/api/v1alpha1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -82,6 +83,11 @@ func (in *A2AServerSpec) DeepCopyInto(out *A2AServerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(v1alpha1.ClientTLS)
+		**out = **in
+	}
 	if in.PollInterval != nil {
 		in, out := &in.PollInterval, &out.PollInterval
 		*out = new(v1.Duration)
@@ -184,6 +190,11 @@ func (in *ExecutionEngineList) DeepCopyObject() runtime.Object {
 func (in *ExecutionEngineSpec) DeepCopyInto(out *ExecutionEngineSpec) {
 	*out = *in
 	in.Address.DeepCopyInto(&out.Address)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(v1alpha1.ClientTLS)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionEngineSpec.
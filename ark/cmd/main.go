@@ -8,17 +8,21 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/tools/record"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -27,8 +31,12 @@ import (
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
+	"mckinsey.com/ark/internal/common"
 	"mckinsey.com/ark/internal/controller"
+	"mckinsey.com/ark/internal/executor"
+	"mckinsey.com/ark/internal/genai"
 	telemetryconfig "mckinsey.com/ark/internal/telemetry/config"
+	"mckinsey.com/ark/internal/telemetry/langfuse"
 	webhookv1 "mckinsey.com/ark/internal/webhook/v1"
 	webhookv1prealpha1 "mckinsey.com/ark/internal/webhook/v1prealpha1"
 	// +kubebuilder:scaffold:imports
@@ -46,6 +54,7 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 
 	utilruntime.Must(arkv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(arkv1prealpha1.AddToScheme(scheme))
@@ -60,6 +69,20 @@ type config struct {
 	probeAddr                                        string
 	secureMetrics                                    bool
 	enableHTTP2                                      bool
+	maxConcurrentQueriesPerNamespace                 int
+	impersonatedClientTTL                            time.Duration
+	orphanedQueryPolicy                              string
+	queryShards, queryShardIndex                     int
+	queryShard                                       *controller.QueryShard
+	queryExecutorImage                               string
+	queryExecutor                                    bool
+	queryExecutorPort                                int
+	queryExecutorCSIDriver                           string
+	queryExecutorControllerID                        string
+	maxTeamNestingDepth                              int
+	mcpStdioCommands                                 string
+	wasmRuntime                                      string
+	workloadIdentityDir                              string
 }
 
 func main() {
@@ -72,6 +95,18 @@ func main() {
 	}
 
 	setupLog.Info("starting ark controller", "version", Version, "commit", GitCommit)
+	common.Version = Version
+
+	if result.orphanedQueryPolicy != controller.OrphanedQueryFail && result.orphanedQueryPolicy != controller.OrphanedQueryResume {
+		setupLog.Error(fmt.Errorf("invalid orphaned query policy %q", result.orphanedQueryPolicy), "--orphaned-query-policy must be fail or resume")
+		os.Exit(1)
+	}
+
+	result.queryShard = resolveQueryShard(result.queryShards, result.queryShardIndex)
+
+	genai.SetMCPStdioCommands(splitCommaList(result.mcpStdioCommands))
+	genai.SetWasmRuntime(strings.Fields(result.wasmRuntime))
+	common.SetWorkloadIdentityDir(result.workloadIdentityDir)
 
 	// Initialize telemetry provider
 	telemetryProvider := telemetryconfig.NewProvider()
@@ -81,12 +116,44 @@ func main() {
 		}
 	}()
 
+	if result.queryExecutor {
+		runQueryExecutor(result.config, telemetryProvider)
+		return
+	}
+
 	mgr, metricsCertWatcher, webhookCertWatcher := setupManager(result.config)
-	setupControllers(mgr, telemetryProvider)
-	setupWebhooks(mgr)
+	setupControllers(mgr, telemetryProvider, result.config)
+	setupWebhooks(mgr, result.config)
 	startManager(mgr, metricsCertWatcher, webhookCertWatcher)
 }
 
+// resolveQueryShard returns the shard of queries this replica executes, or nil when query execution is not sharded
+func resolveQueryShard(shards, index int) *controller.QueryShard {
+	if shards < 1 {
+		setupLog.Error(fmt.Errorf("invalid query shard count %d", shards), "--query-shards must be at least 1")
+		os.Exit(1)
+	}
+	if shards == 1 {
+		return nil
+	}
+	if index < 0 {
+		hostname, err := os.Hostname()
+		if err == nil {
+			index, err = controller.QueryShardIndexFromHostname(hostname)
+		}
+		if err != nil {
+			setupLog.Error(err, "unable to determine the query shard, set --query-shard-index")
+			os.Exit(1)
+		}
+	}
+	if index >= shards {
+		setupLog.Error(fmt.Errorf("invalid query shard index %d", index), "--query-shard-index must be less than --query-shards", "shards", shards)
+		os.Exit(1)
+	}
+	setupLog.Info("executing a shard of queries", "shard", index, "shards", shards)
+	return &controller.QueryShard{Index: index, Count: shards}
+}
+
 func parseFlags() struct {
 	config
 	zapOpts     zap.Options
@@ -111,6 +178,36 @@ func parseFlags() struct {
 	flag.StringVar(&cfg.metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&cfg.enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.IntVar(&cfg.maxConcurrentQueriesPerNamespace, "max-concurrent-queries-per-namespace", 0,
+		"The maximum number of queries executing at once in a namespace. Further queries wait, ordered by priority. 0 means no limit.")
+	flag.DurationVar(&cfg.impersonatedClientTTL, "impersonated-client-ttl", controller.DefaultImpersonatedClientTTL,
+		"How long the client impersonating a query's service account is reused by further queries before it is rebuilt.")
+	flag.StringVar(&cfg.orphanedQueryPolicy, "orphaned-query-policy", controller.OrphanedQueryFail,
+		"What becomes of the queries running when the controller restarted: 'fail' marks them as errored, 'resume' runs them again.")
+	flag.IntVar(&cfg.queryShards, "query-shards", 1,
+		"The number of controller replicas that execute queries, each its own shard of them. 1 leaves every query to the leader.")
+	flag.IntVar(&cfg.queryShardIndex, "query-shard-index", -1,
+		"The shard of queries this replica executes, from 0 to --query-shards - 1. -1 takes the ordinal of the StatefulSet pod from the hostname.")
+	flag.StringVar(&cfg.queryExecutorImage, "query-executor-image", "",
+		"The image of the executor pods the queries of namespaces labelled ark.mckinsey.com/query-executor=enabled are dispatched to. Empty executes every query in the controller.")
+	flag.BoolVar(&cfg.queryExecutor, "query-executor", false,
+		"Run as an executor pod, executing the queries the controller dispatches to it rather than reconciling resources.")
+	flag.IntVar(&cfg.queryExecutorPort, "query-executor-port", executor.DefaultPort, "The port executor pods serve dispatched queries on.")
+	flag.StringVar(&cfg.queryExecutorCSIDriver, "query-executor-csi-driver", "spiffe.csi.cert-manager.io",
+		"The CSI driver mounting the workload certificate of executor pods, issued for the SPIFFE ID of their service account.")
+	flag.StringVar(&cfg.queryExecutorControllerID, "query-executor-controller-id", "",
+		"The SPIFFE ID of the controller, the only caller an executor pod accepts. Set by the controller on the executor pods it creates.")
+	flag.IntVar(&cfg.maxTeamNestingDepth, "max-team-nesting-depth", webhookv1.DefaultTeamMaxNestingDepth,
+		"The maximum number of levels of teams that a team may nest. Deeper teams are rejected by the team webhook.")
+	flag.StringVar(&cfg.mcpStdioCommands, "mcp-stdio-commands", "",
+		"Comma-separated executables that MCPServers with the stdio transport may run in the controller container. "+
+			"Empty disables the stdio transport.")
+	flag.StringVar(&cfg.wasmRuntime, "wasm-runtime", "",
+		"The WASI runtime command running the modules of wasm tools, to which the path of the module is appended, "+
+			"e.g. 'wasmtime run'. Empty disables wasm tools.")
+	flag.StringVar(&cfg.workloadIdentityDir, "workload-identity-dir", "",
+		"The directory with the workload certificate of the controller in tls.crt, tls.key and ca.crt, such as mounted by "+
+			"the SPIFFE CSI driver, presented to servers whose tls sets workloadIdentity. Empty disables workload identity.")
 	flag.BoolVar(&showVersion, "version", false, "Show version information and exit")
 
 	zapOpts := zap.Options{Development: true}
@@ -124,6 +221,17 @@ func parseFlags() struct {
 	}{cfg, zapOpts, showVersion}
 }
 
+// splitCommaList splits a comma-separated flag value, dropping empty entries
+func splitCommaList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func setupManager(cfg config) (ctrl.Manager, *certwatcher.CertWatcher, *certwatcher.CertWatcher) {
 	tlsOpts := setupTLS(cfg.enableHTTP2)
 	webhookServer, webhookCertWatcher := setupWebhookServer(cfg, tlsOpts)
@@ -225,17 +333,24 @@ func setupMetricsServer(cfg config, baseTLSOpts []func(*tls.Config)) (metricsser
 	return metricsServerOptions, metricsCertWatcher
 }
 
-func setupControllers(mgr ctrl.Manager, telemetryProvider *telemetryconfig.Provider) {
+func setupControllers(mgr ctrl.Manager, telemetryProvider *telemetryconfig.Provider, cfg config) {
+	executors := queryExecutors(cfg)
 	controllers := []struct {
 		name       string
 		reconciler interface{ SetupWithManager(ctrl.Manager) error }
 	}{
 		{"Agent", &controller.AgentReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme(), Recorder: mgr.GetEventRecorderFor("agent-controller")}},
 		{"Query", &controller.QueryReconciler{
-			Client:    mgr.GetClient(),
-			Scheme:    mgr.GetScheme(),
-			Recorder:  mgr.GetEventRecorderFor("query-controller"),
-			Telemetry: telemetryProvider,
+			Client:                           mgr.GetClient(),
+			Scheme:                           mgr.GetScheme(),
+			Recorder:                         mgr.GetEventRecorderFor("query-controller"),
+			Telemetry:                        telemetryProvider,
+			RestConfig:                       mgr.GetConfig(),
+			MaxConcurrentQueriesPerNamespace: cfg.maxConcurrentQueriesPerNamespace,
+			ImpersonatedClientTTL:            cfg.impersonatedClientTTL,
+			OrphanedQueryPolicy:              cfg.orphanedQueryPolicy,
+			Shard:                            cfg.queryShard,
+			Executors:                        executors,
 		}},
 		{"Tool", &controller.ToolReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme()}},
 		{"Team", &controller.TeamReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme()}},
@@ -250,7 +365,24 @@ func setupControllers(mgr ctrl.Manager, telemetryProvider *telemetryconfig.Provi
 		{"Memory", &controller.MemoryReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme(), Recorder: mgr.GetEventRecorderFor("memory-controller")}},
 		{"ExecutionEngine", &controller.ExecutionEngineReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme(), Recorder: mgr.GetEventRecorderFor("executionengine-controller")}},
 		{"Evaluator", &controller.EvaluatorReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme()}},
-		{"Evaluation", &controller.EvaluationReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme(), Recorder: mgr.GetEventRecorderFor("evaluation-controller")}},
+		{"Evaluation", &controller.EvaluationReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme(), Recorder: mgr.GetEventRecorderFor("evaluation-controller"), Telemetry: telemetryProvider}},
+		{"CronQuery", &controller.CronQueryReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme(), Recorder: mgr.GetEventRecorderFor("cronquery-controller")}},
+		{"AgentTest", &controller.AgentTestReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme(), Recorder: mgr.GetEventRecorderFor("agenttest-controller")}},
+		{"Feedback", &controller.FeedbackReconciler{
+			Client:    mgr.GetClient(),
+			Scheme:    mgr.GetScheme(),
+			Recorder:  mgr.GetEventRecorderFor("feedback-controller"),
+			Telemetry: telemetryProvider,
+			Scores:    langfuse.NewScoreClientFromEnv(),
+		}},
+		{"Session", &controller.SessionReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme(), Recorder: mgr.GetEventRecorderFor("session-controller")}},
+		{"NamespaceOffboarding", &controller.NamespaceOffboardingReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme(), Recorder: mgr.GetEventRecorderFor("namespace-offboarding-controller")}},
+		{"ArkInstallStatus", &controller.ArkInstallStatusReconciler{
+			Client:            mgr.GetClient(),
+			Scheme:            mgr.GetScheme(),
+			WebhookCertFile:   webhookCertFile(cfg),
+			TelemetryEndpoint: os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		}},
 	}
 
 	for _, reconciler := range controllers {
@@ -259,9 +391,28 @@ func setupControllers(mgr ctrl.Manager, telemetryProvider *telemetryconfig.Provi
 			os.Exit(1)
 		}
 	}
+
+	if executors != nil {
+		if err := (&controller.ExecutorNamespaceReconciler{Client: mgr.GetClient(), Executors: executors}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ExecutorNamespace")
+			os.Exit(1)
+		}
+	}
 }
 
-func setupWebhooks(mgr ctrl.Manager) {
+// webhookCertFile returns the certificate the webhook server is served with, empty when webhooks are disabled
+func webhookCertFile(cfg config) string {
+	if os.Getenv("ENABLE_WEBHOOKS") == "false" {
+		return ""
+	}
+	if cfg.webhookCertPath == "" {
+		// The webhook server's default certificate
+		return filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs", "tls.crt")
+	}
+	return filepath.Join(cfg.webhookCertPath, cfg.webhookCertName)
+}
+
+func setupWebhooks(mgr ctrl.Manager, cfg config) {
 	if os.Getenv("ENABLE_WEBHOOKS") == "false" {
 		return
 	}
@@ -270,7 +421,9 @@ func setupWebhooks(mgr ctrl.Manager) {
 		name  string
 		setup func(ctrl.Manager) error
 	}{
-		{"Team", webhookv1.SetupTeamWebhookWithManager},
+		{"Team", func(mgr ctrl.Manager) error {
+			return webhookv1.SetupTeamWebhookWithManager(mgr, cfg.maxTeamNestingDepth)
+		}},
 		{"Agent", webhookv1.SetupAgentWebhookWithManager},
 		{"Query", webhookv1.SetupQueryWebhookWithManager},
 		{"Tool", webhookv1.SetupToolWebhookWithManager},
@@ -278,6 +431,7 @@ func setupWebhooks(mgr ctrl.Manager) {
 		{"MCPServer", webhookv1.SetupMCPServerWebhookWithManager},
 		{"Evaluator", webhookv1.SetupEvaluatorWebhookWithManager},
 		{"Evaluation", webhookv1.SetupEvaluationWebhookWithManager},
+		{"Session", webhookv1.SetupSessionWebhookWithManager},
 		{"A2AServer", webhookv1prealpha1.SetupA2AServerWebhookWithManager},
 		{"ExecutionEngine", webhookv1prealpha1.SetupExecutionEngineWebhookWithManager},
 	}
@@ -290,6 +444,84 @@ func setupWebhooks(mgr ctrl.Manager) {
 	}
 }
 
+// queryExecutors returns the executor pods queries are dispatched to, or nil when every query executes in the
+// controller. The controller authenticates to executor pods with its workload identity, so it must have one.
+func queryExecutors(cfg config) *controller.QueryExecutors {
+	if cfg.queryExecutorImage == "" {
+		return nil
+	}
+	if cfg.workloadIdentityDir == "" {
+		setupLog.Error(fmt.Errorf("--query-executor-image requires --workload-identity-dir"), "unable to set up query executors")
+		os.Exit(1)
+	}
+	spiffeID, err := common.WorkloadSPIFFEID(cfg.workloadIdentityDir)
+	if err != nil {
+		setupLog.Error(err, "unable to set up query executors")
+		os.Exit(1)
+	}
+	return &controller.QueryExecutors{
+		Image:            cfg.queryExecutorImage,
+		Port:             cfg.queryExecutorPort,
+		WorkloadIdentity: cfg.workloadIdentityDir,
+		SPIFFEID:         spiffeID,
+		CSIDriver:        cfg.queryExecutorCSIDriver,
+	}
+}
+
+// runQueryExecutor runs the process as the executor pod of a namespace and service account, which executes the
+// queries the controller dispatches to it with its own identity
+func runQueryExecutor(cfg config, telemetryProvider *telemetryconfig.Provider) {
+	namespace, serviceAccount := os.Getenv("POD_NAMESPACE"), os.Getenv("POD_SERVICE_ACCOUNT")
+	if namespace == "" || serviceAccount == "" {
+		setupLog.Error(fmt.Errorf("POD_NAMESPACE and POD_SERVICE_ACCOUNT must be set"), "unable to start query executor")
+		os.Exit(1)
+	}
+	if cfg.workloadIdentityDir == "" || cfg.queryExecutorControllerID == "" {
+		setupLog.Error(fmt.Errorf("--workload-identity-dir and --query-executor-controller-id must be set"), "unable to start query executor")
+		os.Exit(1)
+	}
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                metricsserver.Options{BindAddress: "0"},
+		HealthProbeBindAddress: cfg.probeAddr,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to start manager")
+		os.Exit(1)
+	}
+
+	// The service account may only read its own namespace, so resources are read directly rather than watched
+	executorClient, err := client.New(mgr.GetConfig(), client.Options{Scheme: scheme, Mapper: mgr.GetRESTMapper()})
+	if err != nil {
+		setupLog.Error(err, "unable to create client")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(&controller.QueryExecutor{
+		Reconciler: &controller.QueryReconciler{
+			Client:                           executorClient,
+			Scheme:                           scheme,
+			Recorder:                         mgr.GetEventRecorderFor("query-executor"),
+			Telemetry:                        telemetryProvider,
+			MaxConcurrentQueriesPerNamespace: cfg.maxConcurrentQueriesPerNamespace,
+			OrphanedQueryPolicy:              cfg.orphanedQueryPolicy,
+			RunAsServiceAccount:              true,
+		},
+		Namespace:        namespace,
+		ServiceAccount:   serviceAccount,
+		Port:             cfg.queryExecutorPort,
+		WorkloadIdentity: cfg.workloadIdentityDir,
+		ControllerID:     cfg.queryExecutorControllerID,
+	}); err != nil {
+		setupLog.Error(err, "unable to create query executor")
+		os.Exit(1)
+	}
+
+	setupLog.Info("starting query executor", "namespace", namespace, "serviceAccount", serviceAccount)
+	startManager(mgr, nil, nil)
+}
+
 func startManager(mgr ctrl.Manager, metricsCertWatcher, webhookCertWatcher *certwatcher.CertWatcher) {
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
@@ -14,9 +14,10 @@ const (
 
 // A2A annotations
 const (
-	A2AServerName    = ARKPrefix + "a2a-server-name"
-	A2AServerAddress = ARKPrefix + "a2a-server-address"
-	A2AServerSkills  = ARKPrefix + "a2a-server-skills"
+	A2AServerName      = ARKPrefix + "a2a-server-name"
+	A2AServerAddress   = ARKPrefix + "a2a-server-address"
+	A2AServerSkills    = ARKPrefix + "a2a-server-skills"
+	A2AServerStreaming = ARKPrefix + "a2a-server-streaming"
 )
 
 // MCP annotations
@@ -46,8 +47,47 @@ const (
 	LocalhostGatewayPort = ARKPrefix + "localhost-gateway-port"
 )
 
+// Namespace offboarding annotations
+const (
+	OffboardedAt = ARKPrefix + "offboarded-at"
+)
+
 // Streaming annotations
 const (
 	StreamingEnabled = ARKPrefix + "streaming-enabled"
 	StreamingURL     = ARKPrefix + "streaming-url"
 )
+
+// Prompt context annotations
+const (
+	Timezone = ARKPrefix + "timezone"
+	Locale   = ARKPrefix + "locale"
+)
+
+// CronQuery annotations
+const (
+	ScheduledAt = ARKPrefix + "scheduled-at"
+)
+
+// Query target annotations
+const (
+	TargetWeight = ARKPrefix + "target-weight"
+)
+
+// Profile memory labels and annotations
+const (
+	Profile       = ARKPrefix + "profile"
+	ProfileUserID = ARKPrefix + "user-id"
+)
+
+// Query soft delete annotations
+const (
+	SoftDelete   = ARKPrefix + "soft-delete"
+	RestoredFrom = ARKPrefix + "restored-from"
+	DeletedAt    = ARKPrefix + "deleted-at"
+)
+
+// Dead-letter annotations
+const (
+	DeadLetterPublishedAt = ARKPrefix + "dead-letter-published-at"
+)
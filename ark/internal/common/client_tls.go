@@ -0,0 +1,231 @@
+/* Copyright 2025. McKinsey & Company */
+
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// Files of a client certificate, in a Secret or in the workload identity directory
+const (
+	clientTLSCertificateKey = "tls.crt"
+	clientTLSPrivateKeyKey  = "tls.key"
+	clientTLSCAKey          = "ca.crt"
+)
+
+// workloadIdentityDir is the directory a CSI driver mounts the workload certificate of the controller in
+var workloadIdentityDir string
+
+// SetWorkloadIdentityDir sets the directory of the workload certificate that clients with workload identity present
+func SetWorkloadIdentityDir(dir string) {
+	workloadIdentityDir = dir
+}
+
+// ClientTLSConfig is the resolved mutual TLS configuration of a client. The certificate of a Secret is held in
+// the configuration, while the workload identity is read from its directory for each connection.
+type ClientTLSConfig struct {
+	Certificate      []byte
+	PrivateKey       []byte
+	CA               []byte
+	WorkloadIdentity string
+	ServerName       string
+	ServerSPIFFEID   string
+}
+
+// ResolveClientTLS resolves the client certificate of a resource in the namespace, or returns nil when the
+// resource does not use mutual TLS
+func ResolveClientTLS(ctx context.Context, k8sClient client.Client, spec *arkv1alpha1.ClientTLS, namespace string) (*ClientTLSConfig, error) {
+	if spec == nil {
+		return nil, nil
+	}
+	config := &ClientTLSConfig{ServerName: spec.ServerName, ServerSPIFFEID: spec.ServerSPIFFEID}
+
+	if spec.WorkloadIdentity {
+		if workloadIdentityDir == "" {
+			return nil, errors.New("workload identity is not available, the controller has no --workload-identity-dir")
+		}
+		config.WorkloadIdentity = workloadIdentityDir
+		return config, nil
+	}
+
+	var secret corev1.Secret
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: spec.SecretName, Namespace: namespace}, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get client certificate secret %s: %w", spec.SecretName, err)
+	}
+	config.Certificate = secret.Data[clientTLSCertificateKey]
+	config.PrivateKey = secret.Data[clientTLSPrivateKeyKey]
+	config.CA = secret.Data[clientTLSCAKey]
+	if _, err := tls.X509KeyPair(config.Certificate, config.PrivateKey); err != nil {
+		return nil, fmt.Errorf("invalid client certificate in secret %s: %w", spec.SecretName, err)
+	}
+	return config, nil
+}
+
+// NewClientTLSTransport returns a transport that presents the client certificate, or nil for the default
+// transport when the configuration is nil
+func NewClientTLSTransport(config *ClientTLSConfig) http.RoundTripper {
+	if config == nil {
+		return nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config.TLSConfig()
+	return transport
+}
+
+// TLSConfig returns the configuration of TLS connections presenting the client certificate. The server certificate
+// is verified by verifyServer, which checks the SPIFFE ID of the server instead of its name when one is set.
+func (c *ClientTLSConfig) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: c.ServerName,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return c.clientCertificate()
+		},
+		// The default verification is replaced by verifyServer, which still verifies the chain of the certificate
+		InsecureSkipVerify: true, //nolint:gosec
+		VerifyConnection:   c.verifyServer,
+	}
+}
+
+func (c *ClientTLSConfig) clientCertificate() (*tls.Certificate, error) {
+	if c.WorkloadIdentity == "" {
+		certificate, err := tls.X509KeyPair(c.Certificate, c.PrivateKey)
+		return &certificate, err
+	}
+	certificate, err := tls.LoadX509KeyPair(filepath.Join(c.WorkloadIdentity, clientTLSCertificateKey), filepath.Join(c.WorkloadIdentity, clientTLSPrivateKeyKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workload identity: %w", err)
+	}
+	return &certificate, nil
+}
+
+// rootCAs returns the CA certificates trusted for the server, or nil to trust the system's
+func (c *ClientTLSConfig) rootCAs() (*x509.CertPool, error) {
+	ca := c.CA
+	if c.WorkloadIdentity != "" {
+		var err error
+		ca, err = os.ReadFile(filepath.Join(c.WorkloadIdentity, clientTLSCAKey))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load workload identity CA: %w", err)
+		}
+	}
+	if len(ca) == 0 {
+		return nil, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("no CA certificates found")
+	}
+	return pool, nil
+}
+
+func (c *ClientTLSConfig) verifyServer(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("server presented no certificate")
+	}
+	roots, err := c.rootCAs()
+	if err != nil {
+		return err
+	}
+	options := x509.VerifyOptions{Roots: roots, Intermediates: x509.NewCertPool()}
+	for _, intermediate := range state.PeerCertificates[1:] {
+		options.Intermediates.AddCert(intermediate)
+	}
+	if c.ServerSPIFFEID == "" {
+		options.DNSName = state.ServerName
+	}
+
+	server := state.PeerCertificates[0]
+	if _, err := server.Verify(options); err != nil {
+		return err
+	}
+	if c.ServerSPIFFEID != "" && !HasSPIFFEID(server, c.ServerSPIFFEID) {
+		return fmt.Errorf("server certificate does not hold SPIFFE ID %s", c.ServerSPIFFEID)
+	}
+	return nil
+}
+
+// NewWorkloadServerTLSConfig returns the configuration of a server presenting the workload certificate in the
+// directory. Clients must present a certificate issued by the trust bundle of the directory; the server authorizes
+// the SPIFFE ID of the client itself, as HasSPIFFEID does.
+func NewWorkloadServerTLSConfig(dir string) *tls.Config {
+	identity := &ClientTLSConfig{WorkloadIdentity: dir}
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return identity.clientCertificate()
+		},
+		// The chain of the client certificate is verified by verifyClient against the rotating trust bundle
+		ClientAuth:       tls.RequireAnyClientCert,
+		VerifyConnection: identity.verifyClient,
+	}
+}
+
+func (c *ClientTLSConfig) verifyClient(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("client presented no certificate")
+	}
+	roots, err := c.rootCAs()
+	if err != nil {
+		return err
+	}
+	if roots == nil {
+		return errors.New("workload identity has no trust bundle to verify clients with")
+	}
+	options := x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, intermediate := range state.PeerCertificates[1:] {
+		options.Intermediates.AddCert(intermediate)
+	}
+	_, err = state.PeerCertificates[0].Verify(options)
+	return err
+}
+
+// HasSPIFFEID reports whether the certificate holds the SPIFFE ID
+func HasSPIFFEID(certificate *x509.Certificate, spiffeID string) bool {
+	return slices.ContainsFunc(certificate.URIs, func(uri *url.URL) bool { return uri.String() == spiffeID })
+}
+
+// WorkloadSPIFFEID returns the SPIFFE ID of the workload certificate in the directory
+func WorkloadSPIFFEID(dir string) (*url.URL, error) {
+	data, err := os.ReadFile(filepath.Join(dir, clientTLSCertificateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workload identity: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("workload identity holds no certificate")
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workload identity: %w", err)
+	}
+	for _, uri := range certificate.URIs {
+		if uri.Scheme == "spiffe" {
+			return uri, nil
+		}
+	}
+	return nil, errors.New("workload identity holds no SPIFFE ID")
+}
@@ -0,0 +1,231 @@
+/* Copyright 2025. McKinsey & Company */
+
+package common
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// testCA issues certificates for the tests, such as SPIFFE X.509 SVIDs of workloads
+type testCA struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	pem         []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, _ := x509.ParseCertificate(der)
+	return &testCA{certificate: certificate, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns the PEM certificate and key of a workload with the SPIFFE ID, valid for 127.0.0.1
+func (ca *testCA) issue(t *testing.T, spiffeID string) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _ := url.Parse(spiffeID)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		URIs:         []*url.URL{id},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.certificate, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// mutualTLSServer requires clients to present a certificate of the CA
+func mutualTLSServer(t *testing.T, ca *testCA, spiffeID string) *httptest.Server {
+	t.Helper()
+	certificatePEM, keyPEM := ca.issue(t, spiffeID)
+	certificate, err := tls.X509KeyPair(certificatePEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.certificate)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{certificate}, ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func clientTLSGet(config *ClientTLSConfig, address string) error {
+	httpClient := &http.Client{Transport: NewClientTLSTransport(config)}
+	resp, err := httpClient.Get(address)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestResolveClientTLSFromSecret(t *testing.T) {
+	ca := newTestCA(t)
+	server := mutualTLSServer(t, ca, "spiffe://example.org/ns/tools/sa/mcp")
+	certificate, key := ca.issue(t, "spiffe://example.org/ns/ark-system/sa/ark-controller")
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "mcp-client", Namespace: "default"},
+		Data:       map[string][]byte{"tls.crt": certificate, "tls.key": key, "ca.crt": ca.pem},
+	}).Build()
+
+	if config, err := ResolveClientTLS(t.Context(), k8sClient, nil, "default"); config != nil || err != nil {
+		t.Fatalf("expected no configuration without tls, got %v, %v", config, err)
+	}
+
+	spec := &arkv1alpha1.ClientTLS{SecretName: "mcp-client", ServerSPIFFEID: "spiffe://example.org/ns/tools/sa/mcp"}
+	config, err := ResolveClientTLS(t.Context(), k8sClient, spec, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := clientTLSGet(config, server.URL); err != nil {
+		t.Fatalf("expected the server to accept the client certificate: %v", err)
+	}
+
+	config.ServerSPIFFEID = "spiffe://example.org/ns/tools/sa/other"
+	if err := clientTLSGet(config, server.URL); err == nil || !strings.Contains(err.Error(), "does not hold SPIFFE ID") {
+		t.Fatalf("expected a server with another SPIFFE ID to be rejected, got %v", err)
+	}
+
+	config.ServerSPIFFEID = ""
+	if err := clientTLSGet(config, server.URL); err != nil {
+		t.Fatalf("expected the server name to be verified without a SPIFFE ID: %v", err)
+	}
+
+	if _, err := ResolveClientTLS(t.Context(), k8sClient, &arkv1alpha1.ClientTLS{SecretName: "missing"}, "default"); err == nil {
+		t.Fatal("expected an error for a missing secret")
+	}
+}
+
+func TestResolveClientTLSWorkloadIdentity(t *testing.T) {
+	ca := newTestCA(t)
+	server := mutualTLSServer(t, ca, "spiffe://example.org/ns/tools/sa/engine")
+	spec := &arkv1alpha1.ClientTLS{WorkloadIdentity: true, ServerSPIFFEID: "spiffe://example.org/ns/tools/sa/engine"}
+
+	if _, err := ResolveClientTLS(t.Context(), nil, spec, "default"); err == nil {
+		t.Fatal("expected an error when the controller has no workload identity")
+	}
+
+	dir := t.TempDir()
+	SetWorkloadIdentityDir(dir)
+	t.Cleanup(func() { SetWorkloadIdentityDir("") })
+	config, err := ResolveClientTLS(t.Context(), nil, spec, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The certificate is read when connecting, so it is rotated without resolving the configuration again
+	certificate, key := ca.issue(t, "spiffe://example.org/ns/ark-system/sa/ark-controller")
+	for name, data := range map[string][]byte{"tls.crt": certificate, "tls.key": key, "ca.crt": ca.pem} {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := clientTLSGet(config, server.URL); err != nil {
+		t.Fatalf("expected the server to accept the workload identity: %v", err)
+	}
+}
+
+func TestWorkloadServerTLSConfig(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certificate, key := ca.issue(t, "spiffe://example.org/ns/tenant-1/sa/ark-executor")
+	for name, data := range map[string][]byte{"tls.crt": certificate, "tls.key": key, "ca.crt": ca.pem} {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	id, err := WorkloadSPIFFEID(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.String() != "spiffe://example.org/ns/tenant-1/sa/ark-executor" {
+		t.Fatalf("expected the SPIFFE ID of the workload certificate, got %s", id)
+	}
+
+	var callers []string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if HasSPIFFEID(r.TLS.PeerCertificates[0], "spiffe://example.org/ns/ark-system/sa/ark-controller") {
+			callers = append(callers, "controller")
+		}
+	}))
+	server.TLS = NewWorkloadServerTLSConfig(dir)
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	clientCertificate, clientKey := ca.issue(t, "spiffe://example.org/ns/ark-system/sa/ark-controller")
+	config := &ClientTLSConfig{
+		Certificate:    clientCertificate,
+		PrivateKey:     clientKey,
+		CA:             ca.pem,
+		ServerName:     "ark-executor.tenant-1.svc",
+		ServerSPIFFEID: "spiffe://example.org/ns/tenant-1/sa/ark-executor",
+	}
+	if err := clientTLSGet(config, server.URL); err != nil {
+		t.Fatalf("expected the server to accept a client of its trust bundle: %v", err)
+	}
+	if len(callers) != 1 {
+		t.Fatalf("expected the SPIFFE ID of the client to reach the server, got %v", callers)
+	}
+
+	otherCA := newTestCA(t)
+	config.Certificate, config.PrivateKey = otherCA.issue(t, "spiffe://example.org/ns/ark-system/sa/ark-controller")
+	if err := clientTLSGet(config, server.URL); err == nil {
+		t.Fatal("expected a client of another trust bundle to be rejected")
+	}
+}
@@ -0,0 +1,86 @@
+/* Copyright 2025. McKinsey & Company */
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ImpersonationNotPermittedReason is used for conditions and events when the controller cannot impersonate a query's service account
+const ImpersonationNotPermittedReason = "ImpersonationNotPermitted"
+
+// ImpersonationNotPermittedError indicates that the controller's identity lacks the impersonate permission for a service account
+type ImpersonationNotPermittedError struct {
+	Namespace      string
+	ServiceAccount string
+	Reason         string
+}
+
+func (e *ImpersonationNotPermittedError) Error() string {
+	msg := fmt.Sprintf("impersonation not permitted for service account %s/%s; enable rbac.impersonation.enabled in the ark chart", e.Namespace, e.ServiceAccount)
+	if e.Reason != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, e.Reason)
+	}
+	return msg
+}
+
+// ImpersonationUserName returns the username used to impersonate a service account
+func ImpersonationUserName(namespace, serviceAccount string) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccount)
+}
+
+// CheckImpersonationPermitted runs a SelfSubjectAccessReview to verify that the caller can impersonate
+// the given service account. It returns an ImpersonationNotPermittedError when access is denied.
+func CheckImpersonationPermitted(ctx context.Context, k8sClient client.Client, namespace, serviceAccount string) error {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "impersonate",
+				Resource:  "serviceaccounts",
+				Name:      serviceAccount,
+			},
+		},
+	}
+
+	if err := k8sClient.Create(ctx, review); err != nil {
+		return fmt.Errorf("failed to check impersonation permission for service account %s/%s: %w", namespace, serviceAccount, err)
+	}
+
+	if !review.Status.Allowed {
+		return &ImpersonationNotPermittedError{
+			Namespace:      namespace,
+			ServiceAccount: serviceAccount,
+			Reason:         review.Status.Reason,
+		}
+	}
+
+	return nil
+}
+
+// ServiceAccountGroups returns the groups the API server assigns to a service account's identity
+func ServiceAccountGroups(namespace string) []string {
+	return []string{"system:serviceaccounts", fmt.Sprintf("system:serviceaccounts:%s", namespace), "system:authenticated"}
+}
+
+// CheckSubjectAccess runs a SubjectAccessReview to verify that the given user and groups may perform
+// the resource action. It returns whether access is allowed along with the authorizer's reason.
+func CheckSubjectAccess(ctx context.Context, k8sClient client.Client, user string, groups []string, attributes authorizationv1.ResourceAttributes) (bool, string, error) {
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               user,
+			Groups:             groups,
+			ResourceAttributes: &attributes,
+		},
+	}
+
+	if err := k8sClient.Create(ctx, review); err != nil {
+		return false, "", fmt.Errorf("failed to check %s access to %s %s/%s for %s: %w", attributes.Verb, attributes.Resource, attributes.Namespace, attributes.Name, user, err)
+	}
+
+	return review.Status.Allowed, review.Status.Reason, nil
+}
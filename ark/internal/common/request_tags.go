@@ -0,0 +1,82 @@
+/* Copyright 2025. McKinsey & Company */
+
+package common
+
+import (
+	"context"
+	"net/http"
+)
+
+// Version is the ark version sent in the User-Agent of outbound requests. It is set at startup.
+var Version = "dev"
+
+// UserAgent returns the User-Agent of ark's outbound requests
+func UserAgent() string {
+	return "ark/" + Version
+}
+
+// QueryIDHeader carries the correlation ID of the query an outbound request is made for, so that providers'
+// logs can be correlated with the query
+const QueryIDHeader = "X-Ark-Query-Id"
+
+type requestTagsKey struct{}
+
+type queryIDKey struct{}
+
+// WithQueryID sets the correlation ID of the query the outbound requests made with the context are made for
+func WithQueryID(ctx context.Context, queryID string) context.Context {
+	if queryID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, queryIDKey{}, queryID)
+}
+
+// QueryID returns the correlation ID of the query of the context, empty outside of a query
+func QueryID(ctx context.Context) string {
+	queryID, _ := ctx.Value(queryIDKey{}).(string)
+	return queryID
+}
+
+// WithRequestTags sets the headers that tag the outbound requests made with the context
+func WithRequestTags(ctx context.Context, tags map[string]string) context.Context {
+	if len(tags) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, requestTagsKey{}, tags)
+}
+
+// RequestTags returns the headers that tag the outbound requests made with the context
+func RequestTags(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(requestTagsKey{}).(map[string]string)
+	return tags
+}
+
+// TaggingTransport sets the ark User-Agent, the query ID and the request tags of the request's context on
+// outbound requests. Tags do not replace headers the request already has, such as the headers of an MCP server.
+type TaggingTransport struct {
+	Transport http.RoundTripper
+}
+
+// NewTaggingTransport wraps the transport, or the default transport when nil, to tag outbound requests
+func NewTaggingTransport(transport http.RoundTripper) *TaggingTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &TaggingTransport{Transport: transport}
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (t *TaggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it is given
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", UserAgent())
+	if queryID := QueryID(req.Context()); queryID != "" {
+		req.Header.Set(QueryIDHeader, queryID)
+	}
+	for name, value := range RequestTags(req.Context()) {
+		if req.Header.Get(name) == "" {
+			req.Header.Set(name, value)
+		}
+	}
+	return t.Transport.RoundTrip(req)
+}
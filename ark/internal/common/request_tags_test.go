@@ -0,0 +1,63 @@
+/* Copyright 2025. McKinsey & Company */
+
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTaggingTransport(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	ctx := WithRequestTags(context.Background(), map[string]string{
+		"X-Team":        "payments",
+		"X-Cost-Center": "1234",
+	})
+	ctx = WithQueryID(ctx, "6f1c2f0e-query")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Team", "configured")
+	req.Header.Set("User-Agent", "OpenAI/Go")
+
+	client := &http.Client{Transport: NewTaggingTransport(nil)}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	if got := received.Get("User-Agent"); got != "ark/"+Version {
+		t.Errorf("expected User-Agent ark/%s, got %q", Version, got)
+	}
+	if got := received.Get("X-Cost-Center"); got != "1234" {
+		t.Errorf("expected X-Cost-Center tag, got %q", got)
+	}
+	if got := received.Get(QueryIDHeader); got != "6f1c2f0e-query" {
+		t.Errorf("expected the query ID header, got %q", got)
+	}
+	if got := received.Get("X-Team"); got != "configured" {
+		t.Errorf("expected the request's own X-Team header to be kept, got %q", got)
+	}
+	if got := req.Header.Get("User-Agent"); got != "OpenAI/Go" {
+		t.Errorf("expected the original request to be left unmodified, got User-Agent %q", got)
+	}
+}
+
+func TestWithRequestTagsWithoutTags(t *testing.T) {
+	ctx := context.Background()
+	if WithRequestTags(ctx, nil) != ctx {
+		t.Error("expected the context to be unchanged without tags")
+	}
+	if tags := RequestTags(ctx); tags != nil {
+		t.Errorf("expected no tags, got %v", tags)
+	}
+}
@@ -3,15 +3,27 @@ package common
 import (
 	"bytes"
 	"text/template"
+
+	sprig "github.com/go-task/slim-sprig/v3"
 )
 
+// templateFuncs are the Sprig functions available in templates, without those that read the controller's
+// environment or resolve host names
+var templateFuncs = func() template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	for _, name := range []string{"env", "expandenv", "getHostByName"} {
+		delete(funcs, name)
+	}
+	return funcs
+}()
+
 // ResolveTemplate resolves Go template strings using provided data.
 // Returns the resolved string or the original template if an error occurs.
 func ResolveTemplate(tmpl string, data map[string]any) (string, error) {
 	if tmpl == "" {
 		return "", nil
 	}
-	t, err := template.New("template").Parse(tmpl)
+	t, err := template.New("template").Funcs(templateFuncs).Parse(tmpl)
 	if err != nil {
 		return "", err
 	}
@@ -8,33 +8,127 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
-// LoggingTransport wraps an http.RoundTripper to provide optional HTTP request/response logging
-type LoggingTransport struct {
+// Clients that label ark's outbound requests in metrics, traces and logs
+const (
+	HTTPClientModel           = "model"
+	HTTPClientMCP             = "mcp"
+	HTTPClientA2A             = "a2a"
+	HTTPClientMemory          = "memory"
+	HTTPClientStreaming       = "streaming"
+	HTTPClientArtifacts       = "artifacts"
+	HTTPClientTool            = "tool"
+	HTTPClientEvaluator       = "evaluator"
+	HTTPClientExecutionEngine = "execution-engine"
+	HTTPClientDeadLetter      = "dead-letter"
+)
+
+var (
+	httpClientRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ark_http_client_request_duration_seconds",
+		Help:    "Time until the response headers of ark's outbound HTTP requests, by client, method, host and status code",
+		Buckets: prometheus.ExponentialBuckets(0.05, 2, 12),
+	}, []string{"client", "method", "host", "code"})
+	httpClientRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ark_http_client_retries_total",
+		Help: "Outbound HTTP requests of ark that retry an earlier attempt, by client and host",
+	}, []string{"client", "host"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(httpClientRequestDuration, httpClientRetries)
+}
+
+// InstrumentedTransport makes ark's outbound requests observable in one place: each request is traced, has
+// its latency recorded in metrics and is logged at debug level, together with the attempt of requests the
+// client retries. Requests are tagged with the ark User-Agent and the request tags of their context.
+type InstrumentedTransport struct {
+	Client    string
 	Transport http.RoundTripper
-	Context   context.Context
 }
 
-// NewLoggingTransport creates a new LoggingTransport with the given context.
-// The transport is automatically instrumented with OpenTelemetry for HTTP tracing.
-func NewLoggingTransport(ctx context.Context, transport http.RoundTripper) *LoggingTransport {
-	if transport == nil {
-		transport = http.DefaultTransport
-	}
-	// Wrap with OpenTelemetry HTTP instrumentation for automatic HTTP span creation.
-	// The otelhttp.NewTransport will automatically extract the trace context from the
-	// request's context and create child spans for HTTP calls.
-	transport = otelhttp.NewTransport(transport,
+// NewInstrumentedTransport wraps the transport, or the default transport when nil, to instrument the
+// outbound requests of the client
+func NewInstrumentedTransport(client string, transport http.RoundTripper) *InstrumentedTransport {
+	// The otelhttp transport extracts the trace context from the request's context and creates a child span
+	// for the HTTP call
+	traced := otelhttp.NewTransport(NewTaggingTransport(transport),
 		otelhttp.WithSpanNameFormatter(func(operation string, r *http.Request) string {
 			return "HTTP"
 		}),
+		otelhttp.WithSpanOptions(trace.WithAttributes(attribute.String("ark.http.client", client))),
 	)
+	return &InstrumentedTransport{Client: client, Transport: traced}
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (t *InstrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	attempt := retryAttempt(req)
+	if attempt > 0 {
+		httpClientRetries.WithLabelValues(t.Client, host).Inc()
+	}
+
+	start := time.Now()
+	resp, err := t.Transport.RoundTrip(req)
+	duration := time.Since(start)
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	httpClientRequestDuration.WithLabelValues(t.Client, req.Method, host, code).Observe(duration.Seconds())
+
+	log := logf.FromContext(req.Context()).V(1)
+	if err != nil {
+		log.Info("HTTP request failed", "client", t.Client, "method", req.Method, "host", host, "path", req.URL.Path,
+			"attempt", attempt, "duration", duration, "error", err.Error())
+		return nil, err
+	}
+	log.Info("HTTP request completed", "client", t.Client, "method", req.Method, "host", host, "path", req.URL.Path,
+		"attempt", attempt, "duration", duration, "status", resp.StatusCode)
+	return resp, nil
+}
+
+// retryAttempt returns which retry of a request the request is, or 0 for a first attempt. Clients that retry
+// requests themselves number their attempts in a header: the OpenAI SDK counts retries from 0, while the AWS
+// SDK counts attempts from 1.
+func retryAttempt(req *http.Request) int {
+	if count, err := strconv.Atoi(req.Header.Get("X-Stainless-Retry-Count")); err == nil {
+		return count
+	}
+	for _, field := range strings.Split(req.Header.Get("Amz-Sdk-Request"), ";") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(field), "attempt="); ok {
+			if attempt, err := strconv.Atoi(value); err == nil && attempt > 1 {
+				return attempt - 1
+			}
+		}
+	}
+	return 0
+}
+
+// LoggingTransport wraps an http.RoundTripper to provide optional HTTP request/response logging
+type LoggingTransport struct {
+	Transport http.RoundTripper
+	Context   context.Context
+}
+
+// NewLoggingTransport creates a new LoggingTransport with the given context, over the instrumented
+// transport of the client
+func NewLoggingTransport(ctx context.Context, client string, transport http.RoundTripper) *LoggingTransport {
 	return &LoggingTransport{
-		Transport: transport,
+		Transport: NewInstrumentedTransport(client, transport),
 		Context:   ctx,
 	}
 }
@@ -73,9 +167,9 @@ func (lt *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error)
 	return resp, nil
 }
 
-// NewHTTPClientWithLogging creates an HTTP client with logging transport
-func NewHTTPClientWithLogging(ctx context.Context) *http.Client {
+// NewHTTPClientWithLogging creates an HTTP client of the client with logging transport
+func NewHTTPClientWithLogging(ctx context.Context, client string) *http.Client {
 	return &http.Client{
-		Transport: NewLoggingTransport(ctx, nil),
+		Transport: NewLoggingTransport(ctx, client, nil),
 	}
 }
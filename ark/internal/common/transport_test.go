@@ -0,0 +1,135 @@
+/* Copyright 2025. McKinsey & Company */
+
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// observations returns the number of requests recorded in the request duration histogram of the labels
+func observations(t *testing.T, labels ...string) uint64 {
+	t.Helper()
+	var metric dto.Metric
+	if err := httpClientRequestDuration.WithLabelValues(labels...).(prometheus.Metric).Write(&metric); err != nil {
+		t.Fatal(err)
+	}
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestInstrumentedTransport(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	host := server.Listener.Addr().String()
+
+	client := &http.Client{Transport: NewInstrumentedTransport("test-model", nil)}
+	for attempt := 0; attempt < 3; attempt++ {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if attempt > 0 {
+			req.Header.Set("X-Stainless-Retry-Count", "1")
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if userAgent != UserAgent() {
+		t.Errorf("User-Agent = %q, want %q", userAgent, UserAgent())
+	}
+	if got := observations(t, "test-model", http.MethodPost, host, "429"); got != 3 {
+		t.Errorf("recorded requests = %d, want 3", got)
+	}
+	if got := testutil.ToFloat64(httpClientRetries.WithLabelValues("test-model", host)); got != 2 {
+		t.Errorf("retries = %v, want 2", got)
+	}
+
+	// Requests that fail before a response are recorded with the error code
+	unreachable := &url.URL{Scheme: "http", Host: "127.0.0.1:1"}
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, unreachable.String(), nil)
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected the request to fail")
+	}
+	if got := observations(t, "test-model", http.MethodGet, unreachable.Host, "error"); got != 1 {
+		t.Errorf("recorded failed requests = %d, want 1", got)
+	}
+}
+
+func TestRetryAttempt(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{
+		{name: "first attempt", want: 0},
+		{name: "OpenAI first attempt", headers: map[string]string{"X-Stainless-Retry-Count": "0"}, want: 0},
+		{name: "OpenAI retry", headers: map[string]string{"X-Stainless-Retry-Count": "2"}, want: 2},
+		{name: "AWS first attempt", headers: map[string]string{"Amz-Sdk-Request": "attempt=1; max=3"}, want: 0},
+		{name: "AWS retry", headers: map[string]string{"Amz-Sdk-Request": "attempt=3; max=3"}, want: 2},
+		{name: "malformed", headers: map[string]string{"Amz-Sdk-Request": "attempt=x"}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://models.example.com", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			if got := retryAttempt(req); got != tt.want {
+				t.Errorf("retryAttempt() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstrumentedTransportPropagatesTraceContext(t *testing.T) {
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "tool.call")
+	defer span.End()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := (&http.Client{Transport: NewInstrumentedTransport(HTTPClientMCP, nil)}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	// traceparent is version-traceid-spanid-flags, with the span ID of the HTTP span
+	parts := strings.Split(traceparent, "-")
+	if len(parts) != 4 || parts[1] != span.SpanContext().TraceID().String() {
+		t.Errorf("traceparent = %q, want a span of trace %s", traceparent, span.SpanContext().TraceID())
+	}
+}
@@ -105,7 +105,11 @@ func (r *A2AServerReconciler) processServer(ctx context.Context, a2aServer arkv1
 
 	// Use the already resolved address from status
 	resolvedAddress := a2aServer.Status.LastResolvedAddress
-	agentCard, err := genai.DiscoverA2AAgentsWithRecorder(ctx, r.Client, resolvedAddress, a2aServer.Spec.Headers, a2aServer.Namespace, r.Recorder, &a2aServer)
+	ctx, err := genai.WithNamespaceRequestTags(ctx, r.Client, a2aServer.Namespace)
+	if err != nil {
+		log.Error(err, "invalid request tags, outbound requests are not tagged", "server", a2aServer.Name)
+	}
+	agentCard, err := genai.DiscoverA2AAgentsWithRecorder(ctx, r.Client, resolvedAddress, a2aServer.Spec.Headers, a2aServer.Spec.TLS, a2aServer.Namespace, r.Recorder, &a2aServer)
 	if err != nil {
 		log.Error(err, "A2A agent discovery failed", "server", a2aServer.Name, "address", resolvedAddress)
 		r.Recorder.Event(&a2aServer, corev1.EventTypeWarning, "AgentDiscoveryFailed", fmt.Sprintf("Failed to discover agents from A2A server %s: %v", resolvedAddress, err))
@@ -215,6 +219,9 @@ func (r *A2AServerReconciler) buildAgentWithSkills(a2aServer *arkv1prealpha1.A2A
 		annotations.A2AServerAddress: a2aServer.Status.LastResolvedAddress,
 		annotations.A2AServerSkills:  string(skillsJSON),
 	}
+	if agentCard.Capabilities.Streaming != nil && *agentCard.Capabilities.Streaming {
+		agentAnnotations[annotations.A2AServerStreaming] = "true"
+	}
 
 	// Inherit ark.mckinsey.com annotations from A2AServer to Agent
 	// AAS-2657: Will replace with more idiomatic K8s spec.template pattern
@@ -265,10 +272,16 @@ func (r *A2AServerReconciler) createOrUpdateAgent(ctx context.Context, agent *ar
 		return false, fmt.Errorf("failed to get agent %s: %w", agentName, err)
 	}
 
-	// Only update if skills annotation has changed
-	if existingAgent.Annotations[annotations.A2AServerSkills] != agent.Annotations[annotations.A2AServerSkills] {
+	// Only update when what was discovered from the A2A server has changed, such as its address or skills
+	if a2aAgentChanged(existingAgent, agent) {
 		existingAgent.Spec = agent.Spec
-		existingAgent.Annotations = agent.Annotations
+		if existingAgent.Annotations == nil {
+			existingAgent.Annotations = map[string]string{}
+		}
+		delete(existingAgent.Annotations, annotations.A2AServerStreaming)
+		for key, value := range agent.Annotations {
+			existingAgent.Annotations[key] = value
+		}
 		if err := r.Update(ctx, existingAgent); err != nil {
 			log.Error(err, "Failed to update A2A agent", "agent", agentName, "a2aServer", a2aServerName)
 			return false, fmt.Errorf("failed to update agent %s: %w", agentName, err)
@@ -279,6 +292,23 @@ func (r *A2AServerReconciler) createOrUpdateAgent(ctx context.Context, agent *ar
 	return false, nil // Agent was updated or unchanged
 }
 
+// a2aAgentChanged returns whether an agent differs from the agent built from its A2A server. Annotations the
+// agent was not built with, set by others, are not compared.
+func a2aAgentChanged(existing, desired *arkv1alpha1.Agent) bool {
+	if existing.Spec.Description != desired.Spec.Description || existing.Spec.Prompt != desired.Spec.Prompt {
+		return true
+	}
+	if _, streaming := desired.Annotations[annotations.A2AServerStreaming]; !streaming && existing.Annotations[annotations.A2AServerStreaming] != "" {
+		return true
+	}
+	for key, value := range desired.Annotations {
+		if existing.Annotations[key] != value {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *A2AServerReconciler) finalizeA2AServerProcessing(ctx context.Context, a2aServer arkv1prealpha1.A2AServer) (ctrl.Result, error) {
 	readyCondition := meta.FindStatusCondition(a2aServer.Status.Conditions, A2AServerReady)
 	if readyCondition != nil && readyCondition.Status == metav1.ConditionTrue && readyCondition.Reason == "AgentDiscovered" {
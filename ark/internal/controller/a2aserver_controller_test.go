@@ -0,0 +1,113 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/genai"
+)
+
+var _ = Describe("A2AServer Controller", func() {
+	ctx := context.Background()
+
+	var (
+		mu   sync.Mutex
+		card genai.A2AAgentCard
+	)
+	setCard := func(update func(*genai.A2AAgentCard)) {
+		mu.Lock()
+		defer mu.Unlock()
+		update(&card)
+	}
+
+	It("should create, update and prune the agents of the A2A server's agent card", func() {
+		card = genai.A2AAgentCard{Name: "weather_agent", Description: "Forecasts the weather"}
+		card.Capabilities.Streaming = ptr.To(true)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(card)
+		}))
+		DeferCleanup(server.Close)
+
+		a2aServer := &arkv1prealpha1.A2AServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "weather-server", Namespace: "default"},
+			Spec: arkv1prealpha1.A2AServerSpec{
+				Address:      arkv1prealpha1.ValueSource{Value: server.URL},
+				PollInterval: &metav1.Duration{},
+			},
+		}
+		Expect(k8sClient.Create(ctx, a2aServer)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, a2aServer)
+
+		reconciler := &A2AServerReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), Recorder: record.NewFakeRecorder(100)}
+		reconcile := func() {
+			_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(a2aServer)})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		getAgent := func(name string) (*arkv1alpha1.Agent, error) {
+			agent := &arkv1alpha1.Agent{}
+			return agent, k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, agent)
+		}
+
+		By("creating the agent of the card")
+		reconcile()
+		reconcile()
+		agent, err := getAgent("weather-agent")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(agent.Spec.Description).To(Equal("Forecasts the weather"))
+		Expect(agent.Spec.ExecutionEngine.Name).To(Equal(genai.ExecutionEngineA2A))
+		Expect(agent.Annotations).To(HaveKeyWithValue(annotations.A2AServerName, "weather-server"))
+		Expect(agent.Annotations).To(HaveKeyWithValue(annotations.A2AServerAddress, server.URL))
+		Expect(agent.Annotations).To(HaveKeyWithValue(annotations.A2AServerStreaming, "true"))
+		Expect(agent.OwnerReferences).To(HaveLen(1))
+
+		By("updating the agent when the card changes")
+		agent.Annotations["example.com/owner"] = "weather-team"
+		Expect(k8sClient.Update(ctx, agent)).To(Succeed())
+		setCard(func(card *genai.A2AAgentCard) {
+			card.Description = "Forecasts the weather for a week"
+			card.Capabilities.Streaming = nil
+		})
+		reconcile()
+		agent, err = getAgent("weather-agent")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(agent.Spec.Description).To(Equal("Forecasts the weather for a week"))
+		Expect(agent.Annotations).NotTo(HaveKey(annotations.A2AServerStreaming))
+		Expect(agent.Annotations).To(HaveKeyWithValue("example.com/owner", "weather-team"))
+
+		By("pruning the agent of a card that is gone")
+		setCard(func(card *genai.A2AAgentCard) { card.Name = "forecast_agent" })
+		reconcile()
+		_, err = getAgent("forecast-agent")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = getAgent("weather-agent")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		DeferCleanup(func() {
+			agent, err := getAgent("forecast-agent")
+			if err == nil {
+				Expect(k8sClient.Delete(ctx, agent)).To(Succeed())
+			}
+		})
+	})
+})
@@ -21,6 +21,7 @@ import (
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
+	"mckinsey.com/ark/internal/genai"
 )
 
 const (
@@ -40,6 +41,9 @@ type AgentReconciler struct {
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=tools,verbs=get;list;watch
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=models,verbs=get;list;watch
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=a2aservers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=executionengines,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
 func (r *AgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
@@ -101,28 +105,35 @@ func (r *AgentReconciler) checkDependencies(ctx context.Context, agent *arkv1alp
 
 	// Check the status of the agent's model. Some agents (such as A2A agents) have a 'nil' model, and their status is not associated with model availability.
 	if agent.Spec.ModelRef != nil {
-		if ok, msg := r.checkModelDependency(ctx, agent); !ok {
-			return false, "ModelNotFound", msg
+		if ok, reason, msg := r.checkModelDependency(ctx, agent); !ok {
+			return false, reason, msg
 		}
 	}
 
 	// Check tool dependencies
-	if ok, msg := r.checkToolDependencies(ctx, agent); !ok {
-		return false, "ToolNotFound", msg
+	if ok, reason, msg := r.checkToolDependencies(ctx, agent); !ok {
+		return false, reason, msg
+	}
+
+	// Check the execution engine, unless the agent runs on the built-in or the reserved A2A engine
+	if agent.Spec.ExecutionEngine != nil && agent.Spec.ExecutionEngine.Name != genai.ExecutionEngineA2A {
+		if ok, reason, msg := r.checkExecutionEngineDependency(ctx, agent); !ok {
+			return false, reason, msg
+		}
 	}
 
 	// All dependencies resolved
 	return true, "Available", "All dependencies are available"
 }
 
-// checkModelDependency validates model dependency
-func (r *AgentReconciler) checkModelDependency(ctx context.Context, agent *arkv1alpha1.Agent) (bool, string) {
-	modelName := agent.Spec.ModelRef.Name
+// checkModelDependency validates that the agent's model exists and is available
+func (r *AgentReconciler) checkModelDependency(ctx context.Context, agent *arkv1alpha1.Agent) (bool, string, string) {
 	modelNamespace := agent.Namespace
 
 	if agent.Spec.ModelRef.Namespace != "" {
 		modelNamespace = agent.Spec.ModelRef.Namespace
 	}
+	modelName := genai.ResolveModelAlias(ctx, r.Client, agent.Spec.ModelRef.Name, modelNamespace)
 
 	var model arkv1alpha1.Model
 	modelKey := types.NamespacedName{Name: modelName, Namespace: modelNamespace}
@@ -130,24 +141,27 @@ func (r *AgentReconciler) checkModelDependency(ctx context.Context, agent *arkv1
 		if errors.IsNotFound(err) {
 			msg := fmt.Sprintf("Model '%s' not found in namespace '%s'", modelName, modelNamespace)
 			r.Recorder.Event(agent, corev1.EventTypeWarning, "ModelNotFound", msg)
-			return false, msg
+			return false, "ModelNotFound", msg
 		}
-		return false, fmt.Sprintf("Error checking model: %v", err)
+		return false, "ModelNotFound", fmt.Sprintf("Error checking model: %v", err)
 	}
 
 	// Check if model is available
 	modelCondition := meta.FindStatusCondition(model.Status.Conditions, "ModelAvailable")
 	if modelCondition == nil || modelCondition.Status != metav1.ConditionTrue {
 		msg := fmt.Sprintf("Model '%s' is not available", modelName)
+		if modelCondition != nil && modelCondition.Message != "" {
+			msg = fmt.Sprintf("%s: %s", msg, modelCondition.Message)
+		}
 		r.Recorder.Event(agent, corev1.EventTypeWarning, "ModelNotAvailable", msg)
-		return false, msg
+		return false, "ModelNotAvailable", msg
 	}
 
-	return true, ""
+	return true, "", ""
 }
 
-// checkToolDependencies validates tool dependencies
-func (r *AgentReconciler) checkToolDependencies(ctx context.Context, agent *arkv1alpha1.Agent) (bool, string) {
+// checkToolDependencies validates that the agent's custom tools exist and are not in error
+func (r *AgentReconciler) checkToolDependencies(ctx context.Context, agent *arkv1alpha1.Agent) (bool, string, string) {
 	for _, toolSpec := range agent.Spec.Tools {
 		if toolSpec.Type == "custom" && toolSpec.Name != "" {
 			var tool arkv1alpha1.Tool
@@ -156,14 +170,50 @@ func (r *AgentReconciler) checkToolDependencies(ctx context.Context, agent *arkv
 				if errors.IsNotFound(err) {
 					msg := fmt.Sprintf("Tool '%s' not found in namespace '%s'", toolSpec.Name, agent.Namespace)
 					r.Recorder.Event(agent, corev1.EventTypeWarning, "ToolNotFound", msg)
-					return false, msg
+					return false, "ToolNotFound", msg
 				}
-				return false, fmt.Sprintf("Error checking tool: %v", err)
+				return false, "ToolNotFound", fmt.Sprintf("Error checking tool: %v", err)
+			}
+
+			// A tool the tool controller has not checked yet is not reported, it is checked again once it has been
+			if tool.Status.State == arkv1alpha1.ToolStateError {
+				msg := fmt.Sprintf("Tool '%s' is not ready: %s", toolSpec.Name, tool.Status.Message)
+				r.Recorder.Event(agent, corev1.EventTypeWarning, "ToolNotReady", msg)
+				return false, "ToolNotReady", msg
 			}
 		}
 	}
 
-	return true, ""
+	return true, "", ""
+}
+
+// checkExecutionEngineDependency validates that the agent's execution engine exists and has resolved its address
+func (r *AgentReconciler) checkExecutionEngineDependency(ctx context.Context, agent *arkv1alpha1.Agent) (bool, string, string) {
+	engineName, engineNamespace := agent.Spec.ExecutionEngine.Name, agent.Spec.ExecutionEngine.Namespace
+	if engineNamespace == "" {
+		engineNamespace = agent.Namespace
+	}
+
+	var engine arkv1prealpha1.ExecutionEngine
+	if err := r.Get(ctx, types.NamespacedName{Name: engineName, Namespace: engineNamespace}, &engine); err != nil {
+		if errors.IsNotFound(err) {
+			msg := fmt.Sprintf("ExecutionEngine '%s' not found in namespace '%s'", engineName, engineNamespace)
+			r.Recorder.Event(agent, corev1.EventTypeWarning, "ExecutionEngineNotFound", msg)
+			return false, "ExecutionEngineNotFound", msg
+		}
+		return false, "ExecutionEngineNotFound", fmt.Sprintf("Error checking execution engine: %v", err)
+	}
+
+	if engine.Status.Phase != statusReady {
+		msg := fmt.Sprintf("ExecutionEngine '%s' is not ready", engineName)
+		if engine.Status.Message != "" {
+			msg = fmt.Sprintf("%s: %s", msg, engine.Status.Message)
+		}
+		r.Recorder.Event(agent, corev1.EventTypeWarning, "ExecutionEngineNotReady", msg)
+		return false, "ExecutionEngineNotReady", msg
+	}
+
+	return true, "", ""
 }
 
 // checkA2AServerDependency validates A2AServer dependency for agents owned by A2AServers
@@ -238,6 +288,12 @@ func (r *AgentReconciler) updateStatus(ctx context.Context, agent *arkv1alpha1.A
 }
 
 func (r *AgentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := indexValueSourceRefs(mgr, &arkv1alpha1.Agent{}, func(obj client.Object) *valueSourceRefs {
+		return agentValueSourceRefs(obj.(*arkv1alpha1.Agent))
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&arkv1alpha1.Agent{}).
 		// Watch for Tool events and reconcile dependent agents
@@ -250,11 +306,25 @@ func (r *AgentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&arkv1alpha1.Model{},
 			handler.EnqueueRequestsFromMapFunc(r.findAgentsForModel),
 		).
+		// Watch for ExecutionEngine events and reconcile the agents running on them
+		Watches(
+			&arkv1prealpha1.ExecutionEngine{},
+			handler.EnqueueRequestsFromMapFunc(r.findAgentsForExecutionEngine),
+		).
 		// Watch for A2AServer events and reconcile owned agents
 		Watches(
 			&arkv1prealpha1.A2AServer{},
 			handler.EnqueueRequestsFromMapFunc(r.findAgentsForA2AServer),
 		).
+		// Watch for Secret and ConfigMap events and reconcile agents whose parameters reference them
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findAgentsForValueSource),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findAgentsForValueSource),
+		).
 		Named("agent").
 		Complete(r)
 }
@@ -279,10 +349,28 @@ func (r *AgentReconciler) findAgentsForModel(ctx context.Context, obj client.Obj
 	}
 
 	return r.findAgentsForDependency(ctx, model.Name, model.Namespace, "model", func(agent *arkv1alpha1.Agent) bool {
-		return r.agentDependsOnModel(agent, model.Name)
+		return r.agentDependsOnModel(ctx, agent, model.Name)
 	})
 }
 
+// findAgentsForExecutionEngine finds agents that run on the given execution engine
+func (r *AgentReconciler) findAgentsForExecutionEngine(ctx context.Context, obj client.Object) []reconcile.Request {
+	engine, ok := obj.(*arkv1prealpha1.ExecutionEngine)
+	if !ok {
+		return nil
+	}
+
+	return r.findAgentsForDependency(ctx, engine.Name, engine.Namespace, "executionEngine", func(agent *arkv1alpha1.Agent) bool {
+		return agent.Spec.ExecutionEngine != nil && agent.Spec.ExecutionEngine.Name == engine.Name &&
+			(agent.Spec.ExecutionEngine.Namespace == "" || agent.Spec.ExecutionEngine.Namespace == engine.Namespace)
+	})
+}
+
+// findAgentsForValueSource finds agents that resolve parameters or override headers from the given Secret or ConfigMap
+func (r *AgentReconciler) findAgentsForValueSource(ctx context.Context, obj client.Object) []reconcile.Request {
+	return findDependentsForValueSource(ctx, r.Client, obj, &arkv1alpha1.AgentList{}, "agent-controller")
+}
+
 // findAgentsForDependency is a generic function to find agents that depend on a given resource
 func (r *AgentReconciler) findAgentsForDependency(ctx context.Context, resourceName, namespace, resourceType string, dependencyCheck func(*arkv1alpha1.Agent) bool) []reconcile.Request {
 	log := logf.Log.WithName("agent-controller").WithValues(resourceType, resourceName, "namespace", namespace)
@@ -331,9 +419,13 @@ func (r *AgentReconciler) agentDependsOnTool(agent *arkv1alpha1.Agent, toolName
 	return false
 }
 
-// agentDependsOnModel checks if an agent depends on a specific model
-func (r *AgentReconciler) agentDependsOnModel(agent *arkv1alpha1.Agent, modelName string) bool {
-	return agent.Spec.ModelRef != nil && agent.Spec.ModelRef.Name == modelName
+// agentDependsOnModel checks if an agent depends on a specific model, directly or through a model alias
+func (r *AgentReconciler) agentDependsOnModel(ctx context.Context, agent *arkv1alpha1.Agent, modelName string) bool {
+	if agent.Spec.ModelRef == nil {
+		return false
+	}
+	return agent.Spec.ModelRef.Name == modelName ||
+		genai.ResolveModelAlias(ctx, r.Client, agent.Spec.ModelRef.Name, agent.Namespace) == modelName
 }
 
 // findAgentsForA2AServer finds agents owned by the given A2AServer
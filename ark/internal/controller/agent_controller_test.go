@@ -7,7 +7,9 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -15,6 +17,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
+	"mckinsey.com/ark/internal/genai"
 )
 
 var _ = Describe("Agent Controller", func() {
@@ -110,6 +114,50 @@ var _ = Describe("Agent Controller", func() {
 			Expect(k8sClient.Delete(ctx, defaultModelAgent)).To(Succeed())
 		})
 
+		It("should resolve the model of an agent through the namespace's model aliases", func() {
+			model := &arkv1alpha1.Model{
+				ObjectMeta: metav1.ObjectMeta{Name: "alias-target", Namespace: "default"},
+				Spec: arkv1alpha1.ModelSpec{
+					Type:  "openai",
+					Model: arkv1alpha1.ValueSource{Value: "gpt-4o-mini"},
+					Config: arkv1alpha1.ModelConfig{OpenAI: &arkv1alpha1.OpenAIModelConfig{
+						BaseURL: arkv1alpha1.ValueSource{Value: "https://api.openai.com/v1"},
+						APIKey:  arkv1alpha1.ValueSource{Value: "sk-test"},
+					}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, model)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, model)
+			meta.SetStatusCondition(&model.Status.Conditions, metav1.Condition{Type: "ModelAvailable", Status: metav1.ConditionTrue, Reason: "Available"})
+			Expect(k8sClient.Status().Update(ctx, model)).To(Succeed())
+
+			aliases := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: genai.ModelAliasesConfigMapName, Namespace: "default"},
+				Data:       map[string]string{"fast": "alias-target"},
+			}
+			Expect(k8sClient.Create(ctx, aliases)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, aliases)
+
+			fastAgent := &arkv1alpha1.Agent{
+				ObjectMeta: metav1.ObjectMeta{Name: "fast-agent", Namespace: "default"},
+				Spec: arkv1alpha1.AgentSpec{
+					ModelRef: &arkv1alpha1.AgentModelRef{Name: "fast"},
+					Prompt:   "test prompt for an aliased model",
+				},
+			}
+			controllerReconciler := &AgentReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			available, reason, _ := controllerReconciler.checkDependencies(ctx, fastAgent)
+			Expect(available).To(BeTrue())
+			Expect(reason).To(Equal("Available"))
+			Expect(controllerReconciler.agentDependsOnModel(ctx, fastAgent, "alias-target")).To(BeTrue())
+			Expect(agentValueSourceRefs(fastAgent).configMapNames()).To(ContainElement(genai.ModelAliasesConfigMapName))
+		})
+
 		It("should handle A2A agents without model reference", func() {
 			const a2aAgentResourceName = "test-a2a-agent-resource"
 			a2aAgentTypeNamespacedName := types.NamespacedName{
@@ -149,4 +197,108 @@ var _ = Describe("Agent Controller", func() {
 			Expect(k8sClient.Delete(ctx, a2aAgent)).To(Succeed())
 		})
 	})
+
+	Context("When checking the dependencies of an agent", func() {
+		ctx := context.Background()
+
+		newReconciler := func() *AgentReconciler {
+			return &AgentReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), Recorder: record.NewFakeRecorder(10)}
+		}
+
+		newAgent := func(spec arkv1alpha1.AgentSpec) *arkv1alpha1.Agent {
+			spec.Prompt = "test prompt"
+			return &arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "dependent-agent", Namespace: "default"}, Spec: spec}
+		}
+
+		It("should report a model that is not available apart from a missing model", func() {
+			model := &arkv1alpha1.Model{
+				ObjectMeta: metav1.ObjectMeta{Name: "unavailable-model", Namespace: "default"},
+				Spec: arkv1alpha1.ModelSpec{
+					Type:  "openai",
+					Model: arkv1alpha1.ValueSource{Value: "gpt-4o-mini"},
+					Config: arkv1alpha1.ModelConfig{OpenAI: &arkv1alpha1.OpenAIModelConfig{
+						BaseURL: arkv1alpha1.ValueSource{Value: "https://api.openai.com/v1"},
+						APIKey:  arkv1alpha1.ValueSource{Value: "sk-test"},
+					}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, model)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, model)
+			meta.SetStatusCondition(&model.Status.Conditions, metav1.Condition{Type: "ModelAvailable", Status: metav1.ConditionFalse, Reason: "ProbeFailed", Message: "401 Unauthorized"})
+			Expect(k8sClient.Status().Update(ctx, model)).To(Succeed())
+
+			available, reason, message := newReconciler().checkDependencies(ctx, newAgent(arkv1alpha1.AgentSpec{
+				ModelRef: &arkv1alpha1.AgentModelRef{Name: model.Name},
+			}))
+			Expect(available).To(BeFalse())
+			Expect(reason).To(Equal("ModelNotAvailable"))
+			Expect(message).To(ContainSubstring("401 Unauthorized"))
+
+			_, reason, _ = newReconciler().checkDependencies(ctx, newAgent(arkv1alpha1.AgentSpec{
+				ModelRef: &arkv1alpha1.AgentModelRef{Name: "missing-model"},
+			}))
+			Expect(reason).To(Equal("ModelNotFound"))
+		})
+
+		It("should report a tool in error", func() {
+			tool := &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: "broken-tool", Namespace: "default"},
+				Spec: arkv1alpha1.ToolSpec{
+					Type: "http",
+					HTTP: &arkv1alpha1.HTTPSpec{URL: "https://api.example.com/data", Method: "GET"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, tool)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, tool)
+			agent := newAgent(arkv1alpha1.AgentSpec{Tools: []arkv1alpha1.AgentTool{{Type: "custom", Name: tool.Name}}})
+
+			available, _, _ := newReconciler().checkDependencies(ctx, agent)
+			Expect(available).To(BeTrue(), "a tool that has not been checked yet is not reported")
+
+			tool.Status = arkv1alpha1.ToolStatus{State: arkv1alpha1.ToolStateError, Message: "invalid OpenAPI spec"}
+			Expect(k8sClient.Status().Update(ctx, tool)).To(Succeed())
+			available, reason, message := newReconciler().checkDependencies(ctx, agent)
+			Expect(available).To(BeFalse())
+			Expect(reason).To(Equal("ToolNotReady"))
+			Expect(message).To(ContainSubstring("invalid OpenAPI spec"))
+		})
+
+		It("should check the execution engine of the agent", func() {
+			agent := newAgent(arkv1alpha1.AgentSpec{ExecutionEngine: &arkv1alpha1.ExecutionEngineRef{Name: "langchain"}})
+
+			available, reason, _ := newReconciler().checkDependencies(ctx, agent)
+			Expect(available).To(BeFalse())
+			Expect(reason).To(Equal("ExecutionEngineNotFound"))
+
+			engine := &arkv1prealpha1.ExecutionEngine{
+				ObjectMeta: metav1.ObjectMeta{Name: "langchain", Namespace: "default"},
+				Spec: arkv1prealpha1.ExecutionEngineSpec{
+					Type:    "langchain",
+					Address: arkv1prealpha1.ValueSource{Value: "http://langchain-engine:8000"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, engine)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, engine)
+			_, reason, _ = newReconciler().checkDependencies(ctx, agent)
+			Expect(reason).To(Equal("ExecutionEngineNotReady"))
+
+			engine.Status.Phase = statusReady
+			Expect(k8sClient.Status().Update(ctx, engine)).To(Succeed())
+			available, _, _ = newReconciler().checkDependencies(ctx, agent)
+			Expect(available).To(BeTrue())
+
+			Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, agent)
+			Expect(newReconciler().findAgentsForExecutionEngine(ctx, engine)).To(ConsistOf(reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace},
+			}))
+		})
+
+		It("should not check the reserved A2A execution engine", func() {
+			available, _, _ := newReconciler().checkDependencies(ctx, newAgent(arkv1alpha1.AgentSpec{
+				ExecutionEngine: &arkv1alpha1.ExecutionEngineRef{Name: genai.ExecutionEngineA2A},
+			}))
+			Expect(available).To(BeTrue())
+		})
+	})
 })
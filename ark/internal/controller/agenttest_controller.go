@@ -0,0 +1,218 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+	"mckinsey.com/ark/internal/labels"
+)
+
+// AgentTestReconciler runs an AgentTest in a query to its agent whenever the test changes, and asserts on the
+// response once the query completes
+type AgentTestReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=agenttests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=agenttests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=agenttests/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queries,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *AgentTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var test arkv1alpha1.AgentTest
+	if err := r.Get(ctx, req.NamespacedName, &test); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if test.Status.Phase == "" || test.Status.ObservedGeneration != test.Generation {
+		return ctrl.Result{}, r.startTest(ctx, &test)
+	}
+	if test.Status.Phase != arkv1alpha1.AgentTestPhaseRunning {
+		return ctrl.Result{}, nil
+	}
+
+	var query arkv1alpha1.Query
+	if err := r.Get(ctx, types.NamespacedName{Name: test.Status.Query, Namespace: test.Namespace}, &query); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, r.finishTest(ctx, &test, arkv1alpha1.AgentTestPhaseError, fmt.Sprintf("query %s was deleted before it completed", test.Status.Query))
+		}
+		return ctrl.Result{}, err
+	}
+
+	switch query.Status.Phase {
+	case statusDone:
+		return ctrl.Result{}, r.assertResponse(ctx, &test, &query)
+	case statusError, statusCanceled:
+		return ctrl.Result{}, r.finishTest(ctx, &test, arkv1alpha1.AgentTestPhaseError, queryFailureMessage(&query))
+	}
+	return ctrl.Result{}, nil
+}
+
+// startTest creates the query of the current generation of the test, replacing the query of the previous run
+func (r *AgentTestReconciler) startTest(ctx context.Context, test *arkv1alpha1.AgentTest) error {
+	log := logf.FromContext(ctx)
+
+	if test.Status.Query != "" {
+		previous := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: test.Status.Query, Namespace: test.Namespace}}
+		if err := r.Delete(ctx, previous, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "failed to delete query of previous run", "agentTest", test.Name, "query", previous.Name)
+		}
+	}
+
+	query, err := r.buildQuery(test)
+	if err != nil {
+		return err
+	}
+	if err := r.Create(ctx, query); err != nil && !errors.IsAlreadyExists(err) {
+		r.Recorder.Eventf(test, corev1.EventTypeWarning, "QueryCreateFailed", "Failed to create query %s: %v", query.Name, err)
+		return err
+	}
+	log.Info("started agent test", "agentTest", test.Name, "query", query.Name)
+	r.Recorder.Eventf(test, corev1.EventTypeNormal, "TestStarted", "Created query %s", query.Name)
+
+	test.Status = arkv1alpha1.AgentTestStatus{
+		Phase:              arkv1alpha1.AgentTestPhaseRunning,
+		ObservedGeneration: test.Generation,
+		Query:              query.Name,
+		Conditions:         test.Status.Conditions,
+	}
+	r.setConditionPassed(test, metav1.ConditionUnknown, "Running", fmt.Sprintf("Waiting for query %s", query.Name))
+	return r.Status().Update(ctx, test)
+}
+
+// buildQuery creates the query running the input of the test through its agent
+func (r *AgentTestReconciler) buildQuery(test *arkv1alpha1.AgentTest) (*arkv1alpha1.Query, error) {
+	input, err := json.Marshal(test.Spec.Input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input: %w", err)
+	}
+
+	suffix := fmt.Sprintf("-%d", test.Generation)
+	query := &arkv1alpha1.Query{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      shortenName(test.Name, validation.DNS1123SubdomainMaxLength-len(suffix)) + suffix,
+			Namespace: test.Namespace,
+			Labels:    map[string]string{labels.AgentTestLabel: shortenName(test.Name, validation.LabelValueMaxLength)},
+		},
+		Spec: arkv1alpha1.QuerySpec{
+			Type:    "user",
+			Input:   runtime.RawExtension{Raw: input},
+			Targets: []arkv1alpha1.QueryTarget{{Type: "agent", Name: test.Spec.Agent}},
+			Timeout: test.Spec.Timeout,
+		},
+	}
+	if err := controllerutil.SetControllerReference(test, query, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	return query, nil
+}
+
+// assertResponse checks the expectations of the test on the response of its completed query
+func (r *AgentTestReconciler) assertResponse(ctx context.Context, test *arkv1alpha1.AgentTest, query *arkv1alpha1.Query) error {
+	assertions, err := genai.CheckAgentTestExpectations(test.Spec.Expect, query)
+	if err != nil {
+		return r.finishTest(ctx, test, arkv1alpha1.AgentTestPhaseError, err.Error())
+	}
+
+	test.Status.Response = genai.QueryResponseContent(query, "")
+	test.Status.Assertions = assertions
+	failed := 0
+	for _, assertion := range assertions {
+		if !assertion.Passed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return r.finishTest(ctx, test, arkv1alpha1.AgentTestPhaseFailed, fmt.Sprintf("%d of %d assertions failed", failed, len(assertions)))
+	}
+	return r.finishTest(ctx, test, arkv1alpha1.AgentTestPhasePassed, fmt.Sprintf("%d assertions passed", len(assertions)))
+}
+
+// finishTest records the outcome of the test
+func (r *AgentTestReconciler) finishTest(ctx context.Context, test *arkv1alpha1.AgentTest, phase, message string) error {
+	test.Status.Phase = phase
+	test.Status.Message = message
+	now := metav1.Now()
+	test.Status.CompletionTime = &now
+
+	switch phase {
+	case arkv1alpha1.AgentTestPhasePassed:
+		r.setConditionPassed(test, metav1.ConditionTrue, "Passed", message)
+		r.Recorder.Event(test, corev1.EventTypeNormal, "TestPassed", message)
+	case arkv1alpha1.AgentTestPhaseFailed:
+		r.setConditionPassed(test, metav1.ConditionFalse, "Failed", message)
+		r.Recorder.Event(test, corev1.EventTypeWarning, "TestFailed", message)
+	default:
+		r.setConditionPassed(test, metav1.ConditionFalse, "Error", message)
+		r.Recorder.Event(test, corev1.EventTypeWarning, "TestError", message)
+	}
+	return r.Status().Update(ctx, test)
+}
+
+func (r *AgentTestReconciler) setConditionPassed(test *arkv1alpha1.AgentTest, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&test.Status.Conditions, metav1.Condition{
+		Type:               arkv1alpha1.AgentTestPassed,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: test.Generation,
+	})
+}
+
+// queryFailureMessage explains why the query of a test did not complete
+func queryFailureMessage(query *arkv1alpha1.Query) string {
+	if condition := meta.FindStatusCondition(query.Status.Conditions, string(arkv1alpha1.QueryCompleted)); condition != nil && condition.Message != "" {
+		return fmt.Sprintf("query %s %s: %s", query.Name, query.Status.Phase, condition.Message)
+	}
+	if content := genai.QueryResponseContent(query, ""); content != "" {
+		return fmt.Sprintf("query %s %s: %s", query.Name, query.Status.Phase, content)
+	}
+	return fmt.Sprintf("query %s %s", query.Name, query.Status.Phase)
+}
+
+// withAgentTest adds the AgentTest that created the query to the context, so its agent answers with the
+// pinned model and mocked tools of the test
+func (r *QueryReconciler) withAgentTest(ctx context.Context, query *arkv1alpha1.Query) (context.Context, error) {
+	if _, ok := query.Labels[labels.AgentTestLabel]; !ok {
+		return ctx, nil
+	}
+	owner := metav1.GetControllerOf(query)
+	if owner == nil || owner.Kind != "AgentTest" {
+		return ctx, fmt.Errorf("query labelled %s is not controlled by an AgentTest", labels.AgentTestLabel)
+	}
+	var test arkv1alpha1.AgentTest
+	if err := r.Get(ctx, types.NamespacedName{Name: owner.Name, Namespace: query.Namespace}, &test); err != nil {
+		return ctx, fmt.Errorf("failed to get agent test %s: %w", owner.Name, err)
+	}
+	return genai.WithAgentTest(ctx, &test.Spec), nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AgentTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arkv1alpha1.AgentTest{}).
+		Owns(&arkv1alpha1.Query{}).
+		Named("agenttest").
+		Complete(r)
+}
@@ -0,0 +1,140 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/labels"
+)
+
+var _ = Describe("AgentTest Controller", func() {
+	ctx := context.Background()
+
+	newAgentTest := func(name string) *arkv1alpha1.AgentTest {
+		return &arkv1alpha1.AgentTest{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: arkv1alpha1.AgentTestSpec{
+				Agent: "weather-agent",
+				Input: "What is the weather in Paris?",
+				Expect: arkv1alpha1.AgentTestExpectations{
+					Patterns: []string{`(?i)sunny`},
+					Rules:    []arkv1alpha1.ExpressionRule{{Name: "single-response", Expression: `size(responses) == 1`}},
+				},
+			},
+		}
+	}
+
+	reconcileTest := func(test *arkv1alpha1.AgentTest) *arkv1alpha1.AgentTest {
+		reconciler := &AgentTestReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), Recorder: record.NewFakeRecorder(10)}
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: test.Name, Namespace: "default"}})
+		Expect(err).NotTo(HaveOccurred())
+		updated := &arkv1alpha1.AgentTest{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(test), updated)).To(Succeed())
+		return updated
+	}
+
+	completeQuery := func(test *arkv1alpha1.AgentTest, phase, content string) {
+		query := &arkv1alpha1.Query{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: test.Status.Query, Namespace: "default"}, query)).To(Succeed())
+		query.Status.Phase = phase
+		query.Status.Responses = []arkv1alpha1.Response{{Target: query.Spec.Targets[0], Content: content, Phase: phase}}
+		Expect(k8sClient.Status().Update(ctx, query)).To(Succeed())
+	}
+
+	It("should run the input through the agent in a query owned by the test", func() {
+		test := newAgentTest("weather-test")
+		Expect(k8sClient.Create(ctx, test)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, test)
+
+		test = reconcileTest(test)
+		Expect(test.Status.Phase).To(Equal(arkv1alpha1.AgentTestPhaseRunning))
+		Expect(test.Status.ObservedGeneration).To(Equal(test.Generation))
+		Expect(meta.IsStatusConditionPresentAndEqual(test.Status.Conditions, arkv1alpha1.AgentTestPassed, metav1.ConditionUnknown)).To(BeTrue())
+
+		query := &arkv1alpha1.Query{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: test.Status.Query, Namespace: "default"}, query)).To(Succeed())
+		Expect(query.Labels).To(HaveKeyWithValue(labels.AgentTestLabel, "weather-test"))
+		Expect(query.Spec.Targets).To(Equal([]arkv1alpha1.QueryTarget{{Type: "agent", Name: "weather-agent"}}))
+		Expect(string(query.Spec.Input.Raw)).To(Equal(`"What is the weather in Paris?"`))
+		Expect(metav1.IsControlledBy(query, test)).To(BeTrue())
+
+		test = reconcileTest(test)
+		Expect(test.Status.Phase).To(Equal(arkv1alpha1.AgentTestPhaseRunning), "the test waits for the query")
+	})
+
+	It("should pass when every assertion holds on the response", func() {
+		test := newAgentTest("weather-test-pass")
+		Expect(k8sClient.Create(ctx, test)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, test)
+
+		test = reconcileTest(test)
+		completeQuery(test, statusDone, "It is sunny in Paris")
+		test = reconcileTest(test)
+
+		Expect(test.Status.Phase).To(Equal(arkv1alpha1.AgentTestPhasePassed))
+		Expect(test.Status.Response).To(Equal("It is sunny in Paris"))
+		Expect(test.Status.Assertions).To(HaveLen(2))
+		Expect(test.Status.CompletionTime).NotTo(BeNil())
+		Expect(meta.IsStatusConditionTrue(test.Status.Conditions, arkv1alpha1.AgentTestPassed)).To(BeTrue())
+	})
+
+	It("should fail when an assertion does not hold, reporting which", func() {
+		test := newAgentTest("weather-test-fail")
+		Expect(k8sClient.Create(ctx, test)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, test)
+
+		test = reconcileTest(test)
+		completeQuery(test, statusDone, "It is raining in Paris")
+		test = reconcileTest(test)
+
+		Expect(test.Status.Phase).To(Equal(arkv1alpha1.AgentTestPhaseFailed))
+		Expect(test.Status.Message).To(Equal("1 of 2 assertions failed"))
+		Expect(test.Status.Assertions[0].Passed).To(BeFalse())
+		Expect(test.Status.Assertions[1].Passed).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(test.Status.Conditions, arkv1alpha1.AgentTestPassed)).To(BeTrue())
+	})
+
+	It("should report an error when the query fails", func() {
+		test := newAgentTest("weather-test-error")
+		Expect(k8sClient.Create(ctx, test)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, test)
+
+		test = reconcileTest(test)
+		completeQuery(test, statusError, "model weather-model not found")
+		test = reconcileTest(test)
+
+		Expect(test.Status.Phase).To(Equal(arkv1alpha1.AgentTestPhaseError))
+		Expect(test.Status.Message).To(ContainSubstring("model weather-model not found"))
+		Expect(test.Status.Assertions).To(BeEmpty())
+	})
+
+	It("should hand the test to the agent of its query", func() {
+		test := newAgentTest("weather-test-context")
+		Expect(k8sClient.Create(ctx, test)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, test)
+		test = reconcileTest(test)
+
+		query := &arkv1alpha1.Query{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: test.Status.Query, Namespace: "default"}, query)).To(Succeed())
+		queryReconciler := &QueryReconciler{Client: k8sClient}
+		testCtx, err := queryReconciler.withAgentTest(ctx, query)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testCtx).NotTo(Equal(ctx))
+
+		delete(query.Labels, labels.AgentTestLabel)
+		plainCtx, err := queryReconciler.withAgentTest(ctx, query)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plainCtx).To(Equal(ctx))
+	})
+})
@@ -0,0 +1,343 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
+	"mckinsey.com/ark/internal/genai"
+)
+
+const (
+	// installStatusInterval is how often the install status is checked again
+	installStatusInterval = 5 * time.Minute
+	// webhookCertExpiryWarning is how long before it expires the webhook certificate is reported
+	webhookCertExpiryWarning = 7 * 24 * time.Hour
+	// telemetryDialTimeout bounds the check that the telemetry endpoint is reachable
+	telemetryDialTimeout = 5 * time.Second
+)
+
+// ArkInstallStatusReconciler maintains the ArkInstallStatus singleton, which summarizes the health of the
+// install so that platform admins can check one resource instead of the controller logs. It creates the
+// singleton when the controller starts or when it is deleted, and checks the install again periodically.
+type ArkInstallStatusReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// WebhookCertFile is the certificate the admission webhooks are served with, empty when they are disabled
+	WebhookCertFile string
+	// TelemetryEndpoint is the OTEL exporter endpoint, empty when telemetry is not exported
+	TelemetryEndpoint string
+}
+
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=arkinstallstatuses,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=arkinstallstatuses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=memories,verbs=get;list;watch
+
+func (r *ArkInstallStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if req.Name != arkv1alpha1.ArkInstallStatusName {
+		return ctrl.Result{}, nil
+	}
+
+	var installStatus arkv1alpha1.ArkInstallStatus
+	if err := r.Get(ctx, req.NamespacedName, &installStatus); err != nil {
+		if errors.IsNotFound(err) {
+			// The created singleton is reconciled again
+			return ctrl.Result{}, r.createInstallStatus(ctx)
+		}
+		return ctrl.Result{}, err
+	}
+
+	status, problems := r.checkInstall(ctx)
+	status.Conditions = installStatus.Status.Conditions
+	condition := metav1.Condition{
+		Type:               arkv1alpha1.ArkInstallHealthy,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Healthy",
+		Message:            "No problems found",
+		ObservedGeneration: installStatus.Generation,
+	}
+	if len(problems) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ProblemsFound"
+		condition.Message = strings.Join(problems, "; ")
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+
+	installStatus.Status = status
+	if err := r.Status().Update(ctx, &installStatus); err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(problems) > 0 {
+		logf.FromContext(ctx).Info("install status found problems", "problems", problems)
+	}
+	return ctrl.Result{RequeueAfter: installStatusInterval}, nil
+}
+
+// createInstallStatus creates the ArkInstallStatus singleton, unless it exists already
+func (r *ArkInstallStatusReconciler) createInstallStatus(ctx context.Context) error {
+	installStatus := &arkv1alpha1.ArkInstallStatus{ObjectMeta: metav1.ObjectMeta{Name: arkv1alpha1.ArkInstallStatusName}}
+	if err := r.Create(ctx, installStatus); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create install status: %w", err)
+	}
+	return nil
+}
+
+// checkInstall checks the install and returns its status with the problems found
+func (r *ArkInstallStatusReconciler) checkInstall(ctx context.Context) (arkv1alpha1.ArkInstallStatusStatus, []string) {
+	now := metav1.Now()
+	status := arkv1alpha1.ArkInstallStatusStatus{
+		Version:         common.Version,
+		LastCheckedTime: &now,
+	}
+	var problems []string
+	report := func(problem string) {
+		if problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+
+	var problem string
+	status.Webhook, problem = r.checkWebhookCert(now.Time)
+	report(problem)
+
+	crds, err := r.checkCRDs(ctx)
+	if err != nil {
+		report(err.Error())
+	}
+	for _, crd := range crds {
+		if !crd.Established {
+			report(fmt.Sprintf("CRD %s is not established", crd.Name))
+		}
+	}
+	status.CRDs = crds
+
+	status.Telemetry = r.checkTelemetry(ctx)
+	if status.Telemetry.Endpoint != "" && !status.Telemetry.Reachable {
+		report(status.Telemetry.Message)
+	}
+
+	namespaces, err := r.checkNamespaces(ctx)
+	if err != nil {
+		report(err.Error())
+	}
+	for _, namespace := range namespaces {
+		for _, check := range []*arkv1alpha1.InstallConfigCheck{namespace.Streaming, namespace.Memory} {
+			if check != nil && !check.Valid {
+				report(fmt.Sprintf("namespace %s: %s", namespace.Namespace, check.Message))
+			}
+		}
+	}
+	status.Namespaces = namespaces
+
+	return status, problems
+}
+
+// checkWebhookCert reads the expiry of the webhook certificate. A certificate that cannot be read, has
+// expired or expires soon is a problem.
+func (r *ArkInstallStatusReconciler) checkWebhookCert(now time.Time) (arkv1alpha1.InstallWebhookStatus, string) {
+	if r.WebhookCertFile == "" {
+		return arkv1alpha1.InstallWebhookStatus{}, ""
+	}
+	status := arkv1alpha1.InstallWebhookStatus{Enabled: true}
+
+	data, err := os.ReadFile(r.WebhookCertFile)
+	if err != nil {
+		status.Message = fmt.Sprintf("failed to read webhook certificate: %v", err)
+		return status, status.Message
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		status.Message = fmt.Sprintf("webhook certificate %s is not PEM encoded", r.WebhookCertFile)
+		return status, status.Message
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		status.Message = fmt.Sprintf("failed to parse webhook certificate: %v", err)
+		return status, status.Message
+	}
+
+	notAfter := metav1.NewTime(cert.NotAfter)
+	status.CertificateNotAfter = &notAfter
+	switch {
+	case now.After(cert.NotAfter):
+		status.Message = fmt.Sprintf("webhook certificate expired at %s", cert.NotAfter.Format(time.RFC3339))
+	case cert.NotAfter.Sub(now) < webhookCertExpiryWarning:
+		status.Message = fmt.Sprintf("webhook certificate expires at %s", cert.NotAfter.Format(time.RFC3339))
+	}
+	return status, status.Message
+}
+
+// checkCRDs returns the versions of the Ark CRDs installed, by name
+func (r *ArkInstallStatusReconciler) checkCRDs(ctx context.Context) ([]arkv1alpha1.InstallCRDStatus, error) {
+	var crds apiextensionsv1.CustomResourceDefinitionList
+	if err := r.List(ctx, &crds); err != nil {
+		return nil, fmt.Errorf("failed to list CRDs: %w", err)
+	}
+
+	var statuses []arkv1alpha1.InstallCRDStatus
+	for _, crd := range crds.Items {
+		if crd.Spec.Group != arkv1alpha1.GroupVersion.Group {
+			continue
+		}
+		status := arkv1alpha1.InstallCRDStatus{
+			Name:           crd.Name,
+			StoredVersions: crd.Status.StoredVersions,
+		}
+		for _, version := range crd.Spec.Versions {
+			if version.Served {
+				status.ServedVersions = append(status.ServedVersions, version.Name)
+			}
+		}
+		for _, condition := range crd.Status.Conditions {
+			if condition.Type == apiextensionsv1.Established {
+				status.Established = condition.Status == apiextensionsv1.ConditionTrue
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses, nil
+}
+
+// checkTelemetry checks that a connection can be opened to the telemetry endpoint
+func (r *ArkInstallStatusReconciler) checkTelemetry(ctx context.Context) arkv1alpha1.InstallTelemetryStatus {
+	status := arkv1alpha1.InstallTelemetryStatus{Endpoint: r.TelemetryEndpoint}
+	if r.TelemetryEndpoint == "" {
+		return status
+	}
+
+	address, err := telemetryAddress(r.TelemetryEndpoint)
+	if err != nil {
+		status.Message = fmt.Sprintf("invalid telemetry endpoint: %v", err)
+		return status
+	}
+	dialer := net.Dialer{Timeout: telemetryDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		status.Message = fmt.Sprintf("telemetry endpoint is unreachable: %v", err)
+		return status
+	}
+	_ = conn.Close()
+	status.Reachable = true
+	return status
+}
+
+// telemetryAddress returns the host and port of an OTLP endpoint URL, with the port of its scheme by default
+func telemetryAddress(endpoint string) (string, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	if endpointURL.Host == "" {
+		return "", fmt.Errorf("%s has no host", endpoint)
+	}
+	if endpointURL.Port() != "" {
+		return endpointURL.Host, nil
+	}
+	port := "80"
+	if endpointURL.Scheme == "https" {
+		port = "443"
+	}
+	return net.JoinHostPort(endpointURL.Hostname(), port), nil
+}
+
+// checkNamespaces validates the streaming configuration and the memories of the namespaces that have any
+func (r *ArkInstallStatusReconciler) checkNamespaces(ctx context.Context) ([]arkv1alpha1.InstallNamespaceStatus, error) {
+	namespaces := map[string]*arkv1alpha1.InstallNamespaceStatus{}
+	namespaceStatus := func(namespace string) *arkv1alpha1.InstallNamespaceStatus {
+		if namespaces[namespace] == nil {
+			namespaces[namespace] = &arkv1alpha1.InstallNamespaceStatus{Namespace: namespace}
+		}
+		return namespaces[namespace]
+	}
+
+	var configMaps corev1.ConfigMapList
+	if err := r.List(ctx, &configMaps); err != nil {
+		return nil, fmt.Errorf("failed to list ConfigMaps: %w", err)
+	}
+	for _, configMap := range configMaps.Items {
+		if configMap.Name == genai.StreamingConfigMapName {
+			namespaceStatus(configMap.Namespace).Streaming = r.checkStreaming(ctx, configMap.Namespace)
+		}
+	}
+
+	var memories arkv1alpha1.MemoryList
+	if err := r.List(ctx, &memories); err != nil {
+		return nil, fmt.Errorf("failed to list memories: %w", err)
+	}
+	failed := map[string][]string{}
+	for _, memory := range memories.Items {
+		status := namespaceStatus(memory.Namespace)
+		if status.Memory == nil {
+			status.Memory = &arkv1alpha1.InstallConfigCheck{Valid: true}
+		}
+		if memory.Status.Phase == statusError {
+			failed[memory.Namespace] = append(failed[memory.Namespace], fmt.Sprintf("memory %s: %s", memory.Name, memory.Status.Message))
+		}
+	}
+	for namespace, messages := range failed {
+		slices.Sort(messages)
+		namespaces[namespace].Memory = &arkv1alpha1.InstallConfigCheck{Message: strings.Join(messages, "; ")}
+	}
+
+	statuses := make([]arkv1alpha1.InstallNamespaceStatus, 0, len(namespaces))
+	for _, status := range namespaces {
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Namespace < statuses[j].Namespace })
+	return statuses, nil
+}
+
+// checkStreaming validates the streaming ConfigMap of a namespace and resolves its streaming service
+func (r *ArkInstallStatusReconciler) checkStreaming(ctx context.Context, namespace string) *arkv1alpha1.InstallConfigCheck {
+	config, err := genai.GetStreamingConfig(ctx, r.Client, namespace)
+	if err != nil {
+		return &arkv1alpha1.InstallConfigCheck{Message: err.Error()}
+	}
+	if config == nil || !config.Enabled {
+		return &arkv1alpha1.InstallConfigCheck{Valid: true, Message: "streaming is disabled"}
+	}
+	if _, err := common.ResolveServiceReference(ctx, r.Client, &config.ServiceRef, namespace); err != nil {
+		return &arkv1alpha1.InstallConfigCheck{Message: fmt.Sprintf("failed to resolve streaming service %s: %v", config.ServiceRef.Name, err)}
+	}
+	return &arkv1alpha1.InstallConfigCheck{Valid: true}
+}
+
+// SetupWithManager sets up the controller with the Manager, and creates the singleton when the manager starts
+func (r *ArkInstallStatusReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.Add(manager.RunnableFunc(r.createInstallStatus)); err != nil {
+		return err
+	}
+	// The controller updates the status itself, so only changes of the singleton's generation are reconciled
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arkv1alpha1.ArkInstallStatus{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("arkinstallstatus").
+		Complete(r)
+}
@@ -0,0 +1,165 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+var _ = Describe("ArkInstallStatus Controller", func() {
+	ctx := context.Background()
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: arkv1alpha1.ArkInstallStatusName}}
+
+	newReconciler := func(objects ...client.Object) *ArkInstallStatusReconciler {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(apiextensionsv1.AddToScheme(scheme)).To(Succeed())
+		Expect(arkv1alpha1.AddToScheme(scheme)).To(Succeed())
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+			WithStatusSubresource(&arkv1alpha1.ArkInstallStatus{}).Build()
+		return &ArkInstallStatusReconciler{Client: fakeClient, Scheme: scheme}
+	}
+
+	// reconcile creates the singleton and checks the install
+	reconcile := func(r *ArkInstallStatusReconciler) *arkv1alpha1.ArkInstallStatus {
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		result, err := r.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(installStatusInterval))
+
+		installStatus := &arkv1alpha1.ArkInstallStatus{}
+		Expect(r.Get(ctx, request.NamespacedName, installStatus)).To(Succeed())
+		return installStatus
+	}
+
+	writeCert := func(notAfter time.Time) string {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		template := &x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: notAfter.Add(-24 * time.Hour), NotAfter: notAfter}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).NotTo(HaveOccurred())
+		certFile := filepath.Join(GinkgoT().TempDir(), "tls.crt")
+		Expect(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)).To(Succeed())
+		return certFile
+	}
+
+	It("should report a healthy install", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(listener.Close)
+
+		crd := &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "queries.ark.mckinsey.com"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group:    "ark.mckinsey.com",
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1alpha1", Served: true, Storage: true}},
+			},
+			Status: apiextensionsv1.CustomResourceDefinitionStatus{
+				StoredVersions: []string{"v1alpha1"},
+				Conditions:     []apiextensionsv1.CustomResourceDefinitionCondition{{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionTrue}},
+			},
+		}
+		otherCRD := &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "certificates.cert-manager.io"},
+			Spec:       apiextensionsv1.CustomResourceDefinitionSpec{Group: "cert-manager.io"},
+		}
+		streaming := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: genai.StreamingConfigMapName, Namespace: "default"},
+			Data:       map[string]string{"enabled": "false"},
+		}
+
+		r := newReconciler(crd, otherCRD, streaming)
+		r.WebhookCertFile = writeCert(time.Now().Add(90 * 24 * time.Hour))
+		r.TelemetryEndpoint = "http://" + listener.Addr().String()
+		installStatus := reconcile(r)
+
+		status := installStatus.Status
+		Expect(status.LastCheckedTime).NotTo(BeNil())
+		Expect(status.Webhook.Enabled).To(BeTrue())
+		Expect(status.Webhook.CertificateNotAfter).NotTo(BeNil())
+		Expect(status.Webhook.Message).To(BeEmpty())
+		Expect(status.CRDs).To(ConsistOf(arkv1alpha1.InstallCRDStatus{
+			Name:           "queries.ark.mckinsey.com",
+			ServedVersions: []string{"v1alpha1"},
+			StoredVersions: []string{"v1alpha1"},
+			Established:    true,
+		}))
+		Expect(status.Telemetry.Reachable).To(BeTrue())
+		Expect(status.Namespaces).To(HaveLen(1))
+		Expect(status.Namespaces[0].Streaming.Valid).To(BeTrue())
+
+		condition := meta.FindStatusCondition(status.Conditions, arkv1alpha1.ArkInstallHealthy)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+	})
+
+	It("should report the problems found", func() {
+		streaming := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: genai.StreamingConfigMapName, Namespace: "team-a"},
+			Data:       map[string]string{"enabled": "true"},
+		}
+		memory := &arkv1alpha1.Memory{
+			ObjectMeta: metav1.ObjectMeta{Name: "chat-history", Namespace: "team-a"},
+			Status:     arkv1alpha1.MemoryStatus{Phase: statusError, Message: "failed to resolve address"},
+		}
+
+		r := newReconciler(streaming, memory)
+		r.WebhookCertFile = writeCert(time.Now().Add(24 * time.Hour))
+		r.TelemetryEndpoint = "http://127.0.0.1:1"
+		status := reconcile(r).Status
+
+		Expect(status.Webhook.Message).To(ContainSubstring("webhook certificate expires at"))
+		Expect(status.Telemetry.Reachable).To(BeFalse())
+		Expect(status.Telemetry.Message).To(ContainSubstring("unreachable"))
+		Expect(status.Namespaces).To(HaveLen(1))
+		Expect(status.Namespaces[0].Streaming.Valid).To(BeFalse())
+		Expect(status.Namespaces[0].Streaming.Message).To(ContainSubstring("missing 'serviceRef'"))
+		Expect(status.Namespaces[0].Memory.Valid).To(BeFalse())
+		Expect(status.Namespaces[0].Memory.Message).To(Equal("memory chat-history: failed to resolve address"))
+
+		condition := meta.FindStatusCondition(status.Conditions, arkv1alpha1.ArkInstallHealthy)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Message).To(ContainSubstring("namespace team-a: memory chat-history"))
+	})
+
+	It("should not check disabled webhooks and telemetry", func() {
+		status := reconcile(newReconciler()).Status
+		Expect(status.Webhook.Enabled).To(BeFalse())
+		Expect(status.Telemetry.Endpoint).To(BeEmpty())
+		Expect(meta.IsStatusConditionTrue(status.Conditions, arkv1alpha1.ArkInstallHealthy)).To(BeTrue())
+	})
+
+	It("should default the port of the telemetry endpoint to the port of its scheme", func() {
+		Expect(telemetryAddress("https://otel.example.com")).To(Equal("otel.example.com:443"))
+		Expect(telemetryAddress("http://otel-collector:4318/v1/traces")).To(Equal("otel-collector:4318"))
+		_, err := telemetryAddress("otel-collector")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,356 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/labels"
+)
+
+const (
+	defaultSuccessfulQueriesHistoryLimit = 3
+	defaultFailedQueriesHistoryLimit     = 1
+	// maxMissedSchedules bounds how many missed runs are walked when catching up
+	maxMissedSchedules = 100
+)
+
+// CronQueryReconciler reconciles a CronQuery object
+type CronQueryReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// now returns the current time, defaulting to time.Now
+	now func() time.Time
+}
+
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=cronqueries,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=cronqueries/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=cronqueries/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queries,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *CronQueryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var cronQuery arkv1alpha1.CronQuery
+	if err := r.Get(ctx, req.NamespacedName, &cronQuery); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	queries, err := r.listChildQueries(ctx, &cronQuery)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	active, successful, failed := partitionQueries(queries)
+
+	if err := r.updateHistoryStatus(ctx, &cronQuery, active, successful); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.pruneHistory(ctx, successful, historyLimit(cronQuery.Spec.SuccessfulQueriesHistoryLimit, defaultSuccessfulQueriesHistoryLimit))
+	r.pruneHistory(ctx, failed, historyLimit(cronQuery.Spec.FailedQueriesHistoryLimit, defaultFailedQueriesHistoryLimit))
+
+	if cronQuery.Spec.Suspend {
+		log.V(1).Info("cron query suspended", "cronQuery", cronQuery.Name)
+		return ctrl.Result{}, nil
+	}
+
+	schedule, err := parseCronSchedule(cronQuery.Spec)
+	if err != nil {
+		r.Recorder.Event(&cronQuery, corev1.EventTypeWarning, "InvalidSchedule", err.Error())
+		return ctrl.Result{}, r.updateMessage(ctx, &cronQuery, err.Error())
+	}
+
+	now := r.currentTime()
+	missed, next, err := nextScheduleTimes(&cronQuery, schedule, now)
+	if err != nil {
+		r.Recorder.Event(&cronQuery, corev1.EventTypeWarning, "TooManyMissedTimes", err.Error())
+		return ctrl.Result{}, r.updateMessage(ctx, &cronQuery, err.Error())
+	}
+	result := ctrl.Result{RequeueAfter: next.Sub(now)}
+
+	if missed.IsZero() {
+		return result, nil
+	}
+
+	if deadline := cronQuery.Spec.StartingDeadlineSeconds; deadline != nil && missed.Add(time.Duration(*deadline)*time.Second).Before(now) {
+		log.Info("missed starting deadline for run", "cronQuery", cronQuery.Name, "scheduledTime", missed)
+		return result, nil
+	}
+
+	switch cronQuery.Spec.ConcurrencyPolicy {
+	case arkv1alpha1.ConcurrencyPolicyForbid:
+		if len(active) > 0 {
+			log.Info("skipping run, earlier query still running", "cronQuery", cronQuery.Name, "scheduledTime", missed)
+			r.Recorder.Eventf(&cronQuery, corev1.EventTypeNormal, "RunSkipped", "Skipped run scheduled at %s, %d queries still running", missed.Format(time.RFC3339), len(active))
+			return result, nil
+		}
+	case arkv1alpha1.ConcurrencyPolicyReplace:
+		for i := range active {
+			if err := r.cancelQuery(ctx, active[i]); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	query, err := r.buildQuery(&cronQuery, missed)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Create(ctx, query); err != nil && !errors.IsAlreadyExists(err) {
+		r.Recorder.Eventf(&cronQuery, corev1.EventTypeWarning, "QueryCreateFailed", "Failed to create query %s: %v", query.Name, err)
+		return ctrl.Result{}, err
+	}
+	log.Info("created scheduled query", "cronQuery", cronQuery.Name, "query", query.Name, "scheduledTime", missed)
+	r.Recorder.Eventf(&cronQuery, corev1.EventTypeNormal, "QueryCreated", "Created query %s", query.Name)
+
+	cronQuery.Status.LastScheduleTime = &metav1.Time{Time: missed}
+	cronQuery.Status.Message = ""
+	if err := r.Status().Update(ctx, &cronQuery); err != nil {
+		return ctrl.Result{}, err
+	}
+	return result, nil
+}
+
+func (r *CronQueryReconciler) currentTime() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
+
+// listChildQueries returns the queries created by the cron query
+func (r *CronQueryReconciler) listChildQueries(ctx context.Context, cronQuery *arkv1alpha1.CronQuery) ([]*arkv1alpha1.Query, error) {
+	var queryList arkv1alpha1.QueryList
+	if err := r.List(ctx, &queryList, client.InNamespace(cronQuery.Namespace), client.MatchingLabels{labels.CronQueryLabel: shortenName(cronQuery.Name, validation.LabelValueMaxLength)}); err != nil {
+		return nil, fmt.Errorf("failed to list queries: %w", err)
+	}
+
+	queries := make([]*arkv1alpha1.Query, 0, len(queryList.Items))
+	for i := range queryList.Items {
+		if metav1.IsControlledBy(&queryList.Items[i], cronQuery) {
+			queries = append(queries, &queryList.Items[i])
+		}
+	}
+	return queries, nil
+}
+
+// partitionQueries splits queries into running, successful and failed, each ordered by scheduled time
+func partitionQueries(queries []*arkv1alpha1.Query) (active, successful, failed []*arkv1alpha1.Query) {
+	sort.SliceStable(queries, func(i, j int) bool {
+		return scheduledTime(queries[i]).Before(scheduledTime(queries[j]))
+	})
+
+	for _, query := range queries {
+		switch query.Status.Phase {
+		case statusDone:
+			successful = append(successful, query)
+		case statusError, statusCanceled:
+			failed = append(failed, query)
+		default:
+			active = append(active, query)
+		}
+	}
+	return active, successful, failed
+}
+
+// scheduledTime returns when the query was scheduled, falling back to its creation time
+func scheduledTime(query *arkv1alpha1.Query) time.Time {
+	if value, ok := query.Annotations[annotations.ScheduledAt]; ok {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t
+		}
+	}
+	return query.CreationTimestamp.Time
+}
+
+// updateHistoryStatus records the running queries and the last successful run, writing the status only if it changed
+func (r *CronQueryReconciler) updateHistoryStatus(ctx context.Context, cronQuery *arkv1alpha1.CronQuery, active, successful []*arkv1alpha1.Query) error {
+	status := cronQuery.Status.DeepCopy()
+	status.Active = nil
+	for _, query := range active {
+		status.Active = append(status.Active, corev1.ObjectReference{
+			APIVersion: arkv1alpha1.GroupVersion.String(),
+			Kind:       "Query",
+			Name:       query.Name,
+			Namespace:  query.Namespace,
+			UID:        query.UID,
+		})
+	}
+	if len(successful) > 0 {
+		status.LastSuccessfulTime = &metav1.Time{Time: scheduledTime(successful[len(successful)-1])}
+	}
+
+	if equality.Semantic.DeepEqual(status, &cronQuery.Status) {
+		return nil
+	}
+	cronQuery.Status = *status
+	if err := r.Status().Update(ctx, cronQuery); err != nil {
+		return fmt.Errorf("failed to update cron query status: %w", err)
+	}
+	return nil
+}
+
+func (r *CronQueryReconciler) updateMessage(ctx context.Context, cronQuery *arkv1alpha1.CronQuery, message string) error {
+	if cronQuery.Status.Message == message {
+		return nil
+	}
+	cronQuery.Status.Message = message
+	return r.Status().Update(ctx, cronQuery)
+}
+
+// pruneHistory deletes the oldest queries beyond the history limit
+func (r *CronQueryReconciler) pruneHistory(ctx context.Context, queries []*arkv1alpha1.Query, limit int) {
+	log := logf.FromContext(ctx)
+	for i := 0; i < len(queries)-limit; i++ {
+		if err := r.Delete(ctx, queries[i], client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "failed to delete old query", "query", queries[i].Name)
+			continue
+		}
+		log.V(1).Info("deleted old query", "query", queries[i].Name)
+	}
+}
+
+func historyLimit(limit *int32, fallback int) int {
+	if limit == nil {
+		return fallback
+	}
+	return int(*limit)
+}
+
+func (r *CronQueryReconciler) cancelQuery(ctx context.Context, query *arkv1alpha1.Query) error {
+	if query.Spec.Cancel {
+		return nil
+	}
+	patch := client.MergeFrom(query.DeepCopy())
+	query.Spec.Cancel = true
+	if err := r.Patch(ctx, query, patch); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to cancel query %s: %w", query.Name, err)
+	}
+	return nil
+}
+
+// parseCronSchedule parses the schedule in the cron query's time zone
+func parseCronSchedule(spec arkv1alpha1.CronQuerySpec) (cron.Schedule, error) {
+	schedule := spec.Schedule
+	if spec.TimeZone != nil && *spec.TimeZone != "" {
+		if _, err := time.LoadLocation(*spec.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", *spec.TimeZone, err)
+		}
+		schedule = fmt.Sprintf("CRON_TZ=%s %s", *spec.TimeZone, spec.Schedule)
+	}
+
+	parsed, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec.Schedule, err)
+	}
+	return parsed, nil
+}
+
+// nextScheduleTimes returns the latest run that is due but has not been scheduled yet, or zero if there is
+// none, and the time of the next run
+func nextScheduleTimes(cronQuery *arkv1alpha1.CronQuery, schedule cron.Schedule, now time.Time) (time.Time, time.Time, error) {
+	earliest := cronQuery.CreationTimestamp.Time
+	if cronQuery.Status.LastScheduleTime != nil {
+		earliest = cronQuery.Status.LastScheduleTime.Time
+	}
+	if deadline := cronQuery.Spec.StartingDeadlineSeconds; deadline != nil {
+		if start := now.Add(-time.Duration(*deadline) * time.Second); start.After(earliest) {
+			earliest = start
+		}
+	}
+	if earliest.After(now) {
+		return time.Time{}, schedule.Next(now), nil
+	}
+
+	var missed time.Time
+	starts := 0
+	for t := schedule.Next(earliest); !t.After(now); t = schedule.Next(t) {
+		missed = t
+		starts++
+		if starts > maxMissedSchedules {
+			return time.Time{}, time.Time{}, fmt.Errorf("more than %d missed start times, set startingDeadlineSeconds or check clock skew", maxMissedSchedules)
+		}
+	}
+	return missed, schedule.Next(now), nil
+}
+
+// buildQuery creates the query for the run scheduled at the given time
+func (r *CronQueryReconciler) buildQuery(cronQuery *arkv1alpha1.CronQuery, scheduled time.Time) (*arkv1alpha1.Query, error) {
+	template := cronQuery.Spec.QueryTemplate
+
+	queryLabels := make(map[string]string, len(template.Labels)+1)
+	for k, v := range template.Labels {
+		queryLabels[k] = v
+	}
+	queryLabels[labels.CronQueryLabel] = shortenName(cronQuery.Name, validation.LabelValueMaxLength)
+
+	queryAnnotations := make(map[string]string, len(template.Annotations)+1)
+	for k, v := range template.Annotations {
+		queryAnnotations[k] = v
+	}
+	queryAnnotations[annotations.ScheduledAt] = scheduled.UTC().Format(time.RFC3339)
+
+	query := &arkv1alpha1.Query{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        childQueryName(cronQuery.Name, scheduled),
+			Namespace:   cronQuery.Namespace,
+			Labels:      queryLabels,
+			Annotations: queryAnnotations,
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+	if err := controllerutil.SetControllerReference(cronQuery, query, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	return query, nil
+}
+
+// childQueryName returns the name of the query for the run scheduled at the given time, shortening the
+// cron query name when the result would exceed the maximum object name length
+func childQueryName(cronQueryName string, scheduled time.Time) string {
+	suffix := fmt.Sprintf("-%d", scheduled.Unix()/60)
+	return shortenName(cronQueryName, validation.DNS1123SubdomainMaxLength-len(suffix)) + suffix
+}
+
+// shortenName returns name if it fits in maxLength, and otherwise a prefix of it followed by a hash of
+// the full name, so that distinct long names stay distinct
+func shortenName(name string, maxLength int) string {
+	if len(name) <= maxLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	hash := hex.EncodeToString(sum[:])[:8]
+	return strings.TrimRight(name[:maxLength-len(hash)-1], "-.") + "-" + hash
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CronQueryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arkv1alpha1.CronQuery{}).
+		Owns(&arkv1alpha1.Query{}).
+		Named("cronquery").
+		Complete(r)
+}
@@ -0,0 +1,183 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/labels"
+)
+
+var _ = Describe("CronQuery Controller", func() {
+	ctx := context.Background()
+
+	newCronQuery := func(name, policy string) *arkv1alpha1.CronQuery {
+		return &arkv1alpha1.CronQuery{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: arkv1alpha1.CronQuerySpec{
+				Schedule:          "0 2 * * *",
+				ConcurrencyPolicy: policy,
+				QueryTemplate: arkv1alpha1.QueryTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"suite": "nightly"}},
+					Spec: arkv1alpha1.QuerySpec{
+						Input:   runtime.RawExtension{Raw: []byte(`"How are you?"`)},
+						Targets: []arkv1alpha1.QueryTarget{{Type: "agent", Name: "nightly-agent"}},
+					},
+				},
+			},
+		}
+	}
+
+	// reconcileAt creates the cron query and reconciles it as if the given time had passed since creation
+	reconcileAt := func(cronQuery *arkv1alpha1.CronQuery, elapsed time.Duration) *CronQueryReconciler {
+		created := &arkv1alpha1.CronQuery{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cronQuery), created)).To(Succeed())
+
+		reconciler := &CronQueryReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+			now:      func() time.Time { return created.CreationTimestamp.Add(elapsed) },
+		}
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: cronQuery.Name, Namespace: "default"}})
+		Expect(err).NotTo(HaveOccurred())
+		return reconciler
+	}
+
+	listQueries := func(name string) []arkv1alpha1.Query {
+		var queries arkv1alpha1.QueryList
+		Expect(k8sClient.List(ctx, &queries, client.InNamespace("default"), client.MatchingLabels{labels.CronQueryLabel: name})).To(Succeed())
+		return queries.Items
+	}
+
+	It("should create a query from the template once a run is due", func() {
+		cronQuery := newCronQuery("cron-create", arkv1alpha1.ConcurrencyPolicyAllow)
+		Expect(k8sClient.Create(ctx, cronQuery)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, cronQuery)
+
+		reconcileAt(cronQuery, 25*time.Hour)
+
+		queries := listQueries(cronQuery.Name)
+		Expect(queries).To(HaveLen(1))
+		Expect(queries[0].Labels).To(HaveKeyWithValue("suite", "nightly"))
+		Expect(queries[0].Annotations).To(HaveKey(annotations.ScheduledAt))
+		Expect(queries[0].Spec.Targets).To(Equal(cronQuery.Spec.QueryTemplate.Spec.Targets))
+		Expect(metav1.IsControlledBy(&queries[0], cronQuery)).To(BeTrue())
+
+		updated := &arkv1alpha1.CronQuery{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cronQuery), updated)).To(Succeed())
+		Expect(updated.Status.LastScheduleTime).NotTo(BeNil())
+	})
+
+	It("should skip a run while a query is active under the Forbid policy", func() {
+		cronQuery := newCronQuery("cron-forbid", arkv1alpha1.ConcurrencyPolicyForbid)
+		Expect(k8sClient.Create(ctx, cronQuery)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, cronQuery)
+
+		reconcileAt(cronQuery, 25*time.Hour)
+		Expect(listQueries(cronQuery.Name)).To(HaveLen(1))
+
+		reconcileAt(cronQuery, 49*time.Hour)
+		Expect(listQueries(cronQuery.Name)).To(HaveLen(1))
+	})
+
+	It("should cancel active queries under the Replace policy", func() {
+		cronQuery := newCronQuery("cron-replace", arkv1alpha1.ConcurrencyPolicyReplace)
+		Expect(k8sClient.Create(ctx, cronQuery)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, cronQuery)
+
+		reconcileAt(cronQuery, 25*time.Hour)
+		reconcileAt(cronQuery, 49*time.Hour)
+
+		queries := listQueries(cronQuery.Name)
+		Expect(queries).To(HaveLen(2))
+		canceled := 0
+		for _, query := range queries {
+			if query.Spec.Cancel {
+				canceled++
+			}
+		}
+		Expect(canceled).To(Equal(1))
+	})
+
+	It("should not create queries while suspended", func() {
+		cronQuery := newCronQuery("cron-suspended", arkv1alpha1.ConcurrencyPolicyAllow)
+		cronQuery.Spec.Suspend = true
+		Expect(k8sClient.Create(ctx, cronQuery)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, cronQuery)
+
+		reconcileAt(cronQuery, 25*time.Hour)
+		Expect(listQueries(cronQuery.Name)).To(BeEmpty())
+	})
+
+	It("should only schedule the latest missed run and report the next one", func() {
+		schedule, err := parseCronSchedule(arkv1alpha1.CronQuerySpec{Schedule: "0 * * * *"})
+		Expect(err).NotTo(HaveOccurred())
+
+		created := time.Date(2025, 1, 1, 0, 30, 0, 0, time.UTC)
+		cronQuery := &arkv1alpha1.CronQuery{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)}}
+
+		missed, next, err := nextScheduleTimes(cronQuery, schedule, created.Add(3*time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(missed).To(Equal(time.Date(2025, 1, 1, 3, 0, 0, 0, time.UTC)))
+		Expect(next).To(Equal(time.Date(2025, 1, 1, 4, 0, 0, 0, time.UTC)))
+
+		missed, _, err = nextScheduleTimes(cronQuery, schedule, created.Add(10*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(missed).To(BeZero())
+
+		_, _, err = nextScheduleTimes(cronQuery, schedule, created.Add(200*time.Hour))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject invalid schedules and time zones", func() {
+		_, err := parseCronSchedule(arkv1alpha1.CronQuerySpec{Schedule: "every night"})
+		Expect(err).To(HaveOccurred())
+
+		timeZone := "Mars/Olympus"
+		_, err = parseCronSchedule(arkv1alpha1.CronQuerySpec{Schedule: "0 2 * * *", TimeZone: &timeZone})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should keep query names and labels of long cron queries within the Kubernetes limits", func() {
+		scheduled := time.Date(2025, 1, 1, 2, 0, 0, 0, time.UTC)
+		longName := strings.Repeat("nightly-report-", 17)
+
+		name := childQueryName(longName, scheduled)
+		Expect(len(name)).To(BeNumerically("<=", validation.DNS1123SubdomainMaxLength))
+		Expect(validation.IsDNS1123Subdomain(name)).To(BeEmpty())
+		Expect(name).To(HaveSuffix(fmt.Sprintf("-%d", scheduled.Unix()/60)))
+		Expect(childQueryName(longName+"x", scheduled)).NotTo(Equal(name))
+		Expect(childQueryName("nightly", scheduled)).To(Equal(fmt.Sprintf("nightly-%d", scheduled.Unix()/60)))
+
+		Expect(validation.IsValidLabelValue(shortenName(longName, validation.LabelValueMaxLength))).To(BeEmpty())
+	})
+
+	It("should not write the status when the history is unchanged", func() {
+		cronQuery := newCronQuery("cron-history", arkv1alpha1.ConcurrencyPolicyAllow)
+		Expect(k8sClient.Create(ctx, cronQuery)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, cronQuery)
+
+		reconciler := &CronQueryReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		Expect(reconciler.updateHistoryStatus(ctx, cronQuery, nil, nil)).To(Succeed())
+		resourceVersion := cronQuery.ResourceVersion
+
+		Expect(reconciler.updateHistoryStatus(ctx, cronQuery, nil, nil)).To(Succeed())
+		Expect(cronQuery.ResourceVersion).To(Equal(resourceVersion))
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -19,8 +20,10 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
 	"mckinsey.com/ark/internal/common"
 	"mckinsey.com/ark/internal/genai"
+	"mckinsey.com/ark/internal/telemetry"
 )
 
 const (
@@ -31,9 +34,10 @@ const (
 // EvaluationReconciler reconciles an Evaluation object
 type EvaluationReconciler struct {
 	client.Client
-	Scheme   *runtime.Scheme
-	Recorder record.EventRecorder
-	resolver *common.ValueSourceResolver
+	Scheme    *runtime.Scheme
+	Recorder  record.EventRecorder
+	Telemetry telemetry.Provider
+	resolver  *common.ValueSourceResolver
 }
 
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=evaluations,verbs=get;list;watch;create;update;patch;delete
@@ -41,6 +45,8 @@ type EvaluationReconciler struct {
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=evaluations/finalizers,verbs=update
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=evaluators,verbs=get;list;watch
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queries,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=agents;models,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=feedbacks,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
@@ -126,21 +132,25 @@ func (r *EvaluationReconciler) processEvaluation(ctx context.Context, evaluation
 	}
 }
 
-func (r *EvaluationReconciler) validateEvaluatorRef(ctx context.Context, evaluation arkv1alpha1.Evaluation) error {
-	// Resolve evaluator namespace
+// evaluatorKey returns the key of the evaluation's evaluator, which defaults to the evaluation's namespace
+func evaluatorKey(evaluation arkv1alpha1.Evaluation) client.ObjectKey {
 	evaluatorNamespace := evaluation.Spec.Evaluator.Namespace
 	if evaluatorNamespace == "" {
 		evaluatorNamespace = evaluation.Namespace
 	}
-
-	// Check if evaluator exists
-	var evaluator arkv1alpha1.Evaluator
-	evaluatorKey := client.ObjectKey{
+	return client.ObjectKey{
 		Name:      evaluation.Spec.Evaluator.Name,
 		Namespace: evaluatorNamespace,
 	}
+}
 
-	if err := r.Get(ctx, evaluatorKey, &evaluator); err != nil {
+func (r *EvaluationReconciler) validateEvaluatorRef(ctx context.Context, evaluation arkv1alpha1.Evaluation) error {
+	// Check if evaluator exists
+	var evaluator arkv1alpha1.Evaluator
+	key := evaluatorKey(evaluation)
+	evaluatorNamespace := key.Namespace
+
+	if err := r.Get(ctx, key, &evaluator); err != nil {
 		if errors.IsNotFound(err) {
 			return fmt.Errorf("evaluator '%s' not found in namespace '%s'", evaluation.Spec.Evaluator.Name, evaluatorNamespace)
 		}
@@ -294,10 +304,10 @@ func (r *EvaluationReconciler) getModelNameFromParameters(modelNameParam *arkv1a
 	return paramMap[paramModelName]
 }
 
-// modelExistsInNamespace checks if a model exists in the specified namespace
+// modelExistsInNamespace checks if a model, or the model an alias resolves to, exists in the specified namespace
 func (r *EvaluationReconciler) modelExistsInNamespace(ctx context.Context, modelName, namespace string) bool {
 	var model arkv1alpha1.Model
-	modelKey := client.ObjectKey{Name: modelName, Namespace: namespace}
+	modelKey := client.ObjectKey{Name: genai.ResolveModelAlias(ctx, r.Client, modelName, namespace), Namespace: namespace}
 	err := r.Get(ctx, modelKey, &model)
 	return err == nil
 }
@@ -604,6 +614,19 @@ func (r *EvaluationReconciler) processQueryEvaluation(ctx context.Context, evalu
 
 	log.Info("Query validated", "evaluation", evaluation.Name, "query", evaluation.Spec.Config.QueryRef.Name, "queryPhase", query.Status.Phase)
 
+	// Rules and judges are scored by the controller rather than the evaluator service
+	var evaluator arkv1alpha1.Evaluator
+	if err := r.Get(ctx, evaluatorKey(evaluation), &evaluator); err != nil {
+		if err := r.updateStatus(ctx, evaluation, statusError, fmt.Sprintf("Failed to fetch Evaluator: %v", err)); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	hasRules := evaluation.Spec.Config.EventEvaluationConfig != nil && len(evaluation.Spec.Config.Rules) > 0
+	if hasRules || evaluator.Spec.Judge != nil {
+		return r.processInClusterEvaluation(ctx, evaluation, query, &evaluator)
+	}
+
 	// For query evaluation, we don't extract input/output locally
 	// The evaluator service will resolve them from the query reference
 	log.Info("Query validation complete, delegating input/output resolution to evaluator service", "evaluation", evaluation.Name, "query", evaluation.Spec.Config.QueryRef.Name)
@@ -637,6 +660,16 @@ func (r *EvaluationReconciler) processQueryEvaluation(ctx context.Context, evalu
 		parameters["queryRef"] = fmt.Sprintf("%s/%s", queryRef.Namespace, queryRef.Name)
 	}
 
+	// User feedback on the query is passed to the evaluator as a signal and reported with the result
+	feedback, err := r.feedbackSummary(ctx, queryRef)
+	if err != nil {
+		log.Error(err, "Failed to summarize query feedback", "evaluation", evaluation.Name)
+	} else if feedback != nil {
+		parameters["feedback.positive"] = strconv.Itoa(int(feedback.Positive))
+		parameters["feedback.negative"] = strconv.Itoa(int(feedback.Negative))
+		evaluation.Status.Feedback = feedback
+	}
+
 	request := genai.UnifiedEvaluationRequest{
 		Type: "query",
 		Config: map[string]interface{}{
@@ -674,6 +707,121 @@ func (r *EvaluationReconciler) processQueryEvaluation(ctx context.Context, evalu
 	return ctrl.Result{}, nil
 }
 
+// processInClusterEvaluation scores the query by the CEL rules of the evaluation and the judge of its
+// evaluator. When both are set, the score is the mean of the rule score and the judge's score.
+func (r *EvaluationReconciler) processInClusterEvaluation(ctx context.Context, evaluation arkv1alpha1.Evaluation, query *arkv1alpha1.Query, evaluator *arkv1alpha1.Evaluator) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	parameters := r.convertParametersToMap(ctx, r.resolveFinalParameters(ctx, evaluation), evaluation.Namespace)
+	minScore := genai.DefaultRuleMinScore
+	if value, ok := parameters["min-score"]; ok {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			if err := r.updateStatus(ctx, evaluation, statusError, fmt.Sprintf("Invalid min-score parameter %q", value)); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+		minScore = parsed
+	}
+
+	responseTarget := evaluation.Spec.Config.QueryRef.ResponseTarget
+	response := &genai.EvaluationResponse{Metadata: map[string]string{}}
+	var scores []float64
+	var scoredBy []string
+
+	if evaluation.Spec.Config.EventEvaluationConfig != nil && len(evaluation.Spec.Config.Rules) > 0 {
+		ruleResponse, err := genai.EvaluateQueryRules(evaluation.Spec.Config.Rules, query, responseTarget, minScore)
+		if err != nil {
+			if err := r.updateStatus(ctx, evaluation, statusError, fmt.Sprintf("Query evaluation failed: %v", err)); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+		ruleScore, _ := strconv.ParseFloat(ruleResponse.Score, 64)
+		scores = append(scores, ruleScore)
+		scoredBy = append(scoredBy, fmt.Sprintf("%d rules", len(evaluation.Spec.Config.Rules)))
+		evaluation.Status.RuleScore = ruleResponse.Score
+		for key, value := range ruleResponse.Metadata {
+			response.Metadata[key] = value
+		}
+	}
+
+	if evaluator.Spec.Judge != nil {
+		judgement, tokenUsage, err := r.judgeQuery(ctx, &evaluation, query, evaluator)
+		if err != nil {
+			log.Error(err, "Failed to judge query", "evaluation", evaluation.Name, "evaluator", evaluator.Name)
+			if err := r.updateStatus(ctx, evaluation, statusError, fmt.Sprintf("Query evaluation failed: %v", err)); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+		scores = append(scores, judgement.Score)
+		scoredBy = append(scoredBy, "judge")
+		evaluation.Status.Judgement = &arkv1alpha1.EvaluationJudgement{
+			Score:     fmt.Sprintf("%.3f", judgement.Score),
+			Rationale: judgement.Rationale,
+		}
+		response.Metadata["judge_score"] = evaluation.Status.Judgement.Score
+		response.Metadata["judge_rationale"] = judgement.Rationale
+		response.TokenUsage = tokenUsage
+	}
+
+	var score float64
+	for _, s := range scores {
+		score += s
+	}
+	score /= float64(len(scores))
+	response.Score = fmt.Sprintf("%.3f", score)
+	response.Passed = score >= minScore
+
+	statusMessage := fmt.Sprintf("Query evaluation completed with %s", strings.Join(scoredBy, " and "))
+	if response.Passed {
+		statusMessage = fmt.Sprintf("%s - passed (score: %s)", statusMessage, response.Score)
+	} else {
+		statusMessage = fmt.Sprintf("%s - failed (score: %s)", statusMessage, response.Score)
+	}
+	if err := r.updateEvaluationComplete(ctx, evaluation, response, statusMessage); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Query evaluated in cluster", "evaluation", evaluation.Name, "score", response.Score, "passed", response.Passed)
+	return ctrl.Result{}, nil
+}
+
+// judgeQuery has the evaluator's judge score the query's response against its rubric, and returns the
+// judgement with the tokens the judge used
+func (r *EvaluationReconciler) judgeQuery(ctx context.Context, evaluation *arkv1alpha1.Evaluation, query *arkv1alpha1.Query, evaluator *arkv1alpha1.Evaluator) (*genai.Judgement, *arkv1alpha1.TokenUsage, error) {
+	inputMessages, err := genai.GetQueryInputMessages(ctx, *query, r.Client)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get query input: %w", err)
+	}
+	request := genai.ExtractUserMessageContent(inputMessages)
+	answer := genai.QueryResponseContent(query, evaluation.Spec.Config.QueryRef.ResponseTarget)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, r.getEvaluationTimeout(evaluation))
+	defer cancel()
+	judgeCtx := context.WithValue(timeoutCtx, genai.QueryContextKey, query)
+	judgeCtx, err = genai.WithNamespaceRequestTags(judgeCtx, r.Client, evaluation.Namespace)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "invalid request tags, outbound requests are not tagged")
+	}
+
+	collector := genai.NewTokenUsageCollector(genai.NewEvaluationRecorder(evaluation, r.Recorder))
+	judge := genai.NewJudge(r.Client, *evaluator.Spec.Judge, collector, r.Telemetry)
+	judgement, err := judge.Score(judgeCtx, evaluator.Namespace, request, answer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokenUsage := collector.GetTokenSummary()
+	return judgement, &arkv1alpha1.TokenUsage{
+		PromptTokens:     tokenUsage.PromptTokens,
+		CompletionTokens: tokenUsage.CompletionTokens,
+		TotalTokens:      tokenUsage.TotalTokens,
+	}, nil
+}
+
 func (r *EvaluationReconciler) setConditionCompleted(evaluation *arkv1alpha1.Evaluation, status metav1.ConditionStatus, reason, message string) {
 	meta.SetStatusCondition(&evaluation.Status.Conditions, metav1.Condition{
 		Type:               string(arkv1alpha1.EvaluationCompleted),
@@ -772,6 +920,9 @@ func (r *EvaluationReconciler) updateEvaluationComplete(ctx context.Context, eva
 		latest.Status.Score = response.Score
 		latest.Status.Passed = response.Passed
 		latest.Status.TokenUsage = response.TokenUsage
+		latest.Status.Feedback = evaluation.Status.Feedback
+		latest.Status.RuleScore = evaluation.Status.RuleScore
+		latest.Status.Judgement = evaluation.Status.Judgement
 		latest.Status.Phase = statusDone
 		latest.Status.Message = message
 
@@ -909,6 +1060,8 @@ func (r *EvaluationReconciler) aggregateChildResults(ctx context.Context, parent
 		TotalTokens:      0,
 	}
 
+	var feedback *arkv1alpha1.FeedbackSummary
+
 	// Aggregate results from all children
 	for _, child := range childEvaluations.Items {
 		// Count passed/failed
@@ -932,6 +1085,15 @@ func (r *EvaluationReconciler) aggregateChildResults(ctx context.Context, parent
 			aggregatedTokenUsage.CompletionTokens += child.Status.TokenUsage.CompletionTokens
 			aggregatedTokenUsage.TotalTokens += child.Status.TokenUsage.TotalTokens
 		}
+
+		// Aggregate user feedback
+		if child.Status.Feedback != nil {
+			if feedback == nil {
+				feedback = &arkv1alpha1.FeedbackSummary{}
+			}
+			feedback.Positive += child.Status.Feedback.Positive
+			feedback.Negative += child.Status.Feedback.Negative
+		}
 	}
 
 	// Calculate average score
@@ -953,6 +1115,7 @@ func (r *EvaluationReconciler) aggregateChildResults(ctx context.Context, parent
 	parentEvaluation.Status.Phase = statusDone
 	parentEvaluation.Status.Message = message
 	parentEvaluation.Status.TokenUsage = &aggregatedTokenUsage
+	parentEvaluation.Status.Feedback = feedback
 
 	r.setConditionCompleted(&parentEvaluation, metav1.ConditionTrue, "EvaluationCompleted", message)
 
@@ -1142,6 +1305,36 @@ func (r *EvaluationReconciler) addContextToParameters(ctx context.Context, evalu
 	return parameters
 }
 
+// feedbackSummary counts the recorded feedback on the query, limited to the response target when the
+// reference names one. Returns nil when the query has no feedback.
+func (r *EvaluationReconciler) feedbackSummary(ctx context.Context, queryRef *arkv1alpha1.QueryRef) (*arkv1alpha1.FeedbackSummary, error) {
+	var feedbacks arkv1alpha1.FeedbackList
+	if err := r.List(ctx, &feedbacks, client.InNamespace(queryRef.Namespace), client.MatchingLabels{
+		annotations.Query: queryRef.Name,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list feedback: %w", err)
+	}
+
+	var summary *arkv1alpha1.FeedbackSummary
+	for _, feedback := range feedbacks.Items {
+		if feedback.Status.Phase != arkv1alpha1.FeedbackPhaseRecorded {
+			continue
+		}
+		if queryRef.ResponseTarget != "" && feedback.Spec.QueryRef.ResponseTarget != "" && feedback.Spec.QueryRef.ResponseTarget != queryRef.ResponseTarget {
+			continue
+		}
+		if summary == nil {
+			summary = &arkv1alpha1.FeedbackSummary{}
+		}
+		if feedback.Spec.Rating == arkv1alpha1.FeedbackRatingPositive {
+			summary.Positive++
+		} else {
+			summary.Negative++
+		}
+	}
+	return summary, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *EvaluationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
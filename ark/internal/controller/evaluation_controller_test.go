@@ -786,6 +786,87 @@ var _ = Describe("Evaluation Controller", func() {
 			Expect(k8sClient.Delete(ctx, evaluator)).Should(Succeed())
 		})
 
+		It("Should score query rules without calling the evaluator service", func() {
+			ctx := context.Background()
+
+			evaluator := &arkv1alpha1.Evaluator{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-evaluator-query-rules",
+					Namespace: "default",
+				},
+				Spec: arkv1alpha1.EvaluatorSpec{
+					Address: arkv1alpha1.ValueSource{
+						Value: "http://evaluator-service:8080",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, evaluator)).Should(Succeed())
+
+			query := &arkv1alpha1.Query{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-query-rules",
+					Namespace: "default",
+				},
+			}
+			Expect(query.Spec.SetInputString("What is 2+2?")).To(Succeed())
+			Expect(k8sClient.Create(ctx, query)).Should(Succeed())
+
+			query.Status.Phase = statusDone
+			query.Status.Responses = []arkv1alpha1.Response{{Content: "4"}}
+			query.Status.TokenUsage = arkv1alpha1.TokenUsage{TotalTokens: 120}
+			Expect(k8sClient.Status().Update(ctx, query)).Should(Succeed())
+
+			evaluation := &arkv1alpha1.Evaluation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-query-rules-evaluation",
+					Namespace: "default",
+				},
+				Spec: arkv1alpha1.EvaluationSpec{
+					Type: "query",
+					Config: arkv1alpha1.EvaluationConfig{
+						QueryBasedEvaluationConfig: &arkv1alpha1.QueryBasedEvaluationConfig{
+							QueryRef: &arkv1alpha1.QueryRef{
+								Name: "test-query-rules",
+							},
+						},
+						EventEvaluationConfig: &arkv1alpha1.EventEvaluationConfig{
+							Rules: []arkv1alpha1.ExpressionRule{
+								{Name: "answer", Expression: `response == "4"`, Weight: 3},
+								{Name: "cheap", Expression: `tokenUsage.totalTokens < 100`},
+							},
+						},
+					},
+					Evaluator: arkv1alpha1.EvaluationEvaluatorRef{
+						Name: "test-evaluator-query-rules",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, evaluation)).Should(Succeed())
+
+			evaluationLookupKey := types.NamespacedName{Name: "test-query-rules-evaluation", Namespace: "default"}
+			controllerReconciler := &EvaluationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			// Initializes the conditions, starts the evaluation, then scores the rules
+			for range 3 {
+				_, err := controllerReconciler.Reconcile(ctx, ctrl.Request{NamespacedName: evaluationLookupKey})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			createdEvaluation := &arkv1alpha1.Evaluation{}
+			Expect(k8sClient.Get(ctx, evaluationLookupKey, createdEvaluation)).Should(Succeed())
+			Expect(createdEvaluation.Status.Phase).Should(Equal(statusDone))
+			Expect(createdEvaluation.Status.Score).Should(Equal("0.750"))
+			Expect(createdEvaluation.Status.Passed).Should(BeTrue())
+			Expect(createdEvaluation.Annotations).Should(HaveKeyWithValue("evaluation.metadata/rule_1_cheap_passed", "false"))
+
+			Expect(k8sClient.Delete(ctx, createdEvaluation)).Should(Succeed())
+			Expect(k8sClient.Delete(ctx, query)).Should(Succeed())
+			Expect(k8sClient.Delete(ctx, evaluator)).Should(Succeed())
+		})
+
 		It("Should validate Query status is done", func() {
 			ctx := context.Background()
 
@@ -327,7 +327,8 @@ func (r *EvaluatorReconciler) processEvaluatorWithSelector(ctx context.Context,
 
 	// Process each matching query
 	for _, query := range matchingQueries {
-		if query.Status.Phase == statusDone {
+		// Restored queries are read-only snapshots of queries that were already evaluated
+		if query.Status.Phase == statusDone && !isRestoredQuery(&query) {
 			if err := r.createEvaluationForQuery(ctx, evaluator, &query); err != nil {
 				log.Error(err, "Failed to create evaluation", "evaluator", evaluator.Name, "query", query.Name)
 				continue
@@ -416,6 +417,7 @@ func (r *EvaluatorReconciler) createEvaluationForQuery(ctx context.Context, eval
 						ResponseTarget: "", // Default to first response
 					},
 				},
+				EventEvaluationConfig: evaluatorRules(evaluator),
 			},
 			Evaluator: arkv1alpha1.EvaluationEvaluatorRef{
 				Name:       evaluator.Name,
@@ -429,6 +431,15 @@ func (r *EvaluatorReconciler) createEvaluationForQuery(ctx context.Context, eval
 	return r.Create(ctx, evaluation)
 }
 
+// evaluatorRules returns the rules the evaluations of the evaluator are scored by, or nil when the evaluator
+// service scores them
+func evaluatorRules(evaluator *arkv1alpha1.Evaluator) *arkv1alpha1.EventEvaluationConfig {
+	if len(evaluator.Spec.Rules) == 0 {
+		return nil
+	}
+	return &arkv1alpha1.EventEvaluationConfig{Rules: evaluator.Spec.Rules}
+}
+
 // shouldRetriggerEvaluation checks if evaluation should be retriggered based on query changes
 func (r *EvaluatorReconciler) shouldRetriggerEvaluation(evaluation *arkv1alpha1.Evaluation, query *arkv1alpha1.Query) bool {
 	// Check if query generation has changed
@@ -471,6 +482,7 @@ func (r *EvaluatorReconciler) updateEvaluationForQuery(ctx context.Context, eval
 			return fmt.Errorf("failed to resolve parameters: %w", err)
 		}
 		currentEval.Spec.Evaluator.Parameters = parameters
+		currentEval.Spec.Config.EventEvaluationConfig = evaluatorRules(evaluator)
 
 		// Update main object first
 		if err := r.Update(ctx, &currentEval); err != nil {
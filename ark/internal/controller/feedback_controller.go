@@ -0,0 +1,159 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/telemetry"
+	"mckinsey.com/ark/internal/telemetry/langfuse"
+)
+
+const (
+	// feedbackScoreName is the name of the Langfuse score feedback is recorded as
+	feedbackScoreName = "user-feedback"
+	// feedbackPendingRequeue is how often feedback on a query that has not completed yet is retried
+	feedbackPendingRequeue = 10 * time.Second
+)
+
+// FeedbackReconciler reconciles a Feedback object
+type FeedbackReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Recorder  record.EventRecorder
+	Telemetry telemetry.Provider
+	// Scores sends feedback to Langfuse as scores. Nil when Langfuse is not configured.
+	Scores *langfuse.ScoreClient
+}
+
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=feedbacks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=feedbacks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=feedbacks/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queries,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *FeedbackReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var feedback arkv1alpha1.Feedback
+	if err := r.Get(ctx, req.NamespacedName, &feedback); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Feedback is recorded once, later edits are not sent again
+	if feedback.Status.Phase == arkv1alpha1.FeedbackPhaseRecorded || feedback.Status.Phase == arkv1alpha1.FeedbackPhaseError {
+		return ctrl.Result{}, nil
+	}
+
+	queryRef := feedback.Spec.QueryRef
+	if queryRef.Namespace != "" && queryRef.Namespace != feedback.Namespace {
+		return ctrl.Result{}, r.updateFeedbackStatus(ctx, &feedback, arkv1alpha1.FeedbackPhaseError, "feedback must be in the namespace of its query")
+	}
+
+	var query arkv1alpha1.Query
+	if err := r.Get(ctx, client.ObjectKey{Name: queryRef.Name, Namespace: feedback.Namespace}, &query); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, r.updateFeedbackStatus(ctx, &feedback, arkv1alpha1.FeedbackPhaseError, fmt.Sprintf("query %s not found", queryRef.Name))
+		}
+		return ctrl.Result{}, err
+	}
+
+	switch query.Status.Phase {
+	case statusDone, statusError, statusCanceled:
+	default:
+		log.V(1).Info("query not completed yet, waiting to record feedback", "feedback", feedback.Name, "query", query.Name)
+		if err := r.updateFeedbackStatus(ctx, &feedback, arkv1alpha1.FeedbackPhasePending, "waiting for the query to complete"); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: feedbackPendingRequeue}, nil
+	}
+
+	if queryRef.ResponseTarget != "" && !hasResponseFrom(&query, queryRef.ResponseTarget) {
+		return ctrl.Result{}, r.updateFeedbackStatus(ctx, &feedback, arkv1alpha1.FeedbackPhaseError, fmt.Sprintf("query %s has no response from %s", query.Name, queryRef.ResponseTarget))
+	}
+
+	if err := r.linkToQuery(ctx, &feedback, &query); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.Telemetry.QueryRecorder().RecordFeedback(ctx, query.Name, query.Namespace, query.Status.TraceID, feedback.Spec.Rating, feedback.Spec.Score(), feedback.Spec.Comment)
+
+	if r.Scores != nil && query.Status.TraceID != "" {
+		score := langfuse.Score{
+			ID:      string(feedback.UID),
+			TraceID: query.Status.TraceID,
+			Name:    feedbackScoreName,
+			Value:   feedback.Spec.Score(),
+			Comment: feedback.Spec.Comment,
+		}
+		if err := r.Scores.CreateScore(ctx, score); err != nil {
+			r.Recorder.Event(&feedback, corev1.EventTypeWarning, "ScoreFailed", err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
+	feedback.Status.TraceID = query.Status.TraceID
+	feedback.Status.RecordedTime = &metav1.Time{Time: time.Now()}
+	log.Info("feedback recorded", "feedback", feedback.Name, "query", query.Name, "rating", feedback.Spec.Rating)
+	return ctrl.Result{}, r.updateFeedbackStatus(ctx, &feedback, arkv1alpha1.FeedbackPhaseRecorded, "")
+}
+
+// linkToQuery labels the feedback with its query so it can be found from the query, and makes the
+// query its owner so the feedback is deleted with it
+func (r *FeedbackReconciler) linkToQuery(ctx context.Context, feedback *arkv1alpha1.Feedback, query *arkv1alpha1.Query) error {
+	patch := client.MergeFrom(feedback.DeepCopy())
+	if feedback.Labels == nil {
+		feedback.Labels = map[string]string{}
+	}
+	feedback.Labels[annotations.Query] = query.Name
+	if err := controllerutil.SetOwnerReference(query, feedback, r.Scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	if err := r.Patch(ctx, feedback, patch); err != nil {
+		return fmt.Errorf("failed to link feedback to query %s: %w", query.Name, err)
+	}
+	return nil
+}
+
+func (r *FeedbackReconciler) updateFeedbackStatus(ctx context.Context, feedback *arkv1alpha1.Feedback, phase, message string) error {
+	if feedback.Status.Phase == phase && feedback.Status.Message == message {
+		return nil
+	}
+	if phase == arkv1alpha1.FeedbackPhaseError {
+		r.Recorder.Event(feedback, corev1.EventTypeWarning, "InvalidFeedback", message)
+	}
+	feedback.Status.Phase = phase
+	feedback.Status.Message = message
+	return r.Status().Update(ctx, feedback)
+}
+
+func hasResponseFrom(query *arkv1alpha1.Query, targetName string) bool {
+	for _, response := range query.Status.Responses {
+		if response.Target.Name == targetName {
+			return true
+		}
+	}
+	return false
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FeedbackReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arkv1alpha1.Feedback{}).
+		Named("feedback").
+		Complete(r)
+}
@@ -0,0 +1,148 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/telemetry"
+	"mckinsey.com/ark/internal/telemetry/langfuse"
+	"mckinsey.com/ark/internal/telemetry/mock"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+// feedbackTelemetry captures the query recorder's spans and discards everything else
+type feedbackTelemetry struct {
+	telemetry.Provider
+	queryRecorder *mock.MockQueryRecorder
+}
+
+func (p feedbackTelemetry) QueryRecorder() telemetry.QueryRecorder {
+	return p.queryRecorder
+}
+
+var _ = Describe("Feedback Controller", func() {
+	ctx := context.Background()
+	const traceID = "0af7651916cd43dd8448eb211c80319c"
+
+	createQuery := func(name, phase string) *arkv1alpha1.Query {
+		query := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: arkv1alpha1.QuerySpec{
+				Input:   runtime.RawExtension{Raw: []byte(`"What is the weather?"`)},
+				Targets: []arkv1alpha1.QueryTarget{{Type: "agent", Name: "weather-agent"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, query)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, query)
+
+		query.Status.Phase = phase
+		query.Status.TraceID = traceID
+		query.Status.Responses = []arkv1alpha1.Response{{Target: query.Spec.Targets[0], Content: "Sunny", Phase: statusDone}}
+		Expect(k8sClient.Status().Update(ctx, query)).To(Succeed())
+		return query
+	}
+
+	createFeedback := func(name string, queryRef arkv1alpha1.QueryRef, rating string) *arkv1alpha1.Feedback {
+		feedback := &arkv1alpha1.Feedback{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: arkv1alpha1.FeedbackSpec{
+				QueryRef: queryRef,
+				Rating:   rating,
+				Comment:  "Spot on",
+			},
+		}
+		Expect(k8sClient.Create(ctx, feedback)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, feedback)
+		return feedback
+	}
+
+	newReconciler := func(tracer *mock.MockTracer, scores *langfuse.ScoreClient) *FeedbackReconciler {
+		return &FeedbackReconciler{
+			Client:    k8sClient,
+			Scheme:    k8sClient.Scheme(),
+			Recorder:  record.NewFakeRecorder(10),
+			Telemetry: feedbackTelemetry{Provider: noop.NewProvider(), queryRecorder: mock.NewQueryRecorder(tracer)},
+			Scores:    scores,
+		}
+	}
+
+	getFeedback := func(feedback *arkv1alpha1.Feedback) *arkv1alpha1.Feedback {
+		updated := &arkv1alpha1.Feedback{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(feedback), updated)).To(Succeed())
+		return updated
+	}
+
+	It("should record feedback on a completed query to telemetry and Langfuse", func() {
+		var score langfuse.Score
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, _, _ := r.BasicAuth()
+			Expect(user).To(Equal("pk-test"))
+			Expect(r.URL.Path).To(Equal("/api/public/scores"))
+			Expect(json.NewDecoder(r.Body).Decode(&score)).To(Succeed())
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		query := createQuery("feedback-query", statusDone)
+		feedback := createFeedback("feedback-up", arkv1alpha1.QueryRef{Name: query.Name, ResponseTarget: "weather-agent"}, arkv1alpha1.FeedbackRatingPositive)
+
+		tracer := mock.NewTracer()
+		scores := &langfuse.ScoreClient{Host: server.URL, PublicKey: "pk-test", SecretKey: "sk-test", HTTPClient: server.Client()}
+		_, err := newReconciler(tracer, scores).Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(feedback)})
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := getFeedback(feedback)
+		Expect(updated.Status.Phase).To(Equal(arkv1alpha1.FeedbackPhaseRecorded))
+		Expect(updated.Status.TraceID).To(Equal(traceID))
+		Expect(updated.Labels).To(HaveKeyWithValue(annotations.Query, query.Name))
+		Expect(updated.OwnerReferences).To(HaveLen(1))
+		Expect(updated.OwnerReferences[0].UID).To(Equal(query.UID))
+
+		span := tracer.FindSpan("query.feedback")
+		Expect(span).NotTo(BeNil())
+		Expect(span.GetAttributeString(telemetry.AttrFeedbackRating)).To(Equal(arkv1alpha1.FeedbackRatingPositive))
+
+		Expect(score.TraceID).To(Equal(traceID))
+		Expect(score.Value).To(Equal(1.0))
+		Expect(score.Comment).To(Equal("Spot on"))
+		Expect(score.ID).To(Equal(string(updated.UID)))
+	})
+
+	It("should wait for the query to complete", func() {
+		query := createQuery("feedback-running-query", statusRunning)
+		feedback := createFeedback("feedback-running", arkv1alpha1.QueryRef{Name: query.Name}, arkv1alpha1.FeedbackRatingNegative)
+
+		tracer := mock.NewTracer()
+		result, err := newReconciler(tracer, nil).Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(feedback)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(feedbackPendingRequeue))
+		Expect(getFeedback(feedback).Status.Phase).To(Equal(arkv1alpha1.FeedbackPhasePending))
+		Expect(tracer.Spans).To(BeEmpty())
+	})
+
+	It("should reject feedback on a response the query does not have", func() {
+		query := createQuery("feedback-target-query", statusDone)
+		feedback := createFeedback("feedback-target", arkv1alpha1.QueryRef{Name: query.Name, ResponseTarget: "other-agent"}, arkv1alpha1.FeedbackRatingNegative)
+
+		_, err := newReconciler(mock.NewTracer(), nil).Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(feedback)})
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := getFeedback(feedback)
+		Expect(updated.Status.Phase).To(Equal(arkv1alpha1.FeedbackPhaseError))
+		Expect(updated.Status.Message).To(ContainSubstring("no response from other-agent"))
+	})
+})
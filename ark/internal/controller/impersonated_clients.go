@@ -0,0 +1,240 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"mckinsey.com/ark/internal/genai"
+)
+
+// DefaultImpersonatedClientTTL is how long an impersonated client is reused before it is built again
+const DefaultImpersonatedClientTTL = 10 * time.Minute
+
+var impersonatedClientConstruction = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "ark_impersonated_client_construction_seconds",
+	Help:    "Time taken to get the impersonated client of a query, by whether it was cached",
+	Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10),
+}, []string{"cache"})
+
+func init() {
+	metrics.Registry.MustRegister(impersonatedClientConstruction)
+}
+
+// impersonatedClientKey identifies the service account a client impersonates
+type impersonatedClientKey struct {
+	namespace      string
+	serviceAccount string
+}
+
+type impersonatedClientEntry struct {
+	client  client.Client
+	expires time.Time
+}
+
+// impersonatedClientCache reuses the impersonated client of a service account across queries, so that each
+// query does not build a new HTTP transport. Clients are rebuilt after the TTL to pick up rotated credentials.
+type impersonatedClientCache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[impersonatedClientKey]impersonatedClientEntry
+}
+
+func newImpersonatedClientCache(ttl time.Duration) *impersonatedClientCache {
+	if ttl <= 0 {
+		ttl = DefaultImpersonatedClientTTL
+	}
+	return &impersonatedClientCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[impersonatedClientKey]impersonatedClientEntry{},
+	}
+}
+
+// get returns the cached client of the key, or builds and caches a new one. Failed builds are not cached.
+func (c *impersonatedClientCache) get(key impersonatedClientKey, build func() (client.Client, error)) (client.Client, error) {
+	start := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok && start.Before(entry.expires) {
+		impersonatedClientConstruction.WithLabelValues("hit").Observe(c.now().Sub(start).Seconds())
+		return entry.client, nil
+	}
+	c.evictExpired(start)
+
+	impersonatedClient, err := build()
+	if err != nil {
+		return nil, err
+	}
+	c.entries[key] = impersonatedClientEntry{client: impersonatedClient, expires: start.Add(c.ttl)}
+	impersonatedClientConstruction.WithLabelValues("miss").Observe(c.now().Sub(start).Seconds())
+	return impersonatedClient, nil
+}
+
+// evictExpired drops the expired clients, so that service accounts no longer used do not keep theirs
+func (c *impersonatedClientCache) evictExpired(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// refreshingClient is an impersonated client that rebuilds itself from fresh credentials when the API server
+// rejects them, as happens when a long query outlives the controller's token. A call rejected again after the
+// rebuild fails with the ImpersonationExpired reason rather than a plain Unauthorized error.
+type refreshingClient struct {
+	identity string
+	build    func() (client.Client, error)
+	mu       sync.RWMutex
+	current  client.Client
+	// generation counts the refreshes, so that concurrent calls rejected together refresh only once
+	generation int
+}
+
+func newRefreshingClient(identity string, build func() (client.Client, error)) (*refreshingClient, error) {
+	initial, err := build()
+	if err != nil {
+		return nil, err
+	}
+	return &refreshingClient{identity: identity, build: build, current: initial}, nil
+}
+
+func (c *refreshingClient) get() client.Client {
+	current, _ := c.getWithGeneration()
+	return current
+}
+
+func (c *refreshingClient) getWithGeneration() (client.Client, int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.current, c.generation
+}
+
+// refresh rebuilds the client unless another call already replaced the one of the failed generation
+func (c *refreshingClient) refresh(failed int) (client.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation != failed {
+		return c.current, nil
+	}
+	refreshed, err := c.build()
+	if err != nil {
+		return nil, err
+	}
+	c.current = refreshed
+	c.generation++
+	return refreshed, nil
+}
+
+// do makes the call, and makes it again with refreshed credentials when they were rejected
+func (c *refreshingClient) do(call func(client.Client) error) error {
+	current, generation := c.getWithGeneration()
+	err := call(current)
+	if !apierrors.IsUnauthorized(err) {
+		return err
+	}
+
+	refreshed, refreshErr := c.refresh(generation)
+	if refreshErr != nil {
+		return genai.NewError(genai.ReasonImpersonationExpired, fmt.Errorf("credentials impersonating %s were rejected and could not be refreshed: %w", c.identity, refreshErr))
+	}
+	err = call(refreshed)
+	if apierrors.IsUnauthorized(err) {
+		return genai.NewError(genai.ReasonImpersonationExpired, fmt.Errorf("credentials impersonating %s were rejected after refreshing them: %w", c.identity, err))
+	}
+	return err
+}
+
+func (c *refreshingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.do(func(cl client.Client) error { return cl.Get(ctx, key, obj, opts...) })
+}
+
+func (c *refreshingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.do(func(cl client.Client) error { return cl.List(ctx, list, opts...) })
+}
+
+func (c *refreshingClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	return c.do(func(cl client.Client) error { return cl.Apply(ctx, obj, opts...) })
+}
+
+func (c *refreshingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.do(func(cl client.Client) error { return cl.Create(ctx, obj, opts...) })
+}
+
+func (c *refreshingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return c.do(func(cl client.Client) error { return cl.Delete(ctx, obj, opts...) })
+}
+
+func (c *refreshingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.do(func(cl client.Client) error { return cl.Update(ctx, obj, opts...) })
+}
+
+func (c *refreshingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.do(func(cl client.Client) error { return cl.Patch(ctx, obj, patch, opts...) })
+}
+
+func (c *refreshingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	return c.do(func(cl client.Client) error { return cl.DeleteAllOf(ctx, obj, opts...) })
+}
+
+func (c *refreshingClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *refreshingClient) SubResource(subResource string) client.SubResourceClient {
+	return &refreshingSubResourceClient{client: c, subResource: subResource}
+}
+
+func (c *refreshingClient) Scheme() *runtime.Scheme {
+	return c.get().Scheme()
+}
+
+func (c *refreshingClient) RESTMapper() meta.RESTMapper {
+	return c.get().RESTMapper()
+}
+
+func (c *refreshingClient) GroupVersionKindFor(obj runtime.Object) (schema.GroupVersionKind, error) {
+	return c.get().GroupVersionKindFor(obj)
+}
+
+func (c *refreshingClient) IsObjectNamespaced(obj runtime.Object) (bool, error) {
+	return c.get().IsObjectNamespaced(obj)
+}
+
+// refreshingSubResourceClient refreshes the credentials of the subresource calls of a refreshingClient
+type refreshingSubResourceClient struct {
+	client      *refreshingClient
+	subResource string
+}
+
+func (s *refreshingSubResourceClient) Get(ctx context.Context, obj, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	return s.client.do(func(cl client.Client) error { return cl.SubResource(s.subResource).Get(ctx, obj, subResource, opts...) })
+}
+
+func (s *refreshingSubResourceClient) Create(ctx context.Context, obj, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	return s.client.do(func(cl client.Client) error {
+		return cl.SubResource(s.subResource).Create(ctx, obj, subResource, opts...)
+	})
+}
+
+func (s *refreshingSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return s.client.do(func(cl client.Client) error { return cl.SubResource(s.subResource).Update(ctx, obj, opts...) })
+}
+
+func (s *refreshingSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return s.client.do(func(cl client.Client) error { return cl.SubResource(s.subResource).Patch(ctx, obj, patch, opts...) })
+}
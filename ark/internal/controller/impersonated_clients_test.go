@@ -0,0 +1,159 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"mckinsey.com/ark/internal/genai"
+)
+
+var _ = Describe("Impersonated client cache", func() {
+	var (
+		cache  *impersonatedClientCache
+		now    time.Time
+		builds int
+	)
+	key := impersonatedClientKey{namespace: "default", serviceAccount: "query-runner"}
+	build := func() (client.Client, error) {
+		builds++
+		return fake.NewClientBuilder().Build(), nil
+	}
+
+	BeforeEach(func() {
+		cache = newImpersonatedClientCache(time.Minute)
+		now = time.Date(2025, 10, 2, 10, 0, 0, 0, time.UTC)
+		cache.now = func() time.Time { return now }
+		builds = 0
+	})
+
+	It("should reuse the client of a service account within the TTL", func() {
+		first, err := cache.get(key, build)
+		Expect(err).NotTo(HaveOccurred())
+		now = now.Add(30 * time.Second)
+		second, err := cache.get(key, build)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(second).To(BeIdenticalTo(first))
+		Expect(builds).To(Equal(1))
+	})
+
+	It("should build separate clients for other service accounts", func() {
+		_, _ = cache.get(key, build)
+		_, _ = cache.get(impersonatedClientKey{namespace: "other", serviceAccount: "query-runner"}, build)
+		Expect(builds).To(Equal(2))
+	})
+
+	It("should rebuild the client once the TTL expired", func() {
+		first, _ := cache.get(key, build)
+		now = now.Add(time.Minute)
+		second, _ := cache.get(key, build)
+
+		Expect(second).NotTo(BeIdenticalTo(first))
+		Expect(builds).To(Equal(2))
+	})
+
+	It("should evict expired clients of other service accounts", func() {
+		_, _ = cache.get(impersonatedClientKey{namespace: "other", serviceAccount: "retired"}, build)
+		now = now.Add(2 * time.Minute)
+		_, _ = cache.get(key, build)
+
+		Expect(cache.entries).To(HaveLen(1))
+		Expect(cache.entries).To(HaveKey(key))
+	})
+
+	It("should not cache a failed build", func() {
+		_, err := cache.get(key, func() (client.Client, error) { return nil, errors.New("no config") })
+		Expect(err).To(MatchError("no config"))
+
+		_, err = cache.get(key, build)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(builds).To(Equal(1))
+	})
+})
+
+var _ = Describe("Refreshing impersonated client", func() {
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"}}
+	key := client.ObjectKeyFromObject(configMap)
+
+	// expiredClient rejects every call as the API server does once the credentials expired
+	expiredClient := func() client.Client {
+		unauthorized := func() error { return apierrors.NewUnauthorized("token expired") }
+		return interceptor.NewClient(fake.NewClientBuilder().WithObjects(configMap).Build(), interceptor.Funcs{
+			Get: func(ctx context.Context, _ client.WithWatch, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+				return unauthorized()
+			},
+			SubResourcePatch: func(ctx context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				return unauthorized()
+			},
+		})
+	}
+	validClient := func() client.Client {
+		return fake.NewClientBuilder().WithObjects(configMap).Build()
+	}
+	sequence := func(clients ...func() client.Client) (func() (client.Client, error), *int) {
+		builds := 0
+		return func() (client.Client, error) {
+			next := clients[min(builds, len(clients)-1)]
+			builds++
+			if next == nil {
+				return nil, errors.New("no in-cluster config")
+			}
+			return next(), nil
+		}, &builds
+	}
+
+	It("should refresh rejected credentials and retry the call", func() {
+		build, builds := sequence(expiredClient, validClient)
+		refreshing, err := newRefreshingClient("default/query-runner", build)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(refreshing.Get(context.Background(), key, &corev1.ConfigMap{})).To(Succeed())
+		Expect(refreshing.Get(context.Background(), key, &corev1.ConfigMap{})).To(Succeed())
+		Expect(*builds).To(Equal(2), "the refreshed client is kept")
+	})
+
+	It("should report expired impersonation when the refreshed credentials are rejected", func() {
+		build, _ := sequence(expiredClient)
+		refreshing, err := newRefreshingClient("default/query-runner", build)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = refreshing.Get(context.Background(), key, &corev1.ConfigMap{})
+		Expect(genai.ReasonFor(err)).To(Equal(genai.ReasonImpersonationExpired))
+		Expect(err.Error()).To(ContainSubstring("credentials impersonating default/query-runner were rejected after refreshing them"))
+
+		err = refreshing.Status().Patch(context.Background(), configMap, client.MergeFrom(configMap))
+		Expect(genai.ReasonFor(err)).To(Equal(genai.ReasonImpersonationExpired))
+	})
+
+	It("should report expired impersonation when the credentials cannot be refreshed", func() {
+		build, _ := sequence(expiredClient, nil)
+		refreshing, err := newRefreshingClient("default/query-runner", build)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = refreshing.Get(context.Background(), key, &corev1.ConfigMap{})
+		Expect(genai.ReasonFor(err)).To(Equal(genai.ReasonImpersonationExpired))
+		Expect(err.Error()).To(ContainSubstring("could not be refreshed: no in-cluster config"))
+	})
+
+	It("should pass other errors through without refreshing", func() {
+		build, builds := sequence(validClient)
+		refreshing, err := newRefreshingClient("default/query-runner", build)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = refreshing.Get(context.Background(), client.ObjectKey{Name: "missing", Namespace: "default"}, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(*builds).To(Equal(1))
+	})
+})
@@ -0,0 +1,234 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+const (
+	// Field index keys listing the Secrets and ConfigMaps a resource reads values from
+	secretRefIndexKey    = ".spec.secretRefs"
+	configMapRefIndexKey = ".spec.configMapRefs"
+
+	// Field index key holding the namespace/name of the service an MCPServer address resolves from
+	mcpServerServiceRefIndexKey = ".spec.address.serviceRef"
+
+	// Field index key holding the session id of a query
+	querySessionIdIndexKey = ".spec.sessionId"
+)
+
+// valueSourceRefs collects the names of Secrets and ConfigMaps referenced by value sources and headers
+type valueSourceRefs struct {
+	secrets    map[string]bool
+	configMaps map[string]bool
+}
+
+func newValueSourceRefs() *valueSourceRefs {
+	return &valueSourceRefs{
+		secrets:    make(map[string]bool),
+		configMaps: make(map[string]bool),
+	}
+}
+
+func (v *valueSourceRefs) addValueSource(source *arkv1alpha1.ValueSource) {
+	if source == nil || source.ValueFrom == nil {
+		return
+	}
+	v.addKeyRefs(source.ValueFrom.SecretKeyRef, source.ValueFrom.ConfigMapKeyRef)
+}
+
+func (v *valueSourceRefs) addValueSourceMap(sources map[string]arkv1alpha1.ValueSource) {
+	for _, source := range sources {
+		v.addValueSource(&source)
+	}
+}
+
+func (v *valueSourceRefs) addParameters(parameters []arkv1alpha1.Parameter) {
+	for _, parameter := range parameters {
+		if parameter.ValueFrom != nil {
+			v.addKeyRefs(parameter.ValueFrom.SecretKeyRef, parameter.ValueFrom.ConfigMapKeyRef)
+		}
+	}
+}
+
+func (v *valueSourceRefs) addHeaders(headers []arkv1alpha1.Header) {
+	for _, header := range headers {
+		if header.Value.ValueFrom != nil {
+			v.addKeyRefs(header.Value.ValueFrom.SecretKeyRef, header.Value.ValueFrom.ConfigMapKeyRef)
+		}
+	}
+}
+
+func (v *valueSourceRefs) addKeyRefs(secretRef *corev1.SecretKeySelector, configMapRef *corev1.ConfigMapKeySelector) {
+	if secretRef != nil && secretRef.Name != "" {
+		v.secrets[secretRef.Name] = true
+	}
+	if configMapRef != nil && configMapRef.Name != "" {
+		v.configMaps[configMapRef.Name] = true
+	}
+}
+
+func (v *valueSourceRefs) secretNames() []string {
+	return mapKeys(v.secrets)
+}
+
+func (v *valueSourceRefs) configMapNames() []string {
+	return mapKeys(v.configMaps)
+}
+
+func mapKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// modelValueSourceRefs returns the Secrets and ConfigMaps a Model resolves its configuration from
+func modelValueSourceRefs(model *arkv1alpha1.Model) *valueSourceRefs {
+	refs := newValueSourceRefs()
+	refs.addValueSource(&model.Spec.Model)
+
+	if openai := model.Spec.Config.OpenAI; openai != nil {
+		refs.addValueSource(&openai.BaseURL)
+		refs.addValueSource(&openai.APIKey)
+		refs.addHeaders(openai.Headers)
+		refs.addValueSourceMap(openai.Properties)
+	}
+
+	if azure := model.Spec.Config.Azure; azure != nil {
+		refs.addValueSource(&azure.BaseURL)
+		refs.addValueSource(&azure.APIKey)
+		refs.addValueSource(azure.APIVersion)
+		refs.addHeaders(azure.Headers)
+		refs.addValueSourceMap(azure.Properties)
+	}
+
+	if gateway := model.Spec.Config.Gateway; gateway != nil {
+		refs.addValueSource(&gateway.BaseURL)
+		refs.addValueSource(gateway.APIKey)
+		refs.addHeaders(gateway.Headers)
+		refs.addValueSourceMap(gateway.Properties)
+	}
+
+	if bedrock := model.Spec.Config.Bedrock; bedrock != nil {
+		refs.addValueSource(bedrock.Region)
+		refs.addValueSource(bedrock.BaseURL)
+		refs.addValueSource(bedrock.AccessKeyID)
+		refs.addValueSource(bedrock.SecretAccessKey)
+		refs.addValueSource(bedrock.SessionToken)
+		refs.addValueSource(bedrock.ModelArn)
+		refs.addValueSourceMap(bedrock.Properties)
+	}
+
+	return refs
+}
+
+// mcpServerValueSourceRefs returns the Secrets and ConfigMaps an MCPServer resolves its address, headers, credentials
+// and client certificate from
+func mcpServerValueSourceRefs(mcpServer *arkv1alpha1.MCPServer) *valueSourceRefs {
+	refs := newValueSourceRefs()
+	refs.addValueSource(&mcpServer.Spec.Address)
+	refs.addHeaders(mcpServer.Spec.Headers)
+	if auth := mcpServer.Spec.Auth; auth != nil && auth.OAuth2 != nil {
+		refs.addValueSource(&auth.OAuth2.ClientID)
+		refs.addValueSource(&auth.OAuth2.ClientSecret)
+	}
+	if clientTLS := mcpServer.Spec.TLS; clientTLS != nil && clientTLS.SecretName != "" {
+		refs.secrets[clientTLS.SecretName] = true
+	}
+	return refs
+}
+
+// agentValueSourceRefs returns the Secrets and ConfigMaps an Agent resolves its parameters and override headers from
+func agentValueSourceRefs(agent *arkv1alpha1.Agent) *valueSourceRefs {
+	refs := newValueSourceRefs()
+	refs.addParameters(agent.Spec.Parameters)
+	for _, override := range agent.Spec.Overrides {
+		refs.addHeaders(override.Headers)
+	}
+	// Rebinding a model alias changes the model the agent depends on
+	if agent.Spec.ModelRef != nil {
+		refs.configMaps[genai.ModelAliasesConfigMapName] = true
+	}
+	return refs
+}
+
+// indexQuerySessionId extracts the session id index value of a query
+func indexQuerySessionId(obj client.Object) []string {
+	if sessionId := obj.(*arkv1alpha1.Query).Spec.SessionId; sessionId != "" {
+		return []string{sessionId}
+	}
+	return nil
+}
+
+// indexValueSourceRefs registers the secret and configmap reference indexes for a resource type
+func indexValueSourceRefs(mgr ctrl.Manager, obj client.Object, extract func(client.Object) *valueSourceRefs) error {
+	indexer := mgr.GetFieldIndexer()
+	ctx := context.Background()
+
+	if err := indexer.IndexField(ctx, obj, secretRefIndexKey, func(o client.Object) []string {
+		return extract(o).secretNames()
+	}); err != nil {
+		return err
+	}
+
+	return indexer.IndexField(ctx, obj, configMapRefIndexKey, func(o client.Object) []string {
+		return extract(o).configMapNames()
+	})
+}
+
+// findDependentsForValueSource maps a Secret or ConfigMap to the indexed resources in its namespace that reference it
+func findDependentsForValueSource(ctx context.Context, c client.Client, obj client.Object, list client.ObjectList, controllerName string) []reconcile.Request {
+	var indexKey string
+	switch obj.(type) {
+	case *corev1.Secret:
+		indexKey = secretRefIndexKey
+	case *corev1.ConfigMap:
+		indexKey = configMapRefIndexKey
+	default:
+		return nil
+	}
+
+	log := logf.Log.WithName(controllerName).WithValues("source", obj.GetName(), "namespace", obj.GetNamespace())
+
+	if err := c.List(ctx, list, client.InNamespace(obj.GetNamespace()), client.MatchingFields{indexKey: obj.GetName()}); err != nil {
+		log.Error(err, "Failed to list dependents for value source")
+		return nil
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		log.Error(err, "Failed to extract dependents for value source")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range items {
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      accessor.GetName(),
+				Namespace: accessor.GetNamespace(),
+			},
+		})
+		log.V(1).Info("Triggering reconciliation for resource dependent on value source", "resource", accessor.GetName())
+	}
+
+	return requests
+}
@@ -0,0 +1,181 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+var _ = Describe("Value source reference indexes", func() {
+	secretSource := func(name string) arkv1alpha1.ValueSource {
+		return arkv1alpha1.ValueSource{
+			ValueFrom: &arkv1alpha1.ValueFromSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name},
+					Key:                  "token",
+				},
+			},
+		}
+	}
+
+	configMapHeader := func(name string) arkv1alpha1.Header {
+		return arkv1alpha1.Header{
+			Name: "X-Tenant",
+			Value: arkv1alpha1.HeaderValue{
+				ValueFrom: &arkv1alpha1.HeaderValueSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: name},
+						Key:                  "tenant",
+					},
+				},
+			},
+		}
+	}
+
+	It("should collect secrets and configmaps referenced by a model", func() {
+		model := &arkv1alpha1.Model{
+			Spec: arkv1alpha1.ModelSpec{
+				Model: arkv1alpha1.ValueSource{Value: "gpt-4o"},
+				Type:  "openai",
+				Config: arkv1alpha1.ModelConfig{
+					OpenAI: &arkv1alpha1.OpenAIModelConfig{
+						BaseURL: arkv1alpha1.ValueSource{Value: "https://api.openai.com/v1"},
+						APIKey:  secretSource("openai-secret"),
+						Headers: []arkv1alpha1.Header{configMapHeader("tenant-config")},
+					},
+				},
+			},
+		}
+
+		refs := modelValueSourceRefs(model)
+		Expect(refs.secretNames()).To(ConsistOf("openai-secret"))
+		Expect(refs.configMapNames()).To(ConsistOf("tenant-config"))
+	})
+
+	It("should collect references from MCPServer address and headers", func() {
+		mcpServer := &arkv1alpha1.MCPServer{
+			Spec: arkv1alpha1.MCPServerSpec{
+				Address: secretSource("mcp-address"),
+				Headers: []arkv1alpha1.Header{configMapHeader("mcp-headers")},
+			},
+		}
+
+		refs := mcpServerValueSourceRefs(mcpServer)
+		Expect(refs.secretNames()).To(ConsistOf("mcp-address"))
+		Expect(refs.configMapNames()).To(ConsistOf("mcp-headers"))
+	})
+
+	It("should collect references from MCPServer OAuth2 client credentials", func() {
+		mcpServer := &arkv1alpha1.MCPServer{
+			Spec: arkv1alpha1.MCPServerSpec{
+				Address: arkv1alpha1.ValueSource{Value: "https://mcp.example.com"},
+				Auth: &arkv1alpha1.MCPServerAuth{OAuth2: &arkv1alpha1.OAuth2ClientCredentials{
+					TokenURL:     "https://auth.example.com/oauth/token",
+					ClientID:     arkv1alpha1.ValueSource{Value: "ark"},
+					ClientSecret: secretSource("mcp-oauth"),
+				}},
+			},
+		}
+
+		refs := mcpServerValueSourceRefs(mcpServer)
+		Expect(refs.secretNames()).To(ConsistOf("mcp-oauth"))
+		Expect(refs.configMapNames()).To(BeEmpty())
+	})
+
+	It("should collect the client certificate secret of an MCPServer", func() {
+		mcpServer := &arkv1alpha1.MCPServer{
+			Spec: arkv1alpha1.MCPServerSpec{
+				Address: arkv1alpha1.ValueSource{Value: "https://mcp.example.com"},
+				TLS:     &arkv1alpha1.ClientTLS{SecretName: "mcp-client-tls"},
+			},
+		}
+
+		refs := mcpServerValueSourceRefs(mcpServer)
+		Expect(refs.secretNames()).To(ConsistOf("mcp-client-tls"))
+	})
+
+	It("should deduplicate references from agent parameters and overrides", func() {
+		source := secretSource("agent-secret")
+		agent := &arkv1alpha1.Agent{
+			Spec: arkv1alpha1.AgentSpec{
+				Parameters: []arkv1alpha1.Parameter{
+					{Name: "a", ValueFrom: source.ValueFrom},
+					{Name: "b", ValueFrom: source.ValueFrom},
+					{Name: "c", Value: "literal"},
+				},
+				Overrides: []arkv1alpha1.Override{
+					{ResourceType: "model", Headers: []arkv1alpha1.Header{configMapHeader("override-config")}},
+				},
+			},
+		}
+
+		refs := agentValueSourceRefs(agent)
+		Expect(refs.secretNames()).To(ConsistOf("agent-secret"))
+		Expect(refs.configMapNames()).To(ConsistOf("override-config"))
+	})
+})
+
+var _ = Describe("MCPServer service reference index", func() {
+	serviceMCPServer := func(name, namespace, serviceName, serviceNamespace string) *arkv1alpha1.MCPServer {
+		return &arkv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: arkv1alpha1.MCPServerSpec{
+				Address: arkv1alpha1.ValueSource{
+					ValueFrom: &arkv1alpha1.ValueFromSource{
+						ServiceRef: &arkv1alpha1.ServiceReference{Name: serviceName, Namespace: serviceNamespace},
+					},
+				},
+			},
+		}
+	}
+
+	It("should key a service reference by namespace and name, defaulting to the MCPServer namespace", func() {
+		Expect(mcpServerServiceRefKey(serviceMCPServer("mcp", "team-a", "tools", ""))).To(Equal("team-a/tools"))
+		Expect(mcpServerServiceRefKey(serviceMCPServer("mcp", "team-a", "tools", "shared"))).To(Equal("shared/tools"))
+	})
+
+	It("should not index MCPServers with a literal address", func() {
+		mcpServer := &arkv1alpha1.MCPServer{
+			Spec: arkv1alpha1.MCPServerSpec{Address: arkv1alpha1.ValueSource{Value: "http://tools:8080/mcp"}},
+		}
+		Expect(mcpServerServiceRefKey(mcpServer)).To(BeEmpty())
+	})
+
+	It("should only enqueue MCPServers referencing the changed service", func() {
+		scheme := runtime.NewScheme()
+		Expect(arkv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(
+				serviceMCPServer("local", "team-a", "tools", ""),
+				serviceMCPServer("remote", "team-b", "tools", "team-a"),
+				serviceMCPServer("other-namespace", "team-b", "tools", ""),
+				serviceMCPServer("other-service", "team-a", "search", ""),
+			).
+			WithIndex(&arkv1alpha1.MCPServer{}, mcpServerServiceRefIndexKey, indexMCPServerServiceRef).
+			Build()
+
+		reconciler := &MCPServerReconciler{Client: fakeClient}
+		requests := reconciler.findMCPServersForServiceName(context.Background(), "tools", "team-a")
+
+		var names []types.NamespacedName
+		for _, request := range requests {
+			names = append(names, request.NamespacedName)
+		}
+		Expect(names).To(ConsistOf(
+			types.NamespacedName{Name: "local", Namespace: "team-a"},
+			types.NamespacedName{Name: "remote", Namespace: "team-b"},
+		))
+	})
+})
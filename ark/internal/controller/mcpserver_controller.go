@@ -11,15 +11,19 @@ import (
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	"mckinsey.com/ark/internal/annotations"
@@ -49,6 +53,7 @@ type MCPServerReconciler struct {
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
 
 func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
@@ -64,6 +69,16 @@ func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
+	// Do not regenerate tools that the offboarding controller is tearing down
+	offboarding, err := isNamespaceOffboarding(ctx, r.Client, mcpServer.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if offboarding {
+		log.Info("namespace is being offboarded, skipping tool discovery", "server", mcpServer.Name)
+		return ctrl.Result{}, nil
+	}
+
 	if len(mcpServer.Status.Conditions) == 0 {
 		r.setCondition(&mcpServer, MCPServerReady, metav1.ConditionFalse, "Initializing", "MCPServer is being initialized")
 		r.setCondition(&mcpServer, MCPServerDiscovering, metav1.ConditionTrue, "Starting", "Starting tool discovery process")
@@ -110,8 +125,7 @@ func (r *MCPServerReconciler) processServer(ctx context.Context, mcpServer arkv1
 	log := logf.FromContext(ctx)
 	log.Info("mcp tools discover", "server", mcpServer.Name, "namespace", mcpServer.Namespace)
 
-	resolver := r.getResolver()
-	resolvedAddress, err := resolver.ResolveValueSource(ctx, mcpServer.Spec.Address, mcpServer.Namespace)
+	resolvedAddress, err := r.resolveAddress(ctx, &mcpServer)
 	if err != nil {
 		log.Error(err, "failed to resolve MCPServer address", "server", mcpServer.Name)
 		r.setCondition(&mcpServer, MCPServerReady, metav1.ConditionFalse, "AddressResolutionFailed", "Server not ready due to address resolution failure")
@@ -123,12 +137,22 @@ func (r *MCPServerReconciler) processServer(ctx context.Context, mcpServer arkv1
 	}
 
 	mcpServer.Status.ResolvedAddress = resolvedAddress
-	mcpClient, err := r.createMCPClient(ctx, &mcpServer)
+	ctx, err = genai.WithNamespaceRequestTags(ctx, r.Client, mcpServer.Namespace)
+	if err != nil {
+		log.Error(err, "invalid request tags, outbound requests are not tagged", "server", mcpServer.Name)
+	}
+	mcpClient, releaseMCPClient, err := r.acquireMCPClient(ctx, &mcpServer)
 	if err != nil {
 		log.Error(err, "mcp client creation failed", "server", mcpServer.Name)
 		mcpServer.Status.ToolCount = 0
-		r.setCondition(&mcpServer, MCPServerReady, metav1.ConditionFalse, "ClientCreationFailed", "Server not ready due to client creation failure")
-		r.setCondition(&mcpServer, MCPServerDiscovering, metav1.ConditionFalse, "ClientCreationFailed", "Cannot attempt discovery due to client creation failure")
+		if genai.IsUnsupportedProtocolVersion(err) {
+			r.Recorder.Event(&mcpServer, corev1.EventTypeWarning, "UnsupportedProtocolVersion", err.Error())
+			r.setCondition(&mcpServer, MCPServerReady, metav1.ConditionFalse, "UnsupportedProtocolVersion", fmt.Sprintf("Server not ready, it negotiated a protocol version ark does not support: %v", err))
+			r.setCondition(&mcpServer, MCPServerDiscovering, metav1.ConditionFalse, "UnsupportedProtocolVersion", "Cannot attempt discovery due to an unsupported protocol version")
+		} else {
+			r.setCondition(&mcpServer, MCPServerReady, metav1.ConditionFalse, "ClientCreationFailed", "Server not ready due to client creation failure")
+			r.setCondition(&mcpServer, MCPServerDiscovering, metav1.ConditionFalse, "ClientCreationFailed", "Cannot attempt discovery due to client creation failure")
+		}
 		if err := r.updateStatus(ctx, &mcpServer); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -138,8 +162,11 @@ func (r *MCPServerReconciler) processServer(ctx context.Context, mcpServer arkv1
 		}
 		return ctrl.Result{RequeueAfter: mcpServer.Spec.PollInterval.Duration}, nil
 	}
+	defer releaseMCPClient()
 
-	mcpTools, err := mcpClient.ListTools(ctx)
+	r.recordNegotiation(&mcpServer, mcpClient)
+
+	mcpTools, err := r.listTools(ctx, mcpClient, &mcpServer)
 	if err != nil {
 		r.setCondition(&mcpServer, MCPServerDiscovering, metav1.ConditionTrue, "ServerConnectedAndToolListingFailed", err.Error())
 		r.setCondition(&mcpServer, MCPServerReady, metav1.ConditionFalse, "ToolListingFailed", "Server not ready due to tool listing failure")
@@ -158,9 +185,104 @@ func (r *MCPServerReconciler) processServer(ctx context.Context, mcpServer arkv1
 		return ctrl.Result{RequeueAfter: mcpServer.Spec.PollInterval.Duration}, nil
 	}
 
+	r.discoverPromptsAndResources(ctx, mcpClient, &mcpServer)
+
 	return r.finalizeMCPServerProcessing(ctx, mcpServer, len(mcpTools))
 }
 
+// discoverPromptsAndResources lists the prompts and resources of the server into its status. Tools are what
+// makes the server usable, so a failure here keeps the previously discovered entries instead of failing discovery.
+// recordNegotiation records the protocol version, server info and capabilities negotiated with the server,
+// with a warning when the negotiated version changes to one older than the latest ark supports
+func (r *MCPServerReconciler) recordNegotiation(mcpServer *arkv1alpha1.MCPServer, mcpClient *genai.MCPClient) {
+	version := mcpClient.ProtocolVersion()
+	if version != mcpServer.Status.ProtocolVersion && version != "" && version < genai.MCPLatestProtocolVersion {
+		r.Recorder.Event(mcpServer, corev1.EventTypeWarning, "ProtocolVersionSkew",
+			fmt.Sprintf("server negotiated MCP protocol version %s, older than the latest version %s", version, genai.MCPLatestProtocolVersion))
+	}
+	mcpServer.Status.ProtocolVersion = version
+	mcpServer.Status.ServerInfo = nil
+	if info := mcpClient.ServerInfo(); info != nil {
+		mcpServer.Status.ServerInfo = &arkv1alpha1.MCPServerInfo{Name: info.Name, Version: info.Version}
+	}
+	mcpServer.Status.Capabilities = mcpClient.Capabilities()
+}
+
+// listTools lists the tools of the server. A server that does not offer tools has none, with a warning, so
+// that the tools it offered before are deleted.
+func (r *MCPServerReconciler) listTools(ctx context.Context, mcpClient *genai.MCPClient, mcpServer *arkv1alpha1.MCPServer) ([]*mcp.Tool, error) {
+	if !mcpClient.SupportsTools() {
+		r.Recorder.Event(mcpServer, corev1.EventTypeWarning, "ToolsNotSupported", "server does not offer the tools capability, no tools are discovered")
+		return nil, nil
+	}
+	return mcpClient.ListTools(ctx)
+}
+
+func (r *MCPServerReconciler) discoverPromptsAndResources(ctx context.Context, mcpClient *genai.MCPClient, mcpServer *arkv1alpha1.MCPServer) {
+	log := logf.FromContext(ctx)
+
+	if prompts, err := mcpClient.ListPrompts(ctx); err != nil {
+		log.Error(err, "failed to list MCP prompts", "server", mcpServer.Name)
+		r.Recorder.Event(mcpServer, corev1.EventTypeWarning, "PromptListingFailed", err.Error())
+	} else {
+		mcpServer.Status.Prompts = convertMCPPrompts(prompts)
+	}
+
+	if resources, templates, err := mcpClient.ListResources(ctx); err != nil {
+		log.Error(err, "failed to list MCP resources", "server", mcpServer.Name)
+		r.Recorder.Event(mcpServer, corev1.EventTypeWarning, "ResourceListingFailed", err.Error())
+	} else {
+		mcpServer.Status.Resources = convertMCPResources(resources)
+		mcpServer.Status.ResourceTemplates = convertMCPResourceTemplates(templates)
+	}
+}
+
+func convertMCPPrompts(prompts []*mcp.Prompt) []arkv1alpha1.MCPPrompt {
+	var converted []arkv1alpha1.MCPPrompt
+	for _, prompt := range prompts {
+		var arguments []arkv1alpha1.MCPPromptArgument
+		for _, argument := range prompt.Arguments {
+			arguments = append(arguments, arkv1alpha1.MCPPromptArgument{
+				Name:        argument.Name,
+				Description: argument.Description,
+				Required:    argument.Required,
+			})
+		}
+		converted = append(converted, arkv1alpha1.MCPPrompt{
+			Name:        prompt.Name,
+			Description: prompt.Description,
+			Arguments:   arguments,
+		})
+	}
+	return converted
+}
+
+func convertMCPResources(resources []*mcp.Resource) []arkv1alpha1.MCPResource {
+	var converted []arkv1alpha1.MCPResource
+	for _, resource := range resources {
+		converted = append(converted, arkv1alpha1.MCPResource{
+			URI:         resource.URI,
+			Name:        resource.Name,
+			Description: resource.Description,
+			MIMEType:    resource.MIMEType,
+		})
+	}
+	return converted
+}
+
+func convertMCPResourceTemplates(templates []*mcp.ResourceTemplate) []arkv1alpha1.MCPResourceTemplate {
+	var converted []arkv1alpha1.MCPResourceTemplate
+	for _, template := range templates {
+		converted = append(converted, arkv1alpha1.MCPResourceTemplate{
+			URITemplate: template.URITemplate,
+			Name:        template.Name,
+			Description: template.Description,
+			MIMEType:    template.MIMEType,
+		})
+	}
+	return converted
+}
+
 // setCondition sets a condition on the MCPServer
 func (r *MCPServerReconciler) setCondition(mcpServer *arkv1alpha1.MCPServer, conditionType string, status metav1.ConditionStatus, reason, message string) {
 	meta.SetStatusCondition(&mcpServer.Status.Conditions, metav1.Condition{
@@ -184,37 +306,58 @@ func (r *MCPServerReconciler) updateStatus(ctx context.Context, mcpServer *arkv1
 	return err
 }
 
-func (r *MCPServerReconciler) createMCPClient(ctx context.Context, mcpServer *arkv1alpha1.MCPServer) (*genai.MCPClient, error) {
+// resolveAddress resolves the address of the server; servers with the stdio transport have none
+func (r *MCPServerReconciler) resolveAddress(ctx context.Context, mcpServer *arkv1alpha1.MCPServer) (string, error) {
+	if genai.UsesStdioTransport(mcpServer) {
+		return "", nil
+	}
+	return r.getResolver().ResolveValueSource(ctx, mcpServer.Spec.Address, mcpServer.Namespace)
+}
+
+// acquireMCPClient returns a client from the MCP connection pool shared with query execution, and the function
+// that gives it back
+func (r *MCPServerReconciler) acquireMCPClient(ctx context.Context, mcpServer *arkv1alpha1.MCPServer) (*genai.MCPClient, func(), error) {
 	mcpURL, err := genai.BuildMCPServerURL(ctx, r.Client, mcpServer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build MCP server URL: %v", err)
+		return nil, nil, fmt.Errorf("failed to build MCP server URL: %v", err)
 	}
 
 	headers := make(map[string]string)
 	if len(mcpServer.Spec.Headers) > 0 {
 		resolvedHeaders, err := r.resolveHeaders(ctx, mcpServer)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		headers = resolvedHeaders
 	}
 
+	auth, err := genai.ResolveMCPServerAuth(ctx, r.Client, mcpServer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientTLS, err := common.ResolveClientTLS(ctx, r.Client, mcpServer.Spec.TLS, mcpServer.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Parse timeout from MCPServer spec (default to 30s if not specified)
 	timeout := 30 * time.Second
 	if mcpServer.Spec.Timeout != "" {
 		parsedTimeout, err := time.ParseDuration(mcpServer.Spec.Timeout)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse timeout %s: %w", mcpServer.Spec.Timeout, err)
+			return nil, nil, fmt.Errorf("failed to parse timeout %s: %w", mcpServer.Spec.Timeout, err)
 		}
 		timeout = parsedTimeout
 	}
 
 	// MCP settings are not needed for listing tools, etc.
-	mcpClient, err := genai.NewMCPClient(ctx, mcpURL, headers, mcpServer.Spec.Transport, timeout, genai.MCPSettings{})
+	mcpClient, release, err := genai.AcquireMCPClient(ctx, mcpURL, mcpServer.Spec.Command, headers, auth, clientTLS, mcpServer.Spec.Transport, timeout, genai.MCPSettings{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create MCP client: %w", err)
+		genai.RecordMCPConnectionFailure(mcpServer.Namespace, mcpServer.Name, mcpServer.Spec.Transport)
+		return nil, nil, fmt.Errorf("failed to create MCP client: %w", err)
 	}
-	return mcpClient, nil
+	return mcpClient, release, nil
 }
 
 func (r *MCPServerReconciler) resolveHeaders(ctx context.Context, mcpServer *arkv1alpha1.MCPServer) (map[string]string, error) {
@@ -303,9 +446,10 @@ func (r *MCPServerReconciler) buildToolCRD(mcpServer *arkv1alpha1.MCPServer, mcp
 			Annotations: toolAnnotations,
 		},
 		Spec: arkv1alpha1.ToolSpec{
-			Type:        "mcp",
-			Description: mcpTool.Description,
-			InputSchema: r.convertInputSchemaToRawExtension(mcpTool.InputSchema),
+			Type:             "mcp",
+			Description:      mcpTool.Description,
+			InputSchema:      r.convertInputSchemaToRawExtension(mcpTool.InputSchema),
+			ArgumentCoercion: mcpServer.Spec.ArgumentCoercion,
 			MCP: &arkv1alpha1.MCPToolRef{
 				MCPServerRef: arkv1alpha1.MCPServerRef{
 					Name:      mcpServer.Name,
@@ -368,8 +512,114 @@ func (r *MCPServerReconciler) convertInputSchemaToRawExtension(schema any) *runt
 }
 
 func (r *MCPServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := indexValueSourceRefs(mgr, &arkv1alpha1.MCPServer{}, func(obj client.Object) *valueSourceRefs {
+		return mcpServerValueSourceRefs(obj.(*arkv1alpha1.MCPServer))
+	}); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &arkv1alpha1.MCPServer{}, mcpServerServiceRefIndexKey, indexMCPServerServiceRef); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&arkv1alpha1.MCPServer{}).
+		// Watch for Service events so address changes are re-resolved immediately
+		Watches(
+			&corev1.Service{},
+			handler.EnqueueRequestsFromMapFunc(r.findMCPServersForService),
+		).
+		// Watch for EndpointSlice events so discovery re-runs once backends become ready
+		Watches(
+			&discoveryv1.EndpointSlice{},
+			handler.EnqueueRequestsFromMapFunc(r.findMCPServersForEndpointSlice),
+		).
+		// Watch for Secret and ConfigMap events so header and address updates trigger rediscovery
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findMCPServersForValueSource),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findMCPServersForValueSource),
+		).
 		Named("mcpserver").
 		Complete(r)
 }
+
+// findMCPServersForValueSource finds MCPServers that resolve their address or headers from the given Secret or ConfigMap
+func (r *MCPServerReconciler) findMCPServersForValueSource(ctx context.Context, obj client.Object) []reconcile.Request {
+	return findDependentsForValueSource(ctx, r.Client, obj, &arkv1alpha1.MCPServerList{}, "mcpserver-controller")
+}
+
+// findMCPServersForService finds MCPServers whose address references the given service
+func (r *MCPServerReconciler) findMCPServersForService(ctx context.Context, obj client.Object) []reconcile.Request {
+	service, ok := obj.(*corev1.Service)
+	if !ok {
+		return nil
+	}
+
+	return r.findMCPServersForServiceName(ctx, service.Name, service.Namespace)
+}
+
+// findMCPServersForEndpointSlice finds MCPServers whose address references the service backing the given EndpointSlice
+func (r *MCPServerReconciler) findMCPServersForEndpointSlice(ctx context.Context, obj client.Object) []reconcile.Request {
+	endpointSlice, ok := obj.(*discoveryv1.EndpointSlice)
+	if !ok {
+		return nil
+	}
+
+	serviceName := endpointSlice.Labels[discoveryv1.LabelServiceName]
+	if serviceName == "" {
+		return nil
+	}
+
+	return r.findMCPServersForServiceName(ctx, serviceName, endpointSlice.Namespace)
+}
+
+// findMCPServersForServiceName finds MCPServers through the service reference index, which spans namespaces since a service reference may point to another namespace
+func (r *MCPServerReconciler) findMCPServersForServiceName(ctx context.Context, serviceName, serviceNamespace string) []reconcile.Request {
+	log := logf.Log.WithName("mcpserver-controller").WithValues("service", serviceName, "namespace", serviceNamespace)
+
+	var mcpServerList arkv1alpha1.MCPServerList
+	if err := r.List(ctx, &mcpServerList, client.MatchingFields{mcpServerServiceRefIndexKey: serviceNamespace + "/" + serviceName}); err != nil {
+		log.Error(err, "Failed to list MCPServers for service dependency check")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, mcpServer := range mcpServerList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      mcpServer.Name,
+				Namespace: mcpServer.Namespace,
+			},
+		})
+		log.V(1).Info("Triggering reconciliation for MCPServer dependent on service", "mcpServer", mcpServer.Name)
+	}
+
+	return requests
+}
+
+// indexMCPServerServiceRef extracts the service reference index value of an MCPServer
+func indexMCPServerServiceRef(obj client.Object) []string {
+	if key := mcpServerServiceRefKey(obj.(*arkv1alpha1.MCPServer)); key != "" {
+		return []string{key}
+	}
+	return nil
+}
+
+// mcpServerServiceRefKey returns the namespace/name of the service an MCPServer address is resolved from, or "" if none
+func mcpServerServiceRefKey(mcpServer *arkv1alpha1.MCPServer) string {
+	valueFrom := mcpServer.Spec.Address.ValueFrom
+	if valueFrom == nil || valueFrom.ServiceRef == nil {
+		return ""
+	}
+
+	refNamespace := valueFrom.ServiceRef.Namespace
+	if refNamespace == "" {
+		refNamespace = mcpServer.Namespace
+	}
+
+	return refNamespace + "/" + valueFrom.ServiceRef.Name
+}
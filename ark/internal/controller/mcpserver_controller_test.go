@@ -0,0 +1,62 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+var _ = Describe("MCPServer Controller", func() {
+	ctx := context.Background()
+
+	It("should record the negotiated protocol and warn when the server offers no tools", func() {
+		server := mcp.NewServer(&mcp.Implementation{Name: "prompt-library", Version: "v0.3.0"}, &mcp.ServerOptions{HasPrompts: true})
+		httpServer := httptest.NewServer(mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, nil))
+		DeferCleanup(httpServer.Close)
+		// Discovery sessions are pooled and stay open, which would hold up closing the server
+		DeferCleanup(httpServer.CloseClientConnections)
+
+		mcpServer := &arkv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "prompt-library", Namespace: "default"},
+			Spec: arkv1alpha1.MCPServerSpec{
+				Address:      arkv1alpha1.ValueSource{Value: httpServer.URL},
+				Transport:    "http",
+				PollInterval: &metav1.Duration{},
+			},
+		}
+		Expect(k8sClient.Create(ctx, mcpServer)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, mcpServer)
+
+		recorder := record.NewFakeRecorder(100)
+		reconciler := &MCPServerReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), Recorder: recorder}
+		request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(mcpServer)}
+		for range 2 {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		updated := &arkv1alpha1.MCPServer{}
+		Expect(k8sClient.Get(ctx, request.NamespacedName, updated)).To(Succeed())
+		Expect(updated.Status.ProtocolVersion).To(Equal(genai.MCPLatestProtocolVersion))
+		Expect(updated.Status.ServerInfo).To(Equal(&arkv1alpha1.MCPServerInfo{Name: "prompt-library", Version: "v0.3.0"}))
+		Expect(updated.Status.Capabilities).To(ContainElement("prompts"))
+		Expect(updated.Status.Capabilities).NotTo(ContainElement("tools"))
+		Expect(updated.Status.ToolCount).To(BeZero())
+		Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, MCPServerReady)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("ToolsNotSupported")))
+	})
+})
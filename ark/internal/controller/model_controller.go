@@ -6,13 +6,16 @@ import (
 	"context"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	"mckinsey.com/ark/internal/genai"
@@ -43,12 +46,17 @@ func (r *ModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 	var model arkv1alpha1.Model
 	if err := r.Get(ctx, req.NamespacedName, &model); err != nil {
-		if client.IgnoreNotFound(err) != nil {
-			log.Error(err, "unable to fetch model", "model", req.NamespacedName)
+		if errors.IsNotFound(err) {
+			genai.SyncModelRateLimiter(req.Namespace, req.Name, nil)
+			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		log.Error(err, "unable to fetch model", "model", req.NamespacedName)
+		return ctrl.Result{}, err
 	}
 
+	// Apply rate limit changes to calls already sharing the model's limiter
+	genai.SyncModelRateLimiter(model.Namespace, model.Name, model.Spec.RateLimit)
+
 	// Initialize conditions if empty
 	if len(model.Status.Conditions) == 0 {
 		r.setCondition(&model, ModelAvailable, metav1.ConditionUnknown, "Initializing", "Model availability is being determined")
@@ -141,8 +149,28 @@ func (r *ModelReconciler) updateStatus(ctx context.Context, model *arkv1alpha1.M
 }
 
 func (r *ModelReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := indexValueSourceRefs(mgr, &arkv1alpha1.Model{}, func(obj client.Object) *valueSourceRefs {
+		return modelValueSourceRefs(obj.(*arkv1alpha1.Model))
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&arkv1alpha1.Model{}).
+		// Watch for Secret and ConfigMap events so credential updates trigger a new probe
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findModelsForValueSource),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findModelsForValueSource),
+		).
 		Named("model").
 		Complete(r)
 }
+
+// findModelsForValueSource finds models that resolve configuration from the given Secret or ConfigMap
+func (r *ModelReconciler) findModelsForValueSource(ctx context.Context, obj client.Object) []reconcile.Request {
+	return findDependentsForValueSource(ctx, r.Client, obj, &arkv1alpha1.ModelList{}, "model-controller")
+}
@@ -0,0 +1,245 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/genai"
+	"mckinsey.com/ark/internal/labels"
+)
+
+// offboardingRequeueInterval is how often offboarding checks whether canceled queries have finished
+const offboardingRequeueInterval = 5 * time.Second
+
+// NamespaceOffboardingReconciler decommissions namespaces labeled with ark.mckinsey.com/offboard=true.
+// It cancels running queries and waits for them to finish, then purges their memory sessions, flushes
+// archived queries and artifacts and deletes generated tools so that no conversation data is left
+// behind, and records completion with the offboarded-at annotation.
+type NamespaceOffboardingReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queries,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=tools,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=memories,verbs=get;list;watch
+
+func (r *NamespaceOffboardingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var namespace corev1.Namespace
+	if err := r.Get(ctx, req.NamespacedName, &namespace); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !isOffboarding(&namespace) || namespace.Annotations[annotations.OffboardedAt] != "" {
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("offboarding namespace", "namespace", namespace.Name)
+
+	var queries arkv1alpha1.QueryList
+	if err := r.List(ctx, &queries, client.InNamespace(namespace.Name)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list queries: %w", err)
+	}
+
+	canceled, running, err := r.cancelRunningQueries(ctx, queries.Items)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if running > 0 {
+		// Sessions are only purged once no query can write to memory anymore
+		log.Info("waiting for canceled queries to finish", "namespace", namespace.Name, "running", running, "canceled", canceled)
+		return ctrl.Result{RequeueAfter: offboardingRequeueInterval}, nil
+	}
+
+	purged, err := r.purgeMemorySessions(ctx, queries.Items)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	flushedQueries, flushedArtifacts, err := r.flushArchives(ctx, namespace.Name, queries.Items)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	deletedTools, err := r.deleteGeneratedTools(ctx, namespace.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if namespace.Annotations == nil {
+		namespace.Annotations = map[string]string{}
+	}
+	namespace.Annotations[annotations.OffboardedAt] = time.Now().UTC().Format(time.RFC3339)
+	if err := r.Update(ctx, &namespace); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	message := fmt.Sprintf("Purged %d memory sessions, flushed %d queries and %d artifact and snapshot ConfigMaps, deleted %d generated tools", purged, flushedQueries, flushedArtifacts, deletedTools)
+	r.Recorder.Event(&namespace, corev1.EventTypeNormal, "NamespaceOffboarded", message)
+	log.Info("namespace offboarded", "namespace", namespace.Name, "purgedSessions", purged, "flushedQueries", flushedQueries, "flushedArtifacts", flushedArtifacts, "deletedTools", deletedTools)
+
+	return ctrl.Result{}, nil
+}
+
+// cancelRunningQueries requests cancellation of every query that has not finished yet. It returns the
+// number of queries it canceled and the number of queries that are still running.
+func (r *NamespaceOffboardingReconciler) cancelRunningQueries(ctx context.Context, queries []arkv1alpha1.Query) (int, int, error) {
+	canceled, running := 0, 0
+	for i := range queries {
+		query := &queries[i]
+		switch query.Status.Phase {
+		case statusDone, statusError, statusCanceled:
+			continue
+		}
+		running++
+		if query.Spec.Cancel {
+			continue
+		}
+
+		patch := client.MergeFrom(query.DeepCopy())
+		query.Spec.Cancel = true
+		if err := r.Patch(ctx, query, patch); client.IgnoreNotFound(err) != nil {
+			return canceled, running, fmt.Errorf("failed to cancel query %s: %w", query.Name, err)
+		}
+		canceled++
+	}
+	return canceled, running, nil
+}
+
+// purgeMemorySessions deletes the memory session of every query from the memory it used
+func (r *NamespaceOffboardingReconciler) purgeMemorySessions(ctx context.Context, queries []arkv1alpha1.Query) (int, error) {
+	type session struct{ memory, namespace, id string }
+	sessions := map[session]bool{}
+
+	for _, query := range queries {
+		sessionID := query.Spec.SessionId
+		if sessionID == "" {
+			sessionID = string(query.UID)
+		}
+
+		memoryName, memoryNamespace := "default", query.Namespace
+		if query.Spec.Memory != nil {
+			memoryName = query.Spec.Memory.Name
+			if query.Spec.Memory.Namespace != "" {
+				memoryNamespace = query.Spec.Memory.Namespace
+			}
+		}
+		sessions[session{memory: memoryName, namespace: memoryNamespace, id: sessionID}] = true
+	}
+
+	purged := 0
+	for s := range sessions {
+		err := genai.DeleteMemorySession(ctx, r.Client, s.memory, s.namespace, s.id)
+		if errors.IsNotFound(err) {
+			// No memory configured for this query, so nothing was stored
+			continue
+		}
+		if err != nil {
+			return purged, fmt.Errorf("failed to purge session %s from memory %s/%s: %w", s.id, s.namespace, s.memory, err)
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// flushArchives deletes the finished queries, whose status holds the conversation, and the ConfigMaps
+// holding artifacts published by queries or snapshots of soft-deleted queries in the namespace
+func (r *NamespaceOffboardingReconciler) flushArchives(ctx context.Context, namespace string, queries []arkv1alpha1.Query) (int, int, error) {
+	flushedQueries := 0
+	for i := range queries {
+		if err := r.Delete(ctx, &queries[i]); client.IgnoreNotFound(err) != nil {
+			return flushedQueries, 0, fmt.Errorf("failed to delete query %s: %w", queries[i].Name, err)
+		}
+		flushedQueries++
+	}
+
+	flushedArtifacts := 0
+	for _, label := range []string{labels.QueryArtifactsLabel, labels.QuerySnapshotLabel} {
+		var configMaps corev1.ConfigMapList
+		if err := r.List(ctx, &configMaps, client.InNamespace(namespace), client.HasLabels{label}); err != nil {
+			return flushedQueries, flushedArtifacts, fmt.Errorf("failed to list %s ConfigMaps: %w", label, err)
+		}
+
+		for i := range configMaps.Items {
+			if err := r.Delete(ctx, &configMaps.Items[i]); client.IgnoreNotFound(err) != nil {
+				return flushedQueries, flushedArtifacts, fmt.Errorf("failed to delete %s ConfigMap %s: %w", label, configMaps.Items[i].Name, err)
+			}
+			flushedArtifacts++
+		}
+	}
+	return flushedQueries, flushedArtifacts, nil
+}
+
+// deleteGeneratedTools deletes tools that were generated from MCP servers
+func (r *NamespaceOffboardingReconciler) deleteGeneratedTools(ctx context.Context, namespace string) (int, error) {
+	var tools arkv1alpha1.ToolList
+	if err := r.List(ctx, &tools, client.InNamespace(namespace)); err != nil {
+		return 0, fmt.Errorf("failed to list tools: %w", err)
+	}
+
+	deleted := 0
+	for i := range tools.Items {
+		tool := &tools.Items[i]
+		if !isGeneratedTool(tool) {
+			continue
+		}
+		if err := r.Delete(ctx, tool); client.IgnoreNotFound(err) != nil {
+			return deleted, fmt.Errorf("failed to delete tool %s: %w", tool.Name, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// isGeneratedTool reports whether a tool is controlled by an MCPServer
+func isGeneratedTool(tool *arkv1alpha1.Tool) bool {
+	for _, ownerRef := range tool.OwnerReferences {
+		if ownerRef.Kind == "MCPServer" && ownerRef.Controller != nil && *ownerRef.Controller {
+			return true
+		}
+	}
+	return false
+}
+
+func isOffboarding(namespace *corev1.Namespace) bool {
+	return namespace.Labels[labels.OffboardLabel] == genai.TrueString
+}
+
+// isNamespaceOffboarding reports whether a namespace has been marked for offboarding, in which case
+// controllers must not generate new resources in it
+func isNamespaceOffboarding(ctx context.Context, c client.Client, name string) (bool, error) {
+	var namespace corev1.Namespace
+	if err := c.Get(ctx, client.ObjectKey{Name: name}, &namespace); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return isOffboarding(&namespace), nil
+}
+
+func (r *NamespaceOffboardingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Namespace{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetLabels()[labels.OffboardLabel] == genai.TrueString
+		}))).
+		Named("namespace-offboarding").
+		Complete(r)
+}
@@ -0,0 +1,78 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/labels"
+)
+
+var _ = Describe("Namespace offboarding", func() {
+	ctx := context.Background()
+
+	It("should wait for canceled queries to finish before flushing their data", func() {
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "offboarding-test",
+			Labels: map[string]string{labels.OffboardLabel: "true"},
+		}}
+		Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+
+		query := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "running-query", Namespace: namespace.Name},
+			Spec: arkv1alpha1.QuerySpec{
+				Input:   runtime.RawExtension{Raw: []byte(`"Summarize the report"`)},
+				Targets: []arkv1alpha1.QueryTarget{{Type: "agent", Name: "assistant"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, query)).To(Succeed())
+		query.Status.Phase = statusRunning
+		Expect(k8sClient.Status().Update(ctx, query)).To(Succeed())
+
+		artifacts := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:      "running-query-artifacts",
+			Namespace: namespace.Name,
+			Labels:    map[string]string{labels.QueryArtifactsLabel: query.Name},
+		}}
+		Expect(k8sClient.Create(ctx, artifacts)).To(Succeed())
+
+		reconciler := &NamespaceOffboardingReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), Recorder: record.NewFakeRecorder(10)}
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Name: namespace.Name}}
+
+		By("canceling the running query and requeueing")
+		result, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(offboardingRequeueInterval))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(query), query)).To(Succeed())
+		Expect(query.Spec.Cancel).To(BeTrue())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(artifacts), &corev1.ConfigMap{})).To(Succeed())
+
+		By("flushing the query and its artifacts once it has finished")
+		query.Status.Phase = statusCanceled
+		Expect(k8sClient.Status().Update(ctx, query)).To(Succeed())
+
+		result, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		Expect(errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(query), &arkv1alpha1.Query{}))).To(BeTrue())
+		Expect(errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(artifacts), &corev1.ConfigMap{}))).To(BeTrue())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(namespace), namespace)).To(Succeed())
+		Expect(namespace.Annotations).To(HaveKey(annotations.OffboardedAt))
+	})
+})
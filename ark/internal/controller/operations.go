@@ -0,0 +1,162 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// operationCheckInterval is how often running operations are checked against their resources
+const operationCheckInterval = 5 * time.Minute
+
+var (
+	activeOperations = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ark_controller_active_operations",
+		Help: "Number of operations a controller is running in the background",
+	}, []string{"controller"})
+
+	goroutinesStarted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ark_controller_goroutines_started_total",
+		Help: "Number of goroutines a controller started to execute its resources",
+	}, []string{"controller"})
+
+	goroutinesCompleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ark_controller_goroutines_completed_total",
+		Help: "Number of goroutines started by a controller that completed",
+	}, []string{"controller"})
+
+	orphanedOperations = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ark_controller_orphaned_operations",
+		Help: "Number of operations found by the last check whose resource is no longer running",
+	}, []string{"controller"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(activeOperations, goroutinesStarted, goroutinesCompleted, orphanedOperations)
+}
+
+// trackGoroutine counts a goroutine started by the controller. The returned function is deferred by the
+// goroutine to count its completion, so that the difference of the counters reveals leaked goroutines.
+func trackGoroutine(controller string) func() {
+	goroutinesStarted.WithLabelValues(controller).Inc()
+	return func() {
+		goroutinesCompleted.WithLabelValues(controller).Inc()
+	}
+}
+
+// operations holds the cancel functions of the resources a controller executes in the background, keyed by
+// resource, and keeps the active operations gauge in line with them
+type operations struct {
+	controller string
+	mu         sync.Mutex
+	cancels    map[types.NamespacedName]context.CancelFunc
+	// suspects are the operations the last check found without a running resource
+	suspects map[types.NamespacedName]bool
+}
+
+func newOperations(controller string) *operations {
+	return &operations{
+		controller: controller,
+		cancels:    map[types.NamespacedName]context.CancelFunc{},
+		suspects:   map[types.NamespacedName]bool{},
+	}
+}
+
+func (o *operations) exists(key types.NamespacedName) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	_, ok := o.cancels[key]
+	return ok
+}
+
+func (o *operations) store(key types.NamespacedName, cancel context.CancelFunc) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.cancels[key] = cancel
+	activeOperations.WithLabelValues(o.controller).Set(float64(len(o.cancels)))
+}
+
+// cancel cancels and removes the operation of the resource. It reports whether there was one.
+func (o *operations) cancel(key types.NamespacedName) bool {
+	o.mu.Lock()
+	cancel, ok := o.cancels[key]
+	o.mu.Unlock()
+	if ok {
+		cancel()
+	}
+	o.delete(key)
+	return ok
+}
+
+func (o *operations) delete(key types.NamespacedName) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.cancels, key)
+	delete(o.suspects, key)
+	activeOperations.WithLabelValues(o.controller).Set(float64(len(o.cancels)))
+}
+
+func (o *operations) keys() []types.NamespacedName {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	keys := make([]types.NamespacedName, 0, len(o.cancels))
+	for key := range o.cancels {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// check logs the operations whose resource is not running, as reported by running. An operation is only
+// reported once two checks in a row found it, since a resource completes just before its operation ends. It
+// returns the operations reported.
+func (o *operations) check(ctx context.Context, running func(context.Context, types.NamespacedName) (bool, error)) []types.NamespacedName {
+	log := logf.FromContext(ctx)
+	var orphaned []types.NamespacedName
+	suspects := map[types.NamespacedName]bool{}
+	for _, key := range o.keys() {
+		isRunning, err := running(ctx, key)
+		if err != nil {
+			log.Error(err, "failed to check operation", "controller", o.controller, "resource", key.String())
+			continue
+		}
+		if isRunning {
+			continue
+		}
+		suspects[key] = true
+
+		o.mu.Lock()
+		suspected := o.suspects[key]
+		o.mu.Unlock()
+		if suspected {
+			log.Info("operation has no running resource", "controller", o.controller, "resource", key.String())
+			orphaned = append(orphaned, key)
+		}
+	}
+
+	o.mu.Lock()
+	o.suspects = suspects
+	o.mu.Unlock()
+	orphanedOperations.WithLabelValues(o.controller).Set(float64(len(orphaned)))
+	return orphaned
+}
+
+// checkPeriodically checks the operations at every interval until ctx is done
+func (o *operations) checkPeriodically(ctx context.Context, interval time.Duration, running func(context.Context, types.NamespacedName) (bool, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			o.check(ctx, running)
+		}
+	}
+}
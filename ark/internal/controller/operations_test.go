@@ -0,0 +1,55 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Controller operations", func() {
+	first := types.NamespacedName{Name: "first", Namespace: "default"}
+	second := types.NamespacedName{Name: "second", Namespace: "default"}
+
+	It("should keep the active operations gauge in line with the operations", func() {
+		ops := newOperations("operations-test")
+		canceled := false
+		ops.store(first, func() { canceled = true })
+		ops.store(second, func() {})
+		Expect(testutil.ToFloat64(activeOperations.WithLabelValues("operations-test"))).To(Equal(2.0))
+
+		Expect(ops.cancel(first)).To(BeTrue())
+		Expect(canceled).To(BeTrue())
+		Expect(ops.cancel(first)).To(BeFalse(), "an operation is only canceled once")
+		ops.delete(second)
+		Expect(testutil.ToFloat64(activeOperations.WithLabelValues("operations-test"))).To(Equal(0.0))
+	})
+
+	It("should count started and completed goroutines", func() {
+		done := trackGoroutine("goroutines-test")
+		Expect(testutil.ToFloat64(goroutinesStarted.WithLabelValues("goroutines-test"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(goroutinesCompleted.WithLabelValues("goroutines-test"))).To(Equal(0.0))
+		done()
+		Expect(testutil.ToFloat64(goroutinesCompleted.WithLabelValues("goroutines-test"))).To(Equal(1.0))
+	})
+
+	It("should report operations found without a running resource by two checks in a row", func() {
+		ops := newOperations("check-test")
+		ops.store(first, func() {})
+		ops.store(second, func() {})
+		running := map[types.NamespacedName]bool{first: true}
+		isRunning := func(_ context.Context, key types.NamespacedName) (bool, error) { return running[key], nil }
+
+		Expect(ops.check(context.Background(), isRunning)).To(BeEmpty(), "the resource may just have completed")
+		Expect(ops.check(context.Background(), isRunning)).To(ConsistOf(second))
+		Expect(testutil.ToFloat64(orphanedOperations.WithLabelValues("check-test"))).To(Equal(1.0))
+
+		ops.delete(second)
+		Expect(ops.check(context.Background(), isRunning)).To(BeEmpty())
+		Expect(testutil.ToFloat64(orphanedOperations.WithLabelValues("check-test"))).To(Equal(0.0))
+	})
+})
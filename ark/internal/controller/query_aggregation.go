@@ -0,0 +1,108 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+// aggregateResponses combines the done responses of the targets with the query's aggregation strategy. Failed
+// and canceled responses are left out. Nothing is aggregated when the query has no aggregation strategy.
+func (r *QueryReconciler) aggregateResponses(ctx context.Context, query arkv1alpha1.Query, inputMessages []genai.Message, responses []arkv1alpha1.Response, impersonatedClient client.Client, tokenCollector *genai.TokenUsageCollector) *arkv1alpha1.AggregatedResponse {
+	config := query.Spec.Aggregation
+	if config == nil {
+		return nil
+	}
+
+	var done []arkv1alpha1.Response
+	for _, response := range responses {
+		if response.Phase == statusDone {
+			done = append(done, response)
+		}
+	}
+	aggregated := &arkv1alpha1.AggregatedResponse{Strategy: config.Strategy, Phase: statusDone}
+	if len(done) == 0 {
+		aggregated.Phase = statusError
+		aggregated.Message = "no target produced a response to aggregate"
+		return aggregated
+	}
+
+	switch config.Strategy {
+	case arkv1alpha1.AggregationConcat:
+		aggregated.Content = concatResponses(done)
+	case arkv1alpha1.AggregationVote:
+		content, votes := voteResponses(done)
+		aggregated.Content = content
+		aggregated.Message = fmt.Sprintf("%d of %d responses agreed", votes, len(done))
+	case arkv1alpha1.AggregationJudge:
+		content, err := r.judgeResponses(ctx, query, inputMessages, done, impersonatedClient, tokenCollector)
+		if err != nil {
+			logf.FromContext(ctx).Error(err, "failed to aggregate responses")
+			tokenCollector.EmitEvent(ctx, corev1.EventTypeWarning, "AggregationFailed", genai.BaseEvent{
+				Name:     query.Name,
+				Metadata: map[string]string{"error": err.Error()},
+			})
+			aggregated.Phase = statusError
+			aggregated.Message = err.Error()
+			return aggregated
+		}
+		aggregated.Content = content
+	}
+	return aggregated
+}
+
+// concatResponses joins the responses in target order, each under a heading naming its target
+func concatResponses(responses []arkv1alpha1.Response) string {
+	sections := make([]string, 0, len(responses))
+	for _, response := range responses {
+		sections = append(sections, fmt.Sprintf("## %s/%s\n\n%s", response.Target.Type, response.Target.Name, response.Content))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// voteResponses returns the response given by most targets and how many gave it. Responses are compared
+// ignoring case and whitespace and the winner is returned as first given; a tie goes to the response given first.
+func voteResponses(responses []arkv1alpha1.Response) (string, int) {
+	votes := make(map[string]int)
+	first := make(map[string]string)
+	winner, winnerVotes := "", 0
+	for _, response := range responses {
+		key := strings.ToLower(strings.Join(strings.Fields(response.Content), " "))
+		if _, seen := first[key]; !seen {
+			first[key] = response.Content
+		}
+		votes[key]++
+		if votes[key] > winnerVotes {
+			winner, winnerVotes = first[key], votes[key]
+		}
+	}
+	return winner, winnerVotes
+}
+
+// judgeResponses has the query's judge model synthesize one response
+func (r *QueryReconciler) judgeResponses(ctx context.Context, query arkv1alpha1.Query, inputMessages []genai.Message, responses []arkv1alpha1.Response, impersonatedClient client.Client, tokenCollector *genai.TokenUsageCollector) (string, error) {
+	var modelSpec any = ""
+	if query.Spec.Aggregation.JudgeRef != nil {
+		modelSpec = query.Spec.Aggregation.JudgeRef
+	}
+	model, err := genai.LoadModel(ctx, impersonatedClient, modelSpec, query.Namespace, nil, r.Telemetry.ModelRecorder())
+	if err != nil {
+		return "", err
+	}
+
+	answers := make([]string, 0, len(responses))
+	for _, response := range responses {
+		answers = append(answers, response.Content)
+	}
+	prompt := genai.GetBuiltinPrompt(ctx, impersonatedClient, query.Namespace, genai.PromptAggregation)
+	return genai.SynthesizeResponses(ctx, model, tokenCollector, prompt, genai.ExtractUserMessageContent(inputMessages), answers)
+}
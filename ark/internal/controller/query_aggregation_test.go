@@ -0,0 +1,60 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+var _ = Describe("Query aggregation", func() {
+	reconciler := &QueryReconciler{}
+	collector := genai.NewTokenUsageCollector(discardEventEmitter{})
+
+	response := func(name, content, phase string) arkv1alpha1.Response {
+		return arkv1alpha1.Response{Target: arkv1alpha1.QueryTarget{Type: "agent", Name: name}, Content: content, Phase: phase}
+	}
+	aggregate := func(strategy string, responses ...arkv1alpha1.Response) *arkv1alpha1.AggregatedResponse {
+		query := arkv1alpha1.Query{Spec: arkv1alpha1.QuerySpec{Aggregation: &arkv1alpha1.AggregationConfig{Strategy: strategy}}}
+		return reconciler.aggregateResponses(context.Background(), query, nil, responses, nil, collector)
+	}
+
+	It("should concatenate the done responses in target order", func() {
+		aggregated := aggregate(arkv1alpha1.AggregationConcat,
+			response("analyst", "Revenue grew.", statusDone),
+			response("broken", "timeout", statusError),
+			response("writer", "Costs fell.", statusDone))
+
+		Expect(aggregated.Phase).To(Equal(statusDone))
+		Expect(aggregated.Content).To(Equal("## agent/analyst\n\nRevenue grew.\n\n## agent/writer\n\nCosts fell."))
+	})
+
+	It("should pick the most common response by vote", func() {
+		aggregated := aggregate(arkv1alpha1.AggregationVote,
+			response("first", "Paris", statusDone),
+			response("second", "Lyon", statusDone),
+			response("third", " paris ", statusDone))
+
+		Expect(aggregated.Content).To(Equal("Paris"))
+		Expect(aggregated.Message).To(Equal("2 of 3 responses agreed"))
+
+		tie := aggregate(arkv1alpha1.AggregationVote, response("first", "Lyon", statusDone), response("second", "Paris", statusDone))
+		Expect(tie.Content).To(Equal("Lyon"))
+	})
+
+	It("should fail when no target produced a response", func() {
+		aggregated := aggregate(arkv1alpha1.AggregationJudge, response("broken", "timeout", statusError))
+
+		Expect(aggregated.Phase).To(Equal(statusError))
+		Expect(aggregated.Message).To(Equal("no target produced a response to aggregate"))
+	})
+
+	It("should not aggregate without a strategy", func() {
+		Expect(reconciler.aggregateResponses(context.Background(), arkv1alpha1.Query{}, nil, []arkv1alpha1.Response{response("analyst", "Revenue grew.", statusDone)}, nil, collector)).To(BeNil())
+	})
+})
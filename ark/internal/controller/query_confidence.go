@@ -0,0 +1,105 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+	"mckinsey.com/ark/internal/telemetry"
+)
+
+// targetConfidence is the estimated confidence in the response of a target
+type targetConfidence struct {
+	score       string
+	needsReview bool
+}
+
+// applyTo surfaces the confidence on a response. Nothing is recorded when the confidence was not estimated.
+func (c targetConfidence) applyTo(response *arkv1alpha1.Response) {
+	response.Confidence = c.score
+	response.NeedsReview = c.needsReview
+}
+
+// estimateConfidence scores the response of a target with the query's judge model and flags it when the score is
+// below the query's threshold. A failed estimation is reported with an event and leaves the response unscored,
+// since the target itself succeeded.
+func (r *QueryReconciler) estimateConfidence(ctx context.Context, query arkv1alpha1.Query, target arkv1alpha1.QueryTarget, inputMessages, responseMessages []genai.Message, impersonatedClient client.Client, span telemetry.Span, tokenCollector *genai.TokenUsageCollector) targetConfidence {
+	config := query.Spec.Confidence
+	if config == nil || len(responseMessages) == 0 {
+		return targetConfidence{}
+	}
+
+	var modelSpec any = ""
+	if config.ModelRef != nil {
+		modelSpec = config.ModelRef
+	}
+	var score float64
+	model, err := genai.LoadModel(ctx, impersonatedClient, modelSpec, query.Namespace, nil, r.Telemetry.ModelRecorder())
+	if err == nil {
+		prompt := genai.GetBuiltinPrompt(ctx, impersonatedClient, query.Namespace, genai.PromptConfidence)
+		request := genai.ExtractUserMessageContent(inputMessages)
+		answer := messageToText(responseMessages[len(responseMessages)-1])
+		score, err = genai.EstimateConfidence(ctx, model, tokenCollector, prompt, request, answer)
+	}
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to estimate confidence")
+		tokenCollector.EmitEvent(ctx, corev1.EventTypeWarning, "ConfidenceEstimationFailed", genai.ExecutionEvent{
+			BaseEvent: genai.BaseEvent{Name: target.Name, Metadata: map[string]string{"error": err.Error()}},
+			Type:      target.Type,
+		})
+		return targetConfidence{}
+	}
+
+	confidence := targetConfidence{score: strconv.FormatFloat(score, 'f', 2, 64)}
+	if config.Threshold != "" {
+		threshold, err := strconv.ParseFloat(config.Threshold, 64)
+		confidence.needsReview = err == nil && score < threshold
+	}
+	r.Telemetry.QueryRecorder().RecordConfidence(span, score, confidence.needsReview)
+
+	if confidence.needsReview {
+		tokenCollector.EmitEvent(ctx, corev1.EventTypeWarning, "ResponseNeedsReview", genai.ExecutionEvent{
+			BaseEvent: genai.BaseEvent{Name: target.Name, Metadata: map[string]string{
+				"confidence": confidence.score,
+				"threshold":  config.Threshold,
+			}},
+			Type: target.Type,
+		})
+	}
+	return confidence
+}
+
+// setConditionNeedsReview records whether a response of the query needs human review because of its low confidence
+func (r *QueryReconciler) setConditionNeedsReview(query *arkv1alpha1.Query) {
+	if query.Spec.Confidence == nil || query.Spec.Confidence.Threshold == "" {
+		return
+	}
+
+	status, reason, message := metav1.ConditionFalse, "ConfidenceSufficient", "All responses meet the confidence threshold"
+	for _, response := range query.Status.Responses {
+		if response.NeedsReview {
+			status, reason = metav1.ConditionTrue, "LowConfidence"
+			message = fmt.Sprintf("Response of %s %s has confidence %s, below the threshold of %s",
+				response.Target.Type, response.Target.Name, response.Confidence, query.Spec.Confidence.Threshold)
+			break
+		}
+	}
+	meta.SetStatusCondition(&query.Status.Conditions, metav1.Condition{
+		Type:               string(arkv1alpha1.QueryNeedsReview),
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: query.Generation,
+	})
+}
@@ -0,0 +1,55 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+var _ = Describe("Query confidence", func() {
+	reconciler := &QueryReconciler{}
+
+	newQuery := func(threshold string, responses ...arkv1alpha1.Response) *arkv1alpha1.Query {
+		return &arkv1alpha1.Query{
+			Spec:   arkv1alpha1.QuerySpec{Confidence: &arkv1alpha1.ConfidenceConfig{Threshold: threshold}},
+			Status: arkv1alpha1.QueryStatus{Responses: responses},
+		}
+	}
+
+	It("should flag the query when a response is below the threshold", func() {
+		confident := arkv1alpha1.Response{Target: arkv1alpha1.QueryTarget{Type: "agent", Name: "analyst"}}
+		targetConfidence{score: "0.91"}.applyTo(&confident)
+		unsure := arkv1alpha1.Response{Target: arkv1alpha1.QueryTarget{Type: "agent", Name: "writer"}}
+		targetConfidence{score: "0.42", needsReview: true}.applyTo(&unsure)
+
+		query := newQuery("0.7", confident, unsure)
+		reconciler.setConditionNeedsReview(query)
+
+		condition := meta.FindStatusCondition(query.Status.Conditions, string(arkv1alpha1.QueryNeedsReview))
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("LowConfidence"))
+		Expect(condition.Message).To(Equal("Response of agent writer has confidence 0.42, below the threshold of 0.7"))
+	})
+
+	It("should not flag the query when all responses meet the threshold", func() {
+		query := newQuery("0.7", arkv1alpha1.Response{Confidence: "0.91"})
+		reconciler.setConditionNeedsReview(query)
+
+		condition := meta.FindStatusCondition(query.Status.Conditions, string(arkv1alpha1.QueryNeedsReview))
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+	})
+
+	It("should not set the condition without a threshold", func() {
+		query := newQuery("", arkv1alpha1.Response{Confidence: "0.42"})
+		reconciler.setConditionNeedsReview(query)
+
+		Expect(query.Status.Conditions).To(BeEmpty())
+	})
+})
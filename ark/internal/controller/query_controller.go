@@ -5,10 +5,12 @@ package controller
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/openai/openai-go"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -17,20 +19,29 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
 	"mckinsey.com/ark/internal/genai"
 	telemetryconfig "mckinsey.com/ark/internal/telemetry/config"
 )
 
 type targetResult struct {
-	messages []genai.Message
-	err      error
-	target   arkv1alpha1.QueryTarget
+	messages     []genai.Message
+	err          error
+	target       arkv1alpha1.QueryTarget
+	attempts     targetAttempts
+	finishReason genai.FinishReason
+	confidence   targetConfidence
 }
 
 // QueryReconciler reconciles a Query object with telemetry abstraction.
@@ -43,33 +54,111 @@ type targetResult struct {
 // - Never import OTEL packages directly - use the abstraction layer
 type QueryReconciler struct {
 	client.Client
-	Scheme     *runtime.Scheme
-	Recorder   record.EventRecorder
-	Telemetry  *telemetryconfig.Provider
-	operations sync.Map
+	Scheme    *runtime.Scheme
+	Recorder  record.EventRecorder
+	Telemetry *telemetryconfig.Provider
+	// RestConfig is the manager's REST config, used to build impersonated clients
+	// when the controller is not running inside a cluster (envtest, local runs)
+	RestConfig *rest.Config
+	// MaxConcurrentQueriesPerNamespace limits how many queries execute at once in a namespace. Further
+	// queries wait, ordered by priority. Zero means no limit.
+	MaxConcurrentQueriesPerNamespace int
+	// ImpersonatedClientTTL is how long the impersonated client of a service account is reused. Zero means
+	// DefaultImpersonatedClientTTL.
+	ImpersonatedClientTTL time.Duration
+	// OrphanedQueryPolicy is what becomes of the queries a restart of the controller interrupted: they fail with
+	// OrphanedQueryFail, the default, or run again from the start with OrphanedQueryResume
+	OrphanedQueryPolicy string
+	// Shard is the part of the queries the replica executes when query execution is sharded across replicas; nil
+	// when one replica, the leader, executes every query
+	Shard *QueryShard
+	// Executors runs the executor pods the queries of labelled namespaces are dispatched to; nil when every query
+	// executes in the controller
+	Executors *QueryExecutors
+	// RunAsServiceAccount is set in executor pods, which run as the service account of their queries and so
+	// execute them with their own identity rather than impersonating it
+	RunAsServiceAccount bool
+	executorOnce        sync.Once
+	executor            string
+	operationsOnce      sync.Once
+	operations          *operations
+	limiterOnce         sync.Once
+	limiter             *queryLimiter
+	clientsOnce         sync.Once
+	clients             *impersonatedClientCache
+	// deadLetters holds the UIDs of the queries whose dead letter is being published
+	deadLetters sync.Map
+}
+
+const queryControllerName = "query"
+
+func (r *QueryReconciler) getOperations() *operations {
+	r.operationsOnce.Do(func() {
+		r.operations = newOperations(queryControllerName)
+	})
+	return r.operations
+}
+
+// getExecutor returns the identity of the controller process, which is recorded on the queries it runs
+func (r *QueryReconciler) getExecutor() string {
+	r.executorOnce.Do(func() {
+		r.executor = uuid.NewString()
+	})
+	return r.executor
+}
+
+func (r *QueryReconciler) getLimiter() *queryLimiter {
+	r.limiterOnce.Do(func() {
+		r.limiter = newQueryLimiter(r.MaxConcurrentQueriesPerNamespace)
+	})
+	return r.limiter
+}
+
+func (r *QueryReconciler) getImpersonatedClients() *impersonatedClientCache {
+	r.clientsOnce.Do(func() {
+		r.clients = newImpersonatedClientCache(r.ImpersonatedClientTTL)
+	})
+	return r.clients
 }
 
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queries,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queries/finalizers,verbs=update
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queries/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=agents,verbs=get;list
-// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=teams,verbs=get;list
-// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=models,verbs=get;list
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=agents,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=teams,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=models,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=tools,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=impersonate
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=selfsubjectaccessreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=evaluations,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch
 
 func (r *QueryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
+	if owned, err := r.ownsQuery(ctx, req.NamespacedName); !owned || err != nil {
+		if err == nil {
+			r.releaseQuery(ctx, req.NamespacedName)
+		}
+		return ctrl.Result{}, err
+	}
+
 	obj, err := r.fetchQuery(ctx, req.NamespacedName)
 	if err != nil {
 		if client.IgnoreNotFound(err) != nil {
 			log.Error(err, "unable to fetch Query")
+			return ctrl.Result{}, err
 		}
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		r.garbageCollectOrphans(ctx, req.NamespacedName)
+		return ctrl.Result{}, nil
 	}
 
-	expiry := obj.CreationTimestamp.Add(obj.Spec.TTL.Duration)
+	expiry := obj.CreationTimestamp.Add(queryTTL(obj))
 	if time.Now().After(expiry) {
 		// TTL expired: delete the object
 		if err := r.Delete(ctx, &obj); err != nil {
@@ -82,6 +171,11 @@ func (r *QueryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return *result, err
 	}
 
+	// A restored query only holds the snapshot it was restored from until it expires
+	if isRestoredQuery(&obj) {
+		return ctrl.Result{RequeueAfter: time.Until(expiry)}, nil
+	}
+
 	if len(obj.Status.Conditions) == 0 {
 		r.setConditionCompleted(&obj, metav1.ConditionFalse, "QueryNotStarted", "The query has not been started yet")
 		return ctrl.Result{}, r.Status().Update(ctx, &obj)
@@ -106,7 +200,9 @@ func (r *QueryReconciler) handleFinalizer(ctx context.Context, obj *arkv1alpha1.
 	}
 
 	if controllerutil.ContainsFinalizer(obj, finalizer) {
-		r.finalize(ctx, obj)
+		if err := r.finalize(ctx, obj); err != nil {
+			return &ctrl.Result{}, err
+		}
 		controllerutil.RemoveFinalizer(obj, finalizer)
 		return &ctrl.Result{}, r.Update(ctx, obj)
 	}
@@ -114,11 +210,22 @@ func (r *QueryReconciler) handleFinalizer(ctx context.Context, obj *arkv1alpha1.
 	return &ctrl.Result{}, nil
 }
 
+// queryTTL returns the ttl of a query, which is not set on queries created without the defaulting webhook
+func queryTTL(query arkv1alpha1.Query) time.Duration {
+	if query.Spec.TTL == nil {
+		return genai.DefaultQueryTTL
+	}
+	return query.Spec.TTL.Duration
+}
+
 func (r *QueryReconciler) handleQueryExecution(ctx context.Context, req ctrl.Request, obj arkv1alpha1.Query) (ctrl.Result, error) {
-	expiry := obj.CreationTimestamp.Add(obj.Spec.TTL.Duration)
+	expiry := obj.CreationTimestamp.Add(queryTTL(obj))
 
 	if obj.Spec.Cancel && obj.Status.Phase != statusCanceled {
 		r.cleanupExistingOperation(req.NamespacedName)
+		if obj.Status.Phase == statusRunning {
+			r.cancelDispatchedQuery(ctx, &obj)
+		}
 		if err := r.updateStatus(ctx, &obj, statusCanceled); err != nil {
 			return ctrl.Result{
 				RequeueAfter: time.Until(expiry),
@@ -135,6 +242,31 @@ func (r *QueryReconciler) handleQueryExecution(ctx context.Context, req ctrl.Req
 	case statusRunning:
 		return r.handleRunningPhase(ctx, req, obj)
 	default:
+		if obj.Spec.ReplayOf != "" {
+			ready, err := r.prepareReplay(ctx, &obj)
+			if !ready || err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		if len(obj.Spec.DependsOn) > 0 {
+			ready, err := r.checkDependencies(ctx, &obj)
+			if !ready || err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		ready, err := r.checkTargets(ctx, &obj)
+		if !ready || err != nil {
+			return ctrl.Result{}, err
+		}
+		ensureCorrelationID(&obj)
+		dispatched, err := r.dispatchesQuery(ctx, &obj)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		// A dispatched query is claimed by the executor pod that runs it
+		if !dispatched {
+			obj.Status.Executor = r.getExecutor()
+		}
 		if err := r.updateStatus(ctx, &obj, statusRunning); err != nil {
 			return ctrl.Result{
 				RequeueAfter: time.Until(expiry),
@@ -144,16 +276,38 @@ func (r *QueryReconciler) handleQueryExecution(ctx context.Context, req ctrl.Req
 	}
 }
 
+// ensureCorrelationID generates the correlation ID of a query once, when it starts running, and returns it
+func ensureCorrelationID(query *arkv1alpha1.Query) string {
+	if query.Status.CorrelationID == "" {
+		query.Status.CorrelationID = uuid.NewString()
+	}
+	return query.Status.CorrelationID
+}
+
 func (r *QueryReconciler) handleRunningPhase(ctx context.Context, req ctrl.Request, obj arkv1alpha1.Query) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
-	if _, exists := r.operations.Load(req.NamespacedName); exists {
-		log.Info("Exists")
+	if r.getOperations().exists(req.NamespacedName) {
+		log.V(genai.LogLevelDebug).Info("query execution already in progress", "query", req.Name, "namespace", req.Namespace)
 		return ctrl.Result{}, nil
 	}
 
+	if !r.RunAsServiceAccount {
+		dispatched, err := r.dispatchesQuery(ctx, &obj)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if dispatched {
+			return r.dispatchQuery(ctx, &obj)
+		}
+	}
+
+	if obj.Status.Executor != r.getExecutor() {
+		return ctrl.Result{}, r.recoverOrphanedQuery(ctx, &obj)
+	}
+
 	opCtx, cancel := context.WithCancel(ctx)
-	r.operations.Store(req.NamespacedName, cancel)
+	r.getOperations().store(req.NamespacedName, cancel)
 	recorder := genai.NewQueryRecorder(&obj, r.Recorder)
 	tokenCollector := genai.NewTokenUsageCollector(recorder)
 
@@ -162,29 +316,62 @@ func (r *QueryReconciler) handleRunningPhase(ctx context.Context, req ctrl.Reque
 		"targets":   fmt.Sprintf("%d", len(obj.Spec.Targets)),
 	})
 
-	go r.executeQueryAsync(opCtx, obj, req.NamespacedName, queryTracker, tokenCollector)
+	done := trackGoroutine(queryControllerName)
+	go func() {
+		defer done()
+		r.executeQueryAsync(opCtx, obj, req.NamespacedName, queryTracker, tokenCollector)
+	}()
 	return ctrl.Result{}, nil
 }
 
 func (r *QueryReconciler) executeQueryAsync(opCtx context.Context, obj arkv1alpha1.Query, namespacedName types.NamespacedName, queryTracker *genai.OperationTracker, tokenCollector *genai.TokenUsageCollector) {
+	// Start session-aware query tracing using new abstraction
+	sessionId := obj.Spec.SessionId
+	if sessionId == "" {
+		sessionId = string(obj.UID)
+	}
+
+	// Queries that were already running when the controller was upgraded have no correlation ID yet
+	opCtx = common.WithQueryID(opCtx, ensureCorrelationID(&obj))
+
+	// Attach the standard query logging keys and any per-namespace verbosity override
+	opCtx = genai.WithQueryLogger(opCtx, &obj, sessionId)
+	opCtx = genai.WithNamespaceLogLevel(opCtx, r.Client, obj.Namespace)
+
 	log := logf.FromContext(opCtx)
 	cleanupCache := true
-	startTime := time.Now()
 
 	defer func() {
 		if r := recover(); r != nil {
 			log.Error(fmt.Errorf("query execution goroutine panic: %v", r), "Query execution goroutine panicked")
 		}
 		if cleanupCache {
-			r.operations.Delete(namespacedName)
+			r.getOperations().delete(namespacedName)
 		}
 	}()
 
-	// Start session-aware query tracing using new abstraction
-	sessionId := obj.Spec.SessionId
-	if sessionId == "" {
-		sessionId = string(obj.UID)
+	// Wait for an execution slot when the namespace is at its concurrency limit
+	queuedAt := time.Now()
+	release, err := r.getLimiter().acquire(opCtx, obj.Namespace, obj.Spec.Priority, func() {
+		log.Info("query queued, namespace concurrency limit reached", "limit", r.MaxConcurrentQueriesPerNamespace, "priority", obj.Spec.Priority)
+		tokenCollector.EmitEvent(opCtx, corev1.EventTypeNormal, "QueryQueued", genai.BaseEvent{
+			Name: obj.Name,
+			Metadata: map[string]string{
+				"limit":    fmt.Sprintf("%d", r.MaxConcurrentQueriesPerNamespace),
+				"priority": fmt.Sprintf("%d", obj.Spec.Priority),
+			},
+		})
+	})
+	if err != nil {
+		// The operation was canceled while queued, by a cancel request or a controller shutdown, so
+		// the query is recorded as canceled rather than left without a terminal phase
+		r.recordCanceledQuery(opCtx, namespacedName, nil, time.Since(queuedAt))
+		return
 	}
+	defer release()
+	startTime := time.Now()
+	queriesRunning.WithLabelValues(obj.Namespace).Inc()
+	defer queriesRunning.WithLabelValues(obj.Namespace).Dec()
 
 	// Create query execution span with session tracking.
 	// This span represents the entire query lifecycle and includes:
@@ -193,6 +380,7 @@ func (r *QueryReconciler) executeQueryAsync(opCtx context.Context, obj arkv1alph
 	opCtx, span := r.Telemetry.QueryRecorder().StartQuery(opCtx, obj.Name, obj.Namespace, "execute")
 	r.Telemetry.QueryRecorder().RecordSessionID(span, sessionId)
 	defer span.End()
+	obj.Status.TraceID = span.TraceID()
 
 	impersonatedClient, memory, err := r.setupQueryExecution(opCtx, obj, queryTracker, tokenCollector, sessionId)
 	if err != nil {
@@ -200,41 +388,84 @@ func (r *QueryReconciler) executeQueryAsync(opCtx context.Context, obj arkv1alph
 		return
 	}
 
+	// Attachment errors surface when each target resolves its input, so only tool files are prepared here
+	if attachments, err := genai.LoadAttachments(opCtx, impersonatedClient, obj); err == nil {
+		var cleanupAttachments func()
+		opCtx, cleanupAttachments, err = genai.WithAttachmentFiles(opCtx, attachments)
+		if err != nil {
+			log.Error(err, "failed to prepare attachment files for tools")
+		}
+		defer cleanupAttachments()
+	}
+
+	artifactConfig, err := genai.GetArtifactConfig(opCtx, r.Client, obj.Namespace)
+	if err != nil {
+		log.Error(err, "invalid artifact configuration, using defaults")
+	}
+	artifacts := genai.NewArtifactCollector(artifactConfig.MaxSizeBytes)
+	opCtx = genai.WithArtifactCollector(opCtx, artifacts)
+
+	costs := r.newCostTracker(opCtx, obj)
+	opCtx = genai.WithCostTracker(opCtx, costs)
+
+	opCtx, tagsErr := genai.WithNamespaceRequestTags(opCtx, r.Client, obj.Namespace)
+	if tagsErr != nil {
+		log.Error(tagsErr, "invalid request tags, outbound requests are not tagged")
+	}
+
 	inputMessages, err := genai.GetQueryInputMessages(opCtx, obj, impersonatedClient)
 	if err == nil {
 		queryInput := genai.ExtractUserMessageContent(inputMessages)
 		r.Telemetry.QueryRecorder().RecordRootInput(span, queryInput)
 	}
 
-	responses, eventStream, err := r.reconcileQueue(opCtx, obj, impersonatedClient, memory, tokenCollector)
+	// Exceeding the token budget cancels the targets, but not the query, which still reports their responses
+	execCtx, cancelExecution := context.WithCancel(opCtx)
+	defer cancelExecution()
+	if obj.Spec.MaxTokens != nil {
+		tokenCollector.SetBudget(*obj.Spec.MaxTokens, cancelExecution)
+	}
+
+	responses, eventStream, err := r.reconcileQueue(execCtx, obj, impersonatedClient, memory, tokenCollector)
+	if opCtx.Err() != nil {
+		// The operation was canceled (user cancel, deletion or shutdown): keep what was produced rather than erroring
+		r.recordCanceledQuery(opCtx, namespacedName, responses, time.Since(startTime))
+		return
+	}
 	if err != nil {
 		// Stream error to clients if streaming is enabled
 		genai.StreamError(opCtx, eventStream, err, "query_execution_failed", "query")
 		queryTracker.Fail(err)
 		r.Telemetry.QueryRecorder().RecordError(span, err)
-		_ = r.updateStatus(opCtx, &obj, statusError)
+		obj.Status.Cost = queryCost(costs)
+		_ = r.updateStatusWithDuration(opCtx, &obj, statusError, &metav1.Duration{Duration: time.Since(startTime)})
 		return
 	}
 
 	queryTracker.Complete("resolved")
 	obj.Status.Responses = responses
+	obj.Status.AggregatedResponse = r.aggregateResponses(opCtx, obj, inputMessages, responses, impersonatedClient, tokenCollector)
 
 	if len(responses) > 0 && responses[0].Phase == statusDone {
 		r.Telemetry.QueryRecorder().RecordRootOutput(span, responses[0].Content)
 	}
 
 	tokenSummary := tokenCollector.GetTokenSummary()
-	obj.Status.TokenUsage = arkv1alpha1.TokenUsage{
-		PromptTokens:     tokenSummary.PromptTokens,
-		CompletionTokens: tokenSummary.CompletionTokens,
-		TotalTokens:      tokenSummary.TotalTokens,
-	}
+	obj.Status.TokenUsage = toTokenUsage(tokenSummary)
+	obj.Status.TokenUsageBreakdown = tokenUsageBreakdown(tokenCollector.GetTokenBreakdown())
+	obj.Status.Cost = queryCost(costs)
 
 	// Record token usage in telemetry span
 	r.Telemetry.QueryRecorder().RecordTokenUsage(span, tokenSummary.PromptTokens, tokenSummary.CompletionTokens, tokenSummary.TotalTokens)
 
+	r.publishArtifacts(opCtx, &obj, artifactConfig, artifacts.Artifacts())
+
+	if obj.Spec.ReplayOf != "" {
+		obj.Status.Replay = r.compareWithReplayed(opCtx, &obj)
+	}
+
 	// Set overall query status based on whether any targets failed
-	queryStatus := r.determineQueryStatus(responses)
+	queryStatus := r.determineQueryStatusWithPolicies(obj, responses)
 	_ = r.updateStatus(opCtx, &obj, queryStatus)
 
 	duration := &metav1.Duration{Duration: time.Since(startTime)}
@@ -272,6 +503,12 @@ func (r *QueryReconciler) finalizeEventStream(ctx context.Context, eventStream g
 }
 
 func (r *QueryReconciler) setupQueryExecution(opCtx context.Context, obj arkv1alpha1.Query, queryTracker *genai.OperationTracker, tokenCollector *genai.TokenUsageCollector, sessionId string) (client.Client, genai.MemoryInterface, error) {
+	if err := r.preflightImpersonation(opCtx, &obj); err != nil {
+		queryTracker.Fail(err)
+		_ = r.failQuery(opCtx, &obj, common.ImpersonationNotPermittedReason, err.Error())
+		return nil, nil, err
+	}
+
 	impersonatedClient, err := r.getClientForQuery(obj)
 	if err != nil {
 		queryTracker.Fail(fmt.Errorf("failed to create impersonated client: %w", err))
@@ -289,24 +526,51 @@ func (r *QueryReconciler) setupQueryExecution(opCtx context.Context, obj arkv1al
 	return impersonatedClient, memory, nil
 }
 
+// preflightImpersonation verifies that the controller may impersonate the query's service account
+// before any client is built, so a missing rbac.impersonation setting surfaces as a clear condition
+// rather than an opaque authorization failure deep inside execution.
+func (r *QueryReconciler) preflightImpersonation(ctx context.Context, query *arkv1alpha1.Query) error {
+	if query.Spec.ServiceAccount == "" || r.RunAsServiceAccount {
+		return nil
+	}
+
+	err := common.CheckImpersonationPermitted(ctx, r.Client, query.Namespace, query.Spec.ServiceAccount)
+	var notPermitted *common.ImpersonationNotPermittedError
+	if errors.As(err, &notPermitted) {
+		r.Recorder.Event(query, corev1.EventTypeWarning, common.ImpersonationNotPermittedReason, err.Error())
+		return err
+	}
+	if err != nil {
+		// The access review itself failed; let client construction report the real outcome
+		logf.FromContext(ctx).Error(err, "impersonation preflight check failed", "query", query.Name, "namespace", query.Namespace)
+	}
+
+	return nil
+}
+
 func (r *QueryReconciler) resolveTargets(ctx context.Context, query arkv1alpha1.Query, impersonatedClient client.Client) ([]arkv1alpha1.QueryTarget, error) {
 	var allTargets []arkv1alpha1.QueryTarget
 
 	allTargets = append(allTargets, query.Spec.Targets...)
 
 	if query.Spec.Selector != nil {
-		targets, err := r.resolveSelector(ctx, query.Spec.Selector, query.Namespace, impersonatedClient)
+		matches, err := r.resolveSelector(ctx, query.Spec.Selector, query.Namespace, impersonatedClient)
 		if err != nil {
 			return nil, fmt.Errorf("failed to resolve selector: %w", err)
 		}
-		allTargets = append(allTargets, targets...)
+		if policy := query.Spec.SelectorPolicy; policy != nil && policy.Sample != nil {
+			matches = sampleSelectorMatches(matches, int(*policy.Sample))
+		}
+		for _, match := range matches {
+			allTargets = append(allTargets, match.target)
+		}
 	}
 
 	return allTargets, nil
 }
 
-func (r *QueryReconciler) resolveSelector(ctx context.Context, selector *metav1.LabelSelector, namespace string, impersonatedClient client.Client) ([]arkv1alpha1.QueryTarget, error) {
-	targets := make([]arkv1alpha1.QueryTarget, 0, 10)
+func (r *QueryReconciler) resolveSelector(ctx context.Context, selector *metav1.LabelSelector, namespace string, impersonatedClient client.Client) ([]selectorMatch, error) {
+	targets := make([]selectorMatch, 0, 10)
 
 	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
 	if err != nil {
@@ -323,10 +587,7 @@ func (r *QueryReconciler) resolveSelector(ctx context.Context, selector *metav1.
 	}
 
 	for _, agent := range agentList.Items {
-		targets = append(targets, arkv1alpha1.QueryTarget{
-			Type: "agent",
-			Name: agent.Name,
-		})
+		targets = append(targets, newSelectorMatch(ctx, "agent", &agent))
 	}
 
 	// Search for teams
@@ -339,10 +600,7 @@ func (r *QueryReconciler) resolveSelector(ctx context.Context, selector *metav1.
 	}
 
 	for _, team := range teamList.Items {
-		targets = append(targets, arkv1alpha1.QueryTarget{
-			Type: "team",
-			Name: team.Name,
-		})
+		targets = append(targets, newSelectorMatch(ctx, "team", &team))
 	}
 
 	// Search for models
@@ -355,10 +613,7 @@ func (r *QueryReconciler) resolveSelector(ctx context.Context, selector *metav1.
 	}
 
 	for _, model := range modelList.Items {
-		targets = append(targets, arkv1alpha1.QueryTarget{
-			Type: "model",
-			Name: model.Name,
-		})
+		targets = append(targets, newSelectorMatch(ctx, "model", &model))
 	}
 
 	// Search for tools
@@ -371,31 +626,42 @@ func (r *QueryReconciler) resolveSelector(ctx context.Context, selector *metav1.
 	}
 
 	for _, tool := range toolList.Items {
-		targets = append(targets, arkv1alpha1.QueryTarget{
-			Type: "tool",
-			Name: tool.Name,
-		})
+		targets = append(targets, newSelectorMatch(ctx, "tool", &tool))
 	}
 
 	return targets, nil
 }
 
 func (r *QueryReconciler) reconcileQueue(ctx context.Context, query arkv1alpha1.Query, impersonatedClient client.Client, memory genai.MemoryInterface, tokenCollector *genai.TokenUsageCollector) ([]arkv1alpha1.Response, genai.EventStreamInterface, error) {
-	eventStream, err := r.createEventStreamIfNeeded(ctx, query)
+	targets, err := r.resolveTargets(ctx, query, impersonatedClient)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, fmt.Errorf("failed to resolve targets: %w", err)
 	}
 
-	targets, err := r.resolveTargets(ctx, query, impersonatedClient)
+	// With several targets running in parallel, each target also gets its own stream
+	eventStream, err := r.createEventStreamIfNeeded(ctx, query, len(targets) > 1)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to resolve targets: %w", err)
+		return nil, nil, err
+	}
+	// Messages stored in memory are streamed too, unless the query has no memory to store them in
+	if _, noop := memory.(*genai.NoopMemory); eventStream != nil && !noop {
+		sessionId := query.Spec.SessionId
+		if sessionId == "" {
+			sessionId = string(query.UID)
+		}
+		ctx = genai.WithMemoryEvents(ctx, genai.NewMemoryEvents(eventStream, sessionId))
 	}
 
-	allResponses := r.executeTargetsInParallel(ctx, query, targets, impersonatedClient, memory, eventStream, tokenCollector)
+	var allResponses []arkv1alpha1.Response
+	if query.Spec.TargetExecution == arkv1alpha1.TargetExecutionSequential {
+		allResponses = r.executeTargetsSequentially(ctx, query, targets, impersonatedClient, memory, eventStream, tokenCollector)
+	} else {
+		allResponses = r.executeTargetsInParallel(ctx, query, targets, impersonatedClient, memory, eventStream, tokenCollector)
+	}
 	return allResponses, eventStream, nil
 }
 
-func (r *QueryReconciler) createEventStreamIfNeeded(ctx context.Context, query arkv1alpha1.Query) (genai.EventStreamInterface, error) {
+func (r *QueryReconciler) createEventStreamIfNeeded(ctx context.Context, query arkv1alpha1.Query, targetStreams bool) (genai.EventStreamInterface, error) {
 	if !genai.IsStreamingEnabled(query) {
 		return nil, nil
 	}
@@ -405,7 +671,7 @@ func (r *QueryReconciler) createEventStreamIfNeeded(ctx context.Context, query a
 		sessionId = string(query.UID)
 	}
 
-	eventStream, err := genai.NewEventStreamForQuery(ctx, r.Client, query.Namespace, sessionId, query.Name)
+	eventStream, err := genai.NewEventStreamForQuery(ctx, r.Client, query.Namespace, sessionId, query.Name, targetStreams)
 	if err != nil {
 		return nil, fmt.Errorf("streaming configuration error: %w", err)
 	}
@@ -423,12 +689,26 @@ func (r *QueryReconciler) executeTargetsInParallel(ctx context.Context, query ar
 	resultChan := make(chan targetResult, len(targets))
 	var wg sync.WaitGroup
 
-	for _, target := range targets {
+	// Targets the query can stop run under stopCtx, which is canceled once the query no longer needs them
+	stopper := newTargetStopper(query, len(targets))
+	stopCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	for i, target := range targets {
 		wg.Add(1)
+		done := trackGoroutine(queryControllerName)
 		go func(target arkv1alpha1.QueryTarget) {
+			defer done()
 			defer wg.Done()
-			responses, err := r.executeTarget(ctx, query, target, impersonatedClient, memory, eventStream, tokenCollector)
-			resultChan <- targetResult{responses, err, target}
+			if !stopper.stoppable(i) {
+				resultChan <- r.executeQueryTarget(ctx, query, targets, target, impersonatedClient, memory, eventStream, tokenCollector)
+				return
+			}
+			result := r.executeQueryTarget(stopCtx, query, targets, target, impersonatedClient, memory, eventStream, tokenCollector)
+			if stopper.record(i, result) {
+				stop()
+			}
+			resultChan <- stoppedEarly(ctx, stopCtx, result)
 		}(target)
 	}
 
@@ -438,25 +718,75 @@ func (r *QueryReconciler) executeTargetsInParallel(ctx context.Context, query ar
 	return r.processTargetResults(resultChan)
 }
 
+// executeTargetsSequentially runs the targets one after another in order. Each target loads memory when it
+// starts, so it sees the messages the targets before it saved. Responses are in the order of the targets. A
+// failed target does not stop the ones after it, unless the failure policy can no longer be met; stoppable
+// targets after the query stopped are skipped.
+func (r *QueryReconciler) executeTargetsSequentially(ctx context.Context, query arkv1alpha1.Query, targets []arkv1alpha1.QueryTarget, impersonatedClient client.Client, memory genai.MemoryInterface, eventStream genai.EventStreamInterface, tokenCollector *genai.TokenUsageCollector) []arkv1alpha1.Response {
+	resultChan := make(chan targetResult, len(targets))
+	stopper := newTargetStopper(query, len(targets))
+	for i, target := range targets {
+		if stopper.isStopped() && stopper.stoppable(i) {
+			continue
+		}
+		result := r.executeQueryTarget(ctx, query, targets, target, impersonatedClient, memory, eventStream, tokenCollector)
+		stopper.record(i, result)
+		resultChan <- result
+	}
+	close(resultChan)
+
+	return r.processTargetResults(resultChan)
+}
+
+// executeQueryTarget executes one target of the query and, when the query has several targets, ends the
+// target's stream
+func (r *QueryReconciler) executeQueryTarget(ctx context.Context, query arkv1alpha1.Query, targets []arkv1alpha1.QueryTarget, target arkv1alpha1.QueryTarget, impersonatedClient client.Client, memory genai.MemoryInterface, eventStream genai.EventStreamInterface, tokenCollector *genai.TokenUsageCollector) targetResult {
+	result := r.executeTarget(ctx, query, target, impersonatedClient, memory, eventStream, tokenCollector)
+	result = overTokenBudget(result, tokenCollector)
+	if len(targets) > 1 {
+		targetCtx := genai.WithQueryContext(ctx, string(query.UID), query.Spec.SessionId, query.Name)
+		genai.NotifyTargetCompletion(targetCtx, eventStream, fmt.Sprintf("%s/%s", target.Type, target.Name))
+	}
+	return result
+}
+
+// overTokenBudget marks the result of a target that was canceled because the query exceeded its token budget.
+// The cancellation can surface wrapped in another error, e.g. when it interrupted resolving the target.
+func overTokenBudget(result targetResult, tokenCollector *genai.TokenUsageCollector) targetResult {
+	if !errors.Is(result.err, context.Canceled) {
+		return result
+	}
+	if budgetErr := tokenCollector.BudgetError(); budgetErr != nil {
+		result.err = budgetErr
+	}
+	return result
+}
+
 func (r *QueryReconciler) processTargetResults(resultChan chan targetResult) []arkv1alpha1.Response {
 	var allResponses []arkv1alpha1.Response
 
 	for result := range resultChan {
+		var response arkv1alpha1.Response
 		switch {
+		case genai.IsCancellation(result.err):
+			response = r.createCanceledResponse(result.target, result.messages, result.err)
 		case result.err != nil:
-			allResponses = append(allResponses, r.createErrorResponse(result.target, result.err))
+			response = r.createErrorResponse(result.target, result.err)
 		case result.messages == nil:
 			// Skip targets that were delegated to external execution engines (messages == nil)
+			continue
 		default:
-			response := r.createSuccessResponse(result.target, result.messages)
-			allResponses = append(allResponses, response)
+			response = r.createSuccessResponse(result.target, result.messages, result.finishReason)
 		}
+		result.attempts.applyTo(&response)
+		result.confidence.applyTo(&response)
+		allResponses = append(allResponses, response)
 	}
 
 	return allResponses
 }
 
-func (r *QueryReconciler) createSuccessResponse(target arkv1alpha1.QueryTarget, messages []genai.Message) arkv1alpha1.Response {
+func (r *QueryReconciler) createSuccessResponse(target arkv1alpha1.QueryTarget, messages []genai.Message, finishReason genai.FinishReason) arkv1alpha1.Response {
 	rawJSON, err := serializeMessages(messages)
 	if err != nil {
 		serializationErr := fmt.Errorf("failed to serialize messages for target %v: %w", target, err)
@@ -468,54 +798,10 @@ func (r *QueryReconciler) createSuccessResponse(target arkv1alpha1.QueryTarget,
 		Content: messageToText(messages[len(messages)-1]),
 		Raw:     rawJSON,
 		Phase:   statusDone,
+		Reason:  string(finishReason),
 	}
 }
 
-// messageToText extracts text content from a single OpenAI message format structure.
-// This function assumes the message follows OpenAI's ChatCompletionMessageParamUnion format.
-func messageToText(message genai.Message) string {
-	switch {
-	case message.OfAssistant != nil:
-		return message.OfAssistant.Content.OfString.Value
-	case message.OfTool != nil:
-		return message.OfTool.Content.OfString.Value
-	case message.OfUser != nil:
-		return message.OfUser.Content.OfString.Value
-	default:
-		logf.Log.Error(fmt.Errorf("LLMResponseMalformed"),
-			"Unable to parse message content to text",
-			"messageContent", "unknown message structure",
-			"message", message)
-		return ""
-	}
-}
-
-// serializeMessages converts OpenAI union message types to their actual content for JSON serialization
-func serializeMessages(messages []genai.Message) (string, error) {
-	var actualMessages []interface{}
-	for _, msg := range messages {
-		switch {
-		case msg.OfAssistant != nil:
-			actualMessages = append(actualMessages, msg.OfAssistant)
-		case msg.OfUser != nil:
-			actualMessages = append(actualMessages, msg.OfUser)
-		case msg.OfSystem != nil:
-			actualMessages = append(actualMessages, msg.OfSystem)
-		case msg.OfTool != nil:
-			actualMessages = append(actualMessages, msg.OfTool)
-		case msg.OfFunction != nil:
-			actualMessages = append(actualMessages, msg.OfFunction)
-		default:
-			return "", fmt.Errorf("unknown message type encountered during serialization")
-		}
-	}
-	rawBytes, err := json.Marshal(actualMessages)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal messages: %w", err)
-	}
-	return string(rawBytes), nil
-}
-
 func (r *QueryReconciler) setConditionCompleted(query *arkv1alpha1.Query, status metav1.ConditionStatus, reason, message string) {
 	meta.SetStatusCondition(&query.Status.Conditions, metav1.Condition{
 		Type:               string(arkv1alpha1.QueryCompleted),
@@ -527,6 +813,23 @@ func (r *QueryReconciler) setConditionCompleted(query *arkv1alpha1.Query, status
 	})
 }
 
+// publishArtifacts uploads the artifacts emitted by agents to the artifact store and lists them in the
+// query status. Artifacts that fail to upload are reported with an event and left out of the status.
+func (r *QueryReconciler) publishArtifacts(ctx context.Context, query *arkv1alpha1.Query, config genai.ArtifactConfig, artifacts []genai.Artifact) {
+	if len(artifacts) == 0 {
+		return
+	}
+
+	store, err := genai.NewArtifactStore(ctx, r.Client, config, query.Namespace)
+	if err == nil {
+		query.Status.Artifacts, err = genai.PublishArtifacts(ctx, store, query, artifacts)
+	}
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to publish artifacts", "query", query.Name)
+		r.Recorder.Event(query, corev1.EventTypeWarning, "ArtifactPublishFailed", err.Error())
+	}
+}
+
 func (r *QueryReconciler) updateStatus(ctx context.Context, query *arkv1alpha1.Query, status string) error {
 	return r.updateStatusWithDuration(ctx, query, status, nil)
 }
@@ -540,18 +843,46 @@ func (r *QueryReconciler) updateStatusWithDuration(ctx context.Context, query *a
 	case statusRunning:
 		r.setConditionCompleted(query, metav1.ConditionFalse, "QueryRunning", "Query is running")
 	case statusDone:
-		r.setConditionCompleted(query, metav1.ConditionTrue, "QuerySucceeded", "Query completed successfully")
+		reason, message := "QuerySucceeded", "Query completed successfully"
+		for _, response := range query.Status.Responses {
+			switch genai.FinishReason(response.Reason) {
+			case genai.FinishMaxTurnsReached:
+				reason, message = response.Reason, fmt.Sprintf("Query completed: %s %s reached its turn limit", response.Target.Type, response.Target.Name)
+			case genai.FinishTerminated:
+				reason, message = response.Reason, fmt.Sprintf("Query completed: %s %s was terminated", response.Target.Type, response.Target.Name)
+			default:
+				continue
+			}
+			break
+		}
+		// A failure or selector policy can complete the query although some targets failed
+		if succeeded := countSucceededResponses(query.Status.Responses); reason == "QuerySucceeded" && succeeded < len(query.Status.Responses) {
+			reason, message = "QueryPartiallySucceeded", fmt.Sprintf("Query completed: %d of %d targets succeeded", succeeded, len(query.Status.Responses))
+		}
+		r.setConditionCompleted(query, metav1.ConditionTrue, reason, message)
+		r.setConditionNeedsReview(query)
 	case statusError:
 		errorMsg := "Query completed with error"
+		reason := "QueryErrored"
 		for _, response := range query.Status.Responses {
-			if response.Phase == statusError && response.Content != "" {
+			if (response.Phase == statusError || response.Phase == statusUnresolved) && response.Content != "" {
 				errorMsg = response.Content
+				if response.Reason != "" {
+					reason = response.Reason
+				}
 				break
 			}
 		}
-		r.setConditionCompleted(query, metav1.ConditionTrue, "QueryErrored", errorMsg)
+		r.setConditionCompleted(query, metav1.ConditionTrue, reason, errorMsg)
 	case statusCanceled:
-		r.setConditionCompleted(query, metav1.ConditionTrue, "QueryCanceled", "Query canceled")
+		reason, message := "QueryCanceled", "Query canceled"
+		for _, response := range query.Status.Responses {
+			if response.Phase == statusCanceled && response.Reason != "" {
+				reason, message = response.Reason, response.Content
+				break
+			}
+		}
+		r.setConditionCompleted(query, metav1.ConditionTrue, reason, message)
 	}
 	if duration != nil {
 		query.Status.Duration = duration
@@ -559,25 +890,60 @@ func (r *QueryReconciler) updateStatusWithDuration(ctx context.Context, query *a
 	err := r.Status().Update(ctx, query)
 	if err != nil {
 		logf.FromContext(ctx).Error(err, "failed to update query status", "status", status)
+		return err
+	}
+	if duration != nil {
+		observeQueryDuration(query.Namespace, status, duration.Duration)
+	}
+	if status == statusError {
+		r.deadLetterQuery(ctx, query)
+	}
+	return nil
+}
+
+// failQuery marks the query as errored with a specific completion reason
+func (r *QueryReconciler) failQuery(ctx context.Context, query *arkv1alpha1.Query, reason, message string) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	query.Status.Phase = statusError
+	r.setConditionCompleted(query, metav1.ConditionTrue, reason, message)
+	err := r.Status().Update(ctx, query)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to update query status", "status", statusError, "reason", reason)
+		return err
 	}
-	return err
+	r.deadLetterQuery(ctx, query)
+	return nil
 }
 
-// determineQueryStatus checks if any responses have error phase and returns appropriate query status
+// determineQueryStatus returns error if any response failed, canceled if any was cut short, and done otherwise
 func (r *QueryReconciler) determineQueryStatus(responses []arkv1alpha1.Response) string {
+	status := statusDone
 	for _, response := range responses {
-		if response.Phase == statusError {
+		switch response.Phase {
+		case statusError, statusUnresolved:
 			return statusError
+		case statusCanceled:
+			status = statusCanceled
 		}
 	}
-	return statusDone
+	return status
 }
 
 // createErrorResponse creates a standardized error response for a failed target
 func (r *QueryReconciler) createErrorResponse(target arkv1alpha1.QueryTarget, err error) arkv1alpha1.Response {
 	// Create error structure for Raw field - similar to successful message format
+	// Configuration problems are reported separately from failures during execution
+	errorType, phase := "target_execution_error", statusError
+	if genai.IsResolutionError(err) {
+		errorType, phase = "target_resolution_error", statusUnresolved
+	}
+
+	reason := string(genai.ReasonFor(err))
 	errorMessage := map[string]interface{}{
-		"error":   "target_execution_error",
+		"error":   errorType,
+		"reason":  reason,
 		"message": err.Error(),
 	}
 	errorRaw, _ := json.Marshal([]map[string]interface{}{errorMessage})
@@ -586,22 +952,81 @@ func (r *QueryReconciler) createErrorResponse(target arkv1alpha1.QueryTarget, er
 		Target:  target,
 		Content: err.Error(),
 		Raw:     string(errorRaw),
-		Phase:   statusError,
+		Phase:   phase,
+		Reason:  reason,
 	}
 }
 
-func (r *QueryReconciler) finalize(ctx context.Context, query *arkv1alpha1.Query) {
+// createCanceledResponse records a target that was canceled or timed out, preserving any partial output
+func (r *QueryReconciler) createCanceledResponse(target arkv1alpha1.QueryTarget, messages []genai.Message, err error) arkv1alpha1.Response {
+	response := arkv1alpha1.Response{
+		Target:  target,
+		Content: err.Error(),
+		Phase:   statusCanceled,
+		Reason:  string(genai.ReasonFor(err)),
+	}
+
+	if len(messages) > 0 {
+		if rawJSON, serializeErr := serializeMessages(messages); serializeErr == nil {
+			response.Content = messageToText(messages[len(messages)-1])
+			response.Raw = rawJSON
+		}
+	}
+
+	return response
+}
+
+// recordCanceledQuery stores the responses of a query whose operation context was canceled. The operation
+// context can no longer be used, so the latest query is re-read and updated with a detached, bounded context.
+func (r *QueryReconciler) recordCanceledQuery(opCtx context.Context, namespacedName types.NamespacedName, responses []arkv1alpha1.Response, duration time.Duration) {
+	log := logf.FromContext(opCtx)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(opCtx), 10*time.Second)
+	defer cancel()
+
+	var query arkv1alpha1.Query
+	if err := r.Get(ctx, namespacedName, &query); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			log.Error(err, "failed to fetch canceled query")
+		}
+		return
+	}
+	if !query.DeletionTimestamp.IsZero() {
+		return
+	}
+
+	reason, message := "ControllerShutdown", "Query canceled because the controller stopped"
+	if query.Spec.Cancel {
+		reason, message = "QueryCanceled", "Query canceled"
+	}
+
+	query.Status.Phase = statusCanceled
+	query.Status.Responses = responses
+	query.Status.Duration = &metav1.Duration{Duration: duration}
+	r.setConditionCompleted(&query, metav1.ConditionTrue, reason, message)
+	if err := r.Status().Update(ctx, &query); err != nil {
+		log.Error(err, "failed to record canceled query status")
+		return
+	}
+	observeQueryDuration(query.Namespace, statusCanceled, duration)
+}
+
+func (r *QueryReconciler) finalize(ctx context.Context, query *arkv1alpha1.Query) error {
 	log := logf.FromContext(ctx)
 	log.Info("finalizing query", "name", query.Name, "namespace", query.Namespace)
 
 	nsName := types.NamespacedName{Name: query.Name, Namespace: query.Namespace}
-	if cancel, exists := r.operations.Load(nsName); exists {
-		if cancelFunc, ok := cancel.(context.CancelFunc); ok {
-			cancelFunc()
-		}
-		r.operations.Delete(nsName)
+	if r.getOperations().cancel(nsName) {
 		log.Info("cancelled running operation for query", "name", query.Name, "namespace", query.Namespace)
 	}
+	if query.Status.Phase == statusRunning {
+		r.cancelDispatchedQuery(ctx, query)
+	}
+
+	if err := r.snapshotQuery(ctx, query); err != nil {
+		return err
+	}
+
+	return r.garbageCollectQueryChildren(ctx, query)
 }
 
 // handleTargetExecutionError handles error reporting for target execution failures.
@@ -615,10 +1040,14 @@ func (r *QueryReconciler) handleTargetExecutionError(ctx context.Context, err er
 		BaseEvent: genai.BaseEvent{Name: target.Name, Metadata: metadata},
 		Type:      target.Type,
 	}
-	tokenCollector.EmitEvent(ctx, corev1.EventTypeWarning, "TargetExecutionError", event)
+	eventReason := "TargetExecutionError"
+	if genai.IsResolutionError(err) {
+		eventReason = "TargetResolutionError"
+	}
+	tokenCollector.EmitEvent(ctx, corev1.EventTypeWarning, eventReason, event)
 }
 
-func (r *QueryReconciler) executeTarget(ctx context.Context, query arkv1alpha1.Query, target arkv1alpha1.QueryTarget, impersonatedClient client.Client, memory genai.MemoryInterface, eventStream genai.EventStreamInterface, tokenCollector *genai.TokenUsageCollector) ([]genai.Message, error) {
+func (r *QueryReconciler) executeTarget(ctx context.Context, query arkv1alpha1.Query, target arkv1alpha1.QueryTarget, impersonatedClient client.Client, memory genai.MemoryInterface, eventStream genai.EventStreamInterface, tokenCollector *genai.TokenUsageCollector) targetResult {
 	// Store query in context for access in deeper call stacks
 	ctx = context.WithValue(ctx, genai.QueryContextKey, &query)
 
@@ -637,6 +1066,7 @@ func (r *QueryReconciler) executeTarget(ctx context.Context, query arkv1alpha1.Q
 
 	// Add execution metadata for streaming
 	targetString := fmt.Sprintf("%s/%s", target.Type, target.Name)
+	ctx = genai.WithTargetLogger(ctx, targetString)
 	ctx = genai.WithExecutionMetadata(ctx, map[string]interface{}{
 		"target": targetString,
 	})
@@ -646,7 +1076,12 @@ func (r *QueryReconciler) executeTarget(ctx context.Context, query arkv1alpha1.Q
 
 	// Get input messages for processing and telemetry
 	inputMessages, err := genai.GetQueryInputMessages(ctx, query, impersonatedClient)
+	if err == nil {
+		ctx, err = r.withAgentTest(ctx, &query)
+	}
 	if err != nil {
+		err = genai.NewResolutionError(err)
+		metadata["reason"] = string(genai.ReasonFor(err))
 		r.Telemetry.QueryRecorder().RecordError(span, err)
 		// Add trace correlation to event metadata for observability linkage
 		metadata["traceId"] = span.TraceID()
@@ -656,14 +1091,18 @@ func (r *QueryReconciler) executeTarget(ctx context.Context, query arkv1alpha1.Q
 			Type:      target.Type,
 		}
 		tokenCollector.EmitEvent(ctx, corev1.EventTypeWarning, "QueryResolveError", event)
-		return nil, err
+		return targetResult{err: err, target: target}
 	}
 
 	// Record input for telemetry
 	userContent := genai.ExtractUserMessageContent(inputMessages)
 	r.Telemetry.QueryRecorder().RecordInput(span, userContent)
 
-	timeout := 5 * time.Minute
+	// The blackboard is shared by all attempts of the target, so retries see what earlier attempts wrote
+	blackboard := genai.NewBlackboard(memory, fmt.Sprintf("%s-%s-%s", queryID, target.Type, target.Name))
+	ctx = genai.WithBlackboard(ctx, blackboard)
+
+	timeout := genai.DefaultQueryTimeout
 	if query.Spec.Timeout != nil {
 		timeout = query.Spec.Timeout.Duration
 	}
@@ -671,27 +1110,47 @@ func (r *QueryReconciler) executeTarget(ctx context.Context, query arkv1alpha1.Q
 	defer cancel()
 
 	var responseMessages []genai.Message
-	switch target.Type {
-	case "agent":
-		responseMessages, err = r.executeAgent(execCtx, query, inputMessages, target.Name, impersonatedClient, memory, eventStream, tokenCollector)
-	case "team":
-		responseMessages, err = r.executeTeam(execCtx, query, inputMessages, target.Name, impersonatedClient, memory, eventStream, tokenCollector)
-	case "model":
-		responseMessages, err = r.executeModel(execCtx, query, inputMessages, target.Name, impersonatedClient, memory, eventStream, tokenCollector)
-	case "tool":
-		responseMessages, err = r.executeTool(execCtx, query, inputMessages, target.Name, impersonatedClient, tokenCollector)
-	default:
-		panic(fmt.Errorf("unknown query target type:%s", target.Type))
+	var finish *genai.FinishRecorder
+	attempts := r.executeWithRetry(execCtx, query.Spec.RetryPolicy, target, tokenCollector, func(execCtx context.Context) error {
+		finish = genai.NewFinishRecorder()
+		execCtx = genai.WithFinishRecorder(execCtx, finish)
+		switch target.Type {
+		case "agent":
+			responseMessages, err = r.executeAgent(execCtx, query, inputMessages, target.Name, impersonatedClient, memory, eventStream, tokenCollector)
+		case "team":
+			responseMessages, err = r.executeTeam(execCtx, query, inputMessages, target.Name, impersonatedClient, memory, eventStream, tokenCollector)
+		case "model":
+			responseMessages, err = r.executeModel(execCtx, query, inputMessages, target.Name, impersonatedClient, memory, eventStream, tokenCollector)
+		case "tool":
+			responseMessages, err = r.executeTool(execCtx, query, inputMessages, target.Name, impersonatedClient, tokenCollector)
+		case "session":
+			responseMessages, err = r.executeSession(execCtx, query, inputMessages, target.Name, impersonatedClient, memory, eventStream, tokenCollector)
+		default:
+			panic(fmt.Errorf("unknown query target type:%s", target.Type))
+		}
+		return err
+	})
+
+	if err != nil && execCtx.Err() != nil {
+		// Cancellation and timeouts are not failures; return whatever the target produced so far
+		reason := genai.ReasonCanceled
+		if errors.Is(execCtx.Err(), context.DeadlineExceeded) {
+			reason = genai.ReasonQueryTimeout
+		}
+		r.Telemetry.QueryRecorder().RecordError(span, genai.NewError(reason, err))
+		return targetResult{messages: responseMessages, err: genai.NewError(reason, err), target: target, attempts: attempts}
 	}
 
 	if err != nil {
+		err = genai.ClassifyError(err)
+		metadata["reason"] = string(genai.ReasonFor(err))
 		// Record telemetry error before handling error reporting
 		r.Telemetry.QueryRecorder().RecordError(span, err)
 		// Add trace correlation to event metadata for observability linkage
 		metadata["traceId"] = span.TraceID()
 		metadata["spanId"] = span.SpanID()
 		r.handleTargetExecutionError(ctx, err, target, metadata, eventStream, tokenCollector)
-		return nil, err
+		return targetResult{err: err, target: target, attempts: attempts}
 	}
 
 	// Set the final response as output at trace level
@@ -709,7 +1168,9 @@ func (r *QueryReconciler) executeTarget(ctx context.Context, query arkv1alpha1.Q
 		Type:      target.Type,
 	}
 	tokenCollector.EmitEvent(ctx, corev1.EventTypeNormal, "TargetExecutionComplete", event)
-	return responseMessages, err
+
+	confidence := r.estimateConfidence(ctx, query, target, inputMessages, responseMessages, impersonatedClient, span, tokenCollector)
+	return targetResult{messages: responseMessages, target: target, attempts: attempts, finishReason: finish.Reason(), confidence: confidence}
 }
 
 func (r *QueryReconciler) executeAgent(ctx context.Context, query arkv1alpha1.Query, inputMessages []genai.Message, agentName string, impersonatedClient client.Client, memory genai.MemoryInterface, eventStream genai.EventStreamInterface, tokenCollector *genai.TokenUsageCollector) ([]genai.Message, error) {
@@ -717,7 +1178,7 @@ func (r *QueryReconciler) executeAgent(ctx context.Context, query arkv1alpha1.Qu
 	agentKey := types.NamespacedName{Name: agentName, Namespace: query.Namespace}
 
 	if err := impersonatedClient.Get(ctx, agentKey, &agentCRD); err != nil {
-		return nil, fmt.Errorf("unable to get %v, error:%w", agentKey, err)
+		return nil, genai.NewResolutionError(fmt.Errorf("unable to get %v, error:%w", agentKey, err))
 	}
 
 	// Add agent to execution metadata
@@ -729,11 +1190,11 @@ func (r *QueryReconciler) executeAgent(ctx context.Context, query arkv1alpha1.Qu
 	// Regular agent execution
 	agent, err := genai.MakeAgent(ctx, impersonatedClient, &agentCRD, tokenCollector, r.Telemetry)
 	if err != nil {
-		return nil, fmt.Errorf("unable to make agent %v, error:%w", agentKey, err)
+		return nil, genai.NewResolutionError(fmt.Errorf("unable to make agent %v, error:%w", agentKey, err))
 	}
 
 	// Load existing messages from memory
-	memoryMessages, err := r.loadInitialMessages(ctx, memory)
+	memoryMessages, err := r.loadInitialMessages(ctx, impersonatedClient, memory)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load initial messages: %w", err)
 	}
@@ -743,7 +1204,8 @@ func (r *QueryReconciler) executeAgent(ctx context.Context, query arkv1alpha1.Qu
 
 	responseMessages, err := agent.Execute(ctx, currentMessage, contextMessages, memory, eventStream)
 	if err != nil {
-		return nil, err
+		// Partial messages are kept so that a canceled query can still report them
+		return responseMessages, err
 	}
 
 	// Save all new messages (input + response) to memory
@@ -751,6 +1213,7 @@ func (r *QueryReconciler) executeAgent(ctx context.Context, query arkv1alpha1.Qu
 	if err := memory.AddMessages(ctx, query.Name, newMessages); err != nil {
 		return nil, fmt.Errorf("failed to save new messages to memory: %w", err)
 	}
+	genai.StreamMemoryWrite(ctx, memoryMessages, newMessages)
 
 	return responseMessages, nil
 }
@@ -760,15 +1223,15 @@ func (r *QueryReconciler) executeTeam(ctx context.Context, query arkv1alpha1.Que
 	teamKey := types.NamespacedName{Name: teamName, Namespace: query.Namespace}
 
 	if err := impersonatedClient.Get(ctx, teamKey, &teamCRD); err != nil {
-		return nil, fmt.Errorf("unable to fetch team %v, error:%w", teamKey, err)
+		return nil, genai.NewResolutionError(fmt.Errorf("unable to fetch team %v, error:%w", teamKey, err))
 	}
 
 	team, err := genai.MakeTeam(ctx, impersonatedClient, &teamCRD, tokenCollector, r.Telemetry)
 	if err != nil {
-		return nil, fmt.Errorf("unable to make team %v, error:%w", teamKey, err)
+		return nil, genai.NewResolutionError(fmt.Errorf("unable to make team %v, error:%w", teamKey, err))
 	}
 
-	historyMessages, err := r.loadInitialMessages(ctx, memory)
+	historyMessages, err := r.loadInitialMessages(ctx, impersonatedClient, memory)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load initial messages: %w", err)
 	}
@@ -778,7 +1241,8 @@ func (r *QueryReconciler) executeTeam(ctx context.Context, query arkv1alpha1.Que
 
 	responseMessages, err := team.Execute(ctx, currentMessage, contextMessages, memory, eventStream)
 	if err != nil {
-		return nil, err
+		// Partial messages are kept so that a canceled query can still report them
+		return responseMessages, err
 	}
 
 	// Save all new messages (input + response) to memory
@@ -786,6 +1250,7 @@ func (r *QueryReconciler) executeTeam(ctx context.Context, query arkv1alpha1.Que
 	if err := memory.AddMessages(ctx, query.Name, newMessages); err != nil {
 		return nil, fmt.Errorf("failed to save new messages to memory: %w", err)
 	}
+	genai.StreamMemoryWrite(ctx, historyMessages, newMessages)
 
 	return responseMessages, nil
 }
@@ -795,15 +1260,15 @@ func (r *QueryReconciler) executeModel(ctx context.Context, query arkv1alpha1.Qu
 	modelKey := types.NamespacedName{Name: modelName, Namespace: query.Namespace}
 
 	if err := impersonatedClient.Get(ctx, modelKey, &modelCRD); err != nil {
-		return nil, fmt.Errorf("unable to get %v, error:%w", modelKey, err)
+		return nil, genai.NewResolutionError(fmt.Errorf("unable to get %v, error:%w", modelKey, err))
 	}
 
 	model, err := genai.LoadModel(ctx, impersonatedClient, &arkv1alpha1.AgentModelRef{Name: modelName, Namespace: query.Namespace}, query.Namespace, nil, r.Telemetry.ModelRecorder())
 	if err != nil {
-		return nil, fmt.Errorf("unable to load model %v, error:%w", modelKey, err)
+		return nil, genai.NewResolutionError(fmt.Errorf("unable to load model %v, error:%w", modelKey, err))
 	}
 
-	historyMessages, err := r.loadInitialMessages(ctx, memory)
+	historyMessages, err := r.loadInitialMessages(ctx, impersonatedClient, memory)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load initial messages: %w", err)
 	}
@@ -811,54 +1276,82 @@ func (r *QueryReconciler) executeModel(ctx context.Context, query arkv1alpha1.Qu
 	// Append all input messages to conversation history
 	allMessages := genai.PrepareModelMessages(inputMessages, historyMessages)
 
+	responseMessages, err := r.completeWithModel(ctx, model, modelName, "direct", allMessages, eventStream, tokenCollector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save all new messages (input + response) to memory
+	newMessages := genai.PrepareNewMessagesForMemory(inputMessages, responseMessages)
+	if err := memory.AddMessages(ctx, query.Name, newMessages); err != nil {
+		return nil, fmt.Errorf("failed to save new messages to memory: %w", err)
+	}
+	genai.StreamMemoryWrite(ctx, historyMessages, newMessages)
+
+	return responseMessages, nil
+}
+
+// executeSession summarizes the conversation stored in memory for the query's session with the model named
+// by the target, following the query input as the instruction. The summary is not added to the session.
+func (r *QueryReconciler) executeSession(ctx context.Context, query arkv1alpha1.Query, inputMessages []genai.Message, modelName string, impersonatedClient client.Client, memory genai.MemoryInterface, eventStream genai.EventStreamInterface, tokenCollector *genai.TokenUsageCollector) ([]genai.Message, error) {
+	if query.Spec.SessionId == "" {
+		return nil, genai.NewResolutionError(fmt.Errorf("session targets require the query to set sessionId"))
+	}
+
+	modelKey := types.NamespacedName{Name: modelName, Namespace: query.Namespace}
+	model, err := genai.LoadModel(ctx, impersonatedClient, &arkv1alpha1.AgentModelRef{Name: modelName, Namespace: query.Namespace}, query.Namespace, nil, r.Telemetry.ModelRecorder())
+	if err != nil {
+		return nil, genai.NewResolutionError(fmt.Errorf("unable to load model %v, error:%w", modelKey, err))
+	}
+
+	// The whole session is summarized, so the memory's truncation does not apply
+	transcript, err := memory.GetMessages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load session messages: %w", err)
+	}
+
+	messages, err := genai.BuildSessionSummaryMessages(query.Spec.SessionId, transcript, genai.ExtractUserMessageContent(inputMessages))
+	if err != nil {
+		return nil, genai.NewResolutionError(err)
+	}
+
+	return r.completeWithModel(ctx, model, modelName, "session", messages, eventStream, tokenCollector)
+}
+
+// completeWithModel runs a single chat completion, streaming it when the query is streamed, and tracks its
+// token usage
+func (r *QueryReconciler) completeWithModel(ctx context.Context, model *genai.Model, modelName, callType string, messages []genai.Message, eventStream genai.EventStreamInterface, tokenCollector *genai.TokenUsageCollector) ([]genai.Message, error) {
 	// Create operation tracker for the model call
 	modelTracker := genai.NewOperationTracker(tokenCollector, ctx, "ModelCall", modelName, map[string]string{
 		"model":     modelName,
-		"type":      "direct",
+		"type":      callType,
 		"streaming": fmt.Sprintf("%t", eventStream != nil),
 	})
 
-	var responseMessages []genai.Message
-
 	if eventStream != nil {
-		// Execute with streaming
 		// Token usage is tracked within executeModelWithStreaming via the modelTracker
-		var err error
-		responseMessages, err = r.executeModelWithStreaming(ctx, model, allMessages, eventStream, modelTracker)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		completion, err := model.ChatCompletion(ctx, allMessages, nil, 1)
-		if err != nil {
-			modelTracker.Fail(err)
-			return nil, fmt.Errorf("model chat completion failed: %w", err)
-		}
-
-		// Extract and track token usage
-		tokenUsage := genai.TokenUsage{
-			PromptTokens:     completion.Usage.PromptTokens,
-			CompletionTokens: completion.Usage.CompletionTokens,
-			TotalTokens:      completion.Usage.TotalTokens,
-		}
-		modelTracker.CompleteWithTokens(tokenUsage)
+		return r.executeModelWithStreaming(ctx, model, messages, eventStream, modelTracker)
+	}
 
-		if len(completion.Choices) == 0 {
-			return nil, fmt.Errorf("model returned no completion choices")
-		}
+	completion, err := model.ChatCompletion(ctx, messages, nil, 1)
+	if err != nil {
+		modelTracker.Fail(err)
+		return nil, fmt.Errorf("model chat completion failed: %w", err)
+	}
 
-		choice := completion.Choices[0]
-		assistantMessage := genai.NewAssistantMessage(choice.Message.Content)
-		responseMessages = []genai.Message{assistantMessage}
+	// Extract and track token usage
+	tokenUsage := genai.TokenUsage{
+		PromptTokens:     completion.Usage.PromptTokens,
+		CompletionTokens: completion.Usage.CompletionTokens,
+		TotalTokens:      completion.Usage.TotalTokens,
 	}
+	modelTracker.CompleteWithTokens(tokenUsage)
 
-	// Save all new messages (input + response) to memory
-	newMessages := genai.PrepareNewMessagesForMemory(inputMessages, responseMessages)
-	if err := memory.AddMessages(ctx, query.Name, newMessages); err != nil {
-		return nil, fmt.Errorf("failed to save new messages to memory: %w", err)
+	if len(completion.Choices) == 0 {
+		return nil, genai.Errorf(genai.ReasonMalformedResponse, "model returned no completion choices")
 	}
 
-	return responseMessages, nil
+	return []genai.Message{genai.NewAssistantMessage(completion.Choices[0].Message.Content)}, nil
 }
 
 func (r *QueryReconciler) executeTool(ctx context.Context, crd arkv1alpha1.Query, inputMessages []genai.Message, toolName string, impersonatedClient client.Client, tokenCollector *genai.TokenUsageCollector) ([]genai.Message, error) { //nolint:unparam
@@ -867,14 +1360,14 @@ func (r *QueryReconciler) executeTool(ctx context.Context, crd arkv1alpha1.Query
 
 	query, err := genai.MakeQuery(&crd)
 	if err != nil {
-		return nil, fmt.Errorf("unable to make query from CRD, error:%w", err)
+		return nil, genai.NewResolutionError(fmt.Errorf("unable to make query from CRD, error:%w", err))
 	}
 
 	var toolCRD arkv1alpha1.Tool
 	toolKey := types.NamespacedName{Name: toolName, Namespace: query.Namespace}
 
 	if err := impersonatedClient.Get(ctx, toolKey, &toolCRD); err != nil {
-		return nil, fmt.Errorf("unable to get tool %v, error:%w", toolKey, err)
+		return nil, genai.NewResolutionError(fmt.Errorf("unable to get tool %v, error:%w", toolKey, err))
 	}
 
 	// For tools, extract the content from the last message as tool arguments
@@ -922,7 +1415,7 @@ func (r *QueryReconciler) executeTool(ctx context.Context, crd arkv1alpha1.Query
 	mcpPool, McpSettings := toolRegistry.GetMCPPool()
 	executor, err := genai.CreateToolExecutor(ctx, impersonatedClient, &toolCRD, query.Namespace, mcpPool, McpSettings, r.Telemetry)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create tool executor: %w", err)
+		return nil, genai.NewResolutionError(fmt.Errorf("failed to create tool executor: %w", err))
 	}
 	toolRegistry.RegisterTool(toolDefinition, executor)
 
@@ -947,8 +1440,9 @@ func mustMarshalJSON(v any) string {
 	return string(data)
 }
 
-func (r *QueryReconciler) loadInitialMessages(ctx context.Context, memory genai.MemoryInterface) ([]genai.Message, error) {
-	messages, err := memory.GetMessages(ctx)
+// loadInitialMessages loads the history from memory, truncated as configured on the memory
+func (r *QueryReconciler) loadInitialMessages(ctx context.Context, impersonatedClient client.Client, memory genai.MemoryInterface) ([]genai.Message, error) {
+	messages, err := genai.LoadHistory(ctx, impersonatedClient, memory, r.Telemetry.ModelRecorder())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get messages from memory: %w", err)
 	}
@@ -960,41 +1454,64 @@ func (r *QueryReconciler) getClientForQuery(query arkv1alpha1.Query) (client.Cli
 	// If no service account specified, use controller's own identity.
 	// This allows queries to run without impersonation when not needed,
 	// and supports local development where impersonation isn't available.
+	// Executor pods already run as the query's service account.
 	serviceAccount := query.Spec.ServiceAccount
-	if serviceAccount == "" {
+	if serviceAccount == "" || r.RunAsServiceAccount {
 		return r.Client, nil
 	}
 
 	// Impersonate the specified service account.
-	// Note: This requires rbac.impersonation.enabled=true in the Helm chart.
-	// Future architecture will move this to per-namespace query executor pods.
-	cfg, err := rest.InClusterConfig()
+	// Note: This requires rbac.impersonation.enabled=true in the Helm chart,
+	// unless the namespace dispatches its queries to executor pods (see query_executor.go).
+	key := impersonatedClientKey{namespace: query.Namespace, serviceAccount: serviceAccount}
+	return r.getImpersonatedClients().get(key, func() (client.Client, error) {
+		// The client is rebuilt from the base config, with fresh credentials, when they are rejected mid-query
+		return newRefreshingClient(query.Namespace+"/"+serviceAccount, func() (client.Client, error) {
+			return r.newImpersonatedClient(query.Namespace, serviceAccount)
+		})
+	})
+}
+
+func (r *QueryReconciler) newImpersonatedClient(namespace, serviceAccount string) (client.Client, error) {
+	cfg, err := r.baseRestConfig()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get in-cluster config: %w", err)
+		return nil, err
 	}
 
 	cfg.Impersonate = rest.ImpersonationConfig{
-		UserName: fmt.Sprintf("system:serviceaccount:%s:%s", query.Namespace, serviceAccount),
+		UserName: common.ImpersonationUserName(namespace, serviceAccount),
 	}
 
+	// The manager's mapper is shared so that impersonated clients do not rediscover the API
 	impersonatedClient, err := client.New(cfg, client.Options{
 		Scheme: r.Scheme,
 		Mapper: r.RESTMapper(),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create impersonated client for service account %s/%s: %w", query.Namespace, serviceAccount, err)
+		return nil, fmt.Errorf("failed to create impersonated client for service account %s/%s: %w", namespace, serviceAccount, err)
 	}
-
 	return impersonatedClient, nil
 }
 
+// baseRestConfig returns a copy of the config impersonated clients are built from. The in-cluster
+// config is preferred; the manager's config is used outside the cluster so that tests and local
+// managers exercise the same impersonation code path.
+func (r *QueryReconciler) baseRestConfig() (*rest.Config, error) {
+	cfg, err := rest.InClusterConfig()
+	if err == nil {
+		return cfg, nil
+	}
+
+	if r.RestConfig == nil {
+		return nil, fmt.Errorf("failed to get in-cluster config: %w", err)
+	}
+
+	return rest.CopyConfig(r.RestConfig), nil
+}
+
 func (r *QueryReconciler) cleanupExistingOperation(namespacedName types.NamespacedName) {
-	if existingOp, exists := r.operations.Load(namespacedName); exists {
+	if r.getOperations().cancel(namespacedName) {
 		logf.Log.Info("Found existing operation, clearing due to cancel", "query", namespacedName.String())
-		if cancel, ok := existingOp.(context.CancelFunc); ok {
-			cancel()
-		}
-		r.operations.Delete(namespacedName)
 	} else {
 		logf.Log.Info("No existing operation found to cleanup", "query", namespacedName.String())
 	}
@@ -1017,7 +1534,7 @@ func (r *QueryReconciler) executeModelWithStreaming(ctx context.Context, model *
 	modelTracker.CompleteWithTokens(tokenUsage)
 
 	if len(completion.Choices) == 0 {
-		return nil, fmt.Errorf("model returned no completion choices")
+		return nil, genai.Errorf(genai.ReasonMalformedResponse, "model returned no completion choices")
 	}
 
 	choice := completion.Choices[0]
@@ -1030,9 +1547,76 @@ func (r *QueryReconciler) executeModelWithStreaming(ctx context.Context, model *
 	return responseMessages, nil
 }
 
+// isQueryRunning reports whether the query still exists in the running phase
+func (r *QueryReconciler) isQueryRunning(ctx context.Context, key types.NamespacedName) (bool, error) {
+	var query arkv1alpha1.Query
+	if err := r.Get(ctx, key, &query); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return query.Status.Phase == statusRunning, nil
+}
+
 func (r *QueryReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	// Report operations left behind by queries that are no longer running
+	var checker manager.Runnable = manager.RunnableFunc(func(ctx context.Context) error {
+		r.getOperations().checkPeriodically(ctx, operationCheckInterval, r.isQueryRunning)
+		return nil
+	})
+	// Sharded replicas all execute queries, rather than the leader alone
+	if r.Shard.sharded() {
+		checker = unelectedRunnable{checker}
+	}
+	if err := mgr.Add(checker); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &arkv1alpha1.Query{}, queryTargetIndexKey, indexQueryTargets); err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&arkv1alpha1.Query{}).
-		Named("query").
+		// Watch for Query events and start the pending queries that depend on them
+		Watches(
+			&arkv1alpha1.Query{},
+			handler.EnqueueRequestsFromMapFunc(r.findQueriesForDependency),
+		).
+		// Watch for target events and start the pending queries waiting for them
+		Watches(
+			&arkv1alpha1.Agent{},
+			handler.EnqueueRequestsFromMapFunc(r.findQueriesForTarget("agent")),
+		).
+		Watches(
+			&arkv1alpha1.Team{},
+			handler.EnqueueRequestsFromMapFunc(r.findQueriesForTarget("team")),
+		).
+		Watches(
+			&arkv1alpha1.Model{},
+			handler.EnqueueRequestsFromMapFunc(r.findQueriesForTarget("model")),
+		).
+		Watches(
+			&arkv1alpha1.Tool{},
+			handler.EnqueueRequestsFromMapFunc(r.findQueriesForTarget("tool")),
+		)
+	// Hand the running queries of a namespace over to its new shard
+	if r.Shard.sharded() {
+		b = b.Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findQueriesForShardChange),
+			builder.WithPredicates(queryShardChanged),
+		)
+	}
+	return b.
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(!r.Shard.sharded())}).
+		Named(queryControllerName).
 		Complete(r)
 }
+
+// unelectedRunnable runs on every replica, whether or not it is the leader
+type unelectedRunnable struct {
+	manager.Runnable
+}
+
+func (unelectedRunnable) NeedLeaderElection() bool {
+	return false
+}
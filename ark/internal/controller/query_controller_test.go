@@ -4,19 +4,31 @@ package controller
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/openai/openai-go"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
 	"mckinsey.com/ark/internal/genai"
+	telemetryconfig "mckinsey.com/ark/internal/telemetry/config"
 )
 
 var _ = Describe("Query Controller", func() {
@@ -238,6 +250,316 @@ var _ = Describe("Query Controller Message Serialization", func() {
 			_, err := serializeMessages(messages)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal("unknown message type encountered during serialization"))
+			Expect(genai.ReasonFor(err)).To(Equal(genai.ReasonMalformedResponse))
 		})
+
+		It("should return an error response rather than panic without messages", func() {
+			reconciler := &QueryReconciler{}
+			target := arkv1alpha1.QueryTarget{Type: "agent", Name: "silent-agent"}
+
+			response := reconciler.createSuccessResponse(target, []genai.Message{}, genai.FinishCompleted)
+			Expect(response.Phase).To(Equal(statusError))
+			Expect(response.Reason).To(Equal(string(genai.ReasonMalformedResponse)))
+		})
+
+		It("should join the text parts of messages split into parts", func() {
+			message := genai.Message(openai.UserMessage([]openai.ChatCompletionContentPartUnionParam{
+				openai.TextContentPart("first"),
+				openai.ImageContentPart(openai.ChatCompletionContentPartImageImageURLParam{URL: "https://example.com/chart.png"}),
+				openai.TextContentPart("second"),
+			}))
+			Expect(messageToText(message)).To(Equal("first\nsecond"))
+			Expect(messageToText(genai.Message(openai.DeveloperMessage("be brief")))).To(Equal("be brief"))
+			Expect(messageToText(genai.Message{})).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("Query Controller Impersonation", func() {
+	Context("When running outside a cluster", func() {
+		It("should build an impersonated client from the manager config", func() {
+			controllerReconciler := &QueryReconciler{
+				Client:     k8sClient,
+				Scheme:     k8sClient.Scheme(),
+				RestConfig: cfg,
+			}
+
+			query := arkv1alpha1.Query{
+				ObjectMeta: metav1.ObjectMeta{Name: "impersonated-query", Namespace: "default"},
+				Spec:       arkv1alpha1.QuerySpec{ServiceAccount: "query-runner"},
+			}
+
+			impersonatedClient, err := controllerReconciler.getClientForQuery(query)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(impersonatedClient).NotTo(BeNil())
+			Expect(cfg.Impersonate.UserName).To(BeEmpty(), "manager config must not be mutated")
+
+			reused, err := controllerReconciler.getClientForQuery(query)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reused).To(BeIdenticalTo(impersonatedClient), "the client of a service account is reused")
+		})
+
+		It("should fail when neither in-cluster nor manager config is available", func() {
+			controllerReconciler := &QueryReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			query := arkv1alpha1.Query{
+				ObjectMeta: metav1.ObjectMeta{Name: "impersonated-query", Namespace: "default"},
+				Spec:       arkv1alpha1.QuerySpec{ServiceAccount: "query-runner"},
+			}
+
+			_, err := controllerReconciler.getClientForQuery(query)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Impersonation preflight", func() {
+		ctx := context.Background()
+
+		var (
+			query    *arkv1alpha1.Query
+			recorder *record.FakeRecorder
+			reviewed *authorizationv1.SelfSubjectAccessReview
+		)
+
+		BeforeEach(func() {
+			query = &arkv1alpha1.Query{
+				ObjectMeta: metav1.ObjectMeta{Name: "impersonated-query", Namespace: "default"},
+				Spec:       arkv1alpha1.QuerySpec{ServiceAccount: "query-runner"},
+			}
+			recorder = record.NewFakeRecorder(10)
+			reviewed = nil
+		})
+
+		// newReconciler answers the SelfSubjectAccessReview of the preflight with allowed
+		newReconciler := func(allowed bool) *QueryReconciler {
+			fakeClient := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).
+				WithStatusSubresource(&arkv1alpha1.Query{}).WithObjects(query).Build()
+			reviewingClient := interceptor.NewClient(fakeClient, interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if review, ok := obj.(*authorizationv1.SelfSubjectAccessReview); ok {
+						review.Status.Allowed = allowed
+						reviewed = review
+						return nil
+					}
+					return c.Create(ctx, obj, opts...)
+				},
+			})
+			return &QueryReconciler{Client: reviewingClient, Scheme: k8sClient.Scheme(), Recorder: recorder}
+		}
+
+		It("should pass when the controller may impersonate the service account", func() {
+			Expect(newReconciler(true).preflightImpersonation(ctx, query)).To(Succeed())
+
+			Expect(reviewed).NotTo(BeNil())
+			Expect(*reviewed.Spec.ResourceAttributes).To(Equal(authorizationv1.ResourceAttributes{
+				Namespace: "default",
+				Verb:      "impersonate",
+				Resource:  "serviceaccounts",
+				Name:      "query-runner",
+			}))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should fail the query with a clear condition when impersonation is denied", func() {
+			r := newReconciler(false)
+			tracker := genai.NewOperationTracker(genai.NewQueryRecorder(query, record.NewFakeRecorder(10)), ctx, "Query", query.Name, nil)
+
+			_, _, err := r.setupQueryExecution(ctx, *query, tracker, nil, "")
+			Expect(err).To(HaveOccurred())
+
+			var failed arkv1alpha1.Query
+			Expect(r.Get(ctx, types.NamespacedName{Name: query.Name, Namespace: query.Namespace}, &failed)).To(Succeed())
+			Expect(failed.Status.Phase).To(Equal(statusError))
+			condition := meta.FindStatusCondition(failed.Status.Conditions, string(arkv1alpha1.QueryCompleted))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(common.ImpersonationNotPermittedReason))
+			Expect(condition.Message).To(ContainSubstring("impersonation not permitted for service account default/query-runner"))
+			Expect(condition.Message).To(ContainSubstring("enable rbac.impersonation.enabled"))
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning " + common.ImpersonationNotPermittedReason)))
+		})
+
+		It("should skip the check for a query without a service account", func() {
+			query.Spec.ServiceAccount = ""
+
+			Expect(newReconciler(false).preflightImpersonation(ctx, query)).To(Succeed())
+			Expect(reviewed).To(BeNil())
+		})
+	})
+
+	Context("Correlation ID", func() {
+		It("should generate the correlation ID of a query once", func() {
+			query := &arkv1alpha1.Query{}
+			correlationID := ensureCorrelationID(query)
+			Expect(correlationID).NotTo(BeEmpty())
+			Expect(query.Status.CorrelationID).To(Equal(correlationID))
+			Expect(ensureCorrelationID(query)).To(Equal(correlationID))
+		})
+	})
+})
+
+var _ = Describe("Query Controller Cancellation", func() {
+	reconciler := &QueryReconciler{}
+	target := arkv1alpha1.QueryTarget{Type: "agent", Name: "test-agent"}
+
+	It("should preserve partial output for canceled targets", func() {
+		messages := []genai.Message{genai.Message(openai.AssistantMessage("partial answer"))}
+		err := genai.NewError(genai.ReasonCanceled, context.Canceled)
+
+		response := reconciler.createCanceledResponse(target, messages, err)
+		Expect(response.Phase).To(Equal(statusCanceled))
+		Expect(response.Reason).To(Equal(string(genai.ReasonCanceled)))
+		Expect(response.Content).To(Equal("partial answer"))
+		Expect(response.Raw).To(ContainSubstring("partial answer"))
+	})
+
+	It("should report timed out targets as canceled rather than errored", func() {
+		err := genai.NewError(genai.ReasonQueryTimeout, context.DeadlineExceeded)
+		results := make(chan targetResult, 1)
+		results <- targetResult{err: err, target: target}
+		close(results)
+
+		responses := reconciler.processTargetResults(results)
+
+		Expect(responses).To(HaveLen(1))
+		Expect(responses[0].Phase).To(Equal(statusCanceled))
+		Expect(responses[0].Reason).To(Equal(string(genai.ReasonQueryTimeout)))
+	})
+
+	It("should prefer error over canceled when determining query status", func() {
+		Expect(reconciler.determineQueryStatus([]arkv1alpha1.Response{{Phase: statusDone}, {Phase: statusCanceled}})).To(Equal(statusCanceled))
+		Expect(reconciler.determineQueryStatus([]arkv1alpha1.Response{{Phase: statusCanceled}, {Phase: statusError}})).To(Equal(statusError))
+		Expect(reconciler.determineQueryStatus([]arkv1alpha1.Response{{Phase: statusDone}})).To(Equal(statusDone))
+	})
+})
+
+var _ = Describe("Query Controller Token Budget", func() {
+	reconciler := &QueryReconciler{}
+	target := arkv1alpha1.QueryTarget{Type: "team", Name: "selector-team"}
+	canceled := targetResult{target: target, err: genai.NewError(genai.ReasonCanceled, context.Canceled)}
+
+	It("should fail the targets canceled by an exceeded token budget", func() {
+		tokenCollector := genai.NewTokenUsageCollector(discardEventEmitter{})
+		tokenCollector.SetBudget(100, nil)
+		tokenCollector.EmitEvent(context.Background(), corev1.EventTypeNormal, "LLMCallComplete", genai.OperationEvent{
+			TokenUsage: genai.TokenUsage{TotalTokens: 120},
+		})
+
+		results := make(chan targetResult, 1)
+		results <- overTokenBudget(canceled, tokenCollector)
+		close(results)
+		responses := reconciler.processTargetResults(results)
+
+		Expect(responses).To(HaveLen(1))
+		Expect(responses[0].Phase).To(Equal(statusError))
+		Expect(responses[0].Reason).To(Equal(string(genai.ReasonBudgetExceeded)))
+		Expect(responses[0].Content).To(ContainSubstring("query used 120 tokens, exceeding its budget of 100"))
+	})
+
+	It("should keep cancellations within the token budget", func() {
+		tokenCollector := genai.NewTokenUsageCollector(discardEventEmitter{})
+		tokenCollector.SetBudget(100, nil)
+
+		result := overTokenBudget(canceled, tokenCollector)
+		Expect(genai.ReasonFor(result.err)).To(Equal(genai.ReasonCanceled))
+	})
+})
+
+var _ = Describe("Query Controller Finish Reasons", func() {
+	reconciler := &QueryReconciler{}
+	target := arkv1alpha1.QueryTarget{Type: "team", Name: "test-team"}
+
+	It("should report how done responses finished", func() {
+		messages := []genai.Message{genai.Message(openai.AssistantMessage("answer"))}
+		results := make(chan targetResult, 2)
+		results <- targetResult{messages: messages, target: target, finishReason: genai.FinishMaxTurnsReached}
+		results <- targetResult{messages: messages, target: target, finishReason: genai.FinishCompleted}
+		close(results)
+
+		responses := reconciler.processTargetResults(results)
+
+		Expect(responses).To(HaveLen(2))
+		Expect(responses[0].Phase).To(Equal(statusDone))
+		Expect(responses[0].Reason).To(Equal(string(genai.FinishMaxTurnsReached)))
+		Expect(responses[1].Reason).To(Equal(string(genai.FinishCompleted)))
+	})
+})
+
+var _ = Describe("Query Controller Sequential Targets", func() {
+	ctx := context.Background()
+
+	It("should run the targets in order and keep going after a failed one", func() {
+		var mu sync.Mutex
+		var called []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			called = append(called, r.URL.Path)
+			mu.Unlock()
+			_, _ = w.Write([]byte("answer from " + r.URL.Path))
+		}))
+		DeferCleanup(server.Close)
+
+		httpTool := func(name string) *arkv1alpha1.Tool {
+			return &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec:       arkv1alpha1.ToolSpec{Type: genai.ToolTypeHTTP, HTTP: &arkv1alpha1.HTTPSpec{URL: server.URL + "/" + name, Method: http.MethodGet}},
+			}
+		}
+		toolClient := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).
+			WithObjects(httpTool("first"), httpTool("third"), httpTool("fourth")).Build()
+
+		query := arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "sequential-query", Namespace: "default"},
+			Spec:       arkv1alpha1.QuerySpec{TargetExecution: arkv1alpha1.TargetExecutionSequential},
+		}
+		Expect(query.Spec.SetInputString("look it up")).To(Succeed())
+		targets := []arkv1alpha1.QueryTarget{
+			{Type: "tool", Name: "first"},
+			{Type: "tool", Name: "missing"},
+			{Type: "tool", Name: "third"},
+			{Type: "tool", Name: "fourth"},
+		}
+
+		reconciler := &QueryReconciler{Telemetry: telemetryconfig.NewProvider()}
+		responses := reconciler.executeTargetsSequentially(ctx, query, targets, toolClient, genai.NewNoopMemory(), nil,
+			genai.NewTokenUsageCollector(discardEventEmitter{}))
+
+		Expect(called).To(Equal([]string{"/first", "/third", "/fourth"}))
+		Expect(responses).To(HaveLen(4))
+		for i, response := range responses {
+			Expect(response.Target).To(Equal(targets[i]))
+		}
+		Expect(responses[0].Phase).To(Equal(statusDone))
+		Expect(responses[0].Content).To(Equal("answer from /first"))
+		Expect(responses[1].Phase).To(Equal(statusUnresolved))
+		Expect(responses[2].Phase).To(Equal(statusDone))
+		Expect(responses[2].Content).To(Equal("answer from /third"))
+		Expect(responses[3].Content).To(Equal("answer from /fourth"))
+		Expect(reconciler.determineQueryStatus(responses)).To(Equal(statusError))
+	})
+})
+
+var _ = Describe("Query Controller Resolution Errors", func() {
+	reconciler := &QueryReconciler{}
+	target := arkv1alpha1.QueryTarget{Type: "agent", Name: "missing-agent"}
+
+	It("should report missing targets as unresolved", func() {
+		notFound := errors.NewNotFound(arkv1alpha1.GroupVersion.WithResource("agents").GroupResource(), "missing-agent")
+		response := reconciler.createErrorResponse(target, genai.NewResolutionError(notFound))
+
+		Expect(response.Phase).To(Equal(statusUnresolved))
+		Expect(response.Reason).To(Equal(string(genai.ReasonResourceNotFound)))
+		Expect(response.Raw).To(ContainSubstring("target_resolution_error"))
+		Expect(reconciler.determineQueryStatus([]arkv1alpha1.Response{response})).To(Equal(statusError))
+	})
+
+	It("should report runtime failures as errors", func() {
+		response := reconciler.createErrorResponse(target, genai.NewError(genai.ReasonProviderRateLimited, context.DeadlineExceeded))
+
+		Expect(response.Phase).To(Equal(statusError))
+		Expect(response.Reason).To(Equal(string(genai.ReasonProviderRateLimited)))
+		Expect(response.Raw).To(ContainSubstring("target_execution_error"))
 	})
 })
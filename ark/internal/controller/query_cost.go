@@ -0,0 +1,34 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+// newCostTracker returns the tracker of the cost of a query, with its budget when it has one
+func (r *QueryReconciler) newCostTracker(ctx context.Context, query arkv1alpha1.Query) *genai.CostTracker {
+	if query.Spec.MaxCost == "" {
+		return genai.NewCostTracker(0)
+	}
+	budget, err := genai.ParseCost(query.Spec.MaxCost)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "ignoring invalid maxCost", "maxCost", query.Spec.MaxCost)
+		return genai.NewCostTracker(0)
+	}
+	return genai.NewCostTracker(budget)
+}
+
+// queryCost formats the cost of a query for its status, or is empty when none of its models has pricing
+func queryCost(costs *genai.CostTracker) string {
+	total, priced := costs.Total()
+	if !priced {
+		return ""
+	}
+	return genai.FormatCost(total)
+}
@@ -0,0 +1,80 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/genai"
+)
+
+// deadLetterQuery publishes a query that failed permanently to the dead-letter sink in the background, so a
+// slow sink does not hold up the query. A query is published once: the publish is skipped while one is in
+// flight and once the query has the dead-letter-published-at annotation.
+func (r *QueryReconciler) deadLetterQuery(ctx context.Context, query *arkv1alpha1.Query) {
+	if query.Annotations[annotations.DeadLetterPublishedAt] != "" {
+		return
+	}
+	if _, publishing := r.deadLetters.LoadOrStore(query.UID, struct{}{}); publishing {
+		return
+	}
+
+	// The query outlives the operation that failed it, so the publish is not canceled with it
+	ctx = context.WithoutCancel(ctx)
+	query = query.DeepCopy()
+	done := trackGoroutine(queryControllerName)
+	go func() {
+		defer done()
+		defer r.deadLetters.Delete(query.UID)
+		if r.publishDeadLetter(ctx, query) {
+			r.markDeadLetterPublished(ctx, query)
+		}
+	}()
+}
+
+// markDeadLetterPublished annotates the query with the time its dead letter was published. The annotation is
+// merged into the metadata, so it does not conflict with status updates of the query.
+func (r *QueryReconciler) markDeadLetterPublished(ctx context.Context, query *arkv1alpha1.Query) {
+	patch := client.MergeFrom(query.DeepCopy())
+	if query.Annotations == nil {
+		query.Annotations = map[string]string{}
+	}
+	query.Annotations[annotations.DeadLetterPublishedAt] = time.Now().UTC().Format(time.RFC3339)
+	if err := r.Patch(ctx, query, patch); client.IgnoreNotFound(err) != nil {
+		logf.FromContext(ctx).Error(err, "failed to mark dead letter published", "query", query.Name, "namespace", query.Namespace)
+	}
+}
+
+// publishDeadLetter sends a query that failed permanently to the dead-letter sink of its namespace, so that
+// the failure can be triaged after the events of the query expire. A failure to publish is reported with an
+// event and does not affect the query. It returns whether the query was published.
+func (r *QueryReconciler) publishDeadLetter(ctx context.Context, query *arkv1alpha1.Query) bool {
+	log := logf.FromContext(ctx)
+
+	config, err := genai.GetDeadLetterConfig(ctx, r.Client, query.Namespace)
+	if err == nil && config == nil {
+		return false
+	}
+	if err == nil {
+		reason, message := "QueryErrored", "Query completed with error"
+		if condition := meta.FindStatusCondition(query.Status.Conditions, string(arkv1alpha1.QueryCompleted)); condition != nil {
+			reason, message = condition.Reason, condition.Message
+		}
+		err = genai.PublishDeadLetter(ctx, r.Client, config, query.Namespace, genai.NewDeadLetter(query, reason, message))
+	}
+	if err != nil {
+		log.Error(err, "failed to publish dead letter", "query", query.Name, "namespace", query.Namespace)
+		r.Recorder.Event(query, corev1.EventTypeWarning, "DeadLetterFailed", err.Error())
+		return false
+	}
+	r.Recorder.Event(query, corev1.EventTypeNormal, "DeadLetterPublished", "Published the failed query to the dead-letter sink")
+	return true
+}
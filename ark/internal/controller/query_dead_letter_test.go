@@ -0,0 +1,67 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/genai"
+)
+
+var _ = Describe("Query Dead Letters", func() {
+	ctx := context.Background()
+
+	It("should publish a failed query once, without waiting for the sink", func() {
+		var published atomic.Int32
+		release := make(chan struct{})
+		sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+			published.Add(1)
+		}))
+		DeferCleanup(sink.Close)
+
+		query := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "failed-query", Namespace: "default", UID: "failed-query-uid"},
+		}
+		sinkConfig := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: genai.DeadLetterConfigMapName, Namespace: "default"},
+			Data:       map[string]string{"url": sink.URL},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).
+			WithStatusSubresource(&arkv1alpha1.Query{}).WithObjects(query, sinkConfig).Build()
+		r := &QueryReconciler{Client: fakeClient, Scheme: k8sClient.Scheme(), Recorder: record.NewFakeRecorder(10)}
+
+		By("returning while the sink is still receiving the query")
+		Expect(r.updateStatus(ctx, query, statusError)).To(Succeed())
+		Expect(r.failQuery(ctx, query, "QueryErrored", "boom")).To(Succeed())
+		close(release)
+
+		By("annotating the query once it is published")
+		key := types.NamespacedName{Name: query.Name, Namespace: query.Namespace}
+		Eventually(func(g Gomega) {
+			var annotated arkv1alpha1.Query
+			g.Expect(fakeClient.Get(ctx, key, &annotated)).To(Succeed())
+			g.Expect(annotated.Annotations).To(HaveKey(annotations.DeadLetterPublishedAt))
+		}).Should(Succeed())
+		Expect(published.Load()).To(Equal(int32(1)))
+
+		By("not publishing an annotated query again")
+		Expect(fakeClient.Get(ctx, key, query)).To(Succeed())
+		Expect(r.failQuery(ctx, query, "QueryErrored", "boom")).To(Succeed())
+		Consistently(published.Load, 100*time.Millisecond).Should(Equal(int32(1)))
+	})
+})
@@ -0,0 +1,82 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// checkDependencies returns whether the queries the query depends on have all completed successfully. While
+// they have not, the query is kept pending; when one of them fails or is canceled, the query fails.
+func (r *QueryReconciler) checkDependencies(ctx context.Context, query *arkv1alpha1.Query) (bool, error) {
+	var waiting []string
+	for _, name := range query.Spec.DependsOn {
+		var dependency arkv1alpha1.Query
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: query.Namespace}, &dependency); err != nil {
+			if errors.IsNotFound(err) {
+				waiting = append(waiting, name)
+				continue
+			}
+			return false, err
+		}
+
+		switch dependency.Status.Phase {
+		case statusDone:
+		case statusError:
+			return false, r.failQuery(ctx, query, "DependencyFailed", fmt.Sprintf("dependency query %s failed", name))
+		case statusCanceled:
+			return false, r.failQuery(ctx, query, "DependencyFailed", fmt.Sprintf("dependency query %s was canceled", name))
+		default:
+			waiting = append(waiting, name)
+		}
+	}
+
+	if len(waiting) == 0 {
+		return true, nil
+	}
+
+	message := fmt.Sprintf("Waiting for dependency queries: %s", strings.Join(waiting, ", "))
+	// Status updates trigger a reconcile, so the status is only updated when the dependencies waited on change
+	condition := meta.FindStatusCondition(query.Status.Conditions, string(arkv1alpha1.QueryCompleted))
+	if query.Status.Phase == statusPending && condition != nil && condition.Message == message {
+		return false, nil
+	}
+	logf.FromContext(ctx).V(1).Info("query waiting for dependencies", "query", query.Name, "dependencies", waiting)
+	query.Status.Phase = statusPending
+	r.setConditionCompleted(query, metav1.ConditionFalse, "QueryWaitingForDependencies", message)
+	return false, r.Status().Update(ctx, query)
+}
+
+// findQueriesForDependency returns the pending queries that depend on the given query, so that they are
+// reconciled when it completes
+func (r *QueryReconciler) findQueriesForDependency(ctx context.Context, obj client.Object) []reconcile.Request {
+	var queries arkv1alpha1.QueryList
+	if err := r.List(ctx, &queries, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list queries for dependency", "query", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, query := range queries.Items {
+		if query.Status.Phase != "" && query.Status.Phase != statusPending {
+			continue
+		}
+		if slices.Contains(query.Spec.DependsOn, obj.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&query)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,98 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+var _ = Describe("Query dependencies", func() {
+	ctx := context.Background()
+
+	createQuery := func(name string, dependsOn ...string) *arkv1alpha1.Query {
+		query := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: arkv1alpha1.QuerySpec{
+				Targets:   []arkv1alpha1.QueryTarget{{Type: "agent", Name: "test-agent"}},
+				DependsOn: dependsOn,
+			},
+		}
+		Expect(query.Spec.SetInputString("test input question")).To(Succeed())
+		Expect(k8sClient.Create(ctx, query)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, query)
+		return query
+	}
+
+	setPhase := func(query *arkv1alpha1.Query, phase string) {
+		query.Status.Phase = phase
+		Expect(k8sClient.Status().Update(ctx, query)).To(Succeed())
+	}
+
+	newReconciler := func() *QueryReconciler {
+		return &QueryReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+	}
+
+	It("should keep a query pending until its dependencies are done", func() {
+		upstream := createQuery("dependency-upstream")
+		setPhase(upstream, statusRunning)
+		query := createQuery("dependency-downstream", upstream.Name, "dependency-missing")
+
+		ready, err := newReconciler().checkDependencies(ctx, query)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ready).To(BeFalse())
+
+		updated := &arkv1alpha1.Query{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(query), updated)).To(Succeed())
+		Expect(updated.Status.Phase).To(Equal(statusPending))
+		condition := meta.FindStatusCondition(updated.Status.Conditions, string(arkv1alpha1.QueryCompleted))
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal("QueryWaitingForDependencies"))
+		Expect(condition.Message).To(ContainSubstring("dependency-upstream, dependency-missing"))
+
+		Expect(newReconciler().findQueriesForDependency(ctx, upstream)).To(ConsistOf(
+			reconcile.Request{NamespacedName: client.ObjectKeyFromObject(query)},
+		))
+
+		setPhase(upstream, statusDone)
+		createQuery("dependency-missing")
+		ready, err = newReconciler().checkDependencies(ctx, updated)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ready).To(BeFalse(), "the created dependency has not run yet")
+	})
+
+	It("should fail a query whose dependency failed", func() {
+		upstream := createQuery("dependency-failed-upstream")
+		setPhase(upstream, statusError)
+		query := createQuery("dependency-failed-downstream", upstream.Name)
+
+		ready, err := newReconciler().checkDependencies(ctx, query)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ready).To(BeFalse())
+
+		updated := &arkv1alpha1.Query{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(query), updated)).To(Succeed())
+		Expect(updated.Status.Phase).To(Equal(statusError))
+		condition := meta.FindStatusCondition(updated.Status.Conditions, string(arkv1alpha1.QueryCompleted))
+		Expect(condition.Reason).To(Equal("DependencyFailed"))
+		Expect(condition.Message).To(Equal("dependency query dependency-failed-upstream failed"))
+	})
+
+	It("should start a query once its dependencies are done", func() {
+		upstream := createQuery("dependency-done-upstream")
+		setPhase(upstream, statusDone)
+		query := createQuery("dependency-done-downstream", upstream.Name)
+
+		ready, err := newReconciler().checkDependencies(ctx, query)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ready).To(BeTrue())
+	})
+})
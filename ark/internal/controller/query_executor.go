@@ -0,0 +1,446 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
+	"mckinsey.com/ark/internal/executor"
+	"mckinsey.com/ark/internal/labels"
+)
+
+const (
+	// QueryExecutorEnabled is the value of the QueryExecutorLabel of namespaces whose queries run in executor pods
+	QueryExecutorEnabled = "enabled"
+
+	// executorPollInterval is how often a query waiting for its executor pod to become available is dispatched again
+	executorPollInterval = 5 * time.Second
+
+	executorNamePrefix     = "ark-executor-"
+	executorContainerName  = "executor"
+	executorPortName       = "grpc"
+	executorServiceAccount = "ark.mckinsey.com/executor-service-account"
+	executorIdentityVolume = "workload-identity"
+	executorIdentityDir    = "/var/run/ark/workload-identity"
+)
+
+// executorLabels are the labels of the Deployments, Services and pods of executor pods
+var executorLabels = map[string]string{
+	"app.kubernetes.io/name":       "ark-executor",
+	"app.kubernetes.io/managed-by": "ark-controller",
+}
+
+// QueryExecutors runs the executor pods the queries of namespaces labelled with labels.QueryExecutorLabel are
+// dispatched to. A namespace has an executor pod per service account its queries run as, and the pod runs as that
+// service account, so queries are not impersonated and a query can only reach what its service account may.
+//
+// The controller and executor pods authenticate each other over mutual TLS with their workload certificates. The
+// certificate of an executor pod is mounted by CSIDriver and holds the SPIFFE ID of its service account,
+// spiffe://<trust domain>/ns/<namespace>/sa/<service account>, as issued by the cert-manager SPIFFE CSI driver.
+type QueryExecutors struct {
+	// Image of the executor pods, the image of the controller
+	Image string
+	// Port the executor pods serve on, executor.DefaultPort when zero
+	Port int
+	// WorkloadIdentity is the directory of the workload certificate the controller presents to executor pods
+	WorkloadIdentity string
+	// SPIFFEID of the workload certificate of the controller, the only caller executor pods accept
+	SPIFFEID *url.URL
+	// CSIDriver mounts the workload certificate of executor pods
+	CSIDriver string
+	// newRunner connects to the executor pod at an address, executor.NewClient when nil
+	newRunner func(address string) (executor.Runner, error)
+	mu        sync.Mutex
+	runners   map[types.NamespacedName]executor.Runner
+}
+
+func (e *QueryExecutors) port() int {
+	if e.Port == 0 {
+		return executor.DefaultPort
+	}
+	return e.Port
+}
+
+// runner returns the connection to the executor pod serving the service account in the namespace
+func (e *QueryExecutors) runner(namespace, serviceAccount string) (executor.Runner, error) {
+	key := types.NamespacedName{Namespace: namespace, Name: serviceAccount}
+	address := fmt.Sprintf("%s.%s.svc:%d", executorName(serviceAccount), namespace, e.port())
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if runner, ok := e.runners[key]; ok {
+		return runner, nil
+	}
+	newRunner := e.newRunner
+	if newRunner == nil {
+		newRunner = func(address string) (executor.Runner, error) {
+			tlsConfig := &common.ClientTLSConfig{WorkloadIdentity: e.WorkloadIdentity, ServerSPIFFEID: e.executorSPIFFEID(namespace, serviceAccount)}
+			return executor.NewClient(address, tlsConfig.TLSConfig())
+		}
+	}
+	runner, err := newRunner(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to executor %s: %w", address, err)
+	}
+	if e.runners == nil {
+		e.runners = map[types.NamespacedName]executor.Runner{}
+	}
+	e.runners[key] = runner
+	return runner, nil
+}
+
+// evict closes the connections to the executor pods of the namespace
+func (e *QueryExecutors) evict(namespace string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for key, runner := range e.runners {
+		if key.Namespace != namespace {
+			continue
+		}
+		if closer, ok := runner.(io.Closer); ok {
+			_ = closer.Close()
+		}
+		delete(e.runners, key)
+	}
+}
+
+// executorSPIFFEID returns the SPIFFE ID of the executor pod of the service account, in the trust domain of the
+// controller
+func (e *QueryExecutors) executorSPIFFEID(namespace, serviceAccount string) string {
+	return fmt.Sprintf("spiffe://%s/ns/%s/sa/%s", e.SPIFFEID.Host, namespace, serviceAccount)
+}
+
+// ensureExecutor creates or updates the Deployment and Service of the executor pod of the service account in the
+// namespace, and reports whether the pod is available
+func (e *QueryExecutors) ensureExecutor(ctx context.Context, k8sClient client.Client, namespace, serviceAccount string) (bool, error) {
+	name := executorName(serviceAccount)
+	podLabels := map[string]string{"app.kubernetes.io/instance": name}
+	for key, value := range executorLabels {
+		podLabels[key] = value
+	}
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, k8sClient, deployment, func() error {
+		deployment.Labels = podLabels
+		deployment.Annotations = map[string]string{executorServiceAccount: serviceAccount}
+		if deployment.CreationTimestamp.IsZero() {
+			deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: podLabels}
+		}
+		deployment.Spec.Replicas = ptr.To(int32(1))
+		deployment.Spec.Template = e.podTemplate(serviceAccount, podLabels)
+		return nil
+	}); err != nil {
+		return false, fmt.Errorf("failed to create executor deployment %s: %w", name, err)
+	}
+
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, k8sClient, service, func() error {
+		service.Labels = podLabels
+		service.Spec.Selector = podLabels
+		service.Spec.Ports = []corev1.ServicePort{{
+			Name:       executorPortName,
+			Port:       int32(e.port()),
+			TargetPort: intstr.FromString(executorPortName),
+			Protocol:   corev1.ProtocolTCP,
+		}}
+		return controllerutil.SetControllerReference(deployment, service, k8sClient.Scheme())
+	}); err != nil {
+		return false, fmt.Errorf("failed to create executor service %s: %w", name, err)
+	}
+
+	return deployment.Status.AvailableReplicas > 0, nil
+}
+
+func (e *QueryExecutors) podTemplate(serviceAccount string, podLabels map[string]string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: serviceAccount,
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot:   ptr.To(true),
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+			Containers: []corev1.Container{{
+				Name:  executorContainerName,
+				Image: e.Image,
+				Args: []string{
+					"--query-executor",
+					fmt.Sprintf("--query-executor-port=%d", e.port()),
+					"--workload-identity-dir=" + executorIdentityDir,
+					"--query-executor-controller-id=" + e.SPIFFEID.String(),
+				},
+				Env: []corev1.EnvVar{
+					{Name: "POD_NAMESPACE", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}}},
+					{Name: "POD_SERVICE_ACCOUNT", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.serviceAccountName"}}},
+				},
+				Ports:        []corev1.ContainerPort{{Name: executorPortName, ContainerPort: int32(e.port()), Protocol: corev1.ProtocolTCP}},
+				VolumeMounts: []corev1.VolumeMount{{Name: executorIdentityVolume, MountPath: executorIdentityDir, ReadOnly: true}},
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString(executorPortName)}},
+				},
+				SecurityContext: &corev1.SecurityContext{
+					AllowPrivilegeEscalation: ptr.To(false),
+					Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+				},
+			}},
+			Volumes: []corev1.Volume{{
+				Name:         executorIdentityVolume,
+				VolumeSource: corev1.VolumeSource{CSI: &corev1.CSIVolumeSource{Driver: e.CSIDriver, ReadOnly: ptr.To(true)}},
+			}},
+		},
+	}
+}
+
+// executorName returns the name of the Deployment and Service of the executor pod of a service account
+func executorName(serviceAccount string) string {
+	return shortenName(executorNamePrefix+serviceAccount, validation.DNS1035LabelMaxLength)
+}
+
+// executorServiceAccountFor returns the service account the executor pod of the query runs as. Queries without a
+// service account run as the default service account of their namespace.
+func executorServiceAccountFor(query *arkv1alpha1.Query) string {
+	if query.Spec.ServiceAccount == "" {
+		return "default"
+	}
+	return query.Spec.ServiceAccount
+}
+
+// dispatchesQuery reports whether the query runs in an executor pod of its namespace rather than in the controller
+func (r *QueryReconciler) dispatchesQuery(ctx context.Context, query *arkv1alpha1.Query) (bool, error) {
+	if r.Executors == nil {
+		return false, nil
+	}
+	var namespace corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: query.Namespace}, &namespace); err != nil {
+		return false, fmt.Errorf("failed to get namespace %s: %w", query.Namespace, err)
+	}
+	return namespace.Labels[labels.QueryExecutorLabel] == QueryExecutorEnabled, nil
+}
+
+// dispatchQuery sends a running query to the executor pod of its service account, once the pod is available. The
+// query is sent again on every reconcile while it runs, which the executor pod ignores while it executes it, so a
+// query is picked up again by a restarted executor pod.
+func (r *QueryReconciler) dispatchQuery(ctx context.Context, query *arkv1alpha1.Query) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	serviceAccount := executorServiceAccountFor(query)
+
+	available, err := r.Executors.ensureExecutor(ctx, r.Client, query.Namespace, serviceAccount)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !available {
+		log.V(1).Info("waiting for executor pod", "query", query.Name, "namespace", query.Namespace, "executor", executorName(serviceAccount))
+		return ctrl.Result{RequeueAfter: executorPollInterval}, nil
+	}
+
+	runner, err := r.Executors.runner(query.Namespace, serviceAccount)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := runner.ExecuteQuery(ctx, types.NamespacedName{Namespace: query.Namespace, Name: query.Name}); err != nil {
+		if status.Code(err) == codes.Unavailable {
+			log.Info("executor pod unavailable, retrying", "query", query.Name, "namespace", query.Namespace, "executor", executorName(serviceAccount), "error", err.Error())
+			return ctrl.Result{RequeueAfter: executorPollInterval}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to dispatch query to executor %s: %w", executorName(serviceAccount), err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// cancelDispatchedQuery stops the execution of a query in its executor pod
+func (r *QueryReconciler) cancelDispatchedQuery(ctx context.Context, query *arkv1alpha1.Query) {
+	log := logf.FromContext(ctx)
+	if dispatched, err := r.dispatchesQuery(ctx, query); err != nil || !dispatched {
+		return
+	}
+	runner, err := r.Executors.runner(query.Namespace, executorServiceAccountFor(query))
+	if err == nil {
+		err = runner.CancelQuery(ctx, types.NamespacedName{Namespace: query.Namespace, Name: query.Name})
+	}
+	if err != nil {
+		log.Error(err, "failed to cancel query in executor pod", "query", query.Name, "namespace", query.Namespace)
+	}
+}
+
+// QueryExecutor runs in an executor pod and executes the queries the controller dispatches to it. The pod runs as
+// the service account of the queries, so the reconciler executes them with its own identity.
+type QueryExecutor struct {
+	Reconciler     *QueryReconciler
+	Namespace      string
+	ServiceAccount string
+	// Port to serve on, executor.DefaultPort when zero
+	Port int
+	// WorkloadIdentity is the directory of the workload certificate the executor pod serves with
+	WorkloadIdentity string
+	// ControllerID is the SPIFFE ID of the controller, the only caller whose calls are executed
+	ControllerID string
+	// ctx lives as long as the executor pod, so the queries outlive the call that dispatched them
+	ctx context.Context
+}
+
+// Start serves the dispatched queries until ctx is done
+func (e *QueryExecutor) Start(ctx context.Context) error {
+	e.ctx = ctx
+	port := e.Port
+	if port == 0 {
+		port = executor.DefaultPort
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+
+	server := executor.NewServer(e, common.NewWorkloadServerTLSConfig(e.WorkloadIdentity))
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+	logf.FromContext(ctx).Info("serving dispatched queries", "namespace", e.Namespace, "serviceAccount", e.ServiceAccount, "port", port)
+	return server.Serve(listener)
+}
+
+// NeedLeaderElection is false, as the executor pod does not elect a leader
+func (e *QueryExecutor) NeedLeaderElection() bool {
+	return false
+}
+
+// ExecuteQuery starts executing a running query of the namespace and service account of the executor pod
+func (e *QueryExecutor) ExecuteQuery(ctx context.Context, key types.NamespacedName) error {
+	if e.ctx == nil {
+		return status.Error(codes.Unavailable, "executor is not started")
+	}
+	if err := e.authorize(ctx, key); err != nil {
+		return err
+	}
+
+	r := e.Reconciler
+	query, err := r.fetchQuery(ctx, key)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if serviceAccount := executorServiceAccountFor(&query); serviceAccount != e.ServiceAccount {
+		return status.Errorf(codes.PermissionDenied, "executor of service account %s does not execute queries of service account %s", e.ServiceAccount, serviceAccount)
+	}
+	if query.Status.Phase != statusRunning || r.getOperations().exists(key) {
+		return nil
+	}
+
+	// A query dispatched for the first time is claimed by this executor pod; one claimed by another executor pod
+	// was interrupted by its restart and is recovered by handleRunningPhase
+	if query.Status.Executor == "" {
+		query.Status.Executor = r.getExecutor()
+		if err := r.Status().Update(ctx, &query); err != nil {
+			return err
+		}
+	}
+	_, err = r.handleRunningPhase(e.ctx, ctrl.Request{NamespacedName: key}, query)
+	return err
+}
+
+// CancelQuery stops executing a query
+func (e *QueryExecutor) CancelQuery(ctx context.Context, key types.NamespacedName) error {
+	if err := e.authorize(ctx, key); err != nil {
+		return err
+	}
+	e.Reconciler.getOperations().cancel(key)
+	return nil
+}
+
+// authorize accepts the calls of the controller for the queries of the namespace of the executor pod. Any pod of
+// the cluster network can reach the executor pod, so the caller is identified by its client certificate.
+func (e *QueryExecutor) authorize(ctx context.Context, key types.NamespacedName) error {
+	caller := executor.CallerCertificate(ctx)
+	if caller == nil {
+		return status.Error(codes.Unauthenticated, "caller presented no client certificate")
+	}
+	if !common.HasSPIFFEID(caller, e.ControllerID) {
+		return status.Errorf(codes.PermissionDenied, "caller is not the controller %s", e.ControllerID)
+	}
+	if key.Namespace != e.Namespace {
+		return status.Errorf(codes.PermissionDenied, "executor of namespace %s does not execute queries of namespace %s", e.Namespace, key.Namespace)
+	}
+	return nil
+}
+
+// ExecutorNamespaceReconciler removes the executor pods of namespaces whose queries no longer run in them, when
+// the namespace loses its labels.QueryExecutorLabel, and closes the connections of the controller to them
+type ExecutorNamespaceReconciler struct {
+	client.Client
+	Executors *QueryExecutors
+}
+
+// executorLabelChanged passes the namespaces whose executor label changed, and every namespace on start, so that the
+// executor pods of namespaces unlabelled while the controller was down are removed as well
+var executorLabelChanged = predicate.Funcs{
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetLabels()[labels.QueryExecutorLabel] != e.ObjectNew.GetLabels()[labels.QueryExecutorLabel]
+	},
+}
+
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;delete
+
+func (r *ExecutorNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var namespace corev1.Namespace
+	if err := r.Get(ctx, req.NamespacedName, &namespace); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+		r.Executors.evict(req.Name)
+		return ctrl.Result{}, nil
+	}
+	if namespace.Labels[labels.QueryExecutorLabel] == QueryExecutorEnabled && namespace.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	r.Executors.evict(req.Name)
+
+	// The Services of executor pods are owned by their Deployments, so they are garbage collected with them
+	var deployments appsv1.DeploymentList
+	if err := r.List(ctx, &deployments, client.InNamespace(req.Name), client.MatchingLabels(executorLabels)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list executor deployments of namespace %s: %w", req.Name, err)
+	}
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if err := r.Delete(ctx, deployment, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete executor deployment %s: %w", deployment.Name, err)
+		}
+		log.Info("removed executor pod of namespace no longer executing queries", "namespace", req.Name, "executor", deployment.Name)
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ExecutorNamespaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Namespace{}, builder.WithPredicates(executorLabelChanged)).
+		Named("executor-namespace").
+		Complete(r)
+}
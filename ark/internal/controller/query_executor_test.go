@@ -0,0 +1,244 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/executor"
+	"mckinsey.com/ark/internal/labels"
+)
+
+type fakeExecutor struct {
+	mu       sync.Mutex
+	address  string
+	executed []types.NamespacedName
+	canceled []types.NamespacedName
+	closed   bool
+}
+
+func (f *fakeExecutor) ExecuteQuery(_ context.Context, query types.NamespacedName) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.executed = append(f.executed, query)
+	return nil
+}
+
+func (f *fakeExecutor) CancelQuery(_ context.Context, query types.NamespacedName) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.canceled = append(f.canceled, query)
+	return nil
+}
+
+func (f *fakeExecutor) Close() error {
+	f.closed = true
+	return nil
+}
+
+// callerContext returns a context of a call whose caller presented a client certificate with the SPIFFE ID
+func callerContext(ctx context.Context, spiffeID string) context.Context {
+	id, _ := url.Parse(spiffeID)
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{{URIs: []*url.URL{id}}}}
+	return peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+var _ = Describe("Query executor pods", func() {
+	ctx := context.Background()
+	controllerID, _ := url.Parse("spiffe://cluster.local/ns/ark-system/sa/ark-controller")
+
+	createNamespace := func(name string, enabled bool) {
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if enabled {
+			namespace.Labels = map[string]string{labels.QueryExecutorLabel: QueryExecutorEnabled}
+		}
+		Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, namespace)
+	}
+
+	createRunningQuery := func(namespace, serviceAccount string) *arkv1alpha1.Query {
+		query := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: namespace},
+			Spec: arkv1alpha1.QuerySpec{
+				Targets:        []arkv1alpha1.QueryTarget{{Type: "agent", Name: "weather-agent"}},
+				ServiceAccount: serviceAccount,
+			},
+		}
+		Expect(k8sClient.Create(ctx, query)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, query)
+		query.Status.Phase = statusRunning
+		Expect(k8sClient.Status().Update(ctx, query)).To(Succeed())
+		return query
+	}
+
+	It("should dispatch the queries of labelled namespaces to the executor pod of their service account", func() {
+		createNamespace("executor-enabled", true)
+		query := createRunningQuery("executor-enabled", "weather-sa")
+
+		runner := &fakeExecutor{}
+		r := &QueryReconciler{Client: k8sClient, Executors: &QueryExecutors{
+			Image:     "ark-controller:test",
+			SPIFFEID:  controllerID,
+			CSIDriver: "spiffe.csi.cert-manager.io",
+			newRunner: func(address string) (executor.Runner, error) {
+				runner.address = address
+				return runner, nil
+			},
+		}}
+		req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(query)}
+
+		result, err := r.handleRunningPhase(ctx, req, *query)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(executorPollInterval), "the query waits for the executor pod")
+		Expect(runner.executed).To(BeEmpty())
+
+		deployment := &appsv1.Deployment{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "ark-executor-weather-sa", Namespace: "executor-enabled"}, deployment)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, deployment)
+		Expect(deployment.Spec.Template.Spec.ServiceAccountName).To(Equal("weather-sa"))
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("ark-controller:test"))
+		Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElements("--query-executor",
+			"--workload-identity-dir=/var/run/ark/workload-identity", "--query-executor-controller-id="+controllerID.String()))
+		Expect(deployment.Spec.Template.Spec.Volumes[0].CSI.Driver).To(Equal("spiffe.csi.cert-manager.io"))
+		Expect(r.Executors.executorSPIFFEID("executor-enabled", "weather-sa")).To(Equal("spiffe://cluster.local/ns/executor-enabled/sa/weather-sa"))
+
+		service := &corev1.Service{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "ark-executor-weather-sa", Namespace: "executor-enabled"}, service)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, service)
+		Expect(metav1.IsControlledBy(service, deployment)).To(BeTrue())
+
+		deployment.Status.Replicas = 1
+		deployment.Status.AvailableReplicas = 1
+		Expect(k8sClient.Status().Update(ctx, deployment)).To(Succeed())
+
+		result, err = r.handleRunningPhase(ctx, req, *query)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(runner.address).To(Equal("ark-executor-weather-sa.executor-enabled.svc:9090"))
+		Expect(runner.executed).To(Equal([]types.NamespacedName{req.NamespacedName}))
+		Expect(r.getOperations().exists(req.NamespacedName)).To(BeFalse(), "the controller does not execute the query")
+
+		Expect(r.finalize(ctx, query)).To(Succeed())
+		Expect(runner.canceled).To(Equal([]types.NamespacedName{req.NamespacedName}))
+	})
+
+	It("should dispatch the queries of labelled namespaces only when executor pods are configured", func() {
+		createNamespace("executor-claimed", true)
+		query := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: "executor-claimed"}}
+		r := &QueryReconciler{Client: k8sClient, Executors: &QueryExecutors{}}
+
+		dispatched, err := r.dispatchesQuery(ctx, query)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dispatched).To(BeTrue())
+
+		r.Executors = nil
+		dispatched, err = r.dispatchesQuery(ctx, query)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dispatched).To(BeFalse(), "dispatch is disabled without an executor image")
+	})
+
+	It("should execute the queries of unlabelled namespaces in the controller", func() {
+		createNamespace("executor-disabled", false)
+		r := &QueryReconciler{Client: k8sClient, Executors: &QueryExecutors{}}
+		dispatched, err := r.dispatchesQuery(ctx, &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: "executor-disabled"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dispatched).To(BeFalse())
+	})
+
+	It("should refuse queries of other namespaces and service accounts in the executor pod", func() {
+		createNamespace("executor-pod", true)
+		query := createRunningQuery("executor-pod", "other-sa")
+
+		e := &QueryExecutor{
+			Reconciler:     &QueryReconciler{Client: k8sClient, Recorder: record.NewFakeRecorder(10), RunAsServiceAccount: true},
+			Namespace:      "executor-pod",
+			ServiceAccount: "weather-sa",
+			ControllerID:   controllerID.String(),
+			ctx:            ctx,
+		}
+		callCtx := callerContext(ctx, controllerID.String())
+
+		err := e.ExecuteQuery(callCtx, types.NamespacedName{Namespace: "default", Name: "weather"})
+		Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+
+		err = e.ExecuteQuery(callCtx, client.ObjectKeyFromObject(query))
+		Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		Expect(e.Reconciler.getOperations().exists(client.ObjectKeyFromObject(query))).To(BeFalse())
+	})
+
+	It("should only accept the calls of the controller in the executor pod", func() {
+		key := types.NamespacedName{Namespace: "executor-pod", Name: "weather"}
+		r := &QueryReconciler{Client: k8sClient}
+		e := &QueryExecutor{Reconciler: r, Namespace: "executor-pod", ServiceAccount: "weather-sa", ControllerID: controllerID.String(), ctx: ctx}
+
+		canceled := false
+		r.getOperations().store(key, func() { canceled = true })
+		DeferCleanup(r.getOperations().cancel, key)
+
+		err := e.CancelQuery(ctx, key)
+		Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+		err = e.CancelQuery(callerContext(ctx, "spiffe://cluster.local/ns/executor-pod/sa/weather-sa"), key)
+		Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		err = e.ExecuteQuery(callerContext(ctx, "spiffe://cluster.local/ns/executor-pod/sa/weather-sa"), key)
+		Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		Expect(canceled).To(BeFalse())
+
+		Expect(e.CancelQuery(callerContext(ctx, controllerID.String()), key)).To(Succeed())
+		Expect(canceled).To(BeTrue())
+	})
+
+	It("should remove the executor pods of a namespace that is no longer labelled", func() {
+		createNamespace("executor-unlabelled", true)
+		runner := &fakeExecutor{}
+		executors := &QueryExecutors{
+			Image:     "ark-controller:test",
+			SPIFFEID:  controllerID,
+			newRunner: func(string) (executor.Runner, error) { return runner, nil },
+		}
+		_, err := executors.ensureExecutor(ctx, k8sClient, "executor-unlabelled", "weather-sa")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = executors.runner("executor-unlabelled", "weather-sa")
+		Expect(err).NotTo(HaveOccurred())
+
+		r := &ExecutorNamespaceReconciler{Client: k8sClient, Executors: executors}
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "executor-unlabelled"}}
+		key := types.NamespacedName{Name: "ark-executor-weather-sa", Namespace: "executor-unlabelled"}
+
+		By("keeping the executor pods of a labelled namespace")
+		Expect(r.Reconcile(ctx, req)).To(Equal(ctrl.Result{}))
+		Expect(k8sClient.Get(ctx, key, &appsv1.Deployment{})).To(Succeed())
+		Expect(runner.closed).To(BeFalse())
+
+		By("removing them once the label is removed")
+		namespace := &corev1.Namespace{}
+		Expect(k8sClient.Get(ctx, req.NamespacedName, namespace)).To(Succeed())
+		delete(namespace.Labels, labels.QueryExecutorLabel)
+		Expect(k8sClient.Update(ctx, namespace)).To(Succeed())
+
+		Expect(r.Reconcile(ctx, req)).To(Equal(ctrl.Result{}))
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, key, &appsv1.Deployment{}))).To(BeTrue())
+		Expect(runner.closed).To(BeTrue())
+		Expect(executors.runners).To(BeEmpty())
+		DeferCleanup(k8sClient.Delete, ctx, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}})
+	})
+})
@@ -0,0 +1,110 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"sync"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+// targetStopper decides when a query no longer needs the targets still running or not yet started: after the
+// first success of a selector match that stops early, or once the failure policy can only fail the query
+type targetStopper struct {
+	query    arkv1alpha1.Query
+	total    int
+	mu       sync.Mutex
+	failures int
+	stopped  bool
+}
+
+func newTargetStopper(query arkv1alpha1.Query, total int) *targetStopper {
+	return &targetStopper{query: query, total: total}
+}
+
+// stoppable reports whether the target at index i of the resolved targets is stopped with the others
+func (s *targetStopper) stoppable(i int) bool {
+	if stopsAfterFirstSuccess(s.query) {
+		return isSelectorMatch(s.query, i)
+	}
+	policy := s.query.Spec.FailurePolicy
+	return policy != nil && policy.Mode != arkv1alpha1.FailurePolicyBestEffort
+}
+
+// record takes the result of the target at index i and reports whether the stoppable targets should stop
+func (s *targetStopper) record(i int, result targetResult) bool {
+	if !s.stoppable(i) {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case result.err == nil && result.messages != nil:
+		s.stopped = s.stopped || stopsAfterFirstSuccess(s.query)
+	case result.err != nil && !genai.IsCancellation(result.err):
+		s.failures++
+		if policy := s.query.Spec.FailurePolicy; policy != nil {
+			s.stopped = s.stopped || s.failures > s.total-requiredSuccesses(policy, s.total)
+		}
+	}
+	return s.stopped
+}
+
+// isStopped reports whether the stoppable targets should no longer start
+func (s *targetStopper) isStopped() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stopped
+}
+
+// requiredSuccesses is how many of the targets must succeed under the failure policy
+func requiredSuccesses(policy *arkv1alpha1.FailurePolicy, total int) int {
+	switch policy.Mode {
+	case arkv1alpha1.FailurePolicyBestEffort:
+		return 1
+	case arkv1alpha1.FailurePolicyThreshold:
+		if policy.MinSuccesses != nil {
+			return int(*policy.MinSuccesses)
+		}
+		return 1
+	}
+	return total
+}
+
+// determineQueryStatusWithPolicies determines the status of a query with its failure policy, or else with its
+// selector policy
+func (r *QueryReconciler) determineQueryStatusWithPolicies(query arkv1alpha1.Query, responses []arkv1alpha1.Response) string {
+	policy := query.Spec.FailurePolicy
+	if policy == nil {
+		return r.determineQueryStatusWithSelectorPolicy(query, responses)
+	}
+	if policy.Mode == arkv1alpha1.FailurePolicyAllOrNothing {
+		return r.determineQueryStatus(responses)
+	}
+	return r.quorumStatus(responses, requiredSuccesses(policy, len(responses)))
+}
+
+// countSucceededResponses counts the done responses
+func countSucceededResponses(responses []arkv1alpha1.Response) int {
+	succeeded := 0
+	for _, response := range responses {
+		if response.Phase == statusDone {
+			succeeded++
+		}
+	}
+	return succeeded
+}
+
+// quorumStatus is done when at least required of the responses succeeded. Otherwise the failures decide the
+// status, and if none failed there were too few targets to reach the quorum.
+func (r *QueryReconciler) quorumStatus(responses []arkv1alpha1.Response, required int) string {
+	if countSucceededResponses(responses) >= required {
+		return statusDone
+	}
+	if status := r.determineQueryStatus(responses); status != statusDone {
+		return status
+	}
+	return statusError
+}
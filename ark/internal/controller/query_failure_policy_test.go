@@ -0,0 +1,106 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+var _ = Describe("Query failure policy", func() {
+	reconciler := &QueryReconciler{}
+
+	response := func(name, phase string) arkv1alpha1.Response {
+		return arkv1alpha1.Response{Target: arkv1alpha1.QueryTarget{Type: "agent", Name: name}, Phase: phase}
+	}
+	policyQuery := func(mode string, minSuccesses *int32) arkv1alpha1.Query {
+		return arkv1alpha1.Query{Spec: arkv1alpha1.QuerySpec{
+			Targets: []arkv1alpha1.QueryTarget{
+				{Type: "agent", Name: "a"}, {Type: "agent", Name: "b"}, {Type: "agent", Name: "c"},
+			},
+			FailurePolicy: &arkv1alpha1.FailurePolicy{Mode: mode, MinSuccesses: minSuccesses},
+		}}
+	}
+	succeeded := targetResult{messages: []genai.Message{genai.NewAssistantMessage("done")}}
+	failed := targetResult{err: genai.NewError(genai.ReasonToolFailed, errors.New("tool failed"))}
+	canceled := targetResult{err: genai.NewError(genai.ReasonCanceled, context.Canceled)}
+
+	It("should complete a best effort query when any target succeeded", func() {
+		query := policyQuery(arkv1alpha1.FailurePolicyBestEffort, nil)
+		Expect(reconciler.determineQueryStatusWithPolicies(query, []arkv1alpha1.Response{
+			response("a", statusError), response("b", statusDone), response("c", statusError),
+		})).To(Equal(statusDone))
+		Expect(reconciler.determineQueryStatusWithPolicies(query, []arkv1alpha1.Response{
+			response("a", statusError), response("b", statusCanceled),
+		})).To(Equal(statusError))
+	})
+
+	It("should complete a threshold query once enough targets succeeded", func() {
+		query := policyQuery(arkv1alpha1.FailurePolicyThreshold, ptr.To(int32(2)))
+		Expect(reconciler.determineQueryStatusWithPolicies(query, []arkv1alpha1.Response{
+			response("a", statusDone), response("b", statusError), response("c", statusDone),
+		})).To(Equal(statusDone))
+		Expect(reconciler.determineQueryStatusWithPolicies(query, []arkv1alpha1.Response{
+			response("a", statusDone), response("b", statusError), response("c", statusCanceled),
+		})).To(Equal(statusError))
+	})
+
+	It("should fail an all or nothing query when any target failed", func() {
+		query := policyQuery(arkv1alpha1.FailurePolicyAllOrNothing, nil)
+		Expect(reconciler.determineQueryStatusWithPolicies(query, []arkv1alpha1.Response{
+			response("a", statusDone), response("b", statusError), response("c", statusCanceled),
+		})).To(Equal(statusError))
+	})
+
+	It("should stop an all or nothing query at the first failure", func() {
+		stopper := newTargetStopper(policyQuery(arkv1alpha1.FailurePolicyAllOrNothing, nil), 3)
+		Expect(stopper.stoppable(0)).To(BeTrue())
+		Expect(stopper.record(0, succeeded)).To(BeFalse())
+		Expect(stopper.record(1, canceled)).To(BeFalse(), "a cancellation is not a failure")
+		Expect(stopper.record(2, failed)).To(BeTrue())
+		Expect(stopper.isStopped()).To(BeTrue())
+	})
+
+	It("should stop a threshold query once the threshold cannot be reached", func() {
+		stopper := newTargetStopper(policyQuery(arkv1alpha1.FailurePolicyThreshold, ptr.To(int32(2))), 3)
+		Expect(stopper.record(0, failed)).To(BeFalse())
+		Expect(stopper.record(1, failed)).To(BeTrue())
+	})
+
+	It("should never stop a best effort query", func() {
+		stopper := newTargetStopper(policyQuery(arkv1alpha1.FailurePolicyBestEffort, nil), 3)
+		Expect(stopper.stoppable(0)).To(BeFalse())
+		Expect(stopper.record(0, failed)).To(BeFalse())
+	})
+
+	It("should stop the selector matches after the first success", func() {
+		query := arkv1alpha1.Query{Spec: arkv1alpha1.QuerySpec{
+			Targets:        []arkv1alpha1.QueryTarget{{Type: "agent", Name: "lead"}},
+			SelectorPolicy: &arkv1alpha1.SelectorPolicy{StopAfterFirstSuccess: true},
+		}}
+		stopper := newTargetStopper(query, 3)
+		Expect(stopper.stoppable(0)).To(BeFalse(), "listed targets always run")
+		Expect(stopper.record(0, succeeded)).To(BeFalse())
+		Expect(stopper.record(1, failed)).To(BeFalse())
+		Expect(stopper.record(2, succeeded)).To(BeTrue())
+	})
+
+	It("should report a partial success in the completed condition", func() {
+		reconciler := &QueryReconciler{Client: k8sClient}
+		query := &arkv1alpha1.Query{Status: arkv1alpha1.QueryStatus{Responses: []arkv1alpha1.Response{
+			response("a", statusDone), response("b", statusError),
+		}}}
+		_ = reconciler.updateStatus(context.Background(), query, statusDone)
+
+		Expect(query.Status.Conditions).To(HaveLen(1))
+		Expect(query.Status.Conditions[0].Reason).To(Equal("QueryPartiallySucceeded"))
+		Expect(query.Status.Conditions[0].Message).To(Equal("Query completed: 1 of 2 targets succeeded"))
+	})
+})
@@ -0,0 +1,118 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/labels"
+)
+
+var (
+	queryChildrenDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ark_query_children_deleted_total",
+		Help: "Number of resources created on behalf of a query that were deleted with it",
+	}, []string{"kind"})
+
+	queryOrphanedChildren = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ark_query_orphaned_children_total",
+		Help: "Number of resources found whose owning query no longer exists",
+	}, []string{"kind"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(queryChildrenDeleted, queryOrphanedChildren)
+}
+
+// queryChildKind describes a resource type that the controllers create on behalf of a query
+type queryChildKind struct {
+	kind     string
+	newList  func() client.ObjectList
+	selector func(queryName string) client.MatchingLabels
+}
+
+// queryChildKinds lists the resources removed when their query is deleted
+var queryChildKinds = []queryChildKind{
+	{
+		// Evaluations created automatically by evaluators for matching queries
+		kind:    "Evaluation",
+		newList: func() client.ObjectList { return &arkv1alpha1.EvaluationList{} },
+		selector: func(queryName string) client.MatchingLabels {
+			return client.MatchingLabels{annotations.Query: queryName, annotations.Auto: "true"}
+		},
+	},
+	{
+		// ConfigMaps holding the artifacts published by the query
+		kind:    "ConfigMap",
+		newList: func() client.ObjectList { return &corev1.ConfigMapList{} },
+		selector: func(queryName string) client.MatchingLabels {
+			return client.MatchingLabels{labels.QueryArtifactsLabel: queryName}
+		},
+	},
+}
+
+// deleteQueryChildren deletes the resources created on behalf of a query. It returns the number of
+// resources deleted per kind and stops at the first error so the caller can retry.
+func (r *QueryReconciler) deleteQueryChildren(ctx context.Context, query types.NamespacedName) (map[string]int, error) {
+	deleted := make(map[string]int)
+
+	for _, child := range queryChildKinds {
+		list := child.newList()
+		if err := r.List(ctx, list, client.InNamespace(query.Namespace), child.selector(query.Name)); err != nil {
+			return deleted, fmt.Errorf("failed to list %s children of query %s: %w", child.kind, query, err)
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to extract %s children of query %s: %w", child.kind, query, err)
+		}
+
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			if err := r.Delete(ctx, obj, client.PropagationPolicy("Background")); client.IgnoreNotFound(err) != nil {
+				return deleted, fmt.Errorf("failed to delete %s %s: %w", child.kind, obj.GetName(), err)
+			}
+			deleted[child.kind]++
+		}
+	}
+
+	return deleted, nil
+}
+
+// garbageCollectQueryChildren removes the children of a query being finalized
+func (r *QueryReconciler) garbageCollectQueryChildren(ctx context.Context, query *arkv1alpha1.Query) error {
+	deleted, err := r.deleteQueryChildren(ctx, types.NamespacedName{Name: query.Name, Namespace: query.Namespace})
+	for kind, count := range deleted {
+		queryChildrenDeleted.WithLabelValues(kind).Add(float64(count))
+		logf.FromContext(ctx).Info("deleted query children", "query", query.Name, "kind", kind, "count", count)
+	}
+	return err
+}
+
+// garbageCollectOrphans removes children left behind by a query that no longer exists, for example
+// because it was deleted while the controller was not running or its finalizer was removed manually
+func (r *QueryReconciler) garbageCollectOrphans(ctx context.Context, query types.NamespacedName) {
+	deleted, err := r.deleteQueryChildren(ctx, query)
+	for kind, count := range deleted {
+		queryOrphanedChildren.WithLabelValues(kind).Add(float64(count))
+		queryChildrenDeleted.WithLabelValues(kind).Add(float64(count))
+		logf.FromContext(ctx).Info("deleted orphaned query children", "query", query.Name, "kind", kind, "count", count)
+	}
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to delete orphaned query children", "query", query.Name)
+	}
+}
@@ -0,0 +1,83 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/labels"
+)
+
+var _ = Describe("Query garbage collection", func() {
+	ctx := context.Background()
+
+	newEvaluation := func(name string, labels map[string]string) *arkv1alpha1.Evaluation {
+		return &arkv1alpha1.Evaluation{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+			Spec: arkv1alpha1.EvaluationSpec{
+				Type: "direct",
+				Config: arkv1alpha1.EvaluationConfig{
+					DirectEvaluationConfig: &arkv1alpha1.DirectEvaluationConfig{Input: "in", Output: "out"},
+				},
+				Evaluator: arkv1alpha1.EvaluationEvaluatorRef{Name: "gc-evaluator"},
+			},
+		}
+	}
+
+	It("should delete automatic evaluations of a query and keep manual ones", func() {
+		auto := newEvaluation("gc-auto-evaluation", map[string]string{annotations.Query: "gc-query", annotations.Auto: "true"})
+		manual := newEvaluation("gc-manual-evaluation", map[string]string{annotations.Query: "gc-query"})
+		other := newEvaluation("gc-other-evaluation", map[string]string{annotations.Query: "other-query", annotations.Auto: "true"})
+		for _, evaluation := range []*arkv1alpha1.Evaluation{auto, manual, other} {
+			Expect(k8sClient.Create(ctx, evaluation)).To(Succeed())
+		}
+		DeferCleanup(func() {
+			for _, evaluation := range []*arkv1alpha1.Evaluation{manual, other} {
+				_ = k8sClient.Delete(ctx, evaluation)
+			}
+		})
+
+		reconciler := &QueryReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		deleted, err := reconciler.deleteQueryChildren(ctx, types.NamespacedName{Name: "gc-query", Namespace: "default"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(HaveKeyWithValue("Evaluation", 1))
+
+		err = k8sClient.Get(ctx, types.NamespacedName{Name: auto.Name, Namespace: "default"}, &arkv1alpha1.Evaluation{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: manual.Name, Namespace: "default"}, &arkv1alpha1.Evaluation{})).To(Succeed())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: other.Name, Namespace: "default"}, &arkv1alpha1.Evaluation{})).To(Succeed())
+	})
+
+	It("should delete the artifacts ConfigMap of a query and keep other ConfigMaps", func() {
+		artifacts := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: "gc-query-artifacts", Namespace: "default", Labels: map[string]string{labels.QueryArtifactsLabel: "gc-query"},
+		}}
+		unrelated := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: "gc-unrelated", Namespace: "default", Labels: map[string]string{labels.QueryArtifactsLabel: "other-query"},
+		}}
+		for _, cm := range []*corev1.ConfigMap{artifacts, unrelated} {
+			Expect(k8sClient.Create(ctx, cm)).To(Succeed())
+		}
+		DeferCleanup(func() {
+			_ = k8sClient.Delete(ctx, unrelated)
+		})
+
+		reconciler := &QueryReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		deleted, err := reconciler.deleteQueryChildren(ctx, types.NamespacedName{Name: "gc-query", Namespace: "default"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(HaveKeyWithValue("ConfigMap", 1))
+
+		err = k8sClient.Get(ctx, types.NamespacedName{Name: artifacts.Name, Namespace: "default"}, &corev1.ConfigMap{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: unrelated.Name, Namespace: "default"}, &corev1.ConfigMap{})).To(Succeed())
+	})
+})
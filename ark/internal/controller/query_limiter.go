@@ -0,0 +1,152 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+// queryLimiter bounds the number of queries executing concurrently in each namespace. Queries that
+// exceed the limit wait for a slot; waiting queries with a higher priority are admitted first and
+// queries with the same priority are admitted in arrival order.
+type queryLimiter struct {
+	maxConcurrent int
+	mu            sync.Mutex
+	seq           uint64
+	namespaces    map[string]*namespaceSlots
+}
+
+type namespaceSlots struct {
+	running int
+	waiting queryWaiters
+}
+
+type queryWaiter struct {
+	priority int32
+	seq      uint64
+	ready    chan struct{}
+	// index is the position in the heap, or -1 once the waiter has been admitted
+	index int
+}
+
+func newQueryLimiter(maxConcurrent int) *queryLimiter {
+	return &queryLimiter{maxConcurrent: maxConcurrent, namespaces: map[string]*namespaceSlots{}}
+}
+
+// acquire blocks until the query may execute in the namespace or ctx is done. onQueued is called when
+// the query has to wait. The returned release function frees the slot and must be called exactly once.
+func (l *queryLimiter) acquire(ctx context.Context, namespace string, priority int32, onQueued func()) (func(), error) {
+	if l == nil || l.maxConcurrent <= 0 {
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	slots, ok := l.namespaces[namespace]
+	if !ok {
+		slots = &namespaceSlots{}
+		l.namespaces[namespace] = slots
+	}
+	if slots.running < l.maxConcurrent && slots.waiting.Len() == 0 {
+		slots.running++
+		l.mu.Unlock()
+		return l.releaseFunc(namespace), nil
+	}
+
+	l.seq++
+	waiter := &queryWaiter{priority: priority, seq: l.seq, ready: make(chan struct{})}
+	heap.Push(&slots.waiting, waiter)
+	l.mu.Unlock()
+
+	if onQueued != nil {
+		onQueued()
+	}
+
+	select {
+	case <-waiter.ready:
+		return l.releaseFunc(namespace), nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		admitted := waiter.index < 0
+		if !admitted {
+			heap.Remove(&slots.waiting, waiter.index)
+		}
+		l.mu.Unlock()
+		if admitted {
+			// The slot was granted while giving up, so hand it to the next waiter
+			l.release(namespace)
+		}
+		return nil, ctx.Err()
+	}
+}
+
+func (l *queryLimiter) releaseFunc(namespace string) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() { l.release(namespace) })
+	}
+}
+
+func (l *queryLimiter) release(namespace string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	slots, ok := l.namespaces[namespace]
+	if !ok {
+		return
+	}
+	slots.running--
+	for slots.running < l.maxConcurrent && slots.waiting.Len() > 0 {
+		waiter := heap.Pop(&slots.waiting).(*queryWaiter)
+		slots.running++
+		close(waiter.ready)
+	}
+	if slots.running == 0 && slots.waiting.Len() == 0 {
+		delete(l.namespaces, namespace)
+	}
+}
+
+// queued returns the number of queries waiting for a slot in the namespace
+func (l *queryLimiter) queued(namespace string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if slots, ok := l.namespaces[namespace]; ok {
+		return slots.waiting.Len()
+	}
+	return 0
+}
+
+// queryWaiters is a heap ordered by descending priority, then by arrival
+type queryWaiters []*queryWaiter
+
+func (w queryWaiters) Len() int { return len(w) }
+
+func (w queryWaiters) Less(i, j int) bool {
+	if w[i].priority != w[j].priority {
+		return w[i].priority > w[j].priority
+	}
+	return w[i].seq < w[j].seq
+}
+
+func (w queryWaiters) Swap(i, j int) {
+	w[i], w[j] = w[j], w[i]
+	w[i].index = i
+	w[j].index = j
+}
+
+func (w *queryWaiters) Push(x any) {
+	waiter := x.(*queryWaiter)
+	waiter.index = len(*w)
+	*w = append(*w, waiter)
+}
+
+func (w *queryWaiters) Pop() any {
+	old := *w
+	n := len(old)
+	waiter := old[n-1]
+	old[n-1] = nil
+	waiter.index = -1
+	*w = old[:n-1]
+	return waiter
+}
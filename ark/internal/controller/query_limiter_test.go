@@ -0,0 +1,113 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+var _ = Describe("Query limiter", func() {
+	ctx := context.Background()
+
+	// acquireAsync queues a query and reports its name once it is admitted
+	acquireAsync := func(limiter *queryLimiter, name string, priority int32, admitted chan<- string) {
+		go func() {
+			defer GinkgoRecover()
+			release, err := limiter.acquire(ctx, "default", priority, nil)
+			Expect(err).NotTo(HaveOccurred())
+			admitted <- name
+			release()
+		}()
+	}
+
+	It("should not limit queries without a maximum", func() {
+		limiter := newQueryLimiter(0)
+		for i := 0; i < 10; i++ {
+			_, err := limiter.acquire(ctx, "default", 0, nil)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	It("should admit waiting queries by priority, then in arrival order", func() {
+		limiter := newQueryLimiter(1)
+		release, err := limiter.acquire(ctx, "default", 0, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		// Other namespaces are not affected by the limit
+		otherRelease, err := limiter.acquire(ctx, "other", 0, nil)
+		Expect(err).NotTo(HaveOccurred())
+		otherRelease()
+
+		admitted := make(chan string, 3)
+		for i, waiter := range []struct {
+			name     string
+			priority int32
+		}{{"low", 0}, {"high", 10}, {"low-later", 0}} {
+			acquireAsync(limiter, waiter.name, waiter.priority, admitted)
+			Eventually(func() int { return limiter.queued("default") }).Should(Equal(i + 1))
+		}
+
+		release()
+		var order []string
+		for range 3 {
+			var name string
+			Eventually(admitted).Should(Receive(&name))
+			order = append(order, name)
+		}
+		Expect(order).To(Equal([]string{"high", "low", "low-later"}))
+	})
+
+	It("should drop a query that is canceled while queued", func() {
+		limiter := newQueryLimiter(1)
+		release, err := limiter.acquire(ctx, "default", 0, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		queued := false
+		cancelCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err = limiter.acquire(cancelCtx, "default", 0, func() { queued = true })
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(queued).To(BeTrue())
+		Expect(limiter.queued("default")).To(BeZero())
+
+		release()
+		next, err := limiter.acquire(ctx, "default", 0, nil)
+		Expect(err).NotTo(HaveOccurred())
+		next()
+	})
+
+	It("should record a query canceled while queued as canceled", func() {
+		query := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "queued-query", Namespace: "default"},
+			Spec: arkv1alpha1.QuerySpec{
+				Input:   runtime.RawExtension{Raw: []byte(`"Hello"`)},
+				Targets: []arkv1alpha1.QueryTarget{{Type: "agent", Name: "assistant"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, query)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, query)
+
+		reconciler := &QueryReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), MaxConcurrentQueriesPerNamespace: 1}
+		release, err := reconciler.getLimiter().acquire(ctx, "default", 0, nil)
+		Expect(err).NotTo(HaveOccurred())
+		defer release()
+
+		opCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		key := client.ObjectKeyFromObject(query)
+		reconciler.executeQueryAsync(opCtx, *query, key, nil, genai.NewTokenUsageCollector(discardEventEmitter{}))
+
+		Expect(k8sClient.Get(ctx, key, query)).To(Succeed())
+		Expect(query.Status.Phase).To(Equal(statusCanceled))
+	})
+})
@@ -0,0 +1,101 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/openai/openai-go"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"mckinsey.com/ark/internal/genai"
+)
+
+// messageToText extracts the text content of a message in OpenAI's ChatCompletionMessageParamUnion format.
+// Content split into parts has its text parts joined. A message of no known role is logged and has no text.
+func messageToText(message genai.Message) string {
+	switch {
+	case message.OfAssistant != nil:
+		content := message.OfAssistant.Content
+		if content.OfString.Value != "" {
+			return content.OfString.Value
+		}
+		var texts []string
+		for _, part := range content.OfArrayOfContentParts {
+			if part.OfText != nil {
+				texts = append(texts, part.OfText.Text)
+			}
+		}
+		return strings.Join(texts, "\n")
+	case message.OfTool != nil:
+		return textOf(message.OfTool.Content.OfString.Value, message.OfTool.Content.OfArrayOfContentParts)
+	case message.OfUser != nil:
+		content := message.OfUser.Content
+		if content.OfString.Value != "" {
+			return content.OfString.Value
+		}
+		var texts []string
+		for _, part := range content.OfArrayOfContentParts {
+			if part.OfText != nil {
+				texts = append(texts, part.OfText.Text)
+			}
+		}
+		return strings.Join(texts, "\n")
+	case message.OfSystem != nil:
+		return textOf(message.OfSystem.Content.OfString.Value, message.OfSystem.Content.OfArrayOfContentParts)
+	case message.OfDeveloper != nil:
+		return textOf(message.OfDeveloper.Content.OfString.Value, message.OfDeveloper.Content.OfArrayOfContentParts)
+	case message.OfFunction != nil:
+		return message.OfFunction.Content.Value
+	default:
+		logf.Log.Error(genai.Errorf(genai.ReasonMalformedResponse, "message has no known role"),
+			"Unable to parse message content to text")
+		return ""
+	}
+}
+
+// textOf returns the string content of a message, or else its text parts joined
+func textOf(content string, parts []openai.ChatCompletionContentPartTextParam) string {
+	if content != "" {
+		return content
+	}
+	texts := make([]string, 0, len(parts))
+	for _, part := range parts {
+		texts = append(texts, part.Text)
+	}
+	return strings.Join(texts, "\n")
+}
+
+// serializeMessages converts OpenAI union message types to their actual content for JSON serialization.
+// Messages that cannot be serialized, such as a message of no known role, fail with the MalformedResponse
+// reason.
+func serializeMessages(messages []genai.Message) (string, error) {
+	if len(messages) == 0 {
+		return "", genai.Errorf(genai.ReasonMalformedResponse, "no messages to serialize")
+	}
+	actualMessages := make([]any, 0, len(messages))
+	for _, msg := range messages {
+		switch {
+		case msg.OfAssistant != nil:
+			actualMessages = append(actualMessages, msg.OfAssistant)
+		case msg.OfUser != nil:
+			actualMessages = append(actualMessages, msg.OfUser)
+		case msg.OfSystem != nil:
+			actualMessages = append(actualMessages, msg.OfSystem)
+		case msg.OfDeveloper != nil:
+			actualMessages = append(actualMessages, msg.OfDeveloper)
+		case msg.OfTool != nil:
+			actualMessages = append(actualMessages, msg.OfTool)
+		case msg.OfFunction != nil:
+			actualMessages = append(actualMessages, msg.OfFunction)
+		default:
+			return "", genai.Errorf(genai.ReasonMalformedResponse, "unknown message type encountered during serialization")
+		}
+	}
+	rawBytes, err := json.Marshal(actualMessages)
+	if err != nil {
+		return "", genai.Errorf(genai.ReasonMalformedResponse, "failed to marshal messages: %w", err)
+	}
+	return string(rawBytes), nil
+}
@@ -0,0 +1,108 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/openai/openai-go"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+// FuzzSerializeMessages feeds provider output decoded into messages through the serialization of a response,
+// which must either fail with the MalformedResponse reason or produce valid JSON
+func FuzzSerializeMessages(f *testing.F) {
+	f.Add([]byte(`[{"role":"assistant","content":"It is sunny"}]`))
+	f.Add([]byte(`[{"role":"assistant","content":null,"tool_calls":[{"id":"call-1","type":"function","function":{"name":"weather","arguments":"{}"}}]}]`))
+	f.Add([]byte(`[{"role":"user","content":[{"type":"text","text":"Describe"},{"type":"image_url","image_url":{"url":"https://example.com/a.png"}}]}]`))
+	f.Add([]byte(`[{"role":"tool","tool_call_id":"call-1","content":[{"type":"text","text":"20C"}]}]`))
+	f.Add([]byte(`[{"role":"developer","content":"be brief"},{"role":"function","name":"f","content":null}]`))
+	f.Add([]byte(`[{}]`))
+	f.Add([]byte(`[]`))
+
+	reconciler := &QueryReconciler{}
+	target := arkv1alpha1.QueryTarget{Type: "agent", Name: "fuzz-agent"}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var messages []genai.Message
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return
+		}
+
+		raw, err := serializeMessages(messages)
+		if err != nil {
+			if reason := genai.ReasonFor(err); reason != genai.ReasonMalformedResponse {
+				t.Fatalf("serialization failed with reason %s: %v", reason, err)
+			}
+		} else if !json.Valid([]byte(raw)) {
+			t.Fatalf("serialized messages are not valid JSON: %s", raw)
+		}
+
+		for _, message := range messages {
+			_ = messageToText(message)
+		}
+		response := reconciler.createSuccessResponse(target, messages, genai.FinishCompleted)
+		if (err == nil) != (response.Phase == statusDone) {
+			t.Fatalf("response phase %s does not match the serialization error %v", response.Phase, err)
+		}
+	})
+}
+
+// FuzzMessageToText builds messages of every role with text content that is either a string or split into
+// parts, and checks that their text is extracted
+func FuzzMessageToText(f *testing.F) {
+	f.Add(uint8(0), "hello", false)
+	f.Add(uint8(1), "", true)
+	f.Add(uint8(6), "unknown role", false)
+
+	f.Fuzz(func(t *testing.T, role uint8, text string, parts bool) {
+		var message genai.Message
+		switch role % 7 {
+		case 0:
+			content := openai.ChatCompletionAssistantMessageParamContentUnion{OfString: openai.String(text)}
+			if parts {
+				content = openai.ChatCompletionAssistantMessageParamContentUnion{OfArrayOfContentParts: []openai.ChatCompletionAssistantMessageParamContentArrayOfContentPartUnion{
+					{OfText: &openai.ChatCompletionContentPartTextParam{Text: text}},
+				}}
+			}
+			message.OfAssistant = &openai.ChatCompletionAssistantMessageParam{Content: content}
+		case 1:
+			content := openai.ChatCompletionUserMessageParamContentUnion{OfString: openai.String(text)}
+			if parts {
+				content = openai.ChatCompletionUserMessageParamContentUnion{OfArrayOfContentParts: []openai.ChatCompletionContentPartUnionParam{openai.TextContentPart(text)}}
+			}
+			message.OfUser = &openai.ChatCompletionUserMessageParam{Content: content}
+		case 2:
+			content := openai.ChatCompletionToolMessageParamContentUnion{OfString: openai.String(text)}
+			if parts {
+				content = openai.ChatCompletionToolMessageParamContentUnion{OfArrayOfContentParts: []openai.ChatCompletionContentPartTextParam{{Text: text}}}
+			}
+			message.OfTool = &openai.ChatCompletionToolMessageParam{Content: content}
+		case 3:
+			content := openai.ChatCompletionSystemMessageParamContentUnion{OfString: openai.String(text)}
+			if parts {
+				content = openai.ChatCompletionSystemMessageParamContentUnion{OfArrayOfContentParts: []openai.ChatCompletionContentPartTextParam{{Text: text}}}
+			}
+			message.OfSystem = &openai.ChatCompletionSystemMessageParam{Content: content}
+		case 4:
+			content := openai.ChatCompletionDeveloperMessageParamContentUnion{OfString: openai.String(text)}
+			if parts {
+				content = openai.ChatCompletionDeveloperMessageParamContentUnion{OfArrayOfContentParts: []openai.ChatCompletionContentPartTextParam{{Text: text}}}
+			}
+			message.OfDeveloper = &openai.ChatCompletionDeveloperMessageParam{Content: content}
+		case 5:
+			message.OfFunction = &openai.ChatCompletionFunctionMessageParam{Content: openai.String(text), Name: "f"}
+		default:
+			if got := messageToText(message); got != "" {
+				t.Fatalf("message of no known role has text %q", got)
+			}
+			return
+		}
+
+		if got := messageToText(message); got != text {
+			t.Fatalf("message text is %q, expected %q", got, text)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	queryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ark_query_duration_seconds",
+		Help:    "Duration of query executions, by namespace and terminal phase (done, error or canceled)",
+		Buckets: []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600, 1800},
+	}, []string{"namespace", "phase"})
+
+	queriesRunning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ark_queries_running",
+		Help: "Number of queries being executed, excluding queries waiting for a concurrency slot",
+	}, []string{"namespace"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(queryDuration, queriesRunning)
+}
+
+// observeQueryDuration records the duration of a query that reached a terminal phase
+func observeQueryDuration(namespace, phase string, duration time.Duration) {
+	switch phase {
+	case statusDone, statusError, statusCanceled:
+		queryDuration.WithLabelValues(namespace, phase).Observe(duration.Seconds())
+	}
+}
@@ -0,0 +1,21 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("Query metrics", func() {
+	It("should record the duration of queries in a terminal phase only", func() {
+		before := testutil.CollectAndCount(queryDuration)
+		observeQueryDuration("query-metrics-test", statusDone, 2*time.Second)
+		observeQueryDuration("query-metrics-test", statusError, time.Second)
+		observeQueryDuration("query-metrics-test", statusRunning, time.Second)
+		Expect(testutil.CollectAndCount(queryDuration)).To(Equal(before+2), "running queries have no duration series")
+	})
+})
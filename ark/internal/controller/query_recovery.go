@@ -0,0 +1,37 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// Policies for the running queries a restart of the controller interrupted
+const (
+	OrphanedQueryFail   = "fail"
+	OrphanedQueryResume = "resume"
+)
+
+// recoverOrphanedQuery handles a query left running by another controller process, whose execution was lost when
+// the process stopped. The query fails with the ControllerRestarted reason or, when the policy resumes orphaned
+// queries, this process takes it over and runs it again from the start.
+func (r *QueryReconciler) recoverOrphanedQuery(ctx context.Context, query *arkv1alpha1.Query) error {
+	log := logf.FromContext(ctx)
+
+	if r.OrphanedQueryPolicy == OrphanedQueryResume {
+		log.Info("resuming query interrupted by a controller restart", "query", query.Name, "namespace", query.Namespace, "executor", query.Status.Executor)
+		r.Recorder.Event(query, corev1.EventTypeNormal, "QueryResumed", "Query interrupted by a controller restart is run again")
+		// The status update triggers the reconcile that runs the query
+		query.Status.Executor = r.getExecutor()
+		return r.Status().Update(ctx, query)
+	}
+
+	log.Info("failing query interrupted by a controller restart", "query", query.Name, "namespace", query.Namespace, "executor", query.Status.Executor)
+	r.Recorder.Event(query, corev1.EventTypeWarning, "QueryOrphaned", "Query was interrupted by a controller restart")
+	return r.failQuery(ctx, query, "ControllerRestarted", "Query was interrupted by a restart of the controller")
+}
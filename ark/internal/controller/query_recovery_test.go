@@ -0,0 +1,93 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+var _ = Describe("Query recovery", func() {
+	ctx := context.Background()
+
+	var recorder *record.FakeRecorder
+
+	newReconciler := func(policy string) *QueryReconciler {
+		recorder = record.NewFakeRecorder(10)
+		return &QueryReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), Recorder: recorder, OrphanedQueryPolicy: policy}
+	}
+
+	// createQuery creates a query in the given phase, recorded as run by the given executor
+	createQuery := func(name, phase, executor string) *arkv1alpha1.Query {
+		query := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Finalizers: []string{finalizer}, CreationTimestamp: metav1.Now()},
+			Spec:       arkv1alpha1.QuerySpec{Targets: []arkv1alpha1.QueryTarget{{Type: "agent", Name: "recovery-agent"}}},
+		}
+		Expect(query.Spec.SetInputString("test input question")).To(Succeed())
+		Expect(k8sClient.Create(ctx, query)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, query)
+
+		query.Status.Phase = phase
+		query.Status.Executor = executor
+		meta.SetStatusCondition(&query.Status.Conditions, metav1.Condition{
+			Type:   string(arkv1alpha1.QueryCompleted),
+			Status: metav1.ConditionFalse,
+			Reason: "QueryNotStarted",
+		})
+		Expect(k8sClient.Status().Update(ctx, query)).To(Succeed())
+		return query
+	}
+
+	reconcileQuery := func(r *QueryReconciler, query *arkv1alpha1.Query) *arkv1alpha1.Query {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(query)})
+		Expect(err).NotTo(HaveOccurred())
+		reconciled := &arkv1alpha1.Query{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(query), reconciled)).To(Succeed())
+		return reconciled
+	}
+
+	It("should record the controller process running a query", func() {
+		agent := &arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "recovery-agent", Namespace: "default"}}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, agent)
+
+		r := newReconciler("")
+		query := reconcileQuery(r, createQuery("recovery-started", statusPending, ""))
+		Expect(query.Status.Phase).To(Equal(statusRunning))
+		Expect(query.Status.Executor).To(Equal(r.getExecutor()))
+		Expect(newReconciler("").getExecutor()).NotTo(Equal(r.getExecutor()), "each controller process has its own identity")
+	})
+
+	It("should fail a query interrupted by a controller restart", func() {
+		query := reconcileQuery(newReconciler(OrphanedQueryFail), createQuery("recovery-failed", statusRunning, "previous-controller"))
+
+		Expect(query.Status.Phase).To(Equal(statusError))
+		condition := meta.FindStatusCondition(query.Status.Conditions, string(arkv1alpha1.QueryCompleted))
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal("ControllerRestarted"))
+		Expect(recorder.Events).To(Receive(ContainSubstring("QueryOrphaned")))
+	})
+
+	It("should fail running queries of controllers that did not record their executor", func() {
+		query := reconcileQuery(newReconciler(""), createQuery("recovery-legacy", statusRunning, ""))
+		Expect(query.Status.Phase).To(Equal(statusError))
+	})
+
+	It("should take over a query interrupted by a controller restart when resuming", func() {
+		r := newReconciler(OrphanedQueryResume)
+		query := reconcileQuery(r, createQuery("recovery-resumed", statusRunning, "previous-controller"))
+
+		Expect(query.Status.Phase).To(Equal(statusRunning))
+		Expect(query.Status.Executor).To(Equal(r.getExecutor()))
+		Expect(recorder.Events).To(Receive(ContainSubstring("QueryResumed")))
+	})
+})
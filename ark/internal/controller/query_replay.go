@@ -0,0 +1,127 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+// maxReplayDiffBytes bounds the diff of each response, so that long responses do not bloat the status
+const maxReplayDiffBytes = 4096
+
+// prepareReplay clones the replayed query into a query that replays one, once: the input, the parameters
+// resolved to their values, the overrides, and the targets when the query has none. It returns whether the
+// query is ready to run; otherwise the query was updated or failed and is reconciled again.
+func (r *QueryReconciler) prepareReplay(ctx context.Context, query *arkv1alpha1.Query) (bool, error) {
+	if len(query.Spec.Input.Raw) > 0 {
+		return true, nil
+	}
+
+	var replayed arkv1alpha1.Query
+	if err := r.Get(ctx, types.NamespacedName{Name: query.Spec.ReplayOf, Namespace: query.Namespace}, &replayed); err != nil {
+		if errors.IsNotFound(err) {
+			return false, r.failQuery(ctx, query, "ReplayFailed", fmt.Sprintf("replayed query %s not found", query.Spec.ReplayOf))
+		}
+		return false, err
+	}
+	if replayed.Status.Phase != statusDone && replayed.Status.Phase != statusError {
+		return false, r.failQuery(ctx, query, "ReplayFailed", fmt.Sprintf("replayed query %s has not completed", replayed.Name))
+	}
+
+	parameters, err := genai.ResolveParameterValues(ctx, r.Client, replayed.Namespace, replayed.Spec.Parameters)
+	if err != nil {
+		return false, r.failQuery(ctx, query, "ReplayFailed", fmt.Sprintf("failed to resolve the parameters of replayed query %s: %v", replayed.Name, err))
+	}
+
+	query.Spec.Type = replayed.Spec.Type
+	query.Spec.Input = *replayed.Spec.Input.DeepCopy()
+	query.Spec.Parameters = parameters
+	query.Spec.Overrides = replayed.Spec.Overrides
+	query.Spec.DependsOn = replayed.Spec.DependsOn
+	if len(query.Spec.Attachments) == 0 {
+		query.Spec.Attachments = replayed.Spec.Attachments
+	}
+	if len(query.Spec.Targets) == 0 && query.Spec.Selector == nil {
+		query.Spec.Targets = replayed.Spec.Targets
+		query.Spec.Selector = replayed.Spec.Selector
+		query.Spec.SelectorPolicy = replayed.Spec.SelectorPolicy
+	}
+
+	logf.FromContext(ctx).Info("query cloned from replayed query", "query", query.Name, "replayOf", replayed.Name)
+	return false, r.Update(ctx, query)
+}
+
+// compareWithReplayed compares the responses of a query with the responses of the query it replays. A
+// replayed query that no longer exists is reported in the logs and nothing is compared.
+func (r *QueryReconciler) compareWithReplayed(ctx context.Context, query *arkv1alpha1.Query) *arkv1alpha1.QueryReplay {
+	var replayed arkv1alpha1.Query
+	if err := r.Get(ctx, types.NamespacedName{Name: query.Spec.ReplayOf, Namespace: query.Namespace}, &replayed); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to get replayed query", "query", query.Name, "replayOf", query.Spec.ReplayOf)
+		return nil
+	}
+	return compareResponses(replayed, query.Status.Responses)
+}
+
+// compareResponses compares responses with the responses of the replayed query by target, in the order of
+// the responses followed by the targets only the replayed query responded for
+func compareResponses(replayed arkv1alpha1.Query, responses []arkv1alpha1.Response) *arkv1alpha1.QueryReplay {
+	originals := make(map[arkv1alpha1.QueryTarget]arkv1alpha1.Response, len(replayed.Status.Responses))
+	for _, response := range replayed.Status.Responses {
+		originals[response.Target] = response
+	}
+
+	replay := &arkv1alpha1.QueryReplay{Of: replayed.Name}
+	compare := func(target arkv1alpha1.QueryTarget, original, response arkv1alpha1.Response) {
+		compared := arkv1alpha1.ReplayedResponse{
+			Target:        target,
+			Phase:         response.Phase,
+			OriginalPhase: original.Phase,
+			Changed:       response.Phase != original.Phase || response.Content != original.Content,
+		}
+		if compared.Changed {
+			replay.Changed++
+			compared.Diff = diffContent(replayed.Name, original.Content, response.Content)
+		}
+		replay.Responses = append(replay.Responses, compared)
+	}
+
+	for _, response := range responses {
+		original := originals[response.Target]
+		delete(originals, response.Target)
+		compare(response.Target, original, response)
+	}
+	for _, original := range replayed.Status.Responses {
+		if _, ok := originals[original.Target]; ok {
+			compare(original.Target, original, arkv1alpha1.Response{})
+		}
+	}
+	return replay
+}
+
+// diffContent returns the unified diff of the original content to the content, truncated
+func diffContent(replayed, original, content string) string {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(original),
+		B:        difflib.SplitLines(content),
+		FromFile: replayed,
+		ToFile:   "replay",
+		Context:  2,
+	})
+	if err != nil {
+		return ""
+	}
+	if len(diff) > maxReplayDiffBytes {
+		diff = strings.ToValidUTF8(diff[:maxReplayDiffBytes], "") + "\n[diff truncated]"
+	}
+	return diff
+}
@@ -0,0 +1,163 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+var _ = Describe("Query replays", func() {
+	ctx := context.Background()
+
+	writer := arkv1alpha1.QueryTarget{Type: "agent", Name: "writer"}
+	reviewer := arkv1alpha1.QueryTarget{Type: "agent", Name: "reviewer"}
+
+	newReconciler := func() *QueryReconciler {
+		return &QueryReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+	}
+
+	createReplay := func(name, replayOf string) *arkv1alpha1.Query {
+		query := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       arkv1alpha1.QuerySpec{ReplayOf: replayOf},
+		}
+		Expect(k8sClient.Create(ctx, query)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, query)
+		return query
+	}
+
+	It("should clone the replayed query with its parameters resolved", func() {
+		cities := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "replay-cities", Namespace: "default"},
+			Data:       map[string]string{"city": "Paris"},
+		}
+		Expect(k8sClient.Create(ctx, cities)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, cities)
+
+		replayed := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "replayed-weather", Namespace: "default"},
+			Spec: arkv1alpha1.QuerySpec{
+				Targets: []arkv1alpha1.QueryTarget{writer},
+				Parameters: []arkv1alpha1.Parameter{{
+					Name: "city",
+					ValueFrom: &arkv1alpha1.ValueFromSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: cities.Name},
+						Key:                  "city",
+					}},
+				}},
+				Overrides: []arkv1alpha1.Override{{
+					ResourceType: "model",
+					Headers:      []arkv1alpha1.Header{{Name: "X-Trace", Value: arkv1alpha1.HeaderValue{Value: "replay"}}},
+				}},
+			},
+		}
+		Expect(replayed.Spec.SetInputString("What is the weather in {{.city}}?")).To(Succeed())
+		Expect(k8sClient.Create(ctx, replayed)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, replayed)
+		replayed.Status.Phase = statusDone
+		Expect(k8sClient.Status().Update(ctx, replayed)).To(Succeed())
+
+		query := createReplay("weather-replay", replayed.Name)
+		ready, err := newReconciler().prepareReplay(ctx, query)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ready).To(BeFalse(), "the cloned query is reconciled again")
+
+		cloned := &arkv1alpha1.Query{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(query), cloned)).To(Succeed())
+		input, err := cloned.Spec.GetInputString()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(input).To(Equal("What is the weather in {{.city}}?"))
+		Expect(cloned.Spec.Parameters).To(HaveLen(1))
+		Expect(cloned.Spec.Parameters[0].Value).To(Equal("Paris"))
+		Expect(cloned.Spec.Parameters[0].ValueFrom).To(BeNil())
+		Expect(cloned.Spec.Overrides).To(Equal(replayed.Spec.Overrides))
+		Expect(cloned.Spec.Targets).To(ConsistOf(writer))
+
+		ready, err = newReconciler().prepareReplay(ctx, cloned)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ready).To(BeTrue(), "a query is cloned only once")
+	})
+
+	It("should fail a replay of a query that has not completed", func() {
+		replayed := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "replayed-running", Namespace: "default"},
+			Spec:       arkv1alpha1.QuerySpec{Targets: []arkv1alpha1.QueryTarget{writer}},
+		}
+		Expect(replayed.Spec.SetInputString("test input question")).To(Succeed())
+		Expect(k8sClient.Create(ctx, replayed)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, replayed)
+
+		query := createReplay("running-replay", replayed.Name)
+		ready, err := newReconciler().prepareReplay(ctx, query)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ready).To(BeFalse())
+
+		updated := &arkv1alpha1.Query{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(query), updated)).To(Succeed())
+		Expect(updated.Status.Phase).To(Equal(statusError))
+		condition := meta.FindStatusCondition(updated.Status.Conditions, string(arkv1alpha1.QueryCompleted))
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal("ReplayFailed"))
+		Expect(condition.Message).To(ContainSubstring("has not completed"))
+	})
+
+	It("should compare the responses with the replayed query by target", func() {
+		replayed := arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "replayed-summary"},
+			Status: arkv1alpha1.QueryStatus{Responses: []arkv1alpha1.Response{
+				{Target: writer, Content: "Paris is sunny.\nHighs of 25C.", Phase: statusDone},
+				{Target: reviewer, Content: "Approved", Phase: statusDone},
+			}},
+		}
+		replay := compareResponses(replayed, []arkv1alpha1.Response{
+			{Target: writer, Content: "Paris is sunny.\nHighs of 27C.", Phase: statusDone},
+			{Target: reviewer, Content: "Approved", Phase: statusDone},
+		})
+
+		Expect(replay.Of).To(Equal("replayed-summary"))
+		Expect(replay.Changed).To(Equal(int32(1)))
+		Expect(replay.Responses).To(HaveLen(2))
+		Expect(replay.Responses[0].Changed).To(BeTrue())
+		Expect(replay.Responses[0].Diff).To(ContainSubstring("-Highs of 25C."))
+		Expect(replay.Responses[0].Diff).To(ContainSubstring("+Highs of 27C."))
+		Expect(replay.Responses[1].Changed).To(BeFalse())
+		Expect(replay.Responses[1].Diff).To(BeEmpty())
+	})
+
+	It("should report targets that only one of the queries responded for", func() {
+		replayed := arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "replayed-review"},
+			Status: arkv1alpha1.QueryStatus{Responses: []arkv1alpha1.Response{
+				{Target: reviewer, Content: "Approved", Phase: statusDone},
+			}},
+		}
+		replay := compareResponses(replayed, []arkv1alpha1.Response{
+			{Target: writer, Content: "rate limited", Phase: statusError},
+		})
+
+		Expect(replay.Changed).To(Equal(int32(2)))
+		Expect(replay.Responses).To(HaveLen(2))
+		Expect(replay.Responses[0].Target).To(Equal(writer))
+		Expect(replay.Responses[0].OriginalPhase).To(BeEmpty())
+		Expect(replay.Responses[0].Phase).To(Equal(statusError))
+		Expect(replay.Responses[1].Target).To(Equal(reviewer))
+		Expect(replay.Responses[1].Phase).To(BeEmpty())
+		Expect(replay.Responses[1].OriginalPhase).To(Equal(statusDone))
+	})
+
+	It("should truncate long diffs", func() {
+		diff := diffContent("replayed", strings.Repeat("old line\n", 1000), strings.Repeat("new line\n", 1000))
+		Expect(len(diff)).To(BeNumerically("<=", maxReplayDiffBytes+len("\n[diff truncated]")))
+		Expect(diff).To(HaveSuffix("[diff truncated]"))
+	})
+})
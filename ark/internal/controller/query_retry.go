@@ -0,0 +1,144 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+const (
+	defaultRetryInitialDelay = time.Second
+	defaultRetryMaxDelay     = 30 * time.Second
+)
+
+// defaultRetryReasons are the transient failures retried when a retry policy does not list any
+var defaultRetryReasons = []genai.ErrorReason{
+	genai.ReasonProviderRateLimited,
+	genai.ReasonProviderUnavailable,
+	genai.ReasonConnectionFailed,
+	genai.ReasonTimeout,
+	genai.ReasonToolTimeout,
+}
+
+// targetAttempts records how often a target was executed under a retry policy
+type targetAttempts struct {
+	count       int32
+	lastAttempt time.Time
+}
+
+// applyTo surfaces the attempts on a response. Nothing is recorded for queries without a retry policy.
+func (a targetAttempts) applyTo(response *arkv1alpha1.Response) {
+	if a.count == 0 {
+		return
+	}
+	response.Attempts = a.count
+	response.LastAttemptTime = &metav1.Time{Time: a.lastAttempt}
+}
+
+// executeWithRetry runs execute, retrying transient failures according to the policy until it succeeds,
+// the retries are exhausted or ctx is done. Without a policy execute runs once.
+func (r *QueryReconciler) executeWithRetry(ctx context.Context, policy *arkv1alpha1.RetryPolicy, target arkv1alpha1.QueryTarget, tokenCollector *genai.TokenUsageCollector, execute func(context.Context) error) targetAttempts {
+	var attempts targetAttempts
+	if policy == nil {
+		_ = execute(ctx)
+		return attempts
+	}
+
+	// Throttles are retried here rather than also by the model, which would multiply the attempts
+	if slices.Contains(retryReasons(policy), genai.ReasonProviderRateLimited) {
+		ctx = genai.WithoutThrottleRetry(ctx)
+	}
+
+	for {
+		attempts.count++
+		attempts.lastAttempt = time.Now()
+
+		err := execute(ctx)
+		if err == nil || ctx.Err() != nil || !shouldRetry(policy, attempts.count, err) {
+			return attempts
+		}
+
+		delay := retryDelay(policy, attempts.count, err)
+		logf.FromContext(ctx).Info("retrying target after transient failure",
+			"attempt", attempts.count, "maxRetries", policy.MaxRetries, "delay", delay.String(), "reason", genai.ReasonFor(err), "error", err.Error())
+		tokenCollector.EmitEvent(ctx, corev1.EventTypeWarning, "TargetRetry", genai.ExecutionEvent{
+			BaseEvent: genai.BaseEvent{Name: target.Name, Metadata: map[string]string{
+				"targetType": target.Type,
+				"targetName": target.Name,
+				"attempt":    fmt.Sprintf("%d", attempts.count),
+				"delay":      delay.String(),
+				"reason":     string(genai.ReasonFor(err)),
+			}},
+			Type: target.Type,
+		})
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempts
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry reports whether a target that failed with err after the given number of attempts is retried
+func shouldRetry(policy *arkv1alpha1.RetryPolicy, attempts int32, err error) bool {
+	if attempts > policy.MaxRetries || genai.IsCancellation(err) {
+		return false
+	}
+
+	retryOn := retryReasons(policy)
+	for _, reason := range genai.Reasons(err) {
+		if slices.Contains(retryOn, reason) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryReasons returns the error reasons retried under the policy
+func retryReasons(policy *arkv1alpha1.RetryPolicy) []genai.ErrorReason {
+	if len(policy.RetryOn) == 0 {
+		return defaultRetryReasons
+	}
+	retryOn := make([]genai.ErrorReason, len(policy.RetryOn))
+	for i, reason := range policy.RetryOn {
+		retryOn[i] = genai.ErrorReason(reason)
+	}
+	return retryOn
+}
+
+// retryDelay returns the delay before the retry that follows the given number of attempts. A provider
+// that throttled the call with a Retry-After hint is retried when it asked to be, instead of backing off.
+func retryDelay(policy *arkv1alpha1.RetryPolicy, attempts int32, err error) time.Duration {
+	if retryAfter, ok := genai.RetryAfter(err); ok {
+		return retryAfter
+	}
+
+	delay := defaultRetryInitialDelay
+	if policy.InitialDelay != nil {
+		delay = policy.InitialDelay.Duration
+	}
+	maxDelay := defaultRetryMaxDelay
+	if policy.MaxDelay != nil {
+		maxDelay = policy.MaxDelay.Duration
+	}
+
+	if policy.Backoff != arkv1alpha1.RetryBackoffFixed {
+		for i := int32(1); i < attempts && delay < maxDelay; i++ {
+			delay *= 2
+		}
+	}
+	return min(delay, maxDelay)
+}
@@ -0,0 +1,150 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openai/openai-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+type discardEventEmitter struct{}
+
+func (discardEventEmitter) EmitEvent(ctx context.Context, eventType, reason string, data genai.EventData) {
+}
+
+var _ = Describe("Query retry policy", func() {
+	reconciler := &QueryReconciler{}
+	target := arkv1alpha1.QueryTarget{Type: "model", Name: "retry-model"}
+	rateLimited := fmt.Errorf("chat completion failed: %w", &openai.Error{StatusCode: 429})
+
+	newPolicy := func(maxRetries int32) *arkv1alpha1.RetryPolicy {
+		return &arkv1alpha1.RetryPolicy{
+			MaxRetries:   maxRetries,
+			Backoff:      arkv1alpha1.RetryBackoffFixed,
+			InitialDelay: &metav1.Duration{Duration: time.Millisecond},
+		}
+	}
+
+	It("should retry transient failures until the target succeeds", func() {
+		calls := 0
+		attempts := reconciler.executeWithRetry(context.Background(), newPolicy(3), target, genai.NewTokenUsageCollector(discardEventEmitter{}), func(ctx context.Context) error {
+			calls++
+			if calls < 3 {
+				return rateLimited
+			}
+			return nil
+		})
+
+		Expect(calls).To(Equal(3))
+		Expect(attempts.count).To(Equal(int32(3)))
+		Expect(attempts.lastAttempt).NotTo(BeZero())
+	})
+
+	It("should stop after max retries", func() {
+		calls := 0
+		attempts := reconciler.executeWithRetry(context.Background(), newPolicy(2), target, genai.NewTokenUsageCollector(discardEventEmitter{}), func(ctx context.Context) error {
+			calls++
+			return rateLimited
+		})
+
+		Expect(calls).To(Equal(3))
+		Expect(attempts.count).To(Equal(int32(3)))
+	})
+
+	It("should execute once without a policy and record no attempts", func() {
+		calls := 0
+		attempts := reconciler.executeWithRetry(context.Background(), nil, target, genai.NewTokenUsageCollector(discardEventEmitter{}), func(ctx context.Context) error {
+			calls++
+			return rateLimited
+		})
+
+		Expect(calls).To(Equal(1))
+		response := arkv1alpha1.Response{}
+		attempts.applyTo(&response)
+		Expect(response.Attempts).To(BeZero())
+		Expect(response.LastAttemptTime).To(BeNil())
+	})
+
+	It("should only retry the configured error reasons", func() {
+		policy := newPolicy(3)
+		Expect(shouldRetry(policy, 1, rateLimited)).To(BeTrue())
+		Expect(shouldRetry(policy, 1, errors.New("boom"))).To(BeFalse())
+		Expect(shouldRetry(policy, 1, genai.NewResolutionError(fmt.Errorf("mcp: %w", syscall.ECONNREFUSED)))).To(BeTrue())
+		Expect(shouldRetry(policy, 1, genai.NewError(genai.ReasonCanceled, context.Canceled))).To(BeFalse())
+
+		policy.RetryOn = []string{string(genai.ReasonToolFailed)}
+		Expect(shouldRetry(policy, 1, rateLimited)).To(BeFalse())
+		Expect(shouldRetry(policy, 1, genai.NewError(genai.ReasonToolFailed, errors.New("boom")))).To(BeTrue())
+	})
+
+	It("should back off exponentially up to the max delay", func() {
+		policy := &arkv1alpha1.RetryPolicy{
+			Backoff:      arkv1alpha1.RetryBackoffExponential,
+			InitialDelay: &metav1.Duration{Duration: time.Second},
+			MaxDelay:     &metav1.Duration{Duration: 5 * time.Second},
+		}
+		Expect(retryDelay(policy, 1, rateLimited)).To(Equal(time.Second))
+		Expect(retryDelay(policy, 2, rateLimited)).To(Equal(2 * time.Second))
+		Expect(retryDelay(policy, 3, rateLimited)).To(Equal(4 * time.Second))
+		Expect(retryDelay(policy, 4, rateLimited)).To(Equal(5 * time.Second))
+
+		policy.Backoff = arkv1alpha1.RetryBackoffFixed
+		Expect(retryDelay(policy, 4, rateLimited)).To(Equal(time.Second))
+	})
+
+	It("should wait for the delay requested by a throttling provider", func() {
+		policy := &arkv1alpha1.RetryPolicy{InitialDelay: &metav1.Duration{Duration: time.Second}}
+		throttled := fmt.Errorf("chat completion failed: %w", &openai.Error{
+			StatusCode: 429,
+			Response:   &http.Response{StatusCode: 429, Header: http.Header{"Retry-After": []string{"7"}}},
+		})
+		Expect(retryDelay(policy, 1, throttled)).To(Equal(7 * time.Second))
+	})
+
+	It("should not let the model retry throttles that the policy retries", func() {
+		provider := &throttlingProvider{}
+		model := &genai.Model{Model: "throttled", Provider: provider, ModelRecorder: noop.NewModelRecorder()}
+
+		reconciler.executeWithRetry(context.Background(), newPolicy(2), target, genai.NewTokenUsageCollector(discardEventEmitter{}), func(ctx context.Context) error {
+			_, err := model.ChatCompletion(ctx, nil, nil, 1)
+			return err
+		})
+
+		Expect(provider.calls).To(Equal(3))
+	})
+})
+
+// throttlingProvider rejects every call with a 429 that asks to be retried immediately
+type throttlingProvider struct {
+	calls int
+}
+
+func (p *throttlingProvider) ChatCompletion(ctx context.Context, messages []genai.Message, n int64, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	p.calls++
+	return nil, &openai.Error{
+		StatusCode: http.StatusTooManyRequests,
+		Request:    httptest.NewRequest(http.MethodPost, "/chat/completions", nil),
+		Response:   &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"0"}}},
+	}
+}
+
+func (p *throttlingProvider) ChatCompletionStream(ctx context.Context, messages []genai.Message, n int64, streamFunc func(*openai.ChatCompletionChunk) error, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	return p.ChatCompletion(ctx, messages, n, tools...)
+}
+
+func (p *throttlingProvider) SetOutputSchema(schema *runtime.RawExtension, schemaName string) {}
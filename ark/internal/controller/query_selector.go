@@ -0,0 +1,138 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/genai"
+)
+
+// selectorMatch is a target matched by the selector of a query, with the weight it is sampled by
+type selectorMatch struct {
+	target arkv1alpha1.QueryTarget
+	weight float64
+}
+
+// newSelectorMatch reads the weight of a matched resource from its target-weight annotation. A missing or
+// invalid weight counts as 1.
+func newSelectorMatch(ctx context.Context, targetType string, obj client.Object) selectorMatch {
+	match := selectorMatch{
+		target: arkv1alpha1.QueryTarget{Type: targetType, Name: obj.GetName()},
+		weight: 1,
+	}
+	value, ok := obj.GetAnnotations()[annotations.TargetWeight]
+	if !ok {
+		return match
+	}
+	weight, err := strconv.ParseFloat(value, 64)
+	if err != nil || weight <= 0 || math.IsInf(weight, 0) || math.IsNaN(weight) {
+		logf.FromContext(ctx).Info("ignoring invalid target weight", "target", targetType+"/"+obj.GetName(), "weight", value)
+		return match
+	}
+	match.weight = weight
+	return match
+}
+
+// sampleSelectorMatches picks count of the matches at random without replacement, each in proportion to its
+// weight. The picked matches keep their order.
+func sampleSelectorMatches(matches []selectorMatch, count int) []selectorMatch {
+	if count >= len(matches) {
+		return matches
+	}
+
+	// Weighted sampling by Efraimidis and Spirakis: the matches with the largest u^(1/weight) are picked
+	keys := make([]float64, len(matches))
+	order := make([]int, len(matches))
+	for i, match := range matches {
+		keys[i] = math.Pow(rand.Float64(), 1/match.weight)
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int { return -compareFloats(keys[a], keys[b]) })
+	picked := order[:count]
+	slices.Sort(picked)
+
+	sampled := make([]selectorMatch, 0, count)
+	for _, i := range picked {
+		sampled = append(sampled, matches[i])
+	}
+	return sampled
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// isSelectorMatch reports whether the target at index i of the resolved targets was matched by the selector.
+// The targets listed in the spec come first, followed by the selector matches.
+func isSelectorMatch(query arkv1alpha1.Query, i int) bool {
+	return i >= len(query.Spec.Targets)
+}
+
+// stopsAfterFirstSuccess reports whether the query stops its selector matches once one succeeds
+func stopsAfterFirstSuccess(query arkv1alpha1.Query) bool {
+	return query.Spec.SelectorPolicy != nil && query.Spec.SelectorPolicy.StopAfterFirstSuccess
+}
+
+// requiredSelectorSuccesses is how many selector matches must succeed for the query to succeed, or zero when
+// all of them must
+func requiredSelectorSuccesses(policy *arkv1alpha1.SelectorPolicy) int {
+	switch {
+	case policy == nil:
+		return 0
+	case policy.Quorum != nil:
+		return int(*policy.Quorum)
+	case policy.StopAfterFirstSuccess:
+		return 1
+	}
+	return 0
+}
+
+// stoppedEarly marks the result of a target that was canceled because the query no longer needed it
+func stoppedEarly(ctx, stopCtx context.Context, result targetResult) targetResult {
+	if ctx.Err() == nil && stopCtx.Err() != nil && genai.ReasonFor(result.err) == genai.ReasonCanceled {
+		result.err = genai.NewError(genai.ReasonStoppedEarly, result.err)
+	}
+	return result
+}
+
+// determineQueryStatusWithSelectorPolicy determines the status of a query whose selector matches only need a
+// quorum of successes. The listed targets must all succeed; the failures and cancellations of the selector
+// matches are ignored once the quorum is reached.
+func (r *QueryReconciler) determineQueryStatusWithSelectorPolicy(query arkv1alpha1.Query, responses []arkv1alpha1.Response) string {
+	required := requiredSelectorSuccesses(query.Spec.SelectorPolicy)
+	if required == 0 {
+		return r.determineQueryStatus(responses)
+	}
+
+	listed := make(map[arkv1alpha1.QueryTarget]bool, len(query.Spec.Targets))
+	for _, target := range query.Spec.Targets {
+		listed[arkv1alpha1.QueryTarget{Type: target.Type, Name: target.Name}] = true
+	}
+	var listedResponses, matchedResponses []arkv1alpha1.Response
+	for _, response := range responses {
+		if listed[arkv1alpha1.QueryTarget{Type: response.Target.Type, Name: response.Target.Name}] {
+			listedResponses = append(listedResponses, response)
+		} else {
+			matchedResponses = append(matchedResponses, response)
+		}
+	}
+
+	matchedStatus := r.quorumStatus(matchedResponses, required)
+	return r.determineQueryStatus(append(listedResponses, arkv1alpha1.Response{Phase: matchedStatus}))
+}
@@ -0,0 +1,130 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/genai"
+)
+
+var _ = Describe("Query selector policy", func() {
+	reconciler := &QueryReconciler{}
+
+	agent := func(name, weight string) *arkv1alpha1.Agent {
+		agent := &arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{"role": "analyst"},
+		}}
+		if weight != "" {
+			agent.Annotations = map[string]string{annotations.TargetWeight: weight}
+		}
+		return agent
+	}
+	response := func(name, phase string) arkv1alpha1.Response {
+		return arkv1alpha1.Response{Target: arkv1alpha1.QueryTarget{Type: "agent", Name: name}, Phase: phase}
+	}
+	selectorQuery := func(policy *arkv1alpha1.SelectorPolicy) arkv1alpha1.Query {
+		return arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "survey", Namespace: "default"},
+			Spec: arkv1alpha1.QuerySpec{
+				Targets:        []arkv1alpha1.QueryTarget{{Type: "agent", Name: "lead"}},
+				Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"role": "analyst"}},
+				SelectorPolicy: policy,
+			},
+		}
+	}
+
+	It("should sample the matched targets by weight and keep the listed targets", func() {
+		scheme := runtime.NewScheme()
+		Expect(arkv1alpha1.AddToScheme(scheme)).To(Succeed())
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			agent("light", "0.000001"), agent("heavy", "1000000"), agent("plain", ""),
+		).Build()
+
+		query := selectorQuery(&arkv1alpha1.SelectorPolicy{Sample: ptr.To(int32(1))})
+		for range 20 {
+			targets, err := reconciler.resolveTargets(context.Background(), query, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(targets).To(Equal([]arkv1alpha1.QueryTarget{{Type: "agent", Name: "lead"}, {Type: "agent", Name: "heavy"}}))
+		}
+
+		query.Spec.SelectorPolicy.Sample = ptr.To(int32(5))
+		targets, err := reconciler.resolveTargets(context.Background(), query, fakeClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(targets).To(HaveLen(4))
+	})
+
+	It("should keep the order of the sampled matches", func() {
+		matches := []selectorMatch{
+			{target: arkv1alpha1.QueryTarget{Name: "a"}, weight: 1},
+			{target: arkv1alpha1.QueryTarget{Name: "b"}, weight: 1},
+			{target: arkv1alpha1.QueryTarget{Name: "c"}, weight: 1},
+			{target: arkv1alpha1.QueryTarget{Name: "d"}, weight: 1},
+		}
+		sampled := sampleSelectorMatches(matches, 3)
+		Expect(sampled).To(HaveLen(3))
+		Expect(slices.IsSortedFunc(sampled, func(a, b selectorMatch) int {
+			return strings.Compare(a.target.Name, b.target.Name)
+		})).To(BeTrue())
+	})
+
+	It("should ignore invalid target weights", func() {
+		Expect(newSelectorMatch(context.Background(), "agent", agent("negative", "-2")).weight).To(Equal(1.0))
+		Expect(newSelectorMatch(context.Background(), "agent", agent("text", "heavy")).weight).To(Equal(1.0))
+		Expect(newSelectorMatch(context.Background(), "agent", agent("valid", "2.5")).weight).To(Equal(2.5))
+	})
+
+	It("should succeed once the quorum of matches succeeded", func() {
+		query := selectorQuery(&arkv1alpha1.SelectorPolicy{Quorum: ptr.To(int32(2))})
+
+		Expect(reconciler.determineQueryStatusWithSelectorPolicy(query, []arkv1alpha1.Response{
+			response("lead", statusDone), response("a", statusDone), response("b", statusError), response("c", statusDone),
+		})).To(Equal(statusDone))
+		Expect(reconciler.determineQueryStatusWithSelectorPolicy(query, []arkv1alpha1.Response{
+			response("lead", statusDone), response("a", statusDone), response("b", statusError),
+		})).To(Equal(statusError))
+		Expect(reconciler.determineQueryStatusWithSelectorPolicy(query, []arkv1alpha1.Response{
+			response("lead", statusDone), response("a", statusDone),
+		})).To(Equal(statusError), "too few matches to reach the quorum")
+		Expect(reconciler.determineQueryStatusWithSelectorPolicy(query, []arkv1alpha1.Response{
+			response("lead", statusError), response("a", statusDone), response("b", statusDone),
+		})).To(Equal(statusError), "the listed targets must all succeed")
+	})
+
+	It("should ignore the matches stopped after the first success", func() {
+		query := selectorQuery(&arkv1alpha1.SelectorPolicy{StopAfterFirstSuccess: true})
+		Expect(reconciler.determineQueryStatusWithSelectorPolicy(query, []arkv1alpha1.Response{
+			response("lead", statusDone), response("a", statusCanceled), response("b", statusDone),
+		})).To(Equal(statusDone))
+
+		ctx := context.Background()
+		stopCtx, stop := context.WithCancel(ctx)
+		stop()
+		result := stoppedEarly(ctx, stopCtx, targetResult{err: genai.NewError(genai.ReasonCanceled, context.Canceled)})
+		Expect(genai.ReasonFor(result.err)).To(Equal(genai.ReasonStoppedEarly))
+		Expect(genai.IsCancellation(result.err)).To(BeTrue())
+
+		failed := stoppedEarly(ctx, stopCtx, targetResult{err: genai.NewError(genai.ReasonToolFailed, context.Canceled)})
+		Expect(genai.ReasonFor(failed.err)).To(Equal(genai.ReasonToolFailed))
+	})
+
+	It("should require every match without a quorum", func() {
+		query := selectorQuery(&arkv1alpha1.SelectorPolicy{Sample: ptr.To(int32(2))})
+		Expect(reconciler.determineQueryStatusWithSelectorPolicy(query, []arkv1alpha1.Response{
+			response("lead", statusDone), response("a", statusDone), response("b", statusError),
+		})).To(Equal(statusError))
+	})
+})
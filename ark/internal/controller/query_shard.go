@@ -0,0 +1,108 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/labels"
+)
+
+// QueryShard is the part of the queries a controller replica executes when query execution is sharded across
+// replicas. Each of the Count replicas runs with its own Index, from 0 to Count-1.
+type QueryShard struct {
+	Index int
+	Count int
+}
+
+// sharded reports whether query execution is split across replicas
+func (s *QueryShard) sharded() bool {
+	return s != nil && s.Count > 1
+}
+
+// owns reports whether the shard executes a query. The queries of a namespace labelled with a shard are
+// executed by that shard; the others are spread across the shards by the hash of their namespace and name.
+func (s *QueryShard) owns(query types.NamespacedName, namespaceShard string) bool {
+	if index, err := strconv.Atoi(namespaceShard); err == nil && index >= 0 && index < s.Count {
+		return index == s.Index
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(query.String()))
+	return int(hash.Sum32()%uint32(s.Count)) == s.Index
+}
+
+// ownsQuery reports whether the replica executes the query, which is always the case when execution is not sharded
+func (r *QueryReconciler) ownsQuery(ctx context.Context, query types.NamespacedName) (bool, error) {
+	if !r.Shard.sharded() {
+		return true, nil
+	}
+
+	var namespace corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: query.Namespace}, &namespace); client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to get namespace %s: %w", query.Namespace, err)
+	}
+	namespaceShard := namespace.Labels[labels.QueryShardLabel]
+	if _, err := strconv.Atoi(namespaceShard); namespaceShard != "" && err != nil {
+		logf.FromContext(ctx).Info("ignoring invalid query shard label of namespace", "namespace", query.Namespace, "shard", namespaceShard)
+	}
+	return r.Shard.owns(query, namespaceShard), nil
+}
+
+// releaseQuery cancels the execution of a query the replica no longer owns, after the shard of its namespace
+// changed, so that it does not run on both this replica and the one that now owns it
+func (r *QueryReconciler) releaseQuery(ctx context.Context, query types.NamespacedName) {
+	if r.getOperations().cancel(query) {
+		logf.FromContext(ctx).Info("canceled query owned by another shard", "query", query.Name, "namespace", query.Namespace)
+	}
+}
+
+// queryShardChanged passes the updates of namespaces whose query shard label changed
+var queryShardChanged = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetLabels()[labels.QueryShardLabel] != e.ObjectNew.GetLabels()[labels.QueryShardLabel]
+	},
+}
+
+// findQueriesForShardChange enqueues the running queries of a namespace whose shard changed, so that the replica
+// that owned them cancels them and the one that owns them now takes them over
+func (r *QueryReconciler) findQueriesForShardChange(ctx context.Context, obj client.Object) []reconcile.Request {
+	var queryList arkv1alpha1.QueryList
+	if err := r.List(ctx, &queryList, client.InNamespace(obj.GetName())); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list queries of namespace", "namespace", obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, query := range queryList.Items {
+		if query.Status.Phase == statusRunning {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: query.Name, Namespace: query.Namespace}})
+		}
+	}
+	return requests
+}
+
+// QueryShardIndexFromHostname returns the ordinal of a StatefulSet pod, such as 2 for ark-controller-2, as the
+// shard index of the replica
+func QueryShardIndexFromHostname(hostname string) (int, error) {
+	ordinal := hostname[strings.LastIndex(hostname, "-")+1:]
+	index, err := strconv.Atoi(ordinal)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("hostname %s does not end with a StatefulSet ordinal", hostname)
+	}
+	return index, nil
+}
@@ -0,0 +1,124 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/labels"
+)
+
+var _ = Describe("Query sharding", func() {
+	ctx := context.Background()
+
+	It("should spread queries across every shard, each query to exactly one", func() {
+		shards := []*QueryShard{{Index: 0, Count: 3}, {Index: 1, Count: 3}, {Index: 2, Count: 3}}
+		executed := make([]int, len(shards))
+		for i := range 300 {
+			query := types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("query-%d", i)}
+			owners := 0
+			for index, shard := range shards {
+				if shard.owns(query, "") {
+					executed[index]++
+					owners++
+				}
+			}
+			Expect(owners).To(Equal(1), "query %s", query)
+		}
+		for index, count := range executed {
+			Expect(count).To(BeNumerically(">", 50), "shard %d", index)
+		}
+	})
+
+	It("should assign the queries of a labelled namespace to its shard", func() {
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "shard-pinned",
+			Labels: map[string]string{labels.QueryShardLabel: "1"},
+		}}
+		Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, namespace)
+
+		for i := range 10 {
+			query := types.NamespacedName{Namespace: "shard-pinned", Name: fmt.Sprintf("query-%d", i)}
+			for index := range 3 {
+				r := &QueryReconciler{Client: k8sClient, Shard: &QueryShard{Index: index, Count: 3}}
+				owned, err := r.ownsQuery(ctx, query)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(owned).To(Equal(index == 1), "query %s on shard %d", query, index)
+			}
+		}
+	})
+
+	It("should hash the queries of namespaces labelled with a shard that does not exist", func() {
+		shard := &QueryShard{Index: 0, Count: 2}
+		query := types.NamespacedName{Namespace: "default", Name: "query-0"}
+		Expect(shard.owns(query, "5")).To(Equal(shard.owns(query, "")))
+		Expect(shard.owns(query, "first")).To(Equal(shard.owns(query, "")))
+	})
+
+	It("should execute every query when not sharded", func() {
+		for _, shard := range []*QueryShard{nil, {Index: 0, Count: 1}} {
+			r := &QueryReconciler{Client: k8sClient, Shard: shard}
+			owned, err := r.ownsQuery(ctx, types.NamespacedName{Namespace: "default", Name: "query-0"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(owned).To(BeTrue())
+		}
+	})
+
+	It("should cancel the running queries of a namespace moved to another shard", func() {
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "shard-moved",
+			Labels: map[string]string{labels.QueryShardLabel: "1"},
+		}}
+		Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, namespace)
+
+		running := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "shard-moved"}}
+		done := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "done", Namespace: "shard-moved"}}
+		for query, phase := range map[*arkv1alpha1.Query]string{running: statusRunning, done: statusDone} {
+			Expect(k8sClient.Create(ctx, query)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, query)
+			query.Status.Phase = phase
+			Expect(k8sClient.Status().Update(ctx, query)).To(Succeed())
+		}
+
+		r := &QueryReconciler{Client: k8sClient, Shard: &QueryShard{Index: 0, Count: 2}}
+		key := types.NamespacedName{Name: "running", Namespace: "shard-moved"}
+		opCtx, cancel := context.WithCancel(ctx)
+		DeferCleanup(cancel)
+		r.getOperations().store(key, cancel)
+
+		By("enqueueing the running queries when the shard label changes")
+		moved := namespace.DeepCopy()
+		moved.Labels[labels.QueryShardLabel] = "1"
+		Expect(queryShardChanged.Update(event.UpdateEvent{ObjectOld: namespace, ObjectNew: moved})).To(BeFalse())
+		namespace.Labels[labels.QueryShardLabel] = "0"
+		Expect(queryShardChanged.Update(event.UpdateEvent{ObjectOld: namespace, ObjectNew: moved})).To(BeTrue())
+		Expect(r.findQueriesForShardChange(ctx, moved)).To(ConsistOf(reconcile.Request{NamespacedName: key}))
+
+		By("canceling the query the replica no longer owns")
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(opCtx.Err()).To(MatchError(context.Canceled))
+		Expect(r.getOperations().exists(key)).To(BeFalse())
+	})
+
+	It("should take the shard index from the StatefulSet ordinal of the hostname", func() {
+		index, err := QueryShardIndexFromHostname("ark-controller-2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(index).To(Equal(2))
+
+		_, err = QueryShardIndexFromHostname("ark-controller-7d9f8b6c5-x2x4k")
+		Expect(err).To(HaveOccurred())
+	})
+})
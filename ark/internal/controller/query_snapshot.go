@@ -0,0 +1,171 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/genai"
+	"mckinsey.com/ark/internal/labels"
+)
+
+const (
+	// querySnapshotKey holds the metadata, spec and status of a soft-deleted query
+	querySnapshotKey = "query.json"
+	// queryTranscriptKey holds the messages of the session of a soft-deleted query
+	queryTranscriptKey = "transcript.json"
+)
+
+// errNotQuerySnapshot is returned when the ConfigMap a query is snapshotted to belongs to something else
+var errNotQuerySnapshot = errors.New("ConfigMap is not a query snapshot")
+
+// querySnapshotName returns the name of the ConfigMap a soft-deleted query is snapshotted to
+func querySnapshotName(queryName string) string {
+	return queryName + "-snapshot"
+}
+
+// isSoftDeleted reports whether a query is snapshotted before it is deleted
+func isSoftDeleted(query *arkv1alpha1.Query) bool {
+	return query.Annotations[annotations.SoftDelete] == "true"
+}
+
+// isRestoredQuery reports whether a query was restored from a snapshot: restored queries are read-only and never run
+func isRestoredQuery(query *arkv1alpha1.Query) bool {
+	_, ok := query.Annotations[annotations.RestoredFrom]
+	return ok
+}
+
+// snapshotQuery archives a soft-deleted query being finalized to a ConfigMap that outlives it, so that it can be
+// restored: its metadata, spec and status, and the transcript of its session when its memory can be read. The
+// snapshot replaces the snapshot of an earlier query with the same name. Restored queries keep the snapshot they
+// were restored from and the queries of a namespace being offboarded are flushed without a snapshot.
+//
+// Only failures to reach the API server are returned, so that the finalizer retries; a query that cannot be
+// snapshotted is reported with an event and deleted, rather than blocking its deletion forever.
+func (r *QueryReconciler) snapshotQuery(ctx context.Context, query *arkv1alpha1.Query) error {
+	if !isSoftDeleted(query) || isRestoredQuery(query) {
+		return nil
+	}
+
+	log := logf.FromContext(ctx)
+
+	var namespace corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: query.Namespace}, &namespace); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get namespace %s: %w", query.Namespace, err)
+	}
+	if namespace.Labels[labels.OffboardLabel] == "true" {
+		log.Info("query not snapshotted as its namespace is offboarded", "query", query.Name, "namespace", query.Namespace)
+		return nil
+	}
+
+	data, err := r.querySnapshotData(ctx, query)
+	if err != nil {
+		r.Recorder.Event(query, corev1.EventTypeWarning, "QuerySnapshotFailed", err.Error())
+		return nil
+	}
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: querySnapshotName(query.Name), Namespace: query.Namespace}}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		// Never overwrite a ConfigMap that happens to have the name but is not a snapshot of the query
+		if cm.ResourceVersion != "" && cm.Labels[labels.QuerySnapshotLabel] != query.Name {
+			return errNotQuerySnapshot
+		}
+
+		if cm.Labels == nil {
+			cm.Labels = map[string]string{}
+		}
+		cm.Labels[labels.QuerySnapshotLabel] = query.Name
+		if cm.Annotations == nil {
+			cm.Annotations = map[string]string{}
+		}
+		cm.Annotations[annotations.DeletedAt] = time.Now().UTC().Format(time.RFC3339)
+		cm.Data = data
+		return nil
+	})
+	if errors.Is(err, errNotQuerySnapshot) {
+		r.Recorder.Event(query, corev1.EventTypeWarning, "QuerySnapshotFailed",
+			fmt.Sprintf("ConfigMap %s already exists and is not a snapshot of query %s", cm.Name, query.Name))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to snapshot query %s: %w", query.Name, err)
+	}
+
+	log.Info("query snapshotted before deletion", "query", query.Name, "namespace", query.Namespace, "snapshot", cm.Name)
+	return nil
+}
+
+// querySnapshotData returns the content of the snapshot of a query. The transcript is left out when the memory
+// cannot be read or when it would not fit in the ConfigMap with the query.
+func (r *QueryReconciler) querySnapshotData(ctx context.Context, query *arkv1alpha1.Query) (map[string]string, error) {
+	log := logf.FromContext(ctx)
+
+	snapshot := arkv1alpha1.Query{
+		TypeMeta: metav1.TypeMeta{APIVersion: arkv1alpha1.GroupVersion.String(), Kind: "Query"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        query.Name,
+			Namespace:   query.Namespace,
+			Labels:      query.Labels,
+			Annotations: query.Annotations,
+		},
+		Spec:   query.Spec,
+		Status: query.Status,
+	}
+	queryJSON, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal query %s: %w", query.Name, err)
+	}
+	if len(queryJSON) > corev1.MaxSecretSize {
+		return nil, fmt.Errorf("snapshot of query %s would take %d bytes, exceeding the ConfigMap limit of %d bytes", query.Name, len(queryJSON), corev1.MaxSecretSize)
+	}
+	data := map[string]string{querySnapshotKey: string(queryJSON)}
+
+	messages, err := r.readTranscript(ctx, query)
+	if err != nil {
+		log.Error(err, "failed to read the transcript of query, snapshotting it without", "query", query.Name)
+		return data, nil
+	}
+	if len(messages) == 0 {
+		return data, nil
+	}
+	transcriptJSON, err := json.Marshal(messages)
+	if err != nil {
+		log.Error(err, "failed to marshal the transcript of query, snapshotting it without", "query", query.Name)
+		return data, nil
+	}
+	if len(queryJSON)+len(transcriptJSON) > corev1.MaxSecretSize {
+		log.Info("transcript of query too large to snapshot, snapshotting it without", "query", query.Name, "bytes", len(transcriptJSON))
+		return data, nil
+	}
+	data[queryTranscriptKey] = string(transcriptJSON)
+	return data, nil
+}
+
+// readTranscript reads the messages of the session of a query from its memory
+func (r *QueryReconciler) readTranscript(ctx context.Context, query *arkv1alpha1.Query) ([]genai.Message, error) {
+	sessionId := query.Spec.SessionId
+	if sessionId == "" {
+		sessionId = string(query.UID)
+	}
+
+	memory, err := genai.NewMemoryForQuery(ctx, r.Client, query.Spec.Memory, query.Namespace, genai.NewQueryRecorder(query, r.Recorder), sessionId, query.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = memory.Close() }()
+
+	return memory.GetMessages(ctx)
+}
@@ -0,0 +1,137 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/labels"
+)
+
+var _ = Describe("Query snapshots", func() {
+	ctx := context.Background()
+
+	var recorder *record.FakeRecorder
+
+	newReconciler := func() *QueryReconciler {
+		recorder = record.NewFakeRecorder(10)
+		return &QueryReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), Recorder: recorder}
+	}
+
+	newQuery := func(name, namespace string, queryAnnotations map[string]string) *arkv1alpha1.Query {
+		query := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Annotations: queryAnnotations},
+			Spec:       arkv1alpha1.QuerySpec{Targets: []arkv1alpha1.QueryTarget{{Type: "agent", Name: "writer"}}},
+			Status: arkv1alpha1.QueryStatus{
+				Phase:     statusDone,
+				Responses: []arkv1alpha1.Response{{Target: arkv1alpha1.QueryTarget{Type: "agent", Name: "writer"}, Content: "Sunny", Phase: statusDone}},
+			},
+		}
+		Expect(query.Spec.SetInputString("What is the weather?")).To(Succeed())
+		return query
+	}
+
+	getSnapshot := func(name, namespace string) (*corev1.ConfigMap, error) {
+		snapshot := &corev1.ConfigMap{}
+		err := k8sClient.Get(ctx, types.NamespacedName{Name: querySnapshotName(name), Namespace: namespace}, snapshot)
+		if err == nil {
+			DeferCleanup(k8sClient.Delete, ctx, snapshot)
+		}
+		return snapshot, err
+	}
+
+	It("should snapshot a soft-deleted query", func() {
+		query := newQuery("soft-deleted", "default", map[string]string{annotations.SoftDelete: "true"})
+		Expect(newReconciler().snapshotQuery(ctx, query)).To(Succeed())
+
+		snapshot, err := getSnapshot(query.Name, query.Namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshot.Labels).To(HaveKeyWithValue(labels.QuerySnapshotLabel, query.Name))
+		Expect(snapshot.Annotations).To(HaveKey(annotations.DeletedAt))
+		Expect(snapshot.OwnerReferences).To(BeEmpty(), "the snapshot outlives the query")
+		Expect(snapshot.Data).NotTo(HaveKey(queryTranscriptKey), "the query has no memory")
+
+		var snapshotted arkv1alpha1.Query
+		Expect(json.Unmarshal([]byte(snapshot.Data[querySnapshotKey]), &snapshotted)).To(Succeed())
+		Expect(snapshotted.Kind).To(Equal("Query"))
+		Expect(snapshotted.Name).To(Equal(query.Name))
+		Expect(snapshotted.Spec.Targets).To(Equal(query.Spec.Targets))
+		Expect(snapshotted.Status.Responses).To(Equal(query.Status.Responses))
+	})
+
+	It("should only snapshot soft-deleted queries that were not restored", func() {
+		Expect(newReconciler().snapshotQuery(ctx, newQuery("hard-deleted", "default", nil))).To(Succeed())
+		_, err := getSnapshot("hard-deleted", "default")
+		Expect(err).To(HaveOccurred())
+
+		restored := newQuery("restored", "default", map[string]string{
+			annotations.SoftDelete:   "true",
+			annotations.RestoredFrom: querySnapshotName("restored"),
+		})
+		Expect(newReconciler().snapshotQuery(ctx, restored)).To(Succeed())
+		_, err = getSnapshot("restored", "default")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not snapshot the queries of an offboarded namespace", func() {
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "offboarded-snapshots",
+			Labels: map[string]string{labels.OffboardLabel: "true"},
+		}}
+		Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, namespace)
+
+		query := newQuery("flushed", namespace.Name, map[string]string{annotations.SoftDelete: "true"})
+		Expect(newReconciler().snapshotQuery(ctx, query)).To(Succeed())
+		_, err := getSnapshot(query.Name, namespace.Name)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not overwrite a ConfigMap that is not a snapshot of the query", func() {
+		existing := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: querySnapshotName("clashing"), Namespace: "default"},
+			Data:       map[string]string{"app": "config"},
+		}
+		Expect(k8sClient.Create(ctx, existing)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, existing)
+
+		query := newQuery("clashing", "default", map[string]string{annotations.SoftDelete: "true"})
+		r := newReconciler()
+		Expect(r.snapshotQuery(ctx, query)).To(Succeed(), "the query is deleted without a snapshot")
+		Expect(recorder.Events).To(Receive(ContainSubstring("QuerySnapshotFailed")))
+
+		unchanged := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(existing), unchanged)).To(Succeed())
+		Expect(unchanged.Data).To(Equal(existing.Data))
+	})
+
+	It("should never run a restored query", func() {
+		query := newQuery("restored-run", "default", map[string]string{annotations.RestoredFrom: querySnapshotName("restored-run")})
+		query.Finalizers = []string{finalizer}
+		query.CreationTimestamp = metav1.Now()
+		query.Status = arkv1alpha1.QueryStatus{}
+		Expect(k8sClient.Create(ctx, query)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, query)
+
+		result, err := newReconciler().Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(query)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+		reconciled := &arkv1alpha1.Query{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(query), reconciled)).To(Succeed())
+		Expect(reconciled.Status.Phase).To(BeEmpty())
+		Expect(reconciled.Status.Conditions).To(BeEmpty())
+	})
+})
@@ -0,0 +1,111 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// Field index key holding the type/name of the targets a query lists
+const queryTargetIndexKey = ".spec.targets"
+
+// queryTargetKey returns the index value of a target, such as agent/weather
+func queryTargetKey(targetType, name string) string {
+	return targetType + "/" + name
+}
+
+// newQueryTarget returns the resource a target of the given type resolves to, or nil when the type has none
+func newQueryTarget(targetType string) client.Object {
+	switch targetType {
+	case "agent":
+		return &arkv1alpha1.Agent{}
+	case "team":
+		return &arkv1alpha1.Team{}
+	case "model":
+		return &arkv1alpha1.Model{}
+	case "tool":
+		return &arkv1alpha1.Tool{}
+	default:
+		return nil
+	}
+}
+
+// indexQueryTargets extracts the target index values of a query
+func indexQueryTargets(obj client.Object) []string {
+	query := obj.(*arkv1alpha1.Query)
+	keys := make([]string, 0, len(query.Spec.Targets))
+	for _, target := range query.Spec.Targets {
+		if newQueryTarget(target.Type) != nil {
+			keys = append(keys, queryTargetKey(target.Type, target.Name))
+		}
+	}
+	return keys
+}
+
+// checkTargets returns whether the agents, teams, models and tools the query targets all exist. While some of them
+// do not, the query is kept pending rather than failing, and it is reconciled again when they are created.
+func (r *QueryReconciler) checkTargets(ctx context.Context, query *arkv1alpha1.Query) (bool, error) {
+	var missing []string
+	for _, target := range query.Spec.Targets {
+		obj := newQueryTarget(target.Type)
+		if obj == nil {
+			continue
+		}
+		if err := r.Get(ctx, types.NamespacedName{Name: target.Name, Namespace: query.Namespace}, obj); err != nil {
+			if errors.IsNotFound(err) {
+				missing = append(missing, queryTargetKey(target.Type, target.Name))
+				continue
+			}
+			return false, err
+		}
+	}
+
+	if len(missing) == 0 {
+		return true, nil
+	}
+
+	message := fmt.Sprintf("Waiting for targets: %s", strings.Join(missing, ", "))
+	// Status updates trigger a reconcile, so the status is only updated when the targets waited on change
+	condition := meta.FindStatusCondition(query.Status.Conditions, string(arkv1alpha1.QueryCompleted))
+	if query.Status.Phase == statusPending && condition != nil && condition.Message == message {
+		return false, nil
+	}
+	logf.FromContext(ctx).V(1).Info("query waiting for targets", "query", query.Name, "targets", missing)
+	query.Status.Phase = statusPending
+	r.setConditionCompleted(query, metav1.ConditionFalse, "QueryWaitingForTargets", message)
+	return false, r.Status().Update(ctx, query)
+}
+
+// findQueriesForTarget returns the queries that have not started and target the given agent, team, model or tool,
+// so that the queries waiting for it start when it is created
+func (r *QueryReconciler) findQueriesForTarget(targetType string) func(context.Context, client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var queries arkv1alpha1.QueryList
+		if err := r.List(ctx, &queries, client.InNamespace(obj.GetNamespace()),
+			client.MatchingFields{queryTargetIndexKey: queryTargetKey(targetType, obj.GetName())}); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to list queries for target", "type", targetType, "name", obj.GetName())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, query := range queries.Items {
+			if query.Status.Phase != "" && query.Status.Phase != statusPending {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&query)})
+		}
+		return requests
+	}
+}
@@ -0,0 +1,105 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+var _ = Describe("Query targets", func() {
+	ctx := context.Background()
+
+	newQuery := func(name, phase string, targets ...arkv1alpha1.QueryTarget) *arkv1alpha1.Query {
+		query := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       arkv1alpha1.QuerySpec{Targets: targets},
+			Status:     arkv1alpha1.QueryStatus{Phase: phase},
+		}
+		Expect(query.Spec.SetInputString("test input question")).To(Succeed())
+		return query
+	}
+
+	It("should keep a query pending until its targets exist", func() {
+		query := newQuery("targets-waiting", "",
+			arkv1alpha1.QueryTarget{Type: "agent", Name: "targets-agent"},
+			arkv1alpha1.QueryTarget{Type: "model", Name: "targets-model"},
+		)
+		Expect(k8sClient.Create(ctx, query)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, query)
+		r := &QueryReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+		ready, err := r.checkTargets(ctx, query)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ready).To(BeFalse())
+
+		updated := &arkv1alpha1.Query{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(query), updated)).To(Succeed())
+		Expect(updated.Status.Phase).To(Equal(statusPending))
+		condition := meta.FindStatusCondition(updated.Status.Conditions, string(arkv1alpha1.QueryCompleted))
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal("QueryWaitingForTargets"))
+		Expect(condition.Message).To(Equal("Waiting for targets: agent/targets-agent, model/targets-model"))
+
+		agent := &arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "targets-agent", Namespace: "default"}}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, agent)
+		ready, err = r.checkTargets(ctx, updated)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ready).To(BeFalse())
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, string(arkv1alpha1.QueryCompleted)).Message).
+			To(Equal("Waiting for targets: model/targets-model"))
+
+		model := &arkv1alpha1.Model{ObjectMeta: metav1.ObjectMeta{Name: "targets-model", Namespace: "default"}}
+		Expect(k8sClient.Create(ctx, model)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, model)
+		ready, err = r.checkTargets(ctx, updated)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ready).To(BeTrue())
+	})
+
+	It("should only wait for targets that resolve to resources", func() {
+		query := newQuery("targets-session", "", arkv1alpha1.QueryTarget{Type: "session", Name: "gpt-4o"})
+		ready, err := (&QueryReconciler{Client: k8sClient}).checkTargets(ctx, query)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ready).To(BeTrue())
+	})
+
+	It("should enqueue the queries that have not started and target the created resource", func() {
+		agentTarget := arkv1alpha1.QueryTarget{Type: "agent", Name: "weather"}
+		scheme := runtime.NewScheme()
+		Expect(arkv1alpha1.AddToScheme(scheme)).To(Succeed())
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(
+				newQuery("new", "", agentTarget),
+				newQuery("pending", statusPending, agentTarget, arkv1alpha1.QueryTarget{Type: "tool", Name: "weather"}),
+				newQuery("done", statusDone, agentTarget),
+				newQuery("team", "", arkv1alpha1.QueryTarget{Type: "team", Name: "weather"}),
+			).
+			WithIndex(&arkv1alpha1.Query{}, queryTargetIndexKey, indexQueryTargets).
+			Build()
+		r := &QueryReconciler{Client: fakeClient}
+
+		agent := &arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: "default"}}
+		Expect(r.findQueriesForTarget("agent")(ctx, agent)).To(ConsistOf(
+			reconcile.Request{NamespacedName: client.ObjectKey{Name: "new", Namespace: "default"}},
+			reconcile.Request{NamespacedName: client.ObjectKey{Name: "pending", Namespace: "default"}},
+		))
+
+		tool := &arkv1alpha1.Tool{ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: "default"}}
+		Expect(r.findQueriesForTarget("tool")(ctx, tool)).To(ConsistOf(
+			reconcile.Request{NamespacedName: client.ObjectKey{Name: "pending", Namespace: "default"}},
+		))
+	})
+})
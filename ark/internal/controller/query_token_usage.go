@@ -0,0 +1,52 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+// tokenUsageBreakdown converts the breakdown of the token usage collector to the query status, sorted by name.
+// It is nil when no tokens were used.
+func tokenUsageBreakdown(breakdown genai.TokenUsageBreakdown) *arkv1alpha1.TokenUsageBreakdown {
+	if len(breakdown.Targets) == 0 && len(breakdown.Agents) == 0 && len(breakdown.Models) == 0 {
+		return nil
+	}
+
+	result := &arkv1alpha1.TokenUsageBreakdown{
+		Agents: namedTokenUsages(breakdown.Agents),
+		Models: namedTokenUsages(breakdown.Models),
+	}
+	for _, key := range slices.Sorted(maps.Keys(breakdown.Targets)) {
+		// Targets are keyed by type/name, as in the streaming metadata
+		targetType, name, _ := strings.Cut(key, "/")
+		result.Targets = append(result.Targets, arkv1alpha1.TargetTokenUsage{
+			Target:     arkv1alpha1.QueryTarget{Type: targetType, Name: name},
+			TokenUsage: toTokenUsage(breakdown.Targets[key]),
+		})
+	}
+	return result
+}
+
+func namedTokenUsages(usages map[string]genai.TokenUsage) []arkv1alpha1.NamedTokenUsage {
+	var result []arkv1alpha1.NamedTokenUsage
+	for name, usage := range usages {
+		result = append(result, arkv1alpha1.NamedTokenUsage{Name: name, TokenUsage: toTokenUsage(usage)})
+	}
+	slices.SortFunc(result, func(a, b arkv1alpha1.NamedTokenUsage) int { return cmp.Compare(a.Name, b.Name) })
+	return result
+}
+
+func toTokenUsage(usage genai.TokenUsage) arkv1alpha1.TokenUsage {
+	return arkv1alpha1.TokenUsage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		TotalTokens:      usage.TotalTokens,
+	}
+}
@@ -0,0 +1,49 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+var _ = Describe("Query token usage breakdown", func() {
+	It("should convert the breakdown sorted by name", func() {
+		breakdown := tokenUsageBreakdown(genai.TokenUsageBreakdown{
+			Targets: map[string]genai.TokenUsage{
+				"team/research": {PromptTokens: 100, CompletionTokens: 40, TotalTokens: 140},
+				"agent/writer":  {PromptTokens: 8, CompletionTokens: 2, TotalTokens: 10},
+			},
+			Agents: map[string]genai.TokenUsage{
+				"writer":   {TotalTokens: 50},
+				"searcher": {TotalTokens: 100},
+			},
+			Models: map[string]genai.TokenUsage{"gpt-4o": {TotalTokens: 150}},
+		})
+
+		Expect(breakdown.Targets).To(Equal([]arkv1alpha1.TargetTokenUsage{
+			{
+				Target:     arkv1alpha1.QueryTarget{Type: "agent", Name: "writer"},
+				TokenUsage: arkv1alpha1.TokenUsage{PromptTokens: 8, CompletionTokens: 2, TotalTokens: 10},
+			},
+			{
+				Target:     arkv1alpha1.QueryTarget{Type: "team", Name: "research"},
+				TokenUsage: arkv1alpha1.TokenUsage{PromptTokens: 100, CompletionTokens: 40, TotalTokens: 140},
+			},
+		}))
+		Expect(breakdown.Agents).To(Equal([]arkv1alpha1.NamedTokenUsage{
+			{Name: "searcher", TokenUsage: arkv1alpha1.TokenUsage{TotalTokens: 100}},
+			{Name: "writer", TokenUsage: arkv1alpha1.TokenUsage{TotalTokens: 50}},
+		}))
+		Expect(breakdown.Models).To(Equal([]arkv1alpha1.NamedTokenUsage{
+			{Name: "gpt-4o", TokenUsage: arkv1alpha1.TokenUsage{TotalTokens: 150}},
+		}))
+	})
+
+	It("should leave out the breakdown of a query that used no tokens", func() {
+		Expect(tokenUsageBreakdown(genai.NewTokenUsageCollector(discardEventEmitter{}).GetTokenBreakdown())).To(BeNil())
+	})
+})
@@ -0,0 +1,266 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+// sessionRecentQueries is how many of the most recent queries of a session are listed in its status
+const sessionRecentQueries = 10
+
+// SessionReconciler reconciles a Session object
+type SessionReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=sessions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=sessions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=sessions/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queries,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=memories,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *SessionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var session arkv1alpha1.Session
+	if err := r.Get(ctx, req.NamespacedName, &session); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, r.createSessionForQueries(ctx, req.NamespacedName)
+		}
+		return ctrl.Result{}, err
+	}
+
+	queries, err := r.sessionQueries(ctx, &session)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	status := session.Status.DeepCopy()
+	summarizeSessionQueries(status, &session, queries)
+
+	if session.Spec.ForkFrom != "" && status.ForkedFrom == "" {
+		if err := r.fork(ctx, &session, status); err != nil {
+			return ctrl.Result{}, r.updateSessionStatus(ctx, &session, status, err)
+		}
+	}
+
+	requeueAfter, err := r.expire(ctx, &session, status)
+	if err := r.updateSessionStatus(ctx, &session, status, err); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// createSessionForQueries creates the session of new queries that reference a session that does not exist yet,
+// so every conversation can be listed and expires with the ttl of its namespace. Only queries that have not
+// completed create the session, so a deleted session is not created again for the queries it already had.
+func (r *SessionReconciler) createSessionForQueries(ctx context.Context, key types.NamespacedName) error {
+	queries, err := r.listSessionQueries(ctx, key)
+	if err != nil {
+		return err
+	}
+	for _, query := range queries {
+		if !query.DeletionTimestamp.IsZero() {
+			continue
+		}
+		switch query.Status.Phase {
+		case statusDone, statusError, statusCanceled:
+			continue
+		}
+		session := &arkv1alpha1.Session{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec:       arkv1alpha1.SessionSpec{Memory: query.Spec.Memory},
+		}
+		if err := r.Create(ctx, session); client.IgnoreAlreadyExists(err) != nil {
+			return fmt.Errorf("failed to create session %s: %w", key.Name, err)
+		}
+		logf.FromContext(ctx).Info("created session of query", "session", key.Name, "query", query.Name)
+		return nil
+	}
+	return nil
+}
+
+// sessionQueries returns the queries of the session, oldest first
+func (r *SessionReconciler) sessionQueries(ctx context.Context, session *arkv1alpha1.Session) ([]arkv1alpha1.Query, error) {
+	queries, err := r.listSessionQueries(ctx, types.NamespacedName{Name: session.Name, Namespace: session.Namespace})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(queries, func(i, j int) bool {
+		if !queries[i].CreationTimestamp.Equal(&queries[j].CreationTimestamp) {
+			return queries[i].CreationTimestamp.Before(&queries[j].CreationTimestamp)
+		}
+		return queries[i].Name < queries[j].Name
+	})
+	return queries, nil
+}
+
+// listSessionQueries lists the queries whose session id is the name of the session
+func (r *SessionReconciler) listSessionQueries(ctx context.Context, key types.NamespacedName) ([]arkv1alpha1.Query, error) {
+	var queryList arkv1alpha1.QueryList
+	if err := r.List(ctx, &queryList, client.InNamespace(key.Namespace), client.MatchingFields{querySessionIdIndexKey: key.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list queries: %w", err)
+	}
+	return queryList.Items, nil
+}
+
+// summarizeSessionQueries records the queries of the session, their token usage and the time of the last one
+func summarizeSessionQueries(status *arkv1alpha1.SessionStatus, session *arkv1alpha1.Session, queries []arkv1alpha1.Query) {
+	status.QueryCount = int32(len(queries))
+	status.Queries = nil
+	status.TokenUsage = arkv1alpha1.TokenUsage{}
+	lastActivity := session.CreationTimestamp
+	for i, query := range queries {
+		if i >= len(queries)-sessionRecentQueries {
+			status.Queries = append(status.Queries, query.Name)
+		}
+		status.TokenUsage.PromptTokens += query.Status.TokenUsage.PromptTokens
+		status.TokenUsage.CompletionTokens += query.Status.TokenUsage.CompletionTokens
+		status.TokenUsage.TotalTokens += query.Status.TokenUsage.TotalTokens
+		if lastActivity.Before(&query.CreationTimestamp) {
+			lastActivity = query.CreationTimestamp
+		}
+	}
+	status.LastActivityTime = nil
+	if !lastActivity.IsZero() {
+		status.LastActivityTime = &lastActivity
+	}
+}
+
+// fork copies the messages of the session it is forked from into the session, once
+func (r *SessionReconciler) fork(ctx context.Context, session *arkv1alpha1.Session, status *arkv1alpha1.SessionStatus) error {
+	if session.Spec.ForkFrom == session.Name {
+		return fmt.Errorf("session cannot be forked from itself")
+	}
+	memoryName, memoryNamespace := sessionMemory(session)
+	if err := r.Get(ctx, client.ObjectKey{Name: memoryName, Namespace: memoryNamespace}, &arkv1alpha1.Memory{}); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("memory %s/%s not found, no messages to fork", memoryNamespace, memoryName)
+		}
+		return err
+	}
+
+	copied, err := genai.CopyMemorySession(ctx, r.Client, memoryName, memoryNamespace, genai.NewSessionRecorder(session, r.Recorder), session.Spec.ForkFrom, session.Name, string(session.UID))
+	if err != nil {
+		return fmt.Errorf("failed to fork session %s: %w", session.Spec.ForkFrom, err)
+	}
+	status.ForkedFrom = session.Spec.ForkFrom
+	status.ForkedMessages = int32(copied)
+	r.Recorder.Eventf(session, corev1.EventTypeNormal, "SessionForked", "copied %d messages from session %s", copied, session.Spec.ForkFrom)
+	return nil
+}
+
+// expire deletes the messages of a session from memory once its ttl has passed since its last activity, and
+// returns how long until it expires otherwise. A session that expired is active again when it has a new query.
+func (r *SessionReconciler) expire(ctx context.Context, session *arkv1alpha1.Session, status *arkv1alpha1.SessionStatus) (time.Duration, error) {
+	ttl := genai.ResolveSessionTTL(ctx, r.Client, session.Namespace)
+	if session.Spec.TTL != nil {
+		ttl = session.Spec.TTL.Duration
+	}
+	if ttl <= 0 || status.LastActivityTime == nil {
+		status.ExpiresAt = nil
+		status.Phase = arkv1alpha1.SessionPhaseActive
+		return 0, nil
+	}
+
+	expiresAt := status.LastActivityTime.Add(ttl)
+	status.ExpiresAt = &metav1.Time{Time: expiresAt}
+	if remaining := time.Until(expiresAt); remaining > 0 {
+		status.Phase = arkv1alpha1.SessionPhaseActive
+		return remaining, nil
+	}
+	if session.Status.Phase == arkv1alpha1.SessionPhaseExpired {
+		status.Phase = arkv1alpha1.SessionPhaseExpired
+		return 0, nil
+	}
+
+	memoryName, memoryNamespace := sessionMemory(session)
+	err := genai.DeleteMemorySession(ctx, r.Client, memoryName, memoryNamespace, session.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		return 0, fmt.Errorf("failed to delete expired session from memory %s/%s: %w", memoryNamespace, memoryName, err)
+	}
+	status.Phase = arkv1alpha1.SessionPhaseExpired
+	r.Recorder.Event(session, corev1.EventTypeNormal, "SessionExpired", "session expired and its messages were deleted from memory")
+	logf.FromContext(ctx).Info("session expired", "session", session.Name, "lastActivity", status.LastActivityTime.Time)
+	return 0, nil
+}
+
+// updateSessionStatus writes the status, in the error phase when reconciling failed, only if it changed.
+// It returns the reconcile error so the session is retried.
+func (r *SessionReconciler) updateSessionStatus(ctx context.Context, session *arkv1alpha1.Session, status *arkv1alpha1.SessionStatus, reconcileErr error) error {
+	status.Message = ""
+	if reconcileErr != nil {
+		status.Phase = arkv1alpha1.SessionPhaseError
+		status.Message = reconcileErr.Error()
+		if status.Message != session.Status.Message {
+			r.Recorder.Event(session, corev1.EventTypeWarning, "SessionError", status.Message)
+		}
+	}
+	if !equality.Semantic.DeepEqual(status, &session.Status) {
+		session.Status = *status
+		if err := r.Status().Update(ctx, session); err != nil {
+			return fmt.Errorf("failed to update session status: %w", err)
+		}
+	}
+	return reconcileErr
+}
+
+// sessionMemory returns the memory holding the messages of the session
+func sessionMemory(session *arkv1alpha1.Session) (string, string) {
+	if session.Spec.Memory == nil {
+		return "default", session.Namespace
+	}
+	if session.Spec.Memory.Namespace != "" {
+		return session.Spec.Memory.Name, session.Spec.Memory.Namespace
+	}
+	return session.Spec.Memory.Name, session.Namespace
+}
+
+// findSessionForQuery enqueues the session of a query, whether or not it exists yet
+func (r *SessionReconciler) findSessionForQuery(ctx context.Context, obj client.Object) []reconcile.Request {
+	query, ok := obj.(*arkv1alpha1.Query)
+	if !ok || query.Spec.SessionId == "" {
+		return nil
+	}
+	// Session ids that are not valid resource names cannot have a session
+	if len(validation.IsDNS1123Subdomain(query.Spec.SessionId)) > 0 {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: query.Spec.SessionId, Namespace: query.Namespace}}}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SessionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &arkv1alpha1.Query{}, querySessionIdIndexKey, indexQuerySessionId); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arkv1alpha1.Session{}).
+		Watches(&arkv1alpha1.Query{}, handler.EnqueueRequestsFromMapFunc(r.findSessionForQuery)).
+		Named("session").
+		Complete(r)
+}
@@ -0,0 +1,265 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+// sessionMemoryServer stores the messages of sessions like the memory service
+type sessionMemoryServer struct {
+	mu       sync.Mutex
+	messages map[string][]json.RawMessage
+	deleted  []string
+}
+
+func (s *sessionMemoryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == genai.MessagesEndpoint:
+		response := genai.MessagesResponse{}
+		for _, message := range s.messages[r.URL.Query().Get("session_id")] {
+			response.Messages = append(response.Messages, genai.MessageRecord{Message: message})
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	case r.Method == http.MethodPost && r.URL.Path == genai.MessagesEndpoint:
+		var request struct {
+			SessionID string            `json:"session_id"`
+			Messages  []json.RawMessage `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		s.messages[request.SessionID] = append(s.messages[request.SessionID], request.Messages...)
+	case r.Method == http.MethodDelete:
+		session := r.URL.Path[len(genai.SessionsEndpoint)+1:]
+		s.deleted = append(s.deleted, session)
+		delete(s.messages, session)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+var _ = Describe("Session Controller", func() {
+	ctx := context.Background()
+
+	// sessionClient lists the queries of a session by the session id index, like the manager's cache
+	var sessionClient client.Client
+
+	BeforeEach(func() {
+		sessionClient = fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).
+			WithStatusSubresource(&arkv1alpha1.Query{}, &arkv1alpha1.Session{}, &arkv1alpha1.Memory{}).
+			WithIndex(&arkv1alpha1.Query{}, querySessionIdIndexKey, indexQuerySessionId).
+			Build()
+	})
+
+	newReconciler := func() *SessionReconciler {
+		return &SessionReconciler{Client: sessionClient, Scheme: k8sClient.Scheme(), Recorder: record.NewFakeRecorder(10)}
+	}
+
+	reconcileSession := func(r *SessionReconciler, name string) reconcile.Result {
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}})
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	createQuery := func(name, sessionID string, usage arkv1alpha1.TokenUsage) *arkv1alpha1.Query {
+		query := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: arkv1alpha1.QuerySpec{
+				Input:     runtime.RawExtension{Raw: []byte(`"Hello"`)},
+				Targets:   []arkv1alpha1.QueryTarget{{Type: "agent", Name: "chat-agent"}},
+				SessionId: sessionID,
+				Memory:    &arkv1alpha1.MemoryRef{Name: "chat-memory"},
+			},
+		}
+		Expect(sessionClient.Create(ctx, query)).To(Succeed())
+
+		query.Status.Phase = statusDone
+		query.Status.TokenUsage = usage
+		Expect(sessionClient.Status().Update(ctx, query)).To(Succeed())
+		return query
+	}
+
+	createSession := func(name string, spec arkv1alpha1.SessionSpec) {
+		session := &arkv1alpha1.Session{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}, Spec: spec}
+		Expect(sessionClient.Create(ctx, session)).To(Succeed())
+	}
+
+	getSession := func(name string) *arkv1alpha1.Session {
+		session := &arkv1alpha1.Session{}
+		Expect(sessionClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, session)).To(Succeed())
+		return session
+	}
+
+	createMemory := func(server *sessionMemoryServer) {
+		httpServer := httptest.NewServer(server)
+		DeferCleanup(httpServer.Close)
+
+		memory := &arkv1alpha1.Memory{
+			ObjectMeta: metav1.ObjectMeta{Name: "chat-memory", Namespace: "default"},
+			Spec:       arkv1alpha1.MemorySpec{Address: arkv1alpha1.ValueSource{Value: httpServer.URL}},
+		}
+		Expect(sessionClient.Create(ctx, memory)).To(Succeed())
+
+		memory.Status.LastResolvedAddress = &httpServer.URL
+		Expect(sessionClient.Status().Update(ctx, memory)).To(Succeed())
+	}
+
+	It("should record the queries and token usage of the session", func() {
+		createSession("chat-usage", arkv1alpha1.SessionSpec{})
+		createQuery("chat-usage-1", "chat-usage", arkv1alpha1.TokenUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15})
+		createQuery("chat-usage-2", "chat-usage", arkv1alpha1.TokenUsage{PromptTokens: 20, CompletionTokens: 8, TotalTokens: 28})
+		createQuery("other-chat-1", "other-chat", arkv1alpha1.TokenUsage{TotalTokens: 100})
+
+		Expect(reconcileSession(newReconciler(), "chat-usage").RequeueAfter).To(BeZero())
+
+		status := getSession("chat-usage").Status
+		Expect(status.Phase).To(Equal(arkv1alpha1.SessionPhaseActive))
+		Expect(status.QueryCount).To(Equal(int32(2)))
+		Expect(status.Queries).To(Equal([]string{"chat-usage-1", "chat-usage-2"}))
+		Expect(status.TokenUsage).To(Equal(arkv1alpha1.TokenUsage{PromptTokens: 30, CompletionTokens: 13, TotalTokens: 43}))
+		Expect(status.ExpiresAt).To(BeNil())
+	})
+
+	It("should create the session of a new query that references one that does not exist", func() {
+		query := createQuery("chat-new-1", "chat-new", arkv1alpha1.TokenUsage{})
+		query.Status.Phase = statusRunning
+		Expect(sessionClient.Status().Update(ctx, query)).To(Succeed())
+
+		reconcileSession(newReconciler(), "chat-new")
+
+		Expect(getSession("chat-new").Spec.Memory).To(Equal(&arkv1alpha1.MemoryRef{Name: "chat-memory"}))
+	})
+
+	It("should not create a deleted session again for its completed queries", func() {
+		createSession("chat-deleted", arkv1alpha1.SessionSpec{})
+		createQuery("chat-deleted-1", "chat-deleted", arkv1alpha1.TokenUsage{})
+		Expect(sessionClient.Delete(ctx, getSession("chat-deleted"))).To(Succeed())
+
+		reconcileSession(newReconciler(), "chat-deleted")
+
+		err := sessionClient.Get(ctx, types.NamespacedName{Name: "chat-deleted", Namespace: "default"}, &arkv1alpha1.Session{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should only enqueue sessions whose id is a valid name", func() {
+		r := newReconciler()
+		query := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "q", Namespace: "default"}}
+
+		query.Spec.SessionId = "chat-42"
+		Expect(r.findSessionForQuery(ctx, query)).To(ConsistOf(reconcile.Request{NamespacedName: types.NamespacedName{Name: "chat-42", Namespace: "default"}}))
+		query.Spec.SessionId = "Chat 42"
+		Expect(r.findSessionForQuery(ctx, query)).To(BeEmpty())
+		query.Spec.SessionId = ""
+		Expect(r.findSessionForQuery(ctx, query)).To(BeEmpty())
+	})
+
+	It("should take the last activity from the latest query and list the most recent queries", func() {
+		start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		session := &arkv1alpha1.Session{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(start)}}
+		var queries []arkv1alpha1.Query
+		for i := range sessionRecentQueries + 2 {
+			queries = append(queries, arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{
+				Name:              string(rune('a' + i)),
+				CreationTimestamp: metav1.NewTime(start.Add(time.Duration(i) * time.Minute)),
+			}})
+		}
+
+		status := &arkv1alpha1.SessionStatus{}
+		summarizeSessionQueries(status, session, queries)
+
+		Expect(status.QueryCount).To(Equal(int32(sessionRecentQueries + 2)))
+		Expect(status.Queries).To(HaveLen(sessionRecentQueries))
+		Expect(status.Queries[0]).To(Equal("c"))
+		Expect(status.LastActivityTime.Time).To(Equal(start.Add(time.Duration(sessionRecentQueries+1) * time.Minute)))
+	})
+
+	It("should delete the messages of a session once its ttl has passed", func() {
+		server := &sessionMemoryServer{messages: map[string][]json.RawMessage{"chat-expired": {json.RawMessage(`{"role":"user","content":"Hi"}`)}}}
+		createMemory(server)
+		r := newReconciler()
+		session := &arkv1alpha1.Session{
+			ObjectMeta: metav1.ObjectMeta{Name: "chat-expired", Namespace: "default"},
+			Spec:       arkv1alpha1.SessionSpec{Memory: &arkv1alpha1.MemoryRef{Name: "chat-memory"}, TTL: &metav1.Duration{Duration: time.Hour}},
+		}
+
+		By("keeping the session active until its ttl has passed")
+		status := &arkv1alpha1.SessionStatus{LastActivityTime: &metav1.Time{Time: time.Now().Add(-30 * time.Minute)}}
+		requeueAfter, err := r.expire(ctx, session, status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Phase).To(Equal(arkv1alpha1.SessionPhaseActive))
+		Expect(requeueAfter).To(BeNumerically("~", 30*time.Minute, time.Minute))
+		Expect(server.deleted).To(BeEmpty())
+
+		By("deleting its messages when it expires")
+		status.LastActivityTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+		requeueAfter, err = r.expire(ctx, session, status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requeueAfter).To(BeZero())
+		Expect(status.Phase).To(Equal(arkv1alpha1.SessionPhaseExpired))
+		Expect(server.deleted).To(Equal([]string{"chat-expired"}))
+
+		By("deleting them only once")
+		session.Status = *status.DeepCopy()
+		_, err = r.expire(ctx, session, status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(server.deleted).To(HaveLen(1))
+
+		By("becoming active again with a new query")
+		status.LastActivityTime = &metav1.Time{Time: time.Now()}
+		_, err = r.expire(ctx, session, status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Phase).To(Equal(arkv1alpha1.SessionPhaseActive))
+	})
+
+	It("should copy the messages of the forked session once", func() {
+		server := &sessionMemoryServer{messages: map[string][]json.RawMessage{"chat-original": {
+			json.RawMessage(`{"role":"user","content":"Plan a trip"}`),
+			json.RawMessage(`{"role":"assistant","content":"Where to?"}`),
+		}}}
+		createMemory(server)
+		createSession("chat-fork", arkv1alpha1.SessionSpec{Memory: &arkv1alpha1.MemoryRef{Name: "chat-memory"}, ForkFrom: "chat-original"})
+		r := newReconciler()
+
+		reconcileSession(r, "chat-fork")
+		reconcileSession(r, "chat-fork")
+
+		status := getSession("chat-fork").Status
+		Expect(status.Phase).To(Equal(arkv1alpha1.SessionPhaseActive))
+		Expect(status.ForkedFrom).To(Equal("chat-original"))
+		Expect(status.ForkedMessages).To(Equal(int32(2)))
+		Expect(server.messages["chat-fork"]).To(HaveLen(2))
+		Expect(server.messages["chat-original"]).To(HaveLen(2))
+	})
+
+	It("should report a fork without memory as an error", func() {
+		createSession("chat-fork-nomemory", arkv1alpha1.SessionSpec{ForkFrom: "chat-original"})
+
+		_, err := newReconciler().Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "chat-fork-nomemory", Namespace: "default"}})
+		Expect(err).To(HaveOccurred())
+
+		session := getSession("chat-fork-nomemory")
+		Expect(session.Status.Phase).To(Equal(arkv1alpha1.SessionPhaseError))
+		Expect(session.Status.Message).To(ContainSubstring("memory default/default not found"))
+		Expect(session.Status.ForkedFrom).To(BeEmpty())
+	})
+})
@@ -4,13 +4,34 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+const (
+	// Condition types
+	TeamReady = "Ready"
+
+	// Roles of the agents a team calls without them being members
+	teamRoleSelector   = "selector"
+	teamRoleAggregator = "aggregator"
 )
 
 type TeamReconciler struct {
@@ -21,12 +42,234 @@ type TeamReconciler struct {
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=teams,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=teams/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=teams/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=agents,verbs=get;list;watch
 
 func (r *TeamReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = logf.FromContext(ctx)
+	log := logf.FromContext(ctx)
+
+	var team arkv1alpha1.Team
+	if err := r.Get(ctx, req.NamespacedName, &team); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("Team resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get Team")
+		return ctrl.Result{}, err
+	}
+
+	members, reason, message, err := r.checkMembers(ctx, &team)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	status := metav1.ConditionTrue
+	if reason != "Ready" {
+		status = metav1.ConditionFalse
+	}
+
+	current := meta.FindStatusCondition(team.Status.Conditions, TeamReady)
+	if current != nil && current.Status == status && current.Reason == reason && current.Message == message &&
+		current.ObservedGeneration == team.Generation && equality.Semantic.DeepEqual(team.Status.Members, members) {
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("team status changed", "team", team.Name, "ready", status, "reason", reason)
+	team.Status.Members = members
+	meta.SetStatusCondition(&team.Status.Conditions, metav1.Condition{
+		Type:               TeamReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: team.Generation,
+	})
+	if err := r.Status().Update(ctx, &team); err != nil {
+		log.Error(err, "failed to update team status")
+		return ctrl.Result{}, err
+	}
 	return ctrl.Result{}, nil
 }
 
+// checkMembers reports the readiness of the members of a team, including the agents matching its member selector,
+// and of its selector and aggregator agents. The team is ready when all of them are; otherwise the reason and the
+// message of its Ready condition name the members that are not.
+func (r *TeamReconciler) checkMembers(ctx context.Context, team *arkv1alpha1.Team) ([]arkv1alpha1.TeamMemberStatus, string, string, error) {
+	memberSpecs, err := genai.ResolveTeamMemberSpecs(ctx, r.Client, team)
+	if err != nil {
+		if team.Spec.MemberSelector != nil && len(team.Spec.Members) == 0 {
+			return nil, "NoMembers", err.Error(), nil
+		}
+		return nil, "", "", err
+	}
+
+	var members []arkv1alpha1.TeamMemberStatus
+	for _, memberSpec := range memberSpecs {
+		member, err := r.checkMember(ctx, team, memberSpec.Name, memberSpec.Type)
+		if err != nil {
+			return nil, "", "", err
+		}
+		members = append(members, member)
+	}
+	if team.Spec.Selector != nil && team.Spec.Selector.Agent != "" {
+		member, err := r.checkMember(ctx, team, team.Spec.Selector.Agent, "agent")
+		if err != nil {
+			return nil, "", "", err
+		}
+		member.Role = teamRoleSelector
+		members = append(members, member)
+	}
+	if team.Spec.Aggregator != nil {
+		member, err := r.checkMember(ctx, team, team.Spec.Aggregator.Agent, "agent")
+		if err != nil {
+			return nil, "", "", err
+		}
+		member.Role = teamRoleAggregator
+		members = append(members, member)
+	}
+
+	if len(members) == 0 {
+		return nil, "NoMembers", fmt.Sprintf("Team '%s' has no members", team.Name), nil
+	}
+
+	var failures []string
+	for _, member := range members {
+		if !member.Ready {
+			failures = append(failures, member.Message)
+		}
+	}
+	if len(failures) > 0 {
+		return members, "MembersNotReady", strings.Join(failures, "; "), nil
+	}
+	return members, "Ready", "All members are ready", nil
+}
+
+// checkMember reports whether an agent or team the team calls exists and is ready itself
+func (r *TeamReconciler) checkMember(ctx context.Context, team *arkv1alpha1.Team, name, memberType string) (arkv1alpha1.TeamMemberStatus, error) {
+	member := arkv1alpha1.TeamMemberStatus{Name: name, Type: memberType}
+	key := types.NamespacedName{Name: name, Namespace: team.Namespace}
+
+	var obj client.Object
+	var conditions *[]metav1.Condition
+	var conditionType string
+	switch memberType {
+	case "agent":
+		agent := &arkv1alpha1.Agent{}
+		obj, conditions, conditionType = agent, &agent.Status.Conditions, AgentAvailable
+	case "team":
+		if name == team.Name {
+			member.Message = fmt.Sprintf("Team '%s' cannot be a member of itself", name)
+			return member, nil
+		}
+		nested := &arkv1alpha1.Team{}
+		obj, conditions, conditionType = nested, &nested.Status.Conditions, TeamReady
+	default:
+		member.Message = fmt.Sprintf("Member '%s' has unsupported type '%s'", name, memberType)
+		return member, nil
+	}
+
+	if err := r.Get(ctx, key, obj); err != nil {
+		if errors.IsNotFound(err) {
+			member.Message = fmt.Sprintf("%s '%s' not found in namespace '%s'", memberKind(memberType), name, team.Namespace)
+			return member, nil
+		}
+		return member, err
+	}
+
+	condition := meta.FindStatusCondition(*conditions, conditionType)
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		member.Message = fmt.Sprintf("%s '%s' is not ready", memberKind(memberType), name)
+		if condition != nil && condition.Message != "" {
+			member.Message = fmt.Sprintf("%s: %s", member.Message, condition.Message)
+		}
+		return member, nil
+	}
+
+	member.Ready = true
+	return member, nil
+}
+
+// memberKind returns the kind of a member type for messages
+func memberKind(memberType string) string {
+	if memberType == "team" {
+		return "Team"
+	}
+	return "Agent"
+}
+
 func (r *TeamReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).For(&arkv1alpha1.Team{}).Named("team").Complete(r)
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arkv1alpha1.Team{}).
+		// Watch for Agent events and reconcile the teams the agents are, or may become, members of
+		Watches(
+			&arkv1alpha1.Agent{},
+			handler.EnqueueRequestsFromMapFunc(r.findTeamsForAgent),
+		).
+		// Watch for Team events and reconcile the teams they are members of
+		Watches(
+			&arkv1alpha1.Team{},
+			handler.EnqueueRequestsFromMapFunc(r.findTeamsForTeam),
+		).
+		Named("team").
+		Complete(r)
+}
+
+// findTeamsForAgent finds the teams that call the given agent, or whose member selector matches it
+func (r *TeamReconciler) findTeamsForAgent(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.findTeamsForDependency(ctx, obj.GetNamespace(), func(team *arkv1alpha1.Team) bool {
+		if teamHasMember(team, obj.GetName(), "agent") {
+			return true
+		}
+		if team.Spec.Selector != nil && team.Spec.Selector.Agent == obj.GetName() {
+			return true
+		}
+		if team.Spec.Aggregator != nil && team.Spec.Aggregator.Agent == obj.GetName() {
+			return true
+		}
+		// The status lists the agents that matched the member selector, so the team drops an agent that stops matching
+		if teamStatusHasMember(team, obj.GetName(), "agent") {
+			return true
+		}
+		if team.Spec.MemberSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(team.Spec.MemberSelector)
+			return err == nil && selector.Matches(labels.Set(obj.GetLabels()))
+		}
+		return false
+	})
+}
+
+// findTeamsForTeam finds the teams that have the given team as a member
+func (r *TeamReconciler) findTeamsForTeam(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.findTeamsForDependency(ctx, obj.GetNamespace(), func(team *arkv1alpha1.Team) bool {
+		return team.Name != obj.GetName() && (teamHasMember(team, obj.GetName(), "team") || teamStatusHasMember(team, obj.GetName(), "team"))
+	})
+}
+
+// findTeamsForDependency lists the teams of the namespace that depend on a resource
+func (r *TeamReconciler) findTeamsForDependency(ctx context.Context, namespace string, dependencyCheck func(*arkv1alpha1.Team) bool) []reconcile.Request {
+	var teamList arkv1alpha1.TeamList
+	if err := r.List(ctx, &teamList, client.InNamespace(namespace)); err != nil {
+		logf.Log.WithName("team-controller").Error(err, "Failed to list teams for dependency check", "namespace", namespace)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range teamList.Items {
+		if dependencyCheck(&teamList.Items[i]) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&teamList.Items[i])})
+		}
+	}
+	return requests
+}
+
+// teamHasMember reports whether a team lists the member
+func teamHasMember(team *arkv1alpha1.Team, name, memberType string) bool {
+	return slices.ContainsFunc(team.Spec.Members, func(member arkv1alpha1.TeamMember) bool {
+		return member.Name == name && member.Type == memberType
+	})
+}
+
+// teamStatusHasMember reports whether the status of a team reports on the member
+func teamStatusHasMember(team *arkv1alpha1.Team, name, memberType string) bool {
+	return slices.ContainsFunc(team.Status.Members, func(member arkv1alpha1.TeamMemberStatus) bool {
+		return member.Name == name && member.Type == memberType
+	})
 }
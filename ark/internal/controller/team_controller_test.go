@@ -8,7 +8,9 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -72,4 +74,115 @@ var _ = Describe("Team Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+
+	Context("When checking the members of a team", func() {
+		ctx := context.Background()
+
+		newReconciler := func() *TeamReconciler {
+			return &TeamReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		}
+
+		createAgent := func(name string, agentLabels map[string]string, available metav1.ConditionStatus) {
+			agent := &arkv1alpha1.Agent{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: agentLabels},
+				Spec:       arkv1alpha1.AgentSpec{Prompt: "test prompt"},
+			}
+			Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, agent)
+			meta.SetStatusCondition(&agent.Status.Conditions, metav1.Condition{Type: AgentAvailable, Status: available, Reason: "Test", Message: "model unavailable"})
+			Expect(k8sClient.Status().Update(ctx, agent)).To(Succeed())
+		}
+
+		reconcileTeam := func(name string, spec arkv1alpha1.TeamSpec) *arkv1alpha1.Team {
+			team := &arkv1alpha1.Team{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}, Spec: spec}
+			Expect(k8sClient.Create(ctx, team)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, team)
+
+			_, err := newReconciler().Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(team)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(team), team)).To(Succeed())
+			return team
+		}
+
+		It("should be ready when all members and the selector agent are", func() {
+			createAgent("ready-writer", nil, metav1.ConditionTrue)
+			createAgent("ready-selector", nil, metav1.ConditionTrue)
+			nested := reconcileTeam("ready-nested", arkv1alpha1.TeamSpec{
+				Strategy: "sequential",
+				Members:  []arkv1alpha1.TeamMember{{Name: "ready-writer", Type: "agent"}},
+			})
+			Expect(meta.IsStatusConditionTrue(nested.Status.Conditions, TeamReady)).To(BeTrue())
+
+			team := reconcileTeam("ready-team", arkv1alpha1.TeamSpec{
+				Strategy: "selector",
+				Members:  []arkv1alpha1.TeamMember{{Name: "ready-writer", Type: "agent"}, {Name: "ready-nested", Type: "team"}},
+				Selector: &arkv1alpha1.TeamSelectorSpec{Agent: "ready-selector"},
+			})
+			condition := meta.FindStatusCondition(team.Status.Conditions, TeamReady)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("Ready"))
+			Expect(team.Status.Members).To(HaveLen(3))
+			Expect(team.Status.Members[2]).To(Equal(arkv1alpha1.TeamMemberStatus{Name: "ready-selector", Type: "agent", Role: "selector", Ready: true}))
+		})
+
+		It("should report the members that are not ready", func() {
+			createAgent("unavailable-writer", nil, metav1.ConditionFalse)
+			team := reconcileTeam("unready-team", arkv1alpha1.TeamSpec{
+				Strategy: "sequential",
+				Members: []arkv1alpha1.TeamMember{
+					{Name: "unavailable-writer", Type: "agent"},
+					{Name: "missing-team", Type: "team"},
+				},
+				Selector: &arkv1alpha1.TeamSelectorSpec{Agent: "missing-selector"},
+			})
+
+			condition := meta.FindStatusCondition(team.Status.Conditions, TeamReady)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("MembersNotReady"))
+			Expect(condition.Message).To(Equal("Agent 'unavailable-writer' is not ready: model unavailable; " +
+				"Team 'missing-team' not found in namespace 'default'; Agent 'missing-selector' not found in namespace 'default'"))
+			Expect(team.Status.Members).To(HaveLen(3))
+			Expect(team.Status.Members[0].Ready).To(BeFalse())
+		})
+
+		It("should check the agents matching the member selector", func() {
+			createAgent("selected-analyst", map[string]string{"team": "research"}, metav1.ConditionTrue)
+			team := reconcileTeam("selected-team", arkv1alpha1.TeamSpec{
+				Strategy:       "sequential",
+				MemberSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "research"}},
+			})
+			Expect(meta.IsStatusConditionTrue(team.Status.Conditions, TeamReady)).To(BeTrue())
+			Expect(team.Status.Members).To(ConsistOf(arkv1alpha1.TeamMemberStatus{Name: "selected-analyst", Type: "agent", Ready: true}))
+
+			empty := reconcileTeam("empty-team", arkv1alpha1.TeamSpec{
+				Strategy:       "sequential",
+				MemberSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "sales"}},
+			})
+			condition := meta.FindStatusCondition(empty.Status.Conditions, TeamReady)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("NoMembers"))
+		})
+
+		It("should reconcile the teams calling an agent", func() {
+			team := &arkv1alpha1.Team{
+				ObjectMeta: metav1.ObjectMeta{Name: "mapped-team", Namespace: "default"},
+				Spec: arkv1alpha1.TeamSpec{
+					Strategy:   "sequential",
+					Members:    []arkv1alpha1.TeamMember{{Name: "mapped-writer", Type: "agent"}},
+					Aggregator: &arkv1alpha1.TeamAggregatorSpec{Agent: "mapped-aggregator"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, team)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, team)
+
+			agent := func(name string) *arkv1alpha1.Agent {
+				return &arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+			}
+			Expect(newReconciler().findTeamsForAgent(ctx, agent("mapped-writer"))).To(HaveLen(1))
+			Expect(newReconciler().findTeamsForAgent(ctx, agent("mapped-aggregator"))).To(HaveLen(1))
+			Expect(newReconciler().findTeamsForAgent(ctx, agent("unrelated-agent"))).To(BeEmpty())
+		})
+	})
 })
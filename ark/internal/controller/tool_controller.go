@@ -11,16 +11,20 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
 )
 
 type ToolReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	resolver *common.ValueSourceResolver
 }
 
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=tools,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=tools/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=tools/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
 func (r *ToolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	tool := &arkv1alpha1.Tool{}
@@ -28,6 +32,10 @@ func (r *ToolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if tool.Spec.OpenAPI != nil {
+		return r.reconcileOpenAPI(ctx, tool)
+	}
+
 	if tool.Status.State == arkv1alpha1.ToolStateReady {
 		return ctrl.Result{}, nil
 	}
@@ -46,6 +54,27 @@ func (r *ToolReconciler) updateToolStatus(ctx context.Context, tool *arkv1alpha1
 	return ctrl.Result{}, nil
 }
 
+// setToolStatus updates the status when it changed, so that tools reconciled on every change do not
+// reconcile again because of their own status update
+func (r *ToolReconciler) setToolStatus(ctx context.Context, tool *arkv1alpha1.Tool, state, message string) (ctrl.Result, error) {
+	if tool.Status.State == state && tool.Status.Message == message {
+		return ctrl.Result{}, nil
+	}
+	return r.updateToolStatus(ctx, tool, state, message)
+}
+
+func (r *ToolReconciler) getResolver() *common.ValueSourceResolver {
+	if r.resolver == nil {
+		r.resolver = common.NewValueSourceResolver(r.Client)
+	}
+	return r.resolver
+}
+
 func (r *ToolReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).For(&arkv1alpha1.Tool{}).Named("tool").Complete(r)
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arkv1alpha1.Tool{}).
+		// Tools generated from an OpenAPI document are restored when they are changed or deleted
+		Owns(&arkv1alpha1.Tool{}).
+		Named("tool").
+		Complete(r)
 }
@@ -9,11 +9,13 @@ import (
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/labels"
 )
 
 var _ = Describe("Tool Controller", func() {
@@ -78,4 +80,113 @@ var _ = Describe("Tool Controller", func() {
 			Expect(updatedTool.Status.Message).To(Equal("Tool configuration is valid"))
 		})
 	})
+
+	Context("When importing an OpenAPI document", func() {
+		const document = `
+openapi: 3.0.3
+servers:
+  - url: https://petstore.example.com/v1
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List the pets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+`
+		ctx := context.Background()
+		typeNamespacedName := types.NamespacedName{Name: "petstore", Namespace: "default"}
+
+		var reconciler *ToolReconciler
+		BeforeEach(func() {
+			reconciler = &ToolReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			Expect(k8sClient.Create(ctx, &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: typeNamespacedName.Name, Namespace: typeNamespacedName.Namespace},
+				Spec: arkv1alpha1.ToolSpec{
+					Type: "http",
+					OpenAPI: &arkv1alpha1.OpenAPISpec{
+						Spec: &arkv1alpha1.ValueSource{Value: document},
+						Headers: []arkv1alpha1.Header{
+							{Name: "Authorization", Value: arkv1alpha1.HeaderValue{Value: "Bearer token"}},
+						},
+					},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.DeleteAllOf(ctx, &arkv1alpha1.Tool{}, client.InNamespace("default"),
+				client.MatchingLabels{labels.OpenAPIToolLabel: typeNamespacedName.Name})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: typeNamespacedName.Name, Namespace: typeNamespacedName.Namespace},
+			})).To(Succeed())
+		})
+
+		It("should generate a tool per operation", func() {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			source := &arkv1alpha1.Tool{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, source)).To(Succeed())
+			Expect(source.Status.State).To(Equal(arkv1alpha1.ToolStateReady))
+			Expect(source.Status.Message).To(Equal("Generated 2 tools from the OpenAPI document"))
+
+			listPets := &arkv1alpha1.Tool{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "petstore-list-pets", Namespace: "default"}, listPets)).To(Succeed())
+			Expect(listPets.Spec.Type).To(Equal("http"))
+			Expect(listPets.Spec.Description).To(Equal("List the pets"))
+			Expect(listPets.Spec.HTTP.URL).To(Equal("https://petstore.example.com/v1/pets?limit={limit}"))
+			Expect(listPets.Spec.HTTP.Headers).To(HaveLen(1))
+			Expect(listPets.Spec.InputSchema.Raw).To(MatchJSON(`{"type": "object", "properties": {"limit": {"type": "integer"}}}`))
+			Expect(metav1.IsControlledBy(listPets, source)).To(BeTrue())
+
+			createPet := &arkv1alpha1.Tool{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "petstore-create-pet", Namespace: "default"}, createPet)).To(Succeed())
+			Expect(createPet.Spec.HTTP.Method).To(Equal("POST"))
+		})
+
+		It("should delete the tools of operations that are no longer imported", func() {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			source := &arkv1alpha1.Tool{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, source)).To(Succeed())
+			source.Spec.OpenAPI.Operations = []string{"listPets"}
+			Expect(k8sClient.Update(ctx, source)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			var generated arkv1alpha1.ToolList
+			Expect(k8sClient.List(ctx, &generated, client.InNamespace("default"),
+				client.MatchingLabels{labels.OpenAPIToolLabel: typeNamespacedName.Name})).To(Succeed())
+			Expect(generated.Items).To(HaveLen(1))
+			Expect(generated.Items[0].Name).To(Equal("petstore-list-pets"))
+		})
+
+		It("should report documents that cannot be imported", func() {
+			source := &arkv1alpha1.Tool{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, source)).To(Succeed())
+			source.Spec.OpenAPI.Operations = []string{"deletePet"}
+			Expect(k8sClient.Update(ctx, source)).To(Succeed())
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(openAPIRetryInterval))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, source)).To(Succeed())
+			Expect(source.Status.State).To(Equal(arkv1alpha1.ToolStateError))
+			Expect(source.Status.Message).To(ContainSubstring("operation deletePet is not in the document"))
+		})
+	})
 })
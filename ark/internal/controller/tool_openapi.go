@@ -0,0 +1,243 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/common"
+	"mckinsey.com/ark/internal/genai"
+	"mckinsey.com/ark/internal/labels"
+)
+
+const (
+	// openAPIFetchTimeout bounds the download of an OpenAPI document from its URL
+	openAPIFetchTimeout = 30 * time.Second
+	// maxOpenAPIDocumentSize bounds the size of an OpenAPI document downloaded from its URL
+	maxOpenAPIDocumentSize = 10 << 20
+	// openAPIRetryInterval is how long to wait before importing a document that could not be imported
+	openAPIRetryInterval = time.Minute
+)
+
+var (
+	camelCaseBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+	invalidNameChars  = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// reconcileOpenAPI generates an http tool for each operation of the tool's OpenAPI document, and deletes
+// the tools of operations that are no longer imported
+func (r *ToolReconciler) reconcileOpenAPI(ctx context.Context, tool *arkv1alpha1.Tool) (ctrl.Result, error) {
+	count, err := r.importOpenAPI(ctx, tool)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to import OpenAPI document", "tool", tool.Name)
+		if _, err := r.setToolStatus(ctx, tool, arkv1alpha1.ToolStateError, fmt.Sprintf("Failed to import OpenAPI document: %v", err)); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: openAPIRetryInterval}, nil
+	}
+	return r.setToolStatus(ctx, tool, arkv1alpha1.ToolStateReady, fmt.Sprintf("Generated %d tools from the OpenAPI document", count))
+}
+
+func (r *ToolReconciler) importOpenAPI(ctx context.Context, tool *arkv1alpha1.Tool) (int, error) {
+	spec := tool.Spec.OpenAPI
+	data, err := r.loadOpenAPIDocument(ctx, tool)
+	if err != nil {
+		return 0, err
+	}
+	doc, err := genai.ParseOpenAPI(data)
+	if err != nil {
+		return 0, err
+	}
+
+	baseURL, err := openAPIBaseURL(spec, doc)
+	if err != nil {
+		return 0, err
+	}
+
+	operations := doc.Operations
+	if len(spec.Operations) > 0 {
+		operations = nil
+		for _, id := range spec.Operations {
+			index := slices.IndexFunc(doc.Operations, func(op genai.OpenAPIOperation) bool { return op.ID == id })
+			if index < 0 {
+				return 0, fmt.Errorf("operation %s is not in the document", id)
+			}
+			operations = append(operations, doc.Operations[index])
+		}
+	}
+
+	var existing arkv1alpha1.ToolList
+	if err := r.List(ctx, &existing, client.InNamespace(tool.Namespace), client.MatchingLabels{labels.OpenAPIToolLabel: tool.Name}); err != nil {
+		return 0, fmt.Errorf("failed to list generated tools: %w", err)
+	}
+
+	generated := make(map[string]bool, len(operations))
+	for _, op := range operations {
+		operationTool, err := r.buildOperationTool(tool, op, baseURL)
+		if err != nil {
+			return 0, err
+		}
+		if generated[operationTool.Name] {
+			return 0, fmt.Errorf("several operations generate the tool %s", operationTool.Name)
+		}
+		generated[operationTool.Name] = true
+		if err := r.createOrUpdateOperationTool(ctx, operationTool); err != nil {
+			return 0, err
+		}
+	}
+
+	for i := range existing.Items {
+		stale := &existing.Items[i]
+		if generated[stale.Name] {
+			continue
+		}
+		if err := r.Delete(ctx, stale); client.IgnoreNotFound(err) != nil {
+			return 0, fmt.Errorf("failed to delete tool %s: %w", stale.Name, err)
+		}
+		logf.FromContext(ctx).Info("openapi tool deleted", "tool", stale.Name, "source", tool.Name)
+	}
+	return len(operations), nil
+}
+
+// loadOpenAPIDocument reads the document from the tool's spec, or downloads it from its URL
+func (r *ToolReconciler) loadOpenAPIDocument(ctx context.Context, tool *arkv1alpha1.Tool) ([]byte, error) {
+	spec := tool.Spec.OpenAPI
+	if spec.Spec != nil {
+		document, err := r.getResolver().ResolveValueSource(ctx, *spec.Spec, tool.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve OpenAPI document: %w", err)
+		}
+		return []byte(document), nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, openAPIFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, spec.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document URL: %w", err)
+	}
+	resp, err := common.NewHTTPClientWithLogging(ctx, common.HTTPClientTool).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OpenAPI document: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("failed to fetch OpenAPI document: HTTP error %d: %s", resp.StatusCode, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxOpenAPIDocumentSize))
+}
+
+// openAPIBaseURL returns the URL that operation paths are relative to. A relative server URL is
+// resolved against the URL the document was fetched from.
+func openAPIBaseURL(spec *arkv1alpha1.OpenAPISpec, doc *genai.OpenAPIDocument) (string, error) {
+	if spec.BaseURL != "" {
+		return spec.BaseURL, nil
+	}
+	server, err := url.Parse(doc.ServerURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid server URL %q: %w", doc.ServerURL, err)
+	}
+	if !server.IsAbs() && spec.URL != "" {
+		documentURL, err := url.Parse(spec.URL)
+		if err != nil {
+			return "", fmt.Errorf("invalid OpenAPI document URL: %w", err)
+		}
+		server = documentURL.ResolveReference(server)
+	}
+	if server.Scheme != "http" && server.Scheme != "https" {
+		return "", fmt.Errorf("the document has no absolute server URL: set baseURL")
+	}
+	return server.String(), nil
+}
+
+func (r *ToolReconciler) buildOperationTool(tool *arkv1alpha1.Tool, op genai.OpenAPIOperation, baseURL string) (*arkv1alpha1.Tool, error) {
+	inputSchema, err := json.Marshal(op.InputSchema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input schema of operation %s: %w", op.ID, err)
+	}
+
+	// Inherit ark.mckinsey.com annotations, as tools generated from MCP servers do
+	toolAnnotations := make(map[string]string)
+	for key, value := range tool.Annotations {
+		if strings.HasPrefix(key, annotations.ARKPrefix) {
+			toolAnnotations[key] = value
+		}
+	}
+
+	httpSpec := op.HTTPSpec(baseURL, tool.Spec.OpenAPI.Headers, tool.Spec.OpenAPI.Timeout)
+	operationTool := &arkv1alpha1.Tool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        operationToolName(tool.Name, op.ID),
+			Namespace:   tool.Namespace,
+			Labels:      map[string]string{labels.OpenAPIToolLabel: tool.Name},
+			Annotations: toolAnnotations,
+		},
+		Spec: arkv1alpha1.ToolSpec{
+			Type:        genai.ToolTypeHTTP,
+			Description: op.Description,
+			InputSchema: &runtime.RawExtension{Raw: inputSchema},
+			Annotations: tool.Spec.Annotations,
+			HTTP:        &httpSpec,
+		},
+	}
+	if err := controllerutil.SetControllerReference(tool, operationTool, r.Scheme); err != nil {
+		return nil, err
+	}
+	return operationTool, nil
+}
+
+func (r *ToolReconciler) createOrUpdateOperationTool(ctx context.Context, operationTool *arkv1alpha1.Tool) error {
+	existing := &arkv1alpha1.Tool{}
+	err := r.Get(ctx, client.ObjectKeyFromObject(operationTool), existing)
+	if errors.IsNotFound(err) {
+		if err := r.Create(ctx, operationTool); err != nil {
+			return fmt.Errorf("failed to create tool %s: %w", operationTool.Name, err)
+		}
+		logf.FromContext(ctx).Info("openapi tool created", "tool", operationTool.Name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get tool %s: %w", operationTool.Name, err)
+	}
+	if existing.Labels[labels.OpenAPIToolLabel] != operationTool.Labels[labels.OpenAPIToolLabel] {
+		return fmt.Errorf("tool %s already exists and was not generated from this document", operationTool.Name)
+	}
+
+	existing.Labels = operationTool.Labels
+	existing.Annotations = operationTool.Annotations
+	existing.OwnerReferences = operationTool.OwnerReferences
+	existing.Spec = operationTool.Spec
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update tool %s: %w", operationTool.Name, err)
+	}
+	return nil
+}
+
+// operationToolName names the tool of an operation after the importing tool and the operation ID, following
+// the Kubernetes naming rules: listPetsByOwner becomes <tool>-list-pets-by-owner
+func operationToolName(toolName, operationID string) string {
+	name := camelCaseBoundary.ReplaceAllString(operationID, "$1-$2")
+	name = invalidNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	return fmt.Sprintf("%s-%s", toolName, strings.Trim(name, "-"))
+}
@@ -0,0 +1,144 @@
+/* Copyright 2025. McKinsey & Company */
+
+// Package executor is the gRPC service the controller dispatches queries over to the executor pods of the
+// namespaces that isolate query execution. Messages are encoded as JSON, so the service has no generated code.
+package executor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/peer"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ServiceName is the full name of the gRPC service of executor pods
+	ServiceName = "ark.executor.v1.QueryExecutor"
+	// DefaultPort is the port executor pods serve on
+	DefaultPort = 9090
+
+	codecName = "json"
+)
+
+// Runner executes the queries dispatched to an executor pod
+type Runner interface {
+	// ExecuteQuery starts executing a running query. It does nothing when the query is already executing.
+	ExecuteQuery(ctx context.Context, query types.NamespacedName) error
+	// CancelQuery stops executing a query
+	CancelQuery(ctx context.Context, query types.NamespacedName) error
+}
+
+// QueryRequest identifies the query of a call
+type QueryRequest struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// QueryResponse is the empty response of a call
+type QueryResponse struct{}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                       { return codecName }
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Runner)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "ExecuteQuery", Handler: methodHandler("ExecuteQuery", Runner.ExecuteQuery)},
+		{MethodName: "CancelQuery", Handler: methodHandler("CancelQuery", Runner.CancelQuery)},
+	},
+	Metadata: "executor.go",
+}
+
+func methodHandler(method string, call func(Runner, context.Context, types.NamespacedName) error) grpc.MethodHandler {
+	return func(srv any, ctx context.Context, decode func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		request := &QueryRequest{}
+		if err := decode(request); err != nil {
+			return nil, err
+		}
+		invoke := func(ctx context.Context, req any) (any, error) {
+			query := req.(*QueryRequest)
+			return &QueryResponse{}, call(srv.(Runner), ctx, types.NamespacedName{Namespace: query.Namespace, Name: query.Name})
+		}
+		if interceptor == nil {
+			return invoke(ctx, request)
+		}
+		return interceptor(ctx, request, &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod(method)}, invoke)
+	}
+}
+
+func fullMethod(method string) string {
+	return "/" + ServiceName + "/" + method
+}
+
+// NewServer returns a gRPC server serving the runner over mutual TLS. The runner authorizes the caller with
+// CallerCertificate.
+func NewServer(runner Runner, tlsConfig *tls.Config, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(append([]grpc.ServerOption{grpc.Creds(credentials.NewTLS(tlsConfig))}, opts...)...)
+	server.RegisterService(&serviceDesc, runner)
+	return server
+}
+
+// CallerCertificate returns the client certificate the caller of a call presented, or nil when the call is not
+// over mutual TLS
+func CallerCertificate(ctx context.Context) *x509.Certificate {
+	caller, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := caller.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return nil
+	}
+	return info.State.PeerCertificates[0]
+}
+
+// Client calls the executor pod at an address. It is a Runner, so the controller executes queries through it as
+// it would in process.
+type Client struct {
+	conn *grpc.ClientConn
+}
+
+// NewClient returns a client of the executor pod at the address, which connects over mutual TLS and presents the
+// client certificate of the TLS configuration
+func NewClient(address string, tlsConfig *tls.Config, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(codecName)),
+	}, opts...)
+	conn, err := grpc.NewClient(address, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn}, nil
+}
+
+func (c *Client) ExecuteQuery(ctx context.Context, query types.NamespacedName) error {
+	return c.invoke(ctx, "ExecuteQuery", query)
+}
+
+func (c *Client) CancelQuery(ctx context.Context, query types.NamespacedName) error {
+	return c.invoke(ctx, "CancelQuery", query)
+}
+
+func (c *Client) invoke(ctx context.Context, method string, query types.NamespacedName) error {
+	return c.conn.Invoke(ctx, fullMethod(method), &QueryRequest{Namespace: query.Namespace, Name: query.Name}, &QueryResponse{})
+}
+
+// Close closes the connection to the executor pod
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,159 @@
+/* Copyright 2025. McKinsey & Company */
+
+package executor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"k8s.io/apimachinery/pkg/types"
+
+	"mckinsey.com/ark/internal/common"
+)
+
+const controllerID = "spiffe://example.org/ns/ark-system/sa/ark-controller"
+
+type recordingRunner struct {
+	mu       sync.Mutex
+	executed []types.NamespacedName
+	canceled []types.NamespacedName
+}
+
+func (r *recordingRunner) ExecuteQuery(ctx context.Context, query types.NamespacedName) error {
+	if caller := CallerCertificate(ctx); caller == nil || !common.HasSPIFFEID(caller, controllerID) {
+		return status.Error(codes.PermissionDenied, "caller is not the controller")
+	}
+	if query.Namespace != "team-a" {
+		return status.Errorf(codes.PermissionDenied, "executor of namespace team-a cannot execute queries of %s", query.Namespace)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.executed = append(r.executed, query)
+	return nil
+}
+
+func (r *recordingRunner) CancelQuery(_ context.Context, query types.NamespacedName) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.canceled = append(r.canceled, query)
+	return nil
+}
+
+// writeIdentity issues a workload certificate with the SPIFFE ID and writes it to a workload identity directory
+func writeIdentity(t *testing.T, caCertificate *x509.Certificate, caKey *ecdsa.PrivateKey, spiffeID string) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _ := url.Parse(spiffeID)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		URIs:         []*url.URL{id},
+	}, caCertificate, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	dir := t.TempDir()
+	for name, data := range map[string][]byte{
+		"tls.crt": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		"tls.key": pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		"ca.crt":  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCertificate.Raw}),
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestClientCallsRunner(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCertificate, _ := x509.ParseCertificate(caDER)
+
+	listener := bufconn.Listen(1 << 20)
+	runner := &recordingRunner{}
+	server := NewServer(runner, common.NewWorkloadServerTLSConfig(writeIdentity(t, caCertificate, caKey, "spiffe://example.org/ns/team-a/sa/default")))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	dial := grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	})
+	newClient := func(identity, serverID string) *Client {
+		config := &common.ClientTLSConfig{WorkloadIdentity: identity, ServerSPIFFEID: serverID}
+		client, err := NewClient("passthrough:///executor", config.TLSConfig(), dial)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = client.Close() })
+		return client
+	}
+	client := newClient(writeIdentity(t, caCertificate, caKey, controllerID), "spiffe://example.org/ns/team-a/sa/default")
+
+	query := types.NamespacedName{Namespace: "team-a", Name: "weather"}
+	if err := client.ExecuteQuery(t.Context(), query); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CancelQuery(t.Context(), query); err != nil {
+		t.Fatal(err)
+	}
+	if len(runner.executed) != 1 || runner.executed[0] != query {
+		t.Fatalf("expected the query to be executed, got %v", runner.executed)
+	}
+	if len(runner.canceled) != 1 || runner.canceled[0] != query {
+		t.Fatalf("expected the query to be canceled, got %v", runner.canceled)
+	}
+
+	err = client.ExecuteQuery(t.Context(), types.NamespacedName{Namespace: "team-b", Name: "weather"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected the error of the runner to reach the client, got %v", err)
+	}
+
+	other := newClient(writeIdentity(t, caCertificate, caKey, "spiffe://example.org/ns/team-a/sa/default"), "spiffe://example.org/ns/team-a/sa/default")
+	if err := other.ExecuteQuery(t.Context(), query); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected the runner to see the identity of another caller, got %v", err)
+	}
+
+	misdirected := newClient(writeIdentity(t, caCertificate, caKey, controllerID), "spiffe://example.org/ns/team-b/sa/default")
+	if err := misdirected.ExecuteQuery(t.Context(), query); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected the client to refuse a server with another SPIFFE ID, got %v", err)
+	}
+}
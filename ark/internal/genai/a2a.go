@@ -17,8 +17,11 @@ import (
 	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
 	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
 	"mckinsey.com/ark/internal/telemetry"
+
+	"mckinsey.com/ark/internal/common"
 )
 
 const (
@@ -30,19 +33,24 @@ const (
 
 // DiscoverA2AAgents discovers agents from an A2A server using simplified HTTP approach
 func DiscoverA2AAgents(ctx context.Context, k8sClient client.Client, address string, headers []arkv1prealpha1.Header, namespace string) (*A2AAgentCard, error) {
-	return DiscoverA2AAgentsWithRecorder(ctx, k8sClient, address, headers, namespace, nil, nil)
+	return DiscoverA2AAgentsWithRecorder(ctx, k8sClient, address, headers, nil, namespace, nil, nil)
 }
 
 // DiscoverA2AAgentsWithRecorder discovers agents with optional K8s event recording
 // Tries both A2A protocol versions: 0.3.x (agent-card.json) and 0.2.x (agent.json)
 // Note: protocol.AgentCardPath is version 0.2.x (agent.json) at time of writing
-func DiscoverA2AAgentsWithRecorder(ctx context.Context, k8sClient client.Client, address string, headers []arkv1prealpha1.Header, namespace string, recorder record.EventRecorder, obj client.Object) (*A2AAgentCard, error) {
+func DiscoverA2AAgentsWithRecorder(ctx context.Context, k8sClient client.Client, address string, headers []arkv1prealpha1.Header, clientTLS *arkv1alpha1.ClientTLS, namespace string, recorder record.EventRecorder, obj client.Object) (*A2AAgentCard, error) {
 	baseURL := strings.TrimSuffix(address, "/")
 
 	if err := validateA2AClient(address, headers, ctx, k8sClient, namespace, recorder, obj); err != nil {
 		return nil, err
 	}
 
+	httpClient, err := newA2AHTTPClient(ctx, k8sClient, clientTLS, namespace, 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
 	endpoints := []struct {
 		url     string
 		version string
@@ -59,7 +67,7 @@ func DiscoverA2AAgentsWithRecorder(ctx context.Context, k8sClient client.Client,
 			continue
 		}
 
-		agentCard, err := executeA2ARequest(ctx, req, address, recorder, obj)
+		agentCard, err := executeA2ARequest(ctx, httpClient, req, address, recorder, obj)
 		if err == nil {
 			if recorder != nil && obj != nil {
 				recorder.Event(obj, corev1.EventTypeNormal, "A2ADiscoverySuccess", fmt.Sprintf("Successfully discovered agent using %s at %s", endpoint.version, endpoint.url))
@@ -77,16 +85,16 @@ func DiscoverA2AAgentsWithRecorder(ctx context.Context, k8sClient client.Client,
 
 // ExecuteA2AAgent executes a task on an A2A agent using the official library client
 func ExecuteA2AAgent(ctx context.Context, k8sClient client.Client, address string, headers []arkv1prealpha1.Header, namespace, input, agentName string) (string, error) {
-	return ExecuteA2AAgentWithRecorder(ctx, k8sClient, address, headers, namespace, input, agentName, nil, nil)
+	return ExecuteA2AAgentWithRecorder(ctx, k8sClient, address, headers, nil, namespace, input, agentName, nil, nil)
 }
 
 // ExecuteA2AAgentWithRecorder executes a task on an A2A agent with optional K8s event recording
-func ExecuteA2AAgentWithRecorder(ctx context.Context, k8sClient client.Client, address string, headers []arkv1prealpha1.Header, namespace, input, agentName string, recorder record.EventRecorder, obj client.Object) (string, error) {
+func ExecuteA2AAgentWithRecorder(ctx context.Context, k8sClient client.Client, address string, headers []arkv1prealpha1.Header, clientTLS *arkv1alpha1.ClientTLS, namespace, input, agentName string, recorder record.EventRecorder, obj client.Object) (string, error) {
 	rpcURL := strings.TrimSuffix(address, "/")
 	logf.FromContext(ctx).Info("calling A2A server", "url", rpcURL)
 
 	// Create and configure A2A client
-	a2aClient, err := createA2AClientForExecution(ctx, k8sClient, rpcURL, headers, namespace, agentName, recorder, obj)
+	a2aClient, err := createA2AClientForExecution(ctx, k8sClient, rpcURL, headers, clientTLS, namespace, agentName, recorder, obj)
 	if err != nil {
 		return "", err
 	}
@@ -95,15 +103,36 @@ func ExecuteA2AAgentWithRecorder(ctx context.Context, k8sClient client.Client, a
 	return executeA2AAgentMessage(ctx, a2aClient, input, agentName, rpcURL, recorder, obj)
 }
 
+// ExecuteA2AAgentStreaming executes a task on an A2A agent over the A2A streaming method, passing each
+// increment of the agent's response to onDelta as it arrives. It returns the whole response.
+func ExecuteA2AAgentStreaming(ctx context.Context, k8sClient client.Client, address string, headers []arkv1prealpha1.Header, clientTLS *arkv1alpha1.ClientTLS, namespace, input, agentName string, onDelta func(string), recorder record.EventRecorder, obj client.Object) (string, error) {
+	rpcURL := strings.TrimSuffix(address, "/")
+	logf.FromContext(ctx).Info("streaming from A2A server", "url", rpcURL)
+
+	a2aClient, err := createA2AClientForExecution(ctx, k8sClient, rpcURL, headers, clientTLS, namespace, agentName, recorder, obj)
+	if err != nil {
+		return "", err
+	}
+
+	return streamA2AAgentMessage(ctx, a2aClient, input, agentName, rpcURL, onDelta, recorder, obj)
+}
+
 // createA2AClientForExecution creates and configures A2A client for agent execution
-func createA2AClientForExecution(ctx context.Context, k8sClient client.Client, rpcURL string, headers []arkv1prealpha1.Header, namespace, agentName string, recorder record.EventRecorder, obj client.Object) (*a2aclient.A2AClient, error) {
+func createA2AClientForExecution(ctx context.Context, k8sClient client.Client, rpcURL string, headers []arkv1prealpha1.Header, clientTLS *arkv1alpha1.ClientTLS, namespace, agentName string, recorder record.EventRecorder, obj client.Object) (*a2aclient.A2AClient, error) {
 	// Use context deadline if available, otherwise default
 	timeout := 5 * time.Minute
 	if deadline, ok := ctx.Deadline(); ok {
 		timeout = time.Until(deadline)
 	}
 
-	var clientOptions []a2aclient.Option
+	httpClient, err := newA2AHTTPClient(ctx, k8sClient, clientTLS, namespace, timeout)
+	if err != nil {
+		if recorder != nil && obj != nil {
+			recorder.Event(obj, corev1.EventTypeWarning, "A2ATLSResolutionFailed", fmt.Sprintf("Failed to resolve client certificate for agent %s: %v", agentName, err))
+		}
+		return nil, err
+	}
+	clientOptions := []a2aclient.Option{a2aclient.WithHTTPClient(httpClient)}
 	if len(headers) > 0 {
 		resolvedHeaders, err := resolveA2AHeaders(ctx, k8sClient, headers, namespace)
 		if err != nil {
@@ -113,14 +142,9 @@ func createA2AClientForExecution(ctx context.Context, k8sClient client.Client, r
 			return nil, err
 		}
 
-		httpClient := &http.Client{Timeout: timeout}
-		clientOptions = append(clientOptions, a2aclient.WithHTTPClient(httpClient))
 		clientOptions = append(clientOptions, a2aclient.WithHTTPReqHandler(&customA2ARequestHandler{
 			headers: resolvedHeaders,
 		}))
-	} else {
-		// No headers, but still need to set timeout via client options
-		clientOptions = append(clientOptions, a2aclient.WithTimeout(timeout))
 	}
 
 	a2aClient, err := a2aclient.NewA2AClient(rpcURL, clientOptions...)
@@ -175,6 +199,122 @@ func executeA2AAgentMessage(ctx context.Context, a2aClient *a2aclient.A2AClient,
 	return response, nil
 }
 
+// streamA2AAgentMessage streams a message to an A2A agent and reads the events of its response
+func streamA2AAgentMessage(ctx context.Context, a2aClient *a2aclient.A2AClient, input, agentName, rpcURL string, onDelta func(string), recorder record.EventRecorder, obj client.Object) (string, error) {
+	// Stop the client reading the stream when the response fails part way through
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := a2aClient.StreamMessage(ctx, protocol.SendMessageParams{
+		RPCID:   protocol.GenerateRPCID(),
+		Message: protocol.NewMessage(protocol.MessageRoleUser, []protocol.Part{protocol.NewTextPart(input)}),
+	})
+	if err != nil {
+		if recorder != nil && obj != nil {
+			recorder.Event(obj, corev1.EventTypeWarning, "A2AExecutionFailed", fmt.Sprintf("A2A agent %s execution failed at %s: %v", agentName, rpcURL, err))
+		}
+		return "", fmt.Errorf("A2A server stream failed: %w", err)
+	}
+
+	reader := &a2aStreamReader{onDelta: onDelta}
+	for event := range events {
+		if err := reader.read(event); err != nil {
+			if recorder != nil && obj != nil {
+				recorder.Event(obj, corev1.EventTypeWarning, "A2AResponseParseError", fmt.Sprintf("Failed to parse response from agent %s: %v", agentName, err))
+			}
+			return "", err
+		}
+	}
+	// The client closes the stream without an error when the context ends
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("A2A server stream failed: %w", err)
+	}
+	if !reader.done && reader.text.Len() == 0 {
+		return "", fmt.Errorf("A2A server stream ended without a response")
+	}
+
+	response := reader.text.String()
+	if recorder != nil && obj != nil {
+		recorder.Event(obj, corev1.EventTypeNormal, "A2AExecutionSuccess", fmt.Sprintf("Successfully executed agent %s, response length: %d characters", agentName, len(response)))
+	}
+	return response, nil
+}
+
+// a2aStreamReader accumulates the response an A2A agent streams as messages, task status updates and artifact
+// updates, passing each increment of the response to onDelta
+type a2aStreamReader struct {
+	text    strings.Builder
+	onDelta func(string)
+	// done is set once the agent replied with a message or its task completed
+	done bool
+}
+
+// read reads a streamed event, failing when the agent's task did
+func (s *a2aStreamReader) read(event protocol.StreamingMessageEvent) error {
+	switch e := event.Result.(type) {
+	case *protocol.Message:
+		if e.Role == protocol.MessageRoleAgent {
+			s.write(extractTextFromParts(e.Parts), true)
+			s.done = true
+		}
+		return nil
+	case *protocol.Task:
+		// A completed task sent as a whole carries its response in its history, unless it was streamed before
+		if e.Status.State == TaskStateCompleted && s.text.Len() == 0 {
+			text, err := extractTextFromTask(e)
+			if err != nil {
+				return err
+			}
+			s.write(text, true)
+		}
+		return s.status(e.Status)
+	case *protocol.TaskStatusUpdateEvent:
+		if message := e.Status.Message; message != nil && message.Role == protocol.MessageRoleAgent && e.Status.State != TaskStateFailed {
+			s.write(extractTextFromParts(message.Parts), true)
+		}
+		return s.status(e.Status)
+	case *protocol.TaskArtifactUpdateEvent:
+		appended := e.Append != nil && *e.Append
+		s.write(extractTextFromParts(e.Artifact.Parts), !appended)
+		return nil
+	default:
+		return fmt.Errorf("unexpected stream event type: %T", event.Result)
+	}
+}
+
+// status records the state of the agent's task, failing when the task ended without completing
+func (s *a2aStreamReader) status(status protocol.TaskStatus) error {
+	switch status.State {
+	case TaskStateCompleted:
+		s.done = true
+		return nil
+	case TaskStateSubmitted, TaskStateWorking, "":
+		return nil
+	case TaskStateFailed:
+		errorMsg := "task failed"
+		if status.Message != nil && len(status.Message.Parts) > 0 {
+			errorMsg = extractTextFromParts(status.Message.Parts)
+		}
+		return fmt.Errorf("%s", errorMsg)
+	default:
+		return fmt.Errorf("task in state '%s' (expected %s or %s)", status.State, TaskStateCompleted, TaskStateFailed)
+	}
+}
+
+// write appends text to the response, on a new line when it starts a new message or artifact
+func (s *a2aStreamReader) write(text string, newPart bool) {
+	if text == "" {
+		return
+	}
+	if newPart && s.text.Len() > 0 {
+		text = "\n" + text
+	}
+	s.text.WriteString(text)
+	if s.onDelta != nil {
+		s.onDelta(text)
+	}
+}
+
 // customA2ARequestHandler handles adding custom headers and OTEL tracing to A2A requests
 type customA2ARequestHandler struct {
 	headers map[string]string
@@ -324,8 +464,7 @@ func createA2ARequest(ctx context.Context, agentCardURL string, headers []arkv1p
 }
 
 // executeA2ARequest executes HTTP request and parses agent card response
-func executeA2ARequest(ctx context.Context, req *http.Request, address string, recorder record.EventRecorder, obj client.Object) (*A2AAgentCard, error) {
-	httpClient := &http.Client{Timeout: 30 * time.Second}
+func executeA2ARequest(ctx context.Context, httpClient *http.Client, req *http.Request, address string, recorder record.EventRecorder, obj client.Object) (*A2AAgentCard, error) {
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		if recorder != nil && obj != nil {
@@ -361,6 +500,16 @@ func executeA2ARequest(ctx context.Context, req *http.Request, address string, r
 	return &agentCard, nil
 }
 
+// newA2AHTTPClient returns the HTTP client of requests to an A2A server, presenting the client certificate of the
+// server when it uses mutual TLS
+func newA2AHTTPClient(ctx context.Context, k8sClient client.Client, clientTLS *arkv1alpha1.ClientTLS, namespace string, timeout time.Duration) (*http.Client, error) {
+	tlsConfig, err := common.ResolveClientTLS(ctx, k8sClient, clientTLS, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve A2A client certificate: %w", err)
+	}
+	return &http.Client{Timeout: timeout, Transport: common.NewInstrumentedTransport(common.HTTPClientA2A, common.NewClientTLSTransport(tlsConfig))}, nil
+}
+
 // resolveA2AHeaders resolves header values from ValueSources
 func resolveA2AHeaders(ctx context.Context, k8sClient client.Client, headers []arkv1prealpha1.Header, namespace string) (map[string]string, error) {
 	resolvedHeaders := make(map[string]string)
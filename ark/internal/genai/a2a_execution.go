@@ -76,12 +76,22 @@ func (e *A2AExecutionEngine) Execute(ctx context.Context, agentName, namespace s
 
 	// Extract content from the userInput message
 	content := ""
-	if userInput.OfUser != nil && userInput.OfUser.Content.OfString.Value != "" {
-		content = userInput.OfUser.Content.OfString.Value
+	if userInput.OfUser != nil {
+		content = userMessageText(userInput.OfUser.Content)
 	}
 
-	// Execute A2A agent with event recording
-	response, err := ExecuteA2AAgentWithRecorder(ctx, e.client, a2aAddress, a2aServer.Spec.Headers, namespace, content, agentName, nil, &a2aServer)
+	// Use "agent/name" format as per OpenAI-compatible endpoints
+	modelID := fmt.Sprintf("agent/%s", agentName)
+
+	// Stream the response from A2A servers that support it, otherwise wait for the whole response
+	streaming := eventStream != nil && annotations[arkann.A2AServerStreaming] == "true"
+	var response string
+	var err error
+	if streaming {
+		response, err = e.executeStreaming(ctx, &a2aServer, a2aAddress, namespace, content, agentName, modelID, eventStream)
+	} else {
+		response, err = ExecuteA2AAgentWithRecorder(ctx, e.client, a2aAddress, a2aServer.Spec.Headers, a2aServer.Spec.TLS, namespace, content, agentName, nil, &a2aServer)
+	}
 	if err != nil {
 		a2aTracker.Fail(err)
 		e.recorder.EmitEvent(ctx, "Warning", "A2AExecutionFailed", BaseEvent{
@@ -95,7 +105,6 @@ func (e *A2AExecutionEngine) Execute(ctx context.Context, agentName, namespace s
 			},
 		})
 
-		modelID := fmt.Sprintf("agent/%s", agentName)
 		StreamError(ctx, eventStream, err, "a2a_execution_failed", modelID)
 
 		return nil, err
@@ -125,36 +134,62 @@ func (e *A2AExecutionEngine) Execute(ctx context.Context, agentName, namespace s
 	// Convert response to genai.Message format
 	responseMessage := NewAssistantMessage(response)
 
-	// The A2A execution engine does not yet support streaming responses - if streaming
-	// was requested then the final response must be sent as a single chunk, as per the spec.
-	if eventStream != nil {
-		// Use query ID as completion ID (all chunks for a query share the same ID)
-		completionID := getQueryID(ctx)
-		// Use "agent/name" format as per OpenAI-compatible endpoints
-		modelID := fmt.Sprintf("agent/%s", agentName)
-
-		chunk := &openai.ChatCompletionChunk{
-			ID:      completionID,
-			Object:  "chat.completion.chunk",
-			Created: time.Now().Unix(),
-			Model:   modelID,
-			Choices: []openai.ChatCompletionChunkChoice{
-				{
-					Index: 0,
-					Delta: openai.ChatCompletionChunkChoiceDelta{
-						Content: response,
-						Role:    "assistant",
-					},
-					FinishReason: "stop",
-				},
-			},
-		}
+	// A2A servers that do not support streaming have their whole response sent as a single chunk, as per the spec
+	if eventStream != nil && !streaming {
+		e.streamChunk(ctx, eventStream, modelID, openai.ChatCompletionChunkChoiceDelta{
+			Content: response,
+			Role:    "assistant",
+		}, "stop")
+	}
 
-		chunkWithMeta := WrapChunkWithMetadata(ctx, chunk, modelID)
-		if err := eventStream.StreamChunk(ctx, chunkWithMeta); err != nil {
-			log.Error(err, "failed to send A2A response chunk to event stream")
+	return []Message{responseMessage}, nil
+}
+
+// executeStreaming executes a query against an A2A agent that streams its response, forwarding each increment of
+// the response to the event stream followed by a chunk that ends the response
+func (e *A2AExecutionEngine) executeStreaming(ctx context.Context, a2aServer *arkv1prealpha1.A2AServer, a2aAddress, namespace, content, agentName, modelID string, eventStream EventStreamInterface) (string, error) {
+	first := true
+	onDelta := func(delta string) {
+		chunkDelta := openai.ChatCompletionChunkChoiceDelta{Content: delta}
+		if first {
+			chunkDelta.Role = "assistant"
+			first = false
 		}
+		e.streamChunk(ctx, eventStream, modelID, chunkDelta, "")
 	}
 
-	return []Message{responseMessage}, nil
+	response, err := ExecuteA2AAgentStreaming(ctx, e.client, a2aAddress, a2aServer.Spec.Headers, a2aServer.Spec.TLS, namespace, content, agentName, onDelta, nil, a2aServer)
+	if err != nil {
+		return "", err
+	}
+
+	finalDelta := openai.ChatCompletionChunkChoiceDelta{}
+	if first {
+		finalDelta.Role = "assistant"
+	}
+	e.streamChunk(ctx, eventStream, modelID, finalDelta, "stop")
+	return response, nil
+}
+
+// streamChunk sends a chunk of the agent's response to the event stream
+func (e *A2AExecutionEngine) streamChunk(ctx context.Context, eventStream EventStreamInterface, modelID string, delta openai.ChatCompletionChunkChoiceDelta, finishReason string) {
+	chunk := &openai.ChatCompletionChunk{
+		// Use query ID as completion ID (all chunks for a query share the same ID)
+		ID:      getQueryID(ctx),
+		Object:  "chat.completion.chunk",
+		Created: time.Now().Unix(),
+		Model:   modelID,
+		Choices: []openai.ChatCompletionChunkChoice{
+			{
+				Index:        0,
+				Delta:        delta,
+				FinishReason: finishReason,
+			},
+		},
+	}
+
+	chunkWithMeta := WrapChunkWithMetadata(ctx, chunk, modelID)
+	if err := eventStream.StreamChunk(ctx, chunkWithMeta); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to send A2A response chunk to event stream")
+	}
 }
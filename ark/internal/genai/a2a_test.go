@@ -1,10 +1,22 @@
 package genai
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/openai/openai-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
+	arkann "mckinsey.com/ark/internal/annotations"
 )
 
 func TestExtractTextFromTask(t *testing.T) {
@@ -249,3 +261,187 @@ func TestExtractTextFromParts(t *testing.T) {
 		})
 	}
 }
+
+func TestA2AStreamReader(t *testing.T) {
+	appended := true
+	agentMessage := func(text string) *protocol.Message {
+		return &protocol.Message{Role: protocol.MessageRoleAgent, Parts: []protocol.Part{protocol.TextPart{Text: text}}}
+	}
+
+	tests := []struct {
+		name     string
+		events   []protocol.StreamingMessageResult
+		expected string
+		deltas   []string
+		done     bool
+		errorMsg string
+	}{
+		{
+			name:     "agent message",
+			events:   []protocol.StreamingMessageResult{agentMessage("Sunny in Paris")},
+			expected: "Sunny in Paris",
+			deltas:   []string{"Sunny in Paris"},
+			done:     true,
+		},
+		{
+			name: "status updates and appended artifact",
+			events: []protocol.StreamingMessageResult{
+				&protocol.Task{Status: protocol.TaskStatus{State: TaskStateSubmitted}},
+				&protocol.TaskStatusUpdateEvent{Status: protocol.TaskStatus{State: TaskStateWorking, Message: agentMessage("Checking the forecast")}},
+				&protocol.TaskArtifactUpdateEvent{Artifact: protocol.Artifact{Parts: []protocol.Part{protocol.TextPart{Text: "Sunny"}}}},
+				&protocol.TaskArtifactUpdateEvent{Append: &appended, Artifact: protocol.Artifact{Parts: []protocol.Part{protocol.TextPart{Text: " and warm"}}}},
+				&protocol.TaskStatusUpdateEvent{Final: true, Status: protocol.TaskStatus{State: TaskStateCompleted}},
+			},
+			expected: "Checking the forecast\nSunny and warm",
+			deltas:   []string{"Checking the forecast", "\nSunny", " and warm"},
+			done:     true,
+		},
+		{
+			name: "completed task sent as a whole",
+			events: []protocol.StreamingMessageResult{
+				&protocol.Task{
+					Status:  protocol.TaskStatus{State: TaskStateCompleted},
+					History: []protocol.Message{*agentMessage("Sunny in Paris")},
+				},
+			},
+			expected: "Sunny in Paris",
+			deltas:   []string{"Sunny in Paris"},
+			done:     true,
+		},
+		{
+			name: "failed task",
+			events: []protocol.StreamingMessageResult{
+				&protocol.TaskStatusUpdateEvent{Status: protocol.TaskStatus{State: TaskStateWorking, Message: agentMessage("Checking the forecast")}},
+				&protocol.TaskStatusUpdateEvent{Final: true, Status: protocol.TaskStatus{State: TaskStateFailed, Message: agentMessage("Weather service unavailable")}},
+			},
+			errorMsg: "Weather service unavailable",
+		},
+		{
+			name: "task that needs input",
+			events: []protocol.StreamingMessageResult{
+				&protocol.TaskStatusUpdateEvent{Final: true, Status: protocol.TaskStatus{State: TaskStateInputRequired}},
+			},
+			errorMsg: "task in state 'input-required'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deltas []string
+			reader := &a2aStreamReader{onDelta: func(delta string) { deltas = append(deltas, delta) }}
+			var err error
+			for _, event := range tt.events {
+				if err = reader.read(protocol.StreamingMessageEvent{Result: event}); err != nil {
+					break
+				}
+			}
+
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, reader.text.String())
+			assert.Equal(t, tt.deltas, deltas)
+			assert.Equal(t, tt.done, reader.done)
+		})
+	}
+}
+
+// newA2AStreamServer returns an A2A server that replies to every message with the events, as server-sent events
+func newA2AStreamServer(t *testing.T, events ...string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range events {
+			_, _ = fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":\"1\",\"result\":%s}\n\n", event)
+			w.(http.Flusher).Flush()
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+var weatherStreamEvents = []string{
+	`{"kind":"status-update","taskId":"task-1","contextId":"ctx-1","final":false,"status":{"state":"working","message":{"kind":"message","messageId":"m-1","role":"agent","parts":[{"kind":"text","text":"Checking the forecast"}]}}}`,
+	`{"kind":"artifact-update","taskId":"task-1","contextId":"ctx-1","artifact":{"artifactId":"a-1","parts":[{"kind":"text","text":"Sunny"}]}}`,
+	`{"kind":"artifact-update","taskId":"task-1","contextId":"ctx-1","append":true,"artifact":{"artifactId":"a-1","parts":[{"kind":"text","text":" and warm"}]}}`,
+	`{"kind":"status-update","taskId":"task-1","contextId":"ctx-1","final":true,"status":{"state":"completed"}}`,
+}
+
+func TestExecuteA2AAgentStreaming(t *testing.T) {
+	server := newA2AStreamServer(t, weatherStreamEvents...)
+
+	var deltas []string
+	response, err := ExecuteA2AAgentStreaming(context.Background(), nil, server.URL, nil, nil, "default", "What is the weather in Paris?", "weather",
+		func(delta string) { deltas = append(deltas, delta) }, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Checking the forecast\nSunny and warm", response)
+	assert.Equal(t, []string{"Checking the forecast", "\nSunny", " and warm"}, deltas)
+}
+
+func TestA2AExecutionEngineStreaming(t *testing.T) {
+	server := newA2AStreamServer(t, weatherStreamEvents...)
+	scheme := runtime.NewScheme()
+	_ = arkv1prealpha1.AddToScheme(scheme)
+	a2aServer := &arkv1prealpha1.A2AServer{ObjectMeta: metav1.ObjectMeta{Name: "weather-server", Namespace: "default"}}
+	engine := NewA2AExecutionEngine(fake.NewClientBuilder().WithScheme(scheme).WithObjects(a2aServer).Build(), &mockEventRecorder{})
+	annotations := map[string]string{
+		arkann.A2AServerName:    a2aServer.Name,
+		arkann.A2AServerAddress: server.URL,
+	}
+	input := NewUserMessage("What is the weather in Paris?")
+
+	deltas := func(stream *capturingEventStream) ([]openai.ChatCompletionChunkChoiceDelta, []string) {
+		var deltas []openai.ChatCompletionChunkChoiceDelta
+		var finishReasons []string
+		for _, chunk := range stream.chunks {
+			choice := chunk.(ChunkWithMetadata).Choices[0]
+			deltas = append(deltas, choice.Delta)
+			finishReasons = append(finishReasons, choice.FinishReason)
+		}
+		return deltas, finishReasons
+	}
+
+	t.Run("server that streams", func(t *testing.T) {
+		streaming := map[string]string{arkann.A2AServerStreaming: "true"}
+		for key, value := range annotations {
+			streaming[key] = value
+		}
+		stream := &capturingEventStream{}
+		messages, err := engine.Execute(context.Background(), "weather", "default", streaming, input, stream)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "Checking the forecast\nSunny and warm", messages[0].OfAssistant.Content.OfString.Value)
+
+		chunkDeltas, finishReasons := deltas(stream)
+		require.Len(t, chunkDeltas, 4)
+		assert.Equal(t, "assistant", chunkDeltas[0].Role)
+		assert.Equal(t, "Checking the forecast", chunkDeltas[0].Content)
+		assert.Empty(t, chunkDeltas[1].Role)
+		assert.Equal(t, "\nSunny", chunkDeltas[1].Content)
+		assert.Equal(t, " and warm", chunkDeltas[2].Content)
+		assert.Empty(t, chunkDeltas[3].Content)
+		assert.Equal(t, []string{"", "", "", "stop"}, finishReasons)
+	})
+
+	t.Run("server that does not stream", func(t *testing.T) {
+		blocking := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"jsonrpc":"2.0","id":"1","result":{"kind":"message","messageId":"m-1","role":"agent","parts":[{"kind":"text","text":"Sunny and warm"}]}}`)
+		}))
+		t.Cleanup(blocking.Close)
+
+		stream := &capturingEventStream{}
+		_, err := engine.Execute(context.Background(), "weather", "default", map[string]string{
+			arkann.A2AServerName:    a2aServer.Name,
+			arkann.A2AServerAddress: blocking.URL,
+		}, input, stream)
+		require.NoError(t, err)
+
+		chunkDeltas, finishReasons := deltas(stream)
+		require.Len(t, chunkDeltas, 1)
+		assert.Equal(t, "Sunny and warm", chunkDeltas[0].Content)
+		assert.Equal(t, []string{"stop"}, finishReasons)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/packages/param"
@@ -30,7 +31,14 @@ type Agent struct {
 	ExecutionEngine *arkv1alpha1.ExecutionEngineRef
 	Annotations     map[string]string
 	OutputSchema    *runtime.RawExtension
-	client          client.Client
+	Middleware      []Middleware
+	// FewShot selects examples from positively rated answers; nil when the agent has no few-shot config
+	FewShot *FewShotSelector
+	// Reviewer critiques and has the agent revise its answers; nil when the agent has no review config
+	Reviewer *AgentReviewer
+	// toolSchemas defers the tool definitions until the conversation suggests tool use; nil when they are always sent
+	toolSchemas *toolSchemas
+	client      client.Client
 }
 
 // FullName returns the namespace/name format for the agent
@@ -57,25 +65,9 @@ func (a *Agent) Execute(ctx context.Context, userInput Message, history []Messag
 	ctx, span := a.AgentRecorder.StartAgentExecution(ctx, a.Name, a.Namespace)
 	defer span.End()
 
-	var messages []Message
-	var err error
-
-	if a.ExecutionEngine != nil {
-		// Check if this is the reserved 'a2a' execution engine
-		if a.ExecutionEngine.Name == ExecutionEngineA2A {
-			messages, err = a.executeWithA2AExecutionEngine(ctx, userInput, eventStream)
-		} else {
-			messages, err = a.executeWithExecutionEngine(ctx, userInput, history)
-		}
-	} else {
-		// Regular agents require a model
-		if a.Model == nil {
-			err = fmt.Errorf("agent %s has no model configured", a.FullName())
-			a.AgentRecorder.RecordError(span, err)
-			return nil, err
-		}
-
-		messages, err = a.executeLocally(ctx, userInput, history, memory, eventStream)
+	messages, err := a.executeAnswer(ctx, userInput, history, memory, eventStream)
+	if err == nil && a.Reviewer != nil {
+		messages, err = a.review(ctx, userInput, history, messages, memory, eventStream)
 	}
 
 	if err != nil {
@@ -87,6 +79,23 @@ func (a *Agent) Execute(ctx context.Context, userInput Message, history []Messag
 	return messages, nil
 }
 
+// executeAnswer produces the agent's answer with its execution engine, or with its model when it has none
+func (a *Agent) executeAnswer(ctx context.Context, userInput Message, history []Message, memory MemoryInterface, eventStream EventStreamInterface) ([]Message, error) {
+	if a.ExecutionEngine != nil {
+		// Check if this is the reserved 'a2a' execution engine
+		if a.ExecutionEngine.Name == ExecutionEngineA2A {
+			return a.executeWithA2AExecutionEngine(ctx, userInput, eventStream)
+		}
+		return a.executeWithExecutionEngine(ctx, userInput, history)
+	}
+
+	// Regular agents require a model
+	if a.Model == nil {
+		return nil, fmt.Errorf("agent %s has no model configured", a.FullName())
+	}
+	return a.executeLocally(ctx, userInput, history, memory, eventStream)
+}
+
 func (a *Agent) executeWithExecutionEngine(ctx context.Context, userInput Message, history []Message) ([]Message, error) {
 	engineClient := NewExecutionEngineClient(a.client)
 
@@ -117,7 +126,17 @@ func (a *Agent) prepareMessages(ctx context.Context, userInput Message, history
 		return nil, fmt.Errorf("agent %s prompt resolution failed: %w", a.FullName(), err)
 	}
 
-	systemMessage := NewSystemMessage(resolvedPrompt)
+	profile, err := profileContext(ctx, a.client, a.Namespace)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to load user profile, continuing without it", "agent", a.FullName())
+	}
+
+	examples, err := fewShotContext(ctx, a.FewShot, ExtractUserMessageContent([]Message{userInput}))
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to select few-shot examples, continuing without them", "agent", a.FullName())
+	}
+
+	systemMessage := NewSystemMessage(resolvedPrompt + profile + examples)
 	agentMessages := append([]Message{systemMessage}, history...)
 	agentMessages = append(agentMessages, userInput)
 	return agentMessages, nil
@@ -135,11 +154,19 @@ func (a *Agent) executeModelCall(ctx context.Context, agentMessages []Message, t
 	// Truncate schema name to 64 chars for OpenAI API compatibility - name is purely an identifier
 	a.Model.SchemaName = fmt.Sprintf("%.64s", fmt.Sprintf("namespace-%s-agent-%s", a.Namespace, a.Name))
 
-	response, err := a.Model.ChatCompletion(ctx, agentMessages, eventStream, 1, tools)
+	callModel := chainModelCall(a.Middleware, func(ctx context.Context, call *ModelCall) (*openai.ChatCompletion, error) {
+		return a.Model.ChatCompletion(ctx, call.Messages, eventStream, 1, call.Tools)
+	})
+	response, err := callModel(ctx, &ModelCall{Agent: a, Messages: agentMessages, Tools: tools})
 	if err != nil {
 		llmTracker.Fail(err)
 		return nil, fmt.Errorf("agent %s execution failed: %w", a.FullName(), err)
 	}
+	if response == nil {
+		err := fmt.Errorf("agent %s received empty response", a.FullName())
+		llmTracker.Fail(err)
+		return nil, err
+	}
 
 	tokenUsage := TokenUsage{
 		PromptTokens:     response.Usage.PromptTokens,
@@ -165,7 +192,7 @@ func (a *Agent) processAssistantMessage(choice openai.ChatCompletionChoice) Mess
 	return assistantMessage
 }
 
-func (a *Agent) executeToolCall(ctx context.Context, toolCall openai.ChatCompletionMessageToolCall) (Message, error) {
+func (a *Agent) executeToolCall(ctx context.Context, toolCall openai.ChatCompletionMessageToolCall, eventStream EventStreamInterface) (Message, error) {
 	var params map[string]interface{}
 	if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &params); err != nil {
 		params = map[string]interface{}{"_raw": toolCall.Function.Arguments}
@@ -182,13 +209,19 @@ func (a *Agent) executeToolCall(ctx context.Context, toolCall openai.ChatComplet
 		"toolType":   a.Tools.GetToolType(toolCall.Function.Name),
 	})
 
-	result, err := a.Tools.ExecuteTool(ctx, ToolCall(toolCall), a.Recorder)
+	callTool := chainToolCall(a.Middleware, func(ctx context.Context, call ToolCall) (ToolResult, error) {
+		return a.Tools.ExecuteToolWithStream(ctx, call, a.Recorder, eventStream)
+	})
+	result, err := callTool(ctx, ToolCall(toolCall))
 	toolMessage := ToolMessage(result.Content, result.ID)
 
 	if err != nil {
-		if IsTerminateTeam(err) {
+		switch {
+		case IsTerminateTeam(err):
 			toolTracker.CompleteWithTermination(err.Error())
-		} else {
+		case GetHandoff(err) != nil:
+			toolTracker.Complete(result.Content)
+		default:
 			toolTracker.Fail(err)
 		}
 		return toolMessage, err
@@ -202,13 +235,13 @@ func (a *Agent) executeToolCall(ctx context.Context, toolCall openai.ChatComplet
 	return toolMessage, nil
 }
 
-func (a *Agent) executeToolCalls(ctx context.Context, toolCalls []openai.ChatCompletionMessageToolCall, agentMessages, newMessages *[]Message) error {
+func (a *Agent) executeToolCalls(ctx context.Context, toolCalls []openai.ChatCompletionMessageToolCall, agentMessages, newMessages *[]Message, eventStream EventStreamInterface) error {
 	for _, tc := range toolCalls {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
 
-		toolMessage, err := a.executeToolCall(ctx, tc)
+		toolMessage, err := a.executeToolCall(ctx, tc, eventStream)
 		*agentMessages = append(*agentMessages, toolMessage)
 		*newMessages = append(*newMessages, toolMessage)
 
@@ -221,10 +254,7 @@ func (a *Agent) executeToolCalls(ctx context.Context, toolCalls []openai.ChatCom
 
 // executeLocally executes the agent using the built-in OpenAI-compatible engine
 func (a *Agent) executeLocally(ctx context.Context, userInput Message, history []Message, _ MemoryInterface, eventStream EventStreamInterface) ([]Message, error) {
-	var tools []openai.ChatCompletionToolParam
-	if a.Tools != nil {
-		tools = a.Tools.ToOpenAITools()
-	}
+	tools, deferred := a.initialTools(ctx, userInput, history)
 
 	agentMessages, err := a.prepareMessages(ctx, userInput, history)
 	if err != nil {
@@ -253,7 +283,16 @@ func (a *Agent) executeLocally(ctx context.Context, userInput Message, history [
 			return newMessages, nil
 		}
 
-		if err := a.executeToolCalls(ctx, choice.Message.ToolCalls, &agentMessages, &newMessages); err != nil {
+		if deferred && callsLoadTools(choice.Message.ToolCalls) {
+			// The model asked for the tools, which are sent with every call from now on
+			toolMessages := loadToolsResults(choice.Message.ToolCalls)
+			agentMessages = append(agentMessages, toolMessages...)
+			newMessages = append(newMessages, toolMessages...)
+			tools, deferred = a.Tools.ToOpenAITools(), false
+			continue
+		}
+
+		if err := a.executeToolCalls(ctx, choice.Message.ToolCalls, &agentMessages, &newMessages, eventStream); err != nil {
 			logger := logf.FromContext(ctx)
 			logger.Error(err, "Tool execution failed", "agent", a.FullName())
 			return newMessages, err
@@ -261,6 +300,27 @@ func (a *Agent) executeLocally(ctx context.Context, userInput Message, history [
 	}
 }
 
+// initialTools returns the tools of the first model call of an execution, and whether their definitions are
+// deferred, in which case the model is only offered the tool to load them
+func (a *Agent) initialTools(ctx context.Context, userInput Message, history []Message) ([]openai.ChatCompletionToolParam, bool) {
+	if a.Tools == nil {
+		return nil, false
+	}
+
+	tools := a.Tools.ToOpenAITools()
+	toolNames := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		toolNames = append(toolNames, tool.Function.Name)
+	}
+	slices.Sort(toolNames)
+
+	if !a.toolSchemas.defers(userInput, history, toolNames) {
+		return tools, false
+	}
+	logf.FromContext(ctx).V(1).Info("tool definitions deferred", "agent", a.FullName(), "tools", len(tools))
+	return []openai.ChatCompletionToolParam{loadToolsDefinition(toolNames)}, true
+}
+
 func (a *Agent) GetName() string {
 	return a.Name
 }
@@ -310,8 +370,10 @@ func resolveModelHeadersForAgent(ctx context.Context, k8sClient client.Client, a
 
 	var modelHeaders map[string]string
 	if agentCRD.Spec.ModelRef != nil {
-		agentHeaders := agentHeadersMap[agentCRD.Spec.ModelRef.Name]
-		queryHeaders := queryHeadersMap[agentCRD.Spec.ModelRef.Name]
+		// Overrides select Models, so an alias is matched by the Model it resolves to
+		modelName := ResolveModelAlias(ctx, k8sClient, agentCRD.Spec.ModelRef.Name, agentCRD.Namespace)
+		agentHeaders := agentHeadersMap[modelName]
+		queryHeaders := queryHeadersMap[modelName]
 
 		modelHeaders = make(map[string]string)
 		for k, v := range agentHeaders {
@@ -378,10 +440,17 @@ func MakeAgent(ctx context.Context, k8sClient client.Client, crd *arkv1alpha1.Ag
 
 	var resolvedModel *Model
 
+	// An agent under test answers with the model pinned by the test
+	test := agentTestFor(ctx, crd)
+	modelRef := crd.Spec.ModelRef
+	if test != nil && test.ModelRef != nil {
+		modelRef = test.ModelRef
+	}
+
 	// A2A agents don't need models - they delegate to external A2A servers
 	if crd.Spec.ExecutionEngine == nil || crd.Spec.ExecutionEngine.Name != ExecutionEngineA2A {
 		var err error
-		resolvedModel, err = LoadModel(ctx, k8sClient, crd.Spec.ModelRef, crd.Namespace, modelHeaders, telemetryProvider.ModelRecorder())
+		resolvedModel, err = LoadModel(ctx, k8sClient, modelRef, crd.Namespace, modelHeaders, telemetryProvider.ModelRecorder())
 		if err != nil {
 			return nil, fmt.Errorf("failed to load model for agent %s/%s: %w", crd.Namespace, crd.Name, err)
 		}
@@ -405,7 +474,22 @@ func MakeAgent(ctx context.Context, k8sClient client.Client, crd *arkv1alpha1.Ag
 		return nil, err
 	}
 
+	var fewShot *FewShotSelector
+	if crd.Spec.FewShot != nil {
+		fewShot, err = NewFewShotSelector(ctx, k8sClient, crd, telemetryProvider.ModelRecorder())
+		if err != nil {
+			return nil, fmt.Errorf("agent %s/%s: %w", crd.Namespace, crd.Name, err)
+		}
+	}
+
+	var reviewer *AgentReviewer
+	if crd.Spec.Review != nil {
+		reviewer = NewAgentReviewer(k8sClient, crd, eventRecorder, telemetryProvider)
+	}
+
 	tools := NewToolRegistry(mcpSettings, telemetryProvider.ToolRecorder())
+	tools.policy = newToolPolicy(crd.Spec.ToolPolicy)
+	tools.test = test
 
 	if err := tools.registerTools(ctx, k8sClient, crd, telemetryProvider); err != nil {
 		return nil, err
@@ -424,6 +508,10 @@ func MakeAgent(ctx context.Context, k8sClient client.Client, crd *arkv1alpha1.Ag
 		ExecutionEngine: crd.Spec.ExecutionEngine,
 		Annotations:     crd.Annotations,
 		OutputSchema:    crd.Spec.OutputSchema,
+		Middleware:      RegisteredMiddleware(),
+		FewShot:         fewShot,
+		Reviewer:        reviewer,
+		toolSchemas:     newToolSchemas(crd.Spec.ToolSchemas),
 		client:          k8sClient,
 	}, nil
 }
@@ -3,6 +3,7 @@ package genai
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -13,20 +14,22 @@ import (
 )
 
 func (a *Agent) resolvePrompt(ctx context.Context) (string, error) {
-	templateData := make(map[string]any)
-
 	agentParams, err := a.resolveParameters(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to resolve parameters: %w", err)
 	}
-	for name, value := range agentParams {
-		templateData[name] = value
-	}
 
-	if len(templateData) == 0 {
+	if !strings.Contains(a.Prompt, "{{") {
 		return a.Prompt, nil
 	}
 
+	// Agent parameters take precedence over the standard variables
+	query, _ := ctx.Value(QueryContextKey).(*arkv1alpha1.Query)
+	templateData := ResolvePromptContext(ctx, a.client, a.Namespace, query).TemplateData()
+	for name, value := range agentParams {
+		templateData[name] = value
+	}
+
 	resolved, err := common.ResolveTemplate(a.Prompt, templateData)
 	if err != nil {
 		return "", fmt.Errorf("template resolution failed: %w", err)
@@ -38,18 +41,14 @@ func (a *Agent) resolveParameters(ctx context.Context) (map[string]string, error
 	templateData := make(map[string]string)
 
 	for _, param := range a.Parameters {
-		if param.Value != "" {
-			templateData[param.Name] = param.Value
-			continue
-		}
-
-		if param.ValueFrom == nil {
-			return nil, fmt.Errorf("parameter %s must specify either value or valueFrom", param.Name)
-		}
-
-		value, err := a.resolveValueFrom(ctx, param.ValueFrom)
+		value, err := a.resolveParameter(ctx, param)
 		if err != nil {
-			return nil, fmt.Errorf("failed to resolve parameter %s: %w", param.Name, err)
+			return nil, err
+		}
+		if param.Schema != nil {
+			if err := param.Schema.Validate(value); err != nil {
+				return nil, fmt.Errorf("parameter %s: %w", param.Name, err)
+			}
 		}
 		templateData[param.Name] = value
 	}
@@ -57,6 +56,25 @@ func (a *Agent) resolveParameters(ctx context.Context) (map[string]string, error
 	return templateData, nil
 }
 
+func (a *Agent) resolveParameter(ctx context.Context, param arkv1alpha1.Parameter) (string, error) {
+	if param.Value != "" {
+		return param.Value, nil
+	}
+
+	if param.ValueFrom == nil {
+		if param.Schema != nil && param.Schema.Default != "" {
+			return param.Schema.Default, nil
+		}
+		return "", fmt.Errorf("parameter %s must specify either value or valueFrom", param.Name)
+	}
+
+	value, err := a.resolveValueFrom(ctx, param.ValueFrom)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve parameter %s: %w", param.Name, err)
+	}
+	return value, nil
+}
+
 func (a *Agent) resolveValueFrom(ctx context.Context, valueFrom *arkv1alpha1.ValueFromSource) (string, error) {
 	if valueFrom.ConfigMapKeyRef != nil {
 		return a.resolveConfigMapRef(ctx, valueFrom.ConfigMapKeyRef)
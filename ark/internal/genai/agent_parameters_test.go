@@ -3,6 +3,7 @@ package genai
 import (
 	"context"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -11,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
 )
 
 func TestAgentParameterResolution(t *testing.T) {
@@ -127,6 +129,47 @@ func TestAgentParameterResolution(t *testing.T) {
 			},
 			wantPrompt: "Hello NestedUser",
 		},
+		{
+			name: "locale from namespace config",
+			agent: &Agent{
+				Name:      "test-agent",
+				Namespace: "default",
+				Prompt:    "Timezone {{.timezone}}, locale {{.locale}}",
+			},
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: LocaleConfigMapName, Namespace: "default"},
+					Data:       map[string]string{"timezone": "Europe/Berlin", "locale": "de-DE"},
+				},
+			},
+			wantPrompt: "Timezone Europe/Berlin, locale de-DE",
+		},
+		{
+			name: "timezone from query annotation",
+			agent: &Agent{
+				Name:      "test-agent",
+				Namespace: "default",
+				Prompt:    "Timezone {{.timezone}}, locale {{.locale}}",
+			},
+			query: &arkv1alpha1.Query{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-query",
+					Annotations: map[string]string{annotations.Timezone: "Asia/Tokyo"},
+				},
+			},
+			wantPrompt: "Timezone Asia/Tokyo, locale en-US",
+		},
+		{
+			name: "agent parameter overrides standard variable",
+			agent: &Agent{
+				Name:   "test-agent",
+				Prompt: "Locale {{.locale}}",
+				Parameters: []arkv1alpha1.Parameter{
+					{Name: "locale", Value: "fr-FR"},
+				},
+			},
+			wantPrompt: "Locale fr-FR",
+		},
 		{
 			name: "missing query context",
 			agent: &Agent{
@@ -211,3 +254,14 @@ func TestAgentParameterResolution(t *testing.T) {
 		})
 	}
 }
+
+func TestResolvePromptContextInvalidTimezone(t *testing.T) {
+	query := &arkv1alpha1.Query{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{annotations.Timezone: "Mars/Olympus"}},
+	}
+
+	promptContext := ResolvePromptContext(context.Background(), nil, "default", query)
+	if promptContext.Timezone != "UTC" || promptContext.Now.Location() != time.UTC {
+		t.Errorf("ResolvePromptContext() timezone = %v, want UTC", promptContext.Timezone)
+	}
+}
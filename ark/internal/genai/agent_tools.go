@@ -11,22 +11,26 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
 	"mckinsey.com/ark/internal/telemetry"
 )
 
-// Add MCP client pool to ToolRegistry
+// MCPClientPool holds the MCP clients of a ToolRegistry, one per MCP server. The clients are acquired from the
+// shared connection pool and given back to it on Close.
 type MCPClientPool struct {
-	clients map[string]*MCPClient // key: mcpServerName
+	clients  map[string]*MCPClient // key: mcpServerNamespace/mcpServerName
+	releases map[string]func()
 }
 
 func NewMCPClientPool() *MCPClientPool {
 	return &MCPClientPool{
-		clients: make(map[string]*MCPClient),
+		clients:  make(map[string]*MCPClient),
+		releases: make(map[string]func()),
 	}
 }
 
 // GetOrCreateClient returns an existing MCP client or creates a new one for the given server
-func (p *MCPClientPool) GetOrCreateClient(ctx context.Context, serverName, serverNamespace, serverURL string, headers map[string]string, transport string, timeout time.Duration, mcpSettings map[string]MCPSettings) (*MCPClient, error) {
+func (p *MCPClientPool) GetOrCreateClient(ctx context.Context, serverName, serverNamespace, serverURL string, command []string, headers map[string]string, auth *MCPOAuth2Config, clientTLS *common.ClientTLSConfig, transport string, timeout time.Duration, mcpSettings map[string]MCPSettings) (*MCPClient, error) {
 	key := fmt.Sprintf("%s/%s", serverNamespace, serverName)
 	if mcpClient, exists := p.clients[key]; exists {
 		return mcpClient, nil
@@ -35,28 +39,26 @@ func (p *MCPClientPool) GetOrCreateClient(ctx context.Context, serverName, serve
 	// Get MCP settings for this server if available
 	mcpSetting := mcpSettings[key]
 
-	// Create new client for this MCP server
-	mcpClient, err := NewMCPClient(ctx, serverURL, headers, transport, timeout, mcpSetting)
+	// Reuse an open session to this MCP server, or connect
+	mcpClient, release, err := AcquireMCPClient(ctx, serverURL, command, headers, auth, clientTLS, transport, timeout, mcpSetting)
 	if err != nil {
+		RecordMCPConnectionFailure(serverNamespace, serverName, transport)
 		return nil, err
 	}
 
 	p.clients[key] = mcpClient
+	p.releases[key] = release
 	return mcpClient, nil
 }
 
-// Close closes all MCP client connections in the pool
+// Close gives the MCP clients of the pool back to the shared connection pool
 func (p *MCPClientPool) Close() error {
-	var lastErr error
-	for key, mcpClient := range p.clients {
-		if mcpClient != nil && mcpClient.client != nil {
-			if err := mcpClient.client.Close(); err != nil {
-				lastErr = fmt.Errorf("failed to close MCP client %s: %w", key, err)
-			}
-		}
+	for key, release := range p.releases {
+		release()
+		delete(p.releases, key)
 		delete(p.clients, key)
 	}
-	return lastErr
+	return nil
 }
 
 func (r *ToolRegistry) registerTools(ctx context.Context, k8sClient client.Client, agent *arkv1alpha1.Agent, telemetryProvider telemetry.Provider) error {
@@ -77,7 +79,9 @@ func CreateToolExecutor(ctx context.Context, k8sClient client.Client, tool *arkv
 	case ToolTypeAgent:
 		return createAgentExecutor(ctx, k8sClient, tool, namespace, telemetryProvider)
 	case ToolTypeBuiltin:
-		return createBuiltinExecutor(tool)
+		return createBuiltinExecutor(k8sClient, tool, namespace)
+	case ToolTypeWasm:
+		return createWasmExecutor(k8sClient, tool, namespace)
 	default:
 		return nil, fmt.Errorf("unsupported tool type %s for tool %s", tool.Spec.Type, tool.Name)
 	}
@@ -103,12 +107,32 @@ func createAgentExecutor(ctx context.Context, k8sClient client.Client, tool *ark
 	}, nil
 }
 
-func createBuiltinExecutor(tool *arkv1alpha1.Tool) (ToolExecutor, error) {
+func createBuiltinExecutor(k8sClient client.Client, tool *arkv1alpha1.Tool, namespace string) (ToolExecutor, error) {
 	switch tool.Name {
 	case BuiltinToolNoop:
 		return &NoopExecutor{}, nil
 	case BuiltinToolTerminate:
 		return &TerminateExecutor{}, nil
+	case BuiltinToolPublishArtifact:
+		return &PublishArtifactExecutor{}, nil
+	case BuiltinToolRenderChart:
+		return &RenderChartExecutor{}, nil
+	case BuiltinToolSendEmail:
+		return &SendEmailExecutor{K8sClient: k8sClient, Namespace: namespace}, nil
+	case BuiltinToolSendSlackMessage:
+		return &SendSlackMessageExecutor{K8sClient: k8sClient, Namespace: namespace}, nil
+	case BuiltinToolRemember:
+		return &RememberExecutor{K8sClient: k8sClient, Namespace: namespace}, nil
+	case BuiltinToolHandoff:
+		return &HandoffExecutor{}, nil
+	case BuiltinToolBlackboardRead:
+		return &BlackboardReadExecutor{}, nil
+	case BuiltinToolBlackboardWrite:
+		return &BlackboardWriteExecutor{}, nil
+	case BuiltinToolFetchURL:
+		return &FetchURLExecutor{K8sClient: k8sClient, Namespace: namespace}, nil
+	case BuiltinToolCalculator:
+		return &CalculatorExecutor{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported builtin tool %s", tool.Name)
 	}
@@ -158,6 +182,16 @@ func createMCPExecutor(ctx context.Context, k8sClient client.Client, tool *arkv1
 		headers[header.Name] = value
 	}
 
+	auth, err := ResolveMCPServerAuth(ctx, k8sClient, &mcpServerCRD)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve auth of MCP server %v: %w", mcpServerKey, err)
+	}
+
+	clientTLS, err := common.ResolveClientTLS(ctx, k8sClient, mcpServerCRD.Spec.TLS, mcpServerCRD.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve client certificate of MCP server %v: %w", mcpServerKey, err)
+	}
+
 	// Parse timeout from MCPServer spec (default to 30s if not specified)
 	timeout := 30 * time.Second
 	if mcpServerCRD.Spec.Timeout != "" {
@@ -174,7 +208,10 @@ func createMCPExecutor(ctx context.Context, k8sClient client.Client, tool *arkv1
 		tool.Spec.MCP.MCPServerRef.Name,
 		mcpServerNamespace,
 		mcpURL,
+		mcpServerCRD.Spec.Command,
 		headers,
+		auth,
+		clientTLS,
 		mcpServerCRD.Spec.Transport,
 		timeout,
 		mcpSettings,
@@ -198,9 +235,15 @@ func (r *ToolRegistry) registerTool(ctx context.Context, k8sClient client.Client
 	}
 
 	toolDef := CreateToolFromCRD(tool)
-	executor, err := CreateToolExecutor(ctx, k8sClient, tool, namespace, r.mcpPool, r.mcpSettings, telemetryProvider)
-	if err != nil {
-		return fmt.Errorf("failed to create executor for tool %s: %w", agentTool.Name, err)
+	var executor ToolExecutor
+	if mock := r.mockTool(agentTool.Name); mock != nil {
+		executor = &MockToolExecutor{Response: mock.Response}
+	} else {
+		var err error
+		executor, err = CreateToolExecutor(ctx, k8sClient, tool, namespace, r.mcpPool, r.mcpSettings, telemetryProvider)
+		if err != nil {
+			return fmt.Errorf("failed to create executor for tool %s: %w", agentTool.Name, err)
+		}
 	}
 
 	if agentTool.Partial != nil {
@@ -246,7 +289,7 @@ func (a *AgentToolExecutor) Execute(ctx context.Context, call ToolCall, recorder
 			ID:    call.ID,
 			Name:  call.Function.Name,
 			Error: "Failed to parse tool arguments",
-		}, fmt.Errorf("failed to parse tool arguments: %v", err)
+		}, Errorf(ReasonSchemaViolation, "failed to parse tool arguments: %v", err)
 	}
 
 	input, exists := arguments["input"]
@@ -255,7 +298,7 @@ func (a *AgentToolExecutor) Execute(ctx context.Context, call ToolCall, recorder
 			ID:    call.ID,
 			Name:  call.Function.Name,
 			Error: "input parameter is required",
-		}, fmt.Errorf("input parameter is required for agent tool %s", a.AgentName)
+		}, Errorf(ReasonSchemaViolation, "input parameter is required for agent tool %s", a.AgentName)
 	}
 
 	inputStr, ok := input.(string)
@@ -264,12 +307,13 @@ func (a *AgentToolExecutor) Execute(ctx context.Context, call ToolCall, recorder
 			ID:    call.ID,
 			Name:  call.Function.Name,
 			Error: "input parameter must be a string",
-		}, fmt.Errorf("input parameter must be a string for agent tool %s", a.AgentName)
+		}, Errorf(ReasonSchemaViolation, "input parameter must be a string for agent tool %s", a.AgentName)
 	}
 
 	// Log the agent execution
 	log := logf.FromContext(ctx)
-	log.Info("calling agent directly", "agent", a.AgentName, "namespace", a.Namespace, "input", inputStr)
+	log.V(LogLevelDebug).Info("calling agent directly", "agent", a.AgentName, "namespace", a.Namespace)
+	log.V(LogLevelTrace).Info("agent direct call input", "agent", a.AgentName, "input", inputStr)
 
 	// Create the Agent object using the Agent CRD and recorder
 	agent, err := MakeAgent(ctx, a.k8sClient, a.AgentCRD, recorder, a.telemetryProvider)
@@ -300,7 +344,7 @@ func (a *AgentToolExecutor) Execute(ctx context.Context, call ToolCall, recorder
 
 	lastMessage := responseMessages[len(responseMessages)-1]
 
-	log.Info("agent direct call response", "agent", a.AgentName, "response", lastMessage.OfAssistant.Content.OfString.Value)
+	log.V(LogLevelTrace).Info("agent direct call response", "agent", a.AgentName, "response", lastMessage.OfAssistant.Content.OfString.Value)
 
 	return ToolResult{
 		ID:      call.ID,
@@ -0,0 +1,98 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+type agentTestContextKey struct{}
+
+// WithAgentTest marks the context as answering the AgentTest, whose pinned model and mocked tools replace those
+// of the agent under test
+func WithAgentTest(ctx context.Context, test *arkv1alpha1.AgentTestSpec) context.Context {
+	return context.WithValue(ctx, agentTestContextKey{}, test)
+}
+
+// agentTestFor returns the test the agent answers, or nil when the agent is not under test
+func agentTestFor(ctx context.Context, agent *arkv1alpha1.Agent) *arkv1alpha1.AgentTestSpec {
+	test, ok := ctx.Value(agentTestContextKey{}).(*arkv1alpha1.AgentTestSpec)
+	if !ok || test.Agent != agent.Name {
+		return nil
+	}
+	return test
+}
+
+// mockTool returns the mock of the tool in the test, or nil when the tool runs
+func (r *ToolRegistry) mockTool(name string) *arkv1alpha1.AgentTestMockTool {
+	if r.test == nil {
+		return nil
+	}
+	for i := range r.test.MockTools {
+		if r.test.MockTools[i].Name == name {
+			return &r.test.MockTools[i]
+		}
+	}
+	return nil
+}
+
+// MockToolExecutor answers every call of a tool with the fixed response of an AgentTest
+type MockToolExecutor struct {
+	Response string
+}
+
+func (m *MockToolExecutor) Execute(_ context.Context, call ToolCall, _ EventEmitter) (ToolResult, error) {
+	return ToolResult{ID: call.ID, Name: call.Function.Name, Content: m.Response}, nil
+}
+
+// CheckAgentTestExpectations asserts the expectations of a test on the response of the query the agent answered
+// the test in. It returns an error when a pattern or rule is invalid.
+func CheckAgentTestExpectations(expect arkv1alpha1.AgentTestExpectations, query *arkv1alpha1.Query) ([]arkv1alpha1.AgentTestAssertionResult, error) {
+	response := QueryResponseContent(query, "")
+	results := make([]arkv1alpha1.AgentTestAssertionResult, 0, len(expect.Patterns)+len(expect.ExcludedPatterns)+len(expect.Rules))
+
+	for _, pattern := range expect.Patterns {
+		expression, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		result := arkv1alpha1.AgentTestAssertionResult{Name: "pattern", Expression: pattern, Passed: expression.MatchString(response)}
+		if !result.Passed {
+			result.Message = "response does not match the pattern"
+		}
+		results = append(results, result)
+	}
+
+	for _, pattern := range expect.ExcludedPatterns {
+		expression, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid excluded pattern %q: %w", pattern, err)
+		}
+		result := arkv1alpha1.AgentTestAssertionResult{Name: "excludedPattern", Expression: pattern, Passed: !expression.MatchString(response)}
+		if !result.Passed {
+			result.Message = fmt.Sprintf("response matches the excluded pattern with %q", expression.FindString(response))
+		}
+		results = append(results, result)
+	}
+
+	activation := queryRuleActivation(query, "")
+	for _, rule := range expect.Rules {
+		program, err := CompileQueryRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		result := arkv1alpha1.AgentTestAssertionResult{Name: rule.Name, Expression: rule.Expression}
+		out, _, err := program.Eval(activation)
+		if err != nil {
+			result.Message = err.Error()
+		} else if result.Passed, _ = out.Value().(bool); !result.Passed {
+			result.Message = "rule returned false"
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,90 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+func TestCheckAgentTestExpectations(t *testing.T) {
+	expect := arkv1alpha1.AgentTestExpectations{
+		Patterns:         []string{`(?i)sunny`, `Berlin`},
+		ExcludedPatterns: []string{`(?i)sorry`, `Paris`},
+		Rules: []arkv1alpha1.ExpressionRule{
+			{Name: "within-budget", Expression: `tokenUsage.totalTokens <= 2000`},
+			{Name: "owner", Expression: `query.labels["owner"] == "team-a"`},
+		},
+	}
+
+	results, err := CheckAgentTestExpectations(expect, completedQuery())
+	require.NoError(t, err)
+	require.Len(t, results, 6)
+
+	assert.True(t, results[0].Passed)
+	assert.False(t, results[1].Passed)
+	assert.Equal(t, "response does not match the pattern", results[1].Message)
+	assert.True(t, results[2].Passed)
+	assert.False(t, results[3].Passed)
+	assert.Contains(t, results[3].Message, `"Paris"`)
+	assert.Equal(t, arkv1alpha1.AgentTestAssertionResult{Name: "within-budget", Expression: `tokenUsage.totalTokens <= 2000`, Passed: true}, results[4])
+	assert.False(t, results[5].Passed)
+	assert.Contains(t, results[5].Message, "no such key")
+}
+
+func TestCheckAgentTestExpectationsInvalid(t *testing.T) {
+	_, err := CheckAgentTestExpectations(arkv1alpha1.AgentTestExpectations{Patterns: []string{`(`}}, completedQuery())
+	assert.ErrorContains(t, err, "invalid pattern")
+
+	_, err = CheckAgentTestExpectations(arkv1alpha1.AgentTestExpectations{Rules: []arkv1alpha1.ExpressionRule{{Name: "broken", Expression: `response.`}}}, completedQuery())
+	assert.Error(t, err)
+}
+
+func TestAgentTestMocksTools(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = arkv1alpha1.AddToScheme(scheme)
+	// The MCP server of the tool does not exist, so the tool can only be registered when it is mocked
+	tool := &arkv1alpha1.Tool{
+		ObjectMeta: metav1.ObjectMeta{Name: "get-weather", Namespace: "default"},
+		Spec: arkv1alpha1.ToolSpec{
+			Type:        ToolTypeMCP,
+			Description: "Gets the weather of a city",
+			MCP:         &arkv1alpha1.MCPToolRef{MCPServerRef: arkv1alpha1.MCPServerRef{Name: "weather"}, ToolName: "get_weather"},
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tool).Build()
+	agent := &arkv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "weather-agent", Namespace: "default"},
+		Spec:       arkv1alpha1.AgentSpec{Tools: []arkv1alpha1.AgentTool{{Type: "custom", Name: "get-weather"}}},
+	}
+	test := &arkv1alpha1.AgentTestSpec{
+		Agent:     "weather-agent",
+		MockTools: []arkv1alpha1.AgentTestMockTool{{Name: "get-weather", Response: `{"forecast": "sunny"}`}},
+	}
+
+	ctx := WithAgentTest(context.Background(), test)
+	assert.Nil(t, agentTestFor(ctx, &arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "other-agent"}}), "only the agent under test is mocked")
+
+	registry := NewToolRegistry(nil, noop.NewToolRecorder())
+	registry.test = agentTestFor(ctx, agent)
+	require.NoError(t, registry.registerTools(ctx, k8sClient, agent, noop.NewProvider()))
+	assert.Equal(t, "mock", registry.GetToolType("get-weather"))
+	assert.Equal(t, "Gets the weather of a city", registry.GetToolDefinitions()[0].Description)
+
+	result, err := registry.executors["get-weather"].Execute(ctx, ToolCall{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{Name: "get-weather", Arguments: `{"city":"Paris"}`}}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, ToolResult{ID: "call-1", Name: "get-weather", Content: `{"forecast": "sunny"}`}, result)
+
+	registry = NewToolRegistry(nil, noop.NewToolRecorder())
+	assert.Error(t, registry.registerTools(context.Background(), k8sClient, agent, noop.NewProvider()), "the tool runs when the agent is not under test")
+}
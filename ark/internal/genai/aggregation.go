@@ -0,0 +1,46 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const defaultAggregationPrompt = `You combine the answers several AI assistants gave to the same user request into a single answer.
+Keep what the answers agree on, resolve contradictions in favour of the best supported answer and leave out claims only one answer makes without support.
+Reply with the combined answer only, without mentioning that there were several answers.`
+
+// SynthesizeResponses asks a judge model to combine the answers given to the request into one answer
+func SynthesizeResponses(ctx context.Context, model *Model, recorder EventEmitter, prompt, request string, answers []string) (string, error) {
+	llmTracker := NewOperationTracker(recorder, ctx, "LLMCall", model.Model, map[string]string{
+		"model": model.Model,
+	})
+
+	var content strings.Builder
+	fmt.Fprintf(&content, "Request:\n%s", request)
+	for i, answer := range answers {
+		fmt.Fprintf(&content, "\n\nAnswer %d:\n%s", i+1, answer)
+	}
+	messages := []Message{
+		NewSystemMessage(prompt),
+		NewUserMessage(content.String()),
+	}
+	completion, err := model.ChatCompletion(ctx, messages, nil, 1)
+	if err != nil {
+		llmTracker.Fail(err)
+		return "", err
+	}
+	if completion == nil || len(completion.Choices) == 0 {
+		err := fmt.Errorf("judge model returned no completion choices")
+		llmTracker.Fail(err)
+		return "", err
+	}
+	llmTracker.CompleteWithTokens(TokenUsage{
+		PromptTokens:     completion.Usage.PromptTokens,
+		CompletionTokens: completion.Usage.CompletionTokens,
+		TotalTokens:      completion.Usage.TotalTokens,
+	})
+	return completion.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,21 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSynthesizeResponses(t *testing.T) {
+	model, provider := scriptedModel("Paris is the capital of France.")
+
+	answer, err := SynthesizeResponses(context.Background(), model, &mockEventRecorder{}, defaultAggregationPrompt, "What is the capital of France?", []string{"Paris.", "It is Paris."})
+	require.NoError(t, err)
+	assert.Equal(t, "Paris is the capital of France.", answer)
+	require.Len(t, provider.requests, 1)
+	assert.Equal(t, "Request:\nWhat is the capital of France?\n\nAnswer 1:\nParis.\n\nAnswer 2:\nIt is Paris.", provider.requests[0][1].OfUser.Content.OfString.Value)
+}
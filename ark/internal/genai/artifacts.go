@@ -0,0 +1,332 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
+	"mckinsey.com/ark/internal/labels"
+)
+
+// ArtifactsConfigMapName is the optional per-namespace ConfigMap that configures the artifact store
+const ArtifactsConfigMapName = "ark-config-artifacts"
+
+// DefaultArtifactMaxSizeBytes is the largest artifact accepted unless the namespace configures otherwise.
+// It keeps artifacts held in the fallback ConfigMap store under the ConfigMap size limit.
+const DefaultArtifactMaxSizeBytes = 512 * 1024
+
+const artifactCollectorKey contextKey = "artifactCollector"
+
+// Artifact is a named output emitted by an agent while executing a query
+type Artifact struct {
+	Name      string
+	MediaType string
+	Data      []byte
+}
+
+// Digest returns the sha256 digest of the artifact content
+func (a Artifact) Digest() string {
+	sum := sha256.Sum256(a.Data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// ArtifactCollector gathers the artifacts emitted during a query. Emitting an artifact with the
+// name of an earlier one replaces it.
+type ArtifactCollector struct {
+	mu           sync.Mutex
+	maxSizeBytes int64
+	artifacts    map[string]Artifact
+}
+
+func NewArtifactCollector(maxSizeBytes int64) *ArtifactCollector {
+	return &ArtifactCollector{maxSizeBytes: maxSizeBytes, artifacts: map[string]Artifact{}}
+}
+
+// Add records the artifact, rejecting it if it exceeds the size limit
+func (c *ArtifactCollector) Add(artifact Artifact) error {
+	if c.maxSizeBytes > 0 && int64(len(artifact.Data)) > c.maxSizeBytes {
+		return fmt.Errorf("artifact %s: size %d bytes exceeds the limit of %d bytes", artifact.Name, len(artifact.Data), c.maxSizeBytes)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.artifacts[artifact.Name] = artifact
+	return nil
+}
+
+// Artifacts returns the collected artifacts ordered by name
+func (c *ArtifactCollector) Artifacts() []Artifact {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	artifacts := make([]Artifact, 0, len(c.artifacts))
+	for _, artifact := range c.artifacts {
+		artifacts = append(artifacts, artifact)
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].Name < artifacts[j].Name })
+	return artifacts
+}
+
+// WithArtifactCollector makes the collector available to the publish-artifact tool
+func WithArtifactCollector(ctx context.Context, collector *ArtifactCollector) context.Context {
+	return context.WithValue(ctx, artifactCollectorKey, collector)
+}
+
+func artifactCollectorFromContext(ctx context.Context) *ArtifactCollector {
+	collector, _ := ctx.Value(artifactCollectorKey).(*ArtifactCollector)
+	return collector
+}
+
+// ArtifactConfig is the artifact store configuration of a namespace
+type ArtifactConfig struct {
+	MaxSizeBytes int64
+	// ServiceRef is the artifact store service; artifacts are kept in a ConfigMap owned by the query when unset
+	ServiceRef *arkv1alpha1.ServiceReference
+}
+
+// GetArtifactConfig reads the artifact store configuration from the namespace's artifacts ConfigMap
+func GetArtifactConfig(ctx context.Context, k8sClient client.Client, namespace string) (ArtifactConfig, error) {
+	config := ArtifactConfig{MaxSizeBytes: DefaultArtifactMaxSizeBytes}
+
+	cm := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: ArtifactsConfigMapName, Namespace: namespace}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return config, nil
+		}
+		return config, fmt.Errorf("failed to get artifacts ConfigMap: %w", err)
+	}
+
+	if value, ok := cm.Data["maxSizeBytes"]; ok {
+		maxSize, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil || maxSize <= 0 {
+			return config, fmt.Errorf("invalid maxSizeBytes %q in artifacts ConfigMap", value)
+		}
+		config.MaxSizeBytes = maxSize
+	}
+	if value, ok := cm.Data["serviceRef"]; ok {
+		config.ServiceRef = &arkv1alpha1.ServiceReference{}
+		if err := yaml.Unmarshal([]byte(value), config.ServiceRef); err != nil {
+			return config, fmt.Errorf("failed to parse serviceRef: %w", err)
+		}
+		if config.ServiceRef.Name == "" {
+			return config, fmt.Errorf("serviceRef must have a name")
+		}
+	}
+	return config, nil
+}
+
+// ArtifactStore uploads query artifacts and returns where each was stored
+type ArtifactStore interface {
+	Put(ctx context.Context, query *arkv1alpha1.Query, artifact Artifact) (string, error)
+}
+
+// NewArtifactStore returns the artifact store configured for the namespace
+func NewArtifactStore(ctx context.Context, k8sClient client.Client, config ArtifactConfig, namespace string) (ArtifactStore, error) {
+	if config.ServiceRef == nil {
+		return &ConfigMapArtifactStore{Client: k8sClient}, nil
+	}
+	baseURL, err := common.ResolveServiceReference(ctx, k8sClient, config.ServiceRef, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve artifact store service %s: %w", config.ServiceRef.Name, err)
+	}
+	return &HTTPArtifactStore{BaseURL: strings.TrimSuffix(baseURL, "/"), Client: common.NewHTTPClientWithLogging(ctx, common.HTTPClientArtifacts)}, nil
+}
+
+// HTTPArtifactStore uploads artifacts with PUT {baseURL}/artifacts/{namespace}/{query}/{name}
+type HTTPArtifactStore struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+func (s *HTTPArtifactStore) Put(ctx context.Context, query *arkv1alpha1.Query, artifact Artifact) (string, error) {
+	artifactURL := fmt.Sprintf("%s/artifacts/%s/%s/%s", s.BaseURL,
+		url.PathEscape(query.Namespace), url.PathEscape(query.Name), url.PathEscape(artifact.Name))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, artifactURL, bytes.NewReader(artifact.Data))
+	if err != nil {
+		return "", fmt.Errorf("failed to create artifact upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", artifact.MediaType)
+	req.Header.Set("Digest", artifact.Digest())
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload artifact %s: %w", artifact.Name, err)
+	}
+	defer func() {
+		_ = resp.Body.Close() // Standard defer pattern - error rarely meaningful
+	}()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("artifact store returned status %d for artifact %s", resp.StatusCode, artifact.Name)
+	}
+	return artifactURL, nil
+}
+
+// ConfigMapArtifactStore keeps artifacts in a ConfigMap named <query>-artifacts that is owned by the
+// query and labeled with its name, so the artifacts are removed together with it
+type ConfigMapArtifactStore struct {
+	Client client.Client
+}
+
+func (s *ConfigMapArtifactStore) Put(ctx context.Context, query *arkv1alpha1.Query, artifact Artifact) (string, error) {
+	if errs := validation.IsConfigMapKey(artifact.Name); len(errs) > 0 {
+		return "", fmt.Errorf("invalid artifact name %s: %s", artifact.Name, strings.Join(errs, ", "))
+	}
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: query.Name + "-artifacts", Namespace: query.Namespace}}
+	_, err := controllerutil.CreateOrUpdate(ctx, s.Client, cm, func() error {
+		// Never write artifacts into a ConfigMap that happens to have the name but belongs to something else
+		if cm.ResourceVersion != "" && !isOwnedBy(cm, query) {
+			return fmt.Errorf("ConfigMap %s already exists and is not owned by query %s", cm.Name, query.Name)
+		}
+		if size := configMapDataSize(cm, artifact.Name) + len(artifact.Data); size > corev1.MaxSecretSize {
+			return fmt.Errorf("artifacts of query %s would take %d bytes, exceeding the ConfigMap limit of %d bytes", query.Name, size, corev1.MaxSecretSize)
+		}
+
+		if cm.Labels == nil {
+			cm.Labels = map[string]string{}
+		}
+		cm.Labels[labels.QueryArtifactsLabel] = query.Name
+		if cm.BinaryData == nil {
+			cm.BinaryData = map[string][]byte{}
+		}
+		cm.BinaryData[artifact.Name] = artifact.Data
+		return controllerutil.SetOwnerReference(query, cm, s.Client.Scheme())
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store artifact %s: %w", artifact.Name, err)
+	}
+	return fmt.Sprintf("configmap://%s/%s/%s", cm.Namespace, cm.Name, artifact.Name), nil
+}
+
+// isOwnedBy reports whether the query is an owner of the ConfigMap
+func isOwnedBy(cm *corev1.ConfigMap, query *arkv1alpha1.Query) bool {
+	for _, ownerRef := range cm.OwnerReferences {
+		if ownerRef.UID == query.UID {
+			return true
+		}
+	}
+	return false
+}
+
+// configMapDataSize returns the size of the ConfigMap data, leaving out the key about to be replaced
+func configMapDataSize(cm *corev1.ConfigMap, replacedKey string) int {
+	size := 0
+	for key, value := range cm.Data {
+		if key != replacedKey {
+			size += len(value)
+		}
+	}
+	for key, value := range cm.BinaryData {
+		if key != replacedKey {
+			size += len(value)
+		}
+	}
+	return size
+}
+
+// PublishArtifacts uploads the artifacts to the store and returns their status entries
+func PublishArtifacts(ctx context.Context, store ArtifactStore, query *arkv1alpha1.Query, artifacts []Artifact) ([]arkv1alpha1.Artifact, error) {
+	published := make([]arkv1alpha1.Artifact, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		location, err := store.Put(ctx, query, artifact)
+		if err != nil {
+			return published, err
+		}
+		published = append(published, arkv1alpha1.Artifact{
+			Name:      artifact.Name,
+			MediaType: artifact.MediaType,
+			Digest:    artifact.Digest(),
+			Size:      int64(len(artifact.Data)),
+			URL:       location,
+		})
+	}
+	return published, nil
+}
+
+// PublishArtifactExecutor is the publish-artifact built-in tool. It records a named output of the
+// agent, which the controller uploads to the artifact store once the query completes.
+type PublishArtifactExecutor struct{}
+
+type publishArtifactArguments struct {
+	Name      string `json:"name"`
+	MediaType string `json:"mediaType"`
+	Content   string `json:"content"`
+	Encoding  string `json:"encoding"`
+}
+
+func (p *PublishArtifactExecutor) Execute(ctx context.Context, call ToolCall, recorder EventEmitter) (ToolResult, error) {
+	result := ToolResult{ID: call.ID, Name: call.Function.Name}
+
+	var arguments publishArtifactArguments
+	if err := json.Unmarshal([]byte(call.Function.Arguments), &arguments); err != nil {
+		result.Error = fmt.Sprintf("failed to parse arguments: %v", err)
+		return result, fmt.Errorf("failed to parse publish-artifact arguments: %w", err)
+	}
+
+	artifact, err := arguments.artifact()
+	if err == nil {
+		collector := artifactCollectorFromContext(ctx)
+		if collector == nil {
+			err = fmt.Errorf("artifacts cannot be published outside of a query")
+		} else {
+			err = collector.Add(artifact)
+		}
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result, err
+	}
+
+	result.Content = fmt.Sprintf("Published artifact %s (%s, %d bytes, %s)", artifact.Name, artifact.MediaType, len(artifact.Data), artifact.Digest())
+	return result, nil
+}
+
+func (a publishArtifactArguments) artifact() (Artifact, error) {
+	name := strings.TrimSpace(a.Name)
+	if name == "" || name != filepath.Base(name) || len(validation.IsConfigMapKey(name)) > 0 {
+		return Artifact{}, fmt.Errorf("artifact name %q must be a plain file name of letters, digits, '-', '_' and '.'", a.Name)
+	}
+
+	mediaType := strings.TrimSpace(a.MediaType)
+	if mediaType == "" {
+		mediaType = "text/plain"
+	}
+
+	data := []byte(a.Content)
+	switch a.Encoding {
+	case "", "text":
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(a.Content)
+		if err != nil {
+			return Artifact{}, fmt.Errorf("artifact %s: invalid base64 content: %w", name, err)
+		}
+		data = decoded
+	default:
+		return Artifact{}, fmt.Errorf("artifact %s: unsupported encoding %q", name, a.Encoding)
+	}
+	return Artifact{Name: name, MediaType: mediaType, Data: data}, nil
+}
@@ -0,0 +1,156 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/labels"
+)
+
+func publishArtifactCall(arguments string) ToolCall {
+	return ToolCall{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{Name: BuiltinToolPublishArtifact, Arguments: arguments}}
+}
+
+func TestPublishArtifactExecutor(t *testing.T) {
+	collector := NewArtifactCollector(16)
+	ctx := WithArtifactCollector(context.Background(), collector)
+	executor := &PublishArtifactExecutor{}
+
+	result, err := executor.Execute(ctx, publishArtifactCall(`{"name":"report.md","mediaType":"text/markdown","content":"# Draft"}`), nil)
+	require.NoError(t, err)
+	assert.Contains(t, result.Content, "report.md")
+
+	_, err = executor.Execute(ctx, publishArtifactCall(`{"name":"report.md","mediaType":"text/markdown","content":"# Final"}`), nil)
+	require.NoError(t, err)
+	_, err = executor.Execute(ctx, publishArtifactCall(`{"name":"chart.png","mediaType":"image/png","content":"iVBORw==","encoding":"base64"}`), nil)
+	require.NoError(t, err)
+
+	artifacts := collector.Artifacts()
+	require.Len(t, artifacts, 2)
+	assert.Equal(t, "chart.png", artifacts[0].Name)
+	assert.Equal(t, []byte{0x89, 'P', 'N', 'G'}, artifacts[0].Data)
+	assert.Equal(t, "# Final", string(artifacts[1].Data))
+
+	_, err = executor.Execute(ctx, publishArtifactCall(`{"name":"../secrets","content":"x"}`), nil)
+	assert.ErrorContains(t, err, "plain file name")
+	_, err = executor.Execute(ctx, publishArtifactCall(`{"name":"big.txt","content":"more than sixteen bytes"}`), nil)
+	assert.ErrorContains(t, err, "exceeds the limit")
+	_, err = executor.Execute(context.Background(), publishArtifactCall(`{"name":"report.md","content":"x"}`), nil)
+	assert.ErrorContains(t, err, "outside of a query")
+}
+
+func TestConfigMapArtifactStore(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = arkv1alpha1.AddToScheme(scheme)
+	query := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "weekly", Namespace: "default", UID: "query-uid"}}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(query).Build()
+	ctx := context.Background()
+
+	config, err := GetArtifactConfig(ctx, k8sClient, "default")
+	require.NoError(t, err)
+	store, err := NewArtifactStore(ctx, k8sClient, config, "default")
+	require.NoError(t, err)
+
+	published, err := PublishArtifacts(ctx, store, query, []Artifact{
+		{Name: "data.csv", MediaType: "text/csv", Data: []byte("a,b\n1,2")},
+		{Name: "report.md", MediaType: "text/markdown", Data: []byte("test")},
+	})
+	require.NoError(t, err)
+	require.Len(t, published, 2)
+	assert.Equal(t, "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", published[1].Digest)
+	assert.Equal(t, int64(4), published[1].Size)
+	assert.Equal(t, "configmap://default/weekly-artifacts/report.md", published[1].URL)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Name: "weekly-artifacts", Namespace: "default"}, cm))
+	assert.Equal(t, "a,b\n1,2", string(cm.BinaryData["data.csv"]))
+	require.Len(t, cm.OwnerReferences, 1)
+	assert.Equal(t, "weekly", cm.OwnerReferences[0].Name)
+	assert.Equal(t, "weekly", cm.Labels[labels.QueryArtifactsLabel])
+}
+
+func TestConfigMapArtifactStoreRejections(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = arkv1alpha1.AddToScheme(scheme)
+	ctx := context.Background()
+
+	t.Run("invalid key", func(t *testing.T) {
+		query := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "weekly", Namespace: "default", UID: "query-uid"}}
+		store := &ConfigMapArtifactStore{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(query).Build()}
+		_, err := store.Put(ctx, query, Artifact{Name: "weekly report.md", Data: []byte("x")})
+		assert.ErrorContains(t, err, "invalid artifact name")
+	})
+
+	t.Run("ConfigMap owned by something else", func(t *testing.T) {
+		query := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "weekly", Namespace: "default", UID: "query-uid"}}
+		existing := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "weekly-artifacts", Namespace: "default"},
+			Data:       map[string]string{"settings": "keep"},
+		}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(query, existing).Build()
+		store := &ConfigMapArtifactStore{Client: k8sClient}
+
+		_, err := store.Put(ctx, query, Artifact{Name: "report.md", Data: []byte("x")})
+		assert.ErrorContains(t, err, "not owned by query weekly")
+
+		cm := &corev1.ConfigMap{}
+		require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Name: "weekly-artifacts", Namespace: "default"}, cm))
+		assert.Empty(t, cm.BinaryData)
+		assert.Empty(t, cm.OwnerReferences)
+	})
+
+	t.Run("total size", func(t *testing.T) {
+		query := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "weekly", Namespace: "default", UID: "query-uid"}}
+		store := &ConfigMapArtifactStore{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(query).Build()}
+		half := make([]byte, corev1.MaxSecretSize/2+1)
+
+		_, err := store.Put(ctx, query, Artifact{Name: "first.bin", Data: half})
+		require.NoError(t, err)
+		// Replacing an artifact only counts its new content
+		_, err = store.Put(ctx, query, Artifact{Name: "first.bin", Data: half})
+		require.NoError(t, err)
+		_, err = store.Put(ctx, query, Artifact{Name: "second.bin", Data: half})
+		assert.ErrorContains(t, err, "exceeding the ConfigMap limit")
+	})
+}
+
+func TestHTTPArtifactStore(t *testing.T) {
+	var uploaded struct {
+		path, contentType, digest, body string
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		uploaded.path, uploaded.contentType, uploaded.digest, uploaded.body = r.URL.Path, r.Header.Get("Content-Type"), r.Header.Get("Digest"), string(body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	store := &HTTPArtifactStore{BaseURL: server.URL, Client: server.Client()}
+	query := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "weekly", Namespace: "default"}}
+	artifact := Artifact{Name: "report.md", MediaType: "text/markdown", Data: []byte("test")}
+
+	location, err := store.Put(context.Background(), query, artifact)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/artifacts/default/weekly/report.md", location)
+	assert.Equal(t, "/artifacts/default/weekly/report.md", uploaded.path)
+	assert.Equal(t, "text/markdown", uploaded.contentType)
+	assert.Equal(t, artifact.Digest(), uploaded.digest)
+	assert.Equal(t, "test", uploaded.body)
+}
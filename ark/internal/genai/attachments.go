@@ -0,0 +1,261 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/openai/openai-go"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// AttachmentsConfigMapName is the optional per-namespace ConfigMap with the attachment size and type policy
+const AttachmentsConfigMapName = "ark-config-attachments"
+
+const (
+	// DefaultAttachmentMaxSizeBytes is the largest attachment accepted unless the namespace configures otherwise
+	DefaultAttachmentMaxSizeBytes = 10 * 1024 * 1024
+	// DefaultAllowedAttachmentTypes are the media types accepted unless the namespace configures otherwise
+	DefaultAllowedAttachmentTypes = "image/png,image/jpeg,image/gif,image/webp,text/*,application/json,application/pdf"
+)
+
+const attachmentsKey contextKey = "attachments"
+
+// Attachment is a resolved query attachment. Content read from ConfigMaps and Secrets is held in Data;
+// URL attachments are passed on by URL. Path is set once the attachment has been written to a file for tools.
+type Attachment struct {
+	Name      string
+	MediaType string
+	Data      []byte
+	URL       string
+	Path      string
+}
+
+// AttachmentPolicy limits the size and media types of attachments
+type AttachmentPolicy struct {
+	MaxSizeBytes int64
+	AllowedTypes []string
+}
+
+// Allows reports whether the media type matches one of the allowed types. Types may use wildcards, e.g. text/*.
+func (p AttachmentPolicy) Allows(mediaType string) bool {
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	for _, allowed := range p.AllowedTypes {
+		if ok, _ := path.Match(allowed, mediaType); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAttachmentPolicy reads the attachment policy from the namespace's attachments ConfigMap
+func GetAttachmentPolicy(ctx context.Context, k8sClient client.Client, namespace string) (AttachmentPolicy, error) {
+	policy := AttachmentPolicy{
+		MaxSizeBytes: DefaultAttachmentMaxSizeBytes,
+		AllowedTypes: splitMediaTypes(DefaultAllowedAttachmentTypes),
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: AttachmentsConfigMapName, Namespace: namespace}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return policy, nil
+		}
+		return policy, fmt.Errorf("failed to get attachments ConfigMap: %w", err)
+	}
+
+	if value, ok := cm.Data["maxSizeBytes"]; ok {
+		maxSize, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil || maxSize <= 0 {
+			return policy, fmt.Errorf("invalid maxSizeBytes %q in attachments ConfigMap", value)
+		}
+		policy.MaxSizeBytes = maxSize
+	}
+	if value, ok := cm.Data["allowedMediaTypes"]; ok {
+		policy.AllowedTypes = splitMediaTypes(value)
+	}
+	return policy, nil
+}
+
+func splitMediaTypes(value string) []string {
+	var types []string
+	for _, mediaType := range strings.Split(value, ",") {
+		if mediaType = strings.ToLower(strings.TrimSpace(mediaType)); mediaType != "" {
+			types = append(types, mediaType)
+		}
+	}
+	return types
+}
+
+// LoadAttachments resolves the query's attachments and checks them against the namespace's attachment policy
+func LoadAttachments(ctx context.Context, k8sClient client.Client, query arkv1alpha1.Query) ([]Attachment, error) {
+	if len(query.Spec.Attachments) == 0 {
+		return nil, nil
+	}
+
+	policy, err := GetAttachmentPolicy(ctx, k8sClient, query.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]Attachment, 0, len(query.Spec.Attachments))
+	for _, spec := range query.Spec.Attachments {
+		if !policy.Allows(spec.MediaType) {
+			return nil, fmt.Errorf("attachment %s: media type %s is not allowed", spec.Name, spec.MediaType)
+		}
+
+		attachment := Attachment{Name: spec.Name, MediaType: spec.MediaType, URL: spec.Source.URL}
+		if attachment.URL == "" {
+			data, err := readAttachmentData(ctx, k8sClient, query.Namespace, spec.Source)
+			if err != nil {
+				return nil, fmt.Errorf("attachment %s: %w", spec.Name, err)
+			}
+			if int64(len(data)) > policy.MaxSizeBytes {
+				return nil, fmt.Errorf("attachment %s: size %d bytes exceeds the limit of %d bytes", spec.Name, len(data), policy.MaxSizeBytes)
+			}
+			attachment.Data = data
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+func readAttachmentData(ctx context.Context, k8sClient client.Client, namespace string, source arkv1alpha1.AttachmentSource) ([]byte, error) {
+	switch {
+	case source.ConfigMapKeyRef != nil:
+		ref := source.ConfigMapKeyRef
+		cm := &corev1.ConfigMap{}
+		if err := k8sClient.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: namespace}, cm); err != nil {
+			return nil, fmt.Errorf("failed to get ConfigMap %s: %w", ref.Name, err)
+		}
+		if value, ok := cm.BinaryData[ref.Key]; ok {
+			return value, nil
+		}
+		if value, ok := cm.Data[ref.Key]; ok {
+			return []byte(value), nil
+		}
+		return nil, fmt.Errorf("key %s not found in ConfigMap %s", ref.Key, ref.Name)
+	case source.SecretKeyRef != nil:
+		ref := source.SecretKeyRef
+		secret := &corev1.Secret{}
+		if err := k8sClient.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+			return nil, fmt.Errorf("failed to get Secret %s: %w", ref.Name, err)
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in Secret %s", ref.Key, ref.Name)
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("source must set one of configMapKeyRef, secretKeyRef or url")
+	}
+}
+
+// dataURL returns the attachment as a URL, encoding inline content as a data URL
+func (a Attachment) dataURL() string {
+	if a.URL != "" {
+		return a.URL
+	}
+	return fmt.Sprintf("data:%s;base64,%s", a.MediaType, base64.StdEncoding.EncodeToString(a.Data))
+}
+
+func (a Attachment) isText() bool {
+	return strings.HasPrefix(a.MediaType, "text/") || a.MediaType == "application/json"
+}
+
+// contentPart converts the attachment to message content: images as image parts, inline text as text
+// and anything else as a file part
+func (a Attachment) contentPart() openai.ChatCompletionContentPartUnionParam {
+	switch {
+	case strings.HasPrefix(a.MediaType, "image/"):
+		return openai.ImageContentPart(openai.ChatCompletionContentPartImageImageURLParam{URL: a.dataURL()})
+	case a.URL != "":
+		return openai.TextContentPart(fmt.Sprintf("Attachment %s (%s): %s", a.Name, a.MediaType, a.URL))
+	case a.isText():
+		return openai.TextContentPart(fmt.Sprintf("Attachment %s (%s):\n%s", a.Name, a.MediaType, a.Data))
+	default:
+		return openai.FileContentPart(openai.ChatCompletionContentPartFileFileParam{
+			FileData: openai.String(a.dataURL()),
+			Filename: openai.String(a.Name),
+		})
+	}
+}
+
+// AttachToMessages adds the attachments as content parts to the last user message
+func AttachToMessages(messages []Message, attachments []Attachment) []Message {
+	if len(attachments) == 0 {
+		return messages
+	}
+	for i := len(messages) - 1; i >= 0; i-- {
+		user := messages[i].OfUser
+		if user == nil {
+			continue
+		}
+
+		parts := append([]openai.ChatCompletionContentPartUnionParam{}, user.Content.OfArrayOfContentParts...)
+		if text := user.Content.OfString.Value; text != "" {
+			parts = []openai.ChatCompletionContentPartUnionParam{openai.TextContentPart(text)}
+		}
+		for _, attachment := range attachments {
+			parts = append(parts, attachment.contentPart())
+		}
+
+		userMessage := *user
+		userMessage.Content = openai.ChatCompletionUserMessageParamContentUnion{OfArrayOfContentParts: parts}
+
+		attached := append([]Message{}, messages...)
+		attached[i].OfUser = &userMessage
+		return attached
+	}
+	return messages
+}
+
+// WithAttachmentFiles writes inline attachments to a temporary directory so in-process tools can read
+// them from disk, and makes all attachments available through AttachmentsFromContext. The returned
+// cleanup function removes the files.
+func WithAttachmentFiles(ctx context.Context, attachments []Attachment) (context.Context, func(), error) {
+	if len(attachments) == 0 {
+		return ctx, func() {}, nil
+	}
+
+	dir, err := os.MkdirTemp("", "ark-attachments-")
+	if err != nil {
+		return ctx, func() {}, fmt.Errorf("failed to create attachments directory: %w", err)
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(dir); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to remove attachments directory", "dir", dir)
+		}
+	}
+
+	files := make([]Attachment, len(attachments))
+	for i, attachment := range attachments {
+		files[i] = attachment
+		if attachment.URL != "" {
+			continue
+		}
+		files[i].Path = filepath.Join(dir, filepath.Base(attachment.Name))
+		if err := os.WriteFile(files[i].Path, attachment.Data, 0o600); err != nil {
+			cleanup()
+			return ctx, func() {}, fmt.Errorf("failed to write attachment %s: %w", attachment.Name, err)
+		}
+	}
+	return context.WithValue(ctx, attachmentsKey, files), cleanup, nil
+}
+
+// AttachmentsFromContext returns the query attachments available to tools
+func AttachmentsFromContext(ctx context.Context) []Attachment {
+	attachments, _ := ctx.Value(attachmentsKey).([]Attachment)
+	return attachments
+}
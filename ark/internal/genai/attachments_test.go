@@ -0,0 +1,109 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func attachmentQuery(attachments ...arkv1alpha1.Attachment) arkv1alpha1.Query {
+	return arkv1alpha1.Query{
+		ObjectMeta: metav1.ObjectMeta{Name: "query", Namespace: "default"},
+		Spec:       arkv1alpha1.QuerySpec{Attachments: attachments},
+	}
+}
+
+func configMapAttachment(name, mediaType, key string) arkv1alpha1.Attachment {
+	return arkv1alpha1.Attachment{
+		Name:      name,
+		MediaType: mediaType,
+		Source: arkv1alpha1.AttachmentSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "files"},
+			Key:                  key,
+		}},
+	}
+}
+
+func TestLoadAttachments(t *testing.T) {
+	files := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "files", Namespace: "default"},
+		Data:       map[string]string{"report.csv": "a,b\n1,2\n3,4"},
+		BinaryData: map[string][]byte{"chart.png": {0x89, 'P', 'N', 'G'}},
+	}
+	policy := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: AttachmentsConfigMapName, Namespace: "default"},
+		Data:       map[string]string{"maxSizeBytes": "8", "allowedMediaTypes": "image/*, text/csv"},
+	}
+	k8sClient := fake.NewClientBuilder().WithObjects(files, policy).Build()
+	ctx := context.Background()
+
+	attachments, err := LoadAttachments(ctx, k8sClient, attachmentQuery(
+		configMapAttachment("chart.png", "image/png", "chart.png"),
+		arkv1alpha1.Attachment{Name: "scan", MediaType: "image/jpeg", Source: arkv1alpha1.AttachmentSource{URL: "https://bucket.example.com/scan.jpg"}},
+	))
+	require.NoError(t, err)
+	require.Len(t, attachments, 2)
+	assert.Equal(t, []byte{0x89, 'P', 'N', 'G'}, attachments[0].Data)
+	assert.Equal(t, "https://bucket.example.com/scan.jpg", attachments[1].URL)
+
+	_, err = LoadAttachments(ctx, k8sClient, attachmentQuery(configMapAttachment("report.csv", "text/csv", "report.csv")))
+	assert.ErrorContains(t, err, "exceeds the limit")
+
+	_, err = LoadAttachments(ctx, k8sClient, attachmentQuery(configMapAttachment("report.pdf", "application/pdf", "report.csv")))
+	assert.ErrorContains(t, err, "not allowed")
+
+	_, err = LoadAttachments(ctx, k8sClient, attachmentQuery(configMapAttachment("missing.png", "image/png", "missing.png")))
+	assert.ErrorContains(t, err, "key missing.png not found")
+}
+
+func TestAttachToMessages(t *testing.T) {
+	messages := []Message{NewSystemMessage("be helpful"), NewUserMessage("describe these")}
+	attachments := []Attachment{
+		{Name: "chart.png", MediaType: "image/png", Data: []byte("png")},
+		{Name: "notes.txt", MediaType: "text/plain", Data: []byte("remember the milk")},
+		{Name: "report.pdf", MediaType: "application/pdf", Data: []byte("pdf")},
+	}
+
+	attached := AttachToMessages(messages, attachments)
+
+	assert.Equal(t, "describe these", messages[1].OfUser.Content.OfString.Value, "input messages must not be modified")
+	parts := attached[1].OfUser.Content.OfArrayOfContentParts
+	require.Len(t, parts, 4)
+	assert.Equal(t, "describe these", parts[0].OfText.Text)
+	assert.Equal(t, "data:image/png;base64,cG5n", parts[1].OfImageURL.ImageURL.URL)
+	assert.Contains(t, parts[2].OfText.Text, "remember the milk")
+	assert.Equal(t, "report.pdf", parts[3].OfFile.File.Filename.Value)
+
+	assert.Contains(t, ExtractUserMessageContent(attached), "describe these")
+}
+
+func TestWithAttachmentFiles(t *testing.T) {
+	attachments := []Attachment{
+		{Name: "notes.txt", MediaType: "text/plain", Data: []byte("hello")},
+		{Name: "scan", MediaType: "image/jpeg", URL: "https://bucket.example.com/scan.jpg"},
+	}
+
+	ctx, cleanup, err := WithAttachmentFiles(context.Background(), attachments)
+	require.NoError(t, err)
+
+	files := AttachmentsFromContext(ctx)
+	require.Len(t, files, 2)
+	content, err := os.ReadFile(files[0].Path)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+	assert.Empty(t, files[1].Path)
+
+	cleanup()
+	_, err = os.Stat(files[0].Path)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,181 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const blackboardKey contextKey = "blackboard"
+
+// BlackboardStore is implemented by memory backends that persist the blackboards of executions
+type BlackboardStore interface {
+	GetBlackboard(ctx context.Context, executionID string) (map[string]any, error)
+	SetBlackboardEntry(ctx context.Context, executionID, key string, value any) error
+}
+
+// Blackboard is the shared key-value state of a single execution. Members of a team exchange structured
+// intermediate results on it with the blackboard tools, instead of passing them through the conversation.
+type Blackboard struct {
+	mu          sync.Mutex
+	executionID string
+	store       BlackboardStore
+	entries     map[string]any
+	loaded      bool
+}
+
+// NewBlackboard returns the blackboard of an execution. It is persisted in the memory when the memory
+// backend supports blackboards, and only kept for the execution otherwise.
+func NewBlackboard(memory MemoryInterface, executionID string) *Blackboard {
+	store, _ := memory.(BlackboardStore)
+	return &Blackboard{
+		executionID: executionID,
+		store:       store,
+		entries:     make(map[string]any),
+	}
+}
+
+// WithBlackboard makes the blackboard available to the tools executed with the context
+func WithBlackboard(ctx context.Context, blackboard *Blackboard) context.Context {
+	return context.WithValue(ctx, blackboardKey, blackboard)
+}
+
+func getBlackboard(ctx context.Context) *Blackboard {
+	blackboard, _ := ctx.Value(blackboardKey).(*Blackboard)
+	return blackboard
+}
+
+// load reads the entries written by earlier attempts of the execution from the store, once
+func (b *Blackboard) load(ctx context.Context) error {
+	if b.loaded || b.store == nil {
+		return nil
+	}
+	entries, err := b.store.GetBlackboard(ctx, b.executionID)
+	if err != nil {
+		return fmt.Errorf("failed to load blackboard: %w", err)
+	}
+	for key, value := range entries {
+		b.entries[key] = value
+	}
+	b.loaded = true
+	return nil
+}
+
+// Get returns the value of a key and whether the key is set
+func (b *Blackboard) Get(ctx context.Context, key string) (any, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.load(ctx); err != nil {
+		return nil, false, err
+	}
+	value, ok := b.entries[key]
+	return value, ok, nil
+}
+
+// Entries returns a copy of all entries
+func (b *Blackboard) Entries(ctx context.Context) (map[string]any, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.load(ctx); err != nil {
+		return nil, err
+	}
+	entries := make(map[string]any, len(b.entries))
+	for key, value := range b.entries {
+		entries[key] = value
+	}
+	return entries, nil
+}
+
+// Set writes the value of a key, to the store first so that a failed write leaves the blackboard unchanged
+func (b *Blackboard) Set(ctx context.Context, key string, value any) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.load(ctx); err != nil {
+		return err
+	}
+	if b.store != nil {
+		if err := b.store.SetBlackboardEntry(ctx, b.executionID, key, value); err != nil {
+			return fmt.Errorf("failed to save blackboard entry %s: %w", key, err)
+		}
+	}
+	b.entries[key] = value
+	return nil
+}
+
+// BlackboardReadExecutor is the blackboard-read built-in tool. It returns one entry of the execution's
+// blackboard, or all entries when no key is given.
+type BlackboardReadExecutor struct{}
+
+func (e *BlackboardReadExecutor) Execute(ctx context.Context, call ToolCall, recorder EventEmitter) (ToolResult, error) {
+	result := ToolResult{ID: call.ID, Name: call.Function.Name}
+
+	var arguments struct {
+		Key string `json:"key"`
+	}
+	var content any
+	err := json.Unmarshal([]byte(call.Function.Arguments), &arguments)
+	key := strings.TrimSpace(arguments.Key)
+	blackboard := getBlackboard(ctx)
+	switch {
+	case err != nil:
+		err = fmt.Errorf("failed to parse blackboard-read arguments: %w", err)
+	case blackboard == nil:
+		err = fmt.Errorf("the execution has no blackboard")
+	case key == "":
+		content, err = blackboard.Entries(ctx)
+	default:
+		var ok bool
+		content, ok, err = blackboard.Get(ctx, key)
+		if err == nil && !ok {
+			result.Content = fmt.Sprintf("No entry for key %s", key)
+			return result, nil
+		}
+	}
+	if err == nil {
+		var data []byte
+		if data, err = json.Marshal(content); err == nil {
+			result.Content = string(data)
+			return result, nil
+		}
+	}
+	result.Error = err.Error()
+	return result, err
+}
+
+// BlackboardWriteExecutor is the blackboard-write built-in tool. It sets an entry of the execution's blackboard.
+type BlackboardWriteExecutor struct{}
+
+func (e *BlackboardWriteExecutor) Execute(ctx context.Context, call ToolCall, recorder EventEmitter) (ToolResult, error) {
+	result := ToolResult{ID: call.ID, Name: call.Function.Name}
+
+	var arguments struct {
+		Key   string `json:"key"`
+		Value any    `json:"value"`
+	}
+	err := json.Unmarshal([]byte(call.Function.Arguments), &arguments)
+	key := strings.TrimSpace(arguments.Key)
+	blackboard := getBlackboard(ctx)
+	switch {
+	case err != nil:
+		err = fmt.Errorf("failed to parse blackboard-write arguments: %w", err)
+	case key == "":
+		err = fmt.Errorf("key is required")
+	case arguments.Value == nil:
+		err = fmt.Errorf("value is required")
+	case blackboard == nil:
+		err = fmt.Errorf("the execution has no blackboard")
+	default:
+		err = blackboard.Set(ctx, key, arguments.Value)
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result, err
+	}
+
+	result.Content = fmt.Sprintf("Wrote %s to the blackboard", key)
+	return result, nil
+}
@@ -0,0 +1,159 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// fakeBlackboardStore is a memory backend that keeps blackboards in a map
+type fakeBlackboardStore struct {
+	NoopMemory
+	blackboards map[string]map[string]any
+	err         error
+}
+
+func (s *fakeBlackboardStore) GetBlackboard(ctx context.Context, executionID string) (map[string]any, error) {
+	return s.blackboards[executionID], s.err
+}
+
+func (s *fakeBlackboardStore) SetBlackboardEntry(ctx context.Context, executionID, key string, value any) error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.blackboards[executionID] == nil {
+		s.blackboards[executionID] = map[string]any{}
+	}
+	s.blackboards[executionID][key] = value
+	return nil
+}
+
+func blackboardToolCall(name, arguments string) ToolCall {
+	call := ToolCall{ID: "call-1"}
+	call.Function.Name = name
+	call.Function.Arguments = arguments
+	return call
+}
+
+func TestBlackboard(t *testing.T) {
+	t.Run("persists entries in a memory that supports blackboards", func(t *testing.T) {
+		store := &fakeBlackboardStore{blackboards: map[string]map[string]any{
+			"query-1": {"sources": "earlier attempt"},
+		}}
+		blackboard := NewBlackboard(store, "query-1")
+
+		value, ok, err := blackboard.Get(context.Background(), "sources")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "earlier attempt", value)
+
+		require.NoError(t, blackboard.Set(context.Background(), "figures", []any{1.0, 2.0}))
+		assert.Equal(t, []any{1.0, 2.0}, store.blackboards["query-1"]["figures"])
+	})
+
+	t.Run("keeps entries for the execution without a blackboard store", func(t *testing.T) {
+		blackboard := NewBlackboard(NewNoopMemory(), "query-1")
+		require.NoError(t, blackboard.Set(context.Background(), "sources", "report.pdf"))
+
+		entries, err := blackboard.Entries(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"sources": "report.pdf"}, entries)
+	})
+
+	t.Run("a failed write leaves the entry unset", func(t *testing.T) {
+		store := &fakeBlackboardStore{blackboards: map[string]map[string]any{}}
+		blackboard := NewBlackboard(store, "query-1")
+		_, err := blackboard.Entries(context.Background())
+		require.NoError(t, err)
+
+		store.err = errors.New("memory unavailable")
+		require.ErrorContains(t, blackboard.Set(context.Background(), "sources", "report.pdf"), "memory unavailable")
+		_, ok, _ := blackboard.Get(context.Background(), "sources")
+		assert.False(t, ok)
+	})
+}
+
+func TestBlackboardTools(t *testing.T) {
+	ctx := WithBlackboard(context.Background(), NewBlackboard(NewNoopMemory(), "query-1"))
+	write := &BlackboardWriteExecutor{}
+	read := &BlackboardReadExecutor{}
+
+	result, err := write.Execute(ctx, blackboardToolCall(BuiltinToolBlackboardWrite, `{"key": "figures", "value": {"revenue": 12}}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Wrote figures to the blackboard", result.Content)
+
+	result, err = read.Execute(ctx, blackboardToolCall(BuiltinToolBlackboardRead, `{"key": "figures"}`), nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"revenue": 12}`, result.Content)
+
+	result, err = read.Execute(ctx, blackboardToolCall(BuiltinToolBlackboardRead, `{}`), nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"figures": {"revenue": 12}}`, result.Content)
+
+	result, err = read.Execute(ctx, blackboardToolCall(BuiltinToolBlackboardRead, `{"key": "sources"}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "No entry for key sources", result.Content)
+
+	_, err = write.Execute(ctx, blackboardToolCall(BuiltinToolBlackboardWrite, `{"key": "sources"}`), nil)
+	require.EqualError(t, err, "value is required")
+
+	_, err = read.Execute(context.Background(), blackboardToolCall(BuiltinToolBlackboardRead, `{}`), nil)
+	require.EqualError(t, err, "the execution has no blackboard")
+}
+
+func TestHTTPMemoryBlackboard(t *testing.T) {
+	var stored map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/blackboards/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodGet && r.URL.Path == "/blackboards/query-1":
+			assert.Equal(t, "session-1", r.URL.Query().Get("session_id"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"entries": map[string]any{"sources": "report.pdf"}})
+		case r.Method == http.MethodPut && r.URL.Path == "/blackboards/query-1/entries/figures":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&stored))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, arkv1alpha1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&arkv1alpha1.Memory{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"},
+		Spec:       arkv1alpha1.MemorySpec{Address: arkv1alpha1.ValueSource{Value: server.URL}},
+	}).Build()
+	memory := &HTTPMemory{
+		client:     k8sClient,
+		httpClient: server.Client(),
+		baseURL:    server.URL,
+		sessionId:  "session-1",
+		name:       "default",
+		namespace:  "default",
+	}
+
+	entries, err := memory.GetBlackboard(context.Background(), "query-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"sources": "report.pdf"}, entries)
+
+	entries, err = memory.GetBlackboard(context.Background(), "missing")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	require.NoError(t, memory.SetBlackboardEntry(context.Background(), "query-1", "figures", 12))
+	assert.Equal(t, map[string]any{"session_id": "session-1", "value": 12.0}, stored)
+}
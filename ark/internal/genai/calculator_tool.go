@@ -0,0 +1,299 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxCalculatorExpressionLength bounds the expressions that the calculator evaluates
+const maxCalculatorExpressionLength = 1000
+
+// calculatorConstants are the named constants available in calculator expressions
+var calculatorConstants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+// calculatorFunctions are the functions available in calculator expressions, by name and arity. An arity
+// of -1 means one or more arguments.
+var calculatorFunctions = map[string]struct {
+	arity int
+	apply func(args []float64) float64
+}{
+	"abs":   {1, func(a []float64) float64 { return math.Abs(a[0]) }},
+	"sqrt":  {1, func(a []float64) float64 { return math.Sqrt(a[0]) }},
+	"cbrt":  {1, func(a []float64) float64 { return math.Cbrt(a[0]) }},
+	"exp":   {1, func(a []float64) float64 { return math.Exp(a[0]) }},
+	"ln":    {1, func(a []float64) float64 { return math.Log(a[0]) }},
+	"log":   {1, func(a []float64) float64 { return math.Log10(a[0]) }},
+	"log2":  {1, func(a []float64) float64 { return math.Log2(a[0]) }},
+	"sin":   {1, func(a []float64) float64 { return math.Sin(a[0]) }},
+	"cos":   {1, func(a []float64) float64 { return math.Cos(a[0]) }},
+	"tan":   {1, func(a []float64) float64 { return math.Tan(a[0]) }},
+	"floor": {1, func(a []float64) float64 { return math.Floor(a[0]) }},
+	"ceil":  {1, func(a []float64) float64 { return math.Ceil(a[0]) }},
+	"round": {1, func(a []float64) float64 { return math.Round(a[0]) }},
+	"pow":   {2, func(a []float64) float64 { return math.Pow(a[0], a[1]) }},
+	"min": {-1, func(a []float64) float64 {
+		result := a[0]
+		for _, v := range a[1:] {
+			result = math.Min(result, v)
+		}
+		return result
+	}},
+	"max": {-1, func(a []float64) float64 {
+		result := a[0]
+		for _, v := range a[1:] {
+			result = math.Max(result, v)
+		}
+		return result
+	}},
+}
+
+// CalculatorExecutor is the calculator built-in tool. It evaluates an arithmetic expression, so that
+// agents do not have to do arithmetic themselves.
+type CalculatorExecutor struct{}
+
+type calculatorArguments struct {
+	Expression string `json:"expression"`
+}
+
+func (c *CalculatorExecutor) Execute(ctx context.Context, call ToolCall, recorder EventEmitter) (ToolResult, error) {
+	result := ToolResult{ID: call.ID, Name: call.Function.Name}
+
+	var arguments calculatorArguments
+	if err := json.Unmarshal([]byte(call.Function.Arguments), &arguments); err != nil {
+		result.Error = fmt.Sprintf("failed to parse arguments: %v", err)
+		return result, fmt.Errorf("failed to parse calculator arguments: %w", err)
+	}
+
+	value, err := Calculate(arguments.Expression)
+	if err != nil {
+		result.Error = err.Error()
+		return result, err
+	}
+	result.Content = strconv.FormatFloat(value, 'g', -1, 64)
+	return result, nil
+}
+
+// Calculate evaluates an arithmetic expression with the operators + - * / % ^, parentheses, the
+// constants pi and e, and functions such as sqrt, round and max
+func Calculate(expression string) (float64, error) {
+	if strings.TrimSpace(expression) == "" {
+		return 0, fmt.Errorf("expression is required")
+	}
+	if len(expression) > maxCalculatorExpressionLength {
+		return 0, fmt.Errorf("expression is longer than %d characters", maxCalculatorExpressionLength)
+	}
+
+	p := &calculatorParser{input: expression}
+	value, err := p.parseExpression()
+	if err != nil {
+		return 0, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return 0, fmt.Errorf("unexpected %q at position %d", p.input[p.pos], p.pos+1)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("the result is not a finite number")
+	}
+	return value, nil
+}
+
+// calculatorParser is a recursive descent parser that evaluates while it parses:
+//
+//	expression = term { ("+" | "-") term }
+//	term       = unary { ("*" | "/" | "%") unary }
+//	unary      = ("+" | "-") unary | power
+//	power      = primary [ "^" unary ]
+//	primary    = number | constant | function "(" expression { "," expression } ")" | "(" expression ")"
+type calculatorParser struct {
+	input string
+	pos   int
+}
+
+func (p *calculatorParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// consume skips the operator if it is next in the input
+func (p *calculatorParser) consume(operator byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == operator {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *calculatorParser) parseExpression() (float64, error) {
+	value, err := p.parseTerm()
+	for err == nil {
+		var right float64
+		switch {
+		case p.consume('+'):
+			if right, err = p.parseTerm(); err == nil {
+				value += right
+			}
+		case p.consume('-'):
+			if right, err = p.parseTerm(); err == nil {
+				value -= right
+			}
+		default:
+			return value, nil
+		}
+	}
+	return 0, err
+}
+
+func (p *calculatorParser) parseTerm() (float64, error) {
+	value, err := p.parseUnary()
+	for err == nil {
+		var right float64
+		switch {
+		case p.consume('*'):
+			if right, err = p.parseUnary(); err == nil {
+				value *= right
+			}
+		case p.consume('/'):
+			if right, err = p.parseUnary(); err == nil {
+				if right == 0 {
+					return 0, fmt.Errorf("division by zero")
+				}
+				value /= right
+			}
+		case p.consume('%'):
+			if right, err = p.parseUnary(); err == nil {
+				if right == 0 {
+					return 0, fmt.Errorf("division by zero")
+				}
+				value = math.Mod(value, right)
+			}
+		default:
+			return value, nil
+		}
+	}
+	return 0, err
+}
+
+func (p *calculatorParser) parseUnary() (float64, error) {
+	if p.consume('-') {
+		value, err := p.parseUnary()
+		return -value, err
+	}
+	if p.consume('+') {
+		return p.parseUnary()
+	}
+	return p.parsePower()
+}
+
+func (p *calculatorParser) parsePower() (float64, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return 0, err
+	}
+	if !p.consume('^') {
+		return base, nil
+	}
+	// ^ is right associative and binds tighter than unary minus on its left: -2^2 is -4
+	exponent, err := p.parseUnary()
+	if err != nil {
+		return 0, err
+	}
+	return math.Pow(base, exponent), nil
+}
+
+func (p *calculatorParser) parsePrimary() (float64, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return 0, fmt.Errorf("unexpected end of expression")
+	}
+
+	if p.consume('(') {
+		value, err := p.parseExpression()
+		if err != nil {
+			return 0, err
+		}
+		if !p.consume(')') {
+			return 0, fmt.Errorf("missing closing parenthesis at position %d", p.pos+1)
+		}
+		return value, nil
+	}
+
+	start := p.pos
+	char := p.input[p.pos]
+	switch {
+	case char >= '0' && char <= '9' || char == '.':
+		for p.pos < len(p.input) && (p.input[p.pos] >= '0' && p.input[p.pos] <= '9' || p.input[p.pos] == '.') {
+			p.pos++
+		}
+		// Exponent notation, e.g. 1.5e3
+		if p.pos < len(p.input) && (p.input[p.pos] == 'e' || p.input[p.pos] == 'E') {
+			next := p.pos + 1
+			if next < len(p.input) && (p.input[next] == '+' || p.input[next] == '-') {
+				next++
+			}
+			if next < len(p.input) && p.input[next] >= '0' && p.input[next] <= '9' {
+				p.pos = next
+				for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+					p.pos++
+				}
+			}
+		}
+		value, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q", p.input[start:p.pos])
+		}
+		return value, nil
+	case unicode.IsLetter(rune(char)):
+		for p.pos < len(p.input) && (unicode.IsLetter(rune(p.input[p.pos])) || unicode.IsDigit(rune(p.input[p.pos]))) {
+			p.pos++
+		}
+		return p.parseName(strings.ToLower(p.input[start:p.pos]))
+	default:
+		return 0, fmt.Errorf("unexpected %q at position %d", char, p.pos+1)
+	}
+}
+
+// parseName evaluates a constant, or a call of the function of that name
+func (p *calculatorParser) parseName(name string) (float64, error) {
+	function, isFunction := calculatorFunctions[name]
+	if !isFunction {
+		if value, ok := calculatorConstants[name]; ok {
+			return value, nil
+		}
+		return 0, fmt.Errorf("unknown name %q", name)
+	}
+
+	if !p.consume('(') {
+		return 0, fmt.Errorf("function %s must be called with parentheses", name)
+	}
+	var args []float64
+	for {
+		value, err := p.parseExpression()
+		if err != nil {
+			return 0, err
+		}
+		args = append(args, value)
+		if p.consume(')') {
+			break
+		}
+		if !p.consume(',') {
+			return 0, fmt.Errorf("missing closing parenthesis at position %d", p.pos+1)
+		}
+	}
+	if function.arity >= 0 && len(args) != function.arity {
+		return 0, fmt.Errorf("function %s takes %d arguments, got %d", name, function.arity, len(args))
+	}
+	return function.apply(args), nil
+}
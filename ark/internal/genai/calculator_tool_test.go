@@ -0,0 +1,73 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 / 4", 2.5},
+		{"10 % 4", 2},
+		{"2 ^ 3 ^ 2", 512},
+		{"-2 ^ 2", -4},
+		{"2 ^ -1", 0.5},
+		{"--3", 3},
+		{"1.5e3 + .5", 1500.5},
+		{"round(1250 * 1.08 ^ 3)", 1575},
+		{"sqrt(16) + abs(-2)", 6},
+		{"max(1, 7, 3) - min(4, 2)", 5},
+		{"pow(2, 10)", 1024},
+		{"round(PI * 100) / 100", 3.14},
+		{"ln(e)", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			value, err := Calculate(tt.expression)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, value, 1e-9)
+		})
+	}
+
+	errorTests := []struct {
+		expression string
+		expected   string
+	}{
+		{"", "expression is required"},
+		{"1 / 0", "division by zero"},
+		{"(1 + 2", "missing closing parenthesis"},
+		{"1 + ", "unexpected end of expression"},
+		{"2 3", `unexpected '3' at position 3`},
+		{"foo(1)", `unknown name "foo"`},
+		{"sqrt 4", "function sqrt must be called with parentheses"},
+		{"pow(2)", "function pow takes 2 arguments, got 1"},
+		{"sqrt(-1)", "the result is not a finite number"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := Calculate(tt.expression)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func TestCalculatorExecutor(t *testing.T) {
+	executor := &CalculatorExecutor{}
+	result, err := executor.Execute(context.Background(), ToolCall{
+		ID:       "call-1",
+		Function: openai.ChatCompletionMessageToolCallFunction{Name: BuiltinToolCalculator, Arguments: `{"expression": "0.1 + 0.2 * 10"}`},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "2.1", result.Content)
+}
@@ -0,0 +1,344 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Chart types and output formats supported by the render-chart tool
+const (
+	ChartTypeBar   = "bar"
+	ChartTypeLine  = "line"
+	ChartTypeTable = "table"
+
+	ChartFormatSVG      = "svg"
+	ChartFormatPNG      = "png"
+	ChartFormatMarkdown = "markdown"
+)
+
+const (
+	chartWidth   = 800
+	chartHeight  = 480
+	chartMargin  = 60
+	chartMaxRows = 500
+)
+
+// chartPalette colors the series of a chart in order
+var chartPalette = []color.RGBA{
+	{0x1f, 0x77, 0xb4, 0xff},
+	{0xff, 0x7f, 0x0e, 0xff},
+	{0x2c, 0xa0, 0x2c, 0xff},
+	{0xd6, 0x27, 0x28, 0xff},
+	{0x94, 0x67, 0xbd, 0xff},
+	{0x8c, 0x56, 0x4b, 0xff},
+}
+
+// ChartSeries is a named series of values, one per label
+type ChartSeries struct {
+	Name   string    `json:"name"`
+	Values []float64 `json:"values"`
+}
+
+// ChartSpec is the structured data rendered by the render-chart tool
+type ChartSpec struct {
+	Name   string        `json:"name"`
+	Type   string        `json:"type"`
+	Format string        `json:"format"`
+	Title  string        `json:"title"`
+	Labels []string      `json:"labels"`
+	Series []ChartSeries `json:"series"`
+}
+
+// RenderChartExecutor is the render-chart built-in tool. It renders structured data as a bar or line
+// chart (SVG or PNG) or as a markdown table and publishes the result as a query artifact.
+type RenderChartExecutor struct{}
+
+func (r *RenderChartExecutor) Execute(ctx context.Context, call ToolCall, recorder EventEmitter) (ToolResult, error) {
+	result := ToolResult{ID: call.ID, Name: call.Function.Name}
+
+	var spec ChartSpec
+	if err := json.Unmarshal([]byte(call.Function.Arguments), &spec); err != nil {
+		result.Error = fmt.Sprintf("failed to parse arguments: %v", err)
+		return result, fmt.Errorf("failed to parse render-chart arguments: %w", err)
+	}
+
+	artifact, err := RenderChart(spec)
+	if err == nil {
+		collector := artifactCollectorFromContext(ctx)
+		if collector == nil {
+			err = fmt.Errorf("charts cannot be published outside of a query")
+		} else {
+			err = collector.Add(artifact)
+		}
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result, err
+	}
+
+	result.Content = fmt.Sprintf("Published artifact %s (%s, %d bytes)", artifact.Name, artifact.MediaType, len(artifact.Data))
+	if artifact.MediaType == "text/markdown" {
+		// Return the table as well so the agent can include it in its answer
+		result.Content += "\n\n" + string(artifact.Data)
+	}
+	return result, nil
+}
+
+// RenderChart renders the chart spec as an artifact
+func RenderChart(spec ChartSpec) (Artifact, error) {
+	if err := spec.normalize(); err != nil {
+		return Artifact{}, err
+	}
+
+	switch spec.Format {
+	case ChartFormatMarkdown:
+		return Artifact{Name: spec.Name, MediaType: "text/markdown", Data: []byte(spec.markdownTable())}, nil
+	case ChartFormatSVG:
+		return Artifact{Name: spec.Name, MediaType: "image/svg+xml", Data: []byte(spec.svg())}, nil
+	default:
+		data, err := spec.png()
+		if err != nil {
+			return Artifact{}, err
+		}
+		return Artifact{Name: spec.Name, MediaType: "image/png", Data: data}, nil
+	}
+}
+
+// normalize applies defaults and validates the spec
+func (s *ChartSpec) normalize() error {
+	if s.Type == "" {
+		s.Type = ChartTypeBar
+	}
+	if s.Format == "" {
+		s.Format = ChartFormatSVG
+		if s.Type == ChartTypeTable {
+			s.Format = ChartFormatMarkdown
+		}
+	}
+
+	switch s.Type {
+	case ChartTypeBar, ChartTypeLine:
+		if s.Format != ChartFormatSVG && s.Format != ChartFormatPNG {
+			return fmt.Errorf("%s charts can be rendered as svg or png, not %s", s.Type, s.Format)
+		}
+	case ChartTypeTable:
+		if s.Format != ChartFormatMarkdown {
+			return fmt.Errorf("tables can only be rendered as markdown, not %s", s.Format)
+		}
+	default:
+		return fmt.Errorf("unsupported chart type %q: supported types are bar, line and table", s.Type)
+	}
+
+	if len(s.Labels) == 0 || len(s.Series) == 0 {
+		return fmt.Errorf("labels and at least one series are required")
+	}
+	if len(s.Labels) > chartMaxRows {
+		return fmt.Errorf("at most %d labels are supported, got %d", chartMaxRows, len(s.Labels))
+	}
+	for i, series := range s.Series {
+		if len(series.Values) != len(s.Labels) {
+			return fmt.Errorf("series %d has %d values but there are %d labels", i, len(series.Values), len(s.Labels))
+		}
+		for _, value := range series.Values {
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				return fmt.Errorf("series %d contains a value that is not a finite number", i)
+			}
+		}
+	}
+
+	extension := map[string]string{ChartFormatSVG: ".svg", ChartFormatPNG: ".png", ChartFormatMarkdown: ".md"}[s.Format]
+	if s.Name == "" {
+		s.Name = "chart" + extension
+		if s.Type == ChartTypeTable {
+			s.Name = "table" + extension
+		}
+	}
+	_, err := publishArtifactArguments{Name: s.Name}.artifact()
+	return err
+}
+
+func (s ChartSpec) markdownTable() string {
+	escape := func(value string) string { return strings.ReplaceAll(value, "|", `\|`) }
+
+	var b strings.Builder
+	if s.Title != "" {
+		fmt.Fprintf(&b, "**%s**\n\n", s.Title)
+	}
+	b.WriteString("| |")
+	for _, series := range s.Series {
+		fmt.Fprintf(&b, " %s |", escape(series.Name))
+	}
+	b.WriteString("\n|---|")
+	b.WriteString(strings.Repeat("---:|", len(s.Series)))
+	for i, label := range s.Labels {
+		fmt.Fprintf(&b, "\n| %s |", escape(label))
+		for _, series := range s.Series {
+			fmt.Fprintf(&b, " %s |", strconv.FormatFloat(series.Values[i], 'f', -1, 64))
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// chartLayout maps values to pixel coordinates of the plot area
+type chartLayout struct {
+	spec       ChartSpec
+	minValue   float64
+	maxValue   float64
+	left, top  float64
+	plotWidth  float64
+	plotHeight float64
+}
+
+func newChartLayout(spec ChartSpec) chartLayout {
+	minValue, maxValue := 0.0, 0.0
+	for _, series := range spec.Series {
+		for _, value := range series.Values {
+			minValue = math.Min(minValue, value)
+			maxValue = math.Max(maxValue, value)
+		}
+	}
+	if maxValue == minValue {
+		maxValue = minValue + 1
+	}
+	return chartLayout{
+		spec:       spec,
+		minValue:   minValue,
+		maxValue:   maxValue,
+		left:       chartMargin,
+		top:        chartMargin,
+		plotWidth:  chartWidth - 2*chartMargin,
+		plotHeight: chartHeight - 2*chartMargin,
+	}
+}
+
+func (l chartLayout) y(value float64) float64 {
+	return l.top + l.plotHeight*(l.maxValue-value)/(l.maxValue-l.minValue)
+}
+
+// slot returns the left edge and width of the space for the label at index i
+func (l chartLayout) slot(i int) (float64, float64) {
+	width := l.plotWidth / float64(len(l.spec.Labels))
+	return l.left + width*float64(i), width
+}
+
+// bar returns the rectangle of the bar for the series and label
+func (l chartLayout) bar(series, label int) (x, y, width, height float64) {
+	slotX, slotWidth := l.slot(label)
+	groupWidth := slotWidth * 0.8
+	width = groupWidth / float64(len(l.spec.Series))
+	x = slotX + slotWidth*0.1 + width*float64(series)
+	top, bottom := l.y(l.spec.Series[series].Values[label]), l.y(0)
+	return x, math.Min(top, bottom), width, math.Abs(bottom - top)
+}
+
+// point returns the position of the value of the series at the label on a line chart
+func (l chartLayout) point(series, label int) (float64, float64) {
+	slotX, slotWidth := l.slot(label)
+	return slotX + slotWidth/2, l.y(l.spec.Series[series].Values[label])
+}
+
+func (s ChartSpec) svg() string {
+	layout := newChartLayout(s)
+	hex := func(c color.RGBA) string { return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B) }
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`, chartWidth, chartHeight, chartWidth, chartHeight)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#ffffff"/>`, chartWidth, chartHeight)
+	if s.Title != "" {
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle" font-size="16">%s</text>`, chartWidth/2, chartMargin/2, html.EscapeString(s.Title))
+	}
+
+	zero := layout.y(0)
+	fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#333333"/>`, layout.left, layout.top, layout.left, layout.top+layout.plotHeight)
+	fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#333333"/>`, layout.left, zero, layout.left+layout.plotWidth, zero)
+	for _, value := range []float64{layout.minValue, layout.maxValue} {
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="end" dominant-baseline="middle">%s</text>`, layout.left-6, layout.y(value), strconv.FormatFloat(value, 'g', 6, 64))
+	}
+	for i, label := range s.Labels {
+		slotX, slotWidth := layout.slot(i)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`, slotX+slotWidth/2, layout.top+layout.plotHeight+18, html.EscapeString(label))
+	}
+
+	for si, series := range s.Series {
+		fill := hex(chartPalette[si%len(chartPalette)])
+		if s.Type == ChartTypeBar {
+			for li := range s.Labels {
+				x, y, width, height := layout.bar(si, li)
+				fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`, x, y, width, height, fill)
+			}
+		} else {
+			points := make([]string, len(s.Labels))
+			for li := range s.Labels {
+				x, y := layout.point(si, li)
+				points[li] = fmt.Sprintf("%.1f,%.1f", x, y)
+			}
+			fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`, strings.Join(points, " "), fill)
+		}
+		legendY := chartHeight - chartMargin/3
+		legendX := chartMargin + si*140
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="10" height="10" fill="%s"/>`, legendX, legendY-10, fill)
+		fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`, legendX+14, legendY, html.EscapeString(series.Name))
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// png renders the chart without text; titles, labels and the legend are only part of the SVG output
+func (s ChartSpec) png() ([]byte, error) {
+	layout := newChartLayout(s)
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+
+	axis := color.RGBA{0x33, 0x33, 0x33, 0xff}
+	fillRect(img, layout.left, layout.top, 1, layout.plotHeight, axis)
+	fillRect(img, layout.left, layout.y(0), layout.plotWidth, 1, axis)
+
+	for si := range s.Series {
+		c := chartPalette[si%len(chartPalette)]
+		for li := range s.Labels {
+			if s.Type == ChartTypeBar {
+				x, y, width, height := layout.bar(si, li)
+				fillRect(img, x, y, width, height, c)
+				continue
+			}
+			if li > 0 {
+				x0, y0 := layout.point(si, li-1)
+				x1, y1 := layout.point(si, li)
+				drawLine(img, x0, y0, x1, y1, c)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode chart: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func fillRect(img *image.RGBA, x, y, width, height float64, c color.RGBA) {
+	rect := image.Rect(int(math.Round(x)), int(math.Round(y)), int(math.Round(x+math.Max(width, 1))), int(math.Round(y+math.Max(height, 1))))
+	draw.Draw(img, rect, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// drawLine draws a line two pixels wide between the points
+func drawLine(img *image.RGBA, x0, y0, x1, y1 float64, c color.RGBA) {
+	steps := int(math.Max(math.Abs(x1-x0), math.Abs(y1-y0))) + 1
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		fillRect(img, x0+(x1-x0)*t-1, y0+(y1-y0)*t-1, 2, 2, c)
+	}
+}
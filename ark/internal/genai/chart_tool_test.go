@@ -0,0 +1,76 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func revenueChart(chartType, format string) ChartSpec {
+	return ChartSpec{
+		Type:   chartType,
+		Format: format,
+		Title:  "Revenue <2025>",
+		Labels: []string{"Q1", "Q2", "Q3"},
+		Series: []ChartSeries{
+			{Name: "EMEA", Values: []float64{10, 12.5, -3}},
+			{Name: "APAC", Values: []float64{7, 9, 11}},
+		},
+	}
+}
+
+func TestRenderChart(t *testing.T) {
+	table, err := RenderChart(revenueChart(ChartTypeTable, ""))
+	require.NoError(t, err)
+	assert.Equal(t, "table.md", table.Name)
+	assert.Equal(t, "text/markdown", table.MediaType)
+	assert.Equal(t, "**Revenue <2025>**\n\n| | EMEA | APAC |\n|---|---:|---:|\n| Q1 | 10 | 7 |\n| Q2 | 12.5 | 9 |\n| Q3 | -3 | 11 |\n", string(table.Data))
+
+	svg, err := RenderChart(revenueChart(ChartTypeLine, ""))
+	require.NoError(t, err)
+	assert.Equal(t, "chart.svg", svg.Name)
+	assert.Equal(t, "image/svg+xml", svg.MediaType)
+	assert.Contains(t, string(svg.Data), "<polyline")
+	assert.Contains(t, string(svg.Data), "Revenue &lt;2025&gt;")
+
+	bar, err := RenderChart(revenueChart(ChartTypeBar, ChartFormatPNG))
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", bar.MediaType)
+	img, err := png.Decode(bytes.NewReader(bar.Data))
+	require.NoError(t, err)
+	assert.Equal(t, chartWidth, img.Bounds().Dx())
+
+	_, err = RenderChart(revenueChart(ChartTypeTable, ChartFormatPNG))
+	assert.ErrorContains(t, err, "only be rendered as markdown")
+	_, err = RenderChart(revenueChart("pie", ""))
+	assert.ErrorContains(t, err, "unsupported chart type")
+
+	mismatched := revenueChart(ChartTypeBar, "")
+	mismatched.Series[0].Values = mismatched.Series[0].Values[:2]
+	_, err = RenderChart(mismatched)
+	assert.ErrorContains(t, err, "series 0 has 2 values but there are 3 labels")
+}
+
+func TestRenderChartExecutor(t *testing.T) {
+	collector := NewArtifactCollector(DefaultArtifactMaxSizeBytes)
+	ctx := WithArtifactCollector(context.Background(), collector)
+	call := ToolCall{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{
+		Name:      BuiltinToolRenderChart,
+		Arguments: `{"name":"revenue.md","type":"table","labels":["Q1"],"series":[{"name":"EMEA","values":[10]}]}`,
+	}}
+
+	result, err := (&RenderChartExecutor{}).Execute(ctx, call, nil)
+	require.NoError(t, err)
+	assert.Contains(t, result.Content, "| Q1 | 10 |")
+
+	artifacts := collector.Artifacts()
+	require.Len(t, artifacts, 1)
+	assert.Equal(t, "revenue.md", artifacts[0].Name)
+}
@@ -0,0 +1,56 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const defaultConfidencePrompt = `You estimate how confident one can be that an AI assistant's answer to a user's request is correct and complete.
+Reply with a single number between 0 and 1 and nothing else, where 0 means the answer is certainly wrong and 1 means it is certainly right.
+Lower the score when the answer hedges, contradicts itself, does not address the request or states facts that cannot be verified from the request.`
+
+// EstimateConfidence asks a judge model how confident one can be in the answer to the request. It returns a score
+// between 0 and 1.
+func EstimateConfidence(ctx context.Context, model *Model, recorder EventEmitter, prompt, request, answer string) (float64, error) {
+	llmTracker := NewOperationTracker(recorder, ctx, "LLMCall", model.Model, map[string]string{
+		"model": model.Model,
+	})
+	messages := []Message{
+		NewSystemMessage(prompt),
+		NewUserMessage(fmt.Sprintf("Request:\n%s\n\nAnswer:\n%s", request, answer)),
+	}
+	completion, err := model.ChatCompletion(ctx, messages, nil, 1)
+	if err != nil {
+		llmTracker.Fail(err)
+		return 0, err
+	}
+	if completion == nil || len(completion.Choices) == 0 {
+		err := fmt.Errorf("judge model returned no completion choices")
+		llmTracker.Fail(err)
+		return 0, err
+	}
+	llmTracker.CompleteWithTokens(TokenUsage{
+		PromptTokens:     completion.Usage.PromptTokens,
+		CompletionTokens: completion.Usage.CompletionTokens,
+		TotalTokens:      completion.Usage.TotalTokens,
+	})
+	return parseConfidence(completion.Choices[0].Message.Content)
+}
+
+// parseConfidence reads the score from the start of the judge's reply, which models sometimes follow with
+// an explanation despite the prompt
+func parseConfidence(content string) (float64, error) {
+	fields := strings.Fields(content)
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("judge model returned an empty confidence")
+	}
+	score, err := strconv.ParseFloat(strings.TrimRight(fields[0], ".,;"), 64)
+	if err != nil || score < 0 || score > 1 {
+		return 0, fmt.Errorf("judge model returned an invalid confidence %q", fields[0])
+	}
+	return score, nil
+}
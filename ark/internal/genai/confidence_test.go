@@ -0,0 +1,40 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfidence(t *testing.T) {
+	score, err := parseConfidence("0.85")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.85, score, 1e-9)
+
+	score, err = parseConfidence("0.4. The answer does not cite the report.")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.4, score, 1e-9)
+
+	_, err = parseConfidence("High")
+	assert.ErrorContains(t, err, `invalid confidence "High"`)
+
+	_, err = parseConfidence("1.5")
+	assert.ErrorContains(t, err, "invalid confidence")
+
+	_, err = parseConfidence(" ")
+	assert.ErrorContains(t, err, "empty confidence")
+}
+
+func TestEstimateConfidence(t *testing.T) {
+	model, provider := scriptedModel("0.6")
+
+	score, err := EstimateConfidence(context.Background(), model, &mockEventRecorder{}, defaultConfidencePrompt, "What was the Q3 revenue?", "About 12M, I think.")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.6, score, 1e-9)
+	require.Len(t, provider.requests, 1)
+	assert.Equal(t, "Request:\nWhat was the Q3 revenue?\n\nAnswer:\nAbout 12M, I think.", provider.requests[0][1].OfUser.Content.OfString.Value)
+}
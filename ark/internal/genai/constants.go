@@ -10,6 +10,7 @@ const (
 	ModelTypeAzure   = "azure"
 	ModelTypeOpenAI  = "openai"
 	ModelTypeBedrock = "bedrock"
+	ModelTypeGateway = "gateway"
 )
 
 // Agent tool type constants
@@ -32,6 +33,7 @@ const (
 	ToolTypeMCP     = "mcp"
 	ToolTypeAgent   = "agent"
 	ToolTypeBuiltin = "builtin"
+	ToolTypeWasm    = "wasm"
 )
 
 // Team member type constants
@@ -41,6 +43,32 @@ const (
 
 // Built-in tool name constants
 const (
-	BuiltinToolNoop      = "noop"
-	BuiltinToolTerminate = "terminate"
+	BuiltinToolNoop             = "noop"
+	BuiltinToolTerminate        = "terminate"
+	BuiltinToolPublishArtifact  = "publish-artifact"
+	BuiltinToolRenderChart      = "render-chart"
+	BuiltinToolSendEmail        = "send-email"
+	BuiltinToolSendSlackMessage = "send-slack-message"
+	BuiltinToolRemember         = "remember"
+	BuiltinToolHandoff          = "handoff"
+	BuiltinToolBlackboardRead   = "blackboard-read"
+	BuiltinToolBlackboardWrite  = "blackboard-write"
+	BuiltinToolFetchURL         = "fetch-url"
+	BuiltinToolCalculator       = "calculator"
 )
+
+// BuiltinToolNames lists the supported built-in tools
+var BuiltinToolNames = []string{
+	BuiltinToolNoop,
+	BuiltinToolTerminate,
+	BuiltinToolPublishArtifact,
+	BuiltinToolRenderChart,
+	BuiltinToolSendEmail,
+	BuiltinToolSendSlackMessage,
+	BuiltinToolRemember,
+	BuiltinToolHandoff,
+	BuiltinToolBlackboardRead,
+	BuiltinToolBlackboardWrite,
+	BuiltinToolFetchURL,
+	BuiltinToolCalculator,
+}
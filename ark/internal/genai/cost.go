@@ -0,0 +1,176 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// PricingConfigMapName is the optional per-namespace ConfigMap with the prices of models, keyed by the model
+// name the provider uses, e.g. gpt-4o
+const PricingConfigMapName = "ark-config-pricing"
+
+// ModelPricing is the price of one million prompt and completion tokens of a model
+type ModelPricing struct {
+	InputPerMillionTokens  float64
+	OutputPerMillionTokens float64
+}
+
+// Cost returns the price of the tokens. A model without pricing costs nothing.
+func (p *ModelPricing) Cost(promptTokens, completionTokens int64) float64 {
+	if p == nil {
+		return 0
+	}
+	return (float64(promptTokens)*p.InputPerMillionTokens + float64(completionTokens)*p.OutputPerMillionTokens) / 1e6
+}
+
+// loadModelPricing returns the pricing of the Model resource, or else the entry of the pricing ConfigMap for
+// the provider's model name. A model with neither has no pricing.
+func loadModelPricing(ctx context.Context, k8sClient client.Client, modelCRD *arkv1alpha1.Model, providerModel, namespace string) (*ModelPricing, error) {
+	if modelCRD.Spec.Pricing != nil {
+		return parseModelPricing(*modelCRD.Spec.Pricing)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: PricingConfigMapName, Namespace: namespace}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get pricing ConfigMap: %w", err)
+	}
+	entry, ok := cm.Data[providerModel]
+	if !ok {
+		return nil, nil
+	}
+	var pricing arkv1alpha1.ModelPricing
+	if err := yaml.UnmarshalStrict([]byte(entry), &pricing); err != nil {
+		return nil, fmt.Errorf("invalid pricing of model %s in ConfigMap %s: %w", providerModel, PricingConfigMapName, err)
+	}
+	return parseModelPricing(pricing)
+}
+
+func parseModelPricing(pricing arkv1alpha1.ModelPricing) (*ModelPricing, error) {
+	input, err := ParseCost(pricing.InputPerMillionTokens)
+	if err != nil {
+		return nil, fmt.Errorf("invalid inputPerMillionTokens: %w", err)
+	}
+	output, err := ParseCost(pricing.OutputPerMillionTokens)
+	if err != nil {
+		return nil, fmt.Errorf("invalid outputPerMillionTokens: %w", err)
+	}
+	return &ModelPricing{InputPerMillionTokens: input, OutputPerMillionTokens: output}, nil
+}
+
+// ParseCost parses a non-negative decimal amount
+func ParseCost(value string) (float64, error) {
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || amount < 0 || math.IsInf(amount, 0) || math.IsNaN(amount) {
+		return 0, fmt.Errorf("%q is not a non-negative amount", value)
+	}
+	return amount, nil
+}
+
+// FormatCost formats an amount rounded to millionths, the precision of per-token prices
+func FormatCost(amount float64) string {
+	return strconv.FormatFloat(math.Round(amount*1e6)/1e6, 'f', -1, 64)
+}
+
+// CostTracker adds up the estimated cost of the model calls of a query and enforces its budget
+type CostTracker struct {
+	mu     sync.Mutex
+	total  float64
+	priced bool
+	budget float64
+}
+
+// NewCostTracker returns a tracker for a query with the given budget, where zero means no budget
+func NewCostTracker(budget float64) *CostTracker {
+	return &CostTracker{budget: budget}
+}
+
+type costTrackerKey struct{}
+
+// WithCostTracker adds up the cost of the model calls made with the context in the tracker
+func WithCostTracker(ctx context.Context, tracker *CostTracker) context.Context {
+	return context.WithValue(ctx, costTrackerKey{}, tracker)
+}
+
+func costTrackerFrom(ctx context.Context) *CostTracker {
+	tracker, _ := ctx.Value(costTrackerKey{}).(*CostTracker)
+	return tracker
+}
+
+// add records the tokens of a model call at the model's pricing
+func (t *CostTracker) add(pricing *ModelPricing, promptTokens, completionTokens int64) {
+	if t == nil || pricing == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.total += pricing.Cost(promptTokens, completionTokens)
+	t.priced = true
+}
+
+// addReported records the cost an upstream reported for a model call
+func (t *CostTracker) addReported(amount float64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.total += amount
+	t.priced = true
+}
+
+// reportedCost holds the cost a gateway reported for a model call, which replaces the estimate from the pricing
+// of the model
+type reportedCost struct {
+	amount   float64
+	reported bool
+}
+
+type reportedCostKey struct{}
+
+// withReportedCost returns a context in which the provider of a model call can report its cost
+func withReportedCost(ctx context.Context) (context.Context, *reportedCost) {
+	cost := &reportedCost{}
+	return context.WithValue(ctx, reportedCostKey{}, cost), cost
+}
+
+// reportCost records the cost of the model call made with the context
+func reportCost(ctx context.Context, amount float64) {
+	if cost, ok := ctx.Value(reportedCostKey{}).(*reportedCost); ok {
+		cost.amount, cost.reported = amount, true
+	}
+}
+
+// checkBudget fails once the cost has exceeded the budget, so that no further model calls are made
+func (t *CostTracker) checkBudget() error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.budget > 0 && t.total > t.budget {
+		return Errorf(ReasonBudgetExceeded, "query cost %s exceeds its budget of %s", FormatCost(t.total), FormatCost(t.budget))
+	}
+	return nil
+}
+
+// Total returns the cost so far and whether any priced model was called
+func (t *CostTracker) Total() (float64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.total, t.priced
+}
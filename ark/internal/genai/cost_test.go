@@ -0,0 +1,162 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+// meteredProvider answers every call with the same token usage
+type meteredProvider struct {
+	usage openai.CompletionUsage
+	calls int
+}
+
+func (p *meteredProvider) ChatCompletion(ctx context.Context, messages []Message, n int64, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	p.calls++
+	return &openai.ChatCompletion{
+		Choices: []openai.ChatCompletionChoice{{Message: openai.ChatCompletionMessage{Role: "assistant", Content: "done"}}},
+		Usage:   p.usage,
+	}, nil
+}
+
+func (p *meteredProvider) ChatCompletionStream(ctx context.Context, messages []Message, n int64, streamFunc func(*openai.ChatCompletionChunk) error, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	return p.ChatCompletion(ctx, messages, n, tools...)
+}
+
+func (p *meteredProvider) SetOutputSchema(schema *runtime.RawExtension, schemaName string) {}
+
+func TestLoadModelPricing(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	pricing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: PricingConfigMapName, Namespace: "default"},
+		Data: map[string]string{
+			"gpt-4o":  "inputPerMillionTokens: \"2.5\"\noutputPerMillionTokens: \"10\"\n",
+			"corrupt": "inputPerMillionTokens: cheap\n",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		spec          *arkv1alpha1.ModelPricing
+		providerModel string
+		objects       []client.Object
+		expected      *ModelPricing
+		expectError   string
+	}{
+		{
+			name:          "pricing of the model takes precedence",
+			spec:          &arkv1alpha1.ModelPricing{InputPerMillionTokens: "1", OutputPerMillionTokens: "4"},
+			providerModel: "gpt-4o",
+			objects:       []client.Object{pricing},
+			expected:      &ModelPricing{InputPerMillionTokens: 1, OutputPerMillionTokens: 4},
+		},
+		{
+			name:          "pricing ConfigMap",
+			providerModel: "gpt-4o",
+			objects:       []client.Object{pricing},
+			expected:      &ModelPricing{InputPerMillionTokens: 2.5, OutputPerMillionTokens: 10},
+		},
+		{
+			name:          "model missing from the ConfigMap",
+			providerModel: "claude-sonnet",
+			objects:       []client.Object{pricing},
+		},
+		{
+			name:          "no ConfigMap",
+			providerModel: "gpt-4o",
+		},
+		{
+			name:          "invalid ConfigMap entry",
+			providerModel: "corrupt",
+			objects:       []client.Object{pricing},
+			expectError:   "invalid inputPerMillionTokens",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+			model := &arkv1alpha1.Model{Spec: arkv1alpha1.ModelSpec{Pricing: tt.spec}}
+
+			result, err := loadModelPricing(context.Background(), k8sClient, model, tt.providerModel, "default")
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCostTrackerAddsModelCalls(t *testing.T) {
+	provider := &meteredProvider{usage: openai.CompletionUsage{PromptTokens: 1000, CompletionTokens: 500, TotalTokens: 1500}}
+	model := &Model{
+		Model:         "gpt-4o",
+		Provider:      provider,
+		ModelRecorder: noop.NewModelRecorder(),
+		Pricing:       &ModelPricing{InputPerMillionTokens: 2.5, OutputPerMillionTokens: 10},
+	}
+	unpriced := &Model{Model: "local", Provider: provider, ModelRecorder: noop.NewModelRecorder()}
+
+	tracker := NewCostTracker(0)
+	ctx := WithCostTracker(context.Background(), tracker)
+	_, err := unpriced.ChatCompletion(ctx, nil, nil, 1)
+	require.NoError(t, err)
+	_, priced := tracker.Total()
+	assert.False(t, priced, "a model without pricing does not make the query priced")
+
+	for range 2 {
+		_, err := model.ChatCompletion(ctx, nil, nil, 1)
+		require.NoError(t, err)
+	}
+	total, priced := tracker.Total()
+	assert.True(t, priced)
+	assert.Equal(t, "0.015", FormatCost(total))
+}
+
+func TestCostTrackerEnforcesBudget(t *testing.T) {
+	provider := &meteredProvider{usage: openai.CompletionUsage{PromptTokens: 1_000_000, TotalTokens: 1_000_000}}
+	model := &Model{
+		Model:         "gpt-4o",
+		Provider:      provider,
+		ModelRecorder: noop.NewModelRecorder(),
+		Pricing:       &ModelPricing{InputPerMillionTokens: 2.5},
+	}
+	ctx := WithCostTracker(context.Background(), NewCostTracker(4))
+
+	for range 2 {
+		_, err := model.ChatCompletion(ctx, nil, nil, 1)
+		require.NoError(t, err, "calls are made while the cost is within the budget")
+	}
+	_, err := model.ChatCompletion(ctx, nil, nil, 1)
+	assert.Equal(t, ReasonBudgetExceeded, ReasonFor(err))
+	assert.EqualError(t, err, "query cost 5 exceeds its budget of 4")
+	assert.Equal(t, 2, provider.calls)
+}
+
+func TestParseCost(t *testing.T) {
+	amount, err := ParseCost("0.25")
+	require.NoError(t, err)
+	assert.Equal(t, 0.25, amount)
+
+	for _, value := range []string{"", "-1", "free", "NaN"} {
+		_, err := ParseCost(value)
+		assert.Error(t, err, value)
+	}
+}
@@ -0,0 +1,208 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
+)
+
+// DeadLetterConfigMapName is the optional per-namespace ConfigMap with the sink the failed queries of the
+// namespace are published to
+const DeadLetterConfigMapName = "ark-config-dead-letter"
+
+const (
+	// DeadLetterSinkHTTP posts each failed query as JSON to the URL of the sink
+	DeadLetterSinkHTTP = "http"
+	// DeadLetterSinkKafka produces each failed query to a topic through a Kafka REST proxy
+	DeadLetterSinkKafka = "kafka"
+
+	defaultDeadLetterTimeout = 10 * time.Second
+	kafkaJSONContentType     = "application/vnd.kafka.json.v2+json"
+)
+
+// DeadLetterConfig is the dead-letter sink of a namespace
+type DeadLetterConfig struct {
+	Type string
+	// URL of the HTTP endpoint, or the base URL of the Kafka REST proxy
+	URL string
+	// ServiceRef is the in-cluster service of the sink, used when URL is not set
+	ServiceRef *arkv1alpha1.ServiceReference
+	// Topic is the Kafka topic failed queries are produced to
+	Topic string
+	// SecretName is a Secret whose keys are sent as request headers, such as Authorization
+	SecretName string
+	Timeout    time.Duration
+}
+
+// GetDeadLetterConfig reads the dead-letter sink from the namespace's dead-letter ConfigMap. It returns nil
+// when the namespace has no sink.
+func GetDeadLetterConfig(ctx context.Context, k8sClient client.Client, namespace string) (*DeadLetterConfig, error) {
+	cm := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: DeadLetterConfigMapName, Namespace: namespace}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get dead-letter ConfigMap: %w", err)
+	}
+
+	config := &DeadLetterConfig{
+		Type:       valueOr(strings.TrimSpace(cm.Data["type"]), DeadLetterSinkHTTP),
+		URL:        strings.TrimSpace(cm.Data["url"]),
+		Topic:      strings.TrimSpace(cm.Data["topic"]),
+		SecretName: strings.TrimSpace(cm.Data["secretName"]),
+		Timeout:    defaultDeadLetterTimeout,
+	}
+	if config.Type != DeadLetterSinkHTTP && config.Type != DeadLetterSinkKafka {
+		return nil, fmt.Errorf("invalid type %q in dead-letter ConfigMap, must be %s or %s", config.Type, DeadLetterSinkHTTP, DeadLetterSinkKafka)
+	}
+	if value, ok := cm.Data["serviceRef"]; ok {
+		config.ServiceRef = &arkv1alpha1.ServiceReference{}
+		if err := yaml.Unmarshal([]byte(value), config.ServiceRef); err != nil {
+			return nil, fmt.Errorf("failed to parse serviceRef: %w", err)
+		}
+		if config.ServiceRef.Name == "" {
+			return nil, fmt.Errorf("serviceRef must have a name")
+		}
+	}
+	if config.URL == "" && config.ServiceRef == nil {
+		return nil, fmt.Errorf("dead-letter ConfigMap must set url or serviceRef")
+	}
+	if config.Type == DeadLetterSinkKafka && config.Topic == "" {
+		return nil, fmt.Errorf("dead-letter ConfigMap of type %s must set topic", DeadLetterSinkKafka)
+	}
+	if value := strings.TrimSpace(cm.Data["timeout"]); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid timeout %q in dead-letter ConfigMap", value)
+		}
+		config.Timeout = timeout
+	}
+	return config, nil
+}
+
+// DeadLetter is the payload published for a query that failed permanently
+type DeadLetter struct {
+	Query     DeadLetterQuery        `json:"query"`
+	Spec      arkv1alpha1.QuerySpec  `json:"spec"`
+	Reason    string                 `json:"reason"`
+	Message   string                 `json:"message"`
+	Responses []arkv1alpha1.Response `json:"responses,omitempty"`
+	// TokenUsage is the usage of the query until it failed
+	TokenUsage arkv1alpha1.TokenUsage `json:"tokenUsage"`
+	Cost       string                 `json:"cost,omitempty"`
+	Duration   *metav1.Duration       `json:"duration,omitempty"`
+	FailedAt   metav1.Time            `json:"failedAt"`
+}
+
+// DeadLetterQuery identifies the failed query
+type DeadLetterQuery struct {
+	Name          string `json:"name"`
+	Namespace     string `json:"namespace"`
+	UID           string `json:"uid"`
+	CorrelationID string `json:"correlationId,omitempty"`
+	TraceID       string `json:"traceId,omitempty"`
+}
+
+// NewDeadLetter returns the payload of a failed query, with the reason and message of its Completed condition
+// and the responses of its failed targets
+func NewDeadLetter(query *arkv1alpha1.Query, reason, message string) DeadLetter {
+	letter := DeadLetter{
+		Query: DeadLetterQuery{
+			Name:          query.Name,
+			Namespace:     query.Namespace,
+			UID:           string(query.UID),
+			CorrelationID: query.Status.CorrelationID,
+			TraceID:       query.Status.TraceID,
+		},
+		Spec:       query.Spec,
+		Reason:     reason,
+		Message:    message,
+		TokenUsage: query.Status.TokenUsage,
+		Cost:       query.Status.Cost,
+		Duration:   query.Status.Duration,
+		FailedAt:   metav1.Now(),
+	}
+	for _, response := range query.Status.Responses {
+		if response.Phase == "error" || response.Phase == "unresolved" {
+			letter.Responses = append(letter.Responses, response)
+		}
+	}
+	return letter
+}
+
+// PublishDeadLetter sends the payload of a failed query to the dead-letter sink
+func PublishDeadLetter(ctx context.Context, k8sClient client.Client, config *DeadLetterConfig, namespace string, letter DeadLetter) error {
+	baseURL := config.URL
+	if baseURL == "" {
+		var err error
+		if baseURL, err = common.ResolveServiceReference(ctx, k8sClient, config.ServiceRef, namespace); err != nil {
+			return fmt.Errorf("failed to resolve dead-letter service %s: %w", config.ServiceRef.Name, err)
+		}
+	}
+
+	payload, err := json.Marshal(letter)
+	if err != nil {
+		return fmt.Errorf("failed to encode dead letter: %w", err)
+	}
+	sinkURL, contentType := baseURL, "application/json"
+	if config.Type == DeadLetterSinkKafka {
+		sinkURL = strings.TrimSuffix(baseURL, "/") + "/topics/" + url.PathEscape(config.Topic)
+		contentType = kafkaJSONContentType
+		// The query identifies the record, so the failures of a query land on one partition
+		payload, err = json.Marshal(map[string]any{"records": []map[string]any{{
+			"key":   letter.Query.Namespace + "/" + letter.Query.Name,
+			"value": json.RawMessage(payload),
+		}}})
+		if err != nil {
+			return fmt.Errorf("failed to encode dead letter: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sinkURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create dead-letter request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if config.SecretName != "" {
+		secret := &corev1.Secret{}
+		if err := k8sClient.Get(ctx, client.ObjectKey{Name: config.SecretName, Namespace: namespace}, secret); err != nil {
+			return fmt.Errorf("failed to get dead-letter secret %s: %w", config.SecretName, err)
+		}
+		for name, value := range secret.Data {
+			req.Header.Set(name, string(value))
+		}
+	}
+
+	resp, err := common.NewHTTPClientWithLogging(ctx, common.HTTPClientDeadLetter).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to publish dead letter: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close() // Standard defer pattern - error rarely meaningful
+	}()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("dead-letter sink returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func deadLetterClient(objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func deadLetterConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: DeadLetterConfigMapName, Namespace: "default"}, Data: data}
+}
+
+func failedQuery() *arkv1alpha1.Query {
+	return &arkv1alpha1.Query{
+		ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: "default", UID: "query-uid"},
+		Spec:       arkv1alpha1.QuerySpec{Targets: []arkv1alpha1.QueryTarget{{Type: "agent", Name: "weather-agent"}}},
+		Status: arkv1alpha1.QueryStatus{
+			Phase: "error",
+			Responses: []arkv1alpha1.Response{
+				{Target: arkv1alpha1.QueryTarget{Type: "agent", Name: "weather-agent"}, Phase: "error", Content: "model weather-model not found"},
+				{Target: arkv1alpha1.QueryTarget{Type: "agent", Name: "news-agent"}, Phase: "done", Content: "No news"},
+			},
+			TokenUsage: arkv1alpha1.TokenUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15},
+		},
+	}
+}
+
+func TestGetDeadLetterConfig(t *testing.T) {
+	ctx := context.Background()
+
+	config, err := GetDeadLetterConfig(ctx, deadLetterClient(), "default")
+	require.NoError(t, err)
+	assert.Nil(t, config, "namespaces without the ConfigMap have no sink")
+
+	config, err = GetDeadLetterConfig(ctx, deadLetterClient(deadLetterConfigMap(map[string]string{"url": "http://triage/failures"})), "default")
+	require.NoError(t, err)
+	assert.Equal(t, DeadLetterSinkHTTP, config.Type)
+	assert.Equal(t, defaultDeadLetterTimeout, config.Timeout)
+
+	for _, tc := range []struct {
+		data    map[string]string
+		message string
+	}{
+		{map[string]string{"type": "sqs", "url": "http://triage"}, "invalid type"},
+		{map[string]string{"type": "http"}, "must set url or serviceRef"},
+		{map[string]string{"type": "kafka", "url": "http://rest-proxy"}, "must set topic"},
+		{map[string]string{"url": "http://triage", "timeout": "soon"}, "invalid timeout"},
+	} {
+		_, err := GetDeadLetterConfig(ctx, deadLetterClient(deadLetterConfigMap(tc.data)), "default")
+		assert.ErrorContains(t, err, tc.message)
+	}
+}
+
+func TestPublishDeadLetterHTTP(t *testing.T) {
+	var received DeadLetter
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "triage-auth", Namespace: "default"},
+		Data:       map[string][]byte{"Authorization": []byte("Bearer token")},
+	}
+	k8sClient := deadLetterClient(secret)
+	config := &DeadLetterConfig{Type: DeadLetterSinkHTTP, URL: server.URL, SecretName: "triage-auth", Timeout: defaultDeadLetterTimeout}
+
+	letter := NewDeadLetter(failedQuery(), "ModelNotFound", "model weather-model not found")
+	require.NoError(t, PublishDeadLetter(context.Background(), k8sClient, config, "default", letter))
+
+	assert.Equal(t, "Bearer token", authorization)
+	assert.Equal(t, DeadLetterQuery{Name: "weather", Namespace: "default", UID: "query-uid"}, received.Query)
+	assert.Equal(t, "ModelNotFound", received.Reason)
+	assert.Equal(t, int64(15), received.TokenUsage.TotalTokens)
+	assert.Equal(t, "weather-agent", received.Spec.Targets[0].Name)
+	require.Len(t, received.Responses, 1, "only the failed responses are published")
+	assert.Equal(t, "model weather-model not found", received.Responses[0].Content)
+}
+
+func TestPublishDeadLetterKafka(t *testing.T) {
+	var path, contentType string
+	var received struct {
+		Records []struct {
+			Key   string     `json:"key"`
+			Value DeadLetter `json:"value"`
+		} `json:"records"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, contentType = r.URL.Path, r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &received))
+	}))
+	defer server.Close()
+
+	config := &DeadLetterConfig{Type: DeadLetterSinkKafka, URL: server.URL + "/", Topic: "ark.failed-queries", Timeout: defaultDeadLetterTimeout}
+	require.NoError(t, PublishDeadLetter(context.Background(), deadLetterClient(), config, "default", NewDeadLetter(failedQuery(), "QueryErrored", "failed")))
+
+	assert.Equal(t, "/topics/ark.failed-queries", path)
+	assert.Equal(t, kafkaJSONContentType, contentType)
+	require.Len(t, received.Records, 1)
+	assert.Equal(t, "default/weather", received.Records[0].Key)
+	assert.Equal(t, "weather", received.Records[0].Value.Query.Name)
+}
+
+func TestPublishDeadLetterRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := &DeadLetterConfig{Type: DeadLetterSinkHTTP, URL: server.URL, Timeout: defaultDeadLetterTimeout}
+	err := PublishDeadLetter(context.Background(), deadLetterClient(), config, "default", NewDeadLetter(failedQuery(), "QueryErrored", "failed"))
+	assert.ErrorContains(t, err, "status 503")
+}
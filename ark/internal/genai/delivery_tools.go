@@ -0,0 +1,309 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
+)
+
+// DeliveryConfigMapName is the per-namespace ConfigMap with the allowlists and message templates of
+// the delivery tools. Without it the delivery tools refuse to send anything.
+const DeliveryConfigMapName = "ark-config-delivery"
+
+// DefaultDeliverySecretName is the Secret with the SMTP settings and Slack webhooks unless the
+// delivery ConfigMap names another one
+const DefaultDeliverySecretName = "ark-delivery"
+
+const (
+	defaultEmailTemplate = "{{ .Message }}\n\n--\nSent by agent {{ .Agent }} for query {{ .Query }}\n"
+	defaultSlackTemplate = "{{ if .Subject }}*{{ .Subject }}*\n{{ end }}{{ .Message }}"
+	defaultSMTPPort      = "587"
+	slackWebhookPrefix   = "slack-webhook-"
+)
+
+// sendMail is replaced in tests
+var sendMail = smtp.SendMail
+
+// DeliveryConfig is the delivery configuration of a namespace
+type DeliveryConfig struct {
+	SecretName             string
+	AllowedEmailRecipients []string
+	AllowedSlackChannels   []string
+	EmailTemplate          *template.Template
+	SlackTemplate          *template.Template
+}
+
+// AllowsEmail reports whether the address matches the recipient allowlist. Entries may use
+// wildcards, e.g. *@example.com.
+func (c DeliveryConfig) AllowsEmail(address string) bool {
+	address = strings.ToLower(address)
+	for _, allowed := range c.AllowedEmailRecipients {
+		if ok, _ := path.Match(allowed, address); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsSlackChannel reports whether the channel is on the channel allowlist
+func (c DeliveryConfig) AllowsSlackChannel(channel string) bool {
+	for _, allowed := range c.AllowedSlackChannels {
+		if allowed == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDeliveryConfig reads the delivery configuration from the namespace's delivery ConfigMap
+func GetDeliveryConfig(ctx context.Context, k8sClient client.Client, namespace string) (DeliveryConfig, error) {
+	cm := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: DeliveryConfigMapName, Namespace: namespace}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return DeliveryConfig{}, fmt.Errorf("delivery is not configured: ConfigMap %s not found in namespace %s", DeliveryConfigMapName, namespace)
+		}
+		return DeliveryConfig{}, fmt.Errorf("failed to get delivery ConfigMap: %w", err)
+	}
+
+	config := DeliveryConfig{
+		SecretName:             valueOr(cm.Data["secretName"], DefaultDeliverySecretName),
+		AllowedEmailRecipients: splitList(strings.ToLower(cm.Data["allowedEmailRecipients"])),
+		AllowedSlackChannels:   splitList(cm.Data["allowedSlackChannels"]),
+	}
+	var err error
+	if config.EmailTemplate, err = template.New("email").Parse(valueOr(cm.Data["emailTemplate"], defaultEmailTemplate)); err != nil {
+		return config, fmt.Errorf("invalid emailTemplate in delivery ConfigMap: %w", err)
+	}
+	if config.SlackTemplate, err = template.New("slack").Parse(valueOr(cm.Data["slackTemplate"], defaultSlackTemplate)); err != nil {
+		return config, fmt.Errorf("invalid slackTemplate in delivery ConfigMap: %w", err)
+	}
+	return config, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// deliveryMessage is the data available to the message templates
+type deliveryMessage struct {
+	Subject   string
+	Message   string
+	Query     string
+	Namespace string
+	Agent     string
+}
+
+func newDeliveryMessage(ctx context.Context, namespace, subject, message string) deliveryMessage {
+	data := deliveryMessage{Subject: subject, Message: message, Namespace: namespace}
+	if query, ok := ctx.Value(QueryContextKey).(*arkv1alpha1.Query); ok {
+		data.Query = query.Name
+	}
+	if agent, ok := GetExecutionMetadata(ctx)["agent"].(string); ok {
+		data.Agent = agent
+	}
+	return data
+}
+
+func renderDeliveryTemplate(tmpl *template.Template, data deliveryMessage) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", tmpl.Name(), err)
+	}
+	return buf.String(), nil
+}
+
+func getDeliverySecret(ctx context.Context, k8sClient client.Client, namespace, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get delivery Secret %s: %w", name, err)
+	}
+	return secret, nil
+}
+
+// deliveryResult builds the tool result for a delivery, recording the error if there is one
+func deliveryResult(call ToolCall, content string, err error) (ToolResult, error) {
+	result := ToolResult{ID: call.ID, Name: call.Function.Name, Content: content}
+	if err != nil {
+		result.Content = ""
+		result.Error = err.Error()
+	}
+	return result, err
+}
+
+// SendEmailExecutor is the send-email built-in tool. It sends a templated plain text email through
+// the SMTP server configured in the delivery Secret to allowlisted recipients.
+type SendEmailExecutor struct {
+	K8sClient client.Client
+	Namespace string
+}
+
+type sendEmailArguments struct {
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	Message string   `json:"message"`
+}
+
+func (e *SendEmailExecutor) Execute(ctx context.Context, call ToolCall, recorder EventEmitter) (ToolResult, error) {
+	var arguments sendEmailArguments
+	if err := json.Unmarshal([]byte(call.Function.Arguments), &arguments); err != nil {
+		return deliveryResult(call, "", fmt.Errorf("failed to parse send-email arguments: %w", err))
+	}
+	recipients, err := e.send(ctx, arguments)
+	return deliveryResult(call, fmt.Sprintf("Email %q sent to %s", arguments.Subject, strings.Join(recipients, ", ")), err)
+}
+
+func (e *SendEmailExecutor) send(ctx context.Context, arguments sendEmailArguments) ([]string, error) {
+	if len(arguments.To) == 0 {
+		return nil, fmt.Errorf("at least one recipient is required")
+	}
+	if strings.ContainsAny(arguments.Subject, "\r\n") {
+		return nil, fmt.Errorf("subject must be a single line")
+	}
+
+	config, err := GetDeliveryConfig(ctx, e.K8sClient, e.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	recipients := make([]string, 0, len(arguments.To))
+	for _, to := range arguments.To {
+		address, err := mail.ParseAddress(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient %q: %w", to, err)
+		}
+		if !config.AllowsEmail(address.Address) {
+			return nil, fmt.Errorf("recipient %s is not allowed", address.Address)
+		}
+		recipients = append(recipients, address.Address)
+	}
+
+	body, err := renderDeliveryTemplate(config.EmailTemplate, newDeliveryMessage(ctx, e.Namespace, arguments.Subject, arguments.Message))
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := getDeliverySecret(ctx, e.K8sClient, e.Namespace, config.SecretName)
+	if err != nil {
+		return nil, err
+	}
+	host, from := string(secret.Data["smtp-host"]), string(secret.Data["smtp-from"])
+	if host == "" || from == "" {
+		return nil, fmt.Errorf("delivery Secret %s must set smtp-host and smtp-from", config.SecretName)
+	}
+	port := valueOr(string(secret.Data["smtp-port"]), defaultSMTPPort)
+
+	var auth smtp.Auth
+	if username := string(secret.Data["smtp-username"]); username != "" {
+		auth = smtp.PlainAuth("", username, string(secret.Data["smtp-password"]), host)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n",
+		from, strings.Join(recipients, ", "), mime.QEncoding.Encode("utf-8", arguments.Subject))
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	if err := sendMail(net.JoinHostPort(host, port), auth, from, recipients, msg.Bytes()); err != nil {
+		return nil, fmt.Errorf("failed to send email: %w", err)
+	}
+	return recipients, nil
+}
+
+// SendSlackMessageExecutor is the send-slack-message built-in tool. It posts a templated message to
+// an allowlisted channel through the incoming webhook configured for it in the delivery Secret.
+type SendSlackMessageExecutor struct {
+	K8sClient  client.Client
+	Namespace  string
+	HTTPClient *http.Client
+}
+
+type sendSlackMessageArguments struct {
+	Channel string `json:"channel"`
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+}
+
+func (e *SendSlackMessageExecutor) Execute(ctx context.Context, call ToolCall, recorder EventEmitter) (ToolResult, error) {
+	var arguments sendSlackMessageArguments
+	if err := json.Unmarshal([]byte(call.Function.Arguments), &arguments); err != nil {
+		return deliveryResult(call, "", fmt.Errorf("failed to parse send-slack-message arguments: %w", err))
+	}
+	err := e.send(ctx, arguments)
+	return deliveryResult(call, fmt.Sprintf("Message posted to Slack channel %s", arguments.Channel), err)
+}
+
+func (e *SendSlackMessageExecutor) send(ctx context.Context, arguments sendSlackMessageArguments) error {
+	channel := strings.TrimPrefix(strings.TrimSpace(arguments.Channel), "#")
+	config, err := GetDeliveryConfig(ctx, e.K8sClient, e.Namespace)
+	if err != nil {
+		return err
+	}
+	if !config.AllowsSlackChannel(channel) {
+		return fmt.Errorf("slack channel %q is not allowed", channel)
+	}
+
+	text, err := renderDeliveryTemplate(config.SlackTemplate, newDeliveryMessage(ctx, e.Namespace, arguments.Subject, arguments.Message))
+	if err != nil {
+		return err
+	}
+
+	secret, err := getDeliverySecret(ctx, e.K8sClient, e.Namespace, config.SecretName)
+	if err != nil {
+		return err
+	}
+	webhookURL := string(secret.Data[slackWebhookPrefix+channel])
+	if webhookURL == "" {
+		return fmt.Errorf("delivery Secret %s has no %s%s webhook", config.SecretName, slackWebhookPrefix, channel)
+	}
+
+	payload, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("failed to encode slack message: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := e.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second, Transport: common.NewInstrumentedTransport(common.HTTPClientTool, nil)}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post slack message: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close() // Standard defer pattern - error rarely meaningful
+	}()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("slack webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
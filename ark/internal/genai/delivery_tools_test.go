@@ -0,0 +1,106 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func deliveryClient(secretData map[string][]byte) client.Client {
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: DeliveryConfigMapName, Namespace: "default"},
+		Data: map[string]string{
+			"allowedEmailRecipients": "*@example.com, partner@other.com",
+			"allowedSlackChannels":   "reports",
+			"slackTemplate":          "[{{ .Query }}] {{ .Message }}",
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: DefaultDeliverySecretName, Namespace: "default"},
+		Data:       secretData,
+	}
+	return fake.NewClientBuilder().WithObjects(config, secret).Build()
+}
+
+func deliveryCall(name string, arguments any) ToolCall {
+	raw, _ := json.Marshal(arguments)
+	return ToolCall{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{Name: name, Arguments: string(raw)}}
+}
+
+func TestSendEmailExecutor(t *testing.T) {
+	var sentTo []string
+	var sentMessage string
+	originalSendMail := sendMail
+	sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		assert.Equal(t, "smtp.example.com:587", addr)
+		sentTo, sentMessage = to, string(msg)
+		return nil
+	}
+	defer func() { sendMail = originalSendMail }()
+
+	executor := &SendEmailExecutor{K8sClient: deliveryClient(map[string][]byte{
+		"smtp-host": []byte("smtp.example.com"),
+		"smtp-from": []byte("ark@example.com"),
+	}), Namespace: "default"}
+	ctx := context.WithValue(context.Background(), QueryContextKey, &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "weekly"}})
+
+	result, err := executor.Execute(ctx, deliveryCall(BuiltinToolSendEmail, sendEmailArguments{
+		To:      []string{"Ops <ops@example.com>", "partner@other.com"},
+		Subject: "Weekly report",
+		Message: "All systems nominal",
+	}), nil)
+	require.NoError(t, err)
+	assert.Contains(t, result.Content, "ops@example.com, partner@other.com")
+	assert.Equal(t, []string{"ops@example.com", "partner@other.com"}, sentTo)
+	assert.Contains(t, sentMessage, "Subject: Weekly report\r\n")
+	assert.Contains(t, sentMessage, "All systems nominal\r\n\r\n--\r\nSent by agent  for query weekly")
+
+	_, err = executor.Execute(ctx, deliveryCall(BuiltinToolSendEmail, sendEmailArguments{To: []string{"someone@evil.com"}, Subject: "hi"}), nil)
+	assert.ErrorContains(t, err, "recipient someone@evil.com is not allowed")
+
+	_, err = executor.Execute(ctx, deliveryCall(BuiltinToolSendEmail, sendEmailArguments{To: []string{"ops@example.com"}, Subject: "hi\r\nBcc: someone@evil.com"}), nil)
+	assert.ErrorContains(t, err, "single line")
+}
+
+func TestSendSlackMessageExecutor(t *testing.T) {
+	var posted map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&posted))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	executor := &SendSlackMessageExecutor{K8sClient: deliveryClient(map[string][]byte{
+		"slack-webhook-reports": []byte(server.URL),
+	}), Namespace: "default", HTTPClient: server.Client()}
+	ctx := context.WithValue(context.Background(), QueryContextKey, &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "weekly"}})
+
+	_, err := executor.Execute(ctx, deliveryCall(BuiltinToolSendSlackMessage, sendSlackMessageArguments{Channel: "#reports", Message: "Done"}), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "[weekly] Done", posted["text"])
+
+	_, err = executor.Execute(ctx, deliveryCall(BuiltinToolSendSlackMessage, sendSlackMessageArguments{Channel: "general", Message: "Done"}), nil)
+	assert.ErrorContains(t, err, `slack channel "general" is not allowed`)
+}
+
+func TestDeliveryRequiresConfiguration(t *testing.T) {
+	executor := &SendSlackMessageExecutor{K8sClient: fake.NewClientBuilder().Build(), Namespace: "default"}
+	result, err := executor.Execute(context.Background(), deliveryCall(BuiltinToolSendSlackMessage, sendSlackMessageArguments{Channel: "reports", Message: "Done"}), nil)
+	assert.ErrorContains(t, err, "delivery is not configured")
+	assert.NotEmpty(t, result.Error)
+}
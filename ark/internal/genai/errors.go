@@ -0,0 +1,205 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+
+	"github.com/openai/openai-go"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ErrorReason is a machine-readable classification of a query execution failure.
+// Values are CamelCase so they can be used directly as condition reasons.
+type ErrorReason string
+
+const (
+	ReasonProviderRateLimited  ErrorReason = "ProviderRateLimited"
+	ReasonProviderAuthFailed   ErrorReason = "ProviderAuthFailed"
+	ReasonProviderBadRequest   ErrorReason = "ProviderBadRequest"
+	ReasonProviderUnavailable  ErrorReason = "ProviderUnavailable"
+	ReasonConnectionFailed     ErrorReason = "ConnectionFailed"
+	ReasonToolTimeout          ErrorReason = "ToolTimeout"
+	ReasonToolFailed           ErrorReason = "ToolFailed"
+	ReasonToolBlocked          ErrorReason = "ToolBlocked"
+	ReasonSchemaViolation      ErrorReason = "SchemaViolation"
+	ReasonResourceNotFound     ErrorReason = "ResourceNotFound"
+	ReasonResolutionFailed     ErrorReason = "ResolutionFailed"
+	ReasonTimeout              ErrorReason = "Timeout"
+	ReasonCanceled             ErrorReason = "Canceled"
+	ReasonQueryTimeout         ErrorReason = "QueryTimeout"
+	ReasonStoppedEarly         ErrorReason = "StoppedEarly"
+	ReasonBudgetExceeded       ErrorReason = "BudgetExceeded"
+	ReasonImpersonationExpired ErrorReason = "ImpersonationExpired"
+	ReasonMalformedResponse    ErrorReason = "MalformedResponse"
+	ReasonInternal             ErrorReason = "InternalError"
+)
+
+// Error is an execution error annotated with an ErrorReason
+type Error struct {
+	Reason ErrorReason
+	Err    error
+}
+
+// NewError wraps err with the given reason
+func NewError(reason ErrorReason, err error) *Error {
+	return &Error{Reason: reason, Err: err}
+}
+
+// Errorf creates an Error with the given reason and formatted message
+func Errorf(reason ErrorReason, format string, args ...any) *Error {
+	return &Error{Reason: reason, Err: fmt.Errorf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ErrorReason returns the reason as a string, which lets telemetry record it without importing genai
+func (e *Error) ErrorReason() string {
+	return string(e.Reason)
+}
+
+// ReasonFor classifies an error. Explicit reasons set with NewError take precedence;
+// otherwise provider, Kubernetes and context errors are recognised by type.
+func ReasonFor(err error) ErrorReason {
+	if err == nil {
+		return ""
+	}
+
+	var genaiErr *Error
+	if errors.As(err, &genaiErr) {
+		return genaiErr.Reason
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ReasonCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ReasonTimeout
+	case apierrors.IsNotFound(err):
+		return ReasonResourceNotFound
+	}
+
+	var openaiErr *openai.Error
+	if errors.As(err, &openaiErr) {
+		return reasonForStatusCode(openaiErr.StatusCode)
+	}
+
+	// AWS SDK response errors expose the HTTP status code through this method
+	var statusErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &statusErr) {
+		return reasonForStatusCode(statusErr.HTTPStatusCode())
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return ReasonConnectionFailed
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ReasonTimeout
+	}
+
+	return ReasonInternal
+}
+
+// Reasons returns every reason attached to err, outermost first, followed by the classification of
+// the underlying cause. A resolution error caused by a refused connection yields both
+// ResolutionFailed and ConnectionFailed.
+func Reasons(err error) []ErrorReason {
+	var reasons []ErrorReason
+	for err != nil {
+		var genaiErr *Error
+		if !errors.As(err, &genaiErr) {
+			return append(reasons, ReasonFor(err))
+		}
+		reasons = append(reasons, genaiErr.Reason)
+		err = genaiErr.Err
+	}
+	return reasons
+}
+
+// NewResolutionError marks err as a failure to resolve a target or its configuration (agent, team, model,
+// tool, secrets, parameters) before execution started, as opposed to a failure while executing it
+func NewResolutionError(err error) *Error {
+	// Expired credentials are not a problem with the configuration of the target
+	if ReasonFor(err) == ReasonImpersonationExpired {
+		return NewError(ReasonImpersonationExpired, err)
+	}
+	if apierrors.IsNotFound(err) {
+		return NewError(ReasonResourceNotFound, err)
+	}
+	return NewError(ReasonResolutionFailed, err)
+}
+
+// IsResolutionError reports whether err is a configuration problem rather than an execution failure
+func IsResolutionError(err error) bool {
+	var genaiErr *Error
+	if !errors.As(err, &genaiErr) {
+		return false
+	}
+	return genaiErr.Reason == ReasonResourceNotFound || genaiErr.Reason == ReasonResolutionFailed
+}
+
+// ClassifyError annotates err with its reason so that telemetry and status reporting agree on it
+func ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var genaiErr *Error
+	if errors.As(err, &genaiErr) {
+		return err
+	}
+	return NewError(ReasonFor(err), err)
+}
+
+func reasonForStatusCode(statusCode int) ErrorReason {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return ReasonProviderRateLimited
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ReasonProviderAuthFailed
+	case statusCode == http.StatusNotFound:
+		return ReasonResourceNotFound
+	case statusCode >= 500:
+		return ReasonProviderUnavailable
+	case statusCode >= 400:
+		return ReasonProviderBadRequest
+	default:
+		return ReasonInternal
+	}
+}
+
+// newToolError classifies a tool execution failure as a timeout or a generic tool failure
+func newToolError(err error) error {
+	var genaiErr *Error
+	if errors.As(err, &genaiErr) || IsTerminateTeam(err) || GetHandoff(err) != nil || errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return NewError(ReasonToolTimeout, err)
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return NewError(ReasonConnectionFailed, err)
+	}
+	return NewError(ReasonToolFailed, err)
+}
+
+// IsCancellation reports whether err stopped execution because the query was canceled, ran out of time or
+// no longer needed the target, as opposed to failing
+func IsCancellation(err error) bool {
+	reason := ReasonFor(err)
+	return reason == ReasonCanceled || reason == ReasonQueryTimeout || reason == ReasonStoppedEarly
+}
@@ -0,0 +1,64 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestReasonFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected ErrorReason
+	}{
+		{name: "nil", err: nil, expected: ""},
+		{name: "explicit reason", err: fmt.Errorf("wrapped: %w", Errorf(ReasonSchemaViolation, "bad input")), expected: ReasonSchemaViolation},
+		{name: "rate limited", err: fmt.Errorf("call failed: %w", &openai.Error{StatusCode: 429}), expected: ReasonProviderRateLimited},
+		{name: "unauthorized", err: &openai.Error{StatusCode: 401}, expected: ReasonProviderAuthFailed},
+		{name: "bad request", err: &openai.Error{StatusCode: 400}, expected: ReasonProviderBadRequest},
+		{name: "unavailable", err: &openai.Error{StatusCode: 503}, expected: ReasonProviderUnavailable},
+		{name: "deadline", err: fmt.Errorf("timed out: %w", context.DeadlineExceeded), expected: ReasonTimeout},
+		{name: "canceled", err: context.Canceled, expected: ReasonCanceled},
+		{name: "not found", err: apierrors.NewNotFound(schema.GroupResource{Resource: "agents"}, "missing"), expected: ReasonResourceNotFound},
+		{name: "connection refused", err: fmt.Errorf("dial: %w", syscall.ECONNREFUSED), expected: ReasonConnectionFailed},
+		{name: "unknown", err: errors.New("boom"), expected: ReasonInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ReasonFor(tt.err))
+		})
+	}
+}
+
+func TestNewToolError(t *testing.T) {
+	assert.Equal(t, ReasonToolTimeout, ReasonFor(newToolError(context.DeadlineExceeded)))
+	assert.Equal(t, ReasonToolFailed, ReasonFor(newToolError(errors.New("boom"))))
+	assert.Equal(t, ReasonSchemaViolation, ReasonFor(newToolError(Errorf(ReasonSchemaViolation, "bad"))))
+	assert.True(t, IsTerminateTeam(newToolError(&TerminateTeam{})))
+	assert.Equal(t, ReasonConnectionFailed, ReasonFor(newToolError(fmt.Errorf("dial: %w", syscall.ECONNREFUSED))))
+}
+
+func TestReasons(t *testing.T) {
+	err := NewResolutionError(fmt.Errorf("failed to connect to MCP server: %w", syscall.ECONNREFUSED))
+	assert.Equal(t, []ErrorReason{ReasonResolutionFailed, ReasonConnectionFailed}, Reasons(err))
+	assert.Equal(t, []ErrorReason{ReasonInternal}, Reasons(errors.New("boom")))
+	assert.Empty(t, Reasons(nil))
+}
+
+func TestNewResolutionErrorKeepsImpersonationExpired(t *testing.T) {
+	expired := NewError(ReasonImpersonationExpired, errors.New("credentials rejected"))
+	err := NewResolutionError(fmt.Errorf("unable to get agent: %w", expired))
+	assert.Equal(t, ReasonImpersonationExpired, ReasonFor(err))
+	assert.False(t, IsResolutionError(err))
+}
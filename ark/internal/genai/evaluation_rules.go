@@ -0,0 +1,155 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// DefaultRuleMinScore is the score a query needs to pass its rules when the evaluation sets no min-score
+const DefaultRuleMinScore = 0.7
+
+// queryRuleEnv declares the variables the rules of a query evaluation are evaluated over:
+//   - query: the name, namespace and labels of the query
+//   - responses: the responses of the query, each with its target, content, phase and reason
+//   - response: the content of the evaluated response, the one of the response target or else the first
+//   - tokenUsage: the promptTokens, completionTokens and totalTokens of the query
+//   - duration: how long the query took
+var queryRuleEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("query", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("responses", cel.ListType(cel.MapType(cel.StringType, cel.DynType))),
+		cel.Variable("response", cel.StringType),
+		cel.Variable("tokenUsage", cel.MapType(cel.StringType, cel.IntType)),
+		cel.Variable("duration", cel.DurationType),
+	)
+})
+
+// CompileQueryRule compiles the CEL expression of a rule, which must return a boolean
+func CompileQueryRule(rule arkv1alpha1.ExpressionRule) (cel.Program, error) {
+	env, err := queryRuleEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(rule.Expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("rule %s: %w", rule.Name, issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("rule %s: expression must return a bool, not %s", rule.Name, ast.OutputType())
+	}
+	return env.Program(ast)
+}
+
+// EvaluateQueryRules scores a completed query by the weighted share of the rules it passes. The query
+// passes when the score reaches minScore. A rule failing at runtime, such as by reading a missing label,
+// counts as not passed and its error is reported in the metadata.
+func EvaluateQueryRules(rules []arkv1alpha1.ExpressionRule, query *arkv1alpha1.Query, responseTarget string, minScore float64) (*EvaluationResponse, error) {
+	programs := make([]cel.Program, 0, len(rules))
+	for _, rule := range rules {
+		program, err := CompileQueryRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		programs = append(programs, program)
+	}
+
+	activation := queryRuleActivation(query, responseTarget)
+	metadata := map[string]string{}
+	var totalWeight, passedWeight int32
+	passedRules := 0
+	for i, rule := range rules {
+		weight := rule.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		totalWeight += weight
+
+		prefix := fmt.Sprintf("rule_%d_%s", i, rule.Name)
+		passed := false
+		out, _, err := programs[i].Eval(activation)
+		if err != nil {
+			metadata[prefix+"_error"] = err.Error()
+		} else {
+			passed, _ = out.Value().(bool)
+		}
+		if passed {
+			passedWeight += weight
+			passedRules++
+		}
+		metadata[prefix+"_passed"] = strconv.FormatBool(passed)
+		metadata[prefix+"_weight"] = strconv.Itoa(int(weight))
+	}
+
+	score := 0.0
+	if totalWeight > 0 {
+		score = float64(passedWeight) / float64(totalWeight)
+	}
+	metadata["total_rules"] = strconv.Itoa(len(rules))
+	metadata["passed_rules"] = strconv.Itoa(passedRules)
+	metadata["failed_rules"] = strconv.Itoa(len(rules) - passedRules)
+	metadata["total_weight"] = strconv.Itoa(int(totalWeight))
+	metadata["min_score_threshold"] = strconv.FormatFloat(minScore, 'f', -1, 64)
+
+	return &EvaluationResponse{
+		Score:    fmt.Sprintf("%.3f", score),
+		Passed:   score >= minScore,
+		Metadata: metadata,
+	}, nil
+}
+
+// QueryResponseContent returns the content of the query's response from the response target, or of its
+// first response when no target is given
+func QueryResponseContent(query *arkv1alpha1.Query, responseTarget string) string {
+	for i, r := range query.Status.Responses {
+		if (responseTarget == "" && i == 0) || (responseTarget != "" && r.Target.Name == responseTarget) {
+			return r.Content
+		}
+	}
+	return ""
+}
+
+func queryRuleActivation(query *arkv1alpha1.Query, responseTarget string) map[string]any {
+	labels := map[string]any{}
+	for key, value := range query.Labels {
+		labels[key] = value
+	}
+
+	responses := make([]map[string]any, 0, len(query.Status.Responses))
+	for _, r := range query.Status.Responses {
+		responses = append(responses, map[string]any{
+			"target":  map[string]any{"type": r.Target.Type, "name": r.Target.Name},
+			"content": r.Content,
+			"phase":   r.Phase,
+			"reason":  r.Reason,
+		})
+	}
+
+	var duration time.Duration
+	if query.Status.Duration != nil {
+		duration = query.Status.Duration.Duration
+	}
+
+	return map[string]any{
+		"query": map[string]any{
+			"name":      query.Name,
+			"namespace": query.Namespace,
+			"labels":    labels,
+		},
+		"responses": responses,
+		"response":  QueryResponseContent(query, responseTarget),
+		"tokenUsage": map[string]int64{
+			"promptTokens":     query.Status.TokenUsage.PromptTokens,
+			"completionTokens": query.Status.TokenUsage.CompletionTokens,
+			"totalTokens":      query.Status.TokenUsage.TotalTokens,
+		},
+		"duration": duration,
+	}
+}
@@ -0,0 +1,87 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func completedQuery() *arkv1alpha1.Query {
+	return &arkv1alpha1.Query{
+		ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: "default", Labels: map[string]string{"suite": "smoke"}},
+		Status: arkv1alpha1.QueryStatus{
+			Phase: "done",
+			Responses: []arkv1alpha1.Response{
+				{Target: arkv1alpha1.QueryTarget{Type: "agent", Name: "weather-agent"}, Content: "It is sunny in Paris", Phase: "done"},
+				{Target: arkv1alpha1.QueryTarget{Type: "agent", Name: "backup-agent"}, Content: "No idea", Phase: "done"},
+			},
+			TokenUsage: arkv1alpha1.TokenUsage{PromptTokens: 800, CompletionTokens: 200, TotalTokens: 1000},
+			Duration:   &metav1.Duration{Duration: 12 * time.Second},
+		},
+	}
+}
+
+func TestEvaluateQueryRules(t *testing.T) {
+	rules := []arkv1alpha1.ExpressionRule{
+		{Name: "mentions-city", Expression: `response.contains("Paris")`, Weight: 2},
+		{Name: "within-budget", Expression: `tokenUsage.totalTokens <= 500`},
+		{Name: "fast", Expression: `duration < duration("30s")`},
+		{Name: "all-done", Expression: `responses.all(r, r.phase == "done") && query.labels["suite"] == "smoke"`},
+	}
+
+	result, err := EvaluateQueryRules(rules, completedQuery(), "", DefaultRuleMinScore)
+	require.NoError(t, err)
+	assert.Equal(t, "0.800", result.Score)
+	assert.True(t, result.Passed)
+	assert.Equal(t, "3", result.Metadata["passed_rules"])
+	assert.Equal(t, "false", result.Metadata["rule_1_within-budget_passed"])
+	assert.Equal(t, "2", result.Metadata["rule_0_mentions-city_weight"])
+
+	result, err = EvaluateQueryRules(rules, completedQuery(), "backup-agent", 0.9)
+	require.NoError(t, err)
+	assert.Equal(t, "0.400", result.Score, "the response of the response target is evaluated")
+	assert.False(t, result.Passed)
+}
+
+func TestEvaluateQueryRulesRuntimeError(t *testing.T) {
+	rules := []arkv1alpha1.ExpressionRule{{Name: "owner", Expression: `query.labels["owner"] == "team-a"`}}
+
+	result, err := EvaluateQueryRules(rules, completedQuery(), "", DefaultRuleMinScore)
+	require.NoError(t, err)
+	assert.Equal(t, "0.000", result.Score)
+	assert.Equal(t, "false", result.Metadata["rule_0_owner_passed"])
+	assert.Contains(t, result.Metadata["rule_0_owner_error"], "no such key")
+}
+
+func TestCompileQueryRule(t *testing.T) {
+	_, err := CompileQueryRule(arkv1alpha1.ExpressionRule{Name: "valid", Expression: `size(responses) > 0`})
+	require.NoError(t, err)
+
+	_, err = CompileQueryRule(arkv1alpha1.ExpressionRule{Name: "syntax", Expression: `response.contains(`})
+	assert.ErrorContains(t, err, "rule syntax")
+
+	_, err = CompileQueryRule(arkv1alpha1.ExpressionRule{Name: "count", Expression: `tokenUsage.totalTokens`})
+	assert.ErrorContains(t, err, "expression must return a bool")
+
+	_, err = CompileQueryRule(arkv1alpha1.ExpressionRule{Name: "unknown", Expression: `events.size() > 0`})
+	assert.ErrorContains(t, err, "undeclared reference")
+}
+
+func TestQueryResponseContent(t *testing.T) {
+	query := &arkv1alpha1.Query{Status: arkv1alpha1.QueryStatus{Responses: []arkv1alpha1.Response{
+		{Target: arkv1alpha1.QueryTarget{Type: "agent", Name: "writer"}, Content: "first"},
+		{Target: arkv1alpha1.QueryTarget{Type: "agent", Name: "reviewer"}, Content: "second"},
+	}}}
+
+	assert.Equal(t, "first", QueryResponseContent(query, ""))
+	assert.Equal(t, "second", QueryResponseContent(query, "reviewer"))
+	assert.Empty(t, QueryResponseContent(query, "missing"))
+	assert.Empty(t, QueryResponseContent(&arkv1alpha1.Query{}, ""))
+}
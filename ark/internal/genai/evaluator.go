@@ -91,7 +91,7 @@ func callEvaluatorHTTPEndpoint(ctx context.Context, address, endpoint string, re
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpClient := &http.Client{Timeout: timeout}
+	httpClient := &http.Client{Timeout: timeout, Transport: common.NewInstrumentedTransport(common.HTTPClientEvaluator, nil)}
 
 	// Build endpoint URL
 	evaluateURL := address
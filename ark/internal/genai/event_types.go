@@ -51,6 +51,14 @@ type OperationEvent struct {
 	Error      string     `json:"error,omitempty"`
 	Duration   string     `json:"duration,omitempty"`
 	TokenUsage TokenUsage `json:"token_usage,omitempty"`
+	// rollup marks token usage that sums the usage already reported by the operations within this one
+	rollup bool
+}
+
+func (u *TokenUsage) add(other TokenUsage) {
+	u.PromptTokens += other.PromptTokens
+	u.CompletionTokens += other.CompletionTokens
+	u.TotalTokens += other.TotalTokens
 }
 
 func (e OperationEvent) ToMap() map[string]interface{} {
@@ -15,6 +15,7 @@ import (
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
+	"mckinsey.com/ark/internal/common"
 )
 
 // ExecutionEngineMessage represents a chat message in the format expected by execution engines
@@ -71,13 +72,9 @@ type ExecutionEngineResponse struct {
 func convertToExecutionEngineMessage(msg Message) ExecutionEngineMessage {
 	// Handle different message types from OpenAI ChatCompletionMessageParamUnion
 	if msg.OfUser != nil {
-		content := ""
-		if msg.OfUser.Content.OfString.Value != "" {
-			content = msg.OfUser.Content.OfString.Value
-		}
 		return ExecutionEngineMessage{
 			Role:    "user",
-			Content: content,
+			Content: userMessageText(msg.OfUser.Content),
 		}
 	}
 	if msg.OfAssistant != nil {
@@ -148,7 +145,8 @@ func NewExecutionEngineClient(k8sClient client.Client) *ExecutionEngineClient {
 	return &ExecutionEngineClient{
 		client: k8sClient,
 		httpClient: &http.Client{
-			Timeout: 300 * time.Second, // 5 minutes timeout for agent execution
+			Timeout:   300 * time.Second, // 5 minutes timeout for agent execution
+			Transport: common.NewInstrumentedTransport(common.HTTPClientExecutionEngine, nil),
 		},
 	}
 }
@@ -162,11 +160,18 @@ func (c *ExecutionEngineClient) Execute(ctx context.Context, engineRef *arkv1alp
 	})
 	defer engineTracker.Complete("")
 
-	engineAddress, err := c.resolveExecutionEngineAddress(ctx, engineRef, agentConfig.Namespace)
+	engine, err := c.resolveExecutionEngine(ctx, engineRef, agentConfig.Namespace)
 	if err != nil {
 		engineTracker.Fail(err)
 		return nil, fmt.Errorf("failed to resolve execution engine address: %w", err)
 	}
+	engineAddress := engine.Status.LastResolvedAddress
+
+	httpClient, err := c.engineHTTPClient(ctx, engine)
+	if err != nil {
+		engineTracker.Fail(err)
+		return nil, err
+	}
 
 	// Convert messages to execution engine format
 	convertedUserInput := convertToExecutionEngineMessage(userInput)
@@ -198,7 +203,7 @@ func (c *ExecutionEngineClient) Execute(ctx context.Context, engineRef *arkv1alp
 
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		engineTracker.Fail(err)
 		return nil, fmt.Errorf("execution engine request failed: %w", err)
@@ -241,8 +246,8 @@ func (c *ExecutionEngineClient) Execute(ctx context.Context, engineRef *arkv1alp
 	return convertedMessages, nil
 }
 
-// resolveExecutionEngineAddress resolves the address of the execution engine
-func (c *ExecutionEngineClient) resolveExecutionEngineAddress(ctx context.Context, engineRef *arkv1alpha1.ExecutionEngineRef, defaultNamespace string) (string, error) {
+// resolveExecutionEngine returns the execution engine, once its address is resolved
+func (c *ExecutionEngineClient) resolveExecutionEngine(ctx context.Context, engineRef *arkv1alpha1.ExecutionEngineRef, defaultNamespace string) (*arkv1prealpha1.ExecutionEngine, error) {
 	// Resolve execution engine name and namespace
 	engineName := engineRef.Name
 	namespace := engineRef.Namespace
@@ -254,15 +259,31 @@ func (c *ExecutionEngineClient) resolveExecutionEngineAddress(ctx context.Contex
 	var engineCRD arkv1prealpha1.ExecutionEngine
 	engineKey := types.NamespacedName{Name: engineName, Namespace: namespace}
 	if err := c.client.Get(ctx, engineKey, &engineCRD); err != nil {
-		return "", fmt.Errorf("execution engine %s not found in namespace %s: %w", engineName, namespace, err)
+		return nil, fmt.Errorf("execution engine %s not found in namespace %s: %w", engineName, namespace, err)
 	}
 
 	// Check if address is resolved in status
 	if engineCRD.Status.LastResolvedAddress == "" {
-		return "", fmt.Errorf("execution engine %s address not yet resolved", engineName)
+		return nil, fmt.Errorf("execution engine %s address not yet resolved", engineName)
 	}
 
-	return engineCRD.Status.LastResolvedAddress, nil
+	return &engineCRD, nil
+}
+
+// engineHTTPClient returns the HTTP client of requests to the execution engine, which presents the client
+// certificate of the engine when it uses mutual TLS
+func (c *ExecutionEngineClient) engineHTTPClient(ctx context.Context, engine *arkv1prealpha1.ExecutionEngine) (*http.Client, error) {
+	if engine.Spec.TLS == nil {
+		return c.httpClient, nil
+	}
+	clientTLS, err := common.ResolveClientTLS(ctx, c.client, engine.Spec.TLS, engine.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve client certificate of execution engine %s: %w", engine.Name, err)
+	}
+	return &http.Client{
+		Timeout:   c.httpClient.Timeout,
+		Transport: common.NewInstrumentedTransport(common.HTTPClientExecutionEngine, common.NewClientTLSTransport(clientTLS)),
+	}, nil
 }
 
 // buildAgentConfig creates an AgentConfig from the agent and model data
@@ -309,7 +330,8 @@ func buildModelConfig(model *Model) map[string]any {
 		switch model.Type {
 		case ModelTypeAzure:
 			modelConfig["azure"] = configProvider.BuildConfig()
-		case ModelTypeOpenAI:
+		case ModelTypeOpenAI, ModelTypeGateway:
+			// Gateways serve the OpenAI API
 			modelConfig["openai"] = configProvider.BuildConfig()
 		case ModelTypeBedrock:
 			modelConfig["bedrock"] = configProvider.BuildConfig()
@@ -0,0 +1,221 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"mckinsey.com/ark/internal/common"
+)
+
+// FetchConfigMapName is the per-namespace ConfigMap with the domain allowlist of the fetch-url tool.
+// Without it the tool refuses to fetch anything.
+const FetchConfigMapName = "ark-config-fetch"
+
+const (
+	defaultFetchMaxBytes  = 1 << 20
+	defaultFetchTimeout   = 30 * time.Second
+	maxFetchRedirects     = 5
+	fetchTruncationNotice = "\n\n[content truncated at %d bytes]"
+)
+
+// fetchTransport dials only public addresses, so that an allowlisted domain that resolves to a private,
+// loopback or link-local address cannot reach services inside the cluster. The check runs on every
+// connection, including redirects. Proxies are not used, since the check could not see the target.
+var fetchTransport = &http.Transport{
+	DialContext: (&net.Dialer{
+		Timeout:   defaultFetchTimeout,
+		KeepAlive: 30 * time.Second,
+		Control:   checkFetchAddress,
+	}).DialContext,
+	ForceAttemptHTTP2:   true,
+	MaxIdleConns:        100,
+	IdleConnTimeout:     90 * time.Second,
+	TLSHandshakeTimeout: 10 * time.Second,
+}
+
+// FetchConfig is the fetch-url configuration of a namespace
+type FetchConfig struct {
+	AllowedDomains []string
+	MaxBytes       int64
+}
+
+// AllowsHost reports whether the host matches the domain allowlist. Entries may use wildcards,
+// e.g. *.example.com.
+func (c FetchConfig) AllowsHost(host string) bool {
+	host = strings.ToLower(host)
+	for _, allowed := range c.AllowedDomains {
+		if ok, _ := path.Match(allowed, host); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFetchConfig reads the fetch-url configuration from the namespace's fetch ConfigMap
+func GetFetchConfig(ctx context.Context, k8sClient client.Client, namespace string) (FetchConfig, error) {
+	cm := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: FetchConfigMapName, Namespace: namespace}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return FetchConfig{}, fmt.Errorf("fetch-url is not configured: ConfigMap %s not found in namespace %s", FetchConfigMapName, namespace)
+		}
+		return FetchConfig{}, fmt.Errorf("failed to get fetch ConfigMap: %w", err)
+	}
+
+	config := FetchConfig{
+		AllowedDomains: splitList(strings.ToLower(cm.Data["allowedDomains"])),
+		MaxBytes:       defaultFetchMaxBytes,
+	}
+	if maxBytes := cm.Data["maxBytes"]; maxBytes != "" {
+		value, err := strconv.ParseInt(maxBytes, 10, 64)
+		if err != nil || value <= 0 {
+			return config, fmt.Errorf("invalid maxBytes %q in fetch ConfigMap", maxBytes)
+		}
+		config.MaxBytes = value
+	}
+	return config, nil
+}
+
+// FetchURLExecutor is the fetch-url built-in tool. It fetches a web page or document over HTTP(S) from
+// an allowlisted domain and returns its text, truncated at the configured size.
+type FetchURLExecutor struct {
+	K8sClient client.Client
+	Namespace string
+	// HTTPClient replaces the client that only dials public addresses
+	HTTPClient *http.Client
+}
+
+type fetchURLArguments struct {
+	URL string `json:"url"`
+}
+
+func (e *FetchURLExecutor) Execute(ctx context.Context, call ToolCall, recorder EventEmitter) (ToolResult, error) {
+	result := ToolResult{ID: call.ID, Name: call.Function.Name}
+
+	var arguments fetchURLArguments
+	if err := json.Unmarshal([]byte(call.Function.Arguments), &arguments); err != nil {
+		result.Error = fmt.Sprintf("failed to parse arguments: %v", err)
+		return result, fmt.Errorf("failed to parse fetch-url arguments: %w", err)
+	}
+
+	content, err := e.fetch(ctx, arguments.URL)
+	if err != nil {
+		result.Error = err.Error()
+		return result, err
+	}
+	result.Content = content
+	return result, nil
+}
+
+func (e *FetchURLExecutor) fetch(ctx context.Context, rawURL string) (string, error) {
+	config, err := GetFetchConfig(ctx, e.K8sClient, e.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	if err := checkFetchURL(config, target); err != nil {
+		return "", err
+	}
+
+	httpClient := &http.Client{Timeout: defaultFetchTimeout, Transport: common.NewInstrumentedTransport(common.HTTPClientTool, fetchTransport)}
+	if e.HTTPClient != nil {
+		clientCopy := *e.HTTPClient
+		httpClient = &clientCopy
+	}
+	// Redirects must stay on the allowlist too
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxFetchRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxFetchRedirects)
+		}
+		return checkFetchURL(config, req.URL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch URL: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("failed to fetch %s: HTTP error %d: %s", target, resp.StatusCode, resp.Status)
+	}
+	if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil && !isTextMediaType(mediaType) {
+		return "", fmt.Errorf("cannot read %s content from %s: only text content is supported", mediaType, target)
+	}
+
+	// Read one byte past the limit to tell whether the content was truncated
+	body, err := io.ReadAll(io.LimitReader(resp.Body, config.MaxBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", target, err)
+	}
+	if int64(len(body)) > config.MaxBytes {
+		return string(body[:config.MaxBytes]) + fmt.Sprintf(fetchTruncationNotice, config.MaxBytes), nil
+	}
+	return string(body), nil
+}
+
+// checkFetchURL rejects URLs that are not HTTP(S) or whose host is not on the allowlist
+func checkFetchURL(config FetchConfig, target *url.URL) error {
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q: only http and https are supported", target.Scheme)
+	}
+	if target.User != nil {
+		return fmt.Errorf("URLs with credentials are not supported")
+	}
+	if !config.AllowsHost(target.Hostname()) {
+		return fmt.Errorf("domain %s is not allowed", target.Hostname())
+	}
+	return nil
+}
+
+// checkFetchAddress rejects connections to private, loopback, link-local and unspecified addresses
+func checkFetchAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid address %s", host)
+	}
+	if ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("address %s is not allowed: private, loopback and link-local addresses are blocked", ip)
+	}
+	return nil
+}
+
+func isTextMediaType(mediaType string) bool {
+	if strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/xml", "application/xhtml+xml", "application/yaml", "application/javascript":
+		return true
+	}
+	return strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml")
+}
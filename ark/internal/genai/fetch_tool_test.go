@@ -0,0 +1,107 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func fetchExecutor(data map[string]string) *FetchURLExecutor {
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: FetchConfigMapName, Namespace: "default"},
+		Data:       data,
+	}
+	return &FetchURLExecutor{K8sClient: fake.NewClientBuilder().WithObjects(config).Build(), Namespace: "default"}
+}
+
+// localFetchExecutor reaches the local test server, which the default client refuses to dial
+func localFetchExecutor(data map[string]string) *FetchURLExecutor {
+	executor := fetchExecutor(data)
+	executor.HTTPClient = &http.Client{}
+	return executor
+}
+
+func fetchCall(url string) ToolCall {
+	return ToolCall{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{
+		Name:      BuiltinToolFetchURL,
+		Arguments: `{"url": "` + url + `"}`,
+	}}
+}
+
+func TestFetchURLExecutor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<p>" + strings.Repeat("a", 20) + "</p>"))
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte{0x89, 'P', 'N', 'G'})
+		case "/redirect":
+			http.Redirect(w, r, "https://elsewhere.example.com/", http.StatusFound)
+		}
+	}))
+	defer server.Close()
+
+	executor := localFetchExecutor(map[string]string{"allowedDomains": "127.0.0.1, *.example.com"})
+
+	t.Run("returns the content of allowed URLs", func(t *testing.T) {
+		result, err := executor.Execute(context.Background(), fetchCall(server.URL+"/page"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "<p>"+strings.Repeat("a", 20)+"</p>", result.Content)
+	})
+
+	t.Run("truncates content at the size limit", func(t *testing.T) {
+		limited := localFetchExecutor(map[string]string{"allowedDomains": "127.0.0.1", "maxBytes": "10"})
+		result, err := limited.Execute(context.Background(), fetchCall(server.URL+"/page"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "<p>aaaaaaa\n\n[content truncated at 10 bytes]", result.Content)
+	})
+
+	t.Run("rejects domains that are not allowed", func(t *testing.T) {
+		result, err := executor.Execute(context.Background(), fetchCall("https://internal.corp/secrets"), nil)
+		assert.ErrorContains(t, err, "domain internal.corp is not allowed")
+		assert.NotEmpty(t, result.Error)
+	})
+
+	t.Run("rejects redirects to domains that are not allowed", func(t *testing.T) {
+		restricted := localFetchExecutor(map[string]string{"allowedDomains": "127.0.0.1"})
+		_, err := restricted.Execute(context.Background(), fetchCall(server.URL+"/redirect"), nil)
+		assert.ErrorContains(t, err, "domain elsewhere.example.com is not allowed")
+	})
+
+	t.Run("refuses private, loopback and link-local addresses", func(t *testing.T) {
+		guarded := fetchExecutor(map[string]string{"allowedDomains": "127.0.0.1, 10.0.0.1, 169.254.169.254"})
+		for _, target := range []string{server.URL + "/page", "http://10.0.0.1/", "http://169.254.169.254/latest/meta-data/"} {
+			_, err := guarded.Execute(context.Background(), fetchCall(target), nil)
+			assert.ErrorContains(t, err, "private, loopback and link-local addresses are blocked", target)
+		}
+	})
+
+	t.Run("rejects other schemes", func(t *testing.T) {
+		_, err := executor.Execute(context.Background(), fetchCall("file:///etc/passwd"), nil)
+		assert.ErrorContains(t, err, `unsupported URL scheme "file"`)
+	})
+
+	t.Run("rejects binary content", func(t *testing.T) {
+		_, err := executor.Execute(context.Background(), fetchCall(server.URL+"/image"), nil)
+		assert.ErrorContains(t, err, "only text content is supported")
+	})
+
+	t.Run("requires configuration", func(t *testing.T) {
+		unconfigured := &FetchURLExecutor{K8sClient: fake.NewClientBuilder().Build(), Namespace: "default"}
+		_, err := unconfigured.Execute(context.Background(), fetchCall(server.URL+"/page"), nil)
+		assert.ErrorContains(t, err, "fetch-url is not configured")
+	})
+}
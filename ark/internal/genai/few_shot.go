@@ -0,0 +1,262 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/telemetry"
+)
+
+const (
+	// DefaultFewShotTopK is how many examples are added when the agent does not configure it
+	DefaultFewShotTopK = 3
+	// DefaultFewShotMaxTokens caps the tokens examples add when the agent does not configure it
+	DefaultFewShotMaxTokens = 1000
+
+	// fewShotMaxCandidates bounds how many of the newest positive feedbacks are considered
+	fewShotMaxCandidates = 200
+	// charsPerToken is the rough number of characters per token used to estimate prompt size
+	charsPerToken = 4
+)
+
+// fewShotEmbeddings caches the embedding of each example question by embedding model and feedback,
+// so past questions are only embedded once
+var fewShotEmbeddings sync.Map
+
+// FewShotExample is a past question and the answer a user rated positively
+type FewShotExample struct {
+	Question string
+	Answer   string
+	// key identifies the example's feedback in the embedding cache
+	key string
+}
+
+func (e FewShotExample) estimatedTokens() int {
+	return estimateTokens(e.Question) + estimateTokens(e.Answer)
+}
+
+// FewShotSelector picks the positively rated past answers of an agent whose questions are most similar
+// to the current question
+type FewShotSelector struct {
+	client    client.Client
+	namespace string
+	agentName string
+	model     *Model
+	modelKey  string
+	topK      int
+	maxTokens int
+}
+
+// NewFewShotSelector loads the embedding model configured for the agent's few-shot examples
+func NewFewShotSelector(ctx context.Context, k8sClient client.Client, agent *arkv1alpha1.Agent, modelRecorder telemetry.ModelRecorder) (*FewShotSelector, error) {
+	config := agent.Spec.FewShot
+	model, err := LoadModel(ctx, k8sClient, &config.EmbeddingModelRef, agent.Namespace, nil, modelRecorder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load few-shot embedding model: %w", err)
+	}
+	if _, ok := model.Provider.(EmbeddingProvider); !ok {
+		return nil, fmt.Errorf("few-shot embedding model %s does not support embeddings", config.EmbeddingModelRef.Name)
+	}
+
+	// Embeddings are cached by the Model that computed them, so rebinding an alias does not reuse them
+	modelName, modelNamespace, _ := ResolveModelSpec(&config.EmbeddingModelRef, agent.Namespace)
+	modelName = ResolveModelAlias(ctx, k8sClient, modelName, modelNamespace)
+	selector := &FewShotSelector{
+		client:    k8sClient,
+		namespace: agent.Namespace,
+		agentName: agent.Name,
+		model:     model,
+		modelKey:  modelNamespace + "/" + modelName,
+		topK:      DefaultFewShotTopK,
+		maxTokens: DefaultFewShotMaxTokens,
+	}
+	if config.TopK > 0 {
+		selector.topK = int(config.TopK)
+	}
+	if config.MaxTokens > 0 {
+		selector.maxTokens = int(config.MaxTokens)
+	}
+	return selector, nil
+}
+
+// Select returns up to topK examples ordered by similarity to the question, within the token cap
+func (s *FewShotSelector) Select(ctx context.Context, question string) ([]FewShotExample, error) {
+	candidates, err := s.candidates(ctx)
+	if err != nil || len(candidates) == 0 || strings.TrimSpace(question) == "" {
+		return nil, err
+	}
+
+	vectors, err := s.embed(ctx, question, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	questionVector := vectors[0]
+	similarities := make(map[string]float64, len(candidates))
+	for i, candidate := range candidates {
+		similarities[candidate.key] = cosineSimilarity(questionVector, vectors[i+1])
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return similarities[candidates[i].key] > similarities[candidates[j].key]
+	})
+
+	var selected []FewShotExample
+	tokens := 0
+	for _, candidate := range candidates {
+		if len(selected) == s.topK {
+			break
+		}
+		if tokens+candidate.estimatedTokens() > s.maxTokens {
+			continue
+		}
+		tokens += candidate.estimatedTokens()
+		selected = append(selected, candidate)
+	}
+	return selected, nil
+}
+
+// embed returns the embedding of the question followed by those of the candidates, embedding only the
+// candidates that are not cached yet
+func (s *FewShotSelector) embed(ctx context.Context, question string, candidates []FewShotExample) ([][]float64, error) {
+	inputs := []string{question}
+	var missing []int
+	vectors := make([][]float64, len(candidates)+1)
+	for i, candidate := range candidates {
+		if cached, ok := fewShotEmbeddings.Load(s.modelKey + "/" + candidate.key); ok {
+			vectors[i+1] = cached.([]float64)
+			continue
+		}
+		inputs = append(inputs, candidate.Question)
+		missing = append(missing, i)
+	}
+
+	embedded, err := s.model.Embeddings(ctx, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed few-shot questions: %w", err)
+	}
+	vectors[0] = embedded[0]
+	for j, i := range missing {
+		vectors[i+1] = embedded[j+1]
+		fewShotEmbeddings.Store(s.modelKey+"/"+candidates[i].key, embedded[j+1])
+	}
+	return vectors, nil
+}
+
+// candidates returns the questions and answers of the agent that users rated positively, newest first
+func (s *FewShotSelector) candidates(ctx context.Context) ([]FewShotExample, error) {
+	var feedbacks arkv1alpha1.FeedbackList
+	if err := s.client.List(ctx, &feedbacks, client.InNamespace(s.namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list feedback: %w", err)
+	}
+	sort.SliceStable(feedbacks.Items, func(i, j int) bool {
+		return feedbacks.Items[j].CreationTimestamp.Before(&feedbacks.Items[i].CreationTimestamp)
+	})
+
+	queries := map[string]*arkv1alpha1.Query{}
+	seen := map[string]bool{}
+	var candidates []FewShotExample
+	for _, feedback := range feedbacks.Items {
+		if len(candidates) == fewShotMaxCandidates {
+			break
+		}
+		if feedback.Spec.Rating != arkv1alpha1.FeedbackRatingPositive || feedback.Status.Phase != arkv1alpha1.FeedbackPhaseRecorded {
+			continue
+		}
+		if target := feedback.Spec.QueryRef.ResponseTarget; target != "" && target != s.agentName {
+			continue
+		}
+
+		query, ok := queries[feedback.Spec.QueryRef.Name]
+		if !ok {
+			query = &arkv1alpha1.Query{}
+			if err := s.client.Get(ctx, client.ObjectKey{Name: feedback.Spec.QueryRef.Name, Namespace: s.namespace}, query); err != nil {
+				query = nil
+			}
+			queries[feedback.Spec.QueryRef.Name] = query
+		}
+		if query == nil {
+			continue
+		}
+
+		example, ok := s.exampleFromQuery(ctx, query)
+		if !ok || seen[example.Question] {
+			continue
+		}
+		seen[example.Question] = true
+		example.key = string(feedback.UID)
+		candidates = append(candidates, example)
+	}
+	return candidates, nil
+}
+
+// exampleFromQuery returns the query's question and the agent's answer to it
+func (s *FewShotSelector) exampleFromQuery(ctx context.Context, query *arkv1alpha1.Query) (FewShotExample, bool) {
+	var answer string
+	for _, response := range query.Status.Responses {
+		if response.Target.Type == MemberTypeAgent && response.Target.Name == s.agentName && response.Phase == "done" {
+			answer = strings.TrimSpace(response.Content)
+			break
+		}
+	}
+	if answer == "" {
+		return FewShotExample{}, false
+	}
+
+	messages, err := getQueryInputMessages(ctx, *query, s.client)
+	if err != nil {
+		return FewShotExample{}, false
+	}
+	question := strings.TrimSpace(ExtractUserMessageContent(messages))
+	if question == "" {
+		return FewShotExample{}, false
+	}
+	return FewShotExample{Question: question, Answer: answer}, true
+}
+
+// fewShotContext returns the examples for the question as an addition to the system prompt, or an
+// empty string when there are none
+func fewShotContext(ctx context.Context, selector *FewShotSelector, question string) (string, error) {
+	if selector == nil {
+		return "", nil
+	}
+	examples, err := selector.Select(ctx, question)
+	if err != nil || len(examples) == 0 {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\nExamples of earlier questions and answers that users rated as helpful:")
+	for _, example := range examples {
+		fmt.Fprintf(&b, "\n\nQuestion: %s\nAnswer: %s", example.Question, example.Answer)
+	}
+	return b.String(), nil
+}
+
+func estimateTokens(text string) int {
+	return (len(text) + charsPerToken - 1) / charsPerToken
+}
+
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,146 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// fakeEmbeddingProvider embeds text by which topic keywords it mentions
+type fakeEmbeddingProvider struct {
+	calls [][]string
+}
+
+func (p *fakeEmbeddingProvider) ChatCompletion(ctx context.Context, messages []Message, n int64, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	return nil, nil
+}
+
+func (p *fakeEmbeddingProvider) ChatCompletionStream(ctx context.Context, messages []Message, n int64, streamFunc func(*openai.ChatCompletionChunk) error, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	return nil, nil
+}
+
+func (p *fakeEmbeddingProvider) SetOutputSchema(schema *runtime.RawExtension, schemaName string) {}
+
+func (p *fakeEmbeddingProvider) Embeddings(ctx context.Context, inputs []string) ([][]float64, error) {
+	p.calls = append(p.calls, inputs)
+	vectors := make([][]float64, len(inputs))
+	for i, input := range inputs {
+		vector := make([]float64, 3)
+		for j, topic := range []string{"weather", "stock", "recipe"} {
+			if strings.Contains(strings.ToLower(input), topic) {
+				vector[j] = 1
+			}
+		}
+		vectors[i] = vector
+	}
+	return vectors, nil
+}
+
+func fewShotObjects(name, question, answer, rating string, age time.Duration) []client.Object {
+	query := &arkv1alpha1.Query{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: arkv1alpha1.QuerySpec{
+			Input: runtime.RawExtension{Raw: []byte(`"` + question + `"`)},
+		},
+		Status: arkv1alpha1.QueryStatus{
+			Phase: "done",
+			Responses: []arkv1alpha1.Response{
+				{Target: arkv1alpha1.QueryTarget{Type: "agent", Name: "helper"}, Content: answer, Phase: "done"},
+			},
+		},
+	}
+	feedback := &arkv1alpha1.Feedback{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name + "-feedback",
+			Namespace:         "default",
+			UID:               types.UID(name + "-uid"),
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+		},
+		Spec:   arkv1alpha1.FeedbackSpec{QueryRef: arkv1alpha1.QueryRef{Name: name}, Rating: rating},
+		Status: arkv1alpha1.FeedbackStatus{Phase: arkv1alpha1.FeedbackPhaseRecorded},
+	}
+	return []client.Object{query, feedback}
+}
+
+func newTestFewShotSelector(t *testing.T, provider *fakeEmbeddingProvider, topK, maxTokens int, objects ...client.Object) *FewShotSelector {
+	scheme := runtime.NewScheme()
+	require.NoError(t, arkv1alpha1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	return &FewShotSelector{
+		client:    k8sClient,
+		namespace: "default",
+		agentName: "helper",
+		model:     &Model{Type: "openai", Provider: provider},
+		modelKey:  "default/" + t.Name(),
+		topK:      topK,
+		maxTokens: maxTokens,
+	}
+}
+
+func TestFewShotSelectorRanksBySimilarity(t *testing.T) {
+	var objects []client.Object
+	objects = append(objects, fewShotObjects("stock", "What is the stock price?", "It is 42.", arkv1alpha1.FeedbackRatingPositive, time.Minute)...)
+	objects = append(objects, fewShotObjects("weather", "What is the weather in Paris?", "Sunny.", arkv1alpha1.FeedbackRatingPositive, 2*time.Minute)...)
+	objects = append(objects, fewShotObjects("disliked", "Will the weather change?", "No idea.", arkv1alpha1.FeedbackRatingNegative, 3*time.Minute)...)
+
+	provider := &fakeEmbeddingProvider{}
+	selector := newTestFewShotSelector(t, provider, 1, DefaultFewShotMaxTokens, objects...)
+
+	examples, err := selector.Select(context.Background(), "How is the weather today?")
+	require.NoError(t, err)
+	require.Len(t, examples, 1)
+	assert.Equal(t, "What is the weather in Paris?", examples[0].Question)
+	assert.Equal(t, "Sunny.", examples[0].Answer)
+
+	_, err = selector.Select(context.Background(), "Any stock tips?")
+	require.NoError(t, err)
+	require.Len(t, provider.calls, 2)
+	assert.Equal(t, []string{"Any stock tips?"}, provider.calls[1], "example questions are embedded once")
+}
+
+func TestFewShotSelectorTokenCap(t *testing.T) {
+	var objects []client.Object
+	objects = append(objects, fewShotObjects("long", "Weather in Oslo?", strings.Repeat("Rain. ", 100), arkv1alpha1.FeedbackRatingPositive, time.Minute)...)
+	objects = append(objects, fewShotObjects("short", "Weather in Rome?", "Sunny.", arkv1alpha1.FeedbackRatingPositive, 2*time.Minute)...)
+
+	selector := newTestFewShotSelector(t, &fakeEmbeddingProvider{}, 3, 50, objects...)
+
+	examples, err := selector.Select(context.Background(), "What is the weather?")
+	require.NoError(t, err)
+	require.Len(t, examples, 1)
+	assert.Equal(t, "Weather in Rome?", examples[0].Question)
+}
+
+func TestFewShotContext(t *testing.T) {
+	text, err := fewShotContext(context.Background(), nil, "anything")
+	require.NoError(t, err)
+	assert.Empty(t, text)
+
+	objects := fewShotObjects("weather", "What is the weather?", "Sunny.", arkv1alpha1.FeedbackRatingPositive, time.Minute)
+	selector := newTestFewShotSelector(t, &fakeEmbeddingProvider{}, 3, DefaultFewShotMaxTokens, objects...)
+
+	text, err = fewShotContext(context.Background(), selector, "Weather tomorrow?")
+	require.NoError(t, err)
+	assert.Contains(t, text, "Question: What is the weather?\nAnswer: Sunny.")
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	assert.InDelta(t, 1.0, cosineSimilarity([]float64{1, 2}, []float64{2, 4}), 1e-9)
+	assert.InDelta(t, 0.0, cosineSimilarity([]float64{1, 0}, []float64{0, 1}), 1e-9)
+	assert.Zero(t, cosineSimilarity([]float64{1}, []float64{1, 2}))
+	assert.Zero(t, cosineSimilarity([]float64{0, 0}, []float64{1, 2}))
+}
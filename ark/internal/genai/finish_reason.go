@@ -0,0 +1,64 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"sync"
+)
+
+// FinishReason is a machine-readable classification of how a successful execution finished.
+// Values are CamelCase so they can be used directly as condition reasons.
+type FinishReason string
+
+const (
+	// FinishCompleted is a natural finish: every member that was due to run did
+	FinishCompleted FinishReason = "Completed"
+	// FinishMaxTurnsReached is a team that was stopped by its turn limit
+	FinishMaxTurnsReached FinishReason = "MaxTurnsReached"
+	// FinishTerminated is a team that a member ended with the terminate tool
+	FinishTerminated FinishReason = "Terminated"
+)
+
+const finishRecorderKey contextKey = "finishRecorder"
+
+// FinishRecorder collects why the execution of a target stopped before finishing naturally. Teams report on
+// it through the context, so that truncated runs can be told apart from natural finishes.
+type FinishRecorder struct {
+	mu     sync.Mutex
+	reason FinishReason
+}
+
+// NewFinishRecorder returns a recorder for the execution of a single target
+func NewFinishRecorder() *FinishRecorder {
+	return &FinishRecorder{}
+}
+
+// Reason returns the first recorded reason, or FinishCompleted when nothing cut the execution short
+func (r *FinishRecorder) Reason() FinishReason {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.reason == "" {
+		return FinishCompleted
+	}
+	return r.reason
+}
+
+// WithFinishRecorder makes the recorder available to the teams executed with the context
+func WithFinishRecorder(ctx context.Context, recorder *FinishRecorder) context.Context {
+	return context.WithValue(ctx, finishRecorderKey, recorder)
+}
+
+// recordFinishReason records the reason on the context's recorder. The first reason is kept, so a nested team
+// that reached its turn limit is still reported when its parent team finishes afterwards.
+func recordFinishReason(ctx context.Context, reason FinishReason) {
+	recorder, _ := ctx.Value(finishRecorderKey).(*FinishRecorder)
+	if recorder == nil {
+		return
+	}
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if recorder.reason == "" {
+		recorder.reason = reason
+	}
+}
@@ -0,0 +1,89 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+// terminatingTeamMember ends the team when it is executed
+type terminatingTeamMember struct {
+	mockTeamMember
+}
+
+func (m *terminatingTeamMember) Execute(ctx context.Context, userInput Message, history []Message, memory MemoryInterface, eventStream EventStreamInterface) ([]Message, error) {
+	return []Message{NewAssistantMessage("done here")}, &TerminateTeam{}
+}
+
+func TestFinishRecorder(t *testing.T) {
+	recorder := NewFinishRecorder()
+	assert.Equal(t, FinishCompleted, recorder.Reason())
+
+	ctx := WithFinishRecorder(context.Background(), recorder)
+	recordFinishReason(ctx, FinishMaxTurnsReached)
+	recordFinishReason(ctx, FinishTerminated)
+	assert.Equal(t, FinishMaxTurnsReached, recorder.Reason(), "the first reason is kept")
+
+	recordFinishReason(context.Background(), FinishTerminated)
+}
+
+func TestTeamFinishReasons(t *testing.T) {
+	maxTurns := 3
+	tests := []struct {
+		name     string
+		strategy string
+		members  []TeamMember
+		want     FinishReason
+	}{
+		{
+			name:     "sequential team finishes naturally",
+			strategy: "sequential",
+			members:  []TeamMember{&mockTeamMember{name: "researcher"}, &mockTeamMember{name: "writer"}},
+			want:     FinishCompleted,
+		},
+		{
+			name:     "round-robin team reaches its turn limit",
+			strategy: "round-robin",
+			members:  []TeamMember{&mockTeamMember{name: "researcher"}, &mockTeamMember{name: "writer"}},
+			want:     FinishMaxTurnsReached,
+		},
+		{
+			name:     "member terminates the team",
+			strategy: "round-robin",
+			members:  []TeamMember{&mockTeamMember{name: "researcher"}, &terminatingTeamMember{mockTeamMember{name: "writer"}}},
+			want:     FinishTerminated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team := &Team{
+				Name:         "research",
+				Namespace:    "default",
+				Strategy:     tt.strategy,
+				Members:      tt.members,
+				MaxTurns:     &maxTurns,
+				Recorder:     &mockEventRecorder{},
+				TeamRecorder: noop.NewTeamRecorder(),
+			}
+			recorder := NewFinishRecorder()
+			ctx := WithFinishRecorder(context.Background(), recorder)
+
+			var err error
+			switch tt.strategy {
+			case "sequential":
+				_, err = team.executeSequential(ctx, NewUserMessage("go"), nil)
+			case "round-robin":
+				_, err = team.executeRoundRobin(ctx, NewUserMessage("go"), nil)
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, recorder.Reason())
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genaitest_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+	"mckinsey.com/ark/internal/genai/genaitest"
+)
+
+type discardEmitter struct{}
+
+func (discardEmitter) EmitEvent(ctx context.Context, eventType, reason string, data genai.EventData) {
+}
+
+// memoryServer is an in-memory implementation of the memory service API
+type memoryServer struct {
+	mu       sync.Mutex
+	messages map[string][]genai.MessageRecord
+}
+
+func (s *memoryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPost:
+		var request struct {
+			SessionID string            `json:"session_id"`
+			QueryID   string            `json:"query_id"`
+			Messages  []json.RawMessage `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, message := range request.Messages {
+			s.messages[request.SessionID] = append(s.messages[request.SessionID], genai.MessageRecord{
+				SessionID: request.SessionID,
+				QueryID:   request.QueryID,
+				Message:   message,
+			})
+		}
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet:
+		records := s.messages[r.URL.Query().Get("session_id")]
+		_ = json.NewEncoder(w).Encode(genai.MessagesResponse{Messages: records, Total: len(records)})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestNoopMemoryConformance(t *testing.T) {
+	genaitest.MemoryConformance{
+		NewMemory: func(t *testing.T) genai.MemoryInterface { return genai.NewNoopMemory() },
+		Discards:  true,
+	}.Run(t)
+}
+
+func TestHTTPMemoryConformance(t *testing.T) {
+	server := httptest.NewServer(&memoryServer{messages: map[string][]genai.MessageRecord{}})
+	t.Cleanup(server.Close)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, arkv1alpha1.AddToScheme(scheme))
+
+	memory := &arkv1alpha1.Memory{
+		ObjectMeta: metav1.ObjectMeta{Name: "memory", Namespace: "default", UID: "memory-uid"},
+		Spec:       arkv1alpha1.MemorySpec{Address: arkv1alpha1.ValueSource{Value: server.URL}},
+		Status:     arkv1alpha1.MemoryStatus{LastResolvedAddress: &server.URL},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(memory).WithStatusSubresource(memory).Build()
+
+	genaitest.MemoryConformance{
+		NewMemory: func(t *testing.T) genai.MemoryInterface {
+			config := genai.DefaultConfig()
+			config.SessionId = t.Name()
+			m, err := genai.NewHTTPMemory(context.Background(), k8sClient, "memory", "default", discardEmitter{}, config)
+			require.NoError(t, err)
+			return m
+		},
+	}.Run(t)
+}
+
+// streamSink is an in-memory implementation of the streaming service API for a single query
+type streamSink struct {
+	mu        sync.Mutex
+	chunks    []json.RawMessage
+	started   chan struct{}
+	finished  chan struct{}
+	completed chan struct{}
+}
+
+func newStreamSink() *streamSink {
+	return &streamSink{started: make(chan struct{}), finished: make(chan struct{}), completed: make(chan struct{})}
+}
+
+func (s *streamSink) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/complete") {
+		close(s.completed)
+		return
+	}
+
+	close(s.started)
+	defer close(s.finished)
+	scanner := bufio.NewScanner(r.Body)
+	for scanner.Scan() {
+		s.mu.Lock()
+		s.chunks = append(s.chunks, json.RawMessage(append([]byte{}, scanner.Bytes()...)))
+		s.mu.Unlock()
+	}
+}
+
+// received waits for the query to complete and any open stream to be drained
+func (s *streamSink) received(t *testing.T) []json.RawMessage {
+	select {
+	case <-s.completed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream was not completed")
+	}
+	select {
+	case <-s.started:
+		select {
+		case <-s.finished:
+		case <-time.After(5 * time.Second):
+			t.Fatal("stream was not closed")
+		}
+	case <-time.After(time.Second):
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.chunks
+}
+
+func TestHTTPEventStreamConformance(t *testing.T) {
+	genaitest.EventStreamConformance{
+		NewStream: func(t *testing.T) (genai.EventStreamInterface, func() []json.RawMessage) {
+			sink := newStreamSink()
+			server := httptest.NewServer(sink)
+			t.Cleanup(server.Close)
+
+			stream := genai.NewHTTPEventStream(server.URL, "session", "query", server.Client())
+			return stream, func() []json.RawMessage { return sink.received(t) }
+		},
+	}.Run(t)
+}
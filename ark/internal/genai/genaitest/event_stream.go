@@ -0,0 +1,131 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genaitest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mckinsey.com/ark/internal/genai"
+)
+
+// EventStreamConformance describes an EventStreamInterface implementation under test.
+type EventStreamConformance struct {
+	// NewStream returns a stream for a new query, and a function that blocks until the stream has been
+	// completed and returns the chunks the receiving side saw, JSON encoded, in arrival order.
+	NewStream func(t *testing.T) (stream genai.EventStreamInterface, received func() []json.RawMessage)
+}
+
+// testChunk is the payload streamed by the conformance suite
+type testChunk struct {
+	Writer int `json:"writer"`
+	Seq    int `json:"seq"`
+}
+
+// Run runs the event stream conformance suite. It checks that chunks arrive in order and intact,
+// that concurrent writers are serialized, and that invalid chunks fail without breaking the stream.
+func (c EventStreamConformance) Run(t *testing.T) {
+	t.Helper()
+	require.NotNil(t, c.NewStream, "NewStream must be set")
+
+	t.Run("chunks arrive in order", func(t *testing.T) {
+		stream, received := c.newStream(t)
+		ctx := context.Background()
+
+		for seq := range 5 {
+			require.NoError(t, stream.StreamChunk(ctx, testChunk{Seq: seq}))
+		}
+		require.NoError(t, stream.NotifyCompletion(ctx))
+
+		chunks := decodeChunks(t, received())
+		require.Len(t, chunks, 5)
+		for seq, chunk := range chunks {
+			assert.Equal(t, seq, chunk.Seq)
+		}
+	})
+
+	t.Run("completion without chunks", func(t *testing.T) {
+		stream, received := c.newStream(t)
+		require.NoError(t, stream.NotifyCompletion(context.Background()))
+		assert.Empty(t, received())
+	})
+
+	t.Run("concurrent writers are serialized", func(t *testing.T) {
+		stream, received := c.newStream(t)
+		const writers, chunksPerWriter = 4, 25
+
+		var wg sync.WaitGroup
+		errs := make(chan error, writers*chunksPerWriter)
+		for w := range writers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for seq := range chunksPerWriter {
+					errs <- stream.StreamChunk(context.Background(), testChunk{Writer: w, Seq: seq})
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		require.NoError(t, stream.NotifyCompletion(context.Background()))
+
+		chunks := decodeChunks(t, received())
+		require.Len(t, chunks, writers*chunksPerWriter)
+		next := make(map[int]int)
+		for _, chunk := range chunks {
+			assert.Equal(t, next[chunk.Writer], chunk.Seq, "chunks of writer %d out of order", chunk.Writer)
+			next[chunk.Writer] = chunk.Seq + 1
+		}
+	})
+
+	t.Run("invalid chunk fails without breaking the stream", func(t *testing.T) {
+		stream, received := c.newStream(t)
+		ctx := context.Background()
+
+		require.NoError(t, stream.StreamChunk(ctx, testChunk{Seq: 0}))
+		require.Error(t, stream.StreamChunk(ctx, make(chan int)))
+		require.NoError(t, stream.StreamChunk(ctx, testChunk{Seq: 1}))
+		require.NoError(t, stream.NotifyCompletion(ctx))
+
+		chunks := decodeChunks(t, received())
+		require.Len(t, chunks, 2)
+		assert.Equal(t, 1, chunks[1].Seq)
+	})
+
+	t.Run("close is safe after completion", func(t *testing.T) {
+		stream, received := c.newStream(t)
+		require.NoError(t, stream.StreamChunk(context.Background(), testChunk{}))
+		require.NoError(t, stream.NotifyCompletion(context.Background()))
+		received()
+		assert.NoError(t, stream.Close())
+		assert.NoError(t, stream.Close())
+	})
+}
+
+func (c EventStreamConformance) newStream(t *testing.T) (genai.EventStreamInterface, func() []json.RawMessage) {
+	stream, received := c.NewStream(t)
+	require.NotNil(t, stream)
+	require.NotNil(t, received)
+	t.Cleanup(func() { _ = stream.Close() })
+	return stream, received
+}
+
+func decodeChunks(t *testing.T, raw []json.RawMessage) []testChunk {
+	t.Helper()
+	chunks := make([]testChunk, 0, len(raw))
+	for i, data := range raw {
+		var chunk testChunk
+		require.NoError(t, json.Unmarshal(data, &chunk), fmt.Sprintf("chunk %d is not valid JSON: %s", i, data))
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
@@ -0,0 +1,167 @@
+/* Copyright 2025. McKinsey & Company */
+
+// Package genaitest provides conformance tests that MemoryInterface and EventStreamInterface
+// implementations must pass. Backends call the suites from their own tests.
+package genaitest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mckinsey.com/ark/internal/genai"
+)
+
+// MemoryConformance describes a MemoryInterface implementation under test.
+type MemoryConformance struct {
+	// NewMemory returns a memory bound to a new, empty session.
+	NewMemory func(t *testing.T) genai.MemoryInterface
+	// Discards is set for memories that intentionally store nothing, such as NoopMemory. Such memories
+	// must still accept every write and return an empty history.
+	Discards bool
+}
+
+// Run runs the memory conformance suite. It checks that messages are returned in the order they were
+// added, that concurrent writers do not lose or interleave batches, and that failures are reported.
+func (c MemoryConformance) Run(t *testing.T) {
+	t.Helper()
+	require.NotNil(t, c.NewMemory, "NewMemory must be set")
+
+	t.Run("empty session has no messages", func(t *testing.T) {
+		memory := c.newMemory(t)
+		messages, err := memory.GetMessages(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+	})
+
+	t.Run("messages keep their order", func(t *testing.T) {
+		memory := c.newMemory(t)
+		ctx := context.Background()
+
+		require.NoError(t, memory.AddMessages(ctx, "query-1", []genai.Message{
+			genai.NewSystemMessage("system"),
+			genai.NewUserMessage("first"),
+			genai.NewAssistantMessage("second"),
+		}))
+		require.NoError(t, memory.AddMessages(ctx, "query-2", []genai.Message{
+			genai.NewUserMessage("third"),
+		}))
+
+		c.assertHistory(t, memory, []string{"system:system", "user:first", "assistant:second", "user:third"})
+	})
+
+	t.Run("adding no messages is a no-op", func(t *testing.T) {
+		memory := c.newMemory(t)
+		require.NoError(t, memory.AddMessages(context.Background(), "query", nil))
+		c.assertHistory(t, memory, nil)
+	})
+
+	t.Run("concurrent writers keep batches intact", func(t *testing.T) {
+		memory := c.newMemory(t)
+		const writers = 8
+
+		var wg sync.WaitGroup
+		errs := make(chan error, writers)
+		for w := range writers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- memory.AddMessages(context.Background(), fmt.Sprintf("query-%d", w), []genai.Message{
+					genai.NewUserMessage(fmt.Sprintf("question-%d", w)),
+					genai.NewAssistantMessage(fmt.Sprintf("answer-%d", w)),
+				})
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		messages, err := memory.GetMessages(context.Background())
+		require.NoError(t, err)
+		if c.Discards {
+			assert.Empty(t, messages)
+			return
+		}
+
+		history := describeMessages(t, messages)
+		require.Len(t, history, 2*writers)
+		for i := 0; i < len(history); i += 2 {
+			var writer int
+			_, err := fmt.Sscanf(history[i], "user:question-%d", &writer)
+			require.NoError(t, err, "batch %d does not start with a question: %v", i/2, history)
+			assert.Equal(t, fmt.Sprintf("assistant:answer-%d", writer), history[i+1], "batch %d was interleaved: %v", i/2, history)
+		}
+	})
+
+	t.Run("canceled context fails writes", func(t *testing.T) {
+		if c.Discards {
+			t.Skip("memory does not store messages")
+		}
+		memory := c.newMemory(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := memory.AddMessages(ctx, "query", []genai.Message{genai.NewUserMessage("lost")})
+		require.Error(t, err)
+		c.assertHistory(t, memory, nil)
+	})
+
+	t.Run("canceled context fails reads", func(t *testing.T) {
+		if c.Discards {
+			t.Skip("memory does not store messages")
+		}
+		memory := c.newMemory(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := memory.GetMessages(ctx)
+		require.Error(t, err)
+	})
+}
+
+func (c MemoryConformance) newMemory(t *testing.T) genai.MemoryInterface {
+	memory := c.NewMemory(t)
+	require.NotNil(t, memory)
+	t.Cleanup(func() {
+		assert.NoError(t, memory.Close())
+	})
+	return memory
+}
+
+func (c MemoryConformance) assertHistory(t *testing.T, memory genai.MemoryInterface, expected []string) {
+	t.Helper()
+	messages, err := memory.GetMessages(context.Background())
+	require.NoError(t, err)
+	if c.Discards {
+		assert.Empty(t, messages)
+		return
+	}
+	assert.Equal(t, expected, describeMessages(t, messages))
+}
+
+// describeMessages renders messages as "role:content" so histories can be compared across backends
+// that may not round-trip every optional field
+func describeMessages(t *testing.T, messages []genai.Message) []string {
+	t.Helper()
+	var described []string
+	for _, message := range messages {
+		data, err := json.Marshal(openai.ChatCompletionMessageParamUnion(message))
+		require.NoError(t, err)
+
+		var fields struct {
+			Role    string `json:"role"`
+			Content string `json:"content"`
+		}
+		require.NoError(t, json.Unmarshal(data, &fields))
+		described = append(described, fields.Role+":"+fields.Content)
+	}
+	return described
+}
@@ -0,0 +1,114 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/openai/openai-go/packages/param"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Handoff is an explicit delegation of a task from one team member to another
+type Handoff struct {
+	From    string         `json:"from"`
+	Member  string         `json:"member"`
+	Task    string         `json:"task"`
+	Context map[string]any `json:"context,omitempty"`
+}
+
+// HandoffTeam ends the turn of the member that called the handoff tool, so that its team can pass the
+// turn to the member the task was handed off to
+type HandoffTeam struct {
+	Handoff Handoff
+}
+
+func (e *HandoffTeam) Error() string {
+	return "HandoffTeam"
+}
+
+// GetHandoff returns the handoff carried by the error, or nil when the error is not a handoff
+func GetHandoff(err error) *Handoff {
+	var handoffErr *HandoffTeam
+	if !errors.As(err, &handoffErr) {
+		return nil
+	}
+	return &handoffErr.Handoff
+}
+
+// HandoffExecutor is the handoff built-in tool. It hands the current task to another member of the team.
+type HandoffExecutor struct{}
+
+func (e *HandoffExecutor) Execute(ctx context.Context, call ToolCall, recorder EventEmitter) (ToolResult, error) {
+	result := ToolResult{ID: call.ID, Name: call.Function.Name}
+
+	var arguments struct {
+		Member  string         `json:"member"`
+		Task    string         `json:"task"`
+		Context map[string]any `json:"context"`
+	}
+	err := json.Unmarshal([]byte(call.Function.Arguments), &arguments)
+	handoff := Handoff{
+		Member:  strings.TrimSpace(arguments.Member),
+		Task:    strings.TrimSpace(arguments.Task),
+		Context: arguments.Context,
+	}
+	switch {
+	case err != nil:
+		err = fmt.Errorf("failed to parse handoff arguments: %w", err)
+	case handoff.Member == "":
+		err = fmt.Errorf("member is required")
+	case handoff.Task == "":
+		err = fmt.Errorf("task is required")
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result, err
+	}
+
+	result.Content = fmt.Sprintf("Handed off to %s: %s", handoff.Member, handoff.Task)
+	return result, &HandoffTeam{Handoff: handoff}
+}
+
+// handoffMessage returns the assistant message that records the handoff in the team conversation, so that the
+// receiving member and the selector see the delegated task
+func handoffMessage(handoff *Handoff) Message {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Handoff to %s: %s", handoff.Member, handoff.Task)
+	if len(handoff.Context) > 0 {
+		if data, err := json.Marshal(handoff.Context); err == nil {
+			fmt.Fprintf(&b, "\nContext: %s", data)
+		}
+	}
+
+	message := NewAssistantMessage(b.String())
+	message.OfAssistant.Name = param.Opt[string]{Value: handoff.From}
+	return message
+}
+
+// handoffTarget returns the member a handoff is addressed to, when it is one of the candidates. A handoff to
+// any other member is rejected with an event and the team routes the next turn as usual.
+func (t *Team) handoffTarget(ctx context.Context, handoff *Handoff, candidates []TeamMember) TeamMember {
+	for _, member := range candidates {
+		if member.GetName() == handoff.Member {
+			rec := NewExecutionRecorder(t.Recorder)
+			rec.ParticipantSelected(ctx, t.FullName(), member.GetName(), "handoff")
+			return member
+		}
+	}
+
+	t.Recorder.EmitEvent(ctx, corev1.EventTypeWarning, "TeamHandoffRejected", BaseEvent{
+		Name: t.FullName(),
+		Metadata: map[string]string{
+			"from":     handoff.From,
+			"member":   handoff.Member,
+			"strategy": t.Strategy,
+			"teamName": t.FullName(),
+		},
+	})
+	return nil
+}
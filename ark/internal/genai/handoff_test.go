@@ -0,0 +1,163 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+// handoffTestMember answers with a fixed message, or hands off when it has a handoff
+type handoffTestMember struct {
+	name    string
+	answer  string
+	handoff *Handoff
+	history []Message
+}
+
+func (m *handoffTestMember) GetName() string        { return m.name }
+func (m *handoffTestMember) GetType() string        { return MemberTypeAgent }
+func (m *handoffTestMember) GetDescription() string { return "" }
+
+func (m *handoffTestMember) Execute(ctx context.Context, userInput Message, history []Message, memory MemoryInterface, eventStream EventStreamInterface) ([]Message, error) {
+	m.history = history
+	if m.handoff != nil {
+		return nil, fmt.Errorf("tool call failed: %w", &HandoffTeam{Handoff: *m.handoff})
+	}
+	return []Message{NewAssistantMessage(m.answer)}, nil
+}
+
+// handoffEventRecorder records the reasons of the events the team emits
+type handoffEventRecorder struct {
+	reasons []string
+}
+
+func (r *handoffEventRecorder) EmitEvent(ctx context.Context, eventType, reason string, data EventData) {
+	r.reasons = append(r.reasons, reason)
+}
+
+func TestHandoffExecutor(t *testing.T) {
+	executor := &HandoffExecutor{}
+
+	t.Run("hands off the task", func(t *testing.T) {
+		call := ToolCall{ID: "call-1"}
+		call.Function.Name = BuiltinToolHandoff
+		call.Function.Arguments = `{"member": " analyst ", "task": "Check the figures", "context": {"quarter": "Q3"}}`
+
+		result, err := executor.Execute(context.Background(), call, nil)
+		handoff := GetHandoff(err)
+		require.NotNil(t, handoff)
+		assert.Equal(t, Handoff{Member: "analyst", Task: "Check the figures", Context: map[string]any{"quarter": "Q3"}}, *handoff)
+		assert.Equal(t, "Handed off to analyst: Check the figures", result.Content)
+	})
+
+	t.Run("requires a task", func(t *testing.T) {
+		call := ToolCall{ID: "call-2"}
+		call.Function.Arguments = `{"member": "analyst"}`
+
+		result, err := executor.Execute(context.Background(), call, nil)
+		require.EqualError(t, err, "task is required")
+		assert.Nil(t, GetHandoff(err))
+		assert.Equal(t, "task is required", result.Error)
+	})
+}
+
+func TestHandoffMessage(t *testing.T) {
+	message := handoffMessage(&Handoff{From: "researcher", Member: "analyst", Task: "Check the figures", Context: map[string]any{"quarter": "Q3"}})
+	require.NotNil(t, message.OfAssistant)
+	assert.Equal(t, "researcher", message.OfAssistant.Name.Value)
+	assert.Equal(t, "Handoff to analyst: Check the figures\nContext: {\"quarter\":\"Q3\"}", message.OfAssistant.Content.OfString.Value)
+}
+
+func TestTeamSelectorHonorsHandoff(t *testing.T) {
+	researcher := &handoffTestMember{name: "researcher", handoff: &Handoff{Member: "writer", Task: "Write the summary"}}
+	analyst := &handoffTestMember{name: "analyst", answer: "Analysis."}
+	writer := &handoffTestMember{name: "writer", answer: "Summary."}
+	maxTurns := 2
+	team := &Team{
+		Name:      "research",
+		Namespace: "default",
+		Strategy:  "selector",
+		Members:   []TeamMember{researcher, analyst, writer},
+		MaxTurns:  &maxTurns,
+		// With two legal transitions the selector agent would be asked, and it is not configured
+		Graph: &arkv1alpha1.TeamGraphSpec{Edges: []arkv1alpha1.TeamGraphEdge{
+			{From: "researcher", To: "analyst"},
+			{From: "researcher", To: "writer"},
+		}},
+		Selector:     &arkv1alpha1.TeamSelectorSpec{SelectorPrompt: "{{.Participants}}"},
+		Recorder:     &mockEventRecorder{},
+		TeamRecorder: noop.NewTeamRecorder(),
+	}
+
+	messages, err := team.executeSelector(context.Background(), NewUserMessage("Summarize the report"), nil)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "Handoff to writer: Write the summary", messages[0].OfAssistant.Content.OfString.Value)
+	assert.Equal(t, "Summary.", messages[1].OfAssistant.Content.OfString.Value)
+	assert.Nil(t, analyst.history, "the analyst is not selected")
+	assert.Len(t, writer.history, 1, "the writer sees the handoff")
+}
+
+func TestTeamGraphHandoff(t *testing.T) {
+	tests := []struct {
+		name        string
+		handoffTo   string
+		wantAnswer  string
+		wantRejects int
+	}{
+		{name: "routes to the handoff target along an edge", handoffTo: "analyst", wantAnswer: "Analysis."},
+		{name: "rejects a target without an edge", handoffTo: "reviewer", wantAnswer: "Summary.", wantRejects: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			researcher := &handoffTestMember{name: "researcher", handoff: &Handoff{Member: tt.handoffTo, Task: "Take it from here"}}
+			analyst := &handoffTestMember{name: "analyst", answer: "Analysis."}
+			writer := &handoffTestMember{name: "writer", answer: "Summary."}
+			reviewer := &handoffTestMember{name: "reviewer", answer: "Reviewed."}
+			recorder := &handoffEventRecorder{}
+			team := &Team{
+				Name:      "research",
+				Namespace: "default",
+				Strategy:  "graph",
+				Members:   []TeamMember{researcher, analyst, writer, reviewer},
+				Graph: &arkv1alpha1.TeamGraphSpec{Edges: []arkv1alpha1.TeamGraphEdge{
+					{From: "researcher", To: "analyst"},
+					{From: "researcher", To: "writer"},
+				}},
+				Recorder:     recorder,
+				TeamRecorder: noop.NewTeamRecorder(),
+			}
+
+			messages, err := team.executeGraph(context.Background(), NewUserMessage("Summarize the report"), nil)
+			require.NoError(t, err)
+			require.Len(t, messages, 2)
+			assert.Equal(t, tt.wantAnswer, messages[1].OfAssistant.Content.OfString.Value)
+			assert.Nil(t, reviewer.history, "the reviewer has no edge from the researcher")
+
+			rejects := 0
+			for _, reason := range recorder.reasons {
+				if reason == "TeamHandoffRejected" {
+					rejects++
+				}
+			}
+			assert.Equal(t, tt.wantRejects, rejects)
+		})
+	}
+}
+
+func TestTeamHandoffTargetRejectsOtherMembers(t *testing.T) {
+	team := &Team{Name: "research", Namespace: "default", Recorder: &mockEventRecorder{}}
+	candidates := []TeamMember{&mockTeamMember{name: "analyst"}}
+
+	assert.Nil(t, team.handoffTarget(context.Background(), &Handoff{Member: "writer"}, candidates))
+	assert.Equal(t, candidates[0], team.handoffTarget(context.Background(), &Handoff{Member: "analyst"}, candidates))
+}
@@ -0,0 +1,137 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/telemetry"
+)
+
+const defaultJudgePrompt = `You judge the answer of an AI assistant to a user's request against a rubric.
+Reply with a JSON object and nothing else, of the form {"score": <number between 0 and 1>, "rationale": "<why the answer deserves the score>"}, where 0 means the answer does not meet the rubric at all and 1 means it fully meets it.`
+
+// Judgement is the score a judge gave an answer against a rubric, with its rationale
+type Judgement struct {
+	Score     float64
+	Rationale string
+}
+
+// Judge scores answers against a rubric with a judge model or agent
+type Judge struct {
+	config            arkv1alpha1.EvaluatorJudgeConfig
+	client            client.Client
+	recorder          EventEmitter
+	telemetryProvider telemetry.Provider
+}
+
+// NewJudge returns the judge configured for an evaluator. Without a judge model or agent, the answers are
+// judged by the default model.
+func NewJudge(k8sClient client.Client, config arkv1alpha1.EvaluatorJudgeConfig, recorder EventEmitter, telemetryProvider telemetry.Provider) *Judge {
+	return &Judge{
+		config:            config,
+		client:            k8sClient,
+		recorder:          recorder,
+		telemetryProvider: telemetryProvider,
+	}
+}
+
+// Score asks the judge to score the answer to the request against the rubric. A judge agent runs within the
+// query of ctx.
+func (j *Judge) Score(ctx context.Context, namespace, request, answer string) (*Judgement, error) {
+	prompt := GetBuiltinPrompt(ctx, j.client, namespace, PromptJudge)
+	input := fmt.Sprintf("Rubric:\n%s\n\nRequest:\n%s\n\nAnswer:\n%s", j.config.Rubric, request, answer)
+
+	var content string
+	var err error
+	if j.config.Agent != "" {
+		content, err = j.judgeWithAgent(ctx, namespace, NewUserMessage(prompt+"\n\n"+input))
+	} else {
+		content, err = j.judgeWithModel(ctx, namespace, []Message{NewSystemMessage(prompt), NewUserMessage(input)})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseJudgement(content)
+}
+
+func (j *Judge) judgeWithModel(ctx context.Context, namespace string, messages []Message) (string, error) {
+	var modelSpec any = ""
+	if j.config.ModelRef != nil {
+		modelSpec = j.config.ModelRef
+	}
+	model, err := LoadModel(ctx, j.client, modelSpec, namespace, nil, j.telemetryProvider.ModelRecorder())
+	if err != nil {
+		return "", fmt.Errorf("failed to load judge model: %w", err)
+	}
+	return j.complete(ctx, model, messages)
+}
+
+// complete has the judge model reply to the messages
+func (j *Judge) complete(ctx context.Context, model *Model, messages []Message) (string, error) {
+	llmTracker := NewOperationTracker(j.recorder, ctx, "LLMCall", model.Model, map[string]string{
+		"model": model.Model,
+	})
+	completion, err := model.ChatCompletion(ctx, messages, nil, 1)
+	if err != nil {
+		llmTracker.Fail(err)
+		return "", err
+	}
+	if completion == nil || len(completion.Choices) == 0 {
+		err := fmt.Errorf("judge model returned no completion choices")
+		llmTracker.Fail(err)
+		return "", err
+	}
+	llmTracker.CompleteWithTokens(TokenUsage{
+		PromptTokens:     completion.Usage.PromptTokens,
+		CompletionTokens: completion.Usage.CompletionTokens,
+		TotalTokens:      completion.Usage.TotalTokens,
+	})
+	return completion.Choices[0].Message.Content, nil
+}
+
+func (j *Judge) judgeWithAgent(ctx context.Context, namespace string, input Message) (string, error) {
+	var crd arkv1alpha1.Agent
+	if err := j.client.Get(ctx, types.NamespacedName{Name: j.config.Agent, Namespace: namespace}, &crd); err != nil {
+		return "", fmt.Errorf("failed to get judge agent %s: %w", j.config.Agent, err)
+	}
+	// The judgement is scored as it is, so the judge's answers are not reviewed
+	crd.Spec.Review = nil
+
+	judge, err := MakeAgent(ctx, j.client, &crd, j.recorder, j.telemetryProvider)
+	if err != nil {
+		return "", fmt.Errorf("failed to make judge agent %s: %w", j.config.Agent, err)
+	}
+	messages, err := judge.Execute(ctx, input, nil, NewNoopMemory(), nil)
+	if err != nil {
+		return "", fmt.Errorf("judge agent %s failed: %w", j.config.Agent, err)
+	}
+	return lastAssistantContent(messages), nil
+}
+
+// parseJudgement reads the JSON object of the judge's reply, which models sometimes wrap in a code block or
+// surround with text despite the prompt
+func parseJudgement(content string) (*Judgement, error) {
+	start, end := strings.Index(content, "{"), strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("judge returned no judgement: %q", content)
+	}
+	var reply struct {
+		Score     *float64 `json:"score"`
+		Rationale string   `json:"rationale"`
+	}
+	if err := json.Unmarshal([]byte(content[start:end+1]), &reply); err != nil {
+		return nil, fmt.Errorf("judge returned an invalid judgement: %w", err)
+	}
+	if reply.Score == nil || *reply.Score < 0 || *reply.Score > 1 {
+		return nil, fmt.Errorf("judge returned a judgement without a score between 0 and 1")
+	}
+	return &Judgement{Score: *reply.Score, Rationale: strings.TrimSpace(reply.Rationale)}, nil
+}
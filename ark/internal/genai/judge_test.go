@@ -0,0 +1,85 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func TestParseJudgement(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expected    *Judgement
+		expectError string
+	}{
+		{
+			name:     "plain JSON",
+			content:  `{"score": 0.8, "rationale": "Mostly correct"}`,
+			expected: &Judgement{Score: 0.8, Rationale: "Mostly correct"},
+		},
+		{
+			name:     "JSON in a code block",
+			content:  "Here is my judgement:\n```json\n{\"score\": 1, \"rationale\": \" Complete \"}\n```",
+			expected: &Judgement{Score: 1, Rationale: "Complete"},
+		},
+		{
+			name:     "zero score",
+			content:  `{"score": 0, "rationale": "Off topic"}`,
+			expected: &Judgement{Score: 0, Rationale: "Off topic"},
+		},
+		{
+			name:        "no JSON",
+			content:     "The answer is good",
+			expectError: "judge returned no judgement",
+		},
+		{
+			name:        "invalid JSON",
+			content:     `{"score": high}`,
+			expectError: "judge returned an invalid judgement",
+		},
+		{
+			name:        "missing score",
+			content:     `{"rationale": "Fine"}`,
+			expectError: "without a score between 0 and 1",
+		},
+		{
+			name:        "score out of range",
+			content:     `{"score": 7, "rationale": "Great"}`,
+			expectError: "without a score between 0 and 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			judgement, err := parseJudgement(tt.content)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, judgement)
+		})
+	}
+}
+
+func TestJudgeComplete(t *testing.T) {
+	model, provider := scriptedModel(`{"score": 0.5, "rationale": "Half of the steps are missing"}`)
+	judge := NewJudge(nil, arkv1alpha1.EvaluatorJudgeConfig{Rubric: "Lists every step"}, &mockEventRecorder{}, nil)
+
+	content, err := judge.complete(context.Background(), model, []Message{NewSystemMessage(defaultJudgePrompt), NewUserMessage("Rubric:\nLists every step")})
+	require.NoError(t, err)
+	require.Len(t, provider.requests, 1)
+
+	judgement, err := parseJudgement(content)
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, judgement.Score)
+	assert.Equal(t, "Half of the steps are missing", judgement.Rationale)
+}
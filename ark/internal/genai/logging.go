@@ -0,0 +1,150 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// Logging convention for query execution.
+//
+// Every log line emitted while executing a query should carry the query,
+// namespace, session, query ID and (once resolved) target keys. These are attached to the
+// context logger once via WithQueryLogger/WithTargetLogger, so call sites use
+// logf.FromContext(ctx) and only add keys specific to the message.
+const (
+	LogKeyQuery     = "query"
+	LogKeyNamespace = "namespace"
+	LogKeySession   = "session"
+	LogKeyQueryID   = "queryId"
+	LogKeyTarget    = "target"
+)
+
+// Verbosity policy, applied with logger.V(level):
+//   - LogLevelInfo:  lifecycle events (query started/finished, resources created)
+//   - LogLevelDebug: per-step details (model calls, tool calls, team turns)
+//   - LogLevelTrace: payloads (messages, tool arguments, raw responses)
+const (
+	LogLevelInfo  = 0
+	LogLevelDebug = 1
+	LogLevelTrace = 2
+)
+
+// LoggingConfigMapName is the optional per-namespace ConfigMap that overrides
+// query execution verbosity. Its 'level' key accepts info, debug, trace or a number.
+const LoggingConfigMapName = "ark-config-logging"
+
+// WithQueryLogger returns a context whose logger carries the standard query keys
+func WithQueryLogger(ctx context.Context, query *arkv1alpha1.Query, sessionID string) context.Context {
+	logger := logf.FromContext(ctx).WithValues(
+		LogKeyQuery, query.Name,
+		LogKeyNamespace, query.Namespace,
+		LogKeySession, sessionID,
+		LogKeyQueryID, query.Status.CorrelationID,
+	)
+	return logf.IntoContext(ctx, logger)
+}
+
+// WithTargetLogger returns a context whose logger carries the target key
+func WithTargetLogger(ctx context.Context, target string) context.Context {
+	return logf.IntoContext(ctx, logf.FromContext(ctx).WithValues(LogKeyTarget, target))
+}
+
+// WithNamespaceLogLevel applies the namespace's logging override, if any, to the context logger
+func WithNamespaceLogLevel(ctx context.Context, k8sClient client.Client, namespace string) context.Context {
+	level, found, err := GetNamespaceLogLevel(ctx, k8sClient, namespace)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "ignoring invalid logging configuration", "configMap", LoggingConfigMapName)
+		return ctx
+	}
+	if !found {
+		return ctx
+	}
+	return logf.IntoContext(ctx, WithLogLevelOverride(logf.FromContext(ctx), level))
+}
+
+// GetNamespaceLogLevel reads the verbosity override from the namespace's logging ConfigMap
+func GetNamespaceLogLevel(ctx context.Context, k8sClient client.Client, namespace string) (int, bool, error) {
+	cm := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: LoggingConfigMapName, Namespace: namespace}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to get logging ConfigMap: %w", err)
+	}
+
+	levelStr, ok := cm.Data["level"]
+	if !ok {
+		return 0, false, nil
+	}
+
+	level, err := ParseLogLevel(levelStr)
+	if err != nil {
+		return 0, false, err
+	}
+	return level, true, nil
+}
+
+// ParseLogLevel converts a level name or number into a verbosity level
+func ParseLogLevel(value string) (int, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "info":
+		return LogLevelInfo, nil
+	case "debug":
+		return LogLevelDebug, nil
+	case "trace":
+		return LogLevelTrace, nil
+	}
+
+	level, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || level < 0 {
+		return 0, fmt.Errorf("invalid log level %q: expected info, debug, trace or a non-negative number", value)
+	}
+	return level, nil
+}
+
+// WithLogLevelOverride returns a logger that emits messages up to the given verbosity
+// regardless of the global level. Verbose messages are forwarded at the base level and
+// tagged with their original verbosity so they remain distinguishable.
+func WithLogLevelOverride(logger logr.Logger, level int) logr.Logger {
+	sink := logger.GetSink()
+	if sink == nil {
+		return logger
+	}
+	return logger.WithSink(&levelOverrideSink{LogSink: sink, level: level})
+}
+
+type levelOverrideSink struct {
+	logr.LogSink
+	level int
+}
+
+func (s *levelOverrideSink) Enabled(level int) bool {
+	return level <= s.level
+}
+
+func (s *levelOverrideSink) Info(level int, msg string, keysAndValues ...any) {
+	if level > 0 {
+		keysAndValues = append(keysAndValues, "v", level)
+	}
+	s.LogSink.Info(0, msg, keysAndValues...)
+}
+
+func (s *levelOverrideSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return &levelOverrideSink{LogSink: s.LogSink.WithValues(keysAndValues...), level: s.level}
+}
+
+func (s *levelOverrideSink) WithName(name string) logr.LogSink {
+	return &levelOverrideSink{LogSink: s.LogSink.WithName(name), level: s.level}
+}
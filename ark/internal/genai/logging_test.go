@@ -0,0 +1,57 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int
+		wantErr  bool
+	}{
+		{value: "info", expected: LogLevelInfo},
+		{value: "Debug", expected: LogLevelDebug},
+		{value: " trace ", expected: LogLevelTrace},
+		{value: "3", expected: 3},
+		{value: "-1", wantErr: true},
+		{value: "verbose", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			level, err := ParseLogLevel(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, level)
+		})
+	}
+}
+
+func TestWithLogLevelOverride(t *testing.T) {
+	var lines []string
+	base := funcr.New(func(prefix, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{Verbosity: 0})
+
+	logger := WithLogLevelOverride(base, LogLevelDebug).WithValues(LogKeyQuery, "q1")
+
+	logger.Info("lifecycle")
+	logger.V(LogLevelDebug).Info("step")
+	logger.V(LogLevelTrace).Info("payload")
+
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"msg"="lifecycle"`)
+	assert.Contains(t, lines[0], `"query"="q1"`)
+	assert.Contains(t, lines[1], `"msg"="step"`)
+	assert.Contains(t, lines[1], `"v"=1`)
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
@@ -34,11 +35,16 @@ type MCPClient struct {
 const (
 	connectMaxReties = 5
 
-	sseTransport  = "sse"
-	httpTransport = "http"
+	sseTransport   = "sse"
+	httpTransport  = "http"
+	stdioTransport = "stdio"
 
 	sseEndpointPath  = "sse"
 	httpEndpointPath = "mcp"
+
+	// MCPLatestProtocolVersion is the newest MCP protocol version ark negotiates; servers that only speak an
+	// older supported version are connected with that version
+	MCPLatestProtocolVersion = "2025-06-18"
 )
 
 var (
@@ -46,12 +52,17 @@ var (
 	ErrUnsupportedTransport  = "unsupported transport type"
 )
 
-func NewMCPClient(ctx context.Context, baseURL string, headers map[string]string, transportType string, timeout time.Duration, mcpSetting MCPSettings) (*MCPClient, error) {
+func NewMCPClient(ctx context.Context, baseURL string, command []string, headers map[string]string, auth *MCPOAuth2Config, clientTLS *common.ClientTLSConfig, transportType string, timeout time.Duration, mcpSetting MCPSettings) (*MCPClient, error) {
 	mergedHeaders := make(map[string]string)
 	maps.Copy(mergedHeaders, headers)
 	maps.Copy(mergedHeaders, mcpSetting.Headers)
 
-	mcpClient, err := createMCPClientWithRetry(ctx, baseURL, mergedHeaders, transportType, timeout, connectMaxReties)
+	// A stdio server has no URL; its command identifies it in logs and errors
+	if transportType == stdioTransport {
+		baseURL = stdioEndpoint(command)
+	}
+
+	mcpClient, err := createMCPClientWithRetry(ctx, baseURL, command, mergedHeaders, auth, clientTLS, transportType, timeout, connectMaxReties)
 	if err != nil {
 		return nil, err
 	}
@@ -73,10 +84,26 @@ func createHTTPClient() *mcp.Client {
 		Version: arkv1alpha1.GroupVersion.Version,
 	}
 
-	mcpClient := mcp.NewClient(impl, nil)
+	// No sampling or elicitation handlers are set, so ark does not offer these capabilities to servers and
+	// their requests fail as unsupported
+	mcpClient := mcp.NewClient(impl, &mcp.ClientOptions{
+		ProgressNotificationHandler: logToolProgress,
+	})
 	return mcpClient
 }
 
+// logToolProgress logs the progress servers report on the tool calls ark requested progress for
+func logToolProgress(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+	logf.Log.V(1).Info("mcp tool progress", "token", req.Params.ProgressToken, "progress", req.Params.Progress,
+		"total", req.Params.Total, "message", req.Params.Message)
+}
+
+// IsUnsupportedProtocolVersion reports whether connecting to an MCP server failed because the server
+// negotiated a protocol version ark does not support
+func IsUnsupportedProtocolVersion(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "unsupported protocol version")
+}
+
 func performBackoff(ctx context.Context, attempt int, baseURL string) error {
 	log := logf.FromContext(ctx)
 	backoff := time.Duration(1<<uint(attempt)) * time.Second
@@ -90,7 +117,11 @@ func performBackoff(ctx context.Context, attempt int, baseURL string) error {
 	}
 }
 
-func createTransport(baseURL string, headers map[string]string, timeout time.Duration, transportType string) (mcp.Transport, error) {
+func createTransport(baseURL string, command []string, headers map[string]string, auth *MCPOAuth2Config, clientTLS *common.ClientTLSConfig, timeout time.Duration, transportType string) (mcp.Transport, error) {
+	if transportType == stdioTransport {
+		return createStdioTransport(command)
+	}
+
 	// Create HTTP client with headers
 	var httpClient *http.Client
 	if transportType == sseTransport {
@@ -103,11 +134,17 @@ func createTransport(baseURL string, headers map[string]string, timeout time.Dur
 		}
 	}
 
+	var base http.RoundTripper = common.NewInstrumentedTransport(common.HTTPClientMCP, common.NewClientTLSTransport(clientTLS))
+	if auth != nil {
+		base = newOAuth2Transport(auth, base)
+	}
+	httpClient.Transport = base
+
 	// If we have headers, wrap the transport
 	if len(headers) > 0 {
 		httpClient.Transport = &headerTransport{
 			headers: headers,
-			base:    http.DefaultTransport,
+			base:    base,
 		}
 	}
 
@@ -151,10 +188,10 @@ func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return t.base.RoundTrip(req)
 }
 
-func attemptMCPConnection(ctx context.Context, mcpClient *mcp.Client, baseURL string, headers map[string]string, httpTimeout time.Duration, transportType string) (*mcp.ClientSession, error) {
+func attemptMCPConnection(ctx context.Context, mcpClient *mcp.Client, baseURL string, command []string, headers map[string]string, auth *MCPOAuth2Config, clientTLS *common.ClientTLSConfig, httpTimeout time.Duration, transportType string) (*mcp.ClientSession, error) {
 	log := logf.FromContext(ctx)
 
-	transport, err := createTransport(baseURL, headers, httpTimeout, transportType)
+	transport, err := createTransport(baseURL, command, headers, auth, clientTLS, httpTimeout, transportType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create MCP client transport for %s: %w", baseURL, err)
 	}
@@ -173,7 +210,7 @@ func attemptMCPConnection(ctx context.Context, mcpClient *mcp.Client, baseURL st
 	return session, nil
 }
 
-func createMCPClientWithRetry(ctx context.Context, baseURL string, headers map[string]string, transportType string, httpTimeout time.Duration, maxRetries int) (*MCPClient, error) {
+func createMCPClientWithRetry(ctx context.Context, baseURL string, command []string, headers map[string]string, auth *MCPOAuth2Config, clientTLS *common.ClientTLSConfig, transportType string, httpTimeout time.Duration, maxRetries int) (*MCPClient, error) {
 	log := logf.FromContext(ctx)
 
 	mcpClient := createHTTPClient()
@@ -195,7 +232,7 @@ func createMCPClientWithRetry(ctx context.Context, baseURL string, headers map[s
 		// Use the caller's context for the connection
 		// For SSE: This context controls the connection lifetime - when ctx is canceled, connection closes
 		// For HTTP: This context is used per-request
-		session, err := attemptMCPConnection(ctx, mcpClient, baseURL, headers, httpTimeout, transportType)
+		session, err := attemptMCPConnection(ctx, mcpClient, baseURL, command, headers, auth, clientTLS, httpTimeout, transportType)
 		if err == nil {
 			log.Info("MCP client connected successfully", "server", baseURL, "attempts", attempt+1)
 			return &MCPClient{
@@ -248,6 +285,19 @@ func isRetryableError(err error) bool {
 	return false
 }
 
+// Close ends the session with the MCP server. For the stdio transport it stops the command.
+func (c *MCPClient) Close() error {
+	if c.client == nil {
+		return nil
+	}
+	return c.client.Close()
+}
+
+// Ping checks that the session with the MCP server is still alive
+func (c *MCPClient) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx, nil)
+}
+
 func (c *MCPClient) ListTools(ctx context.Context) ([]*mcp.Tool, error) {
 	response, err := c.client.ListTools(ctx, &mcp.ListToolsParams{})
 	if err != nil {
@@ -257,6 +307,108 @@ func (c *MCPClient) ListTools(ctx context.Context) ([]*mcp.Tool, error) {
 	return response.Tools, nil
 }
 
+// ListPrompts returns the prompt templates of the server, or none when the server does not offer prompts
+func (c *MCPClient) ListPrompts(ctx context.Context) ([]*mcp.Prompt, error) {
+	if capabilities := c.serverCapabilities(); capabilities == nil || capabilities.Prompts == nil {
+		return nil, nil
+	}
+	var prompts []*mcp.Prompt
+	for prompt, err := range c.client.Prompts(ctx, nil) {
+		if err != nil {
+			return nil, err
+		}
+		prompts = append(prompts, prompt)
+	}
+	return prompts, nil
+}
+
+// ListResources returns the resources and resource templates of the server, or none when the server does not
+// offer resources
+func (c *MCPClient) ListResources(ctx context.Context) ([]*mcp.Resource, []*mcp.ResourceTemplate, error) {
+	if capabilities := c.serverCapabilities(); capabilities == nil || capabilities.Resources == nil {
+		return nil, nil, nil
+	}
+	var resources []*mcp.Resource
+	for resource, err := range c.client.Resources(ctx, nil) {
+		if err != nil {
+			return nil, nil, err
+		}
+		resources = append(resources, resource)
+	}
+	var templates []*mcp.ResourceTemplate
+	for template, err := range c.client.ResourceTemplates(ctx, nil) {
+		if err != nil {
+			return nil, nil, err
+		}
+		templates = append(templates, template)
+	}
+	return resources, templates, nil
+}
+
+func (c *MCPClient) serverCapabilities() *mcp.ServerCapabilities {
+	if result := c.client.InitializeResult(); result != nil {
+		return result.Capabilities
+	}
+	return nil
+}
+
+// ProtocolVersion returns the MCP protocol version negotiated with the server
+func (c *MCPClient) ProtocolVersion() string {
+	if result := c.client.InitializeResult(); result != nil {
+		return result.ProtocolVersion
+	}
+	return ""
+}
+
+// ServerInfo returns the name and version the server reported, if any
+func (c *MCPClient) ServerInfo() *mcp.Implementation {
+	if result := c.client.InitializeResult(); result != nil {
+		return result.ServerInfo
+	}
+	return nil
+}
+
+// SupportsTools reports whether the server offers tools
+func (c *MCPClient) SupportsTools() bool {
+	capabilities := c.serverCapabilities()
+	return capabilities != nil && capabilities.Tools != nil
+}
+
+// Capabilities returns the sorted names of the capabilities the server offers, such as tools,
+// resources.subscribe or experimental.<name>
+func (c *MCPClient) Capabilities() []string {
+	capabilities := c.serverCapabilities()
+	if capabilities == nil {
+		return nil
+	}
+	var names []string
+	add := func(name string, offered bool) {
+		if offered {
+			names = append(names, name)
+		}
+	}
+	if capabilities.Tools != nil {
+		add("tools", true)
+		add("tools.listChanged", capabilities.Tools.ListChanged)
+	}
+	if capabilities.Prompts != nil {
+		add("prompts", true)
+		add("prompts.listChanged", capabilities.Prompts.ListChanged)
+	}
+	if capabilities.Resources != nil {
+		add("resources", true)
+		add("resources.listChanged", capabilities.Resources.ListChanged)
+		add("resources.subscribe", capabilities.Resources.Subscribe)
+	}
+	add("logging", capabilities.Logging != nil)
+	add("completions", capabilities.Completions != nil)
+	for name := range capabilities.Experimental {
+		add("experimental."+name, true)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // MCP Tool Executor
 type MCPExecutor struct {
 	MCPClient *MCPClient
@@ -284,16 +436,23 @@ func (m *MCPExecutor) Execute(ctx context.Context, call ToolCall, recorder Event
 		arguments = make(map[string]any)
 	}
 
-	log.Info("calling mcp", "tool", m.ToolName, "server", m.MCPClient.baseURL)
-	response, err := m.MCPClient.client.CallTool(ctx, &mcp.CallToolParams{
+	log.V(LogLevelDebug).Info("calling mcp", "tool", m.ToolName, "server", m.MCPClient.baseURL)
+	params := &mcp.CallToolParams{
 		Name:      m.ToolName,
 		Arguments: arguments,
-	})
+	}
+	// Servers that report progress on long-running calls have it logged against the tool call. The SDK only
+	// sets the token on existing metadata.
+	if call.ID != "" {
+		params.Meta = mcp.Meta{}
+		params.SetProgressToken(call.ID)
+	}
+	response, err := m.MCPClient.client.CallTool(ctx, params)
 	if err != nil {
 		log.Info("tool call error", "tool", m.ToolName, "error", err, "errorType", fmt.Sprintf("%T", err))
 		return ToolResult{ID: call.ID, Name: call.Function.Name, Content: ""}, err
 	}
-	log.V(2).Info("tool call response", "tool", m.ToolName, "response", response)
+	log.V(LogLevelTrace).Info("tool call response", "tool", m.ToolName, "response", response)
 	var result strings.Builder
 	for _, content := range response.Content {
 		if textContent, ok := content.(*mcp.TextContent); ok {
@@ -308,6 +467,11 @@ func (m *MCPExecutor) Execute(ctx context.Context, call ToolCall, recorder Event
 
 // BuildMCPServerURL builds the URL for an MCP server with full ValueSource resolution
 func BuildMCPServerURL(ctx context.Context, k8sClient client.Client, mcpServerCRD *arkv1alpha1.MCPServer) (string, error) {
+	// A stdio server runs its command instead of being reached at an address
+	if UsesStdioTransport(mcpServerCRD) {
+		return "", nil
+	}
+
 	address := mcpServerCRD.Spec.Address
 
 	// Handle direct value
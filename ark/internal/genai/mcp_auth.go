@@ -0,0 +1,129 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
+)
+
+// MCPOAuth2Config is the resolved OAuth2 client credentials configuration of an MCP server
+type MCPOAuth2Config struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// ResolveMCPServerAuth resolves the OAuth2 client credentials of an MCPServer, or returns nil when the server
+// does not use OAuth2
+func ResolveMCPServerAuth(ctx context.Context, k8sClient client.Client, mcpServer *arkv1alpha1.MCPServer) (*MCPOAuth2Config, error) {
+	if mcpServer.Spec.Auth == nil || mcpServer.Spec.Auth.OAuth2 == nil {
+		return nil, nil
+	}
+	oauth2Spec := mcpServer.Spec.Auth.OAuth2
+
+	resolver := common.NewValueSourceResolver(k8sClient)
+	clientID, err := resolver.ResolveValueSource(ctx, oauth2Spec.ClientID, mcpServer.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve OAuth2 clientId: %w", err)
+	}
+	clientSecret, err := resolver.ResolveValueSource(ctx, oauth2Spec.ClientSecret, mcpServer.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve OAuth2 clientSecret: %w", err)
+	}
+
+	return &MCPOAuth2Config{
+		TokenURL:     oauth2Spec.TokenURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       oauth2Spec.Scopes,
+	}, nil
+}
+
+// oauth2Transport sets a bearer token obtained with the client credentials grant on every request. The token
+// is reused until it expires. A 401 response drops the token and the request is retried once with a new one.
+type oauth2Transport struct {
+	config *clientcredentials.Config
+	base   http.RoundTripper
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+func newOAuth2Transport(auth *MCPOAuth2Config, base http.RoundTripper) *oauth2Transport {
+	return &oauth2Transport{
+		config: &clientcredentials.Config{
+			ClientID:     auth.ClientID,
+			ClientSecret: auth.ClientSecret,
+			TokenURL:     auth.TokenURL,
+			Scopes:       auth.Scopes,
+		},
+		base: base,
+	}
+}
+
+// currentToken returns the cached token, or obtains a new one when there is none, it expired or it is the
+// token that was rejected
+func (t *oauth2Transport) currentToken(ctx context.Context, rejected *oauth2.Token) (*oauth2.Token, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != nil && t.token != rejected && t.token.Valid() {
+		return t.token, nil
+	}
+
+	token, err := t.config.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain OAuth2 token from %s: %w", t.config.TokenURL, err)
+	}
+	t.token = token
+	return token, nil
+}
+
+func (t *oauth2Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.currentToken(req.Context(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(authorizedRequest(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	// The body of the request was consumed and cannot be sent again
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	token, err = t.currentToken(req.Context(), token)
+	if err != nil {
+		return nil, err
+	}
+	retry := authorizedRequest(req, token)
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	return t.base.RoundTrip(retry)
+}
+
+func authorizedRequest(req *http.Request, token *oauth2.Token) *http.Request {
+	authorized := req.Clone(req.Context())
+	token.SetAuthHeader(authorized)
+	return authorized
+}
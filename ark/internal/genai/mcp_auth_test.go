@@ -0,0 +1,147 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// oauth2TestServers returns a token endpoint issuing numbered tokens and an MCP endpoint that accepts only
+// the given token
+func oauth2TestServers(t *testing.T, acceptedToken string) (tokenServer, mcpServer *httptest.Server, issued *atomic.Int32, bodies *[]string) {
+	issued = &atomic.Int32{}
+	tokenServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, _ := r.BasicAuth()
+		if clientID != "ark" || clientSecret != "s3cret" || r.FormValue("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "Bearer", "expires_in": 3600}`, issued.Add(1))
+	}))
+	t.Cleanup(tokenServer.Close)
+
+	bodies = &[]string{}
+	mcpServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		if r.Header.Get("Authorization") != "Bearer "+acceptedToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(mcpServer.Close)
+	return tokenServer, mcpServer, issued, bodies
+}
+
+func TestOAuth2TransportReusesTokens(t *testing.T) {
+	tokenServer, mcpServer, issued, _ := oauth2TestServers(t, "token-1")
+	httpClient := &http.Client{Transport: newOAuth2Transport(&MCPOAuth2Config{
+		TokenURL:     tokenServer.URL,
+		ClientID:     "ark",
+		ClientSecret: "s3cret",
+		Scopes:       []string{"mcp"},
+	}, http.DefaultTransport)}
+
+	for range 3 {
+		resp, err := httpClient.Get(mcpServer.URL)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.Equal(t, int32(1), issued.Load())
+}
+
+func TestOAuth2TransportRefreshesRejectedTokens(t *testing.T) {
+	tokenServer, mcpServer, issued, bodies := oauth2TestServers(t, "token-2")
+	httpClient := &http.Client{Transport: newOAuth2Transport(&MCPOAuth2Config{
+		TokenURL:     tokenServer.URL,
+		ClientID:     "ark",
+		ClientSecret: "s3cret",
+	}, http.DefaultTransport)}
+
+	resp, err := httpClient.Post(mcpServer.URL, "application/json", strings.NewReader(`{"method": "tools/list"}`))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), issued.Load())
+	assert.Equal(t, []string{`{"method": "tools/list"}`, `{"method": "tools/list"}`}, *bodies, "the retry sends the body again")
+
+	// A refreshed token that is rejected as well ends the request
+	rejecting := &http.Client{Transport: newOAuth2Transport(&MCPOAuth2Config{
+		TokenURL:     tokenServer.URL,
+		ClientID:     "ark",
+		ClientSecret: "s3cret",
+	}, http.DefaultTransport)}
+	resp, err = rejecting.Get(mcpServer.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, int32(4), issued.Load())
+}
+
+func TestOAuth2TransportReportsTokenErrors(t *testing.T) {
+	tokenServer, mcpServer, _, _ := oauth2TestServers(t, "token-1")
+	httpClient := &http.Client{Transport: newOAuth2Transport(&MCPOAuth2Config{
+		TokenURL:     tokenServer.URL,
+		ClientID:     "ark",
+		ClientSecret: "wrong",
+	}, http.DefaultTransport)}
+
+	_, err := httpClient.Get(mcpServer.URL)
+	assert.ErrorContains(t, err, "failed to obtain OAuth2 token from "+tokenServer.URL)
+}
+
+func TestResolveMCPServerAuth(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "mcp-oauth", Namespace: "default"},
+		Data:       map[string][]byte{"client-secret": []byte("s3cret")},
+	}).Build()
+
+	mcpServer := &arkv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "default"},
+	}
+	auth, err := ResolveMCPServerAuth(context.Background(), k8sClient, mcpServer)
+	require.NoError(t, err)
+	assert.Nil(t, auth)
+
+	mcpServer.Spec.Auth = &arkv1alpha1.MCPServerAuth{OAuth2: &arkv1alpha1.OAuth2ClientCredentials{
+		TokenURL: "https://auth.example.com/oauth/token",
+		ClientID: arkv1alpha1.ValueSource{Value: "ark"},
+		ClientSecret: arkv1alpha1.ValueSource{ValueFrom: &arkv1alpha1.ValueFromSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "mcp-oauth"}, Key: "client-secret"},
+		}},
+		Scopes: []string{"tools"},
+	}}
+	auth, err = ResolveMCPServerAuth(context.Background(), k8sClient, mcpServer)
+	require.NoError(t, err)
+	assert.Equal(t, &MCPOAuth2Config{
+		TokenURL:     "https://auth.example.com/oauth/token",
+		ClientID:     "ark",
+		ClientSecret: "s3cret",
+		Scopes:       []string{"tools"},
+	}, auth)
+
+	mcpServer.Spec.Auth.OAuth2.ClientSecret.ValueFrom.SecretKeyRef.Name = "missing"
+	_, err = ResolveMCPServerAuth(context.Background(), k8sClient, mcpServer)
+	assert.ErrorContains(t, err, "failed to resolve OAuth2 clientSecret")
+}
@@ -0,0 +1,161 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"mckinsey.com/ark/internal/common"
+)
+
+// mcpConnectionIdleTimeout is how long an MCP connection that no query or discovery uses is kept open
+const mcpConnectionIdleTimeout = 5 * time.Minute
+
+// mcpConnections is shared by the tool executors of all queries and by the MCPServer controller, so that
+// they reuse MCP sessions instead of connecting and handshaking for every execution
+var mcpConnections = newMCPConnectionPool(mcpConnectionIdleTimeout)
+
+// mcpConnection is a pooled MCP session with the number of users holding it
+type mcpConnection struct {
+	client    *MCPClient
+	err       error
+	ready     chan struct{}
+	refs      int
+	idleTimer *time.Timer
+}
+
+// mcpConnectionPool is a reference-counted pool of MCP sessions. A session is closed once it has been unused
+// for the idle timeout, and an idle session is pinged before it is handed out again.
+type mcpConnectionPool struct {
+	mu          sync.Mutex
+	connections map[string]*mcpConnection
+	idleTimeout time.Duration
+}
+
+func newMCPConnectionPool(idleTimeout time.Duration) *mcpConnectionPool {
+	return &mcpConnectionPool{
+		connections: make(map[string]*mcpConnection),
+		idleTimeout: idleTimeout,
+	}
+}
+
+// AcquireMCPClient returns a pooled MCP client for the server, connecting if there is no open session with the
+// same address, command, headers, credentials, client certificate and settings. The client must be given back with the returned
+// release function and not closed.
+func AcquireMCPClient(ctx context.Context, baseURL string, command []string, headers map[string]string, auth *MCPOAuth2Config, clientTLS *common.ClientTLSConfig, transportType string, timeout time.Duration, mcpSetting MCPSettings) (*MCPClient, func(), error) {
+	key := mcpConnectionKey(baseURL, command, headers, auth, clientTLS, transportType, timeout, mcpSetting)
+	return mcpConnections.acquire(ctx, key, func(ctx context.Context) (*MCPClient, error) {
+		return NewMCPClient(ctx, baseURL, command, headers, auth, clientTLS, transportType, timeout, mcpSetting)
+	})
+}
+
+// mcpConnectionKey hashes everything that makes a session differ, so that credentials are not kept in the key
+func mcpConnectionKey(baseURL string, command []string, headers map[string]string, auth *MCPOAuth2Config, clientTLS *common.ClientTLSConfig, transportType string, timeout time.Duration, mcpSetting MCPSettings) string {
+	data, _ := json.Marshal(struct {
+		BaseURL   string
+		Command   []string
+		Headers   map[string]string
+		Auth      *MCPOAuth2Config
+		TLS       *common.ClientTLSConfig
+		Transport string
+		Timeout   time.Duration
+		Setting   MCPSettings
+	}{baseURL, command, headers, auth, clientTLS, transportType, timeout, mcpSetting})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (p *mcpConnectionPool) acquire(ctx context.Context, key string, connect func(context.Context) (*MCPClient, error)) (*MCPClient, func(), error) {
+	p.mu.Lock()
+	conn, exists := p.connections[key]
+	if !exists {
+		conn = &mcpConnection{ready: make(chan struct{}), refs: 1}
+		p.connections[key] = conn
+		p.mu.Unlock()
+
+		// The session outlives the query or discovery that opened it
+		conn.client, conn.err = connect(context.WithoutCancel(ctx))
+		close(conn.ready)
+		if conn.err != nil {
+			p.remove(key, conn)
+			return nil, nil, conn.err
+		}
+		return conn.client, p.releaser(key, conn), nil
+	}
+
+	conn.refs++
+	idle := conn.idleTimer != nil
+	if idle {
+		conn.idleTimer.Stop()
+		conn.idleTimer = nil
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-conn.ready:
+	case <-ctx.Done():
+		p.release(key, conn)
+		return nil, nil, ctx.Err()
+	}
+	if conn.err != nil {
+		return nil, nil, conn.err
+	}
+
+	if idle {
+		if err := conn.client.Ping(ctx); err != nil {
+			logf.FromContext(ctx).V(1).Info("idle MCP connection failed its health check, reconnecting", "server", conn.client.baseURL, "error", err)
+			p.remove(key, conn)
+			p.release(key, conn)
+			return p.acquire(ctx, key, connect)
+		}
+	}
+	return conn.client, p.releaser(key, conn), nil
+}
+
+// releaser returns a function that gives the connection back once, however often it is called
+func (p *mcpConnectionPool) releaser(key string, conn *mcpConnection) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() { p.release(key, conn) })
+	}
+}
+
+// release drops a reference to the connection. An unused connection is closed after the idle timeout, or at
+// once if it was removed from the pool.
+func (p *mcpConnectionPool) release(key string, conn *mcpConnection) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	conn.refs--
+	if conn.refs > 0 || conn.client == nil {
+		return
+	}
+	if p.connections[key] != conn {
+		_ = conn.client.Close()
+		return
+	}
+	conn.idleTimer = time.AfterFunc(p.idleTimeout, func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if conn.refs == 0 && p.connections[key] == conn {
+			delete(p.connections, key)
+			_ = conn.client.Close()
+		}
+	})
+}
+
+// remove takes the connection out of the pool so that it is no longer handed out
+func (p *mcpConnectionPool) remove(key string, conn *mcpConnection) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.connections[key] == conn {
+		delete(p.connections, key)
+	}
+}
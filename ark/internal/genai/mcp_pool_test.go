@@ -0,0 +1,106 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingConnect connects in-memory clients to the greeter mock and counts the connections
+func countingConnect(t *testing.T, connects *int) func(context.Context) (*MCPClient, error) {
+	return func(context.Context) (*MCPClient, error) {
+		*connects++
+		return connectInMemory(t, mcpServerMock{}.New(t, mcpConnectionOps{}).server), nil
+	}
+}
+
+func TestMCPConnectionPoolReusesConnections(t *testing.T) {
+	pool := newMCPConnectionPool(time.Minute)
+	connects := 0
+
+	first, releaseFirst, err := pool.acquire(t.Context(), "greeter", countingConnect(t, &connects))
+	require.NoError(t, err)
+	second, releaseSecond, err := pool.acquire(t.Context(), "greeter", countingConnect(t, &connects))
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	releaseFirst()
+	releaseFirst()
+	releaseSecond()
+
+	// An idle connection that passes its health check is reused
+	third, releaseThird, err := pool.acquire(t.Context(), "greeter", countingConnect(t, &connects))
+	require.NoError(t, err)
+	assert.Same(t, first, third)
+	releaseThird()
+
+	_, releaseOther, err := pool.acquire(t.Context(), "other", countingConnect(t, &connects))
+	require.NoError(t, err)
+	releaseOther()
+	assert.Equal(t, 2, connects)
+}
+
+func TestMCPConnectionPoolEvictsIdleConnections(t *testing.T) {
+	pool := newMCPConnectionPool(10 * time.Millisecond)
+	connects := 0
+
+	client, release, err := pool.acquire(t.Context(), "greeter", countingConnect(t, &connects))
+	require.NoError(t, err)
+	release()
+
+	assert.Eventually(t, func() bool {
+		pool.mu.Lock()
+		defer pool.mu.Unlock()
+		return len(pool.connections) == 0
+	}, time.Second, 5*time.Millisecond)
+	assert.Error(t, client.Ping(t.Context()), "the evicted session is closed")
+
+	_, release, err = pool.acquire(t.Context(), "greeter", countingConnect(t, &connects))
+	require.NoError(t, err)
+	release()
+	assert.Equal(t, 2, connects)
+}
+
+func TestMCPConnectionPoolReplacesUnhealthyConnections(t *testing.T) {
+	pool := newMCPConnectionPool(time.Minute)
+	connects := 0
+
+	broken, release, err := pool.acquire(t.Context(), "greeter", countingConnect(t, &connects))
+	require.NoError(t, err)
+	release()
+	require.NoError(t, broken.Close())
+
+	healthy, release, err := pool.acquire(t.Context(), "greeter", countingConnect(t, &connects))
+	require.NoError(t, err)
+	defer release()
+	assert.NotSame(t, broken, healthy)
+	assert.NoError(t, healthy.Ping(t.Context()))
+	assert.Equal(t, 2, connects)
+}
+
+func TestMCPConnectionPoolDoesNotKeepFailedConnections(t *testing.T) {
+	pool := newMCPConnectionPool(time.Minute)
+	failing := func(context.Context) (*MCPClient, error) { return nil, errors.New("connection refused") }
+
+	_, _, err := pool.acquire(t.Context(), "greeter", failing)
+	assert.ErrorContains(t, err, "connection refused")
+
+	connects := 0
+	_, release, err := pool.acquire(t.Context(), "greeter", countingConnect(t, &connects))
+	require.NoError(t, err)
+	release()
+	assert.Equal(t, 1, connects)
+}
+
+func TestMCPConnectionKey(t *testing.T) {
+	key := mcpConnectionKey("http://mcp", nil, map[string]string{"Authorization": "Bearer a"}, nil, nil, httpTransport, time.Second, MCPSettings{})
+	assert.Equal(t, key, mcpConnectionKey("http://mcp", nil, map[string]string{"Authorization": "Bearer a"}, nil, nil, httpTransport, time.Second, MCPSettings{}))
+	assert.NotEqual(t, key, mcpConnectionKey("http://mcp", nil, map[string]string{"Authorization": "Bearer b"}, nil, nil, httpTransport, time.Second, MCPSettings{}))
+	assert.NotContains(t, key, "Bearer")
+}
@@ -0,0 +1,53 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// mcpStdioCommands are the executables MCPServers with the stdio transport may run in the controller container.
+// It is set once at startup; while it is empty the stdio transport is disabled.
+var mcpStdioCommands []string
+
+// SetMCPStdioCommands sets the executables MCPServers with the stdio transport may run
+func SetMCPStdioCommands(commands []string) {
+	mcpStdioCommands = commands
+}
+
+// UsesStdioTransport reports whether the MCPServer runs a command instead of being reached at an address
+func UsesStdioTransport(mcpServer *arkv1alpha1.MCPServer) bool {
+	return mcpServer.Spec.Transport == stdioTransport
+}
+
+// CheckMCPStdioCommand returns an error unless the command runs one of the allowed executables
+func CheckMCPStdioCommand(command []string) error {
+	if len(command) == 0 || command[0] == "" {
+		return fmt.Errorf("the %s transport requires a command", stdioTransport)
+	}
+	if !slices.Contains(mcpStdioCommands, command[0]) {
+		return fmt.Errorf("command %s is not allowed for the %s transport: the allowed commands are set with --mcp-stdio-commands", command[0], stdioTransport)
+	}
+	return nil
+}
+
+// stdioEndpoint describes a stdio MCP server in logs and errors, in place of its URL
+func stdioEndpoint(command []string) string {
+	return stdioTransport + ":" + strings.Join(command, " ")
+}
+
+// createStdioTransport starts the command when the client connects and exchanges MCP messages over its stdin and
+// stdout. Closing the session stops the command.
+func createStdioTransport(command []string) (mcp.Transport, error) {
+	if err := CheckMCPStdioCommand(command); err != nil {
+		return nil, err
+	}
+	return &mcp.CommandTransport{Command: exec.Command(command[0], command[1:]...)}, nil
+}
@@ -0,0 +1,57 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mcpStdioHelperEnv = "ARK_TEST_MCP_STDIO_SERVER"
+
+// TestMCPStdioHelperProcess is the stdio MCP server the stdio tests run, by executing the test binary
+func TestMCPStdioHelperProcess(t *testing.T) {
+	if os.Getenv(mcpStdioHelperEnv) != "1" {
+		t.Skip("only runs as the stdio MCP server of the stdio tests")
+	}
+	mock := mcpServerMock{}.New(t, mcpConnectionOps{})
+	if err := mock.server.Run(context.Background(), &mcp.StdioTransport{}); err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func TestCheckMCPStdioCommand(t *testing.T) {
+	t.Cleanup(func() { SetMCPStdioCommands(nil) })
+
+	assert.ErrorContains(t, CheckMCPStdioCommand([]string{"/usr/bin/socat"}), "command /usr/bin/socat is not allowed for the stdio transport")
+
+	SetMCPStdioCommands([]string{"/usr/bin/socat"})
+	assert.NoError(t, CheckMCPStdioCommand([]string{"/usr/bin/socat", "-", "UNIX-CONNECT:/var/run/mcp/server.sock"}))
+	assert.ErrorContains(t, CheckMCPStdioCommand([]string{"/bin/sh", "-c", "socat"}), "is not allowed")
+	assert.ErrorContains(t, CheckMCPStdioCommand(nil), "the stdio transport requires a command")
+}
+
+func TestNewMCPClientWithStdioTransport(t *testing.T) {
+	t.Setenv(mcpStdioHelperEnv, "1")
+	SetMCPStdioCommands([]string{os.Args[0]})
+	t.Cleanup(func() { SetMCPStdioCommands(nil) })
+
+	command := []string{os.Args[0], "-test.run=^TestMCPStdioHelperProcess$"}
+	client, err := NewMCPClient(t.Context(), "", command, nil, nil, nil, stdioTransport, 10*time.Second, MCPSettings{})
+	require.NoError(t, err)
+	defer func() {
+		_ = client.Close()
+	}()
+
+	tools, err := client.ListTools(t.Context())
+	require.NoError(t, err)
+	require.Len(t, tools, 1)
+	assert.Equal(t, "greet", tools[0].Name)
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -129,6 +131,9 @@ func TestNewMCPClient(t *testing.T) {
 				ctx,
 				fmt.Sprintf("http://%s:%s", tc.mcpClient.connectionOptions.host, tc.mcpClient.connectionOptions.port),
 				nil,
+				nil,
+				nil,
+				nil,
 				tc.mcpClient.connectionOptions.transport,
 				1*time.Second,
 				MCPSettings{},
@@ -247,3 +252,101 @@ func waitForServer(t *testing.T, ctx context.Context, url string, timeout time.D
 
 	return fmt.Errorf("server at %s did not become ready within %v", url, timeout)
 }
+
+// connectInMemory connects an MCPClient to the server over an in-memory transport
+func connectInMemory(t *testing.T, server *mcp.Server) *MCPClient {
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	session, err := createHTTPClient().Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = session.Close()
+		_ = serverSession.Wait()
+	})
+	return &MCPClient{client: session}
+}
+
+func TestMCPClientListPromptsAndResources(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "docs", Version: "v0.0.1"}, nil)
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "summarize",
+		Description: "Summarize a document",
+		Arguments:   []*mcp.PromptArgument{{Name: "uri", Required: true}},
+	}, func(context.Context, *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return &mcp.GetPromptResult{}, nil
+	})
+	readResource := func(context.Context, *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		return &mcp.ReadResourceResult{}, nil
+	}
+	server.AddResource(&mcp.Resource{URI: "docs://readme", Name: "readme", MIMEType: "text/markdown"}, readResource)
+	server.AddResourceTemplate(&mcp.ResourceTemplate{URITemplate: "docs://pages/{page}", Name: "page"}, readResource)
+
+	client := connectInMemory(t, server)
+	prompts, err := client.ListPrompts(t.Context())
+	require.NoError(t, err)
+	require.Len(t, prompts, 1)
+	assert.Equal(t, "summarize", prompts[0].Name)
+	assert.Equal(t, "uri", prompts[0].Arguments[0].Name)
+
+	resources, templates, err := client.ListResources(t.Context())
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "docs://readme", resources[0].URI)
+	require.Len(t, templates, 1)
+	assert.Equal(t, "docs://pages/{page}", templates[0].URITemplate)
+
+	// A server that offers only tools has no prompts or resources
+	toolsOnly := connectInMemory(t, mcpServerMock{}.New(t, mcpConnectionOps{}).server)
+	prompts, err = toolsOnly.ListPrompts(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, prompts)
+	resources, templates, err = toolsOnly.ListResources(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+	assert.Empty(t, templates)
+}
+
+func TestMCPClientNegotiation(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "docs", Version: "v1.2.0"}, &mcp.ServerOptions{
+		HasTools:     true,
+		HasResources: true,
+	})
+	server.AddResource(&mcp.Resource{URI: "docs://readme", Name: "readme"}, func(context.Context, *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		return &mcp.ReadResourceResult{}, nil
+	})
+
+	client := connectInMemory(t, server)
+	assert.Equal(t, MCPLatestProtocolVersion, client.ProtocolVersion())
+	require.NotNil(t, client.ServerInfo())
+	assert.Equal(t, "docs", client.ServerInfo().Name)
+	assert.Equal(t, "v1.2.0", client.ServerInfo().Version)
+	assert.True(t, client.SupportsTools())
+	assert.Equal(t, []string{"logging", "resources", "resources.listChanged", "tools", "tools.listChanged"}, client.Capabilities())
+
+	// A server that offers only prompts has no tools to discover
+	promptsOnly := mcp.NewServer(&mcp.Implementation{Name: "prompts"}, &mcp.ServerOptions{HasPrompts: true})
+	assert.False(t, connectInMemory(t, promptsOnly).SupportsTools())
+}
+
+func TestMCPExecutorRequestsProgress(t *testing.T) {
+	var token any
+	server := mcp.NewServer(&mcp.Implementation{Name: "slow"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "index"}, func(ctx context.Context, req *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, any, error) {
+		token = req.Params.GetProgressToken()
+		err := req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{ProgressToken: token, Progress: 1, Total: 2})
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "indexed"}}}, nil, err
+	})
+
+	executor := &MCPExecutor{MCPClient: connectInMemory(t, server), ToolName: "index"}
+	result, err := executor.Execute(t.Context(), ToolCall{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{Name: "index", Arguments: "{}"}}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "indexed", result.Content)
+	assert.Equal(t, "call-1", token)
+}
+
+func TestIsUnsupportedProtocolVersion(t *testing.T) {
+	assert.True(t, IsUnsupportedProtocolVersion(fmt.Errorf("failed to connect MCP client for http://docs: %w", fmt.Errorf("unsupported protocol version: %q", "2099-01-01"))))
+	assert.False(t, IsUnsupportedProtocolVersion(fmt.Errorf("connection refused")))
+	assert.False(t, IsUnsupportedProtocolVersion(nil))
+}
@@ -18,10 +18,11 @@ const (
 	DefaultTimeoutSeconds = 30 // Default timeout in seconds
 	ContentTypeJSON       = "application/json"
 	MessagesEndpoint      = "/messages"
+	SessionsEndpoint      = "/sessions"
+	BlackboardsEndpoint   = "/blackboards"
 	CompletionEndpoint    = "/stream/%s/complete"
 	MaxRetries            = 3
 	RetryDelay            = 100 * time.Millisecond
-	UserAgent             = "ark-memory-client/1.0"
 )
 
 // getMemoryTimeout reads ARK_MEMORY_HTTP_TIMEOUT_SECONDS env var or returns default
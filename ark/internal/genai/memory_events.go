@@ -0,0 +1,93 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"sync"
+
+	"github.com/openai/openai-go"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Memory event types streamed when a query writes to its session's memory
+const (
+	MemoryEventSessionCreated   = "memory.session_created"
+	MemoryEventMessagesAppended = "memory.messages_appended"
+)
+
+// MemoryEventObject identifies memory events among the chunks of an event stream
+const MemoryEventObject = "ark.memory"
+
+const memoryEventsKey contextKey = "memoryEvents"
+
+// MemoryEventData describes the memory mutation an event refers to
+type MemoryEventData struct {
+	Session  string                                   `json:"session"`
+	Messages []openai.ChatCompletionMessageParamUnion `json:"messages,omitempty"`
+}
+
+// MemoryEventWithMetadata is a memory event wrapped with ARK metadata
+type MemoryEventWithMetadata struct {
+	Object string          `json:"object"`
+	Type   string          `json:"type"`
+	Memory MemoryEventData `json:"memory"`
+	Ark    *StreamMetadata `json:"ark,omitempty"`
+}
+
+func (e MemoryEventWithMetadata) streamMetadata() *StreamMetadata { return e.Ark }
+
+// WrapMemoryEventWithMetadata adds ARK metadata to a memory event
+func WrapMemoryEventWithMetadata(ctx context.Context, eventType string, data MemoryEventData) interface{} {
+	return MemoryEventWithMetadata{
+		Object: MemoryEventObject,
+		Type:   eventType,
+		Memory: data,
+		Ark:    buildMetadata(ctx, ""),
+	}
+}
+
+// MemoryEvents streams the memory mutations of a query, so clients render the conversation as it is stored
+// rather than polling the memory service. Targets of a query share it, so a new session is reported once.
+type MemoryEvents struct {
+	eventStream EventStreamInterface
+	session     string
+	created     sync.Once
+}
+
+// NewMemoryEvents returns the memory events of the query's session, streamed to the event stream
+func NewMemoryEvents(eventStream EventStreamInterface, session string) *MemoryEvents {
+	return &MemoryEvents{eventStream: eventStream, session: session}
+}
+
+// WithMemoryEvents makes the memory events available to the targets of a query
+func WithMemoryEvents(ctx context.Context, events *MemoryEvents) context.Context {
+	return context.WithValue(ctx, memoryEventsKey, events)
+}
+
+// StreamMemoryWrite streams the messages a target stored in memory, preceded by the creation of the session
+// when it had no history before the query. It does nothing when the query streams no events.
+func StreamMemoryWrite(ctx context.Context, history, messages []Message) {
+	events, _ := ctx.Value(memoryEventsKey).(*MemoryEvents)
+	if events == nil || events.eventStream == nil || len(messages) == 0 {
+		return
+	}
+	if len(history) == 0 {
+		events.created.Do(func() {
+			events.stream(ctx, MemoryEventSessionCreated, MemoryEventData{Session: events.session})
+		})
+	}
+
+	data := MemoryEventData{Session: events.session, Messages: make([]openai.ChatCompletionMessageParamUnion, len(messages))}
+	for i, message := range messages {
+		data.Messages[i] = openai.ChatCompletionMessageParamUnion(message)
+	}
+	events.stream(ctx, MemoryEventMessagesAppended, data)
+}
+
+// stream sends the event, logging rather than returning failures since the memory write succeeded
+func (e *MemoryEvents) stream(ctx context.Context, eventType string, data MemoryEventData) {
+	if err := e.eventStream.StreamChunk(ctx, WrapMemoryEventWithMetadata(ctx, eventType, data)); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to send memory event to event stream", "type", eventType, "session", data.Session)
+	}
+}
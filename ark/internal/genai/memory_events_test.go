@@ -0,0 +1,53 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamMemoryWrite(t *testing.T) {
+	stream := &capturingEventStream{}
+	ctx := WithMemoryEvents(WithQueryContext(context.Background(), "query-uid", "session-1", "weather"), NewMemoryEvents(stream, "session-1"))
+	messages := []Message{NewUserMessage("What is the weather?"), NewAssistantMessage("Sunny")}
+
+	// Targets running in parallel on a new session both see it without history
+	StreamMemoryWrite(ctx, nil, messages)
+	StreamMemoryWrite(ctx, nil, messages[1:])
+	StreamMemoryWrite(ctx, messages, nil)
+
+	var types []string
+	for _, chunk := range stream.chunks {
+		event, ok := chunk.(MemoryEventWithMetadata)
+		require.True(t, ok)
+		assert.Equal(t, MemoryEventObject, event.Object)
+		assert.Equal(t, "session-1", event.Memory.Session)
+		assert.Equal(t, "session-1", event.Ark.Session)
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []string{MemoryEventSessionCreated, MemoryEventMessagesAppended, MemoryEventMessagesAppended}, types,
+		"the session is created once and writes without messages are not streamed")
+
+	encoded, err := json.Marshal(stream.chunks[1])
+	require.NoError(t, err)
+	var decoded struct {
+		Memory struct {
+			Messages []map[string]any `json:"messages"`
+		} `json:"memory"`
+	}
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, []map[string]any{
+		{"role": "user", "content": "What is the weather?"},
+		{"role": "assistant", "content": "Sunny"},
+	}, decoded.Memory.Messages, "messages are encoded as OpenAI chat messages")
+}
+
+func TestStreamMemoryWriteWithoutEventStream(t *testing.T) {
+	// Queries that do not stream have no memory events in their context
+	StreamMemoryWrite(context.Background(), nil, []Message{NewUserMessage("hi")})
+}
@@ -8,8 +8,10 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 
 	"github.com/openai/openai-go"
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	"mckinsey.com/ark/internal/common"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -20,10 +22,12 @@ type HTTPMemory struct {
 	client     client.Client
 	httpClient *http.Client
 	baseURL    string
+	baseURLMu  sync.Mutex
 	sessionId  string
 	name       string
 	namespace  string
 	recorder   EventEmitter
+	truncation *arkv1alpha1.MemoryTruncation
 }
 
 // NewHTTPMemory creates a new HTTP-based memory implementation
@@ -48,7 +52,7 @@ func NewHTTPMemory(ctx context.Context, k8sClient client.Client, memoryName, nam
 	}
 
 	// Create HTTP client with timeout for memory operations
-	httpClient := common.NewHTTPClientWithLogging(ctx)
+	httpClient := common.NewHTTPClientWithLogging(ctx, common.HTTPClientMemory)
 	if config.Timeout > 0 {
 		httpClient.Timeout = config.Timeout
 	}
@@ -61,23 +65,34 @@ func NewHTTPMemory(ctx context.Context, k8sClient client.Client, memoryName, nam
 		name:       memoryName,
 		namespace:  namespace,
 		recorder:   recorder,
+		truncation: memory.Spec.Truncation,
 	}, nil
 }
 
-// resolveAndUpdateAddress dynamically resolves the memory address and updates the status if it changed
-func (m *HTTPMemory) resolveAndUpdateAddress(ctx context.Context) error {
+// Truncation returns how the history loaded from the memory is truncated
+func (m *HTTPMemory) Truncation() (*arkv1alpha1.MemoryTruncation, string) {
+	return m.truncation, m.namespace
+}
+
+// resolveAndUpdateAddress dynamically resolves the memory address, updates the status if it changed and
+// returns the base URL to use for the request
+func (m *HTTPMemory) resolveAndUpdateAddress(ctx context.Context) (string, error) {
 	memory, err := getMemoryResource(ctx, m.client, m.name, m.namespace)
 	if err != nil {
-		return fmt.Errorf("failed to get memory resource: %w", err)
+		return "", fmt.Errorf("failed to get memory resource: %w", err)
 	}
 
 	// Resolve the address using ValueSourceResolver
 	resolver := common.NewValueSourceResolver(m.client)
 	resolvedAddress, err := resolver.ResolveValueSource(ctx, memory.Spec.Address, m.namespace)
 	if err != nil {
-		return fmt.Errorf("failed to resolve memory address: %w", err)
+		return "", fmt.Errorf("failed to resolve memory address: %w", err)
 	}
 
+	// Memory may be shared by concurrent operations of the same query
+	m.baseURLMu.Lock()
+	defer m.baseURLMu.Unlock()
+
 	// Check if address changed from current baseURL
 	newBaseURL := strings.TrimSuffix(resolvedAddress, "/")
 	if m.baseURL != newBaseURL {
@@ -95,8 +110,8 @@ func (m *HTTPMemory) resolveAndUpdateAddress(ctx context.Context) error {
 	}
 
 	// Update the baseURL
-	m.baseURL = strings.TrimSuffix(resolvedAddress, "/")
-	return nil
+	m.baseURL = newBaseURL
+	return newBaseURL, nil
 }
 
 // AddMessages stores messages to the memory backend
@@ -106,7 +121,8 @@ func (m *HTTPMemory) AddMessages(ctx context.Context, queryID string, messages [
 	}
 
 	// Resolve address dynamically
-	if err := m.resolveAndUpdateAddress(ctx); err != nil {
+	baseURL, err := m.resolveAndUpdateAddress(ctx)
+	if err != nil {
 		return err
 	}
 
@@ -133,7 +149,7 @@ func (m *HTTPMemory) AddMessages(ctx context.Context, queryID string, messages [
 		return fmt.Errorf("failed to serialize messages: %w", err)
 	}
 
-	requestURL := fmt.Sprintf("%s%s", m.baseURL, MessagesEndpoint)
+	requestURL := fmt.Sprintf("%s%s", baseURL, MessagesEndpoint)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(reqBody))
 	if err != nil {
 		tracker.Fail(fmt.Errorf("failed to create request: %w", err))
@@ -141,7 +157,6 @@ func (m *HTTPMemory) AddMessages(ctx context.Context, queryID string, messages [
 	}
 
 	req.Header.Set("Content-Type", ContentTypeJSON)
-	req.Header.Set("User-Agent", UserAgent)
 
 	resp, err := m.httpClient.Do(req)
 	if err != nil {
@@ -163,7 +178,8 @@ func (m *HTTPMemory) AddMessages(ctx context.Context, queryID string, messages [
 // GetMessages retrieves messages from the memory backend
 func (m *HTTPMemory) GetMessages(ctx context.Context) ([]Message, error) {
 	// Resolve address dynamically
-	if err := m.resolveAndUpdateAddress(ctx); err != nil {
+	baseURL, err := m.resolveAndUpdateAddress(ctx)
+	if err != nil {
 		return nil, err
 	}
 
@@ -172,7 +188,7 @@ func (m *HTTPMemory) GetMessages(ctx context.Context) ([]Message, error) {
 		"sessionId": m.sessionId,
 	})
 
-	requestURL := fmt.Sprintf("%s%s?session_id=%s", m.baseURL, MessagesEndpoint, url.QueryEscape(m.sessionId))
+	requestURL := fmt.Sprintf("%s%s?session_id=%s", baseURL, MessagesEndpoint, url.QueryEscape(m.sessionId))
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
 	if err != nil {
 		tracker.Fail(fmt.Errorf("failed to create request: %w", err))
@@ -180,7 +196,6 @@ func (m *HTTPMemory) GetMessages(ctx context.Context) ([]Message, error) {
 	}
 
 	req.Header.Set("Accept", ContentTypeJSON)
-	req.Header.Set("User-Agent", UserAgent)
 
 	resp, err := m.httpClient.Do(req)
 	if err != nil {
@@ -218,6 +233,153 @@ func (m *HTTPMemory) GetMessages(ctx context.Context) ([]Message, error) {
 	return messages, nil
 }
 
+// BlackboardResponse is the blackboard of an execution as returned by the memory backend
+type BlackboardResponse struct {
+	Entries map[string]any `json:"entries"`
+}
+
+// GetBlackboard retrieves the entries of an execution's blackboard from the memory backend. A blackboard
+// that does not exist yet has no entries.
+func (m *HTTPMemory) GetBlackboard(ctx context.Context, executionID string) (map[string]any, error) {
+	baseURL, err := m.resolveAndUpdateAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requestURL := fmt.Sprintf("%s%s/%s?session_id=%s", baseURL, BlackboardsEndpoint, url.PathEscape(executionID), url.QueryEscape(m.sessionId))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", ContentTypeJSON)
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return map[string]any{}, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("HTTP status %d", resp.StatusCode)
+	}
+
+	var response BlackboardResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if response.Entries == nil {
+		response.Entries = map[string]any{}
+	}
+	return response.Entries, nil
+}
+
+// SetBlackboardEntry stores an entry of an execution's blackboard in the memory backend
+func (m *HTTPMemory) SetBlackboardEntry(ctx context.Context, executionID, key string, value any) error {
+	baseURL, err := m.resolveAndUpdateAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	reqBody, err := json.Marshal(map[string]any{
+		"session_id": m.sessionId,
+		"value":      value,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to serialize blackboard entry: %w", err)
+	}
+
+	requestURL := fmt.Sprintf("%s%s/%s/entries/%s", baseURL, BlackboardsEndpoint, url.PathEscape(executionID), url.PathEscape(key))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, requestURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", ContentTypeJSON)
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// DeleteMemorySession removes all messages stored for a session in the given memory backend.
+// A session that does not exist in the backend is not an error. If the memory resource does not exist
+// the Kubernetes NotFound error is returned unwrapped so callers can skip it.
+func DeleteMemorySession(ctx context.Context, k8sClient client.Client, memoryName, namespace, sessionID string) error {
+	var memory arkv1alpha1.Memory
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: memoryName, Namespace: namespace}, &memory); err != nil {
+		return err
+	}
+
+	resolver := common.NewValueSourceResolver(k8sClient)
+	address, err := resolver.ResolveValueSource(ctx, memory.Spec.Address, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to resolve memory address: %w", err)
+	}
+
+	requestURL := fmt.Sprintf("%s%s/%s", strings.TrimSuffix(address, "/"), SessionsEndpoint, url.PathEscape(sessionID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, requestURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpClient := common.NewHTTPClientWithLogging(ctx, common.HTTPClientMemory)
+	httpClient.Timeout = getMemoryTimeout()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// CopyMemorySession appends the messages of a session to another session of the same memory and returns
+// the number of messages copied. The copy is attributed to the given query id.
+func CopyMemorySession(ctx context.Context, k8sClient client.Client, memoryName, namespace string, recorder EventEmitter, fromSessionID, toSessionID, queryID string) (int, error) {
+	config := DefaultConfig()
+	config.SessionId = fromSessionID
+	source, err := NewMemoryWithConfig(ctx, k8sClient, memoryName, namespace, recorder, config)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = source.Close() }()
+
+	messages, err := source.GetMessages(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get messages of session %s: %w", fromSessionID, err)
+	}
+	if len(messages) == 0 {
+		return 0, nil
+	}
+
+	config.SessionId = toSessionID
+	target, err := NewMemoryWithConfig(ctx, k8sClient, memoryName, namespace, recorder, config)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = target.Close() }()
+
+	if err := target.AddMessages(ctx, queryID, messages); err != nil {
+		return 0, fmt.Errorf("failed to add messages to session %s: %w", toSessionID, err)
+	}
+	return len(messages), nil
+}
+
 // Close closes the HTTP client connections
 func (m *HTTPMemory) Close() error {
 	if m.httpClient != nil {
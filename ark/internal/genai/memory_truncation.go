@@ -0,0 +1,169 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/openai/openai-go"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/telemetry"
+)
+
+const (
+	// DefaultTruncationMaxMessages is the number of messages kept when the truncation does not configure it
+	DefaultTruncationMaxMessages = 20
+	// DefaultTruncationMaxTokens is the token budget of the history when the truncation does not configure it
+	DefaultTruncationMaxTokens = 8000
+)
+
+const historySummaryPrompt = `You summarize the earlier part of a conversation between a user and AI agents so that the conversation can continue without it.
+Keep facts, decisions, names, numbers and open questions. Leave out greetings and repetition. Answer with the summary only.`
+
+// TruncatedMemory is implemented by memories that are configured to truncate the history loaded for execution
+type TruncatedMemory interface {
+	// Truncation returns the truncation of the memory, or nil, and the namespace of the memory
+	Truncation() (*arkv1alpha1.MemoryTruncation, string)
+}
+
+// LoadHistory returns the messages of the memory, truncated when the memory is configured to truncate them
+func LoadHistory(ctx context.Context, k8sClient client.Client, memory MemoryInterface, modelRecorder telemetry.ModelRecorder) ([]Message, error) {
+	messages, err := memory.GetMessages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	truncated, ok := memory.(TruncatedMemory)
+	if !ok {
+		return messages, nil
+	}
+	truncation, namespace := truncated.Truncation()
+	return TruncateMessages(ctx, k8sClient, namespace, messages, truncation, modelRecorder)
+}
+
+// TruncateMessages shortens the history with the truncation's strategy. Tool results are never kept without
+// the assistant message that called the tool.
+func TruncateMessages(ctx context.Context, k8sClient client.Client, namespace string, messages []Message, truncation *arkv1alpha1.MemoryTruncation, modelRecorder telemetry.ModelRecorder) ([]Message, error) {
+	if truncation == nil || len(messages) == 0 {
+		return messages, nil
+	}
+
+	maxMessages := DefaultTruncationMaxMessages
+	if truncation.MaxMessages > 0 {
+		maxMessages = int(truncation.MaxMessages)
+	}
+	maxTokens := DefaultTruncationMaxTokens
+	if truncation.MaxTokens > 0 {
+		maxTokens = int(truncation.MaxTokens)
+	}
+
+	switch truncation.Strategy {
+	case arkv1alpha1.MemoryTruncationMessageCount:
+		return keepRecentMessages(messages, maxMessages), nil
+	case arkv1alpha1.MemoryTruncationTokenWindow:
+		return keepRecentTokens(messages, maxTokens), nil
+	case arkv1alpha1.MemoryTruncationSummarize:
+		return summarizeMessages(ctx, k8sClient, namespace, messages, truncation.ModelRef, maxMessages, maxTokens, modelRecorder)
+	default:
+		return nil, fmt.Errorf("unsupported memory truncation strategy: %s", truncation.Strategy)
+	}
+}
+
+// summarizeMessages replaces all but the most recent messages with a summary once the history is over the
+// token budget
+func summarizeMessages(ctx context.Context, k8sClient client.Client, namespace string, messages []Message, modelRef *arkv1alpha1.AgentModelRef, maxMessages, maxTokens int, modelRecorder telemetry.ModelRecorder) ([]Message, error) {
+	if estimateMessagesTokens(messages) <= maxTokens {
+		return messages, nil
+	}
+
+	recent := keepRecentMessages(messages, maxMessages)
+	older := messages[:len(messages)-len(recent)]
+	if formatTranscript(older) == "" {
+		return keepRecentTokens(messages, maxTokens), nil
+	}
+
+	summary, err := summarizeHistory(ctx, k8sClient, namespace, modelRef, older, modelRecorder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize memory: %w", err)
+	}
+	return append([]Message{summary}, recent...), nil
+}
+
+// summarizeHistory returns a system message with a model's summary of the user and assistant turns of the
+// messages
+func summarizeHistory(ctx context.Context, k8sClient client.Client, namespace string, modelRef *arkv1alpha1.AgentModelRef, messages []Message, modelRecorder telemetry.ModelRecorder) (Message, error) {
+	var modelSpec any = ""
+	if modelRef != nil {
+		modelSpec = modelRef
+	}
+	model, err := LoadModel(ctx, k8sClient, modelSpec, namespace, nil, modelRecorder)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to load summary model: %w", err)
+	}
+
+	completion, err := model.ChatCompletion(ctx, []Message{NewSystemMessage(historySummaryPrompt), NewUserMessage(formatTranscript(messages))}, nil, 1)
+	if err != nil {
+		return Message{}, err
+	}
+	if len(completion.Choices) == 0 {
+		return Message{}, fmt.Errorf("model returned no completion choices")
+	}
+
+	return NewSystemMessage("Summary of the earlier conversation:\n" + strings.TrimSpace(completion.Choices[0].Message.Content)), nil
+}
+
+// keepRecentMessages returns the last maxMessages messages
+func keepRecentMessages(messages []Message, maxMessages int) []Message {
+	if len(messages) <= maxMessages {
+		return messages
+	}
+	return dropOrphanedToolMessages(messages[len(messages)-maxMessages:])
+}
+
+// keepRecentTokens returns the most recent messages whose estimated size fits in maxTokens
+func keepRecentTokens(messages []Message, maxTokens int) []Message {
+	tokens := 0
+	start := len(messages)
+	for start > 0 {
+		tokens += estimateMessageTokens(messages[start-1])
+		if tokens > maxTokens {
+			break
+		}
+		start--
+	}
+	if start == 0 {
+		return messages
+	}
+	return dropOrphanedToolMessages(messages[start:])
+}
+
+// dropOrphanedToolMessages removes tool results at the start of a truncated history, whose tool call was
+// truncated away. Models reject tool results without their call.
+func dropOrphanedToolMessages(messages []Message) []Message {
+	for len(messages) > 0 && messages[0].OfTool != nil {
+		messages = messages[1:]
+	}
+	return messages
+}
+
+func estimateMessagesTokens(messages []Message) int {
+	tokens := 0
+	for _, message := range messages {
+		tokens += estimateMessageTokens(message)
+	}
+	return tokens
+}
+
+// estimateMessageTokens estimates the size of a message from its serialized form, so that tool calls count too
+func estimateMessageTokens(message Message) int {
+	data, err := json.Marshal(openai.ChatCompletionMessageParamUnion(message))
+	if err != nil {
+		return 0
+	}
+	return estimateTokens(string(data))
+}
@@ -0,0 +1,81 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func conversation(turns int) []Message {
+	var messages []Message
+	for i := 0; i < turns; i++ {
+		messages = append(messages, NewUserMessage(fmt.Sprintf("question %d", i)), NewAssistantMessage(fmt.Sprintf("answer %d", i)))
+	}
+	return messages
+}
+
+func TestTruncateMessagesMessageCount(t *testing.T) {
+	messages := conversation(5)
+	truncation := &arkv1alpha1.MemoryTruncation{Strategy: arkv1alpha1.MemoryTruncationMessageCount, MaxMessages: 4}
+
+	truncated, err := TruncateMessages(context.Background(), nil, "default", messages, truncation, nil)
+	require.NoError(t, err)
+	require.Len(t, truncated, 4)
+	assert.Equal(t, "question 3", ExtractUserMessageContent(truncated))
+
+	truncated, err = TruncateMessages(context.Background(), nil, "default", messages, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, truncated, 10, "memories without truncation return the whole history")
+}
+
+func TestTruncateMessagesDropsOrphanedToolResults(t *testing.T) {
+	messages := []Message{
+		NewUserMessage("what is the weather?"),
+		NewAssistantMessage("calling the weather tool"),
+		ToolMessage("sunny", "call-1"),
+		NewAssistantMessage("it is sunny"),
+	}
+	truncation := &arkv1alpha1.MemoryTruncation{Strategy: arkv1alpha1.MemoryTruncationMessageCount, MaxMessages: 2}
+
+	truncated, err := TruncateMessages(context.Background(), nil, "default", messages, truncation, nil)
+	require.NoError(t, err)
+	require.Len(t, truncated, 1)
+	assert.NotNil(t, truncated[0].OfAssistant)
+}
+
+func TestTruncateMessagesTokenWindow(t *testing.T) {
+	messages := []Message{
+		NewUserMessage(strings.Repeat("long question ", 100)),
+		NewAssistantMessage("short answer"),
+		NewUserMessage("short question"),
+	}
+	maxTokens := estimateMessageTokens(messages[1]) + estimateMessageTokens(messages[2])
+	truncation := &arkv1alpha1.MemoryTruncation{Strategy: arkv1alpha1.MemoryTruncationTokenWindow, MaxTokens: int32(maxTokens)}
+
+	truncated, err := TruncateMessages(context.Background(), nil, "default", messages, truncation, nil)
+	require.NoError(t, err)
+	require.Len(t, truncated, 2)
+	assert.Equal(t, "short question", ExtractUserMessageContent(truncated))
+}
+
+func TestTruncateMessagesSummarizeWithinBudget(t *testing.T) {
+	messages := conversation(3)
+	truncation := &arkv1alpha1.MemoryTruncation{Strategy: arkv1alpha1.MemoryTruncationSummarize, MaxMessages: 2}
+
+	truncated, err := TruncateMessages(context.Background(), nil, "default", messages, truncation, nil)
+	require.NoError(t, err)
+	assert.Equal(t, messages, truncated, "histories within the token budget are not summarized")
+}
+
+func TestTruncateMessagesUnsupportedStrategy(t *testing.T) {
+	_, err := TruncateMessages(context.Background(), nil, "default", conversation(1), &arkv1alpha1.MemoryTruncation{Strategy: "random"}, nil)
+	assert.ErrorContains(t, err, "unsupported memory truncation strategy")
+}
@@ -2,7 +2,11 @@
 
 package genai
 
-import "github.com/openai/openai-go"
+import (
+	"strings"
+
+	"github.com/openai/openai-go"
+)
 
 // PrepareExecutionMessages separates the current message from context messages
 // and combines with memory history for agent/team execution.
@@ -24,8 +28,8 @@ func ExtractUserMessageContent(messages []Message) string {
 	for _, msg := range messages {
 		msgUnion := openai.ChatCompletionMessageParamUnion(msg)
 		if msgUnion.OfUser != nil {
-			if content := msgUnion.OfUser.Content; content.OfString.Value != "" {
-				return content.OfString.Value
+			if content := userMessageText(msgUnion.OfUser.Content); content != "" {
+				return content
 			}
 		}
 	}
@@ -51,3 +55,18 @@ func PrepareNewMessagesForMemory(inputMessages, responseMessages []Message) []Me
 	newMessages = append(newMessages, responseMessages...)
 	return newMessages
 }
+
+// userMessageText returns the text of a user message. Content with attachments is split into parts, of
+// which the text parts are joined.
+func userMessageText(content openai.ChatCompletionUserMessageParamContentUnion) string {
+	if content.OfString.Value != "" {
+		return content.OfString.Value
+	}
+	var texts []string
+	for _, part := range content.OfArrayOfContentParts {
+		if part.OfText != nil {
+			texts = append(texts, part.OfText.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,83 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+var (
+	modelTokens = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ark_model_tokens_total",
+		Help: "Number of tokens used by model calls, by namespace, model and token type (prompt or completion)",
+	}, []string{"namespace", "model", "type"})
+
+	modelThrottled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ark_model_throttled_total",
+		Help: "Number of model calls rate limited by the provider with 429 Too Many Requests, by namespace and model",
+	}, []string{"namespace", "model"})
+
+	toolCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ark_tool_call_duration_seconds",
+		Help:    "Duration of tool calls, by namespace, tool, tool type and result (success or error)",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"namespace", "tool", "type", "result"})
+
+	mcpConnectionFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ark_mcp_connection_failures_total",
+		Help: "Number of MCP clients that could not be created for an MCPServer, after all connection retries",
+	}, []string{"namespace", "server", "transport"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(modelTokens, modelThrottled, toolCallDuration, mcpConnectionFailures)
+}
+
+const (
+	toolCallSucceeded = "success"
+	toolCallFailed    = "error"
+)
+
+// metricsNamespace returns the namespace of the query being executed, or an empty string outside a query
+func metricsNamespace(ctx context.Context) string {
+	if query, ok := ctx.Value(QueryContextKey).(*arkv1alpha1.Query); ok {
+		return query.Namespace
+	}
+	return ""
+}
+
+func recordModelTokens(ctx context.Context, model string, usage TokenUsage) {
+	if model == "" {
+		model = "unknown"
+	}
+	namespace := metricsNamespace(ctx)
+	modelTokens.WithLabelValues(namespace, model, "prompt").Add(float64(usage.PromptTokens))
+	modelTokens.WithLabelValues(namespace, model, "completion").Add(float64(usage.CompletionTokens))
+}
+
+func recordModelThrottle(ctx context.Context, model string) {
+	if model == "" {
+		model = "unknown"
+	}
+	modelThrottled.WithLabelValues(metricsNamespace(ctx), model).Inc()
+}
+
+func recordToolCall(ctx context.Context, tool, toolType string, start time.Time, err error) {
+	result := toolCallSucceeded
+	if err != nil {
+		result = toolCallFailed
+	}
+	toolCallDuration.WithLabelValues(metricsNamespace(ctx), tool, toolType, result).Observe(time.Since(start).Seconds())
+}
+
+// RecordMCPConnectionFailure counts an MCP client that could not be created for the MCPServer. Servers are
+// labeled by name rather than address, which is empty for stdio servers and may carry credentials.
+func RecordMCPConnectionFailure(namespace, server, transport string) {
+	mcpConnectionFailures.WithLabelValues(namespace, server, transport).Inc()
+}
@@ -0,0 +1,76 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func metricsQueryContext(namespace string) context.Context {
+	query := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: namespace}}
+	return context.WithValue(context.Background(), QueryContextKey, query)
+}
+
+func TestModelTokensMetric(t *testing.T) {
+	ctx := metricsQueryContext("metrics-tokens")
+	collector := NewTokenUsageCollector(&mockRecorder{})
+
+	collector.EmitEvent(ctx, corev1.EventTypeNormal, "LLMCallComplete", OperationEvent{
+		BaseEvent:  BaseEvent{Name: "llm-call", Metadata: map[string]string{"model": "gpt-4o"}},
+		TokenUsage: TokenUsage{PromptTokens: 100, CompletionTokens: 40, TotalTokens: 140},
+	})
+	collector.EmitEvent(ctx, corev1.EventTypeNormal, "LLMCallComplete", OperationEvent{
+		BaseEvent:  BaseEvent{Name: "llm-call", Metadata: map[string]string{"model": "gpt-4o"}},
+		TokenUsage: TokenUsage{PromptTokens: 20, CompletionTokens: 10, TotalTokens: 30},
+	})
+	// Rolled-up usage was already counted by the calls it sums
+	collector.EmitEvent(ctx, corev1.EventTypeNormal, "AgentExecution", OperationEvent{
+		BaseEvent:  BaseEvent{Name: "agent", Metadata: map[string]string{"model": "gpt-4o"}},
+		TokenUsage: TokenUsage{PromptTokens: 120, CompletionTokens: 50, TotalTokens: 170},
+		rollup:     true,
+	})
+
+	assert.Equal(t, 120.0, testutil.ToFloat64(modelTokens.WithLabelValues("metrics-tokens", "gpt-4o", "prompt")))
+	assert.Equal(t, 50.0, testutil.ToFloat64(modelTokens.WithLabelValues("metrics-tokens", "gpt-4o", "completion")))
+}
+
+func TestToolCallDurationMetric(t *testing.T) {
+	ctx := metricsQueryContext("metrics-tools")
+	registry := policyRegistry(&arkv1alpha1.AgentToolPolicy{Deny: []string{"send_email"}}, "noop", "send_email")
+
+	_, err := registry.ExecuteTool(ctx, toolCall("noop"), nil)
+	require.NoError(t, err)
+	_, err = registry.ExecuteTool(ctx, toolCall("send_email"), nil)
+	require.Error(t, err)
+
+	assert.Equal(t, uint64(1), toolCallCount(t, "metrics-tools", "noop", toolCallSucceeded))
+	assert.Equal(t, uint64(1), toolCallCount(t, "metrics-tools", "send_email", toolCallFailed))
+}
+
+func TestMCPConnectionFailuresMetric(t *testing.T) {
+	pool := NewMCPClientPool()
+	defer func() { _ = pool.Close() }()
+
+	_, err := pool.GetOrCreateClient(context.Background(), "weather-mcp", "metrics-mcp", "", []string{"/nonexistent/weather-mcp"}, nil, nil, nil, stdioTransport, time.Second, nil)
+	require.Error(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(mcpConnectionFailures.WithLabelValues("metrics-mcp", "weather-mcp", stdioTransport)))
+}
+
+func toolCallCount(t *testing.T, namespace, tool, result string) uint64 {
+	var metric dto.Metric
+	require.NoError(t, toolCallDuration.WithLabelValues(namespace, tool, "builtin", result).(prometheus.Histogram).Write(&metric))
+	return metric.GetHistogram().GetSampleCount()
+}
@@ -0,0 +1,109 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"sync"
+
+	"github.com/openai/openai-go"
+)
+
+// ModelCall is a model call an agent is about to make. Middleware may rewrite the messages or tools
+// before passing the call on.
+type ModelCall struct {
+	Agent    *Agent
+	Messages []Message
+	Tools    []openai.ChatCompletionToolParam
+}
+
+// ModelCallHandler performs a model call.
+type ModelCallHandler func(ctx context.Context, call *ModelCall) (*openai.ChatCompletion, error)
+
+// ToolCallHandler performs a tool call requested by the model.
+type ToolCallHandler func(ctx context.Context, call ToolCall) (ToolResult, error)
+
+// Middleware hooks into target execution around every model and tool call, so that cross-cutting
+// features such as guardrails, redaction, caching and budget enforcement do not need to be wired into
+// agent and team code. A middleware can inspect or rewrite the call, short-circuit it by returning
+// without calling next, or inspect and rewrite the result.
+type Middleware interface {
+	WrapModelCall(next ModelCallHandler) ModelCallHandler
+	WrapToolCall(next ToolCallHandler) ToolCallHandler
+}
+
+// MiddlewareFuncs adapts before and after hooks to Middleware. Nil hooks are skipped. A before hook
+// that returns an error stops the call; an after hook receives the outcome of the call and may replace
+// its error.
+type MiddlewareFuncs struct {
+	BeforeModelCall func(ctx context.Context, call *ModelCall) error
+	AfterModelCall  func(ctx context.Context, call *ModelCall, response *openai.ChatCompletion, err error) error
+	BeforeToolCall  func(ctx context.Context, call *ToolCall) error
+	AfterToolCall   func(ctx context.Context, call ToolCall, result *ToolResult, err error) error
+}
+
+func (m MiddlewareFuncs) WrapModelCall(next ModelCallHandler) ModelCallHandler {
+	return func(ctx context.Context, call *ModelCall) (*openai.ChatCompletion, error) {
+		if m.BeforeModelCall != nil {
+			if err := m.BeforeModelCall(ctx, call); err != nil {
+				return nil, err
+			}
+		}
+		response, err := next(ctx, call)
+		if m.AfterModelCall != nil {
+			err = m.AfterModelCall(ctx, call, response, err)
+		}
+		return response, err
+	}
+}
+
+func (m MiddlewareFuncs) WrapToolCall(next ToolCallHandler) ToolCallHandler {
+	return func(ctx context.Context, call ToolCall) (ToolResult, error) {
+		if m.BeforeToolCall != nil {
+			if err := m.BeforeToolCall(ctx, &call); err != nil {
+				return ToolResult{ID: call.ID, Name: call.Function.Name, Error: err.Error()}, err
+			}
+		}
+		result, err := next(ctx, call)
+		if m.AfterToolCall != nil {
+			err = m.AfterToolCall(ctx, call, &result, err)
+		}
+		return result, err
+	}
+}
+
+var (
+	middlewareMu sync.RWMutex
+	middlewares  []Middleware
+)
+
+// RegisterMiddleware adds a middleware to every agent created afterwards. Middleware registered first
+// runs outermost. It is intended to be called during controller startup.
+func RegisterMiddleware(m Middleware) {
+	middlewareMu.Lock()
+	defer middlewareMu.Unlock()
+	middlewares = append(middlewares, m)
+}
+
+// RegisteredMiddleware returns the registered middleware in registration order.
+func RegisteredMiddleware() []Middleware {
+	middlewareMu.RLock()
+	defer middlewareMu.RUnlock()
+	return append([]Middleware(nil), middlewares...)
+}
+
+// chainModelCall wraps handler with the middleware so that the first middleware runs outermost
+func chainModelCall(chain []Middleware, handler ModelCallHandler) ModelCallHandler {
+	for i := len(chain) - 1; i >= 0; i-- {
+		handler = chain[i].WrapModelCall(handler)
+	}
+	return handler
+}
+
+// chainToolCall wraps handler with the middleware so that the first middleware runs outermost
+func chainToolCall(chain []Middleware, handler ToolCallHandler) ToolCallHandler {
+	for i := len(chain) - 1; i >= 0; i-- {
+		handler = chain[i].WrapToolCall(handler)
+	}
+	return handler
+}
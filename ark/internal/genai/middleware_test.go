@@ -0,0 +1,109 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tracingMiddleware records the order in which wrapped calls are entered and left
+type tracingMiddleware struct {
+	name  string
+	trace *[]string
+}
+
+func (m tracingMiddleware) WrapModelCall(next ModelCallHandler) ModelCallHandler {
+	return func(ctx context.Context, call *ModelCall) (*openai.ChatCompletion, error) {
+		*m.trace = append(*m.trace, "before "+m.name)
+		response, err := next(ctx, call)
+		*m.trace = append(*m.trace, "after "+m.name)
+		return response, err
+	}
+}
+
+func (m tracingMiddleware) WrapToolCall(next ToolCallHandler) ToolCallHandler {
+	return next
+}
+
+func TestChainModelCallOrder(t *testing.T) {
+	var trace []string
+	chain := []Middleware{tracingMiddleware{"first", &trace}, tracingMiddleware{"second", &trace}}
+
+	handler := chainModelCall(chain, func(ctx context.Context, call *ModelCall) (*openai.ChatCompletion, error) {
+		trace = append(trace, "model")
+		return &openai.ChatCompletion{}, nil
+	})
+	_, err := handler(context.Background(), &ModelCall{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"before first", "before second", "model", "after second", "after first"}, trace)
+}
+
+func TestMiddlewareFuncsRewriteModelCall(t *testing.T) {
+	redact := MiddlewareFuncs{
+		BeforeModelCall: func(ctx context.Context, call *ModelCall) error {
+			call.Messages = []Message{NewUserMessage("[redacted]")}
+			return nil
+		},
+	}
+
+	var seen []Message
+	handler := chainModelCall([]Middleware{redact}, func(ctx context.Context, call *ModelCall) (*openai.ChatCompletion, error) {
+		seen = call.Messages
+		return &openai.ChatCompletion{}, nil
+	})
+	_, err := handler(context.Background(), &ModelCall{Messages: []Message{NewUserMessage("secret")}})
+	require.NoError(t, err)
+
+	require.Len(t, seen, 1)
+	assert.Equal(t, "[redacted]", seen[0].OfUser.Content.OfString.Value)
+}
+
+func TestMiddlewareFuncsBlockToolCall(t *testing.T) {
+	blocked := errors.New("tool not allowed")
+	guardrail := MiddlewareFuncs{
+		BeforeToolCall: func(ctx context.Context, call *ToolCall) error {
+			if call.Function.Name == "delete-everything" {
+				return blocked
+			}
+			return nil
+		},
+	}
+
+	called := false
+	handler := chainToolCall([]Middleware{guardrail}, func(ctx context.Context, call ToolCall) (ToolResult, error) {
+		called = true
+		return ToolResult{ID: call.ID, Name: call.Function.Name, Content: "done"}, nil
+	})
+
+	call := ToolCall{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{Name: "delete-everything"}}
+	result, err := handler(context.Background(), call)
+	assert.ErrorIs(t, err, blocked)
+	assert.False(t, called)
+	assert.Equal(t, "call-1", result.ID)
+	assert.Equal(t, "tool not allowed", result.Error)
+}
+
+func TestMiddlewareFuncsAfterToolCall(t *testing.T) {
+	truncate := MiddlewareFuncs{
+		AfterToolCall: func(ctx context.Context, call ToolCall, result *ToolResult, err error) error {
+			if len(result.Content) > 4 {
+				result.Content = result.Content[:4]
+			}
+			return err
+		},
+	}
+
+	handler := chainToolCall([]Middleware{truncate}, func(ctx context.Context, call ToolCall) (ToolResult, error) {
+		return ToolResult{ID: call.ID, Content: "a long result"}, nil
+	})
+	result, err := handler(context.Background(), ToolCall{ID: "call-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "a lo", result.Content)
+}
@@ -47,6 +47,7 @@ func LoadModel(ctx context.Context, k8sClient client.Client, modelSpec interface
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve model spec: %w", err)
 	}
+	modelName = ResolveModelAlias(ctx, k8sClient, modelName, namespace)
 	modelCRD, err := loadModelCRD(ctx, k8sClient, modelName, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load model CRD %s in namespace %s: %w", modelName, namespace, err)
@@ -62,6 +63,13 @@ func LoadModel(ctx context.Context, k8sClient client.Client, modelSpec interface
 		Model:         model,
 		Type:          modelCRD.Spec.Type,
 		ModelRecorder: modelRecorder,
+		RateLimiter:   modelRateLimiterFor(namespace, modelName, modelCRD.Spec.RateLimit),
+	}
+
+	// A model whose pricing cannot be read still works; its calls are just not counted in the query cost
+	modelInstance.Pricing, err = loadModelPricing(ctx, k8sClient, modelCRD, model, namespace)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "ignoring model pricing", "model", modelName, "namespace", namespace)
 	}
 
 	switch modelCRD.Spec.Type {
@@ -77,6 +85,10 @@ func LoadModel(ctx context.Context, k8sClient client.Client, modelSpec interface
 		if err := loadBedrockConfig(ctx, resolver, modelCRD.Spec.Config.Bedrock, namespace, model, modelInstance); err != nil {
 			return nil, err
 		}
+	case ModelTypeGateway:
+		if err := loadGatewayConfig(ctx, resolver, modelCRD.Spec.Config.Gateway, namespace, modelInstance, additionalHeaders); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("unsupported model type: %s", modelCRD.Spec.Type)
 	}
@@ -0,0 +1,39 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ModelAliasesConfigMapName is the optional per-namespace ConfigMap that maps logical model names, such as
+// default, fast or smart, to the Models of the namespace
+const ModelAliasesConfigMapName = "ark-config-model-aliases"
+
+// ResolveModelAlias returns the name of the Model a model name is an alias of in the namespace, or the name
+// itself when it is not an alias. Aliases take precedence over Models of the same name, so that a tier can be
+// rebound without editing the agents that use it; aliases of aliases are not followed.
+func ResolveModelAlias(ctx context.Context, k8sClient client.Client, name, namespace string) string {
+	if k8sClient == nil || name == "" {
+		return name
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: ModelAliasesConfigMapName, Namespace: namespace}, cm); err != nil {
+		if !errors.IsNotFound(err) {
+			logf.FromContext(ctx).Error(err, "failed to get model aliases ConfigMap, using the model name as is", "namespace", namespace)
+		}
+		return name
+	}
+
+	if model := cm.Data[name]; model != "" {
+		logf.FromContext(ctx).V(1).Info("model alias resolved", "alias", name, "model", model, "namespace", namespace)
+		return model
+	}
+	return name
+}
@@ -0,0 +1,77 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+func TestResolveModelAlias(t *testing.T) {
+	aliases := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ModelAliasesConfigMapName, Namespace: "staging"},
+		Data:       map[string]string{"default": "gpt-4o", "fast": "gpt-4o-mini", "cheap": "fast"},
+	}
+	k8sClient := fake.NewClientBuilder().WithObjects(aliases).Build()
+
+	tests := []struct {
+		name      string
+		model     string
+		namespace string
+		expected  string
+	}{
+		{name: "alias", model: "fast", namespace: "staging", expected: "gpt-4o-mini"},
+		{name: "alias of the default model", model: "default", namespace: "staging", expected: "gpt-4o"},
+		{name: "aliases of aliases are not followed", model: "cheap", namespace: "staging", expected: "fast"},
+		{name: "not an alias", model: "claude-sonnet", namespace: "staging", expected: "claude-sonnet"},
+		{name: "no configmap", model: "fast", namespace: "default", expected: "fast"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ResolveModelAlias(context.Background(), k8sClient, tt.model, tt.namespace))
+		})
+	}
+
+	assert.Equal(t, "fast", ResolveModelAlias(context.Background(), nil, "fast", "staging"))
+}
+
+func TestLoadModelResolvesAlias(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = arkv1alpha1.AddToScheme(scheme)
+
+	model := &arkv1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpt-4o-mini", Namespace: "staging"},
+		Spec: arkv1alpha1.ModelSpec{
+			Type:  ModelTypeOpenAI,
+			Model: arkv1alpha1.ValueSource{Value: "gpt-4o-mini-2024-07-18"},
+			Config: arkv1alpha1.ModelConfig{OpenAI: &arkv1alpha1.OpenAIModelConfig{
+				BaseURL: arkv1alpha1.ValueSource{Value: "https://api.openai.com/v1"},
+				APIKey:  arkv1alpha1.ValueSource{Value: "sk-test"},
+			}},
+		},
+	}
+	aliases := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ModelAliasesConfigMapName, Namespace: "staging"},
+		Data:       map[string]string{"fast": "gpt-4o-mini"},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(model, aliases).Build()
+
+	loaded, err := LoadModel(context.Background(), k8sClient, &arkv1alpha1.AgentModelRef{Name: "fast"}, "staging", nil, noop.NewModelRecorder())
+	require.NoError(t, err)
+	assert.Equal(t, "gpt-4o-mini-2024-07-18", loaded.Model)
+
+	_, err = LoadModel(context.Background(), k8sClient, &arkv1alpha1.AgentModelRef{Name: "smart"}, "staging", nil, noop.NewModelRecorder())
+	assert.ErrorContains(t, err, "smart")
+}
@@ -0,0 +1,73 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
+)
+
+// defaultGatewayCostHeader is the header LiteLLM reports the cost of a call in
+const defaultGatewayCostHeader = "x-litellm-response-cost"
+
+func loadGatewayConfig(ctx context.Context, resolver *common.ValueSourceResolver, config *arkv1alpha1.GatewayModelConfig, namespace string, model *Model, additionalHeaders map[string]string) error {
+	if config == nil {
+		return fmt.Errorf("gateway configuration is required for gateway model type")
+	}
+
+	baseURL, err := resolver.ResolveValueSource(ctx, config.BaseURL, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to resolve gateway baseURL: %w", err)
+	}
+
+	var apiKey string
+	if config.APIKey != nil {
+		apiKey, err = resolver.ResolveValueSource(ctx, *config.APIKey, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to resolve gateway apiKey: %w", err)
+		}
+	}
+
+	headers, err := resolveModelHeaders(ctx, resolver.Client, config.Headers, namespace)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range additionalHeaders {
+		headers[k] = v
+	}
+
+	var properties map[string]string
+	if config.Properties != nil {
+		properties = make(map[string]string)
+		for key, valueSource := range config.Properties {
+			value, err := resolver.ResolveValueSource(ctx, valueSource, namespace)
+			if err != nil {
+				return fmt.Errorf("failed to resolve gateway property %s: %w", key, err)
+			}
+			properties[key] = value
+		}
+	}
+
+	usageHeaders := arkv1alpha1.GatewayUsageHeaders{Cost: defaultGatewayCostHeader}
+	if config.UsageHeaders != nil {
+		usageHeaders = *config.UsageHeaders
+	}
+
+	model.Provider = &GatewayProvider{
+		OpenAIProvider: OpenAIProvider{
+			Model:      model.Model,
+			BaseURL:    baseURL,
+			APIKey:     apiKey,
+			Headers:    headers,
+			Properties: properties,
+		},
+		UsageHeaders: usageHeaders,
+	}
+	model.Properties = properties
+
+	return nil
+}
@@ -3,9 +3,12 @@ package genai
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/openai/openai-go"
 	"k8s.io/apimachinery/pkg/runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
 	"mckinsey.com/ark/internal/telemetry"
 )
 
@@ -19,6 +22,11 @@ type ConfigProvider interface {
 	BuildConfig() map[string]any
 }
 
+// EmbeddingProvider is implemented by providers that can embed text
+type EmbeddingProvider interface {
+	Embeddings(ctx context.Context, inputs []string) ([][]float64, error)
+}
+
 type Model struct {
 	Model         string
 	Type          string
@@ -27,6 +35,10 @@ type Model struct {
 	OutputSchema  *runtime.RawExtension
 	SchemaName    string
 	ModelRecorder telemetry.ModelRecorder
+	// RateLimiter is shared by all users of the model; nil when the model has no rate limit
+	RateLimiter *ModelRateLimiter
+	// Pricing estimates the cost of the model's calls; nil when the model has no price
+	Pricing *ModelPricing
 }
 
 func (m *Model) ChatCompletion(ctx context.Context, messages []Message, eventStream EventStreamInterface, n int64, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
@@ -49,18 +61,23 @@ func (m *Model) ChatCompletion(ctx context.Context, messages []Message, eventStr
 		m.Provider.SetOutputSchema(m.OutputSchema, m.SchemaName)
 	}
 
-	var response *openai.ChatCompletion
-	var err error
+	if err := costTrackerFrom(ctx).checkBudget(); err != nil {
+		m.ModelRecorder.RecordError(span, err)
+		return nil, err
+	}
 
-	if eventStream != nil {
-		response, err = m.Provider.ChatCompletionStream(ctx, messages, n, func(chunk *openai.ChatCompletionChunk) error {
-			chunkWithMeta := WrapChunkWithMetadata(ctx, chunk, m.Model)
-			return eventStream.StreamChunk(ctx, chunkWithMeta)
-		}, tools...)
-	} else {
-		response, err = m.Provider.ChatCompletion(ctx, messages, n, tools...)
+	waited, err := m.RateLimiter.Wait(ctx)
+	if waited > 0 {
+		m.ModelRecorder.RecordRateLimitWait(span, waited)
+		logf.FromContext(ctx).V(LogLevelDebug).Info("model call waited for rate limit", "model", m.Model, "waited", waited.String())
+	}
+	if err != nil {
+		m.ModelRecorder.RecordError(span, err)
+		return nil, err
 	}
 
+	callCtx, cost := withReportedCost(ctx)
+	response, err := m.callProviderWithThrottleRetry(callCtx, span, messages, eventStream, n, tools...)
 	if err != nil {
 		m.ModelRecorder.RecordError(span, err)
 		return nil, err
@@ -77,7 +94,89 @@ func (m *Model) ChatCompletion(ctx context.Context, messages []Message, eventStr
 	}
 
 	m.ModelRecorder.RecordTokenUsage(span, response.Usage.PromptTokens, response.Usage.CompletionTokens, response.Usage.TotalTokens)
+	m.RateLimiter.RecordTokens(response.Usage.TotalTokens)
+	if cost.reported {
+		costTrackerFrom(ctx).addReported(cost.amount)
+	} else {
+		costTrackerFrom(ctx).add(m.Pricing, response.Usage.PromptTokens, response.Usage.CompletionTokens)
+	}
 	m.ModelRecorder.RecordSuccess(span)
 
 	return response, nil
 }
+
+// Embeddings embeds the inputs with the model, returning one vector per input
+func (m *Model) Embeddings(ctx context.Context, inputs []string) ([][]float64, error) {
+	provider, ok := m.Provider.(EmbeddingProvider)
+	if !ok {
+		return nil, fmt.Errorf("model type %s does not support embeddings", m.Type)
+	}
+	if _, err := m.RateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return provider.Embeddings(ctx, inputs)
+}
+
+// callProviderWithThrottleRetry calls the provider, waiting and retrying when it rate limits the call with a
+// Retry-After hint. Throttles are counted in the ark_model_throttled_total metric, and recorded on the span
+// rather than as errors unless retries are exhausted.
+// Throttles are returned as is when the caller retries them, see WithoutThrottleRetry.
+func (m *Model) callProviderWithThrottleRetry(ctx context.Context, span telemetry.Span, messages []Message, eventStream EventStreamInterface, n int64, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	for attempt := 0; ; attempt++ {
+		response, err := m.callProvider(ctx, span, messages, eventStream, n, tools...)
+
+		retryAfter, throttled := RetryAfter(err)
+		if throttled {
+			recordModelThrottle(ctx, m.Model)
+		}
+		if !throttled || throttleRetryDisabled(ctx) || retryAfter > maxRetryAfter || attempt >= maxThrottleRetries {
+			return response, err
+		}
+		// Fail fast with the throttle error rather than a timeout if the wait would outlast the caller's deadline
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(retryAfter).After(deadline) {
+			return response, err
+		}
+
+		m.ModelRecorder.RecordThrottle(span, retryAfter)
+		logf.FromContext(ctx).V(LogLevelDebug).Info("model call rate limited, retrying", "model", m.Model, "retryAfter", retryAfter.String(), "attempt", attempt+1)
+
+		timer := time.NewTimer(retryAfter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (m *Model) callProvider(ctx context.Context, span telemetry.Span, messages []Message, eventStream EventStreamInterface, n int64, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	if eventStream != nil {
+		var chunkCount, tokenCount int64
+		toolCalls := newToolCallDeltaTracker()
+		return m.Provider.ChatCompletionStream(ctx, messages, n, func(chunk *openai.ChatCompletionChunk) error {
+			chunkCount++
+			tokenCount = cumulativeStreamTokens(chunk, tokenCount)
+			m.ModelRecorder.RecordStreamChunk(span, chunkCount, tokenCount)
+
+			metadata := buildMetadata(ctx, m.Model)
+			metadata.ToolCalls = toolCalls.track(chunk)
+			return eventStream.StreamChunk(ctx, ChunkWithMetadata{ChatCompletionChunk: chunk, Ark: metadata})
+		}, tools...)
+	}
+	return m.Provider.ChatCompletion(ctx, messages, n, tools...)
+}
+
+// cumulativeStreamTokens estimates the completion tokens streamed so far. Providers usually report usage
+// only on the final chunk, so until then each chunk carrying content or tool call deltas counts as one token.
+func cumulativeStreamTokens(chunk *openai.ChatCompletionChunk, previous int64) int64 {
+	if chunk.Usage.CompletionTokens > 0 {
+		return chunk.Usage.CompletionTokens
+	}
+	for _, choice := range chunk.Choices {
+		if choice.Delta.Content != "" || len(choice.Delta.ToolCalls) > 0 {
+			return previous + 1
+		}
+	}
+	return previous
+}
@@ -32,6 +32,14 @@ func ProbeModel(ctx context.Context, model *Model) ProbeResult {
 
 	// Try to get a completion (streaming disabled for probe)
 	_, err := model.ChatCompletion(probeCtx, testMessages, nil, 1)
+	if ReasonFor(err) == ReasonProviderRateLimited {
+		// A throttled model is reachable and configured correctly, so it is not reported as unavailable
+		return ProbeResult{
+			Available:     true,
+			Message:       "Model is available (rate limited)",
+			DetailedError: err,
+		}
+	}
 	if err != nil {
 		return ProbeResult{
 			Available:     false,
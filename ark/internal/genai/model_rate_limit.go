@@ -0,0 +1,158 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const rateLimitWindow = time.Minute
+
+// modelRateLimiters holds one limiter per model so that all agents and queries sharing a model share its limit
+var modelRateLimiters sync.Map
+
+// ModelRateLimiter enforces a model's requests and tokens per minute over a sliding one minute window.
+// Token usage is only known once a call completes, so calls are admitted while the tokens used in the
+// window are below the limit.
+type ModelRateLimiter struct {
+	mu                sync.Mutex
+	requestsPerMinute int
+	tokensPerMinute   int64
+	requests          []time.Time
+	tokens            []tokenUsage
+	now               func() time.Time
+}
+
+type tokenUsage struct {
+	at     time.Time
+	tokens int64
+}
+
+func newModelRateLimiter(limit arkv1alpha1.ModelRateLimit) *ModelRateLimiter {
+	limiter := &ModelRateLimiter{now: time.Now}
+	limiter.setLimit(limit)
+	return limiter
+}
+
+// modelRateLimiterFor returns the shared limiter of the model, updating its limits if the model changed
+func modelRateLimiterFor(namespace, name string, limit *arkv1alpha1.ModelRateLimit) *ModelRateLimiter {
+	if limit == nil {
+		SyncModelRateLimiter(namespace, name, nil)
+		return nil
+	}
+	value, loaded := modelRateLimiters.LoadOrStore(namespace+"/"+name, newModelRateLimiter(*limit))
+	limiter := value.(*ModelRateLimiter)
+	if loaded {
+		limiter.setLimit(*limit)
+	}
+	return limiter
+}
+
+// SyncModelRateLimiter applies a model's rate limit to its shared limiter, removing the limiter when the
+// model has no limit or was deleted (limit is nil), so a recreated model starts with an empty window
+func SyncModelRateLimiter(namespace, name string, limit *arkv1alpha1.ModelRateLimit) {
+	key := namespace + "/" + name
+	if limit == nil {
+		modelRateLimiters.Delete(key)
+		return
+	}
+	if value, ok := modelRateLimiters.Load(key); ok {
+		value.(*ModelRateLimiter).setLimit(*limit)
+	}
+}
+
+func (l *ModelRateLimiter) setLimit(limit arkv1alpha1.ModelRateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.requestsPerMinute = int(limit.RequestsPerMinute)
+	l.tokensPerMinute = limit.TokensPerMinute
+}
+
+// Wait blocks until the model has capacity for another call or ctx is done, and returns how long it waited
+func (l *ModelRateLimiter) Wait(ctx context.Context) (time.Duration, error) {
+	if l == nil {
+		return 0, nil
+	}
+
+	start := l.now()
+	waited := false
+	for {
+		delay := l.reserve()
+		if delay <= 0 {
+			if !waited {
+				return 0, nil
+			}
+			return l.now().Sub(start), nil
+		}
+		waited = true
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return l.now().Sub(start), ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve admits a call if the window has capacity, or returns how long to wait before trying again
+func (l *ModelRateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now)
+
+	var delay time.Duration
+	if l.requestsPerMinute > 0 && len(l.requests) >= l.requestsPerMinute {
+		delay = l.requests[len(l.requests)-l.requestsPerMinute].Add(rateLimitWindow).Sub(now)
+	}
+	if l.tokensPerMinute > 0 {
+		used := int64(0)
+		for _, usage := range l.tokens {
+			used += usage.tokens
+		}
+		// Wait until enough of the usage has left the window to bring it below the limit
+		for i := 0; used >= l.tokensPerMinute && i < len(l.tokens); i++ {
+			used -= l.tokens[i].tokens
+			delay = max(delay, l.tokens[i].at.Add(rateLimitWindow).Sub(now))
+		}
+	}
+	if delay > 0 {
+		return delay
+	}
+
+	l.requests = append(l.requests, now)
+	return 0
+}
+
+// RecordTokens records the tokens used by a completed call
+func (l *ModelRateLimiter) RecordTokens(tokens int64) {
+	if l == nil || tokens <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = append(l.tokens, tokenUsage{at: l.now(), tokens: tokens})
+}
+
+// prune drops calls and token usage that have left the window
+func (l *ModelRateLimiter) prune(now time.Time) {
+	cutoff := now.Add(-rateLimitWindow)
+	i := 0
+	for i < len(l.requests) && !l.requests[i].After(cutoff) {
+		i++
+	}
+	l.requests = l.requests[i:]
+
+	i = 0
+	for i < len(l.tokens) && !l.tokens[i].at.After(cutoff) {
+		i++
+	}
+	l.tokens = l.tokens[i:]
+}
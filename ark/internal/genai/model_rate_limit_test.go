@@ -0,0 +1,89 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func TestModelRateLimiterRequests(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newModelRateLimiter(arkv1alpha1.ModelRateLimit{RequestsPerMinute: 2})
+	limiter.now = func() time.Time { return now }
+
+	assert.Zero(t, limiter.reserve())
+	now = now.Add(10 * time.Second)
+	assert.Zero(t, limiter.reserve())
+	assert.Equal(t, 50*time.Second, limiter.reserve(), "the third call waits for the first to leave the window")
+
+	now = now.Add(50 * time.Second)
+	assert.Zero(t, limiter.reserve())
+}
+
+func TestModelRateLimiterTokens(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newModelRateLimiter(arkv1alpha1.ModelRateLimit{TokensPerMinute: 1000})
+	limiter.now = func() time.Time { return now }
+
+	assert.Zero(t, limiter.reserve())
+	limiter.RecordTokens(600)
+	now = now.Add(20 * time.Second)
+	assert.Zero(t, limiter.reserve(), "calls are admitted while usage is below the limit")
+	limiter.RecordTokens(600)
+
+	now = now.Add(10 * time.Second)
+	assert.Equal(t, 30*time.Second, limiter.reserve())
+	now = now.Add(30 * time.Second)
+	assert.Zero(t, limiter.reserve())
+}
+
+func TestModelRateLimiterWait(t *testing.T) {
+	limiter := newModelRateLimiter(arkv1alpha1.ModelRateLimit{RequestsPerMinute: 1})
+	waited, err := limiter.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, waited)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	waited, err = limiter.Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Positive(t, waited)
+
+	var unlimited *ModelRateLimiter
+	_, err = unlimited.Wait(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestModelRateLimiterIsShared(t *testing.T) {
+	limit := &arkv1alpha1.ModelRateLimit{RequestsPerMinute: 5}
+	first := modelRateLimiterFor("default", "shared-model", limit)
+	second := modelRateLimiterFor("default", "shared-model", &arkv1alpha1.ModelRateLimit{RequestsPerMinute: 10})
+	assert.Same(t, first, second)
+	assert.Equal(t, 10, first.requestsPerMinute)
+
+	assert.Nil(t, modelRateLimiterFor("default", "shared-model", nil))
+	assert.NotSame(t, first, modelRateLimiterFor("default", "shared-model", limit))
+}
+
+func TestSyncModelRateLimiter(t *testing.T) {
+	limiter := modelRateLimiterFor("default", "synced-model", &arkv1alpha1.ModelRateLimit{RequestsPerMinute: 5})
+
+	SyncModelRateLimiter("default", "synced-model", &arkv1alpha1.ModelRateLimit{RequestsPerMinute: 20})
+	assert.Equal(t, 20, limiter.requestsPerMinute)
+
+	SyncModelRateLimiter("default", "synced-model", nil)
+	_, ok := modelRateLimiters.Load("default/synced-model")
+	assert.False(t, ok)
+
+	// Models without a limiter in use are not given one
+	SyncModelRateLimiter("default", "unused-model", &arkv1alpha1.ModelRateLimit{RequestsPerMinute: 5})
+	_, ok = modelRateLimiters.Load("default/unused-model")
+	assert.False(t, ok)
+}
@@ -0,0 +1,285 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// OCI media types of the manifest of an artifact and of the layer holding a WebAssembly module
+const (
+	ociManifestMediaType   = "application/vnd.oci.image.manifest.v1+json"
+	ociWasmLayerMediaType  = "application/vnd.wasm.content.layer.v1+wasm"
+	wasmModuleMediaType    = "application/wasm"
+	maxOCIManifestBytes    = 4 << 20
+	maxWasmModuleBytes     = 64 << 20
+	registryAuthHeaderName = "WWW-Authenticate"
+)
+
+// ociClient pulls from registries. It is replaced in tests to trust their registry.
+var ociClient = http.DefaultClient
+
+// ociReference is an OCI artifact pinned by the digest of its manifest
+type ociReference struct {
+	Registry   string
+	Repository string
+	Digest     string
+}
+
+// parseOCIReference parses a reference of the form registry/repository@sha256:<digest>
+func parseOCIReference(image string) (ociReference, error) {
+	name, digest, found := strings.Cut(image, "@")
+	if !found {
+		return ociReference{}, fmt.Errorf("image %s must be pinned by digest, e.g. %s@sha256:<digest>", image, image)
+	}
+	hexDigest, ok := strings.CutPrefix(digest, "sha256:")
+	if decoded, err := hex.DecodeString(hexDigest); !ok || err != nil || len(decoded) != sha256.Size {
+		return ociReference{}, fmt.Errorf("image %s has an invalid digest, expected sha256:<64 hex characters>", image)
+	}
+	registry, repository, found := strings.Cut(name, "/")
+	if !found || registry == "" || repository == "" {
+		return ociReference{}, fmt.Errorf("image %s must name its registry and repository", image)
+	}
+	// A tag next to the digest only documents the version, the digest is pulled
+	repository, _, _ = strings.Cut(repository, ":")
+	return ociReference{Registry: registry, Repository: repository, Digest: digest}, nil
+}
+
+// registryCredentials are the credentials of a registry from a kubernetes.io/dockerconfigjson Secret
+type registryCredentials struct {
+	Username string
+	Password string
+}
+
+// parseDockerConfig returns the credentials of the registry in the .dockerconfigjson of a Secret, or nil when it
+// has none for the registry
+func parseDockerConfig(data []byte, registry string) (*registryCredentials, error) {
+	var config struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid .dockerconfigjson: %w", err)
+	}
+	for server, auth := range config.Auths {
+		host := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+		if strings.TrimSuffix(host, "/") != registry && !strings.HasPrefix(host, registry+"/") {
+			continue
+		}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("invalid auth of registry %s: %w", server, err)
+			}
+			username, password, _ := strings.Cut(string(decoded), ":")
+			return &registryCredentials{Username: username, Password: password}, nil
+		}
+		return &registryCredentials{Username: auth.Username, Password: auth.Password}, nil
+	}
+	return nil, nil
+}
+
+// ociPuller pulls the content of a repository, with the token of the registry once it challenged for one
+type ociPuller struct {
+	ref         ociReference
+	credentials *registryCredentials
+	token       string
+}
+
+// pullWasmModule returns the path of the WebAssembly module of the artifact in the cache directory, pulling it
+// when it is not cached. Modules are cached by the digest of their manifest, and verified against it, so a cached
+// module is the one the reference pins.
+func pullWasmModule(ctx context.Context, ref ociReference, credentials *registryCredentials, cacheDir string) (string, error) {
+	path := filepath.Join(cacheDir, strings.TrimPrefix(ref.Digest, "sha256:")+".wasm")
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	puller := &ociPuller{ref: ref, credentials: credentials}
+	manifestData, err := puller.get(ctx, "manifests/"+ref.Digest, ociManifestMediaType, maxOCIManifestBytes)
+	if err != nil {
+		return "", err
+	}
+	if err := verifyDigest(manifestData, ref.Digest); err != nil {
+		return "", fmt.Errorf("manifest of %s: %w", ref.Repository, err)
+	}
+	var manifest struct {
+		Layers []struct {
+			MediaType string `json:"mediaType"`
+			Digest    string `json:"digest"`
+		} `json:"layers"`
+	}
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return "", fmt.Errorf("invalid manifest of %s: %w", ref.Repository, err)
+	}
+
+	layer := ""
+	for _, candidate := range manifest.Layers {
+		if candidate.MediaType == ociWasmLayerMediaType || candidate.MediaType == wasmModuleMediaType || len(manifest.Layers) == 1 {
+			layer = candidate.Digest
+			break
+		}
+	}
+	if layer == "" {
+		return "", fmt.Errorf("artifact %s@%s has no WebAssembly layer", ref.Repository, ref.Digest)
+	}
+	module, err := puller.get(ctx, "blobs/"+layer, "", maxWasmModuleBytes)
+	if err != nil {
+		return "", err
+	}
+	if err := verifyDigest(module, layer); err != nil {
+		return "", fmt.Errorf("module of %s: %w", ref.Repository, err)
+	}
+
+	// Written next to its final path and renamed, so concurrent pulls never run a partial module
+	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create module cache: %w", err)
+	}
+	file, err := os.CreateTemp(cacheDir, "pull-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to cache module: %w", err)
+	}
+	defer func() { _ = os.Remove(file.Name()) }()
+	if _, err := file.Write(module); err != nil {
+		_ = file.Close()
+		return "", fmt.Errorf("failed to cache module: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to cache module: %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return "", fmt.Errorf("failed to cache module: %w", err)
+	}
+	return path, nil
+}
+
+func verifyDigest(data []byte, digest string) error {
+	sum := sha256.Sum256(data)
+	if actual := "sha256:" + hex.EncodeToString(sum[:]); actual != digest {
+		return fmt.Errorf("digest %s does not match %s", actual, digest)
+	}
+	return nil
+}
+
+// get reads a path of the repository, authenticating as the registry challenges the first request
+func (p *ociPuller) get(ctx context.Context, path, accept string, maxBytes int64) ([]byte, error) {
+	endpoint := fmt.Sprintf("https://%s/v2/%s/%s", p.ref.Registry, p.ref.Repository, path)
+	resp, err := p.do(ctx, endpoint, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && p.token == "" {
+		challenge := resp.Header.Get(registryAuthHeaderName)
+		_ = resp.Body.Close()
+		if err := p.authenticate(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = p.do(ctx, endpoint, accept); err != nil {
+			return nil, err
+		}
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to pull %s from %s: %s", path, p.ref.Registry, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull %s from %s: %w", path, p.ref.Registry, err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("%s of %s exceeds %d bytes", path, p.ref.Repository, maxBytes)
+	}
+	return data, nil
+}
+
+func (p *ociPuller) do(ctx context.Context, endpoint, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	switch {
+	case p.token != "":
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	case p.credentials != nil:
+		req.SetBasicAuth(p.credentials.Username, p.credentials.Password)
+	}
+	resp, err := ociClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach registry %s: %w", p.ref.Registry, err)
+	}
+	return resp, nil
+}
+
+// authenticate gets a pull token from the token service of a Bearer challenge, with the credentials when there are
+// any and anonymously otherwise
+func (p *ociPuller) authenticate(ctx context.Context, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return fmt.Errorf("registry %s requires credentials, set imagePullSecret", p.ref.Registry)
+	}
+	realm, query := "", url.Values{}
+	for _, param := range strings.Split(params, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		value = strings.Trim(value, `"`)
+		switch key {
+		case "realm":
+			realm = value
+		case "service", "scope":
+			query.Set(key, value)
+		}
+	}
+	if realm == "" {
+		return fmt.Errorf("registry %s sent a challenge without a realm", p.ref.Registry)
+	}
+	if query.Get("scope") == "" {
+		query.Set("scope", fmt.Sprintf("repository:%s:pull", p.ref.Repository))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if p.credentials != nil {
+		req.SetBasicAuth(p.credentials.Username, p.credentials.Password)
+	}
+	resp, err := ociClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to authenticate to registry %s: %w", p.ref.Registry, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to authenticate to registry %s: %s", p.ref.Registry, resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOCIManifestBytes)).Decode(&token); err != nil {
+		return fmt.Errorf("invalid token from registry %s: %w", p.ref.Registry, err)
+	}
+	p.token = token.Token
+	if p.token == "" {
+		p.token = token.AccessToken
+	}
+	if p.token == "" {
+		return fmt.Errorf("registry %s issued no token", p.ref.Registry)
+	}
+	return nil
+}
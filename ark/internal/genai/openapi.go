@@ -0,0 +1,269 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// maxOpenAPIRefDepth bounds the resolution of nested $ref, so that recursive schemas terminate
+const maxOpenAPIRefDepth = 10
+
+// openAPIMethods are the operation methods that http tools support
+var openAPIMethods = []string{"get", "post", "put", "patch", "delete"}
+
+// OpenAPIDocument is the part of an OpenAPI 3 document that is imported as tools
+type OpenAPIDocument struct {
+	// ServerURL is the URL of the first server of the document
+	ServerURL  string
+	Operations []OpenAPIOperation
+}
+
+// OpenAPIOperation is an operation of a REST service, with the input schema of the tool that invokes it
+type OpenAPIOperation struct {
+	ID          string
+	Method      string
+	Path        string
+	Description string
+	InputSchema map[string]any
+	// QueryParameters and HeaderParameters are the names of the arguments sent in the query and in headers
+	QueryParameters  []string
+	HeaderParameters []string
+	// HasBody is true when the operation takes a JSON request body, which is the "body" argument
+	HasBody bool
+}
+
+// ParseOpenAPI reads the operations of an OpenAPI 3 document in JSON or YAML. Operations are ordered by
+// path and method, and local $ref are resolved.
+func ParseOpenAPI(data []byte) (*OpenAPIDocument, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	var root map[string]any
+	if err := json.Unmarshal(jsonData, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	if version, _ := root["openapi"].(string); !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q: only OpenAPI 3 documents are supported", version)
+	}
+
+	doc := &OpenAPIDocument{}
+	if servers, ok := root["servers"].([]any); ok && len(servers) > 0 {
+		if server, ok := servers[0].(map[string]any); ok {
+			doc.ServerURL, _ = server["url"].(string)
+		}
+	}
+
+	paths, _ := root["paths"].(map[string]any)
+	pathNames := make([]string, 0, len(paths))
+	for path := range paths {
+		pathNames = append(pathNames, path)
+	}
+	sort.Strings(pathNames)
+
+	for _, path := range pathNames {
+		item, _ := resolveOpenAPIRefs(root, paths[path], 0).(map[string]any)
+		pathParameters, _ := item["parameters"].([]any)
+		for _, method := range openAPIMethods {
+			operation, ok := item[method].(map[string]any)
+			if !ok {
+				continue
+			}
+			op, err := parseOpenAPIOperation(root, method, path, operation, pathParameters)
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		}
+	}
+	return doc, nil
+}
+
+func parseOpenAPIOperation(root map[string]any, method, path string, operation map[string]any, pathParameters []any) (OpenAPIOperation, error) {
+	op := OpenAPIOperation{Method: strings.ToUpper(method), Path: path}
+	op.ID, _ = operation["operationId"].(string)
+	if op.ID == "" {
+		op.ID = method + strings.NewReplacer("/", "-", "{", "", "}", "").Replace(path)
+	}
+	op.Description, _ = operation["description"].(string)
+	if op.Description == "" {
+		op.Description, _ = operation["summary"].(string)
+	}
+
+	properties := map[string]any{}
+	var required []string
+
+	// Operation parameters override the path parameters of the same name and location
+	operationParameters, _ := operation["parameters"].([]any)
+	parameters := map[string]map[string]any{}
+	var order []string
+	for _, raw := range append(append([]any{}, pathParameters...), operationParameters...) {
+		parameter, ok := resolveOpenAPIRefs(root, raw, 0).(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := parameter["name"].(string)
+		in, _ := parameter["in"].(string)
+		key := in + "/" + name
+		if _, seen := parameters[key]; !seen {
+			order = append(order, key)
+		}
+		parameters[key] = parameter
+	}
+
+	for _, key := range order {
+		parameter := parameters[key]
+		name, _ := parameter["name"].(string)
+		in, _ := parameter["in"].(string)
+		switch in {
+		case "path":
+		case "query":
+			op.QueryParameters = append(op.QueryParameters, name)
+		case "header":
+			op.HeaderParameters = append(op.HeaderParameters, name)
+		default:
+			continue
+		}
+		if _, exists := properties[name]; exists {
+			return op, fmt.Errorf("operation %s has several parameters named %s", op.ID, name)
+		}
+
+		schema, _ := parameter["schema"].(map[string]any)
+		property := map[string]any{"type": "string"}
+		if schema != nil {
+			property = copyOpenAPISchema(schema)
+		}
+		if description, _ := parameter["description"].(string); description != "" {
+			property["description"] = description
+		}
+		properties[name] = property
+		if isRequired, _ := parameter["required"].(bool); isRequired || in == "path" {
+			required = append(required, name)
+		}
+	}
+
+	if body, ok := resolveOpenAPIRefs(root, operation["requestBody"], 0).(map[string]any); ok {
+		content, _ := body["content"].(map[string]any)
+		if media, ok := content["application/json"].(map[string]any); ok {
+			if _, exists := properties["body"]; exists {
+				return op, fmt.Errorf("operation %s has a parameter named body and a request body", op.ID)
+			}
+			schema, _ := media["schema"].(map[string]any)
+			property := map[string]any{"type": "object"}
+			if schema != nil {
+				property = copyOpenAPISchema(schema)
+			}
+			if description, _ := body["description"].(string); description != "" {
+				property["description"] = description
+			}
+			properties["body"] = property
+			op.HasBody = true
+			if isRequired, _ := body["required"].(bool); isRequired {
+				required = append(required, "body")
+			}
+		}
+	}
+
+	op.InputSchema = map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		op.InputSchema["required"] = required
+	}
+	return op, nil
+}
+
+// HTTPSpec returns the configuration of the http tool that invokes the operation. Path and query parameters
+// are placeholders in the URL, header parameters and the body are templates over the tool arguments.
+func (op OpenAPIOperation) HTTPSpec(baseURL string, headers []arkv1alpha1.Header, timeout string) arkv1alpha1.HTTPSpec {
+	target := strings.TrimSuffix(baseURL, "/") + op.Path
+	if len(op.QueryParameters) > 0 {
+		query := make([]string, 0, len(op.QueryParameters))
+		for _, name := range op.QueryParameters {
+			query = append(query, fmt.Sprintf("%s={%s}", url.QueryEscape(name), name))
+		}
+		target += "?" + strings.Join(query, "&")
+	}
+
+	spec := arkv1alpha1.HTTPSpec{
+		URL:     target,
+		Method:  op.Method,
+		Headers: append([]arkv1alpha1.Header{}, headers...),
+		Timeout: timeout,
+	}
+	for _, name := range op.HeaderParameters {
+		spec.Headers = append(spec.Headers, arkv1alpha1.Header{
+			Name:  name,
+			Value: arkv1alpha1.HeaderValue{Value: fmt.Sprintf(`{{ index .input %q | default "" }}`, name)},
+		})
+	}
+	if op.HasBody {
+		spec.Body = "{{ toJson .input.body }}"
+	}
+	return spec
+}
+
+// resolveOpenAPIRefs replaces the local $ref in the node with the parts of the document they point to
+func resolveOpenAPIRefs(root map[string]any, node any, depth int) any {
+	switch value := node.(type) {
+	case map[string]any:
+		if ref, ok := value["$ref"].(string); ok {
+			if depth >= maxOpenAPIRefDepth {
+				return map[string]any{"type": "object"}
+			}
+			target, ok := lookupOpenAPIRef(root, ref)
+			if !ok {
+				return map[string]any{}
+			}
+			return resolveOpenAPIRefs(root, target, depth+1)
+		}
+		resolved := make(map[string]any, len(value))
+		for key, child := range value {
+			resolved[key] = resolveOpenAPIRefs(root, child, depth)
+		}
+		return resolved
+	case []any:
+		resolved := make([]any, len(value))
+		for i, child := range value {
+			resolved[i] = resolveOpenAPIRefs(root, child, depth)
+		}
+		return resolved
+	default:
+		return node
+	}
+}
+
+// lookupOpenAPIRef finds the target of a local JSON pointer such as #/components/schemas/Pet
+func lookupOpenAPIRef(root map[string]any, ref string) (any, bool) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, false
+	}
+	var node any = root
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		object, ok := node.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if node, ok = object[token]; !ok {
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+// copyOpenAPISchema copies a schema so that descriptions can be added to it without changing the document
+func copyOpenAPISchema(schema map[string]any) map[string]any {
+	copied := make(map[string]any, len(schema))
+	for key, value := range schema {
+		copied[key] = value
+	}
+	return copied
+}
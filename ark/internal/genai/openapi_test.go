@@ -0,0 +1,202 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/labels"
+)
+
+const petstoreDocument = `
+openapi: 3.0.3
+servers:
+  - url: https://petstore.example.com/v1
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List the pets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: X-Tenant
+          in: header
+          required: true
+          schema:
+            type: string
+    post:
+      operationId: createPet
+      description: Add a pet to the store
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+  /pets/{petId}:
+    parameters:
+      - $ref: '#/components/parameters/PetId'
+    get:
+      summary: Get a pet
+    options:
+      summary: Not supported by http tools
+components:
+  parameters:
+    PetId:
+      name: petId
+      in: path
+      description: ID of the pet
+      schema:
+        type: string
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      type: object
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+`
+
+func TestParseOpenAPI(t *testing.T) {
+	doc, err := ParseOpenAPI([]byte(petstoreDocument))
+	require.NoError(t, err)
+	assert.Equal(t, "https://petstore.example.com/v1", doc.ServerURL)
+	require.Len(t, doc.Operations, 3)
+
+	list := doc.Operations[0]
+	assert.Equal(t, "listPets", list.ID)
+	assert.Equal(t, "GET", list.Method)
+	assert.Equal(t, "List the pets", list.Description)
+	assert.Equal(t, []string{"limit"}, list.QueryParameters)
+	assert.Equal(t, []string{"X-Tenant"}, list.HeaderParameters)
+	assert.Equal(t, []string{"X-Tenant"}, list.InputSchema["required"])
+
+	create := doc.Operations[1]
+	assert.Equal(t, "POST", create.Method)
+	assert.Equal(t, "Add a pet to the store", create.Description)
+	assert.True(t, create.HasBody)
+	body := create.InputSchema["properties"].(map[string]any)["body"].(map[string]any)
+	assert.Equal(t, []any{"name"}, body["required"])
+	assert.Equal(t, []string{"body"}, create.InputSchema["required"])
+
+	get := doc.Operations[2]
+	assert.Equal(t, "get-pets-petId", get.ID)
+	assert.Equal(t, map[string]any{"type": "string", "description": "ID of the pet"}, get.InputSchema["properties"].(map[string]any)["petId"])
+	assert.Equal(t, []string{"petId"}, get.InputSchema["required"])
+
+	t.Run("rejects documents that are not OpenAPI 3", func(t *testing.T) {
+		_, err := ParseOpenAPI([]byte(`swagger: "2.0"`))
+		assert.ErrorContains(t, err, "only OpenAPI 3 documents are supported")
+	})
+}
+
+func TestOpenAPIOperationHTTPSpec(t *testing.T) {
+	doc, err := ParseOpenAPI([]byte(petstoreDocument))
+	require.NoError(t, err)
+
+	auth := arkv1alpha1.Header{Name: "Authorization", Value: arkv1alpha1.HeaderValue{Value: "Bearer token"}}
+	spec := doc.Operations[0].HTTPSpec("https://petstore.example.com/v1/", []arkv1alpha1.Header{auth}, "10s")
+	assert.Equal(t, "https://petstore.example.com/v1/pets?limit={limit}", spec.URL)
+	assert.Equal(t, "GET", spec.Method)
+	assert.Equal(t, "10s", spec.Timeout)
+	assert.Equal(t, []arkv1alpha1.Header{
+		auth,
+		{Name: "X-Tenant", Value: arkv1alpha1.HeaderValue{Value: `{{ index .input "X-Tenant" | default "" }}`}},
+	}, spec.Headers)
+	assert.Empty(t, spec.Body)
+
+	assert.Equal(t, "{{ toJson .input.body }}", doc.Operations[1].HTTPSpec("https://petstore.example.com", nil, "").Body)
+}
+
+func TestHTTPExecutorInvokesOpenAPIOperation(t *testing.T) {
+	doc, err := ParseOpenAPI([]byte(petstoreDocument))
+	require.NoError(t, err)
+
+	var received *http.Request
+	var receivedBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, arkv1alpha1.AddToScheme(scheme))
+	toolFor := func(name string, op OpenAPIOperation) *arkv1alpha1.Tool {
+		spec := op.HTTPSpec(server.URL, nil, "")
+		return &arkv1alpha1.Tool{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{labels.OpenAPIToolLabel: "petstore"}},
+			Spec:       arkv1alpha1.ToolSpec{Type: ToolTypeHTTP, HTTP: &spec},
+		}
+	}
+	handWritten := toolFor("hand-written", doc.Operations[0])
+	handWritten.Labels = nil
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		toolFor("petstore-list-pets", doc.Operations[0]),
+		toolFor("petstore-create-pet", doc.Operations[1]),
+		handWritten,
+	).Build()
+
+	call := func(name string, arguments map[string]any) ToolResult {
+		data, err := json.Marshal(arguments)
+		require.NoError(t, err)
+		executor := &HTTPExecutor{K8sClient: k8sClient, ToolName: name, ToolNamespace: "default"}
+		result, err := executor.Execute(context.Background(), ToolCall{
+			ID:       "call-1",
+			Function: openai.ChatCompletionMessageToolCallFunction{Name: name, Arguments: string(data)},
+		}, nil)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("drops query parameters that are not given", func(t *testing.T) {
+		result := call("petstore-list-pets", map[string]any{"X-Tenant": "acme"})
+		assert.Equal(t, "[]", result.Content)
+		assert.Equal(t, "/pets", received.URL.Path)
+		assert.Empty(t, received.URL.RawQuery)
+		assert.Equal(t, "acme", received.Header.Get("X-Tenant"))
+	})
+
+	t.Run("sends the query parameters that are given", func(t *testing.T) {
+		call("petstore-list-pets", map[string]any{"limit": 5, "X-Tenant": "acme"})
+		assert.Equal(t, "5", received.URL.Query().Get("limit"))
+	})
+
+	t.Run("leaves the URL and headers of other http tools as written", func(t *testing.T) {
+		call("hand-written", map[string]any{"X-Tenant": "acme"})
+		assert.Equal(t, "{limit}", received.URL.Query().Get("limit"))
+		assert.Equal(t, `{{ index .input "X-Tenant" | default "" }}`, received.Header.Get("X-Tenant"))
+	})
+
+	t.Run("sends the body as JSON", func(t *testing.T) {
+		call("petstore-create-pet", map[string]any{"body": map[string]any{"name": "Rex"}})
+		assert.Equal(t, http.MethodPost, received.Method)
+		assert.JSONEq(t, `{"name": "Rex"}`, string(receivedBody))
+	})
+}
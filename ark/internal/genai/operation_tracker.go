@@ -65,6 +65,12 @@ func (t *OperationTracker) CompleteWithTokens(tokenUsage TokenUsage) {
 	t.emitCompletion(corev1.EventTypeNormal, t.operation+"Complete", "", tokenUsage)
 }
 
+// CompleteWithRollupTokens reports the tokens used by the operations within this one, which the token usage
+// collector has already counted
+func (t *OperationTracker) CompleteWithRollupTokens(tokenUsage TokenUsage) {
+	t.emitOperationEvent(corev1.EventTypeNormal, t.operation+"Complete", OperationEvent{TokenUsage: tokenUsage, rollup: true}, nil)
+}
+
 func (t *OperationTracker) Fail(err error) {
 	errorMsg := ""
 	if err != nil {
@@ -99,6 +105,10 @@ func (t *OperationTracker) emitCompletion(eventType, reason, errorMsg string, to
 }
 
 func (t *OperationTracker) emitCompletionWithMetadata(eventType, reason, errorMsg string, tokenUsage TokenUsage, additionalMetadata map[string]string) {
+	t.emitOperationEvent(eventType, reason, OperationEvent{Error: errorMsg, TokenUsage: tokenUsage}, additionalMetadata)
+}
+
+func (t *OperationTracker) emitOperationEvent(eventType, reason string, event OperationEvent, additionalMetadata map[string]string) {
 	metadata := make(map[string]string)
 	maps.Copy(metadata, t.metadata)
 
@@ -106,15 +116,11 @@ func (t *OperationTracker) emitCompletionWithMetadata(eventType, reason, errorMs
 		maps.Copy(metadata, additionalMetadata)
 	}
 
-	event := OperationEvent{
-		BaseEvent: BaseEvent{
-			Name:     t.name,
-			Metadata: metadata,
-		},
-		Error:      errorMsg,
-		Duration:   time.Since(t.startTime).String(),
-		TokenUsage: tokenUsage,
+	event.BaseEvent = BaseEvent{
+		Name:     t.name,
+		Metadata: metadata,
 	}
+	event.Duration = time.Since(t.startTime).String()
 
 	t.emitter.EmitEvent(t.ctx, eventType, reason, event)
 }
@@ -0,0 +1,251 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+)
+
+// ProfileMemoryConfigMapName is the optional per-namespace ConfigMap with the profile memory retention policy
+const ProfileMemoryConfigMapName = "ark-config-profile-memory"
+
+const (
+	// DefaultProfileRetention is how long remembered facts are kept unless the namespace configures otherwise
+	DefaultProfileRetention = 90 * 24 * time.Hour
+	// DefaultProfileMaxFacts is how many facts are kept per user unless the namespace configures otherwise
+	DefaultProfileMaxFacts = 50
+
+	profileFactsKey    = "facts"
+	profileMaxFactSize = 1024
+)
+
+// ProfileFact is a durable fact or preference remembered about a user
+type ProfileFact struct {
+	Fact      string    `json:"fact"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ProfileRetention limits how long and how many facts are kept per user
+type ProfileRetention struct {
+	MaxAge   time.Duration
+	MaxFacts int
+}
+
+// apply drops expired facts and keeps at most MaxFacts of the newest ones
+func (r ProfileRetention) apply(facts []ProfileFact, now time.Time) []ProfileFact {
+	kept := make([]ProfileFact, 0, len(facts))
+	for _, fact := range facts {
+		if r.MaxAge <= 0 || now.Sub(fact.CreatedAt) <= r.MaxAge {
+			kept = append(kept, fact)
+		}
+	}
+	if r.MaxFacts > 0 && len(kept) > r.MaxFacts {
+		kept = kept[len(kept)-r.MaxFacts:]
+	}
+	return kept
+}
+
+// GetProfileRetention reads the retention policy from the namespace's profile memory ConfigMap
+func GetProfileRetention(ctx context.Context, k8sClient client.Client, namespace string) (ProfileRetention, error) {
+	retention := ProfileRetention{MaxAge: DefaultProfileRetention, MaxFacts: DefaultProfileMaxFacts}
+
+	cm := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: ProfileMemoryConfigMapName, Namespace: namespace}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return retention, nil
+		}
+		return retention, fmt.Errorf("failed to get profile memory ConfigMap: %w", err)
+	}
+
+	if value, ok := cm.Data["retention"]; ok {
+		maxAge, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || maxAge < 0 {
+			return retention, fmt.Errorf("invalid retention %q in profile memory ConfigMap", value)
+		}
+		retention.MaxAge = maxAge
+	}
+	if value, ok := cm.Data["maxFacts"]; ok {
+		maxFacts, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || maxFacts < 0 {
+			return retention, fmt.Errorf("invalid maxFacts %q in profile memory ConfigMap", value)
+		}
+		retention.MaxFacts = maxFacts
+	}
+	return retention, nil
+}
+
+// ProfileStore keeps the facts remembered about each user in a ConfigMap per user, named after a
+// hash of the user ID so arbitrary IDs can be used
+type ProfileStore struct {
+	Client    client.Client
+	Namespace string
+	now       func() time.Time
+}
+
+func NewProfileStore(k8sClient client.Client, namespace string) *ProfileStore {
+	return &ProfileStore{Client: k8sClient, Namespace: namespace, now: time.Now}
+}
+
+func profileConfigMapName(userID string) string {
+	sum := sha256.Sum256([]byte(userID))
+	return "ark-profile-" + hex.EncodeToString(sum[:])[:16]
+}
+
+// Facts returns the facts remembered about the user that are within the retention policy
+func (s *ProfileStore) Facts(ctx context.Context, userID string) ([]ProfileFact, error) {
+	retention, err := GetProfileRetention(ctx, s.Client, s.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := s.Client.Get(ctx, client.ObjectKey{Name: profileConfigMapName(userID), Namespace: s.Namespace}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user profile: %w", err)
+	}
+	facts, err := decodeProfileFacts(cm)
+	if err != nil {
+		return nil, err
+	}
+	return retention.apply(facts, s.now()), nil
+}
+
+// Remember adds a fact to the user's profile. Repeating a known fact refreshes it.
+func (s *ProfileStore) Remember(ctx context.Context, userID, fact string) error {
+	retention, err := GetProfileRetention(ctx, s.Client, s.Namespace)
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: profileConfigMapName(userID), Namespace: s.Namespace}}
+	_, err = controllerutil.CreateOrUpdate(ctx, s.Client, cm, func() error {
+		facts, err := decodeProfileFacts(cm)
+		if err != nil {
+			return err
+		}
+		kept := facts[:0]
+		for _, existing := range facts {
+			if !strings.EqualFold(existing.Fact, fact) {
+				kept = append(kept, existing)
+			}
+		}
+		kept = retention.apply(append(kept, ProfileFact{Fact: fact, CreatedAt: s.now().UTC()}), s.now())
+
+		data, err := json.Marshal(kept)
+		if err != nil {
+			return fmt.Errorf("failed to encode user profile: %w", err)
+		}
+		if cm.Labels == nil {
+			cm.Labels = map[string]string{}
+		}
+		cm.Labels[annotations.Profile] = TrueString
+		if cm.Annotations == nil {
+			cm.Annotations = map[string]string{}
+		}
+		cm.Annotations[annotations.ProfileUserID] = userID
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[profileFactsKey] = string(data)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update user profile: %w", err)
+	}
+	return nil
+}
+
+func decodeProfileFacts(cm *corev1.ConfigMap) ([]ProfileFact, error) {
+	value := cm.Data[profileFactsKey]
+	if value == "" {
+		return nil, nil
+	}
+	var facts []ProfileFact
+	if err := json.Unmarshal([]byte(value), &facts); err != nil {
+		return nil, fmt.Errorf("invalid user profile %s: %w", cm.Name, err)
+	}
+	return facts, nil
+}
+
+// queryUserID returns the user the query in the context runs for
+func queryUserID(ctx context.Context) string {
+	if query, ok := ctx.Value(QueryContextKey).(*arkv1alpha1.Query); ok && query.Spec.UserContext != nil {
+		return query.Spec.UserContext.ID
+	}
+	return ""
+}
+
+// profileContext returns the facts remembered about the query's user as an addition to the system
+// prompt, or an empty string when there are none
+func profileContext(ctx context.Context, k8sClient client.Client, namespace string) (string, error) {
+	userID := queryUserID(ctx)
+	if userID == "" || k8sClient == nil {
+		return "", nil
+	}
+	facts, err := NewProfileStore(k8sClient, namespace).Facts(ctx, userID)
+	if err != nil || len(facts) == 0 {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\nWhat you know about the user from earlier sessions:")
+	for _, fact := range facts {
+		fmt.Fprintf(&b, "\n- %s", fact.Fact)
+	}
+	return b.String(), nil
+}
+
+// RememberExecutor is the remember built-in tool. It stores a durable fact or preference about the
+// query's user, which is added to the context of the user's future queries.
+type RememberExecutor struct {
+	K8sClient client.Client
+	Namespace string
+}
+
+func (e *RememberExecutor) Execute(ctx context.Context, call ToolCall, recorder EventEmitter) (ToolResult, error) {
+	result := ToolResult{ID: call.ID, Name: call.Function.Name}
+
+	var arguments struct {
+		Fact string `json:"fact"`
+	}
+	err := json.Unmarshal([]byte(call.Function.Arguments), &arguments)
+	fact := strings.TrimSpace(arguments.Fact)
+	userID := queryUserID(ctx)
+	switch {
+	case err != nil:
+		err = fmt.Errorf("failed to parse remember arguments: %w", err)
+	case fact == "":
+		err = fmt.Errorf("fact is required")
+	case len(fact) > profileMaxFactSize:
+		err = fmt.Errorf("fact is longer than %d bytes", profileMaxFactSize)
+	case userID == "":
+		err = fmt.Errorf("the query has no userContext, so there is no user profile to remember facts in")
+	default:
+		err = NewProfileStore(e.K8sClient, e.Namespace).Remember(ctx, userID, fact)
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result, err
+	}
+
+	result.Content = fmt.Sprintf("Remembered: %s", fact)
+	return result, nil
+}
@@ -0,0 +1,86 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func userQueryContext(userID string) context.Context {
+	query := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "query", Namespace: "default"}}
+	if userID != "" {
+		query.Spec.UserContext = &arkv1alpha1.UserContext{ID: userID}
+	}
+	return context.WithValue(context.Background(), QueryContextKey, query)
+}
+
+func TestProfileStoreRetention(t *testing.T) {
+	policy := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ProfileMemoryConfigMapName, Namespace: "default"},
+		Data:       map[string]string{"retention": "24h", "maxFacts": "2"},
+	}
+	k8sClient := fake.NewClientBuilder().WithObjects(policy).Build()
+	ctx := context.Background()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := NewProfileStore(k8sClient, "default")
+	store.now = func() time.Time { return now }
+
+	require.NoError(t, store.Remember(ctx, "alice@example.com", "Prefers metric units"))
+	now = now.Add(time.Hour)
+	require.NoError(t, store.Remember(ctx, "alice@example.com", "Works in the Berlin office"))
+	require.NoError(t, store.Remember(ctx, "bob@example.com", "Likes short answers"))
+
+	facts, err := store.Facts(ctx, "alice@example.com")
+	require.NoError(t, err)
+	require.Len(t, facts, 2)
+	assert.Equal(t, "Prefers metric units", facts[0].Fact)
+
+	// Only the newest facts are kept and repeating a fact refreshes it
+	require.NoError(t, store.Remember(ctx, "alice@example.com", "Prefers tables"))
+	require.NoError(t, store.Remember(ctx, "alice@example.com", "works in the Berlin office"))
+	facts, err = store.Facts(ctx, "alice@example.com")
+	require.NoError(t, err)
+	require.Len(t, facts, 2)
+	assert.Equal(t, "Prefers tables", facts[0].Fact)
+	assert.Equal(t, "works in the Berlin office", facts[1].Fact)
+
+	// Facts expire after the retention period
+	now = now.Add(25 * time.Hour)
+	facts, err = store.Facts(ctx, "alice@example.com")
+	require.NoError(t, err)
+	assert.Empty(t, facts)
+}
+
+func TestRememberExecutor(t *testing.T) {
+	k8sClient := fake.NewClientBuilder().Build()
+	executor := &RememberExecutor{K8sClient: k8sClient, Namespace: "default"}
+	call := ToolCall{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{Name: BuiltinToolRemember, Arguments: `{"fact":"Prefers metric units"}`}}
+
+	_, err := executor.Execute(userQueryContext(""), call, nil)
+	assert.ErrorContains(t, err, "no userContext")
+
+	ctx := userQueryContext("alice")
+	result, err := executor.Execute(ctx, call, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Remembered: Prefers metric units", result.Content)
+
+	profile, err := profileContext(ctx, k8sClient, "default")
+	require.NoError(t, err)
+	assert.Contains(t, profile, "- Prefers metric units")
+
+	profile, err = profileContext(userQueryContext("bob"), k8sClient, "default")
+	require.NoError(t, err)
+	assert.Empty(t, profile)
+}
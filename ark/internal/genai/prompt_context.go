@@ -0,0 +1,86 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+)
+
+// LocaleConfigMapName is the optional per-namespace ConfigMap with the default timezone and locale
+const LocaleConfigMapName = "ark-config-locale"
+
+const (
+	defaultTimezone = "UTC"
+	defaultLocale   = "en-US"
+)
+
+// Standard template variables available to every agent prompt
+const (
+	PromptVarNow      = "now"
+	PromptVarTimezone = "timezone"
+	PromptVarLocale   = "locale"
+)
+
+// PromptContext is the time and locale a query runs in
+type PromptContext struct {
+	Now      time.Time
+	Timezone string
+	Locale   string
+}
+
+// TemplateData returns the standard prompt template variables
+func (p PromptContext) TemplateData() map[string]any {
+	return map[string]any{
+		PromptVarNow:      p.Now.Format(time.RFC1123),
+		PromptVarTimezone: p.Timezone,
+		PromptVarLocale:   p.Locale,
+	}
+}
+
+// ResolvePromptContext determines the timezone and locale for a query. Query annotations take precedence
+// over the namespace's locale ConfigMap, which takes precedence over UTC and en-US.
+func ResolvePromptContext(ctx context.Context, k8sClient client.Client, namespace string, query *arkv1alpha1.Query) PromptContext {
+	timezone, locale := defaultTimezone, defaultLocale
+
+	if k8sClient != nil {
+		cm := &corev1.ConfigMap{}
+		err := k8sClient.Get(ctx, client.ObjectKey{Name: LocaleConfigMapName, Namespace: namespace}, cm)
+		switch {
+		case err == nil:
+			timezone = valueOr(cm.Data["timezone"], timezone)
+			locale = valueOr(cm.Data["locale"], locale)
+		case !errors.IsNotFound(err):
+			logf.FromContext(ctx).Error(err, "failed to get locale ConfigMap", "namespace", namespace)
+		}
+	}
+
+	if query != nil {
+		timezone = valueOr(query.Annotations[annotations.Timezone], timezone)
+		locale = valueOr(query.Annotations[annotations.Locale], locale)
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		logf.FromContext(ctx).Error(fmt.Errorf("invalid timezone %q: %w", timezone, err), "falling back to UTC")
+		timezone, loc = defaultTimezone, time.UTC
+	}
+
+	return PromptContext{Now: time.Now().In(loc), Timezone: timezone, Locale: locale}
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
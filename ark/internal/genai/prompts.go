@@ -0,0 +1,76 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// PromptsConfigMapName is the optional per-namespace ConfigMap overriding built-in prompts
+const PromptsConfigMapName = "ark-config-prompts"
+
+// PromptSelector is the key of the team selector prompt in the prompts ConfigMap
+const PromptSelector = "selector"
+
+// PromptReview is the key of the agent review prompt in the prompts ConfigMap
+const PromptReview = "review"
+
+// PromptConfidence is the key of the confidence estimation prompt in the prompts ConfigMap
+const PromptConfidence = "confidence"
+
+// PromptAggregation is the key of the prompt that synthesizes the responses of a query's targets
+const PromptAggregation = "aggregation"
+
+// PromptJudge is the key of the prompt with which a judge scores query responses against the rubric of an evaluator
+const PromptJudge = "judge"
+
+// builtinPrompts holds the embedded default for every prompt that can be overridden
+var builtinPrompts = map[string]string{
+	PromptSelector:    defaultSelectorPrompt,
+	PromptReview:      defaultReviewPrompt,
+	PromptConfidence:  defaultConfidencePrompt,
+	PromptAggregation: defaultAggregationPrompt,
+	PromptJudge:       defaultJudgePrompt,
+}
+
+// GetBuiltinPrompt returns the named prompt from the namespace's prompts ConfigMap, falling back to the
+// embedded default when the ConfigMap or key is missing or cannot be read
+func GetBuiltinPrompt(ctx context.Context, k8sClient client.Client, namespace, name string) string {
+	fallback := builtinPrompts[name]
+	if k8sClient == nil {
+		return fallback
+	}
+
+	prompt, ok, err := getPromptOverride(ctx, k8sClient, namespace, name)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "using built-in prompt", "prompt", name, "namespace", namespace)
+		return fallback
+	}
+	if !ok {
+		return fallback
+	}
+	return prompt
+}
+
+func getPromptOverride(ctx context.Context, k8sClient client.Client, namespace, name string) (string, bool, error) {
+	cm := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: PromptsConfigMapName, Namespace: namespace}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to get prompts ConfigMap: %w", err)
+	}
+
+	prompt, ok := cm.Data[name]
+	if !ok || strings.TrimSpace(prompt) == "" {
+		return "", false, nil
+	}
+	return prompt, true, nil
+}
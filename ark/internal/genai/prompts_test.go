@@ -0,0 +1,43 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetBuiltinPrompt(t *testing.T) {
+	override := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: PromptsConfigMapName, Namespace: "custom"},
+		Data:       map[string]string{PromptSelector: "Pick one of {{.Participants}}"},
+	}
+	empty := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: PromptsConfigMapName, Namespace: "empty"},
+		Data:       map[string]string{PromptSelector: "  "},
+	}
+	k8sClient := fake.NewClientBuilder().WithObjects(override, empty).Build()
+
+	tests := []struct {
+		name      string
+		namespace string
+		expected  string
+	}{
+		{name: "override", namespace: "custom", expected: "Pick one of {{.Participants}}"},
+		{name: "blank override", namespace: "empty", expected: defaultSelectorPrompt},
+		{name: "no configmap", namespace: "default", expected: defaultSelectorPrompt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetBuiltinPrompt(context.Background(), k8sClient, tt.namespace, PromptSelector))
+		})
+	}
+
+	assert.Equal(t, defaultSelectorPrompt, GetBuiltinPrompt(context.Background(), nil, "custom", PromptSelector))
+}
@@ -137,7 +137,7 @@ func (ap *AzureProvider) ChatCompletionStream(ctx context.Context, messages []Me
 }
 
 func (ap *AzureProvider) createClient(ctx context.Context) openai.Client {
-	httpClient := common.NewHTTPClientWithLogging(ctx)
+	httpClient := common.NewHTTPClientWithLogging(ctx, common.HTTPClientModel)
 
 	deploymentURL := fmt.Sprintf("%s/openai/deployments/%s", ap.BaseURL, ap.Model)
 	options := []option.RequestOption{
@@ -153,6 +153,10 @@ func (ap *AzureProvider) createClient(ctx context.Context) openai.Client {
 	return openai.NewClient(options...)
 }
 
+func (ap *AzureProvider) Embeddings(ctx context.Context, inputs []string) ([][]float64, error) {
+	return createEmbeddings(ctx, ap.createClient(ctx), ap.Model, inputs)
+}
+
 func (ap *AzureProvider) BuildConfig() map[string]any {
 	config := map[string]any{
 		"baseUrl": ap.BaseURL,
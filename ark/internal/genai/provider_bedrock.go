@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -12,6 +13,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
 	"github.com/openai/openai-go"
 	"k8s.io/apimachinery/pkg/runtime"
+
+	"mckinsey.com/ark/internal/common"
 )
 
 type BedrockModel struct {
@@ -99,6 +102,9 @@ func (bm *BedrockModel) initClient(ctx context.Context) error {
 		return fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
+	// Tag requests with the ark User-Agent and the request tags of their context
+	cfg.HTTPClient = &http.Client{Transport: common.NewInstrumentedTransport(common.HTTPClientModel, nil)}
+
 	// If BaseURL is provided, use it as custom endpoint
 	if bm.BaseURL != "" {
 		cfg.BaseEndpoint = aws.String(bm.BaseURL)
@@ -346,8 +352,8 @@ func extractMessageContent(msg Message) (string, string) {
 	}
 
 	if userMsg := openaiMsg.OfUser; userMsg != nil {
-		if content := userMsg.Content.OfString; content.Value != "" {
-			return content.Value, RoleUser
+		if content := userMessageText(userMsg.Content); content != "" {
+			return content, RoleUser
 		}
 	}
 
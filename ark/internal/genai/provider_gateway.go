@@ -0,0 +1,97 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// GatewayProvider calls an inference gateway through its OpenAI compatible API. The model name is passed through
+// for the gateway to route, and the usage and cost the gateway reports in response headers are read.
+type GatewayProvider struct {
+	OpenAIProvider
+	UsageHeaders arkv1alpha1.GatewayUsageHeaders
+}
+
+func (gp *GatewayProvider) ChatCompletion(ctx context.Context, messages []Message, n int64, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	var httpResponse *http.Response
+	completion, err := gp.chatCompletion(ctx, messages, n, gp.requestOptions(&httpResponse), tools...)
+	if err != nil {
+		return nil, err
+	}
+	gp.readUsageHeaders(ctx, httpResponse, completion)
+	return completion, nil
+}
+
+func (gp *GatewayProvider) ChatCompletionStream(ctx context.Context, messages []Message, n int64, streamFunc func(*openai.ChatCompletionChunk) error, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	var httpResponse *http.Response
+	completion, err := gp.chatCompletionStream(ctx, messages, n, streamFunc, gp.requestOptions(&httpResponse), tools...)
+	if err != nil {
+		return nil, err
+	}
+	gp.readUsageHeaders(ctx, httpResponse, completion)
+	return completion, nil
+}
+
+// requestOptions captures the HTTP response of a call. Gateways usually hold the credentials of the providers
+// themselves, so without an API key no authorization header is sent.
+func (gp *GatewayProvider) requestOptions(httpResponse **http.Response) []option.RequestOption {
+	requestOptions := []option.RequestOption{option.WithResponseInto(httpResponse)}
+	if gp.APIKey == "" {
+		requestOptions = append(requestOptions, option.WithHeaderDel("authorization"))
+	}
+	return requestOptions
+}
+
+// readUsageHeaders fills in the token usage of a completion whose body has none, as streamed completions often
+// do not, and reports the cost of the call from the response headers
+func (gp *GatewayProvider) readUsageHeaders(ctx context.Context, httpResponse *http.Response, completion *openai.ChatCompletion) {
+	if httpResponse == nil || completion == nil {
+		return
+	}
+	log := logf.FromContext(ctx)
+
+	if completion.Usage.TotalTokens == 0 {
+		promptTokens, promptOK := gatewayTokenHeader(httpResponse.Header, gp.UsageHeaders.PromptTokens)
+		completionTokens, completionOK := gatewayTokenHeader(httpResponse.Header, gp.UsageHeaders.CompletionTokens)
+		if promptOK || completionOK {
+			completion.Usage.PromptTokens = promptTokens
+			completion.Usage.CompletionTokens = completionTokens
+			completion.Usage.TotalTokens = promptTokens + completionTokens
+		}
+	}
+
+	if gp.UsageHeaders.Cost == "" {
+		return
+	}
+	value := httpResponse.Header.Get(gp.UsageHeaders.Cost)
+	if value == "" {
+		return
+	}
+	cost, err := ParseCost(value)
+	if err != nil {
+		log.V(LogLevelDebug).Info("ignoring invalid gateway cost header", "header", gp.UsageHeaders.Cost, "value", value)
+		return
+	}
+	reportCost(ctx, cost)
+}
+
+// gatewayTokenHeader parses a token count header, which is not read when it is not named
+func gatewayTokenHeader(header http.Header, name string) (int64, bool) {
+	if name == "" {
+		return 0, false
+	}
+	tokens, err := strconv.ParseInt(header.Get(name), 10, 64)
+	if err != nil || tokens < 0 {
+		return 0, false
+	}
+	return tokens, true
+}
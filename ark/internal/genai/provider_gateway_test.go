@@ -0,0 +1,119 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+// gatewayServer answers chat completions without usage in the body, reporting it in headers instead
+func gatewayServer(t *testing.T, headers map[string]string) (*httptest.Server, *http.Request) {
+	received := &http.Request{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*received = *r.Clone(context.Background())
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		for name, value := range headers {
+			w.Header().Set(name, value)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":      "chatcmpl-1",
+			"object":  "chat.completion",
+			"model":   body["model"],
+			"choices": []map[string]any{{"index": 0, "finish_reason": "stop", "message": map[string]any{"role": "assistant", "content": "done"}}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+func TestGatewayProviderReadsUsageHeaders(t *testing.T) {
+	server, received := gatewayServer(t, map[string]string{
+		"x-litellm-response-cost": "0.0042",
+		"x-prompt-tokens":         "120",
+		"x-completion-tokens":     "30",
+	})
+	model := &Model{
+		Model: "team-default",
+		Type:  ModelTypeGateway,
+		Provider: &GatewayProvider{
+			OpenAIProvider: OpenAIProvider{Model: "team-default", BaseURL: server.URL},
+			UsageHeaders: arkv1alpha1.GatewayUsageHeaders{
+				Cost:             defaultGatewayCostHeader,
+				PromptTokens:     "x-prompt-tokens",
+				CompletionTokens: "x-completion-tokens",
+			},
+		},
+		ModelRecorder: noop.NewModelRecorder(),
+		Pricing:       &ModelPricing{InputPerMillionTokens: 2.5, OutputPerMillionTokens: 10},
+	}
+
+	tracker := NewCostTracker(0)
+	response, err := model.ChatCompletion(WithCostTracker(t.Context(), tracker), []Message{NewUserMessage("hi")}, nil, 1)
+	require.NoError(t, err)
+
+	assert.Empty(t, received.Header.Get("Authorization"), "no API key is sent to a gateway without one")
+	assert.Equal(t, "team-default", response.Model, "the model name is passed through to the gateway")
+	assert.Equal(t, int64(120), response.Usage.PromptTokens)
+	assert.Equal(t, int64(30), response.Usage.CompletionTokens)
+	assert.Equal(t, int64(150), response.Usage.TotalTokens)
+
+	total, priced := tracker.Total()
+	assert.True(t, priced)
+	assert.Equal(t, "0.0042", FormatCost(total), "the reported cost replaces the estimate from pricing")
+}
+
+func TestGatewayProviderWithoutUsageHeaders(t *testing.T) {
+	server, received := gatewayServer(t, nil)
+	model := &Model{
+		Model: "team-default",
+		Type:  ModelTypeGateway,
+		Provider: &GatewayProvider{
+			OpenAIProvider: OpenAIProvider{Model: "team-default", BaseURL: server.URL, APIKey: "sk-gateway"},
+			UsageHeaders:   arkv1alpha1.GatewayUsageHeaders{Cost: defaultGatewayCostHeader},
+		},
+		ModelRecorder: noop.NewModelRecorder(),
+		Pricing:       &ModelPricing{InputPerMillionTokens: 2.5, OutputPerMillionTokens: 10},
+	}
+
+	tracker := NewCostTracker(0)
+	response, err := model.ChatCompletion(WithCostTracker(t.Context(), tracker), []Message{NewUserMessage("hi")}, nil, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Bearer sk-gateway", received.Header.Get("Authorization"))
+	assert.Zero(t, response.Usage.TotalTokens)
+	_, priced := tracker.Total()
+	assert.True(t, priced, "calls without a reported cost are estimated from pricing")
+}
+
+func TestLoadGatewayConfig(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, arkv1alpha1.AddToScheme(scheme))
+	resolver := common.NewValueSourceResolver(fake.NewClientBuilder().WithScheme(scheme).Build())
+
+	model := &Model{Model: "team-default"}
+	config := &arkv1alpha1.GatewayModelConfig{BaseURL: arkv1alpha1.ValueSource{Value: "http://litellm.ark-system:4000/v1"}}
+	require.NoError(t, loadGatewayConfig(t.Context(), resolver, config, "default", model, nil))
+
+	provider, ok := model.Provider.(*GatewayProvider)
+	require.True(t, ok)
+	assert.Equal(t, "http://litellm.ark-system:4000/v1", provider.BaseURL)
+	assert.Empty(t, provider.APIKey)
+	assert.Equal(t, arkv1alpha1.GatewayUsageHeaders{Cost: defaultGatewayCostHeader}, provider.UsageHeaders)
+
+	require.Error(t, loadGatewayConfig(t.Context(), resolver, nil, "default", model, nil))
+}
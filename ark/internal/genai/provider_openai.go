@@ -28,6 +28,11 @@ func (op *OpenAIProvider) SetOutputSchema(schema *runtime.RawExtension, schemaNa
 }
 
 func (op *OpenAIProvider) ChatCompletion(ctx context.Context, messages []Message, n int64, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	return op.chatCompletion(ctx, messages, n, nil, tools...)
+}
+
+// chatCompletion makes a chat completion call with additional request options
+func (op *OpenAIProvider) chatCompletion(ctx context.Context, messages []Message, n int64, requestOptions []option.RequestOption, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
 	openaiMessages := make([]openai.ChatCompletionMessageParamUnion, len(messages))
 	for i, msg := range messages {
 		openaiMessages[i] = openai.ChatCompletionMessageParamUnion(msg)
@@ -49,7 +54,7 @@ func (op *OpenAIProvider) ChatCompletion(ctx context.Context, messages []Message
 	applyStructuredOutputToParams(op.outputSchema, op.schemaName, &params)
 
 	client := op.createClient(ctx)
-	return client.Chat.Completions.New(ctx, params)
+	return client.Chat.Completions.New(ctx, params, requestOptions...)
 }
 
 // accumulateStreamChunk processes a streaming chunk and accumulates content and tool calls.
@@ -118,9 +123,10 @@ func accumulateStreamChunk(chunk *openai.ChatCompletionChunk, fullResponse **ope
 }
 
 // processToolCalls processes accumulated tool calls from streaming
-func (op *OpenAIProvider) processToolCalls(toolCallsMap map[int64]*openai.ChatCompletionMessageToolCall, fullResponse *openai.ChatCompletion, streamFunc func(*openai.ChatCompletionChunk) error) error {
-	logf.Log.Info("Stream completed", "toolCallsMapSize", len(toolCallsMap))
-	logf.Log.Info("Checking accumulated tool calls", "mapSize", len(toolCallsMap),
+func (op *OpenAIProvider) processToolCalls(ctx context.Context, toolCallsMap map[int64]*openai.ChatCompletionMessageToolCall, fullResponse *openai.ChatCompletion, streamFunc func(*openai.ChatCompletionChunk) error) error {
+	log := logf.FromContext(ctx)
+	log.V(LogLevelDebug).Info("stream completed", "toolCallsMapSize", len(toolCallsMap))
+	log.V(LogLevelTrace).Info("checking accumulated tool calls", "mapSize", len(toolCallsMap),
 		"hasResponse", fullResponse != nil,
 		"hasChoices", fullResponse != nil && len(fullResponse.Choices) > 0)
 
@@ -129,7 +135,7 @@ func (op *OpenAIProvider) processToolCalls(toolCallsMap map[int64]*openai.ChatCo
 		return nil
 	}
 
-	logf.Log.Info("Accumulated tool calls from streaming", "count", len(toolCallsMap))
+	log.V(LogLevelDebug).Info("accumulated tool calls from streaming", "count", len(toolCallsMap))
 
 	// Find max index to iterate in order
 	maxIndex := int64(-1)
@@ -144,22 +150,22 @@ func (op *OpenAIProvider) processToolCalls(toolCallsMap map[int64]*openai.ChatCo
 	for i := int64(0); i <= maxIndex; i++ {
 		if toolCall, exists := toolCallsMap[i]; exists {
 			toolCalls = append(toolCalls, *toolCall)
-			logf.Log.Info("Adding tool call", "index", i, "id", toolCall.ID, "name", toolCall.Function.Name)
+			log.V(LogLevelTrace).Info("adding tool call", "index", i, "id", toolCall.ID, "name", toolCall.Function.Name)
 		}
 	}
 	fullResponse.Choices[0].Message.ToolCalls = toolCalls
-	logf.Log.Info("Set tool calls on response", "count", len(toolCalls))
+	log.V(LogLevelTrace).Info("set tool calls on response", "count", len(toolCalls))
 
 	// Send final accumulated message if needed
 	if streamFunc != nil && len(toolCalls) > 0 {
-		return op.sendFinalToolCallChunk(fullResponse, toolCalls, streamFunc)
+		return op.sendFinalToolCallChunk(ctx, fullResponse, toolCalls, streamFunc)
 	}
 
 	return nil
 }
 
 // sendFinalToolCallChunk sends the final chunk with accumulated tool calls
-func (op *OpenAIProvider) sendFinalToolCallChunk(fullResponse *openai.ChatCompletion, toolCalls []openai.ChatCompletionMessageToolCall, streamFunc func(*openai.ChatCompletionChunk) error) error {
+func (op *OpenAIProvider) sendFinalToolCallChunk(ctx context.Context, fullResponse *openai.ChatCompletion, toolCalls []openai.ChatCompletionMessageToolCall, streamFunc func(*openai.ChatCompletionChunk) error) error {
 	finalChunk := &openai.ChatCompletionChunk{
 		ID:      fullResponse.ID,
 		Object:  "chat.completion.chunk",
@@ -177,9 +183,10 @@ func (op *OpenAIProvider) sendFinalToolCallChunk(fullResponse *openai.ChatComple
 	// Send complete accumulated message as final update
 	// This is a special chunk that contains the full message with tool calls
 	// It's marked with a special field so memory can handle it appropriately
-	logf.Log.Info("Sending final accumulated message with tool calls", "toolCount", len(toolCalls))
+	log := logf.FromContext(ctx)
+	log.V(LogLevelDebug).Info("sending final accumulated message with tool calls", "toolCount", len(toolCalls))
 	if err := streamFunc(finalChunk); err != nil {
-		logf.Log.Error(err, "Failed to send final accumulated message")
+		log.Error(err, "Failed to send final accumulated message")
 		return err
 	}
 	return nil
@@ -211,12 +218,17 @@ func (op *OpenAIProvider) prepareStreamParams(messages []Message, n int64, tools
 }
 
 func (op *OpenAIProvider) ChatCompletionStream(ctx context.Context, messages []Message, n int64, streamFunc func(*openai.ChatCompletionChunk) error, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
-	logf.Log.Info("OpenAIProvider.ChatCompletionStream called", "messageCount", len(messages), "toolCount", len(tools))
+	return op.chatCompletionStream(ctx, messages, n, streamFunc, nil, tools...)
+}
+
+// chatCompletionStream makes a streaming chat completion call with additional request options
+func (op *OpenAIProvider) chatCompletionStream(ctx context.Context, messages []Message, n int64, streamFunc func(*openai.ChatCompletionChunk) error, requestOptions []option.RequestOption, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	logf.FromContext(ctx).V(LogLevelDebug).Info("streaming chat completion", "messageCount", len(messages), "toolCount", len(tools))
 
 	params := op.prepareStreamParams(messages, n, tools...)
 
 	client := op.createClient(ctx)
-	stream := client.Chat.Completions.NewStreaming(ctx, params)
+	stream := client.Chat.Completions.NewStreaming(ctx, params, requestOptions...)
 	defer func() { _ = stream.Close() }()
 
 	var fullResponse *openai.ChatCompletion
@@ -234,8 +246,8 @@ func (op *OpenAIProvider) ChatCompletionStream(ctx context.Context, messages []M
 	}
 
 	// Process accumulated tool calls
-	if err := op.processToolCalls(toolCallsMap, fullResponse, streamFunc); err != nil {
-		logf.Log.Error(err, "Failed to process tool calls")
+	if err := op.processToolCalls(ctx, toolCallsMap, fullResponse, streamFunc); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to process tool calls")
 	}
 
 	if err := stream.Err(); err != nil {
@@ -260,7 +272,7 @@ func (op *OpenAIProvider) ChatCompletionStream(ctx context.Context, messages []M
 }
 
 func (op *OpenAIProvider) createClient(ctx context.Context) openai.Client {
-	httpClient := common.NewHTTPClientWithLogging(ctx)
+	httpClient := common.NewHTTPClientWithLogging(ctx, common.HTTPClientModel)
 
 	options := []option.RequestOption{
 		option.WithBaseURL(op.BaseURL),
@@ -273,6 +285,35 @@ func (op *OpenAIProvider) createClient(ctx context.Context) openai.Client {
 	return openai.NewClient(options...)
 }
 
+func (op *OpenAIProvider) Embeddings(ctx context.Context, inputs []string) ([][]float64, error) {
+	return createEmbeddings(ctx, op.createClient(ctx), op.Model, inputs)
+}
+
+// createEmbeddings embeds the inputs in a single request and returns the vectors in input order
+func createEmbeddings(ctx context.Context, client openai.Client, model string, inputs []string) ([][]float64, error) {
+	response, err := client.Embeddings.New(ctx, openai.EmbeddingNewParams{
+		Model: model,
+		Input: openai.EmbeddingNewParamsInputUnion{OfArrayOfStrings: inputs},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float64, len(inputs))
+	for _, embedding := range response.Data {
+		if embedding.Index < 0 || int(embedding.Index) >= len(inputs) {
+			return nil, fmt.Errorf("embedding index %d out of range", embedding.Index)
+		}
+		vectors[embedding.Index] = embedding.Embedding
+	}
+	for i, vector := range vectors {
+		if vector == nil {
+			return nil, fmt.Errorf("no embedding returned for input %d", i)
+		}
+	}
+	return vectors, nil
+}
+
 func (op *OpenAIProvider) BuildConfig() map[string]any {
 	config := map[string]any{
 		"baseUrl": op.BaseURL,
@@ -0,0 +1,73 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// QueriesConfigMapName is the optional per-namespace ConfigMap with the default ttl and timeout of queries
+const QueriesConfigMapName = "ark-config-queries"
+
+const (
+	// DefaultQueryTTL is how long a query is kept when neither the query nor its namespace sets a ttl
+	DefaultQueryTTL = 720 * time.Hour
+	// DefaultQueryTimeout is how long a query runs when neither the query nor its namespace sets a timeout
+	DefaultQueryTimeout = 5 * time.Minute
+)
+
+// ResolveQueryDefaults returns the ttl and timeout of queries of a namespace that do not set them. The
+// namespace's queries ConfigMap takes precedence over DefaultQueryTTL and DefaultQueryTimeout; invalid
+// durations in it are logged and ignored.
+func ResolveQueryDefaults(ctx context.Context, k8sClient client.Client, namespace string) (ttl, timeout time.Duration) {
+	ttl, timeout = DefaultQueryTTL, DefaultQueryTimeout
+	if k8sClient == nil {
+		return ttl, timeout
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: QueriesConfigMapName, Namespace: namespace}, cm); err != nil {
+		if !errors.IsNotFound(err) {
+			logf.FromContext(ctx).Error(err, "failed to get queries ConfigMap", "namespace", namespace)
+		}
+		return ttl, timeout
+	}
+
+	return parseDefaultDuration(ctx, cm, "ttl", ttl), parseDefaultDuration(ctx, cm, "timeout", timeout)
+}
+
+func parseDefaultDuration(ctx context.Context, cm *corev1.ConfigMap, key string, fallback time.Duration) time.Duration {
+	value := cm.Data[key]
+	if value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		logf.FromContext(ctx).Error(fmt.Errorf("invalid %s %q in ConfigMap %s", key, value, cm.Name), "using the default", "default", fallback)
+		return fallback
+	}
+	return duration
+}
+
+// SessionsConfigMapName is the optional per-namespace ConfigMap with the default ttl of sessions
+const SessionsConfigMapName = "ark-config-sessions"
+
+// ResolveSessionTTL returns the ttl of sessions of a namespace that do not set one, from the namespace's
+// sessions ConfigMap. Zero means sessions do not expire.
+func ResolveSessionTTL(ctx context.Context, k8sClient client.Client, namespace string) time.Duration {
+	cm := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: SessionsConfigMapName, Namespace: namespace}, cm); err != nil {
+		if !errors.IsNotFound(err) {
+			logf.FromContext(ctx).Error(err, "failed to get sessions ConfigMap", "namespace", namespace)
+		}
+		return 0
+	}
+	return parseDefaultDuration(ctx, cm, "ttl", 0)
+}
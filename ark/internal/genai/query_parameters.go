@@ -12,8 +12,8 @@ import (
 	"mckinsey.com/ark/internal/common"
 )
 
-func ResolveQueryInput(ctx context.Context, k8sClient client.Client, namespace, input string, parameters []arkv1alpha1.Parameter) (string, error) {
-	if len(parameters) == 0 {
+func ResolveQueryInput(ctx context.Context, k8sClient client.Client, namespace, input string, parameters []arkv1alpha1.Parameter, dependsOn []string) (string, error) {
+	if len(parameters) == 0 && len(dependsOn) == 0 {
 		return input, nil
 	}
 
@@ -22,34 +22,101 @@ func ResolveQueryInput(ctx context.Context, k8sClient client.Client, namespace,
 		return "", fmt.Errorf("failed to resolve parameters: %w", err)
 	}
 
-	resolved, err := common.ResolveTemplate(input, toAnyMap(templateData))
+	data := toAnyMap(templateData)
+	if len(dependsOn) > 0 {
+		outputs, err := resolveQueryOutputs(ctx, k8sClient, namespace, dependsOn)
+		if err != nil {
+			return "", err
+		}
+		data["outputs"] = outputs
+	}
+
+	resolved, err := common.ResolveTemplate(input, data)
 	if err != nil {
 		return "", fmt.Errorf("template resolution failed: %w", err)
 	}
 	return resolved, nil
 }
 
+// resolveQueryOutputs returns the responses of the queries a query depends on, by query name. Each output has
+// the content of the query's first response and the content of every response by target name.
+func resolveQueryOutputs(ctx context.Context, k8sClient client.Client, namespace string, dependsOn []string) (map[string]any, error) {
+	outputs := make(map[string]any, len(dependsOn))
+	for _, name := range dependsOn {
+		var query arkv1alpha1.Query
+		if err := k8sClient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &query); err != nil {
+			return nil, fmt.Errorf("failed to get dependency query %s: %w", name, err)
+		}
+		if query.Status.Phase != "done" {
+			return nil, fmt.Errorf("dependency query %s has not completed", name)
+		}
+
+		responses := make(map[string]any, len(query.Status.Responses))
+		content := ""
+		for i, response := range query.Status.Responses {
+			if i == 0 {
+				content = response.Content
+			}
+			responses[response.Target.Name] = response.Content
+		}
+		outputs[name] = map[string]any{
+			"content":   content,
+			"responses": responses,
+		}
+	}
+	return outputs, nil
+}
+
 func resolveQueryParameters(ctx context.Context, k8sClient client.Client, namespace string, parameters []arkv1alpha1.Parameter) (map[string]string, error) {
 	templateData := make(map[string]string)
 
 	for _, param := range parameters {
-		if param.Value != "" {
-			templateData[param.Name] = param.Value
-			continue
+		value, err := resolveQueryParameter(ctx, k8sClient, namespace, param)
+		if err != nil {
+			return nil, err
 		}
-
-		if param.ValueFrom == nil {
-			return nil, fmt.Errorf("parameter %s must specify either value or valueFrom", param.Name)
+		if param.Schema != nil {
+			if err := param.Schema.Validate(value); err != nil {
+				return nil, fmt.Errorf("parameter %s: %w", param.Name, err)
+			}
 		}
+		templateData[param.Name] = value
+	}
+
+	return templateData, nil
+}
 
-		value, err := resolveQueryValueFrom(ctx, k8sClient, namespace, param.ValueFrom)
+// ResolveParameterValues returns the parameters with their values resolved, so that they no longer depend on
+// the ConfigMaps, Secrets or defaults they were read from
+func ResolveParameterValues(ctx context.Context, k8sClient client.Client, namespace string, parameters []arkv1alpha1.Parameter) ([]arkv1alpha1.Parameter, error) {
+	resolved := make([]arkv1alpha1.Parameter, 0, len(parameters))
+	for _, param := range parameters {
+		value, err := resolveQueryParameter(ctx, k8sClient, namespace, param)
 		if err != nil {
-			return nil, fmt.Errorf("failed to resolve parameter %s: %w", param.Name, err)
+			return nil, err
 		}
-		templateData[param.Name] = value
+		resolved = append(resolved, arkv1alpha1.Parameter{Name: param.Name, Value: value, Schema: param.Schema})
 	}
+	return resolved, nil
+}
 
-	return templateData, nil
+func resolveQueryParameter(ctx context.Context, k8sClient client.Client, namespace string, param arkv1alpha1.Parameter) (string, error) {
+	if param.Value != "" {
+		return param.Value, nil
+	}
+
+	if param.ValueFrom == nil {
+		if param.Schema != nil && param.Schema.Default != "" {
+			return param.Schema.Default, nil
+		}
+		return "", fmt.Errorf("parameter %s must specify either value or valueFrom", param.Name)
+	}
+
+	value, err := resolveQueryValueFrom(ctx, k8sClient, namespace, param.ValueFrom)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve parameter %s: %w", param.Name, err)
+	}
+	return value, nil
 }
 
 func resolveQueryValueFrom(ctx context.Context, k8sClient client.Client, namespace string, valueFrom *arkv1alpha1.ValueFromSource) (string, error) {
@@ -114,8 +181,22 @@ func ResolveBodyTemplate(ctx context.Context, k8sClient client.Client, namespace
 	return resolved, nil
 }
 
-// GetQueryInputMessages returns a message array based on query type, handling both input and messages
+// GetQueryInputMessages returns a message array based on query type, handling both input and messages.
+// Query attachments are added to the last user message.
 func GetQueryInputMessages(ctx context.Context, query arkv1alpha1.Query, k8sClient client.Client) ([]Message, error) {
+	messages, err := getQueryInputMessages(ctx, query, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := LoadAttachments(ctx, k8sClient, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load attachments: %w", err)
+	}
+	return AttachToMessages(messages, attachments), nil
+}
+
+func getQueryInputMessages(ctx context.Context, query arkv1alpha1.Query, k8sClient client.Client) ([]Message, error) {
 	queryType := query.Spec.Type
 	if queryType == "" {
 		queryType = RoleUser // default type
@@ -129,7 +210,7 @@ func GetQueryInputMessages(ctx context.Context, query arkv1alpha1.Query, k8sClie
 		}
 
 		// Resolve input with template parameters and create a single user message
-		resolvedInput, err := ResolveQueryInput(ctx, k8sClient, query.Namespace, inputString, query.Spec.Parameters)
+		resolvedInput, err := ResolveQueryInput(ctx, k8sClient, query.Namespace, inputString, query.Spec.Parameters, query.Spec.DependsOn)
 		if err != nil {
 			return nil, fmt.Errorf("failed to resolve query input: %w", err)
 		}
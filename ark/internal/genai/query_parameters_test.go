@@ -281,6 +281,88 @@ func TestGetQueryInputMessages(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, messages, 0)
 	})
+	t.Run("user type with sprig functions and parameter schemas", func(t *testing.T) {
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		query := arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-query", Namespace: "test-ns"},
+			Spec: arkv1alpha1.QuerySpec{
+				Type: "user",
+				Parameters: []arkv1alpha1.Parameter{
+					{Name: "city", Value: "berlin"},
+					{Name: "days", Schema: &arkv1alpha1.ParameterSchema{Type: arkv1alpha1.ParameterTypeInteger, Default: "3"}},
+					{Name: "units", Value: "metric", Schema: &arkv1alpha1.ParameterSchema{Type: arkv1alpha1.ParameterTypeEnum, Enum: []string{"metric", "imperial"}}},
+				},
+			},
+		}
+		require.NoError(t, query.Spec.SetInputString("Weather in {{ .city | title }} for {{ add .days 1 }} days in {{ .units | upper }}"))
+
+		messages, err := GetQueryInputMessages(ctx, query, k8sClient)
+		require.NoError(t, err)
+		assert.Equal(t, "Weather in Berlin for 4 days in METRIC", messages[0].OfUser.Content.OfString.Value)
+
+		query.Spec.Parameters[2].Value = "kelvin"
+		_, err = GetQueryInputMessages(ctx, query, k8sClient)
+		assert.ErrorContains(t, err, "parameter units: value \"kelvin\" is not one of metric, imperial")
+	})
+
+	t.Run("user type without environment access", func(t *testing.T) {
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		query := arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-query", Namespace: "test-ns"},
+			Spec: arkv1alpha1.QuerySpec{
+				Type:       "user",
+				Parameters: []arkv1alpha1.Parameter{{Name: "name", Value: "HOME"}},
+			},
+		}
+		require.NoError(t, query.Spec.SetInputString("{{ env .name }}"))
+
+		_, err := GetQueryInputMessages(ctx, query, k8sClient)
+		assert.ErrorContains(t, err, "function \"env\" not defined")
+	})
+
+	t.Run("user type with dependency outputs", func(t *testing.T) {
+		research := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "research", Namespace: "test-ns"},
+			Status: arkv1alpha1.QueryStatus{
+				Phase: "done",
+				Responses: []arkv1alpha1.Response{
+					{Target: arkv1alpha1.QueryTarget{Type: "agent", Name: "researcher"}, Content: "Berlin has 3.7M people"},
+					{Target: arkv1alpha1.QueryTarget{Type: "agent", Name: "checker"}, Content: "Confirmed"},
+				},
+			},
+		}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(research).Build()
+
+		query := arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "test-ns"},
+			Spec:       arkv1alpha1.QuerySpec{Type: "user", DependsOn: []string{"research"}},
+		}
+		require.NoError(t, query.Spec.SetInputString("Summarize: {{.outputs.research.content}} ({{.outputs.research.responses.checker}})"))
+
+		messages, err := GetQueryInputMessages(ctx, query, k8sClient)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "Summarize: Berlin has 3.7M people (Confirmed)", messages[0].OfUser.Content.OfString.Value)
+	})
+
+	t.Run("user type with incomplete dependency", func(t *testing.T) {
+		running := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "test-ns"},
+			Status:     arkv1alpha1.QueryStatus{Phase: "running"},
+		}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(running).Build()
+
+		query := arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "test-ns"},
+			Spec:       arkv1alpha1.QuerySpec{Type: "user", DependsOn: []string{"running"}},
+		}
+		require.NoError(t, query.Spec.SetInputString("{{.outputs.running.content}}"))
+
+		_, err := GetQueryInputMessages(ctx, query, k8sClient)
+		assert.ErrorContains(t, err, "dependency query running has not completed")
+	})
 }
 
 func BenchmarkGetQueryInputMessages(b *testing.B) {
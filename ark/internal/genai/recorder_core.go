@@ -32,6 +32,20 @@ func NewModelRecorder(model *arkv1alpha1.Model, recorder record.EventRecorder) *
 	}
 }
 
+func NewEvaluationRecorder(evaluation *arkv1alpha1.Evaluation, recorder record.EventRecorder) *Recorder[*arkv1alpha1.Evaluation] {
+	return &Recorder[*arkv1alpha1.Evaluation]{
+		resource: evaluation,
+		recorder: recorder,
+	}
+}
+
+func NewSessionRecorder(session *arkv1alpha1.Session, recorder record.EventRecorder) *Recorder[*arkv1alpha1.Session] {
+	return &Recorder[*arkv1alpha1.Session]{
+		resource: session,
+		recorder: recorder,
+	}
+}
+
 func (r *Recorder[T]) EmitEvent(ctx context.Context, eventType, reason string, data EventData) {
 	log := logf.FromContext(ctx).WithValues("reason", reason)
 
@@ -0,0 +1,66 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/net/http/httpguts"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"mckinsey.com/ark/internal/common"
+)
+
+// RequestTagsConfigMapName is the optional per-namespace ConfigMap of the headers that tag the outbound
+// requests of the namespace's queries, for attribution by providers or firewall policies. Each key is a
+// header name and each value its value.
+const RequestTagsConfigMapName = "ark-config-request-tags"
+
+// reservedRequestHeaders cannot be set by request tags, since they are set by ark or its clients
+var reservedRequestHeaders = map[string]bool{
+	"Authorization":      true,
+	"Content-Length":     true,
+	"Content-Type":       true,
+	"Cookie":             true,
+	"Host":               true,
+	"User-Agent":         true,
+	common.QueryIDHeader: true,
+}
+
+// GetRequestTags reads the request tags of a namespace, which has none without the request tags ConfigMap
+func GetRequestTags(ctx context.Context, k8sClient client.Client, namespace string) (map[string]string, error) {
+	cm := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: RequestTagsConfigMapName, Namespace: namespace}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get request tags ConfigMap: %w", err)
+	}
+
+	tags := make(map[string]string, len(cm.Data))
+	for name, value := range cm.Data {
+		if !httpguts.ValidHeaderFieldName(name) || !httpguts.ValidHeaderFieldValue(value) {
+			return nil, fmt.Errorf("invalid request tag %q in request tags ConfigMap", name)
+		}
+		name = http.CanonicalHeaderKey(name)
+		if reservedRequestHeaders[name] {
+			return nil, fmt.Errorf("request tag %q in request tags ConfigMap is a reserved header", name)
+		}
+		tags[name] = value
+	}
+	return tags, nil
+}
+
+// WithNamespaceRequestTags tags the outbound requests made with the context with the request tags of the
+// namespace. Invalid request tags are reported and the requests are not tagged.
+func WithNamespaceRequestTags(ctx context.Context, k8sClient client.Client, namespace string) (context.Context, error) {
+	tags, err := GetRequestTags(ctx, k8sClient, namespace)
+	if err != nil {
+		return ctx, err
+	}
+	return common.WithRequestTags(ctx, tags), nil
+}
@@ -0,0 +1,63 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"mckinsey.com/ark/internal/common"
+)
+
+func TestGetRequestTags(t *testing.T) {
+	tagsConfigMap := func(namespace string, data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: RequestTagsConfigMapName, Namespace: namespace},
+			Data:       data,
+		}
+	}
+	k8sClient := fake.NewClientBuilder().WithObjects(
+		tagsConfigMap("tagged", map[string]string{"x-team": "payments", "X-Cost-Center": "1234"}),
+		tagsConfigMap("reserved", map[string]string{"authorization": "Bearer secret"}),
+		tagsConfigMap("invalid", map[string]string{"X Team": "payments"}),
+	).Build()
+
+	tests := []struct {
+		name        string
+		namespace   string
+		expected    map[string]string
+		expectError string
+	}{
+		{
+			name:      "tags are canonical headers",
+			namespace: "tagged",
+			expected:  map[string]string{"X-Team": "payments", "X-Cost-Center": "1234"},
+		},
+		{name: "no configmap", namespace: "default"},
+		{name: "reserved header", namespace: "reserved", expectError: "reserved header"},
+		{name: "invalid header", namespace: "invalid", expectError: "invalid request tag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags, err := GetRequestTags(context.Background(), k8sClient, tt.namespace)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, tags)
+
+			ctx, err := WithNamespaceRequestTags(context.Background(), k8sClient, tt.namespace)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, common.RequestTags(ctx))
+		})
+	}
+}
@@ -0,0 +1,224 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/telemetry"
+)
+
+// DefaultReviewMaxRounds is how many times an agent revises its answer when its review does not configure it
+const DefaultReviewMaxRounds = 1
+
+// reviewApproved is the reply of a reviewer that accepts the answer as it is
+const reviewApproved = "APPROVED"
+
+const defaultReviewPrompt = `You review the answer of an AI agent to a user's request. Check that the answer is correct, complete and addresses the request.
+If the answer needs no changes, reply with APPROVED and nothing else. Otherwise reply with concise, actionable feedback that the agent can use to revise its answer.`
+
+const reviseRequest = `A reviewer gave the following feedback on your answer. Revise your answer to address it, and reply with the complete revised answer.
+
+%s`
+
+// AgentReviewer critiques the answers of an agent with a reviewer model or agent
+type AgentReviewer struct {
+	config            arkv1alpha1.AgentReviewConfig
+	modelRef          *arkv1alpha1.AgentModelRef
+	client            client.Client
+	recorder          EventEmitter
+	telemetryProvider telemetry.Provider
+	// model is the reviewer model, loaded with the first review
+	model *Model
+}
+
+// reviewRound is one critique of an answer of the agent
+type reviewRound struct {
+	Answer   string `json:"answer"`
+	Feedback string `json:"feedback,omitempty"`
+	Approved bool   `json:"approved"`
+}
+
+// reviewRecord is published as an artifact, so that the quality of drafts and final answers can be tracked
+type reviewRecord struct {
+	Agent  string        `json:"agent"`
+	Rounds []reviewRound `json:"rounds"`
+	Final  string        `json:"final"`
+}
+
+// NewAgentReviewer returns the reviewer configured for the agent. Without a reviewer model or agent,
+// the answers are reviewed by the agent's own model.
+func NewAgentReviewer(k8sClient client.Client, agent *arkv1alpha1.Agent, recorder EventEmitter, telemetryProvider telemetry.Provider) *AgentReviewer {
+	config := *agent.Spec.Review
+	modelRef := config.ModelRef
+	if modelRef == nil && config.Agent == "" {
+		modelRef = agent.Spec.ModelRef
+	}
+	return &AgentReviewer{
+		config:            config,
+		modelRef:          modelRef,
+		client:            k8sClient,
+		recorder:          recorder,
+		telemetryProvider: telemetryProvider,
+	}
+}
+
+func (r *AgentReviewer) maxRounds() int {
+	if r.config.MaxRounds > 0 {
+		return int(r.config.MaxRounds)
+	}
+	return DefaultReviewMaxRounds
+}
+
+// review critiques the agent's answer and has the agent revise it until the reviewer approves it or the
+// agent has revised it maxRounds times. It returns the messages of the final answer.
+func (a *Agent) review(ctx context.Context, userInput Message, history, messages []Message, memory MemoryInterface, eventStream EventStreamInterface) ([]Message, error) {
+	tracker := NewOperationTracker(a.Recorder, ctx, "AgentReview", a.FullName(), map[string]string{
+		"agentName": a.FullName(),
+		"queryId":   getQueryID(ctx),
+		"sessionId": getSessionID(ctx),
+	})
+
+	request := ExtractUserMessageContent([]Message{userInput})
+	record := reviewRecord{Agent: a.Name}
+	for len(record.Rounds) < a.Reviewer.maxRounds() {
+		answer := lastAssistantContent(messages)
+		feedback, approved, err := a.Reviewer.critique(ctx, a.Namespace, request, answer)
+		if err != nil {
+			tracker.Fail(err)
+			return messages, fmt.Errorf("agent %s review failed: %w", a.FullName(), err)
+		}
+		record.Rounds = append(record.Rounds, reviewRound{Answer: answer, Feedback: feedback, Approved: approved})
+		if approved {
+			break
+		}
+
+		revisionHistory := append(append(slices.Clone(history), userInput), messages...)
+		revision := NewUserMessage(fmt.Sprintf(reviseRequest, feedback))
+		if messages, err = a.executeAnswer(ctx, revision, revisionHistory, memory, eventStream); err != nil {
+			tracker.Fail(err)
+			return messages, err
+		}
+	}
+
+	record.Final = lastAssistantContent(messages)
+	a.publishReview(ctx, record)
+
+	last := record.Rounds[len(record.Rounds)-1]
+	tracker.CompleteWithMetadata(record.Final, map[string]string{
+		"rounds":   fmt.Sprintf("%d", len(record.Rounds)),
+		"approved": fmt.Sprintf("%t", last.Approved),
+	})
+	return messages, nil
+}
+
+// publishReview adds the review to the artifacts of the query. A review outside of a query is not published.
+func (a *Agent) publishReview(ctx context.Context, record reviewRecord) {
+	collector := artifactCollectorFromContext(ctx)
+	if collector == nil {
+		return
+	}
+	data, err := json.Marshal(record)
+	if err == nil {
+		err = collector.Add(Artifact{Name: fmt.Sprintf("review-%s.json", a.Name), MediaType: "application/json", Data: data})
+	}
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to publish review", "agent", a.FullName())
+	}
+}
+
+// critique asks the reviewer for feedback on the answer, and whether it approves the answer as it is
+func (r *AgentReviewer) critique(ctx context.Context, namespace, request, answer string) (string, bool, error) {
+	prompt := GetBuiltinPrompt(ctx, r.client, namespace, PromptReview)
+	input := fmt.Sprintf("Request:\n%s\n\nAnswer:\n%s", request, answer)
+
+	var content string
+	var err error
+	if r.config.Agent != "" {
+		content, err = r.critiqueWithAgent(ctx, namespace, NewUserMessage(prompt+"\n\n"+input))
+	} else {
+		content, err = r.critiqueWithModel(ctx, namespace, []Message{NewSystemMessage(prompt), NewUserMessage(input)})
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", false, fmt.Errorf("the reviewer returned an empty review")
+	}
+	return content, strings.HasPrefix(strings.ToUpper(content), reviewApproved), nil
+}
+
+func (r *AgentReviewer) critiqueWithModel(ctx context.Context, namespace string, messages []Message) (string, error) {
+	if r.model == nil {
+		var modelSpec any = ""
+		if r.modelRef != nil {
+			modelSpec = r.modelRef
+		}
+		model, err := LoadModel(ctx, r.client, modelSpec, namespace, nil, r.telemetryProvider.ModelRecorder())
+		if err != nil {
+			return "", fmt.Errorf("failed to load reviewer model: %w", err)
+		}
+		r.model = model
+	}
+	model := r.model
+
+	llmTracker := NewOperationTracker(r.recorder, ctx, "LLMCall", model.Model, map[string]string{
+		"model": model.Model,
+	})
+	completion, err := model.ChatCompletion(ctx, messages, nil, 1)
+	if err != nil {
+		llmTracker.Fail(err)
+		return "", err
+	}
+	if completion == nil || len(completion.Choices) == 0 {
+		err := fmt.Errorf("reviewer model returned no completion choices")
+		llmTracker.Fail(err)
+		return "", err
+	}
+	llmTracker.CompleteWithTokens(TokenUsage{
+		PromptTokens:     completion.Usage.PromptTokens,
+		CompletionTokens: completion.Usage.CompletionTokens,
+		TotalTokens:      completion.Usage.TotalTokens,
+	})
+	return completion.Choices[0].Message.Content, nil
+}
+
+func (r *AgentReviewer) critiqueWithAgent(ctx context.Context, namespace string, input Message) (string, error) {
+	var crd arkv1alpha1.Agent
+	if err := r.client.Get(ctx, types.NamespacedName{Name: r.config.Agent, Namespace: namespace}, &crd); err != nil {
+		return "", fmt.Errorf("failed to get reviewer agent %s: %w", r.config.Agent, err)
+	}
+	// The reviewer's own answers are not reviewed, so that agents reviewing each other do not recurse
+	crd.Spec.Review = nil
+
+	reviewer, err := MakeAgent(ctx, r.client, &crd, r.recorder, r.telemetryProvider)
+	if err != nil {
+		return "", fmt.Errorf("failed to make reviewer agent %s: %w", r.config.Agent, err)
+	}
+	messages, err := reviewer.Execute(ctx, input, nil, NewNoopMemory(), nil)
+	if err != nil {
+		return "", fmt.Errorf("reviewer agent %s failed: %w", r.config.Agent, err)
+	}
+	return lastAssistantContent(messages), nil
+}
+
+// lastAssistantContent returns the text of the last assistant message, which is the answer of an agent
+func lastAssistantContent(messages []Message) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].OfAssistant != nil {
+			return messages[i].OfAssistant.Content.OfString.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+// scriptedProvider answers each chat completion with the next of its replies
+type scriptedProvider struct {
+	replies  []string
+	requests [][]Message
+}
+
+func (p *scriptedProvider) ChatCompletion(ctx context.Context, messages []Message, n int64, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	p.requests = append(p.requests, messages)
+	reply := p.replies[0]
+	p.replies = p.replies[1:]
+	return &openai.ChatCompletion{Choices: []openai.ChatCompletionChoice{{
+		Message: openai.ChatCompletionMessage{Role: "assistant", Content: reply},
+	}}}, nil
+}
+
+func (p *scriptedProvider) ChatCompletionStream(ctx context.Context, messages []Message, n int64, streamFunc func(*openai.ChatCompletionChunk) error, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	return p.ChatCompletion(ctx, messages, n, tools...)
+}
+
+func (p *scriptedProvider) SetOutputSchema(schema *runtime.RawExtension, schemaName string) {}
+
+func scriptedModel(replies ...string) (*Model, *scriptedProvider) {
+	provider := &scriptedProvider{replies: replies}
+	return &Model{Model: "scripted", Provider: provider, ModelRecorder: noop.NewModelRecorder()}, provider
+}
+
+func reviewedAgent(maxRounds int32, answers []string, reviews []string) (*Agent, *scriptedProvider) {
+	model, _ := scriptedModel(answers...)
+	reviewerModel, reviewer := scriptedModel(reviews...)
+	return &Agent{
+		Name:          "writer",
+		Namespace:     "default",
+		Prompt:        "You write summaries",
+		Model:         model,
+		Recorder:      &mockEventRecorder{},
+		AgentRecorder: noop.NewAgentRecorder(),
+		Reviewer: &AgentReviewer{
+			config:   arkv1alpha1.AgentReviewConfig{MaxRounds: maxRounds},
+			recorder: &mockEventRecorder{},
+			model:    reviewerModel,
+		},
+	}, reviewer
+}
+
+func TestAgentReview(t *testing.T) {
+	t.Run("revises the answer until the reviewer approves it", func(t *testing.T) {
+		agent, reviewer := reviewedAgent(3, []string{"Draft.", "Revised."}, []string{"Cite the sources.", "APPROVED"})
+		collector := NewArtifactCollector(0)
+		ctx := WithArtifactCollector(context.Background(), collector)
+
+		messages, err := agent.Execute(ctx, NewUserMessage("Summarize the report"), nil, NewNoopMemory(), nil)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "Revised.", messages[0].OfAssistant.Content.OfString.Value)
+		require.Len(t, reviewer.requests, 2)
+		assert.Contains(t, reviewer.requests[1][1].OfUser.Content.OfString.Value, "Answer:\nRevised.")
+
+		artifacts := collector.Artifacts()
+		require.Len(t, artifacts, 1)
+		assert.Equal(t, "review-writer.json", artifacts[0].Name)
+		var record reviewRecord
+		require.NoError(t, json.Unmarshal(artifacts[0].Data, &record))
+		assert.Equal(t, reviewRecord{
+			Agent: "writer",
+			Rounds: []reviewRound{
+				{Answer: "Draft.", Feedback: "Cite the sources."},
+				{Answer: "Revised.", Feedback: "APPROVED", Approved: true},
+			},
+			Final: "Revised.",
+		}, record)
+	})
+
+	t.Run("stops after the maximum number of revisions", func(t *testing.T) {
+		agent, reviewer := reviewedAgent(1, []string{"Draft.", "Revised."}, []string{"Cite the sources."})
+
+		messages, err := agent.Execute(context.Background(), NewUserMessage("Summarize the report"), nil, NewNoopMemory(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Revised.", lastAssistantContent(messages))
+		assert.Len(t, reviewer.requests, 1)
+	})
+}
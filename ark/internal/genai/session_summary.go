@@ -0,0 +1,55 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"fmt"
+	"strings"
+)
+
+const sessionSummaryPrompt = `You summarize conversations between users and AI agents.
+Write a concise summary of the conversation, followed by a list of action items with their owners when they are known.
+Only use information from the conversation. If there are no action items, say so.`
+
+// DefaultSessionSummaryInstruction is used when a session query has no input
+const DefaultSessionSummaryInstruction = "Summarize the session and list the action items."
+
+// BuildSessionSummaryMessages returns the messages that ask a model to summarize a session transcript
+// following the instruction
+func BuildSessionSummaryMessages(sessionID string, transcript []Message, instruction string) ([]Message, error) {
+	history := formatTranscript(transcript)
+	if history == "" {
+		return nil, fmt.Errorf("session %s has no messages to summarize", sessionID)
+	}
+	if strings.TrimSpace(instruction) == "" {
+		instruction = DefaultSessionSummaryInstruction
+	}
+
+	content := fmt.Sprintf("Conversation from session %s:\n\n%s\n\n%s", sessionID, history, instruction)
+	return []Message{
+		NewSystemMessage(sessionSummaryPrompt),
+		NewUserMessage(content),
+	}, nil
+}
+
+// formatTranscript renders the user and assistant turns of a session, leaving out tool calls and results
+func formatTranscript(messages []Message) string {
+	var turns []string
+	for _, msg := range messages {
+		switch {
+		case msg.OfUser != nil:
+			if text := strings.TrimSpace(userMessageText(msg.OfUser.Content)); text != "" {
+				turns = append(turns, "user: "+text)
+			}
+		case msg.OfAssistant != nil:
+			speaker := "assistant"
+			if name := msg.OfAssistant.Name.Value; name != "" {
+				speaker = name
+			}
+			if text := strings.TrimSpace(msg.OfAssistant.Content.OfString.Value); text != "" {
+				turns = append(turns, speaker+": "+text)
+			}
+		}
+	}
+	return strings.Join(turns, "\n\n")
+}
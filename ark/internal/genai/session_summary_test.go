@@ -0,0 +1,38 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSessionSummaryMessages(t *testing.T) {
+	transcript := []Message{
+		NewUserMessage("Can you book the venue for Friday?"),
+		NewAssistantMessage("The venue is booked. Someone still needs to send the invites."),
+	}
+
+	messages, err := BuildSessionSummaryMessages("session-1", transcript, "")
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	require.NotNil(t, messages[0].OfSystem)
+
+	content := ExtractUserMessageContent(messages)
+	assert.Contains(t, content, "session-1")
+	assert.Contains(t, content, "Can you book the venue for Friday?")
+	assert.Contains(t, content, "send the invites")
+	assert.Contains(t, content, DefaultSessionSummaryInstruction)
+
+	messages, err = BuildSessionSummaryMessages("session-1", transcript, "Only list the action items.")
+	require.NoError(t, err)
+	assert.Contains(t, ExtractUserMessageContent(messages), "Only list the action items.")
+	assert.NotContains(t, ExtractUserMessageContent(messages), DefaultSessionSummaryInstruction)
+}
+
+func TestBuildSessionSummaryMessagesEmptySession(t *testing.T) {
+	_, err := BuildSessionSummaryMessages("session-1", nil, "")
+	assert.ErrorContains(t, err, "session session-1 has no messages")
+}
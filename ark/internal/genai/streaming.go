@@ -5,8 +5,11 @@ package genai
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
 	"net/url"
@@ -33,6 +36,24 @@ type StreamMetadata struct {
 	Agent       string            `json:"agent,omitempty"`
 	Model       string            `json:"model,omitempty"`
 	Annotations map[string]string `json:"annotations,omitempty"`
+	ToolCalls   []ToolCallDelta   `json:"toolCalls,omitempty"`
+
+	// Sequence numbers chunks across the whole query stream from 1, TargetSequence within the chunk's target.
+	Sequence       int64 `json:"sequence,omitempty"`
+	TargetSequence int64 `json:"targetSequence,omitempty"`
+	// TotalChunks and Checksum are set on the completion marker that ends a sequenced stream
+	TotalChunks *int64 `json:"totalChunks,omitempty"`
+	Checksum    string `json:"checksum,omitempty"`
+}
+
+// ToolCallDelta is a streamed fragment of a function call together with what is known of the call so far.
+// Providers send the call ID and function name only on the first fragment, so both are carried forward here.
+type ToolCallDelta struct {
+	Index          int64  `json:"index"`
+	ID             string `json:"id,omitempty"`
+	Name           string `json:"name,omitempty"`
+	ArgumentsDelta string `json:"argumentsDelta,omitempty"`
+	Arguments      string `json:"arguments,omitempty"`
 }
 
 // ChunkWithMetadata wraps an OpenAI chunk with ARK metadata
@@ -55,6 +76,17 @@ type ErrorWithMetadata struct {
 	Ark *StreamMetadata `json:"ark,omitempty"`
 }
 
+// streamMetadataCarrier is implemented by chunks that carry ARK metadata
+type streamMetadataCarrier interface {
+	streamMetadata() *StreamMetadata
+}
+
+func (c ChunkWithMetadata) streamMetadata() *StreamMetadata { return c.Ark }
+
+func (e ErrorWithMetadata) streamMetadata() *StreamMetadata { return e.Ark }
+
+func (m StreamCompletionMarker) streamMetadata() *StreamMetadata { return m.Ark }
+
 func buildMetadata(ctx context.Context, modelName string) *StreamMetadata {
 	// Build metadata from context
 	metadata := &StreamMetadata{}
@@ -129,6 +161,89 @@ func WrapChunkWithMetadata(ctx context.Context, chunk *openai.ChatCompletionChun
 	}
 }
 
+// toolCallDeltaTracker accumulates the function call deltas of a streamed completion by tool call index
+type toolCallDeltaTracker struct {
+	calls map[int64]*ToolCallDelta
+}
+
+func newToolCallDeltaTracker() *toolCallDeltaTracker {
+	return &toolCallDeltaTracker{calls: make(map[int64]*ToolCallDelta)}
+}
+
+// track records the tool call deltas of a chunk and returns them with the call ID, name and arguments so far.
+// A delta carrying an ID opens a call; later deltas for the same index only append argument fragments.
+func (t *toolCallDeltaTracker) track(chunk *openai.ChatCompletionChunk) []ToolCallDelta {
+	var deltas []ToolCallDelta
+	for _, choice := range chunk.Choices {
+		for _, toolCall := range choice.Delta.ToolCalls {
+			call, exists := t.calls[toolCall.Index]
+			if !exists || toolCall.ID != "" {
+				call = &ToolCallDelta{Index: toolCall.Index, ID: toolCall.ID}
+				t.calls[toolCall.Index] = call
+			}
+			if toolCall.Function.Name != "" {
+				call.Name = toolCall.Function.Name
+			}
+			call.Arguments += toolCall.Function.Arguments
+
+			delta := *call
+			delta.ArgumentsDelta = toolCall.Function.Arguments
+			deltas = append(deltas, delta)
+		}
+	}
+	return deltas
+}
+
+// Tool call event types streamed while an agent executes tools
+const (
+	ToolCallEventStarted   = "tool_call.started"
+	ToolCallEventArguments = "tool_call.arguments"
+	ToolCallEventResult    = "tool_call.result"
+)
+
+// ToolCallEventObject identifies tool call events among the chunks of an event stream
+const ToolCallEventObject = "ark.tool_call"
+
+// ToolCallEventData describes the tool call an event refers to
+type ToolCallEventData struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments,omitempty"`
+	Result    string `json:"result,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ToolCallEventWithMetadata is a tool call event wrapped with ARK metadata
+type ToolCallEventWithMetadata struct {
+	Object   string            `json:"object"`
+	Type     string            `json:"type"`
+	ToolCall ToolCallEventData `json:"tool_call"`
+	Ark      *StreamMetadata   `json:"ark,omitempty"`
+}
+
+func (e ToolCallEventWithMetadata) streamMetadata() *StreamMetadata { return e.Ark }
+
+// WrapToolCallEventWithMetadata adds ARK metadata to a tool call event
+func WrapToolCallEventWithMetadata(ctx context.Context, eventType string, data ToolCallEventData) interface{} {
+	return ToolCallEventWithMetadata{
+		Object:   ToolCallEventObject,
+		Type:     eventType,
+		ToolCall: data,
+		Ark:      buildMetadata(ctx, ""),
+	}
+}
+
+// StreamToolCallEvent streams a tool call event to the event stream if available.
+// Failures are logged rather than returned so they do not interrupt tool execution.
+func StreamToolCallEvent(ctx context.Context, eventStream EventStreamInterface, eventType string, data ToolCallEventData) {
+	if eventStream == nil {
+		return
+	}
+	if err := eventStream.StreamChunk(ctx, WrapToolCallEventWithMetadata(ctx, eventType, data)); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to send tool call event to event stream", "type", eventType, "tool", data.Name)
+	}
+}
+
 // EventStreamInterface defines streaming capabilities for real-time event delivery
 type EventStreamInterface interface {
 	// StreamChunk sends a chunk of data to the event stream
@@ -141,6 +256,35 @@ type EventStreamInterface interface {
 	Close() error
 }
 
+// TargetCompletionNotifier is implemented by event streams that end the chunks of a target before the query
+// completes, so that clients following a single target of a multi-target query are not kept waiting
+type TargetCompletionNotifier interface {
+	// NotifyTargetCompletion signals that the target streams no more chunks
+	NotifyTargetCompletion(ctx context.Context, target string) error
+}
+
+// NotifyTargetCompletion signals the end of a target's chunks if the event stream supports it.
+// Failures are logged rather than returned since the query itself is not affected.
+func NotifyTargetCompletion(ctx context.Context, eventStream EventStreamInterface, target string) {
+	notifier, ok := eventStream.(TargetCompletionNotifier)
+	if !ok {
+		return
+	}
+	if err := notifier.NotifyTargetCompletion(ctx, target); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to complete target stream", "target", target)
+	}
+}
+
+// TargetStreamName is the name of the stream that carries only the chunks of one target of a query, such as
+// "my-query/agent/researcher". The streaming service stores it like any other query stream, so clients read it
+// at /stream/{name} with the name path-escaped.
+func TargetStreamName(queryName, target string) string {
+	return queryName + "/" + target
+}
+
+// StreamingConfigMapName is the ConfigMap that configures streaming in a namespace
+const StreamingConfigMapName = "ark-config-streaming"
+
 // StreamingConfig represents the resolved streaming configuration
 type StreamingConfig struct {
 	Enabled    bool
@@ -156,7 +300,7 @@ func GetStreamingConfig(ctx context.Context, k8sClient client.Client, namespace
 	// Try to get streaming ConfigMap
 	cm := &corev1.ConfigMap{}
 	err := k8sClient.Get(ctx, client.ObjectKey{
-		Name:      "ark-config-streaming",
+		Name:      StreamingConfigMapName,
 		Namespace: namespace,
 	}, cm)
 	if err != nil {
@@ -205,7 +349,8 @@ func GetStreamingConfig(ctx context.Context, k8sClient client.Client, namespace
 // NewEventStreamForQuery creates an EventStreamInterface if streaming is configured and enabled
 // Returns (nil, nil) if streaming is not configured or disabled
 // Returns (nil, error) if configuration is invalid or service cannot be resolved
-func NewEventStreamForQuery(ctx context.Context, k8sClient client.Client, namespace, sessionId, queryName string) (EventStreamInterface, error) {
+// With targetStreams, the chunks of each target are also streamed to the target's own stream, see TargetStreamName.
+func NewEventStreamForQuery(ctx context.Context, k8sClient client.Client, namespace, sessionId, queryName string, targetStreams bool) (EventStreamInterface, error) {
 	// Get streaming configuration
 	config, err := GetStreamingConfig(ctx, k8sClient, namespace)
 	if err != nil {
@@ -224,12 +369,130 @@ func NewEventStreamForQuery(ctx context.Context, k8sClient client.Client, namesp
 	}
 
 	// Create HTTP event stream client
+	httpStream := NewHTTPEventStream(baseURL, sessionId, queryName, common.NewHTTPClientWithLogging(ctx, common.HTTPClientStreaming))
+	httpStream.targetStreams = targetStreams
+	return NewSequencedEventStream(httpStream), nil
+}
+
+// StreamCompletedObject identifies the completion marker that ends a sequenced stream
+const StreamCompletedObject = "ark.stream.completed"
+
+// TargetStreamCompletedObject identifies the marker that ends the chunks of one target of a sequenced stream
+const TargetStreamCompletedObject = "ark.stream.target.completed"
+
+// StreamCompletionMarker is the last chunk of a sequenced stream. Its metadata carries the number of chunks
+// streamed before it and their checksum, so consumers can verify they received the whole stream.
+type StreamCompletionMarker struct {
+	Object string          `json:"object"`
+	Ark    *StreamMetadata `json:"ark"`
+}
+
+// SequencedEventStream numbers the chunks written to an event stream and ends it with a completion marker.
+// The checksum is the hex SHA-256 of the JSON encoding of every numbered chunk, each followed by a newline,
+// which is the NDJSON the stream sends to the streaming service.
+type SequencedEventStream struct {
+	EventStreamInterface
+
+	mutex           sync.Mutex
+	sequence        int64
+	targetSequences map[string]int64
+	checksum        hash.Hash
+}
+
+// NewSequencedEventStream wraps an event stream so that its chunks are numbered
+func NewSequencedEventStream(stream EventStreamInterface) *SequencedEventStream {
+	return &SequencedEventStream{
+		EventStreamInterface: stream,
+		targetSequences:      make(map[string]int64),
+		checksum:             sha256.New(),
+	}
+}
+
+// StreamChunk numbers the chunk and sends it to the wrapped stream. Chunks are numbered and sent under the
+// same lock so that sequence numbers follow the order in which chunks reach the stream.
+func (s *SequencedEventStream) StreamChunk(ctx context.Context, chunk interface{}) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sequence := s.sequence + 1
+	if carrier, ok := chunk.(streamMetadataCarrier); ok {
+		if metadata := carrier.streamMetadata(); metadata != nil {
+			metadata.Sequence = sequence
+			metadata.TargetSequence = s.targetSequences[metadata.Target] + 1
+			s.targetSequences[metadata.Target] = metadata.TargetSequence
+		}
+	}
+
+	data, err := json.Marshal(chunk)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chunk: %w", err)
+	}
+	if err := s.EventStreamInterface.StreamChunk(ctx, chunk); err != nil {
+		return err
+	}
+
+	s.sequence = sequence
+	s.checksum.Write(append(data, '\n'))
+	return nil
+}
+
+// NotifyTargetCompletion sends the target completion marker, whose metadata carries the number of chunks
+// streamed for the target, and then notifies the wrapped stream
+func (s *SequencedEventStream) NotifyTargetCompletion(ctx context.Context, target string) error {
+	s.mutex.Lock()
+	total := s.targetSequences[target]
+	marker := StreamCompletionMarker{
+		Object: TargetStreamCompletedObject,
+		Ark: &StreamMetadata{
+			Query:       getQueryID(ctx),
+			Session:     getSessionID(ctx),
+			Target:      target,
+			TotalChunks: &total,
+		},
+	}
+	err := s.EventStreamInterface.StreamChunk(ctx, marker)
+	s.mutex.Unlock()
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to send target completion marker", "target", target, "totalChunks", total)
+	}
+
+	if notifier, ok := s.EventStreamInterface.(TargetCompletionNotifier); ok {
+		return notifier.NotifyTargetCompletion(ctx, target)
+	}
+	return nil
+}
+
+// NotifyCompletion sends the completion marker and then notifies the wrapped stream
+func (s *SequencedEventStream) NotifyCompletion(ctx context.Context) error {
+	s.mutex.Lock()
+	total := s.sequence
+	marker := StreamCompletionMarker{
+		Object: StreamCompletedObject,
+		Ark: &StreamMetadata{
+			Query:       getQueryID(ctx),
+			Session:     getSessionID(ctx),
+			TotalChunks: &total,
+			Checksum:    hex.EncodeToString(s.checksum.Sum(nil)),
+		},
+	}
+	err := s.EventStreamInterface.StreamChunk(ctx, marker)
+	s.mutex.Unlock()
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to send stream completion marker", "totalChunks", total)
+	}
+
+	return s.EventStreamInterface.NotifyCompletion(ctx)
+}
+
+// NewHTTPEventStream creates an event stream that posts chunks for a query to the streaming service at baseURL
+func NewHTTPEventStream(baseURL, sessionId, queryName string, httpClient *http.Client) *HTTPEventStream {
 	return &HTTPEventStream{
 		baseURL:   baseURL,
 		sessionId: sessionId,
 		queryName: queryName,
-		client:    common.NewHTTPClientWithLogging(ctx),
-	}, nil
+		client:    httpClient,
+		streams:   make(map[string]io.WriteCloser),
+	}
 }
 
 // HTTPEventStream implements EventStreamInterface for HTTP-based streaming
@@ -239,50 +502,72 @@ type HTTPEventStream struct {
 	queryName string
 	client    *http.Client
 
-	// For persistent streaming connection
-	streamWriter io.WriteCloser
-	streamMutex  sync.Mutex
+	// targetStreams also sends each chunk that belongs to a target to the target's own stream
+	targetStreams bool
+
+	// Persistent streaming connections, by stream name
+	streams     map[string]io.WriteCloser
+	streamMutex sync.Mutex
 }
 
-// StreamChunk sends a chunk to the event stream
+// StreamChunk sends a chunk to the event stream, and to the stream of its target when target streams are enabled
 func (h *HTTPEventStream) StreamChunk(ctx context.Context, chunk interface{}) error {
 	h.streamMutex.Lock()
 	defer h.streamMutex.Unlock()
 
-	// If we don't have an active stream, start one
-	if h.streamWriter == nil {
-		if err := h.startStream(ctx); err != nil {
-			return fmt.Errorf("failed to start stream: %w", err)
-		}
-	}
-
-	// Write the chunk to the stream
 	data, err := json.Marshal(chunk)
 	if err != nil {
 		return fmt.Errorf("failed to marshal chunk: %w", err)
 	}
 
+	if err := h.write(ctx, h.queryName, data); err != nil {
+		return err
+	}
+	if target := chunkTarget(chunk); h.targetStreams && target != "" {
+		return h.write(ctx, TargetStreamName(h.queryName, target), data)
+	}
+	return nil
+}
+
+func chunkTarget(chunk interface{}) string {
+	if carrier, ok := chunk.(streamMetadataCarrier); ok {
+		if metadata := carrier.streamMetadata(); metadata != nil {
+			return metadata.Target
+		}
+	}
+	return ""
+}
+
+// write sends an encoded chunk to the named stream, starting the stream on its first chunk
+func (h *HTTPEventStream) write(ctx context.Context, name string, data []byte) error {
+	writer, ok := h.streams[name]
+	if !ok {
+		var err error
+		if writer, err = h.startStream(ctx, name); err != nil {
+			return fmt.Errorf("failed to start stream: %w", err)
+		}
+		h.streams[name] = writer
+	}
+
 	// Write with newline delimiter for streaming
-	if _, err := h.streamWriter.Write(append(data, '\n')); err != nil {
+	if _, err := writer.Write(append(data, '\n')); err != nil {
 		// Stream broken, clear it
-		_ = h.streamWriter.Close() // Ignore error - we're already in error state
-		h.streamWriter = nil
+		_ = writer.Close() // Ignore error - we're already in error state
+		delete(h.streams, name)
 		return fmt.Errorf("failed to write chunk to stream: %w", err)
 	}
-
 	return nil
 }
 
-// startStream initializes a persistent streaming connection
-func (h *HTTPEventStream) startStream(ctx context.Context) error {
+// startStream initializes a persistent streaming connection for the named stream
+func (h *HTTPEventStream) startStream(ctx context.Context, name string) (io.WriteCloser, error) {
 	log := logf.FromContext(ctx)
 
 	// Create a pipe for streaming
 	pipeReader, pipeWriter := io.Pipe()
-	h.streamWriter = pipeWriter
 
 	// Construct the streaming URL with proper escaping
-	streamURL := fmt.Sprintf("%s/stream/%s", h.baseURL, url.QueryEscape(h.queryName))
+	streamURL := fmt.Sprintf("%s/stream/%s", h.baseURL, url.QueryEscape(name))
 
 	// CRITICAL: Use context.Background() instead of the query context for the streaming HTTP request.
 	// This allows the HTTP POST to complete gracefully when NotifyCompletion is called.
@@ -291,7 +576,7 @@ func (h *HTTPEventStream) startStream(ctx context.Context) error {
 	// Using the query context would cause "context canceled" errors when the query completes.
 	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, streamURL, pipeReader)
 	if err != nil {
-		return fmt.Errorf("failed to create streaming request: %w", err)
+		return nil, fmt.Errorf("failed to create streaming request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/x-ndjson")
 	req.Header.Set("Transfer-Encoding", "chunked")
@@ -324,24 +609,61 @@ func (h *HTTPEventStream) startStream(ctx context.Context) error {
 		}
 	}()
 
-	return nil
+	return pipeWriter, nil
+}
+
+// NotifyTargetCompletion closes the stream of the target and signals that it has completed
+func (h *HTTPEventStream) NotifyTargetCompletion(ctx context.Context, target string) error {
+	if !h.targetStreams {
+		return nil
+	}
+
+	h.streamMutex.Lock()
+	defer h.streamMutex.Unlock()
+
+	name := TargetStreamName(h.queryName, target)
+	if _, ok := h.streams[name]; !ok {
+		// Nothing was streamed for the target, or it has already completed
+		return nil
+	}
+	h.closeStream(ctx, name)
+	return h.complete(ctx, name)
 }
 
-// NotifyCompletion signals that the stream has completed
+// NotifyCompletion closes the streams of the query and its targets and signals that they have completed
 func (h *HTTPEventStream) NotifyCompletion(ctx context.Context) error {
 	h.streamMutex.Lock()
 	defer h.streamMutex.Unlock()
 
-	// Close the streaming connection if open
-	if h.streamWriter != nil {
-		if err := h.streamWriter.Close(); err != nil {
-			logf.FromContext(ctx).Error(err, "failed to close stream writer on completion")
+	for name := range h.streams {
+		if name == h.queryName {
+			continue
 		}
-		h.streamWriter = nil
+		h.closeStream(ctx, name)
+		if err := h.complete(ctx, name); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to complete target stream", "stream", name)
+		}
+	}
+
+	h.closeStream(ctx, h.queryName)
+	return h.complete(ctx, h.queryName)
+}
+
+// closeStream closes the streaming connection of the named stream if it is open
+func (h *HTTPEventStream) closeStream(ctx context.Context, name string) {
+	writer, ok := h.streams[name]
+	if !ok {
+		return
+	}
+	if err := writer.Close(); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to close stream writer on completion", "stream", name)
 	}
+	delete(h.streams, name)
+}
 
-	// Send completion signal
-	completeURL := fmt.Sprintf("%s/stream/%s/complete", h.baseURL, url.QueryEscape(h.queryName))
+// complete sends the completion signal of the named stream
+func (h *HTTPEventStream) complete(ctx context.Context, name string) error {
+	completeURL := fmt.Sprintf("%s/stream/%s/complete", h.baseURL, url.QueryEscape(name))
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, completeURL, bytes.NewReader([]byte("{}")))
 	if err != nil {
 		return fmt.Errorf("failed to create completion request: %w", err)
@@ -349,7 +671,7 @@ func (h *HTTPEventStream) NotifyCompletion(ctx context.Context) error {
 	req.Header.Set("Content-Type", "application/json")
 
 	// Use a client with timeout for completion
-	completeClient := &http.Client{Timeout: 10 * time.Second}
+	completeClient := &http.Client{Timeout: 10 * time.Second, Transport: common.NewInstrumentedTransport(common.HTTPClientStreaming, nil)}
 	resp, err := completeClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send completion: %w", err)
@@ -370,10 +692,12 @@ func (h *HTTPEventStream) Close() error {
 	h.streamMutex.Lock()
 	defer h.streamMutex.Unlock()
 
-	if h.streamWriter != nil {
-		err := h.streamWriter.Close()
-		h.streamWriter = nil
-		return err
+	var closeErr error
+	for name, writer := range h.streams {
+		if err := writer.Close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+		delete(h.streams, name)
 	}
-	return nil
+	return closeErr
 }
@@ -4,11 +4,23 @@ package genai
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/openai/openai-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/telemetry/noop"
 )
 
 func TestWrapChunkWithMetadata(t *testing.T) {
@@ -147,3 +159,190 @@ func TestStreamMetadata_Empty(t *testing.T) {
 	nonEmptyMeta := StreamMetadata{Query: "test"}
 	assert.Equal(t, "test", nonEmptyMeta.Query)
 }
+
+type capturingEventStream struct {
+	chunks []interface{}
+}
+
+func (s *capturingEventStream) StreamChunk(ctx context.Context, chunk interface{}) error {
+	s.chunks = append(s.chunks, chunk)
+	return nil
+}
+
+func (s *capturingEventStream) NotifyCompletion(ctx context.Context) error { return nil }
+
+func (s *capturingEventStream) Close() error { return nil }
+
+func TestExecuteToolWithStream(t *testing.T) {
+	registry := NewToolRegistry(nil, noop.NewToolRecorder())
+	registry.RegisterTool(GetNoopTool(), &NoopExecutor{})
+	ctx := WithExecutionMetadata(context.Background(), map[string]interface{}{"agent": "weather-agent"})
+	stream := &capturingEventStream{}
+
+	call := ToolCall{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{Name: "noop", Arguments: `{"message":"hi"}`}}
+	_, err := registry.ExecuteToolWithStream(ctx, call, nil, stream)
+	require.NoError(t, err)
+
+	require.Len(t, stream.chunks, 3)
+	var types []string
+	for _, chunk := range stream.chunks {
+		event, ok := chunk.(ToolCallEventWithMetadata)
+		require.True(t, ok)
+		assert.Equal(t, ToolCallEventObject, event.Object)
+		assert.Equal(t, "call-1", event.ToolCall.ID)
+		assert.Equal(t, "weather-agent", event.Ark.Agent)
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []string{ToolCallEventStarted, ToolCallEventArguments, ToolCallEventResult}, types)
+	assert.Equal(t, `{"message":"hi"}`, stream.chunks[1].(ToolCallEventWithMetadata).ToolCall.Arguments)
+	assert.Contains(t, stream.chunks[2].(ToolCallEventWithMetadata).ToolCall.Result, "hi")
+
+	stream.chunks = nil
+	missing := ToolCall{ID: "call-2", Function: openai.ChatCompletionMessageToolCallFunction{Name: "missing"}}
+	_, err = registry.ExecuteToolWithStream(ctx, missing, nil, stream)
+	require.Error(t, err)
+	require.Len(t, stream.chunks, 3)
+	assert.Equal(t, "tool missing not found", stream.chunks[2].(ToolCallEventWithMetadata).ToolCall.Error)
+}
+
+func TestToolCallDeltaTracker(t *testing.T) {
+	chunkWith := func(toolCalls ...openai.ChatCompletionChunkChoiceDeltaToolCall) *openai.ChatCompletionChunk {
+		return &openai.ChatCompletionChunk{Choices: []openai.ChatCompletionChunkChoice{{Delta: openai.ChatCompletionChunkChoiceDelta{ToolCalls: toolCalls}}}}
+	}
+	tracker := newToolCallDeltaTracker()
+
+	deltas := tracker.track(chunkWith(openai.ChatCompletionChunkChoiceDeltaToolCall{
+		Index: 0, ID: "call-1", Function: openai.ChatCompletionChunkChoiceDeltaToolCallFunction{Name: "search", Arguments: `{"q":`},
+	}))
+	require.Len(t, deltas, 1)
+	assert.Equal(t, ToolCallDelta{Index: 0, ID: "call-1", Name: "search", ArgumentsDelta: `{"q":`, Arguments: `{"q":`}, deltas[0])
+
+	deltas = tracker.track(chunkWith(
+		openai.ChatCompletionChunkChoiceDeltaToolCall{Index: 0, Function: openai.ChatCompletionChunkChoiceDeltaToolCallFunction{Arguments: `"ark"}`}},
+		openai.ChatCompletionChunkChoiceDeltaToolCall{Index: 1, ID: "call-2", Function: openai.ChatCompletionChunkChoiceDeltaToolCallFunction{Name: "fetch-url"}},
+	))
+	require.Len(t, deltas, 2)
+	assert.Equal(t, ToolCallDelta{Index: 0, ID: "call-1", Name: "search", ArgumentsDelta: `"ark"}`, Arguments: `{"q":"ark"}`}, deltas[0])
+	assert.Equal(t, ToolCallDelta{Index: 1, ID: "call-2", Name: "fetch-url"}, deltas[1])
+
+	assert.Empty(t, tracker.track(&openai.ChatCompletionChunk{Choices: []openai.ChatCompletionChunkChoice{{Delta: openai.ChatCompletionChunkChoiceDelta{Content: "hello"}}}}))
+}
+
+func TestSequencedEventStream(t *testing.T) {
+	inner := &capturingEventStream{}
+	stream := NewSequencedEventStream(inner)
+	ctx := WithQueryContext(context.Background(), "query-123", "session-456", "test-query")
+	first := WithExecutionMetadata(ctx, map[string]interface{}{"target": "agent/first"})
+	second := WithExecutionMetadata(ctx, map[string]interface{}{"target": "agent/second"})
+
+	require.NoError(t, stream.StreamChunk(first, WrapChunkWithMetadata(first, &openai.ChatCompletionChunk{ID: "a"}, "")))
+	require.NoError(t, stream.StreamChunk(second, WrapChunkWithMetadata(second, &openai.ChatCompletionChunk{ID: "b"}, "")))
+	StreamToolCallEvent(first, stream, ToolCallEventStarted, ToolCallEventData{ID: "call-1", Name: "noop"})
+	require.NoError(t, stream.NotifyCompletion(ctx))
+
+	require.Len(t, inner.chunks, 4)
+	assert.Equal(t, int64(1), inner.chunks[0].(ChunkWithMetadata).Ark.Sequence)
+	assert.Equal(t, int64(1), inner.chunks[0].(ChunkWithMetadata).Ark.TargetSequence)
+	assert.Equal(t, int64(2), inner.chunks[1].(ChunkWithMetadata).Ark.Sequence)
+	assert.Equal(t, int64(1), inner.chunks[1].(ChunkWithMetadata).Ark.TargetSequence)
+	assert.Equal(t, int64(3), inner.chunks[2].(ToolCallEventWithMetadata).Ark.Sequence)
+	assert.Equal(t, int64(2), inner.chunks[2].(ToolCallEventWithMetadata).Ark.TargetSequence)
+
+	marker, ok := inner.chunks[3].(StreamCompletionMarker)
+	require.True(t, ok)
+	assert.Equal(t, StreamCompletedObject, marker.Object)
+	assert.Equal(t, "query-123", marker.Ark.Query)
+	require.NotNil(t, marker.Ark.TotalChunks)
+	assert.Equal(t, int64(3), *marker.Ark.TotalChunks)
+
+	checksum := sha256.New()
+	for _, chunk := range inner.chunks[:3] {
+		data, err := json.Marshal(chunk)
+		require.NoError(t, err)
+		checksum.Write(append(data, '\n'))
+	}
+	assert.Equal(t, hex.EncodeToString(checksum.Sum(nil)), marker.Ark.Checksum)
+}
+
+func TestSequencedEventStreamTargetCompletion(t *testing.T) {
+	inner := &capturingEventStream{}
+	stream := NewSequencedEventStream(inner)
+	ctx := WithQueryContext(context.Background(), "query-123", "session-456", "test-query")
+	first := WithExecutionMetadata(ctx, map[string]interface{}{"target": "agent/first"})
+
+	require.NoError(t, stream.StreamChunk(first, WrapChunkWithMetadata(first, &openai.ChatCompletionChunk{ID: "a"}, "")))
+	require.NoError(t, stream.StreamChunk(first, WrapChunkWithMetadata(first, &openai.ChatCompletionChunk{ID: "b"}, "")))
+	NotifyTargetCompletion(ctx, stream, "agent/first")
+	NotifyTargetCompletion(ctx, stream, "agent/second")
+
+	require.Len(t, inner.chunks, 4)
+	marker := inner.chunks[2].(StreamCompletionMarker)
+	assert.Equal(t, TargetStreamCompletedObject, marker.Object)
+	assert.Equal(t, "agent/first", marker.Ark.Target)
+	assert.Equal(t, int64(2), *marker.Ark.TotalChunks)
+	assert.Zero(t, marker.Ark.Sequence, "markers are not numbered")
+	assert.Equal(t, int64(0), *inner.chunks[3].(StreamCompletionMarker).Ark.TotalChunks)
+}
+
+// targetStreamService records what the streaming service receives, by escaped stream name
+type targetStreamService struct {
+	mu        sync.Mutex
+	chunks    map[string][]string
+	completed []string
+}
+
+func (s *targetStreamService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.EscapedPath(), "/stream/")
+	if completed, ok := strings.CutSuffix(name, "/complete"); ok {
+		s.mu.Lock()
+		s.completed = append(s.completed, completed)
+		s.mu.Unlock()
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	s.mu.Lock()
+	s.chunks[name] = append(s.chunks[name], strings.Fields(string(body))...)
+	s.mu.Unlock()
+}
+
+func (s *targetStreamService) received(name string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.chunks[name]
+}
+
+func TestHTTPEventStreamTargetStreams(t *testing.T) {
+	service := &targetStreamService{chunks: map[string][]string{}}
+	server := httptest.NewServer(service)
+	t.Cleanup(server.Close)
+
+	stream := NewHTTPEventStream(server.URL, "session", "query", server.Client())
+	stream.targetStreams = true
+	ctx := WithQueryContext(context.Background(), "query-123", "session-456", "query")
+	first := WithExecutionMetadata(ctx, map[string]interface{}{"target": "agent/first"})
+	second := WithExecutionMetadata(ctx, map[string]interface{}{"target": "agent/second"})
+
+	require.NoError(t, stream.StreamChunk(first, WrapChunkWithMetadata(first, &openai.ChatCompletionChunk{ID: "a"}, "")))
+	require.NoError(t, stream.StreamChunk(second, WrapChunkWithMetadata(second, &openai.ChatCompletionChunk{ID: "b"}, "")))
+	require.NoError(t, stream.StreamChunk(first, WrapChunkWithMetadata(first, &openai.ChatCompletionChunk{ID: "c"}, "")))
+	require.NoError(t, stream.NotifyTargetCompletion(ctx, "agent/first"))
+	require.NoError(t, stream.NotifyCompletion(ctx))
+
+	ids := func(name string) []string {
+		var ids []string
+		for _, chunk := range service.received(name) {
+			var decoded struct {
+				ID string `json:"id"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(chunk), &decoded))
+			ids = append(ids, decoded.ID)
+		}
+		return ids
+	}
+	assert.Eventually(t, func() bool { return len(service.received("query")) == 3 }, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return len(service.received("query%2Fagent%2Fsecond")) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"a", "b", "c"}, ids("query"))
+	assert.Equal(t, []string{"a", "c"}, ids("query%2Fagent%2Ffirst"))
+	assert.Equal(t, []string{"b"}, ids("query%2Fagent%2Fsecond"))
+	assert.Equal(t, []string{"query%2Fagent%2Ffirst", "query%2Fagent%2Fsecond", "query"}, service.completed)
+}
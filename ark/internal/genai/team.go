@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -21,6 +24,10 @@ type Team struct {
 	MaxTurns          *int
 	Selector          *arkv1alpha1.TeamSelectorSpec
 	Graph             *arkv1alpha1.TeamGraphSpec
+	Aggregator        *arkv1alpha1.TeamAggregatorSpec
+	MemberHistory     map[string]*arkv1alpha1.TeamMemberHistory
+	OutputSchema      *runtime.RawExtension
+	Finalizer         *arkv1alpha1.TeamFinalizerSpec
 	Recorder          EventEmitter
 	TeamRecorder      telemetry.TeamRecorder
 	TelemetryProvider telemetry.Provider
@@ -62,13 +69,24 @@ func (t *Team) Execute(ctx context.Context, userInput Message, history []Message
 		execFunc = t.executeSelector
 	case "graph":
 		execFunc = t.executeGraph
+	case "parallel":
+		execFunc = t.executeParallel
 	default:
 		err := fmt.Errorf("unsupported strategy %s for team %s", t.Strategy, t.FullName())
 		teamTracker.Fail(err)
 		return nil, err
 	}
 
-	return t.executeWithTracking(teamTracker, execFunc, ctx, userInput, history)
+	newMessages, err := t.executeWithTracking(teamTracker, execFunc, ctx, userInput, history)
+	if err != nil || t.OutputSchema == nil {
+		return newMessages, err
+	}
+
+	finalMessage, err := t.finalize(ctx, userInput, newMessages)
+	if err != nil {
+		return newMessages, err
+	}
+	return append(newMessages, finalMessage), nil
 }
 
 func (t *Team) executeSequential(ctx context.Context, userInput Message, history []Message) ([]Message, error) {
@@ -123,6 +141,7 @@ func (t *Team) executeRoundRobin(ctx context.Context, userInput Message, history
 		if t.MaxTurns != nil && messageCount >= *t.MaxTurns {
 			turnTracker := NewExecutionRecorder(t.Recorder)
 			turnTracker.TeamTurn(ctx, "MaxTurns", t.FullName(), t.Strategy, messageCount)
+			recordFinishReason(ctx, FinishMaxTurnsReached)
 
 			// Log maxTurns reached and return success with accumulated messages
 			t.Recorder.EmitEvent(ctx, corev1.EventTypeWarning, "TeamMaxTurnsReached", BaseEvent{
@@ -204,6 +223,10 @@ func MakeTeam(ctx context.Context, k8sClient client.Client, crd *arkv1alpha1.Tea
 		MaxTurns:          crd.Spec.MaxTurns,
 		Selector:          crd.Spec.Selector,
 		Graph:             crd.Spec.Graph,
+		Aggregator:        crd.Spec.Aggregator,
+		MemberHistory:     memberHistoryPolicies(crd.Spec.Members),
+		OutputSchema:      crd.Spec.OutputSchema,
+		Finalizer:         crd.Spec.Finalizer,
 		Recorder:          recorder,
 		TeamRecorder:      telemetryProvider.TeamRecorder(),
 		TelemetryProvider: telemetryProvider,
@@ -213,9 +236,14 @@ func MakeTeam(ctx context.Context, k8sClient client.Client, crd *arkv1alpha1.Tea
 }
 
 func loadTeamMembers(ctx context.Context, k8sClient client.Client, crd *arkv1alpha1.Team, recorder EventEmitter, telemetryProvider telemetry.Provider) ([]TeamMember, error) {
-	members := make([]TeamMember, 0, len(crd.Spec.Members))
+	memberSpecs, err := ResolveTeamMemberSpecs(ctx, k8sClient, crd)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, memberSpec := range crd.Spec.Members {
+	members := make([]TeamMember, 0, len(memberSpecs))
+
+	for _, memberSpec := range memberSpecs {
 		member, err := loadTeamMember(ctx, k8sClient, memberSpec, crd.Namespace, crd.Name, recorder, telemetryProvider)
 		if err != nil {
 			return nil, err
@@ -226,6 +254,47 @@ func loadTeamMembers(ctx context.Context, k8sClient client.Client, crd *arkv1alp
 	return members, nil
 }
 
+// ResolveTeamMemberSpecs returns the members of the team followed by the agents matching its member selector that
+// are not members already, in name order. The selector is resolved on every call, so newly labeled agents join
+// the team on its next execution.
+func ResolveTeamMemberSpecs(ctx context.Context, k8sClient client.Client, crd *arkv1alpha1.Team) ([]arkv1alpha1.TeamMember, error) {
+	if crd.Spec.MemberSelector == nil {
+		return crd.Spec.Members, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(crd.Spec.MemberSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid memberSelector for team %s: %w", crd.Name, err)
+	}
+
+	var agentList arkv1alpha1.AgentList
+	if err := k8sClient.List(ctx, &agentList, &client.ListOptions{
+		Namespace:     crd.Namespace,
+		LabelSelector: selector,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list agents for team %s: %w", crd.Name, err)
+	}
+	slices.SortFunc(agentList.Items, func(a, b arkv1alpha1.Agent) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	memberSpecs := slices.Clone(crd.Spec.Members)
+	for _, agent := range agentList.Items {
+		isMember := slices.ContainsFunc(memberSpecs, func(member arkv1alpha1.TeamMember) bool {
+			return member.Type == "agent" && member.Name == agent.Name
+		})
+		if !isMember {
+			memberSpecs = append(memberSpecs, arkv1alpha1.TeamMember{Name: agent.Name, Type: "agent"})
+		}
+	}
+
+	if len(memberSpecs) == 0 {
+		return nil, fmt.Errorf("team %s has no members: no agents match its memberSelector", crd.Name)
+	}
+
+	return memberSpecs, nil
+}
+
 func (t *Team) executeWithTracking(tracker *OperationTracker, execFunc func(context.Context, Message, []Message) ([]Message, error), ctx context.Context, userInput Message, history []Message) ([]Message, error) {
 	maxTurns := 0
 	if t.MaxTurns != nil {
@@ -276,7 +345,7 @@ func (t *Team) executeWithTracking(tracker *OperationTracker, execFunc func(cont
 
 	t.TeamRecorder.RecordSuccess(span)
 	if teamTokenUsage.TotalTokens > 0 {
-		tracker.CompleteWithTokens(teamTokenUsage)
+		tracker.CompleteWithRollupTokens(teamTokenUsage)
 	} else {
 		tracker.Complete("")
 	}
@@ -285,6 +354,13 @@ func (t *Team) executeWithTracking(tracker *OperationTracker, execFunc func(cont
 
 // executeMemberAndAccumulate executes a member and accumulates new messages
 func (t *Team) executeMemberAndAccumulate(ctx context.Context, member TeamMember, userInput Message, messages, newMessages *[]Message, turn int) error {
+	_, err := t.executeMemberWithHandoff(ctx, member, userInput, messages, newMessages, turn)
+	return err
+}
+
+// executeMemberWithHandoff executes a member like executeMemberAndAccumulate and returns the handoff the member made,
+// if any. A handoff ends the member's turn without an error and is recorded in the conversation.
+func (t *Team) executeMemberWithHandoff(ctx context.Context, member TeamMember, userInput Message, messages, newMessages *[]Message, turn int) (*Handoff, error) {
 	// Add team and current member to execution metadata for streaming
 	ctx = WithExecutionMetadata(ctx, map[string]interface{}{
 		"team":  t.Name,
@@ -300,9 +376,34 @@ func (t *Team) executeMemberAndAccumulate(ctx context.Context, member TeamMember
 		"strategy":   t.Strategy,
 	})
 
-	memberNewMessages, err := member.Execute(ctx, userInput, *messages, t.memory, t.eventStream)
+	history, err := t.memberHistory(ctx, member, *messages)
+	if err != nil {
+		memberTracker.Fail(err)
+		return nil, err
+	}
+
+	memberNewMessages, err := member.Execute(ctx, userInput, history, t.memory, t.eventStream)
+	if handoff := GetHandoff(err); handoff != nil {
+		handoff.From = member.GetName()
+		t.Recorder.EmitEvent(ctx, corev1.EventTypeNormal, "TeamHandoff", BaseEvent{
+			Name: t.FullName(),
+			Metadata: map[string]string{
+				"from":     handoff.From,
+				"member":   handoff.Member,
+				"task":     handoff.Task,
+				"strategy": t.Strategy,
+				"teamName": t.FullName(),
+			},
+		})
+		memberTracker.Complete("")
+		memberNewMessages = append(memberNewMessages, handoffMessage(handoff))
+		*messages = append(*messages, memberNewMessages...)
+		*newMessages = append(*newMessages, memberNewMessages...)
+		return handoff, nil
+	}
 	if err != nil {
 		if IsTerminateTeam(err) {
+			recordFinishReason(ctx, FinishTerminated)
 			memberTracker.CompleteWithTermination(err.Error())
 		} else {
 			memberTracker.Fail(err)
@@ -310,13 +411,13 @@ func (t *Team) executeMemberAndAccumulate(ctx context.Context, member TeamMember
 		// Still accumulate messages even on error
 		*messages = append(*messages, memberNewMessages...)
 		*newMessages = append(*newMessages, memberNewMessages...)
-		return err
+		return nil, err
 	}
 
 	memberTracker.Complete("")
 	*messages = append(*messages, memberNewMessages...)
 	*newMessages = append(*newMessages, memberNewMessages...)
-	return nil
+	return nil, nil
 }
 
 func loadTeamMember(ctx context.Context, k8sClient client.Client, memberSpec arkv1alpha1.TeamMember, namespace, teamName string, recorder EventEmitter, telemetryProvider telemetry.Provider) (TeamMember, error) {
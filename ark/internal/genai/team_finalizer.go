@@ -0,0 +1,154 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openai/openai-go/packages/param"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const teamFinalizerPrompt = `You write the final answer of a conversation between a user and a team of AI agents.
+Answer the user's request with the conclusions the team reached, in the requested JSON format. Only use information from the conversation.`
+
+// finalize coerces the team conversation into the team's output schema and returns it as the team's final message
+func (t *Team) finalize(ctx context.Context, userInput Message, newMessages []Message) (Message, error) {
+	schema, err := resolveOutputSchema(t.OutputSchema)
+	if err != nil {
+		return Message{}, fmt.Errorf("team %s has an invalid output schema: %w", t.FullName(), err)
+	}
+
+	strategy := arkv1alpha1.TeamFinalizerModel
+	var modelRef *arkv1alpha1.AgentModelRef
+	if t.Finalizer != nil {
+		if t.Finalizer.Strategy != "" {
+			strategy = t.Finalizer.Strategy
+		}
+		modelRef = t.Finalizer.ModelRef
+	}
+
+	var content string
+	switch strategy {
+	case arkv1alpha1.TeamFinalizerExtract:
+		content, err = extractFinalAnswer(schema, newMessages)
+	case arkv1alpha1.TeamFinalizerModel:
+		content, err = t.writeFinalAnswer(ctx, schema, modelRef, append([]Message{userInput}, newMessages...))
+	default:
+		err = fmt.Errorf("unsupported finalizer strategy %s", strategy)
+	}
+	if err != nil {
+		return Message{}, fmt.Errorf("team %s failed to produce its final answer: %w", t.FullName(), err)
+	}
+
+	message := NewAssistantMessage(content)
+	message.OfAssistant.Name = param.Opt[string]{Value: t.Name}
+	return message, nil
+}
+
+// writeFinalAnswer asks a model to answer in the output schema from the transcript of the conversation
+func (t *Team) writeFinalAnswer(ctx context.Context, schema *jsonschema.Resolved, modelRef *arkv1alpha1.AgentModelRef, conversation []Message) (string, error) {
+	transcript := formatTranscript(conversation)
+	if transcript == "" {
+		return "", fmt.Errorf("the conversation has no messages")
+	}
+
+	var modelSpec any = ""
+	if modelRef != nil {
+		modelSpec = modelRef
+	}
+	model, err := LoadModel(ctx, t.Client, modelSpec, t.Namespace, nil, t.TelemetryProvider.ModelRecorder())
+	if err != nil {
+		return "", fmt.Errorf("failed to load finalizer model: %w", err)
+	}
+	model.OutputSchema = t.OutputSchema
+	// Truncate schema name to 64 chars for OpenAI API compatibility - name is purely an identifier
+	model.SchemaName = fmt.Sprintf("%.64s", fmt.Sprintf("namespace-%s-team-%s", t.Namespace, t.Name))
+
+	llmTracker := NewOperationTracker(t.Recorder, ctx, "LLMCall", model.Model, map[string]string{
+		"team":  t.FullName(),
+		"model": model.Model,
+	})
+	messages := []Message{NewSystemMessage(teamFinalizerPrompt), NewUserMessage(transcript)}
+	completion, err := model.ChatCompletion(ctx, messages, nil, 1)
+	if err != nil {
+		llmTracker.Fail(err)
+		return "", err
+	}
+	llmTracker.CompleteWithTokens(TokenUsage{
+		PromptTokens:     completion.Usage.PromptTokens,
+		CompletionTokens: completion.Usage.CompletionTokens,
+		TotalTokens:      completion.Usage.TotalTokens,
+	})
+	if len(completion.Choices) == 0 {
+		return "", fmt.Errorf("model returned no completion choices")
+	}
+
+	content := completion.Choices[0].Message.Content
+	if err := validateAgainstSchema(schema, content); err != nil {
+		return "", fmt.Errorf("model answer does not match the output schema: %w", err)
+	}
+	return strings.TrimSpace(content), nil
+}
+
+// extractFinalAnswer returns the most recent JSON value in the members' messages that matches the output schema
+func extractFinalAnswer(schema *jsonschema.Resolved, messages []Message) (string, error) {
+	var lastErr error
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].OfAssistant == nil {
+			continue
+		}
+		candidate := extractJSON(messages[i].OfAssistant.Content.OfString.Value)
+		if candidate == "" {
+			continue
+		}
+		if err := validateAgainstSchema(schema, candidate); err != nil {
+			lastErr = err
+			continue
+		}
+		return candidate, nil
+	}
+	if lastErr != nil {
+		return "", fmt.Errorf("no message matches the output schema: %w", lastErr)
+	}
+	return "", fmt.Errorf("no message contains a JSON answer")
+}
+
+// extractJSON returns the JSON in a message, which is either the whole message or a fenced code block in it
+func extractJSON(content string) string {
+	content = strings.TrimSpace(content)
+	if start := strings.Index(content, "```"); start >= 0 {
+		block := content[start+3:]
+		if end := strings.Index(block, "```"); end >= 0 {
+			block = block[:end]
+			block = strings.TrimPrefix(block, "json")
+			content = strings.TrimSpace(block)
+		}
+	}
+	if !json.Valid([]byte(content)) {
+		return ""
+	}
+	return content
+}
+
+func resolveOutputSchema(outputSchema *runtime.RawExtension) (*jsonschema.Resolved, error) {
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(outputSchema.Raw, &schema); err != nil {
+		return nil, err
+	}
+	return schema.Resolve(nil)
+}
+
+func validateAgainstSchema(schema *jsonschema.Resolved, content string) error {
+	var value any
+	if err := json.Unmarshal([]byte(content), &value); err != nil {
+		return err
+	}
+	return schema.Validate(value)
+}
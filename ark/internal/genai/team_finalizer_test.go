@@ -0,0 +1,64 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const testTeamOutputSchema = `{
+	"type": "object",
+	"properties": {"verdict": {"type": "string"}, "score": {"type": "integer"}},
+	"required": ["verdict", "score"]
+}`
+
+func TestExtractJSON(t *testing.T) {
+	assert.Equal(t, `{"a": 1}`, extractJSON(` {"a": 1} `))
+	assert.Equal(t, `{"a": 1}`, extractJSON("Here is the result:\n```json\n{\"a\": 1}\n```"))
+	assert.Equal(t, `[1, 2]`, extractJSON("```\n[1, 2]\n```"))
+	assert.Empty(t, extractJSON("The verdict is approve."))
+}
+
+func TestTeamFinalizeExtract(t *testing.T) {
+	team := &Team{
+		Name:         "review",
+		Namespace:    "default",
+		OutputSchema: &runtime.RawExtension{Raw: []byte(testTeamOutputSchema)},
+		Finalizer:    &arkv1alpha1.TeamFinalizerSpec{Strategy: arkv1alpha1.TeamFinalizerExtract},
+	}
+	messages := []Message{
+		NewAssistantMessage("```json\n{\"verdict\": \"approve\", \"score\": 8}\n```"),
+		NewAssistantMessage(`{"verdict": "approve"}`),
+		NewAssistantMessage("Looks good to me."),
+	}
+
+	final, err := team.finalize(context.Background(), NewUserMessage("Review the change"), messages)
+	require.NoError(t, err)
+	require.NotNil(t, final.OfAssistant)
+	assert.Equal(t, "review", final.OfAssistant.Name.Value)
+	assert.JSONEq(t, `{"verdict": "approve", "score": 8}`, final.OfAssistant.Content.OfString.Value)
+
+	_, err = team.finalize(context.Background(), NewUserMessage("Review the change"), messages[1:])
+	assert.ErrorContains(t, err, "no message matches the output schema")
+
+	_, err = team.finalize(context.Background(), NewUserMessage("Review the change"), messages[2:])
+	assert.ErrorContains(t, err, "no message contains a JSON answer")
+}
+
+func TestTeamFinalizeInvalidSchema(t *testing.T) {
+	team := &Team{
+		Name:         "review",
+		Namespace:    "default",
+		OutputSchema: &runtime.RawExtension{Raw: []byte(`{"type": 5}`)},
+	}
+
+	_, err := team.finalize(context.Background(), NewUserMessage("Review the change"), nil)
+	assert.ErrorContains(t, err, "team default/review has an invalid output schema")
+}
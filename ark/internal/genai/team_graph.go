@@ -21,9 +21,11 @@ func (t *Team) executeGraph(ctx context.Context, userInput Message, history []Me
 	}
 
 	transitionMap := make(map[string]string)
+	edgeTargets := make(map[string][]string)
 	if t.Graph != nil {
 		for _, edge := range t.Graph.Edges {
 			transitionMap[edge.From] = edge.To
+			edgeTargets[edge.From] = append(edgeTargets[edge.From], edge.To)
 		}
 	}
 
@@ -45,7 +47,7 @@ func (t *Team) executeGraph(ctx context.Context, userInput Message, history []Me
 		turnCtx, turnSpan := t.TeamRecorder.StartTurn(ctx, turns, member.GetName(), member.GetType())
 		defer turnSpan.End()
 
-		err := t.executeMemberAndAccumulate(turnCtx, member, userInput, &messages, &newMessages, turns)
+		handoff, err := t.executeMemberWithHandoff(turnCtx, member, userInput, &messages, &newMessages, turns)
 
 		// Record turn output
 		if len(newMessages) > 0 {
@@ -63,6 +65,20 @@ func (t *Team) executeGraph(ctx context.Context, userInput Message, history []Me
 		t.TeamRecorder.RecordSuccess(turnSpan)
 
 		nextMember := transitionMap[currentMemberName]
+
+		// A handoff routes the next turn along one of the member's edges; a handoff to any other member is rejected
+		if handoff != nil {
+			var candidates []TeamMember
+			for _, name := range edgeTargets[currentMemberName] {
+				if next, exists := memberMap[name]; exists {
+					candidates = append(candidates, next)
+				}
+			}
+			if target := t.handoffTarget(ctx, handoff, candidates); target != nil {
+				nextMember = target.GetName()
+			}
+		}
+
 		if nextMember == "" {
 			break
 		}
@@ -71,6 +87,7 @@ func (t *Team) executeGraph(ctx context.Context, userInput Message, history []Me
 
 		if t.MaxTurns != nil && turns+1 >= *t.MaxTurns {
 			turnTracker.TeamTurn(ctx, "MaxTurns", t.FullName(), t.Strategy, turns+1)
+			recordFinishReason(ctx, FinishMaxTurnsReached)
 			// Log the maxTurns limit for observability, but return success with accumulated messages
 			t.Recorder.EmitEvent(ctx, corev1.EventTypeWarning, "TeamMaxTurnsReached", BaseEvent{
 				Name: t.FullName(),
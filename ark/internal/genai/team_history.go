@@ -0,0 +1,63 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// DefaultMemberHistoryLastN is the number of recent messages the last-n and summary policies keep when the
+// member history does not configure it
+const DefaultMemberHistoryLastN = 10
+
+// memberHistoryKey identifies a member in the team history policies; an agent and a team may share a name
+func memberHistoryKey(memberType, name string) string {
+	return memberType + "/" + name
+}
+
+// memberHistoryPolicies returns the history policies of the members that limit their history, by member type and name
+func memberHistoryPolicies(members []arkv1alpha1.TeamMember) map[string]*arkv1alpha1.TeamMemberHistory {
+	policies := make(map[string]*arkv1alpha1.TeamMemberHistory)
+	for _, member := range members {
+		if member.History != nil {
+			policies[memberHistoryKey(member.Type, member.Name)] = member.History
+		}
+	}
+	return policies
+}
+
+// memberHistory returns the part of the team conversation that is passed to the member under its history policy
+func (t *Team) memberHistory(ctx context.Context, member TeamMember, messages []Message) ([]Message, error) {
+	policy := t.MemberHistory[memberHistoryKey(member.GetType(), member.GetName())]
+	if policy == nil || len(messages) == 0 {
+		return messages, nil
+	}
+
+	lastN := DefaultMemberHistoryLastN
+	if policy.LastN > 0 {
+		lastN = int(policy.LastN)
+	}
+
+	switch policy.Policy {
+	case "", arkv1alpha1.TeamMemberHistoryFull:
+		return messages, nil
+	case arkv1alpha1.TeamMemberHistoryLastN:
+		return keepRecentMessages(messages, lastN), nil
+	case arkv1alpha1.TeamMemberHistorySummary:
+		recent := keepRecentMessages(messages, lastN)
+		older := messages[:len(messages)-len(recent)]
+		if formatTranscript(older) == "" {
+			return recent, nil
+		}
+		summary, err := summarizeHistory(ctx, t.Client, t.Namespace, policy.ModelRef, older, t.TelemetryProvider.ModelRecorder())
+		if err != nil {
+			return nil, fmt.Errorf("failed to summarize history for member %s in team %s: %w", member.GetName(), t.FullName(), err)
+		}
+		return append([]Message{summary}, recent...), nil
+	default:
+		return nil, fmt.Errorf("unsupported history policy %s for member %s in team %s", policy.Policy, member.GetName(), t.FullName())
+	}
+}
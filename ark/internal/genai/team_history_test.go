@@ -0,0 +1,122 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func testConversation(turns int) []Message {
+	var messages []Message
+	for i := range turns {
+		messages = append(messages, NewUserMessage(fmt.Sprintf("question %d", i)), NewAssistantMessage(fmt.Sprintf("answer %d", i)))
+	}
+	return messages
+}
+
+func TestMemberHistoryPolicies(t *testing.T) {
+	lastN := &arkv1alpha1.TeamMemberHistory{Policy: arkv1alpha1.TeamMemberHistoryLastN, LastN: 2}
+	policies := memberHistoryPolicies([]arkv1alpha1.TeamMember{
+		{Name: "researcher", Type: "agent"},
+		{Name: "writers", Type: "team", History: lastN},
+	})
+	assert.Equal(t, map[string]*arkv1alpha1.TeamMemberHistory{"team/writers": lastN}, policies)
+}
+
+func TestTeamMemberHistoryKeysByMemberType(t *testing.T) {
+	team := &Team{Name: "research", Namespace: "default", MemberHistory: memberHistoryPolicies([]arkv1alpha1.TeamMember{
+		{Name: "writers", Type: "team", History: &arkv1alpha1.TeamMemberHistory{Policy: arkv1alpha1.TeamMemberHistoryLastN, LastN: 2}},
+	})}
+	messages := testConversation(4)
+
+	history, err := team.memberHistory(context.Background(), &mockTeamMember{name: "writers", memberType: MemberTypeAgent}, messages)
+	require.NoError(t, err)
+	assert.Equal(t, messages, history)
+
+	history, err = team.memberHistory(context.Background(), &mockTeamMember{name: "writers", memberType: "team"}, messages)
+	require.NoError(t, err)
+	assert.Equal(t, messages[6:], history)
+}
+
+func TestTeamMemberHistory(t *testing.T) {
+	member := &mockTeamMember{name: "writers", memberType: "team"}
+	messages := testConversation(8)
+
+	tests := []struct {
+		name    string
+		history *arkv1alpha1.TeamMemberHistory
+		want    []Message
+		wantErr string
+	}{
+		{
+			name: "no policy passes the full history",
+			want: messages,
+		},
+		{
+			name:    "full policy",
+			history: &arkv1alpha1.TeamMemberHistory{Policy: arkv1alpha1.TeamMemberHistoryFull},
+			want:    messages,
+		},
+		{
+			name:    "last-n policy",
+			history: &arkv1alpha1.TeamMemberHistory{Policy: arkv1alpha1.TeamMemberHistoryLastN, LastN: 3},
+			want:    messages[13:],
+		},
+		{
+			name:    "last-n policy defaults",
+			history: &arkv1alpha1.TeamMemberHistory{Policy: arkv1alpha1.TeamMemberHistoryLastN},
+			want:    messages[6:],
+		},
+		{
+			name:    "summary policy without older messages",
+			history: &arkv1alpha1.TeamMemberHistory{Policy: arkv1alpha1.TeamMemberHistorySummary, LastN: 20},
+			want:    messages,
+		},
+		{
+			name:    "unsupported policy",
+			history: &arkv1alpha1.TeamMemberHistory{Policy: "everything"},
+			wantErr: "unsupported history policy everything for member writers in team default/research",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team := &Team{Name: "research", Namespace: "default", MemberHistory: map[string]*arkv1alpha1.TeamMemberHistory{}}
+			if tt.history != nil {
+				team.MemberHistory[memberHistoryKey(member.memberType, member.name)] = tt.history
+			}
+
+			history, err := team.memberHistory(context.Background(), member, messages)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, history)
+		})
+	}
+}
+
+func TestTeamMemberHistoryDropsOrphanedToolResults(t *testing.T) {
+	member := &mockTeamMember{name: "researcher"}
+	messages := []Message{
+		NewUserMessage("look it up"),
+		NewAssistantMessage("calling the tool"),
+		ToolMessage("result", "call-1"),
+		NewAssistantMessage("the answer"),
+	}
+	team := &Team{Name: "research", Namespace: "default", MemberHistory: map[string]*arkv1alpha1.TeamMemberHistory{
+		memberHistoryKey(MemberTypeAgent, member.name): {Policy: arkv1alpha1.TeamMemberHistoryLastN, LastN: 2},
+	}}
+
+	history, err := team.memberHistory(context.Background(), member, messages)
+	require.NoError(t, err)
+	assert.Equal(t, messages[3:], history)
+}
@@ -0,0 +1,132 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const aggregatorPrompt = `The members of your team answered the user's request independently. Their answers follow.
+Merge them into a single final answer to the request. Resolve contradictions, remove repetition and keep every relevant detail.
+
+%s`
+
+// executeParallel runs all members concurrently on the same input and history. The members' messages are
+// returned in member order, followed by the aggregator's merged answer when the team has an aggregator.
+func (t *Team) executeParallel(ctx context.Context, userInput Message, history []Message) ([]Message, error) {
+	memberMessages := make([][]Message, len(t.Members))
+	memberErrors := make([]error, len(t.Members))
+
+	var wg sync.WaitGroup
+	for i, member := range t.Members {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Start turn-level telemetry span
+			turnCtx, turnSpan := t.TeamRecorder.StartTurn(ctx, i, member.GetName(), member.GetType())
+			defer turnSpan.End()
+
+			// Each member sees only the shared history, not the answers of the other members
+			messages := slices.Clone(history)
+			err := t.executeMemberAndAccumulate(turnCtx, member, userInput, &messages, &memberMessages[i], i)
+
+			if len(memberMessages[i]) > 0 {
+				t.TeamRecorder.RecordTurnOutput(turnSpan, memberMessages[i], len(memberMessages[i]))
+			}
+			if err != nil && !IsTerminateTeam(err) {
+				t.TeamRecorder.RecordError(turnSpan, err)
+				memberErrors[i] = err
+				return
+			}
+			t.TeamRecorder.RecordSuccess(turnSpan)
+		}()
+	}
+	wg.Wait()
+
+	var newMessages []Message
+	for _, messages := range memberMessages {
+		newMessages = append(newMessages, messages...)
+	}
+
+	for i, err := range memberErrors {
+		if err == nil {
+			continue
+		}
+		member := t.Members[i]
+		t.Recorder.EmitEvent(ctx, corev1.EventTypeWarning, "TeamMemberFailed", BaseEvent{
+			Name: member.GetName(),
+			Metadata: map[string]string{
+				"error":       err.Error(),
+				"memberIndex": fmt.Sprintf("%d", i),
+				"strategy":    t.Strategy,
+				"teamName":    t.FullName(),
+			},
+		})
+		return newMessages, fmt.Errorf("%s %s failed in team %s: %w", member.GetType(), member.GetName(), t.FullName(), err)
+	}
+
+	if t.Aggregator == nil {
+		return newMessages, nil
+	}
+	return t.aggregate(ctx, userInput, history, newMessages)
+}
+
+// aggregate has the aggregator agent merge the members' answers into a single final message
+func (t *Team) aggregate(ctx context.Context, userInput Message, history, memberMessages []Message) ([]Message, error) {
+	aggregator, err := t.loadAggregatorAgent(ctx)
+	if err != nil {
+		return memberMessages, err
+	}
+
+	turn := len(t.Members)
+	turnCtx, turnSpan := t.TeamRecorder.StartTurn(ctx, turn, aggregator.GetName(), aggregator.GetType())
+	defer turnSpan.End()
+
+	messages := append(slices.Clone(history), NewSystemMessage(fmt.Sprintf(aggregatorPrompt, buildHistory(memberMessages))))
+	newMessages := slices.Clone(memberMessages)
+	err = t.executeMemberAndAccumulate(turnCtx, aggregator, userInput, &messages, &newMessages, turn)
+
+	if len(newMessages) > len(memberMessages) {
+		t.TeamRecorder.RecordTurnOutput(turnSpan, newMessages[len(memberMessages):], len(newMessages)-len(memberMessages))
+	}
+	if err != nil {
+		if IsTerminateTeam(err) {
+			return newMessages, nil
+		}
+		t.TeamRecorder.RecordError(turnSpan, err)
+		return newMessages, fmt.Errorf("aggregator agent %s failed in team %s: %w", aggregator.GetName(), t.FullName(), err)
+	}
+
+	t.TeamRecorder.RecordSuccess(turnSpan)
+	return newMessages, nil
+}
+
+func (t *Team) loadAggregatorAgent(ctx context.Context) (*Agent, error) {
+	if t.Aggregator == nil || t.Aggregator.Agent == "" {
+		return nil, fmt.Errorf("aggregator agent must be specified")
+	}
+
+	agentName := t.Aggregator.Agent
+
+	var agentCRD arkv1alpha1.Agent
+	key := types.NamespacedName{Name: agentName, Namespace: t.Namespace}
+	if err := t.Client.Get(ctx, key, &agentCRD); err != nil {
+		return nil, fmt.Errorf("failed to get aggregator agent %s in namespace %s: %w", agentName, t.Namespace, err)
+	}
+
+	agent, err := MakeAgent(ctx, t.Client, &agentCRD, t.Recorder, t.TelemetryProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aggregator agent: %w", err)
+	}
+
+	return agent, nil
+}
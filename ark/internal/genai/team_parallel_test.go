@@ -0,0 +1,119 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+// parallelTestMember answers with a fixed message once all members of the team have started
+type parallelTestMember struct {
+	name    string
+	answer  string
+	err     error
+	started *sync.WaitGroup
+	history []Message
+}
+
+func (m *parallelTestMember) GetName() string        { return m.name }
+func (m *parallelTestMember) GetType() string        { return MemberTypeAgent }
+func (m *parallelTestMember) GetDescription() string { return "" }
+
+func (m *parallelTestMember) Execute(ctx context.Context, userInput Message, history []Message, memory MemoryInterface, eventStream EventStreamInterface) ([]Message, error) {
+	m.history = history
+	m.started.Done()
+	m.started.Wait()
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []Message{NewAssistantMessage(m.answer)}, nil
+}
+
+func newParallelTestTeam(members ...*parallelTestMember) *Team {
+	started := &sync.WaitGroup{}
+	started.Add(len(members))
+	team := &Team{
+		Name:         "fan-out",
+		Namespace:    "default",
+		Strategy:     "parallel",
+		Recorder:     &mockEventRecorder{},
+		TeamRecorder: noop.NewTeamRecorder(),
+	}
+	for _, member := range members {
+		member.started = started
+		team.Members = append(team.Members, member)
+	}
+	return team
+}
+
+func runParallel(t *testing.T, team *Team, history []Message) ([]Message, error) {
+	t.Helper()
+	type result struct {
+		messages []Message
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		messages, err := team.executeParallel(context.Background(), NewUserMessage("Compare the options"), history)
+		done <- result{messages, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.messages, r.err
+	case <-time.After(5 * time.Second):
+		t.Fatal("members did not run concurrently")
+		return nil, nil
+	}
+}
+
+func TestTeamExecuteParallel(t *testing.T) {
+	first := &parallelTestMember{name: "pros", answer: "Pros: fast."}
+	second := &parallelTestMember{name: "cons", answer: "Cons: costly."}
+	team := newParallelTestTeam(first, second)
+	history := []Message{NewUserMessage("Earlier question"), NewAssistantMessage("Earlier answer")}
+
+	messages, err := runParallel(t, team, history)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "Pros: fast.", messages[0].OfAssistant.Content.OfString.Value, "messages are returned in member order")
+	assert.Equal(t, "Cons: costly.", messages[1].OfAssistant.Content.OfString.Value)
+	assert.Equal(t, history, first.history, "members only see the shared history")
+	assert.Equal(t, history, second.history)
+}
+
+func TestTeamExecuteParallelMemberFailure(t *testing.T) {
+	team := newParallelTestTeam(
+		&parallelTestMember{name: "pros", answer: "Pros: fast."},
+		&parallelTestMember{name: "cons", err: errors.New("model unavailable")},
+	)
+
+	messages, err := runParallel(t, team, nil)
+	require.ErrorContains(t, err, "agent cons failed in team default/fan-out: model unavailable")
+	assert.Len(t, messages, 1, "the answers of the other members are kept")
+}
+
+func TestTeamExecuteParallelMissingAggregator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, arkv1alpha1.AddToScheme(scheme))
+
+	team := newParallelTestTeam(&parallelTestMember{name: "pros", answer: "Pros: fast."})
+	team.Client = fake.NewClientBuilder().WithScheme(scheme).Build()
+	team.Aggregator = &arkv1alpha1.TeamAggregatorSpec{Agent: "merger"}
+
+	messages, err := runParallel(t, team, nil)
+	require.ErrorContains(t, err, "failed to get aggregator agent merger in namespace default")
+	assert.Len(t, messages, 1)
+}
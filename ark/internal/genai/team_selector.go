@@ -226,9 +226,11 @@ func (t *Team) executeSelector(ctx context.Context, userInput Message, history [
 	messages := append([]Message{}, history...)
 	var newMessages []Message
 
-	promptTemplate := defaultSelectorPrompt
+	var promptTemplate string
 	if t.Selector != nil && t.Selector.SelectorPrompt != "" {
 		promptTemplate = t.Selector.SelectorPrompt
+	} else {
+		promptTemplate = GetBuiltinPrompt(ctx, t.Client, t.Namespace, PromptSelector)
 	}
 
 	tmpl, err := template.New("selector").Parse(promptTemplate)
@@ -255,25 +257,39 @@ func (t *Team) executeSelector(ctx context.Context, userInput Message, history [
 	}
 
 	previousMember := ""
+	var handoff *Handoff
 
 	for turn := 0; ; turn++ {
 		turnTracker := NewExecutionRecorder(t.Recorder)
 		turnTracker.TeamTurn(ctx, "Start", t.FullName(), t.Strategy, turn)
 
+		// A handoff from the previous member selects the next member without asking the selector agent
+		var nextMember TeamMember
+		if handoff != nil {
+			candidates := t.Members
+			if len(legalTransitions) > 0 {
+				candidates = legalTransitions[previousMember]
+			}
+			nextMember = t.handoffTarget(ctx, handoff, candidates)
+		}
+
 		// Determine next member based on graph constraints (if any)
-		nextMember, err := t.determineNextMember(ctx, messages, tmpl, previousMember, legalTransitions)
-		if err != nil {
-			if IsTerminateTeam(err) {
-				return newMessages, nil
+		if nextMember == nil {
+			nextMember, err = t.determineNextMember(ctx, messages, tmpl, previousMember, legalTransitions)
+			if err != nil {
+				if IsTerminateTeam(err) {
+					recordFinishReason(ctx, FinishTerminated)
+					return newMessages, nil
+				}
+				return newMessages, err
 			}
-			return newMessages, err
 		}
 
 		// Start turn-level telemetry span
 		turnCtx, turnSpan := t.TeamRecorder.StartTurn(ctx, turn, nextMember.GetName(), nextMember.GetType())
 		defer turnSpan.End()
 
-		err = t.executeMemberAndAccumulate(turnCtx, nextMember, userInput, &messages, &newMessages, turn)
+		handoff, err = t.executeMemberWithHandoff(turnCtx, nextMember, userInput, &messages, &newMessages, turn)
 
 		// Record turn output
 		if len(newMessages) > 0 {
@@ -294,6 +310,7 @@ func (t *Team) executeSelector(ctx context.Context, userInput Message, history [
 
 		if t.MaxTurns != nil && turn+1 >= *t.MaxTurns {
 			turnTracker.TeamTurn(ctx, "MaxTurns", t.FullName(), t.Strategy, turn+1)
+			recordFinishReason(ctx, FinishMaxTurnsReached)
 			// Log the maxTurns limit for observability, but return success with accumulated messages
 			t.Recorder.EmitEvent(ctx, corev1.EventTypeWarning, "TeamMaxTurnsReached", BaseEvent{
 				Name: t.FullName(),
@@ -0,0 +1,75 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func labeledAgent(name, namespace string, labels map[string]string) *arkv1alpha1.Agent {
+	return &arkv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Spec:       arkv1alpha1.AgentSpec{Prompt: "You are " + name},
+	}
+}
+
+func TestResolveTeamMemberSpecs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, arkv1alpha1.AddToScheme(scheme))
+
+	research := map[string]string{"team": "research"}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		labeledAgent("writer", "default", research),
+		labeledAgent("analyst", "default", research),
+		labeledAgent("coordinator", "default", map[string]string{"team": "ops"}),
+		labeledAgent("researcher", "other", research),
+	).Build()
+
+	newTeam := func(members []arkv1alpha1.TeamMember, selector *metav1.LabelSelector) *arkv1alpha1.Team {
+		return &arkv1alpha1.Team{
+			ObjectMeta: metav1.ObjectMeta{Name: "research", Namespace: "default"},
+			Spec:       arkv1alpha1.TeamSpec{Members: members, MemberSelector: selector, Strategy: "sequential"},
+		}
+	}
+
+	t.Run("static members only", func(t *testing.T) {
+		members := []arkv1alpha1.TeamMember{{Name: "coordinator", Type: "agent"}}
+		specs, err := ResolveTeamMemberSpecs(context.Background(), k8sClient, newTeam(members, nil))
+		require.NoError(t, err)
+		assert.Equal(t, members, specs)
+	})
+
+	t.Run("selected agents follow the members in name order", func(t *testing.T) {
+		members := []arkv1alpha1.TeamMember{
+			{Name: "coordinator", Type: "agent"},
+			{Name: "writer", Type: "agent"},
+		}
+		specs, err := ResolveTeamMemberSpecs(context.Background(), k8sClient, newTeam(members, &metav1.LabelSelector{MatchLabels: research}))
+		require.NoError(t, err)
+		assert.Equal(t, []arkv1alpha1.TeamMember{
+			{Name: "coordinator", Type: "agent"},
+			{Name: "writer", Type: "agent"},
+			{Name: "analyst", Type: "agent"},
+		}, specs)
+	})
+
+	t.Run("no matching agents", func(t *testing.T) {
+		_, err := ResolveTeamMemberSpecs(context.Background(), k8sClient, newTeam(nil, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "sales"}}))
+		require.ErrorContains(t, err, "team research has no members")
+	})
+
+	t.Run("invalid selector", func(t *testing.T) {
+		selector := &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Matches"}}}
+		_, err := ResolveTeamMemberSpecs(context.Background(), k8sClient, newTeam(nil, selector))
+		require.ErrorContains(t, err, "invalid memberSelector for team research")
+	})
+}
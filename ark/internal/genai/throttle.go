@@ -0,0 +1,77 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/openai/openai-go"
+)
+
+const (
+	// maxThrottleRetries bounds how many times a rate limited model call is retried
+	maxThrottleRetries = 3
+	// maxRetryAfter is the longest provider-requested delay that is honored; longer delays fail fast
+	maxRetryAfter = 60 * time.Second
+)
+
+const throttleRetryDisabledKey contextKey = "throttleRetryDisabled"
+
+// WithoutThrottleRetry makes model calls return throttles instead of retrying them, for callers that
+// retry rate limited calls themselves
+func WithoutThrottleRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, throttleRetryDisabledKey, true)
+}
+
+func throttleRetryDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(throttleRetryDisabledKey).(bool)
+	return disabled
+}
+
+// RetryAfter returns the delay requested by a provider that rejected a call with 429 Too Many Requests.
+// It returns false if the error is not a throttle, or the provider did not say when to retry.
+func RetryAfter(err error) (time.Duration, bool) {
+	header, ok := throttleResponseHeader(err)
+	if !ok {
+		return 0, false
+	}
+
+	if ms := header.Get("Retry-After-Ms"); ms != "" {
+		if value, err := strconv.ParseFloat(ms, 64); err == nil && value >= 0 {
+			return time.Duration(value * float64(time.Millisecond)), true
+		}
+	}
+
+	retryAfter := strings.TrimSpace(header.Get("Retry-After"))
+	if retryAfter == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseFloat(retryAfter, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	if at, err := http.ParseTime(retryAfter); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// throttleResponseHeader returns the response headers of a 429 from the OpenAI or AWS SDKs
+func throttleResponseHeader(err error) (http.Header, bool) {
+	var openaiErr *openai.Error
+	if errors.As(err, &openaiErr) && openaiErr.StatusCode == http.StatusTooManyRequests && openaiErr.Response != nil {
+		return openaiErr.Response.Header, true
+	}
+
+	var awsErr *smithyhttp.ResponseError
+	if errors.As(err, &awsErr) && awsErr.HTTPStatusCode() == http.StatusTooManyRequests && awsErr.Response != nil && awsErr.Response.Response != nil {
+		return awsErr.Response.Header, true
+	}
+
+	return nil, false
+}
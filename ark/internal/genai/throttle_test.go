@@ -0,0 +1,102 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/openai/openai-go"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+func throttleError(statusCode int, header http.Header) error {
+	return fmt.Errorf("chat completion failed: %w", &openai.Error{
+		StatusCode: statusCode,
+		Response:   &http.Response{StatusCode: statusCode, Header: header},
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		expected      time.Duration
+		expectRetried bool
+	}{
+		{
+			name:          "retry-after seconds",
+			err:           throttleError(429, http.Header{"Retry-After": []string{"2"}}),
+			expected:      2 * time.Second,
+			expectRetried: true,
+		},
+		{
+			name:          "retry-after-ms takes precedence",
+			err:           throttleError(429, http.Header{"Retry-After-Ms": []string{"250"}, "Retry-After": []string{"2"}}),
+			expected:      250 * time.Millisecond,
+			expectRetried: true,
+		},
+		{
+			name: "throttle without hint",
+			err:  throttleError(429, http.Header{}),
+		},
+		{
+			name: "not a throttle",
+			err:  throttleError(500, http.Header{"Retry-After": []string{"2"}}),
+		},
+		{
+			name: "plain error",
+			err:  errors.New("boom"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retryAfter, ok := RetryAfter(tt.err)
+			assert.Equal(t, tt.expectRetried, ok)
+			assert.Equal(t, tt.expected, retryAfter)
+		})
+	}
+}
+
+// throttlingProvider rejects every call with a 429 that asks to be retried immediately
+type throttlingProvider struct {
+	calls int
+}
+
+func (p *throttlingProvider) ChatCompletion(ctx context.Context, messages []Message, n int64, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	p.calls++
+	return nil, throttleError(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"0"}})
+}
+
+func (p *throttlingProvider) ChatCompletionStream(ctx context.Context, messages []Message, n int64, streamFunc func(*openai.ChatCompletionChunk) error, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	return p.ChatCompletion(ctx, messages, n, tools...)
+}
+
+func (p *throttlingProvider) SetOutputSchema(schema *runtime.RawExtension, schemaName string) {}
+
+func TestWithoutThrottleRetry(t *testing.T) {
+	ctx := metricsQueryContext("metrics-throttle")
+	provider := &throttlingProvider{}
+	model := &Model{Model: "throttled", Provider: provider, ModelRecorder: noop.NewModelRecorder()}
+
+	_, err := model.ChatCompletion(ctx, nil, nil, 1)
+	assert.Equal(t, ReasonProviderRateLimited, ReasonFor(err))
+	assert.Equal(t, maxThrottleRetries+1, provider.calls)
+
+	provider.calls = 0
+	_, err = model.ChatCompletion(WithoutThrottleRetry(ctx), nil, nil, 1)
+	assert.Equal(t, ReasonProviderRateLimited, ReasonFor(err))
+	assert.Equal(t, 1, provider.calls)
+
+	// Every throttled response is counted, whether or not it was retried
+	assert.Equal(t, float64(maxThrottleRetries+2), testutil.ToFloat64(modelThrottled.WithLabelValues("metrics-throttle", "throttled")))
+}
@@ -4,48 +4,163 @@ package genai
 
 import (
 	"context"
+	"strconv"
 	"sync"
+
+	corev1 "k8s.io/api/core/v1"
 )
 
 type TokenUsageCollector struct {
 	recorder    EventEmitter
 	mu          sync.RWMutex
-	tokenUsages []TokenUsage
+	tokenUsages []attributedTokenUsage
+	maxTokens   int64
+	onExceeded  func()
+	exceeded    bool
+}
+
+// attributedTokenUsage is the usage of one operation with the query target, agent and model that used it. Each
+// is empty when the operation did not run within one.
+type attributedTokenUsage struct {
+	TokenUsage
+	target string
+	agent  string
+	model  string
+}
+
+// TokenUsageBreakdown attributes the tokens of a query to the targets, agents and models that used them, keyed by
+// target type/name, agent name and model name
+type TokenUsageBreakdown struct {
+	Targets map[string]TokenUsage
+	Agents  map[string]TokenUsage
+	Models  map[string]TokenUsage
 }
 
 func NewTokenUsageCollector(recorder EventEmitter) *TokenUsageCollector {
 	return &TokenUsageCollector{
 		recorder:    recorder,
-		tokenUsages: make([]TokenUsage, 0),
+		tokenUsages: make([]attributedTokenUsage, 0),
 	}
 }
 
 func (c *TokenUsageCollector) EmitEvent(ctx context.Context, eventType, reason string, data EventData) {
 	c.recorder.EmitEvent(ctx, eventType, reason, data)
 
-	if opEvent, ok := data.(OperationEvent); ok && opEvent.TokenUsage.TotalTokens > 0 {
+	if opEvent, ok := data.(OperationEvent); ok && opEvent.TokenUsage.TotalTokens > 0 && !opEvent.rollup {
+		usage := newAttributedTokenUsage(ctx, opEvent)
+		recordModelTokens(ctx, usage.model, usage.TokenUsage)
 		c.mu.Lock()
-		c.tokenUsages = append(c.tokenUsages, opEvent.TokenUsage)
+		c.tokenUsages = append(c.tokenUsages, usage)
+		crossed := c.maxTokens > 0 && !c.exceeded && c.totalTokens() > c.maxTokens
+		c.exceeded = c.exceeded || crossed
 		c.mu.Unlock()
+
+		if crossed {
+			c.budgetExceeded(ctx)
+		}
+	}
+}
+
+// newAttributedTokenUsage attributes the usage of an operation to the target and agent executing it, and to the
+// model it called
+func newAttributedTokenUsage(ctx context.Context, event OperationEvent) attributedTokenUsage {
+	metadata := GetExecutionMetadata(ctx)
+	target, _ := metadata["target"].(string)
+	agent, _ := metadata["agent"].(string)
+	return attributedTokenUsage{
+		TokenUsage: event.TokenUsage,
+		target:     target,
+		agent:      agent,
+		model:      event.Metadata["model"],
+	}
+}
+
+// SetBudget calls onExceeded once, when the total tokens used exceed maxTokens
+func (c *TokenUsageCollector) SetBudget(maxTokens int64, onExceeded func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxTokens = maxTokens
+	c.onExceeded = onExceeded
+}
+
+// BudgetError returns a BudgetExceeded error once the token budget was exceeded, and nil before
+func (c *TokenUsageCollector) BudgetError() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.exceeded {
+		return nil
+	}
+	return Errorf(ReasonBudgetExceeded, "query used %d tokens, exceeding its budget of %d", c.totalTokens(), c.maxTokens)
+}
+
+func (c *TokenUsageCollector) budgetExceeded(ctx context.Context) {
+	c.mu.RLock()
+	total, maxTokens, onExceeded := c.totalTokens(), c.maxTokens, c.onExceeded
+	c.mu.RUnlock()
+
+	c.recorder.EmitEvent(ctx, corev1.EventTypeWarning, "TokenBudgetExceeded", BaseEvent{
+		Name: "token-budget",
+		Metadata: map[string]string{
+			"maxTokens":   strconv.FormatInt(maxTokens, 10),
+			"totalTokens": strconv.FormatInt(total, 10),
+		},
+	})
+	if onExceeded != nil {
+		onExceeded()
 	}
 }
 
+// totalTokens must be called with the lock held
+func (c *TokenUsageCollector) totalTokens() int64 {
+	var total int64
+	for _, usage := range c.tokenUsages {
+		total += usage.TotalTokens
+	}
+	return total
+}
+
 func (c *TokenUsageCollector) GetTokenSummary() TokenUsage {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
 	var total TokenUsage
 	for _, usage := range c.tokenUsages {
-		total.PromptTokens += usage.PromptTokens
-		total.CompletionTokens += usage.CompletionTokens
-		total.TotalTokens += usage.TotalTokens
+		total.add(usage.TokenUsage)
 	}
 
 	return total
 }
 
+// GetTokenBreakdown returns the usage of each target, agent and model. Usage outside of any of them, such as the
+// calls aggregating the responses of the targets, only counts toward the total and the models.
+func (c *TokenUsageCollector) GetTokenBreakdown() TokenUsageBreakdown {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	breakdown := TokenUsageBreakdown{
+		Targets: map[string]TokenUsage{},
+		Agents:  map[string]TokenUsage{},
+		Models:  map[string]TokenUsage{},
+	}
+	addTo := func(usages map[string]TokenUsage, key string, usage TokenUsage) {
+		if key == "" {
+			return
+		}
+		total := usages[key]
+		total.add(usage)
+		usages[key] = total
+	}
+	for _, usage := range c.tokenUsages {
+		addTo(breakdown.Targets, usage.target, usage.TokenUsage)
+		addTo(breakdown.Agents, usage.agent, usage.TokenUsage)
+		addTo(breakdown.Models, usage.model, usage.TokenUsage)
+	}
+	return breakdown
+}
+
 func (c *TokenUsageCollector) Reset() {
 	c.mu.Lock()
-	c.tokenUsages = make([]TokenUsage, 0)
+	c.tokenUsages = make([]attributedTokenUsage, 0)
+	c.exceeded = false
 	c.mu.Unlock()
 }
@@ -69,3 +69,58 @@ func TestTokenUsageCollector(t *testing.T) {
 	assert.Equal(t, int64(0), summary.CompletionTokens)
 	assert.Equal(t, int64(0), summary.TotalTokens)
 }
+
+func TestTokenUsageCollectorBudget(t *testing.T) {
+	mockRec := &mockRecorder{}
+	collector := NewTokenUsageCollector(mockRec)
+	exceeded := 0
+	collector.SetBudget(200, func() { exceeded++ })
+
+	call := OperationEvent{BaseEvent: BaseEvent{Name: "llm-call"}, TokenUsage: TokenUsage{TotalTokens: 150}}
+	collector.EmitEvent(t.Context(), corev1.EventTypeNormal, "LLMCallComplete", call)
+	assert.NoError(t, collector.BudgetError())
+	assert.Equal(t, 0, exceeded)
+
+	collector.EmitEvent(t.Context(), corev1.EventTypeNormal, "LLMCallComplete", call)
+	collector.EmitEvent(t.Context(), corev1.EventTypeNormal, "LLMCallComplete", call)
+	assert.Equal(t, 1, exceeded, "the budget is reported once")
+	assert.Equal(t, ReasonBudgetExceeded, ReasonFor(collector.BudgetError()))
+	assert.EqualError(t, collector.BudgetError(), "query used 450 tokens, exceeding its budget of 200")
+
+	budgetEvent, ok := mockRec.events[2].(BaseEvent)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"maxTokens": "200", "totalTokens": "300"}, budgetEvent.Metadata)
+}
+
+func TestTokenUsageCollectorBreakdown(t *testing.T) {
+	collector := NewTokenUsageCollector(&mockRecorder{})
+	llmCall := func(ctx context.Context, model string, tokens int64) {
+		tracker := NewOperationTracker(collector, ctx, "LLMCall", model, map[string]string{"model": model})
+		tracker.CompleteWithTokens(TokenUsage{PromptTokens: tokens, TotalTokens: tokens})
+	}
+
+	teamCtx := WithExecutionMetadata(t.Context(), map[string]interface{}{"target": "team/research"})
+	teamTracker := NewOperationTracker(collector, teamCtx, "TeamExecution", "research", nil)
+	llmCall(WithExecutionMetadata(teamCtx, map[string]interface{}{"agent": "searcher"}), "gpt-4o", 100)
+	llmCall(WithExecutionMetadata(teamCtx, map[string]interface{}{"agent": "writer"}), "gpt-4o-mini", 40)
+	teamTracker.CompleteWithRollupTokens(TokenUsage{PromptTokens: 140, TotalTokens: 140})
+
+	agentCtx := WithExecutionMetadata(t.Context(), map[string]interface{}{"target": "agent/writer", "agent": "writer"})
+	llmCall(agentCtx, "gpt-4o-mini", 10)
+	llmCall(t.Context(), "gpt-4o", 5)
+
+	assert.Equal(t, int64(155), collector.GetTokenSummary().TotalTokens, "the team rollup is not counted again")
+	breakdown := collector.GetTokenBreakdown()
+	assert.Equal(t, map[string]TokenUsage{
+		"team/research": {PromptTokens: 140, TotalTokens: 140},
+		"agent/writer":  {PromptTokens: 10, TotalTokens: 10},
+	}, breakdown.Targets)
+	assert.Equal(t, map[string]TokenUsage{
+		"searcher": {PromptTokens: 100, TotalTokens: 100},
+		"writer":   {PromptTokens: 50, TotalTokens: 50},
+	}, breakdown.Agents)
+	assert.Equal(t, map[string]TokenUsage{
+		"gpt-4o":      {PromptTokens: 105, TotalTokens: 105},
+		"gpt-4o-mini": {PromptTokens: 50, TotalTokens: 50},
+	}, breakdown.Models)
+}
@@ -0,0 +1,296 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Modes of coercing the arguments of tool calls into the types of the tool's input schema
+const (
+	// ArgumentCoercionNone passes arguments to the tool as the model sent them
+	ArgumentCoercionNone = "none"
+	// ArgumentCoercionLenient converts the arguments it can and passes the others as they are
+	ArgumentCoercionLenient = "lenient"
+	// ArgumentCoercionStrict converts the arguments it can and fails calls whose arguments still do not match
+	ArgumentCoercionStrict = "strict"
+)
+
+// coerceToolArguments coerces the JSON arguments of a call to the tool into the types of its input schema.
+// Models often send numbers and booleans as strings, null for optional arguments, or a single value for an
+// array, which tools such as MCP servers reject. Omitted arguments with a default in the schema are filled in.
+// Arguments that need no coercion are returned as they are, as are all arguments of tools that did not opt in
+// to coercion. Under strict coercion, arguments that are missing
+// or that cannot be converted fail the call with the SchemaViolation reason.
+func coerceToolArguments(def ToolDefinition, arguments string) (string, error) {
+	if def.ArgumentCoercion == "" || def.ArgumentCoercion == ArgumentCoercionNone || len(def.Parameters) == 0 {
+		return arguments, nil
+	}
+
+	values := map[string]any{}
+	if strings.TrimSpace(arguments) != "" {
+		decoder := json.NewDecoder(strings.NewReader(arguments))
+		// Numbers are kept as they were sent, so that large integers do not lose precision
+		decoder.UseNumber()
+		if err := decoder.Decode(&values); err != nil {
+			if def.ArgumentCoercion == ArgumentCoercionStrict {
+				return "", Errorf(ReasonSchemaViolation, "arguments of tool %s are not a JSON object: %v", def.Name, err)
+			}
+			// The tool reports arguments it cannot parse
+			return arguments, nil
+		}
+	}
+
+	var problems []string
+	changed := coerceObject(def.Parameters, values, "", &problems)
+	if def.ArgumentCoercion == ArgumentCoercionStrict && len(problems) > 0 {
+		slices.Sort(problems)
+		return "", Errorf(ReasonSchemaViolation, "arguments of tool %s do not match its input schema: %s", def.Name, strings.Join(problems, "; "))
+	}
+	if !changed {
+		return arguments, nil
+	}
+
+	coerced, err := json.Marshal(values)
+	if err != nil {
+		return arguments, nil
+	}
+	return string(coerced), nil
+}
+
+// coerceObject coerces the properties of an object in place, returning whether any changed
+func coerceObject(schema map[string]any, object map[string]any, path string, problems *[]string) bool {
+	properties, _ := schema["properties"].(map[string]any)
+	requiredFields, _, _ := getRequiredFields(schema)
+	required := make(map[string]bool, len(requiredFields))
+	for _, name := range requiredFields {
+		required[name] = true
+	}
+
+	changed := false
+	for name, property := range properties {
+		propertySchema, ok := property.(map[string]any)
+		if !ok {
+			continue
+		}
+		value, present := object[name]
+		switch {
+		case !present:
+			if defaultValue, ok := propertySchema["default"]; ok {
+				object[name] = defaultValue
+				changed = true
+			}
+		case value == nil && !required[name] && !allowsType(propertySchema, "null"):
+			// Models send null for optional arguments they mean to omit
+			delete(object, name)
+			changed = true
+		default:
+			coerced, valueChanged := coerceValue(propertySchema, value, joinArgumentPath(path, name), problems)
+			if valueChanged {
+				object[name] = coerced
+				changed = true
+			}
+		}
+	}
+
+	for _, name := range requiredFields {
+		if _, present := object[name]; !present {
+			*problems = append(*problems, fmt.Sprintf("%s is required", joinArgumentPath(path, name)))
+		}
+	}
+	return changed
+}
+
+// coerceValue converts a value into a type of its schema, returning the value and whether it changed. Values
+// that cannot be converted are reported as problems and returned as they are.
+func coerceValue(schema map[string]any, value any, path string, problems *[]string) (any, bool) {
+	types := schemaTypes(schema)
+	if len(types) == 0 {
+		if _, ok := schema["properties"]; !ok {
+			return value, false
+		}
+		types = []string{"object"}
+	}
+
+	for _, schemaType := range types {
+		if matchesType(value, schemaType) {
+			return coerceContents(schema, schemaType, value, path, problems)
+		}
+	}
+	for _, schemaType := range types {
+		if converted, ok := convertValue(value, schemaType); ok {
+			coerced, _ := coerceContents(schema, schemaType, converted, path, problems)
+			return coerced, true
+		}
+	}
+
+	*problems = append(*problems, fmt.Sprintf("%s must be of type %s", path, strings.Join(types, " or ")))
+	return value, false
+}
+
+// coerceContents coerces the items of an array or the properties of an object
+func coerceContents(schema map[string]any, schemaType string, value any, path string, problems *[]string) (any, bool) {
+	switch schemaType {
+	case "object":
+		object, _ := value.(map[string]any)
+		return object, coerceObject(schema, object, path, problems)
+	case "array":
+		items, _ := value.([]any)
+		itemSchema, ok := schema["items"].(map[string]any)
+		if !ok {
+			return items, false
+		}
+		changed := false
+		for i, item := range items {
+			coerced, itemChanged := coerceValue(itemSchema, item, fmt.Sprintf("%s[%d]", path, i), problems)
+			if itemChanged {
+				items[i] = coerced
+				changed = true
+			}
+		}
+		return items, changed
+	default:
+		return value, false
+	}
+}
+
+// convertValue converts a value into the type, when the value represents one of the type
+func convertValue(value any, schemaType string) (any, bool) {
+	switch schemaType {
+	case "integer", "number":
+		text, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		number := json.Number(strings.TrimSpace(text))
+		if !matchesType(number, schemaType) {
+			return nil, false
+		}
+		return number, true
+	case "boolean":
+		text, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		switch strings.ToLower(strings.TrimSpace(text)) {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+		return nil, false
+	case "string":
+		switch v := value.(type) {
+		case json.Number:
+			return v.String(), true
+		case bool:
+			return strconv.FormatBool(v), true
+		}
+		return nil, false
+	case "array":
+		if text, ok := value.(string); ok {
+			if parsed, ok := parseJSONArgument(text).([]any); ok {
+				return parsed, true
+			}
+		}
+		if value == nil {
+			return nil, false
+		}
+		// A single value stands for an array of it
+		return []any{value}, true
+	case "object":
+		if text, ok := value.(string); ok {
+			if parsed, ok := parseJSONArgument(text).(map[string]any); ok {
+				return parsed, true
+			}
+		}
+		return nil, false
+	default:
+		return nil, false
+	}
+}
+
+// matchesType returns whether a value decoded with UseNumber is of a JSON schema type
+func matchesType(value any, schemaType string) bool {
+	switch schemaType {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number", "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		f, err := strconv.ParseFloat(number.String(), 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) || !json.Valid([]byte(number)) {
+			return false
+		}
+		return schemaType == "number" || f == math.Trunc(f)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "null":
+		return value == nil
+	default:
+		return true
+	}
+}
+
+// schemaTypes returns the types of a schema, which JSON schema allows to be one type or a list of types
+func schemaTypes(schema map[string]any) []string {
+	switch t := schema["type"].(type) {
+	case string:
+		return []string{t}
+	case []any:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			if name, ok := item.(string); ok {
+				types = append(types, name)
+			}
+		}
+		return types
+	case []string:
+		return t
+	default:
+		return nil
+	}
+}
+
+func allowsType(schema map[string]any, schemaType string) bool {
+	for _, t := range schemaTypes(schema) {
+		if t == schemaType {
+			return true
+		}
+	}
+	return false
+}
+
+// parseJSONArgument parses a JSON array or object a model sent encoded in a string, or returns nil
+func parseJSONArgument(text string) any {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(strings.TrimSpace(text))))
+	decoder.UseNumber()
+	var parsed any
+	if err := decoder.Decode(&parsed); err != nil || decoder.More() {
+		return nil
+	}
+	return parsed
+}
+
+func joinArgumentPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,157 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+var forecastParameters = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"city":    map[string]any{"type": "string"},
+		"days":    map[string]any{"type": "integer"},
+		"celsius": map[string]any{"type": "boolean", "default": true},
+		"radius":  map[string]any{"type": "number"},
+		"hours":   map[string]any{"type": "array", "items": map[string]any{"type": "integer"}},
+		"units":   map[string]any{"type": "object", "properties": map[string]any{"precision": map[string]any{"type": "integer"}}},
+		"station": map[string]any{"type": []any{"string", "null"}},
+	},
+	"required": []any{"city", "days"},
+}
+
+func TestCoerceToolArguments(t *testing.T) {
+	tests := []struct {
+		name      string
+		coercion  string
+		arguments string
+		expected  string
+		errorMsg  string
+	}{
+		{
+			name:      "matching arguments are passed as they are",
+			coercion:  ArgumentCoercionLenient,
+			arguments: `{"city": "Paris", "days": 3, "celsius": false}`,
+			expected:  `{"city": "Paris", "days": 3, "celsius": false}`,
+		},
+		{
+			name:      "numbers and booleans sent as strings",
+			coercion:  ArgumentCoercionLenient,
+			arguments: `{"city":"Paris","days":"3","celsius":"FALSE","radius":" 2.5 "}`,
+			expected:  `{"celsius":false,"city":"Paris","days":3,"radius":2.5}`,
+		},
+		{
+			name:      "numbers sent for strings keep their precision",
+			coercion:  ArgumentCoercionLenient,
+			arguments: `{"city":75001,"days":12345678901234567890}`,
+			expected:  `{"celsius":true,"city":"75001","days":12345678901234567890}`,
+		},
+		{
+			name:      "null optional arguments are dropped and nullable ones kept",
+			coercion:  ArgumentCoercionLenient,
+			arguments: `{"city":"Paris","days":3,"celsius":false,"radius":null,"station":null}`,
+			expected:  `{"celsius":false,"city":"Paris","days":3,"station":null}`,
+		},
+		{
+			name:      "arrays and objects",
+			coercion:  ArgumentCoercionLenient,
+			arguments: `{"city":"Paris","days":3,"celsius":true,"hours":"9","units":"{\"precision\":\"1\"}"}`,
+			expected:  `{"celsius":true,"city":"Paris","days":3,"hours":[9],"units":{"precision":1}}`,
+		},
+		{
+			name:      "arrays sent as JSON strings",
+			coercion:  ArgumentCoercionLenient,
+			arguments: `{"city":"Paris","days":3,"celsius":true,"hours":"[9, \"12\"]"}`,
+			expected:  `{"celsius":true,"city":"Paris","days":3,"hours":[9,12]}`,
+		},
+		{
+			name:      "lenient coercion passes what it cannot convert",
+			coercion:  ArgumentCoercionLenient,
+			arguments: `{"city":"Paris","days":"three","celsius":true}`,
+			expected:  `{"city":"Paris","days":"three","celsius":true}`,
+		},
+		{
+			name:      "unset coercion passes arguments as they are",
+			arguments: `{"city":"Paris","days":"3"}`,
+			expected:  `{"city":"Paris","days":"3"}`,
+		},
+		{
+			name:      "no coercion",
+			coercion:  ArgumentCoercionNone,
+			arguments: `{"city":"Paris","days":"3"}`,
+			expected:  `{"city":"Paris","days":"3"}`,
+		},
+		{
+			name:      "strict coercion converts what it can",
+			coercion:  ArgumentCoercionStrict,
+			arguments: `{"city":"Paris","days":"3","celsius":true}`,
+			expected:  `{"celsius":true,"city":"Paris","days":3}`,
+		},
+		{
+			name:      "strict coercion fails arguments it cannot convert",
+			coercion:  ArgumentCoercionStrict,
+			arguments: `{"days":"3.5","units":{"precision":"high"}}`,
+			errorMsg:  "arguments of tool forecast do not match its input schema: city is required; days must be of type integer; units.precision must be of type integer",
+		},
+		{
+			name:      "strict coercion fails arguments that are not an object",
+			coercion:  ArgumentCoercionStrict,
+			arguments: `["Paris"]`,
+			errorMsg:  "arguments of tool forecast are not a JSON object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := ToolDefinition{Name: "forecast", Parameters: forecastParameters, ArgumentCoercion: tt.coercion}
+			arguments, err := coerceToolArguments(def, tt.arguments)
+			if tt.errorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+				var genaiErr *Error
+				require.True(t, errors.As(err, &genaiErr))
+				assert.Equal(t, ReasonSchemaViolation, genaiErr.Reason)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, arguments)
+		})
+	}
+}
+
+// argumentsExecutor records the arguments of the calls it executes
+type argumentsExecutor struct {
+	arguments []string
+}
+
+func (e *argumentsExecutor) Execute(ctx context.Context, call ToolCall, recorder EventEmitter) (ToolResult, error) {
+	e.arguments = append(e.arguments, call.Function.Arguments)
+	return ToolResult{ID: call.ID, Name: call.Function.Name, Content: "done"}, nil
+}
+
+func TestExecuteToolCoercesArguments(t *testing.T) {
+	registry := NewToolRegistry(nil, noop.NewToolRecorder())
+	executor := &argumentsExecutor{}
+	registry.RegisterTool(ToolDefinition{Name: "forecast", Parameters: forecastParameters, ArgumentCoercion: ArgumentCoercionStrict}, executor)
+
+	call := func(arguments string) ToolCall {
+		return ToolCall{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{Name: "forecast", Arguments: arguments}}
+	}
+
+	_, err := registry.ExecuteTool(context.Background(), call(`{"city":"Paris","days":"3"}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"celsius":true,"city":"Paris","days":3}`}, executor.arguments)
+
+	result, err := registry.ExecuteTool(context.Background(), call(`{"city":"Paris"}`), nil)
+	require.Error(t, err)
+	assert.Contains(t, result.Error, "days is required")
+	assert.Len(t, executor.arguments, 1, "the tool does not run with arguments that do not match")
+}
@@ -0,0 +1,89 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"path"
+	"sync"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// toolPolicy enforces an agent's tool policy on the calls made through its tool registry. The registry is
+// built for each execution of the agent, so the call count is per execution.
+type toolPolicy struct {
+	allow       []string
+	deny        []string
+	maxCalls    int
+	maxParallel int
+
+	mu      sync.Mutex
+	calls   int
+	running int
+}
+
+func newToolPolicy(spec *arkv1alpha1.AgentToolPolicy) *toolPolicy {
+	if spec == nil {
+		return nil
+	}
+	policy := &toolPolicy{allow: spec.Allow, deny: spec.Deny}
+	if spec.MaxCallsPerExecution != nil {
+		policy.maxCalls = int(*spec.MaxCallsPerExecution)
+	}
+	if spec.MaxParallelCalls != nil {
+		policy.maxParallel = int(*spec.MaxParallelCalls)
+	}
+	return policy
+}
+
+// allows reports whether the policy lets the agent call the tool. Deny patterns take precedence over allow
+// patterns, and every tool is allowed when there are no allow patterns.
+func (p *toolPolicy) allows(name string) bool {
+	if p == nil {
+		return true
+	}
+	if matchesToolPattern(p.deny, name) {
+		return false
+	}
+	return len(p.allow) == 0 || matchesToolPattern(p.allow, name)
+}
+
+// start admits a call to the tool, returning the function to call when it finishes, or the error to block
+// it with
+func (p *toolPolicy) start(name string) (func(), error) {
+	if p == nil {
+		return func() {}, nil
+	}
+	if !p.allows(name) {
+		return nil, Errorf(ReasonToolBlocked, "tool %s is not allowed by the agent's tool policy", name)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.maxCalls > 0 && p.calls >= p.maxCalls {
+		return nil, Errorf(ReasonToolBlocked, "tool %s exceeds the agent's limit of %d tool calls per execution", name, p.maxCalls)
+	}
+	if p.maxParallel > 0 && p.running >= p.maxParallel {
+		return nil, Errorf(ReasonToolBlocked, "tool %s exceeds the agent's limit of %d parallel tool calls", name, p.maxParallel)
+	}
+	p.calls++
+	p.running++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.running--
+		})
+	}, nil
+}
+
+func matchesToolPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+// blockingExecutor holds each call until it is released
+type blockingExecutor struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (e *blockingExecutor) Execute(ctx context.Context, call ToolCall, recorder EventEmitter) (ToolResult, error) {
+	e.started <- struct{}{}
+	<-e.release
+	return ToolResult{ID: call.ID, Name: call.Function.Name, Content: "done"}, nil
+}
+
+func policyRegistry(policy *arkv1alpha1.AgentToolPolicy, names ...string) *ToolRegistry {
+	registry := NewToolRegistry(nil, noop.NewToolRecorder())
+	for _, name := range names {
+		registry.RegisterTool(ToolDefinition{Name: name}, &NoopExecutor{})
+	}
+	registry.policy = newToolPolicy(policy)
+	return registry
+}
+
+func toolCall(name string) ToolCall {
+	return ToolCall{ID: "call-" + name, Function: openai.ChatCompletionMessageToolCallFunction{Name: name, Arguments: `{}`}}
+}
+
+func TestToolPolicyAllowAndDeny(t *testing.T) {
+	registry := policyRegistry(&arkv1alpha1.AgentToolPolicy{
+		Allow: []string{"github_*"},
+		Deny:  []string{"github_delete_*"},
+	}, "github_list_issues", "github_delete_repo", "send_email")
+
+	var offered []string
+	for _, tool := range registry.ToOpenAITools() {
+		offered = append(offered, tool.Function.Name)
+	}
+	assert.Equal(t, []string{"github_list_issues"}, offered)
+	assert.Len(t, registry.GetToolDefinitions(), 1)
+
+	recorder := &handoffEventRecorder{}
+	_, err := registry.ExecuteTool(t.Context(), toolCall("github_list_issues"), recorder)
+	require.NoError(t, err)
+
+	for _, name := range []string{"github_delete_repo", "send_email"} {
+		result, err := registry.ExecuteTool(t.Context(), toolCall(name), recorder)
+		require.Error(t, err)
+		assert.Equal(t, ReasonToolBlocked, ReasonFor(err))
+		assert.Contains(t, result.Error, "is not allowed by the agent's tool policy")
+	}
+	assert.Equal(t, []string{"ToolCallBlocked", "ToolCallBlocked"}, recorder.reasons)
+}
+
+func TestToolPolicyMaxCallsPerExecution(t *testing.T) {
+	registry := policyRegistry(&arkv1alpha1.AgentToolPolicy{MaxCallsPerExecution: ptr.To(int32(2))}, "noop")
+
+	for range 2 {
+		_, err := registry.ExecuteTool(t.Context(), toolCall("noop"), nil)
+		require.NoError(t, err)
+	}
+	_, err := registry.ExecuteTool(t.Context(), toolCall("noop"), nil)
+	assert.ErrorContains(t, err, "exceeds the agent's limit of 2 tool calls per execution")
+}
+
+func TestToolPolicyMaxParallelCalls(t *testing.T) {
+	registry := policyRegistry(&arkv1alpha1.AgentToolPolicy{MaxParallelCalls: ptr.To(int32(1))})
+	executor := &blockingExecutor{started: make(chan struct{}), release: make(chan struct{})}
+	registry.RegisterTool(ToolDefinition{Name: "slow"}, executor)
+
+	done := make(chan error)
+	go func() {
+		_, err := registry.ExecuteTool(context.Background(), toolCall("slow"), nil)
+		done <- err
+	}()
+	<-executor.started
+
+	_, err := registry.ExecuteTool(t.Context(), toolCall("slow"), nil)
+	assert.ErrorContains(t, err, "exceeds the agent's limit of 1 parallel tool calls")
+
+	close(executor.release)
+	require.NoError(t, <-done)
+
+	go func() { <-executor.started }()
+	_, err = registry.ExecuteTool(t.Context(), toolCall("slow"), nil)
+	assert.NoError(t, err, "the slot is free again once the first call finished")
+}
+
+func TestToolPolicyAllowsEverythingWithoutPolicy(t *testing.T) {
+	registry := policyRegistry(nil, "noop")
+	assert.Len(t, registry.ToOpenAITools(), 1)
+	_, err := registry.ExecuteTool(t.Context(), toolCall("noop"), nil)
+	assert.NoError(t, err)
+}
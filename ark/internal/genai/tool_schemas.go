@@ -0,0 +1,118 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/shared"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const (
+	// loadToolsName is the tool offered to the model in place of the agent's tools while their definitions are
+	// deferred, so that the model can ask for them
+	loadToolsName = "load_tools"
+
+	// minToolNameWordLength leaves out the short words of tool names, such as get or set, which most
+	// messages contain without suggesting tool use
+	minToolNameWordLength = 4
+)
+
+// toolSchemas defers the definitions of an agent's tools until the conversation suggests tool use. It is nil
+// when the definitions are always sent.
+type toolSchemas struct {
+	keywords []string
+}
+
+func newToolSchemas(spec *arkv1alpha1.AgentToolSchemas) *toolSchemas {
+	if spec == nil || spec.Mode != arkv1alpha1.ToolSchemasAdaptive {
+		return nil
+	}
+	schemas := &toolSchemas{}
+	for _, keyword := range spec.Keywords {
+		if keyword = strings.TrimSpace(strings.ToLower(keyword)); keyword != "" {
+			schemas.keywords = append(schemas.keywords, keyword)
+		}
+	}
+	return schemas
+}
+
+// defers reports whether the tool definitions are held back from the first model call of an execution: the
+// conversation has not used a tool yet, and the user message mentions neither a word of a tool name nor a keyword
+func (s *toolSchemas) defers(userInput Message, history []Message, toolNames []string) bool {
+	if s == nil || len(toolNames) == 0 {
+		return false
+	}
+
+	for _, message := range history {
+		if message.OfTool != nil || (message.OfAssistant != nil && len(message.OfAssistant.ToolCalls) > 0) {
+			return false
+		}
+	}
+
+	content := strings.ToLower(ExtractUserMessageContent([]Message{userInput}))
+	for _, keyword := range s.keywords {
+		if strings.Contains(content, keyword) {
+			return false
+		}
+	}
+	for _, name := range toolNames {
+		for _, word := range toolNameWords(name) {
+			if strings.Contains(content, word) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// toolNameWords splits a tool name, such as get_weather-forecast, into its lowercase words long enough to match
+func toolNameWords(name string) []string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return slices.DeleteFunc(words, func(word string) bool {
+		return len(word) < minToolNameWordLength
+	})
+}
+
+// loadToolsDefinition returns the definition of the tool the model calls to have the tools sent. It names the
+// tools, which costs far fewer tokens than their definitions.
+func loadToolsDefinition(toolNames []string) openai.ChatCompletionToolParam {
+	return openai.ChatCompletionToolParam{
+		Type: "function",
+		Function: shared.FunctionDefinitionParam{
+			Name: loadToolsName,
+			Description: openai.String(fmt.Sprintf("Makes the following tools available, call it before using any of them: %s",
+				strings.Join(toolNames, ", "))),
+			Parameters: shared.FunctionParameters{"type": "object", "properties": map[string]any{}},
+		},
+	}
+}
+
+// loadToolsResults answers the tool calls of a model that asked for the tools. Calls to other tools, which the
+// model did not know yet, are answered without running them, so that the model calls them again.
+func loadToolsResults(toolCalls []openai.ChatCompletionMessageToolCall) []Message {
+	messages := make([]Message, 0, len(toolCalls))
+	for _, toolCall := range toolCalls {
+		content := "The tools are now available."
+		if toolCall.Function.Name != loadToolsName {
+			content = fmt.Sprintf("Tool %s was not called as the tools were not loaded yet. Call it again.", toolCall.Function.Name)
+		}
+		messages = append(messages, ToolMessage(content, toolCall.ID))
+	}
+	return messages
+}
+
+// callsLoadTools reports whether the model asked for the tools
+func callsLoadTools(toolCalls []openai.ChatCompletionMessageToolCall) bool {
+	return slices.ContainsFunc(toolCalls, func(toolCall openai.ChatCompletionMessageToolCall) bool {
+		return toolCall.Function.Name == loadToolsName
+	})
+}
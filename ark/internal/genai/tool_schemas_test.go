@@ -0,0 +1,65 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func modelToolCall(name string) openai.ChatCompletionMessageToolCall {
+	return openai.ChatCompletionMessageToolCall{ID: "call-" + name, Function: openai.ChatCompletionMessageToolCallFunction{Name: name}}
+}
+
+func TestNewToolSchemas(t *testing.T) {
+	assert.Nil(t, newToolSchemas(nil))
+	assert.Nil(t, newToolSchemas(&arkv1alpha1.AgentToolSchemas{Mode: arkv1alpha1.ToolSchemasAlways}))
+
+	schemas := newToolSchemas(&arkv1alpha1.AgentToolSchemas{Mode: arkv1alpha1.ToolSchemasAdaptive, Keywords: []string{" Invoice ", ""}})
+	require.NotNil(t, schemas)
+	assert.Equal(t, []string{"invoice"}, schemas.keywords)
+}
+
+func TestToolSchemasDefers(t *testing.T) {
+	schemas := newToolSchemas(&arkv1alpha1.AgentToolSchemas{Mode: arkv1alpha1.ToolSchemasAdaptive, Keywords: []string{"invoice"}})
+	toolNames := []string{"get_weather-forecast", "search_docs"}
+
+	assert.True(t, schemas.defers(NewUserMessage("Hello, how are you?"), nil, toolNames))
+	assert.False(t, schemas.defers(NewUserMessage("What is the Weather in Paris?"), nil, toolNames), "a word of a tool name")
+	assert.False(t, schemas.defers(NewUserMessage("Send me the last INVOICE"), nil, toolNames), "a keyword")
+	assert.False(t, schemas.defers(NewUserMessage("Hello"), nil, nil), "no tools")
+
+	var always *toolSchemas
+	assert.False(t, always.defers(NewUserMessage("Hello"), nil, toolNames))
+
+	history := []Message{NewUserMessage("Hi"), ToolMessage("sunny", "call-1")}
+	assert.False(t, schemas.defers(NewUserMessage("Thanks"), history, toolNames), "the conversation used a tool")
+	history = []Message{NewUserMessage("Hi"), NewAssistantMessage("Hello")}
+	assert.True(t, schemas.defers(NewUserMessage("Thanks"), history, toolNames))
+}
+
+func TestToolNameWords(t *testing.T) {
+	assert.Equal(t, []string{"weather", "forecast"}, toolNameWords("get_weather-forecast"))
+	assert.Empty(t, toolNameWords("get_set"))
+}
+
+func TestLoadToolsResults(t *testing.T) {
+	toolCalls := []openai.ChatCompletionMessageToolCall{modelToolCall(loadToolsName), modelToolCall("search_docs")}
+	require.True(t, callsLoadTools(toolCalls))
+	assert.False(t, callsLoadTools(toolCalls[1:]))
+
+	results := loadToolsResults(toolCalls)
+	require.Len(t, results, 2)
+	assert.Equal(t, "call-"+loadToolsName, results[0].OfTool.ToolCallID)
+	assert.Equal(t, "The tools are now available.", results[0].OfTool.Content.OfString.Value)
+	assert.Contains(t, results[1].OfTool.Content.OfString.Value, "Tool search_docs was not called")
+
+	definition := loadToolsDefinition([]string{"search_docs", "get_weather"})
+	assert.Equal(t, loadToolsName, definition.Function.Name)
+	assert.Contains(t, definition.Function.Description.Value, "search_docs, get_weather")
+}
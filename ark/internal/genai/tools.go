@@ -20,6 +20,8 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
+	"mckinsey.com/ark/internal/labels"
 	"mckinsey.com/ark/internal/telemetry"
 )
 
@@ -27,6 +29,8 @@ type ToolDefinition struct {
 	Name        string         `json:"name"`
 	Description string         `json:"description"`
 	Parameters  map[string]any `json:"parameters"`
+	// ArgumentCoercion is how the arguments of calls are coerced into the types of the parameters, none when empty
+	ArgumentCoercion string `json:"-"`
 }
 
 // HTTPExecutor executes HTTP tools
@@ -75,6 +79,10 @@ func (h *HTTPExecutor) Execute(ctx context.Context, call ToolCall, recorder Even
 		}, fmt.Errorf("HTTP spec is required")
 	}
 
+	// Tools generated from an OpenAPI document template their headers from the arguments and leave optional
+	// parameters unset when the model does not give them
+	generated := tool.Labels[labels.OpenAPIToolLabel] != ""
+
 	// Substitute URL parameters
 	finalURL := h.substituteURLParameters(httpSpec.URL, arguments)
 
@@ -87,6 +95,9 @@ func (h *HTTPExecutor) Execute(ctx context.Context, call ToolCall, recorder Even
 			Error: fmt.Sprintf("invalid URL: %v", err),
 		}, fmt.Errorf("invalid URL: %w", err)
 	}
+	if generated {
+		dropUnsetQueryParameters(parsedURL)
+	}
 
 	// Determine HTTP method
 	method := httpSpec.Method
@@ -122,6 +133,9 @@ func (h *HTTPExecutor) Execute(ctx context.Context, call ToolCall, recorder Even
 	// Add headers
 	for _, header := range httpSpec.Headers {
 		value, err := h.resolveHeaderValue(ctx, header.Value, tool.Namespace)
+		if err == nil && generated {
+			value, err = resolveHeaderTemplate(value, arguments)
+		}
 		if err != nil {
 			return ToolResult{
 				ID:    call.ID,
@@ -129,15 +143,19 @@ func (h *HTTPExecutor) Execute(ctx context.Context, call ToolCall, recorder Even
 				Error: fmt.Sprintf("failed to resolve header %s: %v", header.Name, err),
 			}, fmt.Errorf("failed to resolve header %s: %w", header.Name, err)
 		}
+		// Headers templated from optional arguments that were not given are not sent
+		if generated && value == "" {
+			continue
+		}
 		req.Header.Set(header.Name, value)
 	}
 
 	// Set timeout
 	timeout := h.getTimeout(httpSpec.Timeout)
-	httpClient := &http.Client{Timeout: timeout}
+	httpClient := &http.Client{Timeout: timeout, Transport: common.NewInstrumentedTransport(common.HTTPClientTool, nil)}
 
 	// Make the request
-	log.Info("making HTTP request", "method", method, "url", parsedURL.String())
+	log.V(LogLevelDebug).Info("making HTTP request", "method", method, "url", parsedURL.String())
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return ToolResult{
@@ -169,7 +187,7 @@ func (h *HTTPExecutor) Execute(ctx context.Context, call ToolCall, recorder Even
 		}, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	log.Info("HTTP request completed", "status", resp.StatusCode, "responseSize", len(body))
+	log.V(LogLevelDebug).Info("HTTP request completed", "status", resp.StatusCode, "responseSize", len(body))
 
 	return ToolResult{
 		ID:      call.ID,
@@ -184,6 +202,8 @@ type ToolRegistry struct {
 	mcpPool      *MCPClientPool         // One MCP client pool per agent
 	mcpSettings  map[string]MCPSettings // MCP settings per MCP server (namespace/name)
 	toolRecorder telemetry.ToolRecorder
+	policy       *toolPolicy                // The agent's tool policy, nil when its calls are not restricted
+	test         *arkv1alpha1.AgentTestSpec // The test the agent answers, whose mocked tools are not run
 }
 
 func NewToolRegistry(mcpSettings map[string]MCPSettings, toolRecorder telemetry.ToolRecorder) *ToolRegistry {
@@ -204,7 +224,9 @@ func (tr *ToolRegistry) RegisterTool(def ToolDefinition, executor ToolExecutor)
 func (tr *ToolRegistry) GetToolDefinitions() []ToolDefinition {
 	definitions := make([]ToolDefinition, 0, len(tr.tools))
 	for _, def := range tr.tools {
-		definitions = append(definitions, def)
+		if tr.policy.allows(def.Name) {
+			definitions = append(definitions, def)
+		}
 	}
 	return definitions
 }
@@ -220,18 +242,32 @@ func (tr *ToolRegistry) GetToolType(toolName string) string {
 		return "builtin"
 	case *TerminateExecutor:
 		return "builtin"
+	case *PublishArtifactExecutor:
+		return "builtin"
+	case *RenderChartExecutor:
+		return "builtin"
+	case *SendEmailExecutor, *SendSlackMessageExecutor:
+		return "builtin"
+	case *RememberExecutor, *HandoffExecutor, *BlackboardReadExecutor, *BlackboardWriteExecutor:
+		return "builtin"
+	case *FetchURLExecutor, *CalculatorExecutor:
+		return "builtin"
 	case *HTTPExecutor:
 		return "custom"
 	case *MCPExecutor:
 		return "mcp"
+	case *WasmExecutor:
+		return "wasm"
 	case *FilteredToolExecutor:
 		return "filtered"
+	case *MockToolExecutor:
+		return "mock"
 	default:
 		return "unknown"
 	}
 }
 
-func (tr *ToolRegistry) ExecuteTool(ctx context.Context, call ToolCall, recorder EventEmitter) (ToolResult, error) {
+func (tr *ToolRegistry) ExecuteTool(ctx context.Context, call ToolCall, recorder EventEmitter) (result ToolResult, err error) {
 	executor, exists := tr.executors[call.Function.Name]
 	if !exists {
 		return ToolResult{
@@ -244,9 +280,32 @@ func (tr *ToolRegistry) ExecuteTool(ctx context.Context, call ToolCall, recorder
 	toolType := tr.GetToolType(call.Function.Name)
 	ctx, span := tr.toolRecorder.StartToolExecution(ctx, call.Function.Name, toolType, call.ID, call.Function.Arguments)
 	defer span.End()
+	start := time.Now()
+	defer func() { recordToolCall(ctx, call.Function.Name, toolType, start, err) }()
+
+	finish, err := tr.policy.start(call.Function.Name)
+	if err != nil {
+		tr.toolRecorder.RecordError(span, err)
+		if recorder != nil {
+			recorder.EmitEvent(ctx, corev1.EventTypeWarning, "ToolCallBlocked", BaseEvent{
+				Name:     call.Function.Name,
+				Metadata: map[string]string{"toolId": call.ID, "error": err.Error()},
+			})
+		}
+		return ToolResult{ID: call.ID, Name: call.Function.Name, Error: err.Error()}, err
+	}
+	defer finish()
+
+	arguments, err := coerceToolArguments(tr.tools[call.Function.Name], call.Function.Arguments)
+	if err != nil {
+		tr.toolRecorder.RecordError(span, err)
+		return ToolResult{ID: call.ID, Name: call.Function.Name, Error: err.Error()}, err
+	}
+	call.Function.Arguments = arguments
 
-	result, err := executor.Execute(ctx, call, recorder)
+	result, err = executor.Execute(ctx, call, recorder)
 	if err != nil {
+		err = newToolError(err)
 		tr.toolRecorder.RecordError(span, err)
 		return result, err
 	}
@@ -257,10 +316,35 @@ func (tr *ToolRegistry) ExecuteTool(ctx context.Context, call ToolCall, recorder
 	return result, nil
 }
 
+// ExecuteToolWithStream executes the tool like ExecuteTool and streams the start of the call, its
+// arguments and its result to the event stream, so clients can show tool progress in real time
+func (tr *ToolRegistry) ExecuteToolWithStream(ctx context.Context, call ToolCall, recorder EventEmitter, eventStream EventStreamInterface) (ToolResult, error) {
+	event := ToolCallEventData{ID: call.ID, Name: call.Function.Name}
+	StreamToolCallEvent(ctx, eventStream, ToolCallEventStarted, event)
+
+	event.Arguments = call.Function.Arguments
+	StreamToolCallEvent(ctx, eventStream, ToolCallEventArguments, event)
+
+	result, err := tr.ExecuteTool(ctx, call, recorder)
+
+	event.Arguments = ""
+	event.Result = result.Content
+	event.Error = result.Error
+	if err != nil && event.Error == "" {
+		event.Error = err.Error()
+	}
+	StreamToolCallEvent(ctx, eventStream, ToolCallEventResult, event)
+
+	return result, err
+}
+
 func (tr *ToolRegistry) ToOpenAITools() []openai.ChatCompletionToolParam {
 	tools := make([]openai.ChatCompletionToolParam, 0, len(tr.tools))
 
 	for _, def := range tr.tools {
+		if !tr.policy.allows(def.Name) {
+			continue
+		}
 		tool := openai.ChatCompletionToolParam{
 			Type: "function",
 			Function: shared.FunctionDefinitionParam{
@@ -293,7 +377,7 @@ type NoopExecutor struct{}
 func (n *NoopExecutor) Execute(ctx context.Context, call ToolCall, recorder EventEmitter) (ToolResult, error) {
 	var arguments map[string]any
 	if err := json.Unmarshal([]byte(call.Function.Arguments), &arguments); err != nil {
-		logf.Log.Info("Error parsing tool arguments", "ToolCall", call)
+		logf.FromContext(ctx).V(LogLevelDebug).Info("Error parsing tool arguments", "ToolCall", call)
 		arguments = make(map[string]any)
 	}
 	return ToolResult{
@@ -324,7 +408,7 @@ type TerminateExecutor struct{}
 func (t *TerminateExecutor) Execute(ctx context.Context, call ToolCall, recorder EventEmitter) (ToolResult, error) {
 	var arguments map[string]any
 	if err := json.Unmarshal([]byte(call.Function.Arguments), &arguments); err != nil {
-		logf.Log.Info("Error parsing tool arguments", "ToolCall", call)
+		logf.FromContext(ctx).V(LogLevelDebug).Info("Error parsing tool arguments", "ToolCall", call)
 		arguments = make(map[string]any)
 	}
 	if responseArg, exists := arguments["response"]; exists {
@@ -392,10 +476,36 @@ func (h *HTTPExecutor) substituteURLParameters(urlTemplate string, arguments map
 	return result
 }
 
+// dropUnsetQueryParameters removes the query parameters whose placeholder was not substituted, because the
+// model did not give the optional argument
+func dropUnsetQueryParameters(u *url.URL) {
+	if !strings.Contains(u.RawQuery, "{") {
+		return
+	}
+	query := u.Query()
+	for name, values := range query {
+		if len(values) == 1 && strings.HasPrefix(values[0], "{") && strings.HasSuffix(values[0], "}") {
+			query.Del(name)
+		}
+	}
+	u.RawQuery = query.Encode()
+}
+
+// resolveHeaderTemplate resolves a header value with golang template syntax against the tool arguments
+func resolveHeaderTemplate(value string, arguments map[string]any) (string, error) {
+	if !strings.Contains(value, "{{") {
+		return value, nil
+	}
+	if arguments == nil {
+		arguments = map[string]any{}
+	}
+	return common.ResolveTemplate(value, map[string]any{"input": arguments})
+}
+
 func CreateToolFromCRD(toolCRD *arkv1alpha1.Tool) ToolDefinition {
 	description := getToolDescription(toolCRD)
 	parameters := getToolParameters(toolCRD)
-	return ToolDefinition{Name: toolCRD.Name, Description: description, Parameters: parameters}
+	return ToolDefinition{Name: toolCRD.Name, Description: description, Parameters: parameters, ArgumentCoercion: toolCRD.Spec.ArgumentCoercion}
 }
 
 func CreatePartialToolDefinition(tooldefinition ToolDefinition, partial *arkv1alpha1.ToolPartial) (ToolDefinition, error) {
@@ -454,9 +564,10 @@ func CreatePartialToolDefinition(tooldefinition ToolDefinition, partial *arkv1al
 	}
 
 	return ToolDefinition{
-		Name:        newName,
-		Description: newDesc,
-		Parameters:  newParams,
+		Name:             newName,
+		Description:      newDesc,
+		Parameters:       newParams,
+		ArgumentCoercion: tooldefinition.ArgumentCoercion,
 	}, nil
 }
 
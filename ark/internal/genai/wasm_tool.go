@@ -0,0 +1,175 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const (
+	defaultWasmTimeout = 30 * time.Second
+	maxWasmOutputBytes = 1 << 20
+	maxWasmStderrBytes = 4 << 10
+)
+
+// wasmRuntime is the WASI runtime command the modules of wasm tools run with, e.g. wasmtime run. The path of the
+// module is appended to it.
+var wasmRuntime []string
+
+// wasmCacheDir holds the modules pulled for wasm tools, by the digest of their manifest
+var wasmCacheDir = filepath.Join(os.TempDir(), "ark-wasm")
+
+// SetWasmRuntime sets the WASI runtime command that runs the modules of wasm tools
+func SetWasmRuntime(command []string) {
+	wasmRuntime = command
+}
+
+// CheckWasmTool returns an error unless the wasm tool can run: the controller has a WASI runtime and the module is
+// pinned by digest
+func CheckWasmTool(spec *arkv1alpha1.WasmSpec) error {
+	if spec == nil {
+		return errors.New("wasm spec is required for wasm type")
+	}
+	if len(wasmRuntime) == 0 {
+		return errors.New("wasm tools are disabled: the WASI runtime is set with --wasm-runtime")
+	}
+	_, err := parseOCIReference(spec.Image)
+	return err
+}
+
+// WasmExecutor runs the WebAssembly module of a wasm tool for each call. The runtime gets no directories, network
+// or environment of the controller, so the module only sees the arguments on its stdin.
+type WasmExecutor struct {
+	K8sClient client.Client
+	ToolName  string
+	Namespace string
+	Spec      arkv1alpha1.WasmSpec
+}
+
+func createWasmExecutor(k8sClient client.Client, tool *arkv1alpha1.Tool, namespace string) (ToolExecutor, error) {
+	if err := CheckWasmTool(tool.Spec.Wasm); err != nil {
+		return nil, fmt.Errorf("tool %s: %w", tool.Name, err)
+	}
+	return &WasmExecutor{K8sClient: k8sClient, ToolName: tool.Name, Namespace: namespace, Spec: *tool.Spec.Wasm}, nil
+}
+
+// Execute implements ToolExecutor interface for wasm tools
+func (w *WasmExecutor) Execute(ctx context.Context, call ToolCall, recorder EventEmitter) (ToolResult, error) {
+	result := ToolResult{ID: call.ID, Name: call.Function.Name}
+	fail := func(err error) (ToolResult, error) {
+		result.Error = err.Error()
+		return result, err
+	}
+
+	module, err := w.module(ctx)
+	if err != nil {
+		return fail(err)
+	}
+
+	timeout := w.timeout()
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	arguments := call.Function.Arguments
+	if arguments == "" {
+		arguments = "{}"
+	}
+	stdout := &cappedBuffer{limit: maxWasmOutputBytes}
+	stderr := &cappedBuffer{limit: maxWasmStderrBytes}
+	cmd := exec.CommandContext(runCtx, wasmRuntime[0], append(wasmRuntime[1:], module)...)
+	cmd.Env = []string{}
+	cmd.Stdin = strings.NewReader(arguments)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	logf.FromContext(ctx).V(1).Info("running wasm tool", "tool", w.ToolName, "image", w.Spec.Image)
+	if err := cmd.Run(); err != nil {
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			return fail(fmt.Errorf("wasm tool %s timed out after %s", w.ToolName, timeout))
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fail(fmt.Errorf("wasm tool %s failed: %w: %s", w.ToolName, err, message))
+		}
+		return fail(fmt.Errorf("wasm tool %s failed: %w", w.ToolName, err))
+	}
+	if stdout.truncated {
+		return fail(fmt.Errorf("wasm tool %s wrote more than %d bytes", w.ToolName, maxWasmOutputBytes))
+	}
+
+	result.Content = stdout.String()
+	return result, nil
+}
+
+// module returns the path of the module of the tool, pulling it with the credentials of its pull secret
+func (w *WasmExecutor) module(ctx context.Context) (string, error) {
+	ref, err := parseOCIReference(w.Spec.Image)
+	if err != nil {
+		return "", err
+	}
+
+	var credentials *registryCredentials
+	if w.Spec.ImagePullSecret != "" {
+		var secret corev1.Secret
+		if err := w.K8sClient.Get(ctx, types.NamespacedName{Name: w.Spec.ImagePullSecret, Namespace: w.Namespace}, &secret); err != nil {
+			return "", fmt.Errorf("failed to get image pull secret %s: %w", w.Spec.ImagePullSecret, err)
+		}
+		if credentials, err = parseDockerConfig(secret.Data[corev1.DockerConfigJsonKey], ref.Registry); err != nil {
+			return "", fmt.Errorf("image pull secret %s: %w", w.Spec.ImagePullSecret, err)
+		}
+	}
+
+	path, err := pullWasmModule(ctx, ref, credentials, wasmCacheDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to pull module of wasm tool %s: %w", w.ToolName, err)
+	}
+	return path, nil
+}
+
+func (w *WasmExecutor) timeout() time.Duration {
+	if w.Spec.Timeout == "" {
+		return defaultWasmTimeout
+	}
+	value := w.Spec.Timeout
+	if !strings.HasSuffix(value, "s") && !strings.HasSuffix(value, "m") && !strings.HasSuffix(value, "h") {
+		value += "s"
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultWasmTimeout
+	}
+	return timeout
+}
+
+// cappedBuffer keeps the first limit bytes written to it and drops the rest, so a module cannot grow the memory of
+// the controller with its output
+type cappedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.Len(); remaining < len(p) {
+		b.truncated = true
+		if remaining > 0 {
+			b.Buffer.Write(p[:remaining])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
@@ -0,0 +1,224 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// TestWasmRuntimeHelperProcess is the WASI runtime the wasm tool tests run, by executing the test binary with the
+// path of the module as its last argument. The module names what the runtime does with the call.
+func TestWasmRuntimeHelperProcess(t *testing.T) {
+	args := flag.Args()
+	if len(args) == 0 || !strings.HasSuffix(args[len(args)-1], ".wasm") {
+		t.Skip("only runs as the WASI runtime of the wasm tool tests")
+	}
+	module, err := os.ReadFile(args[len(args)-1])
+	if err != nil {
+		os.Exit(2)
+	}
+	switch string(module) {
+	case "echo":
+		input, _ := io.ReadAll(os.Stdin)
+		fmt.Printf("called with %s and %d environment variables", input, len(os.Environ()))
+	case "fail":
+		fmt.Fprint(os.Stderr, "boom")
+		os.Exit(3)
+	case "sleep":
+		time.Sleep(10 * time.Second)
+	}
+	os.Exit(0)
+}
+
+// wasmRegistry serves a module as a single-layer OCI artifact, behind a token service like public registries
+type wasmRegistry struct {
+	server *httptest.Server
+	// manifest is served for digest, the digest of the manifest the registry was created with
+	manifest []byte
+	digest   string
+	pulls    atomic.Int32
+	// tokenAuth is the Authorization header of the last token request
+	tokenAuth atomic.Value
+}
+
+func newWasmRegistry(t *testing.T, module string) *wasmRegistry {
+	t.Helper()
+	registry := &wasmRegistry{}
+	layerDigest := digestOf([]byte(module))
+	registry.manifest, _ = json.Marshal(map[string]any{
+		"schemaVersion": 2,
+		"mediaType":     ociManifestMediaType,
+		"layers":        []map[string]any{{"mediaType": ociWasmLayerMediaType, "digest": layerDigest, "size": len(module)}},
+	})
+	registry.digest = digestOf(registry.manifest)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		registry.tokenAuth.Store(r.Header.Get("Authorization"))
+		if r.URL.Query().Get("scope") != "repository:tools/echo:pull" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "pull-token"})
+	})
+	mux.HandleFunc("/v2/tools/echo/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer pull-token" {
+			w.Header().Set(registryAuthHeaderName, fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:tools/echo:pull"`, registry.server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/tools/echo/manifests/" + registry.digest:
+			_, _ = w.Write(registry.manifest)
+		case "/v2/tools/echo/blobs/" + layerDigest:
+			registry.pulls.Add(1)
+			_, _ = w.Write([]byte(module))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	registry.server = httptest.NewTLSServer(mux)
+	t.Cleanup(registry.server.Close)
+
+	client := ociClient
+	ociClient = registry.server.Client()
+	t.Cleanup(func() { ociClient = client })
+	return registry
+}
+
+// image returns the reference of the module pinned by the digest of its manifest
+func (r *wasmRegistry) image() string {
+	return strings.TrimPrefix(r.server.URL, "https://") + "/tools/echo@" + r.digest
+}
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// useWasmRuntime runs modules with TestWasmRuntimeHelperProcess and caches them in a directory of the test
+func useWasmRuntime(t *testing.T) {
+	SetWasmRuntime([]string{os.Args[0], "-test.run=^TestWasmRuntimeHelperProcess$", "--"})
+	t.Cleanup(func() { SetWasmRuntime(nil) })
+	cacheDir := wasmCacheDir
+	wasmCacheDir = t.TempDir()
+	t.Cleanup(func() { wasmCacheDir = cacheDir })
+}
+
+func wasmCall(arguments string) ToolCall {
+	return ToolCall{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{Name: "echo", Arguments: arguments}}
+}
+
+func TestCheckWasmTool(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	assert.ErrorContains(t, CheckWasmTool(nil), "wasm spec is required")
+	assert.ErrorContains(t, CheckWasmTool(&arkv1alpha1.WasmSpec{Image: "ghcr.io/acme/echo@" + digest}), "set with --wasm-runtime")
+
+	useWasmRuntime(t)
+	assert.NoError(t, CheckWasmTool(&arkv1alpha1.WasmSpec{Image: "ghcr.io/acme/echo@" + digest}))
+	assert.NoError(t, CheckWasmTool(&arkv1alpha1.WasmSpec{Image: "ghcr.io/acme/echo:1.0@" + digest}))
+	assert.ErrorContains(t, CheckWasmTool(&arkv1alpha1.WasmSpec{Image: "ghcr.io/acme/echo:1.0"}), "must be pinned by digest")
+	assert.ErrorContains(t, CheckWasmTool(&arkv1alpha1.WasmSpec{Image: "ghcr.io/acme/echo@sha256:abc"}), "invalid digest")
+	assert.ErrorContains(t, CheckWasmTool(&arkv1alpha1.WasmSpec{Image: "echo@" + digest}), "must name its registry")
+}
+
+func TestWasmExecutorRunsModule(t *testing.T) {
+	useWasmRuntime(t)
+	registry := newWasmRegistry(t, "echo")
+	tool := &arkv1alpha1.Tool{
+		ObjectMeta: metav1.ObjectMeta{Name: "echo"},
+		Spec:       arkv1alpha1.ToolSpec{Type: ToolTypeWasm, Wasm: &arkv1alpha1.WasmSpec{Image: registry.image()}},
+	}
+	executor, err := createWasmExecutor(nil, tool, "default")
+	require.NoError(t, err)
+
+	result, err := executor.Execute(t.Context(), wasmCall(`{"text":"hi"}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, `called with {"text":"hi"} and 0 environment variables`, result.Content)
+
+	// The module is pulled once and run from the cache
+	result, err = executor.Execute(t.Context(), wasmCall(""), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "called with {} and 0 environment variables", result.Content)
+	assert.Equal(t, int32(1), registry.pulls.Load())
+}
+
+func TestWasmExecutorReportsFailures(t *testing.T) {
+	useWasmRuntime(t)
+	for module, expected := range map[string]string{
+		"fail":  "wasm tool echo failed: exit status 3: boom",
+		"sleep": "wasm tool echo timed out after 1s",
+	} {
+		t.Run(module, func(t *testing.T) {
+			registry := newWasmRegistry(t, module)
+			executor := &WasmExecutor{ToolName: "echo", Spec: arkv1alpha1.WasmSpec{Image: registry.image(), Timeout: "1"}}
+
+			result, err := executor.Execute(t.Context(), wasmCall("{}"), nil)
+			assert.EqualError(t, err, expected)
+			assert.Equal(t, expected, result.Error)
+		})
+	}
+}
+
+func TestWasmExecutorRejectsTamperedModule(t *testing.T) {
+	useWasmRuntime(t)
+	registry := newWasmRegistry(t, "echo")
+	registry.manifest = []byte(strings.Replace(string(registry.manifest), `"size":4`, `"size":5`, 1))
+
+	executor := &WasmExecutor{ToolName: "echo", Spec: arkv1alpha1.WasmSpec{Image: registry.image()}}
+	_, err := executor.Execute(t.Context(), wasmCall("{}"), nil)
+	assert.ErrorContains(t, err, "does not match")
+}
+
+func TestWasmExecutorPullsWithImagePullSecret(t *testing.T) {
+	useWasmRuntime(t)
+	registry := newWasmRegistry(t, "echo")
+	host := strings.TrimPrefix(registry.server.URL, "https://")
+
+	auth := base64.StdEncoding.EncodeToString([]byte("robot:s3cret"))
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "default"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(fmt.Sprintf(`{"auths":{"%s":{"auth":"%s"}}}`, host, auth))},
+	}).Build()
+
+	executor := &WasmExecutor{K8sClient: k8sClient, ToolName: "echo", Namespace: "default",
+		Spec: arkv1alpha1.WasmSpec{Image: registry.image(), ImagePullSecret: "registry"}}
+	_, err := executor.Execute(t.Context(), wasmCall("{}"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Basic "+auth, registry.tokenAuth.Load())
+}
+
+func TestCappedBuffer(t *testing.T) {
+	buffer := &cappedBuffer{limit: 4}
+	n, err := buffer.Write([]byte("abcdef"))
+	require.NoError(t, err)
+	assert.Equal(t, 6, n, "the writer is not failed, so the module is not killed by a broken pipe")
+	assert.Equal(t, "abcd", buffer.String())
+	assert.True(t, buffer.truncated)
+}
@@ -3,6 +3,30 @@
 package labels
 
 const (
-	MCPServerLabel = "mcp/server"
-	A2AServerLabel = "a2a/server"
+	MCPServerLabel   = "mcp/server"
+	A2AServerLabel   = "a2a/server"
+	OpenAPIToolLabel = "openapi/tool"
+
+	// OffboardLabel requests the decommissioning of a namespace when set to "true"
+	OffboardLabel = "ark.mckinsey.com/offboard"
+
+	// QueryShardLabel assigns the queries of a namespace to a shard of the controller replicas when query
+	// execution is sharded
+	QueryShardLabel = "ark.mckinsey.com/query-shard"
+
+	// QueryExecutorLabel dispatches the queries of a namespace to executor pods running as their service account
+	// when set to "enabled"
+	QueryExecutorLabel = "ark.mckinsey.com/query-executor"
+
+	// CronQueryLabel marks the queries created by a CronQuery with its name
+	CronQueryLabel = "ark.mckinsey.com/cron-query"
+
+	// AgentTestLabel marks the queries created by an AgentTest with its name
+	AgentTestLabel = "ark.mckinsey.com/agent-test"
+
+	// QueryArtifactsLabel marks the ConfigMap holding a query's artifacts with the query name
+	QueryArtifactsLabel = "query/artifacts"
+
+	// QuerySnapshotLabel marks the ConfigMap a soft-deleted query was snapshotted to with the query name
+	QuerySnapshotLabel = "query/snapshot"
 )
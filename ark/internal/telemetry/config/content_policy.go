@@ -0,0 +1,74 @@
+/* Copyright 2025. McKinsey & Company */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"mckinsey.com/ark/internal/telemetry"
+)
+
+const (
+	// ContentSampleRateEnv is the fraction of traces, between 0 and 1, that record prompts, completions and tool
+	// arguments and results
+	ContentSampleRateEnv = "ARK_TELEMETRY_CONTENT_SAMPLE_RATE"
+	// ContentMasksEnv holds regular expressions, one per line, whose matches are masked in recorded content
+	ContentMasksEnv = "ARK_TELEMETRY_CONTENT_MASKS"
+	// ContentMaxLengthEnv truncates recorded content to this many characters
+	ContentMaxLengthEnv = "ARK_TELEMETRY_CONTENT_MAX_LENGTH"
+	// ContentModeEnv is "full" to record content, or "hash" to record only its SHA-256
+	ContentModeEnv = "ARK_TELEMETRY_CONTENT_MODE"
+
+	ContentModeFull = "full"
+	ContentModeHash = "hash"
+)
+
+// ContentPolicyFromEnv returns the content policy configured by the ARK_TELEMETRY_CONTENT_* variables, or nil
+// when none is set and content is recorded as is
+func ContentPolicyFromEnv() (*telemetry.ContentPolicy, error) {
+	sampleRate := strings.TrimSpace(os.Getenv(ContentSampleRateEnv))
+	masks := strings.TrimSpace(os.Getenv(ContentMasksEnv))
+	maxLength := strings.TrimSpace(os.Getenv(ContentMaxLengthEnv))
+	mode := strings.TrimSpace(os.Getenv(ContentModeEnv))
+	if sampleRate == "" && masks == "" && maxLength == "" && mode == "" {
+		return nil, nil
+	}
+
+	policy := telemetry.DefaultContentPolicy()
+	if sampleRate != "" {
+		rate, err := strconv.ParseFloat(sampleRate, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("invalid %s %q, must be a number between 0 and 1", ContentSampleRateEnv, sampleRate)
+		}
+		policy.SampleRate = rate
+	}
+	for _, pattern := range strings.Split(masks, "\n") {
+		if pattern = strings.TrimSpace(pattern); pattern == "" {
+			continue
+		}
+		mask, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q in %s: %w", pattern, ContentMasksEnv, err)
+		}
+		policy.Masks = append(policy.Masks, mask)
+	}
+	if maxLength != "" {
+		length, err := strconv.Atoi(maxLength)
+		if err != nil || length < 0 {
+			return nil, fmt.Errorf("invalid %s %q, must be a non-negative integer", ContentMaxLengthEnv, maxLength)
+		}
+		policy.MaxLength = length
+	}
+	switch mode {
+	case "", ContentModeFull:
+	case ContentModeHash:
+		policy.HashOnly = true
+	default:
+		return nil, fmt.Errorf("invalid %s %q, must be %s or %s", ContentModeEnv, mode, ContentModeFull, ContentModeHash)
+	}
+	return policy, nil
+}
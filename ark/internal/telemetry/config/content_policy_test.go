@@ -0,0 +1,70 @@
+/* Copyright 2025. McKinsey & Company */
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otelapi "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"mckinsey.com/ark/internal/telemetry"
+	otelimpl "mckinsey.com/ark/internal/telemetry/otel"
+)
+
+func TestContentPolicyFromEnv(t *testing.T) {
+	policy, err := ContentPolicyFromEnv()
+	require.NoError(t, err)
+	assert.Nil(t, policy, "content is recorded as is without configuration")
+
+	t.Setenv(ContentSampleRateEnv, "0.25")
+	t.Setenv(ContentMasksEnv, "\\d{16}\n\n[\\w.]+@[\\w.]+\n")
+	t.Setenv(ContentMaxLengthEnv, "2000")
+	t.Setenv(ContentModeEnv, ContentModeHash)
+	policy, err = ContentPolicyFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, 0.25, policy.SampleRate)
+	assert.Len(t, policy.Masks, 2)
+	assert.Equal(t, 2000, policy.MaxLength)
+	assert.True(t, policy.HashOnly)
+
+	for env, value := range map[string]string{
+		ContentSampleRateEnv: "1.5",
+		ContentMasksEnv:      "[a-",
+		ContentMaxLengthEnv:  "-1",
+		ContentModeEnv:       "none",
+	} {
+		t.Run(env, func(t *testing.T) {
+			t.Setenv(env, value)
+			_, err := ContentPolicyFromEnv()
+			assert.ErrorContains(t, err, env)
+		})
+	}
+}
+
+func TestTracerAppliesContentPolicy(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	previous := otelapi.GetTracerProvider()
+	otelapi.SetTracerProvider(trace.NewTracerProvider(trace.WithSpanProcessor(spans)))
+	t.Cleanup(func() { otelapi.SetTracerProvider(previous) })
+
+	policy := &telemetry.ContentPolicy{SampleRate: 1, MaxLength: 5}
+	recorder := otelimpl.NewToolRecorder(otelimpl.NewTracerWithContentPolicy("test", policy))
+	_, span := recorder.StartToolExecution(context.Background(), "lookup", "builtin", "call-1", `{"account":"12345678"}`)
+	recorder.RecordToolResult(span, "balance is 100")
+	span.End()
+
+	require.Len(t, spans.Ended(), 1)
+	attributes := map[attribute.Key]string{}
+	for _, attr := range spans.Ended()[0].Attributes() {
+		attributes[attr.Key] = attr.Value.Emit()
+	}
+	assert.Equal(t, "lookup", attributes[telemetry.AttrToolName])
+	assert.Equal(t, `{"acc`, attributes[telemetry.AttrToolInput])
+	assert.Equal(t, "balan", attributes[telemetry.AttrToolOutput])
+}
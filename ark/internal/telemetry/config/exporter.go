@@ -0,0 +1,91 @@
+/* Copyright 2025. McKinsey & Company */
+
+package config
+
+import (
+	"context"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// maxPendingSpans bounds how many spans from failed exports are kept for retry
+const maxPendingSpans = 2048
+
+var (
+	exportedSpans = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "ark_telemetry_exported_spans_total",
+		Help: "Number of spans successfully exported to the telemetry backend",
+	})
+
+	exportFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "ark_telemetry_export_failures_total",
+		Help: "Number of failed span export attempts",
+	})
+
+	droppedSpans = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "ark_telemetry_dropped_spans_total",
+		Help: "Number of spans dropped because they could not be exported",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(exportedSpans, exportFailures, droppedSpans)
+}
+
+// retryingExporter keeps spans from failed exports and sends them again with the next batch,
+// so that a transient collector outage does not silently lose traces
+type retryingExporter struct {
+	trace.SpanExporter
+
+	mu      sync.Mutex
+	pending []trace.ReadOnlySpan
+}
+
+func newRetryingExporter(exporter trace.SpanExporter) *retryingExporter {
+	return &retryingExporter{SpanExporter: exporter}
+}
+
+func (e *retryingExporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.export(ctx, spans)
+}
+
+// retryPending exports the spans left over from failed exports
+func (e *retryingExporter) retryPending(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.pending) == 0 {
+		return nil
+	}
+	return e.export(ctx, nil)
+}
+
+// dropPending discards spans that could not be exported and returns how many were lost
+func (e *retryingExporter) dropPending() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	dropped := len(e.pending)
+	droppedSpans.Add(float64(dropped))
+	e.pending = nil
+	return dropped
+}
+
+func (e *retryingExporter) export(ctx context.Context, spans []trace.ReadOnlySpan) error {
+	batch := append(e.pending, spans...)
+	if err := e.SpanExporter.ExportSpans(ctx, batch); err != nil {
+		exportFailures.Inc()
+		if overflow := len(batch) - maxPendingSpans; overflow > 0 {
+			droppedSpans.Add(float64(overflow))
+			batch = batch[overflow:]
+		}
+		e.pending = batch
+		return err
+	}
+	exportedSpans.Add(float64(len(batch)))
+	e.pending = nil
+	return nil
+}
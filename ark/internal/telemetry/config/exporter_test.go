@@ -0,0 +1,75 @@
+/* Copyright 2025. McKinsey & Company */
+
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// flakyExporter fails a fixed number of exports before delegating to an in-memory exporter
+type flakyExporter struct {
+	*tracetest.InMemoryExporter
+	failures int
+}
+
+func (e *flakyExporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan) error {
+	if e.failures > 0 {
+		e.failures--
+		return errors.New("collector unavailable")
+	}
+	return e.InMemoryExporter.ExportSpans(ctx, spans)
+}
+
+func newTestSpans(names ...string) []trace.ReadOnlySpan {
+	stubs := make(tracetest.SpanStubs, len(names))
+	for i, name := range names {
+		stubs[i] = tracetest.SpanStub{Name: name}
+	}
+	return stubs.Snapshots()
+}
+
+func TestRetryingExporterResendsFailedSpans(t *testing.T) {
+	inner := &flakyExporter{InMemoryExporter: tracetest.NewInMemoryExporter(), failures: 1}
+	exporter := newRetryingExporter(inner)
+	ctx := context.Background()
+
+	require.Error(t, exporter.ExportSpans(ctx, newTestSpans("first")))
+	assert.Empty(t, inner.GetSpans())
+
+	require.NoError(t, exporter.ExportSpans(ctx, newTestSpans("second")))
+	spans := inner.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "first", spans[0].Name)
+	assert.Equal(t, "second", spans[1].Name)
+	assert.Zero(t, exporter.dropPending())
+}
+
+func TestRetryingExporterRetryPending(t *testing.T) {
+	inner := &flakyExporter{InMemoryExporter: tracetest.NewInMemoryExporter(), failures: 2}
+	exporter := newRetryingExporter(inner)
+	ctx := context.Background()
+
+	require.Error(t, exporter.ExportSpans(ctx, newTestSpans("span")))
+	require.Error(t, exporter.retryPending(ctx))
+	require.NoError(t, exporter.retryPending(ctx))
+	assert.Len(t, inner.GetSpans(), 1)
+}
+
+func TestRetryingExporterBoundsPendingSpans(t *testing.T) {
+	inner := &flakyExporter{InMemoryExporter: tracetest.NewInMemoryExporter(), failures: 1}
+	exporter := newRetryingExporter(inner)
+
+	names := make([]string, maxPendingSpans+10)
+	for i := range names {
+		names[i] = "span"
+	}
+	require.Error(t, exporter.ExportSpans(context.Background(), newTestSpans(names...)))
+	assert.Equal(t, maxPendingSpans, exporter.dropPending())
+}
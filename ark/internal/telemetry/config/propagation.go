@@ -0,0 +1,43 @@
+/* Copyright 2025. McKinsey & Company */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// PropagatorsEnv lists the formats trace context is propagated in to MCP servers, A2A servers, execution engines
+// and other services, as in other OpenTelemetry SDKs
+const PropagatorsEnv = "OTEL_PROPAGATORS"
+
+const defaultPropagators = "tracecontext,baggage"
+
+// newPropagator returns the propagator of the formats in OTEL_PROPAGATORS, W3C trace context and baggage by
+// default. Outbound requests carry the trace context of the span that made them, so the spans of the services
+// they call join the query's trace.
+func newPropagator() propagation.TextMapPropagator {
+	value := strings.TrimSpace(os.Getenv(PropagatorsEnv))
+	if value == "" {
+		value = defaultPropagators
+	}
+
+	var propagators []propagation.TextMapPropagator
+	for _, name := range strings.Split(value, ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "tracecontext":
+			propagators = append(propagators, propagation.TraceContext{})
+		case "baggage":
+			propagators = append(propagators, propagation.Baggage{})
+		case "none":
+			return propagation.NewCompositeTextMapPropagator()
+		case "":
+		default:
+			log.Error(fmt.Errorf("unsupported propagator %q", name), "ignoring propagator", "env", PropagatorsEnv)
+		}
+	}
+	return propagation.NewCompositeTextMapPropagator(propagators...)
+}
@@ -0,0 +1,20 @@
+/* Copyright 2025. McKinsey & Company */
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPropagator(t *testing.T) {
+	assert.ElementsMatch(t, []string{"traceparent", "tracestate", "baggage"}, newPropagator().Fields(),
+		"W3C trace context and baggage are propagated by default")
+
+	t.Setenv(PropagatorsEnv, "tracecontext, b3")
+	assert.ElementsMatch(t, []string{"traceparent", "tracestate"}, newPropagator().Fields(), "unsupported formats are ignored")
+
+	t.Setenv(PropagatorsEnv, "none")
+	assert.Empty(t, newPropagator().Fields())
+}
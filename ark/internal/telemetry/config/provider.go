@@ -4,7 +4,10 @@ package config
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"time"
 
 	otelapi "go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
@@ -14,12 +17,22 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"mckinsey.com/ark/internal/telemetry"
+	"mckinsey.com/ark/internal/telemetry/langfuse"
 	"mckinsey.com/ark/internal/telemetry/noop"
 	otelimpl "mckinsey.com/ark/internal/telemetry/otel"
 )
 
 var log = logf.Log.WithName("telemetry.config")
 
+const (
+	// shutdownTimeout bounds how long shutdown waits for buffered spans to be exported
+	shutdownTimeout = 10 * time.Second
+	// maxFlushAttempts is how many times shutdown tries to flush spans before giving up
+	maxFlushAttempts = 3
+	// flushRetryBackoff is the delay between flush attempts during shutdown
+	flushRetryBackoff = time.Second
+)
+
 // Provider manages telemetry lifecycle and provides tracers/recorders.
 type Provider struct {
 	tracer        telemetry.Tracer
@@ -31,31 +44,84 @@ type Provider struct {
 	shutdown      func() error
 }
 
+// BackendEnv selects where telemetry is sent: "otel" to the OTLP endpoint, "langfuse" directly to the Langfuse
+// ingestion API, or "noop". Unset, spans go to the OTLP endpoint when one is configured.
+const BackendEnv = "ARK_TELEMETRY_BACKEND"
+
+const (
+	BackendOTEL     = "otel"
+	BackendLangfuse = "langfuse"
+	BackendNoop     = "noop"
+)
+
 // NewProvider creates a telemetry provider based on configuration.
-// If OTEL endpoint is not configured, returns a no-op provider.
+// If no backend is configured, returns a no-op provider.
 func NewProvider() *Provider {
+	serviceName := os.Getenv("OTEL_SERVICE_NAME")
+	if serviceName == "" {
+		serviceName = "ark-controller"
+	}
+
+	backend := os.Getenv(BackendEnv)
+	if backend == "" {
+		backend = BackendNoop
+		if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" {
+			backend = BackendOTEL
+		}
+	}
+
+	switch backend {
+	case BackendOTEL:
+		return newOTELProvider(serviceName)
+	case BackendLangfuse:
+		return newLangfuseProvider(serviceName)
+	case BackendNoop:
+		log.Info("no telemetry backend configured, using no-op telemetry")
+		return newNoopProvider()
+	default:
+		log.Error(fmt.Errorf("unknown telemetry backend %q", backend), "falling back to no-op telemetry", "env", BackendEnv)
+		return newNoopProvider()
+	}
+}
+
+// newOTELProvider exports spans to the OTLP endpoint
+func newOTELProvider(serviceName string) *Provider {
 	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
 	if endpoint == "" {
 		log.Info("OTEL_EXPORTER_OTLP_ENDPOINT not set, using no-op telemetry")
 		return newNoopProvider()
 	}
 
-	serviceName := os.Getenv("OTEL_SERVICE_NAME")
-	if serviceName == "" {
-		serviceName = "ark-controller"
-	}
-
 	headers := os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")
 
 	log.Info("initializing OTEL telemetry", "endpoint", endpoint, "service", serviceName, "headers", headers)
 
 	// Auto-configure OTLP exporter from environment variables:
 	// OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_EXPORTER_OTLP_HEADERS, OTEL_SERVICE_NAME
-	exporter, err := otlptracehttp.New(context.Background())
+	otlpExporter, err := otlptracehttp.New(context.Background())
 	if err != nil {
 		log.Error(err, "failed to create OTLP exporter, falling back to no-op telemetry")
 		return newNoopProvider()
 	}
+	return newTracingProvider(serviceName, otlpExporter)
+}
+
+// newLangfuseProvider exports spans to the Langfuse ingestion API, configured from LANGFUSE_HOST,
+// LANGFUSE_PUBLIC_KEY and LANGFUSE_SECRET_KEY
+func newLangfuseProvider(serviceName string) *Provider {
+	langfuseExporter := langfuse.NewExporterFromEnv()
+	if langfuseExporter == nil {
+		log.Info("LANGFUSE_HOST, LANGFUSE_PUBLIC_KEY or LANGFUSE_SECRET_KEY not set, using no-op telemetry")
+		return newNoopProvider()
+	}
+
+	log.Info("initializing Langfuse telemetry", "host", langfuseExporter.Host, "service", serviceName)
+	return newTracingProvider(serviceName, langfuseExporter)
+}
+
+// newTracingProvider creates the OTEL-backed recorders, whose spans are batched to the exporter
+func newTracingProvider(serviceName string, spanExporter trace.SpanExporter) *Provider {
+	exporter := newRetryingExporter(spanExporter)
 
 	// Create trace provider
 	tp := trace.NewTracerProvider(
@@ -67,19 +133,30 @@ func NewProvider() *Provider {
 	)
 
 	otelapi.SetTracerProvider(tp)
+	otelapi.SetTextMapPropagator(newPropagator())
 
 	// Send startup event
 	sendStartupEvent(serviceName)
 
+	// Content that cannot be recorded as configured is not recorded at all, rather than recorded verbatim
+	contentPolicy, err := ContentPolicyFromEnv()
+	if err != nil {
+		log.Error(err, "invalid telemetry content policy, recording no content")
+		contentPolicy = &telemetry.ContentPolicy{SampleRate: 0}
+	} else if contentPolicy != nil {
+		log.Info("recording telemetry content under policy", "sampleRate", contentPolicy.SampleRate, "masks", len(contentPolicy.Masks),
+			"maxLength", contentPolicy.MaxLength, "hashOnly", contentPolicy.HashOnly)
+	}
+
 	// Create OTEL-backed implementations
-	tracer := otelimpl.NewTracer("ark/controller")
+	tracer := otelimpl.NewTracerWithContentPolicy("ark/controller", contentPolicy)
 	queryRecorder := otelimpl.NewQueryRecorder(tracer)
 	agentRecorder := otelimpl.NewAgentRecorder(tracer)
 	modelRecorder := otelimpl.NewModelRecorder(tracer)
 	toolRecorder := otelimpl.NewToolRecorder(tracer)
 	teamRecorder := otelimpl.NewTeamRecorder(tracer)
 
-	log.Info("OTEL telemetry initialized successfully")
+	log.Info("telemetry initialized successfully")
 
 	return &Provider{
 		tracer:        tracer,
@@ -90,7 +167,7 @@ func NewProvider() *Provider {
 		teamRecorder:  teamRecorder,
 		shutdown: func() error {
 			log.Info("shutting down telemetry")
-			return tp.Shutdown(context.Background())
+			return shutdownTracerProvider(tp, exporter)
 		},
 	}
 }
@@ -151,6 +228,38 @@ func (p *Provider) Shutdown() error {
 	return p.shutdown()
 }
 
+// shutdownTracerProvider flushes buffered spans, retrying failed exports a bounded number of times,
+// then shuts the provider down. Spans that still cannot be exported are counted as dropped.
+func shutdownTracerProvider(tp *trace.TracerProvider, exporter *retryingExporter) error {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	var flushErr error
+	for attempt := 1; attempt <= maxFlushAttempts; attempt++ {
+		flushErr = tp.ForceFlush(ctx)
+		if flushErr == nil {
+			flushErr = exporter.retryPending(ctx)
+		}
+		if flushErr == nil {
+			break
+		}
+		log.Error(flushErr, "failed to flush telemetry", "attempt", attempt, "maxAttempts", maxFlushAttempts)
+		if attempt == maxFlushAttempts || ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(flushRetryBackoff):
+		}
+	}
+
+	if dropped := exporter.dropPending(); dropped > 0 {
+		log.Info("dropped spans that could not be exported before shutdown", "spans", dropped)
+	}
+
+	return errors.Join(flushErr, tp.Shutdown(ctx))
+}
+
 // sendStartupEvent sends a basic startup event to validate telemetry.
 func sendStartupEvent(serviceName string) {
 	tracer := otelapi.Tracer("ark/controller-startup")
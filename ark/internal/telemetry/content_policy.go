@@ -0,0 +1,106 @@
+/* Copyright 2025. McKinsey & Company */
+
+package telemetry
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// RedactedContent replaces the matches of a content mask
+const RedactedContent = "[REDACTED]"
+
+// contentAttributes are the attributes that carry prompts, completions, tool arguments and results
+var contentAttributes = map[string]bool{
+	AttrQueryInput:      true,
+	AttrQueryOutput:     true,
+	AttrQueryRootInput:  true,
+	AttrQueryRootOutput: true,
+	AttrToolInput:       true,
+	AttrToolOutput:      true,
+	AttrMessagesInput:   true,
+	AttrMessagesOutput:  true,
+	AttrFeedbackComment: true,
+	"turn.output":       true,
+}
+
+// IsContentAttribute reports whether the attribute carries content rather than metadata, including the
+// llm.input_messages.* and llm.output_messages.* message contents and tool call arguments
+func IsContentAttribute(key string) bool {
+	if contentAttributes[key] {
+		return true
+	}
+	if strings.HasPrefix(key, "llm.input_messages.") || strings.HasPrefix(key, "llm.output_messages.") {
+		return strings.HasSuffix(key, ".content") || strings.HasSuffix(key, ".function.arguments")
+	}
+	return false
+}
+
+// ContentPolicy controls how content attributes are recorded on spans, so traces can be kept where prompts
+// and completions may not be stored verbatim. Metadata attributes such as names and token usage are not affected.
+type ContentPolicy struct {
+	// SampleRate is the fraction of traces whose content is recorded. Content is dropped from the other traces.
+	SampleRate float64
+	// Masks replace each of their matches with RedactedContent
+	Masks []*regexp.Regexp
+	// MaxLength truncates content to this many characters, 0 keeps it whole
+	MaxLength int
+	// HashOnly records the SHA-256 of the content instead of the content
+	HashOnly bool
+}
+
+// DefaultContentPolicy records all content as is
+func DefaultContentPolicy() *ContentPolicy {
+	return &ContentPolicy{SampleRate: 1}
+}
+
+// Samples reports whether the content of the trace is recorded. The decision only depends on the trace ID, so
+// the spans of a trace either all have content or none has.
+func (p *ContentPolicy) Samples(traceID [16]byte) bool {
+	if p.SampleRate >= 1 {
+		return true
+	}
+	if p.SampleRate <= 0 {
+		return false
+	}
+	// Trace ratio samplers decide on the low bytes of the ID, so the high bytes keep both decisions independent
+	return binary.BigEndian.Uint64(traceID[:8])>>1 < uint64(p.SampleRate*(1<<63))
+}
+
+// Redact returns the content as recorded under the policy
+func (p *ContentPolicy) Redact(content string) string {
+	if p.HashOnly {
+		sum := sha256.Sum256([]byte(content))
+		return "sha256:" + hex.EncodeToString(sum[:])
+	}
+	for _, mask := range p.Masks {
+		content = mask.ReplaceAllString(content, RedactedContent)
+	}
+	if p.MaxLength > 0 {
+		if runes := []rune(content); len(runes) > p.MaxLength {
+			content = string(runes[:p.MaxLength])
+		}
+	}
+	return content
+}
+
+// Apply returns the attributes of a span of the trace as recorded under the policy: content attributes are
+// redacted, or dropped when the trace's content is not sampled
+func (p *ContentPolicy) Apply(traceID [16]byte, attributes []Attribute) []Attribute {
+	result := make([]Attribute, 0, len(attributes))
+	for _, attr := range attributes {
+		content, ok := attr.Value.(string)
+		if !ok || !IsContentAttribute(attr.Key) {
+			result = append(result, attr)
+			continue
+		}
+		if !p.Samples(traceID) {
+			continue
+		}
+		result = append(result, String(attr.Key, p.Redact(content)))
+	}
+	return result
+}
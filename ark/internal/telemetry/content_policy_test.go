@@ -0,0 +1,45 @@
+/* Copyright 2025. McKinsey & Company */
+
+package telemetry
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsContentAttribute(t *testing.T) {
+	for _, key := range []string{AttrQueryInput, AttrToolOutput, "llm.input_messages.0.message.content", "llm.output_messages.0.message.tool_calls.1.function.arguments"} {
+		assert.True(t, IsContentAttribute(key), key)
+	}
+	for _, key := range []string{AttrQueryName, AttrTokensTotal, "llm.input_messages.0.message.role", "llm.output_messages.0.message.tool_calls.1.function.name"} {
+		assert.False(t, IsContentAttribute(key), key)
+	}
+}
+
+func TestContentPolicyRedact(t *testing.T) {
+	policy := &ContentPolicy{
+		SampleRate: 1,
+		Masks:      []*regexp.Regexp{regexp.MustCompile(`[\w.]+@[\w.]+`), regexp.MustCompile(`\b\d{4}-\d{4}\b`)},
+		MaxLength:  32,
+	}
+	assert.Equal(t, "Mail [REDACTED] about [REDACTED]", policy.Redact("Mail jane@example.com about 1234-5678"))
+	assert.Equal(t, "Mail [REDACTED] about [REDACTED]", policy.Redact("Mail jane@example.com about 1234-5678 today"), "masked content is truncated")
+
+	policy.HashOnly = true
+	assert.Equal(t, "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", policy.Redact("hello"))
+}
+
+func TestContentPolicySampling(t *testing.T) {
+	low := [16]byte{0x10, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	high := [16]byte{0xf0}
+	policy := &ContentPolicy{SampleRate: 0.5}
+	assert.True(t, policy.Samples(low))
+	assert.False(t, policy.Samples(high))
+
+	attributes := []Attribute{String(AttrQueryName, "weather"), String(AttrQueryInput, "What is the weather?"), Int(AttrMessagesInputCount, 2)}
+	assert.Equal(t, attributes, policy.Apply(low, attributes))
+	assert.Equal(t, []Attribute{String(AttrQueryName, "weather"), Int(AttrMessagesInputCount, 2)}, policy.Apply(high, attributes),
+		"the content of unsampled traces is dropped")
+}
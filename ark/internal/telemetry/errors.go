@@ -0,0 +1,20 @@
+/* Copyright 2025. McKinsey & Company */
+
+package telemetry
+
+import "errors"
+
+// reasonedError is implemented by errors that carry a machine-readable reason
+type reasonedError interface {
+	error
+	ErrorReason() string
+}
+
+// ErrorReason returns the machine-readable reason of err, or an empty string if it has none.
+func ErrorReason(err error) string {
+	var reasoned reasonedError
+	if errors.As(err, &reasoned) {
+		return reasoned.ErrorReason()
+	}
+	return ""
+}
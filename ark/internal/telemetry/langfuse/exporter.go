@@ -0,0 +1,247 @@
+/* Copyright 2025. McKinsey & Company */
+
+package langfuse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace"
+
+	"mckinsey.com/ark/internal/telemetry"
+)
+
+const (
+	ingestionPath = "/api/public/ingestion"
+	// maxBatchEvents bounds the events of an ingestion request, which Langfuse limits in size
+	maxBatchEvents = 200
+
+	eventTraceCreate      = "trace-create"
+	eventSpanCreate       = "span-create"
+	eventGenerationCreate = "generation-create"
+
+	openInferenceSpanKind = "openinference.span.kind"
+	inputMessagesPrefix   = "llm.input_messages."
+	outputMessagesPrefix  = "llm.output_messages."
+)
+
+// Exporter sends spans to the Langfuse ingestion API, so traces reach Langfuse without an OTLP collector. The
+// root span of a trace creates the Langfuse trace, model calls become generations and every other span a span.
+// Events are keyed by the trace and span IDs, so a batch that is sent again updates rather than duplicates them.
+type Exporter struct {
+	Host       string
+	PublicKey  string
+	SecretKey  string
+	HTTPClient *http.Client
+}
+
+// NewExporterFromEnv returns an exporter configured from LANGFUSE_HOST, LANGFUSE_PUBLIC_KEY and
+// LANGFUSE_SECRET_KEY, or nil when Langfuse is not configured.
+func NewExporterFromEnv() *Exporter {
+	client := NewScoreClientFromEnv()
+	if client == nil {
+		return nil
+	}
+	return &Exporter{Host: client.Host, PublicKey: client.PublicKey, SecretKey: client.SecretKey, HTTPClient: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// ingestionEvent is an event of an ingestion batch
+type ingestionEvent struct {
+	ID        string         `json:"id"`
+	Timestamp string         `json:"timestamp"`
+	Type      string         `json:"type"`
+	Body      map[string]any `json:"body"`
+}
+
+type ingestionResponse struct {
+	Errors []struct {
+		ID      string `json:"id"`
+		Status  int    `json:"status"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// ExportSpans sends the spans to Langfuse
+func (e *Exporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan) error {
+	var events []ingestionEvent
+	for _, span := range spans {
+		events = append(events, spanEvents(span)...)
+	}
+	for start := 0; start < len(events); start += maxBatchEvents {
+		end := min(start+maxBatchEvents, len(events))
+		if err := e.send(ctx, events[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown does nothing, as the exporter holds no resources
+func (e *Exporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func (e *Exporter) send(ctx context.Context, events []ingestionEvent) error {
+	body, err := json.Marshal(map[string]any{"batch": events})
+	if err != nil {
+		return fmt.Errorf("failed to encode ingestion batch: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.Host+ingestionPath, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create ingestion request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(e.PublicKey, e.SecretKey)
+
+	resp, err := e.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send spans to Langfuse: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("langfuse returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	// Langfuse accepts a batch with 207 and reports the events it rejected
+	var result ingestionResponse
+	if err := json.Unmarshal(message, &result); err == nil && len(result.Errors) > 0 {
+		first := result.Errors[0]
+		return fmt.Errorf("langfuse rejected %d of %d events, first with status %d: %s", len(result.Errors), len(events), first.Status, first.Message)
+	}
+	return nil
+}
+
+// spanEvents returns the events of a span: its observation, preceded by the trace when it is the root span
+func spanEvents(span trace.ReadOnlySpan) []ingestionEvent {
+	attrs := attributeMap(span.Attributes())
+	traceID := span.SpanContext().TraceID().String()
+	timestamp := formatTime(span.EndTime())
+
+	var events []ingestionEvent
+	if !span.Parent().IsValid() {
+		traceBody := map[string]any{
+			"id":        traceID,
+			"name":      span.Name(),
+			"timestamp": formatTime(span.StartTime()),
+			"metadata":  attrs,
+		}
+		setIfPresent(traceBody, "sessionId", attrs[telemetry.AttrSessionID])
+		setIfPresent(traceBody, "input", firstPresent(attrs, telemetry.AttrQueryRootInput, telemetry.AttrQueryInput))
+		setIfPresent(traceBody, "output", firstPresent(attrs, telemetry.AttrQueryRootOutput, telemetry.AttrQueryOutput))
+		events = append(events, ingestionEvent{ID: uuid.NewString(), Timestamp: timestamp, Type: eventTraceCreate, Body: traceBody})
+	}
+
+	observation := map[string]any{
+		"id":        span.SpanContext().SpanID().String(),
+		"traceId":   traceID,
+		"name":      span.Name(),
+		"startTime": formatTime(span.StartTime()),
+		"endTime":   timestamp,
+		"metadata":  attrs,
+	}
+	if span.Parent().IsValid() {
+		observation["parentObservationId"] = span.Parent().SpanID().String()
+	}
+	if span.Status().Code == codes.Error {
+		observation["level"] = "ERROR"
+		observation["statusMessage"] = span.Status().Description
+	}
+
+	eventType := eventSpanCreate
+	if attrs[openInferenceSpanKind] == "LLM" && attrs[telemetry.AttrModelType] != nil {
+		eventType = eventGenerationCreate
+		observation["model"] = attrs[telemetry.AttrModelName]
+		setIfPresent(observation, "input", messagesFromAttributes(attrs, inputMessagesPrefix))
+		setIfPresent(observation, "output", messagesFromAttributes(attrs, outputMessagesPrefix))
+		if attrs[telemetry.AttrTokensTotal] != nil {
+			observation["usage"] = map[string]any{
+				"input":  attrs[telemetry.AttrTokensPrompt],
+				"output": attrs[telemetry.AttrTokensCompletion],
+				"total":  attrs[telemetry.AttrTokensTotal],
+			}
+		}
+	}
+	if observation["input"] == nil {
+		setIfPresent(observation, "input", firstPresent(attrs, telemetry.AttrQueryRootInput, telemetry.AttrQueryInput, telemetry.AttrToolInput, telemetry.AttrMessagesInput))
+	}
+	if observation["output"] == nil {
+		setIfPresent(observation, "output", firstPresent(attrs, telemetry.AttrQueryRootOutput, telemetry.AttrQueryOutput, telemetry.AttrToolOutput, telemetry.AttrMessagesOutput))
+	}
+
+	return append(events, ingestionEvent{ID: uuid.NewString(), Timestamp: timestamp, Type: eventType, Body: observation})
+}
+
+func attributeMap(attrs []attribute.KeyValue) map[string]any {
+	values := make(map[string]any, len(attrs))
+	for _, attr := range attrs {
+		values[string(attr.Key)] = attr.Value.AsInterface()
+	}
+	return values
+}
+
+// messagesFromAttributes rebuilds the messages recorded as <prefix><index>.message.<field> attributes
+func messagesFromAttributes(attrs map[string]any, prefix string) []map[string]any {
+	byIndex := map[int]map[string]any{}
+	for key, value := range attrs {
+		rest, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+		indexText, field, ok := strings.Cut(rest, ".message.")
+		index, err := strconv.Atoi(indexText)
+		if !ok || err != nil || strings.Contains(field, ".") {
+			continue
+		}
+		if byIndex[index] == nil {
+			byIndex[index] = map[string]any{}
+		}
+		byIndex[index][field] = value
+	}
+	if len(byIndex) == 0 {
+		return nil
+	}
+
+	indexes := make([]int, 0, len(byIndex))
+	for index := range byIndex {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	messages := make([]map[string]any, 0, len(indexes))
+	for _, index := range indexes {
+		messages = append(messages, byIndex[index])
+	}
+	return messages
+}
+
+func firstPresent(attrs map[string]any, keys ...string) any {
+	for _, key := range keys {
+		if value, ok := attrs[key]; ok {
+			return value
+		}
+	}
+	return nil
+}
+
+func setIfPresent(body map[string]any, key string, value any) {
+	if messages, ok := value.([]map[string]any); value == nil || (ok && messages == nil) {
+		return
+	}
+	body[key] = value
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
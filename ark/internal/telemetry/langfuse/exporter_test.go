@@ -0,0 +1,124 @@
+/* Copyright 2025. McKinsey & Company */
+
+package langfuse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"mckinsey.com/ark/internal/telemetry"
+)
+
+func testSpans() []sdktrace.ReadOnlySpan {
+	traceID := oteltrace.TraceID{1}
+	root := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{TraceID: traceID, SpanID: oteltrace.SpanID{1}})
+	model := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{TraceID: traceID, SpanID: oteltrace.SpanID{2}})
+	start := time.Date(2025, 10, 2, 10, 0, 0, 0, time.UTC)
+
+	return tracetest.SpanStubs{
+		{
+			Name:        "query.weather",
+			SpanContext: root,
+			StartTime:   start,
+			EndTime:     start.Add(2 * time.Second),
+			Attributes: []attribute.KeyValue{
+				attribute.String(telemetry.AttrSessionID, "session-1"),
+				attribute.String(telemetry.AttrQueryRootInput, "What is the weather?"),
+				attribute.String(telemetry.AttrQueryRootOutput, "Sunny"),
+			},
+		},
+		{
+			Name:        "llm.gpt-4o",
+			SpanContext: model,
+			Parent:      root,
+			StartTime:   start,
+			EndTime:     start.Add(time.Second),
+			Attributes: []attribute.KeyValue{
+				attribute.String(openInferenceSpanKind, "LLM"),
+				attribute.String(telemetry.AttrModelName, "gpt-4o"),
+				attribute.String(telemetry.AttrModelType, "openai"),
+				attribute.String("llm.input_messages.1.message.role", "user"),
+				attribute.String("llm.input_messages.1.message.content", "What is the weather?"),
+				attribute.String("llm.input_messages.0.message.role", "system"),
+				attribute.String("llm.input_messages.0.message.content", "You are a weather agent"),
+				attribute.String("llm.output_messages.0.message.content", "Sunny"),
+				attribute.Int64(telemetry.AttrTokensPrompt, 12),
+				attribute.Int64(telemetry.AttrTokensCompletion, 3),
+				attribute.Int64(telemetry.AttrTokensTotal, 15),
+			},
+			Status: sdktrace.Status{Code: codes.Error, Description: "rate limited"},
+		},
+	}.Snapshots()
+}
+
+func TestExporterSendsTracesAndGenerations(t *testing.T) {
+	var batch []ingestionEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, ingestionPath, r.URL.Path)
+		user, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "pk", user)
+		assert.Equal(t, "sk", password)
+		var body struct {
+			Batch []ingestionEvent `json:"batch"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		batch = body.Batch
+		w.WriteHeader(http.StatusMultiStatus)
+		_, _ = w.Write([]byte(`{"successes":[],"errors":[]}`))
+	}))
+	defer server.Close()
+
+	exporter := &Exporter{Host: server.URL, PublicKey: "pk", SecretKey: "sk", HTTPClient: server.Client()}
+	require.NoError(t, exporter.ExportSpans(context.Background(), testSpans()))
+
+	require.Len(t, batch, 3)
+	assert.Equal(t, eventTraceCreate, batch[0].Type)
+	assert.Equal(t, oteltrace.TraceID{1}.String(), batch[0].Body["id"])
+	assert.Equal(t, "session-1", batch[0].Body["sessionId"])
+	assert.Equal(t, "What is the weather?", batch[0].Body["input"])
+	assert.Equal(t, eventSpanCreate, batch[1].Type)
+	assert.NotContains(t, batch[1].Body, "parentObservationId")
+
+	generation := batch[2]
+	assert.Equal(t, eventGenerationCreate, generation.Type)
+	assert.Equal(t, oteltrace.SpanID{1}.String(), generation.Body["parentObservationId"])
+	assert.Equal(t, "gpt-4o", generation.Body["model"])
+	assert.Equal(t, "ERROR", generation.Body["level"])
+	assert.Equal(t, "rate limited", generation.Body["statusMessage"])
+	assert.Equal(t, map[string]any{"input": float64(12), "output": float64(3), "total": float64(15)}, generation.Body["usage"])
+	assert.Equal(t, []any{
+		map[string]any{"role": "system", "content": "You are a weather agent"},
+		map[string]any{"role": "user", "content": "What is the weather?"},
+	}, generation.Body["input"])
+}
+
+func TestExporterReportsRejectedEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMultiStatus)
+		_, _ = w.Write([]byte(`{"successes":[],"errors":[{"id":"1","status":400,"message":"invalid body"}]}`))
+	}))
+	defer server.Close()
+
+	exporter := &Exporter{Host: server.URL, PublicKey: "pk", SecretKey: "sk", HTTPClient: server.Client()}
+	err := exporter.ExportSpans(context.Background(), testSpans())
+	assert.ErrorContains(t, err, "rejected 1 of 3 events")
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	err = exporter.ExportSpans(context.Background(), testSpans())
+	assert.ErrorContains(t, err, "status 401")
+}
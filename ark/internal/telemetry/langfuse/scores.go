@@ -0,0 +1,79 @@
+/* Copyright 2025. McKinsey & Company */
+
+package langfuse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const scoresPath = "/api/public/scores"
+
+// Score is a Langfuse score attached to a trace
+type Score struct {
+	// ID makes creating the score idempotent: Langfuse updates a score sent again with the same ID
+	ID      string  `json:"id,omitempty"`
+	TraceID string  `json:"traceId"`
+	Name    string  `json:"name"`
+	Value   float64 `json:"value"`
+	Comment string  `json:"comment,omitempty"`
+}
+
+// ScoreClient creates scores through the Langfuse public API
+type ScoreClient struct {
+	Host       string
+	PublicKey  string
+	SecretKey  string
+	HTTPClient *http.Client
+}
+
+// NewScoreClientFromEnv returns a client configured from LANGFUSE_HOST, LANGFUSE_PUBLIC_KEY and
+// LANGFUSE_SECRET_KEY, or nil when Langfuse is not configured.
+func NewScoreClientFromEnv() *ScoreClient {
+	host := os.Getenv("LANGFUSE_HOST")
+	publicKey := os.Getenv("LANGFUSE_PUBLIC_KEY")
+	secretKey := os.Getenv("LANGFUSE_SECRET_KEY")
+	if host == "" || publicKey == "" || secretKey == "" {
+		return nil
+	}
+	return &ScoreClient{
+		Host:       strings.TrimSuffix(host, "/"),
+		PublicKey:  publicKey,
+		SecretKey:  secretKey,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// CreateScore sends the score to Langfuse
+func (c *ScoreClient) CreateScore(ctx context.Context, score Score) error {
+	body, err := json.Marshal(score)
+	if err != nil {
+		return fmt.Errorf("failed to encode score: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Host+scoresPath, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create score request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.PublicKey, c.SecretKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send score to Langfuse: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("langfuse returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
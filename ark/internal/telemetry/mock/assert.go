@@ -0,0 +1,167 @@
+/* Copyright 2025. McKinsey & Company */
+
+package mock
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// TestingT is the subset of testing.T used by the assertion helpers.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// FindSpans returns all spans with the given name, in start order.
+func (t *MockTracer) FindSpans(name string) []*MockSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var spans []*MockSpan
+	for _, span := range t.Spans {
+		if span.Name == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// RootSpans returns the spans started without a parent span, in start order.
+func (t *MockTracer) RootSpans() []*MockSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var spans []*MockSpan
+	for _, span := range t.Spans {
+		if span.Parent == nil {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// FindChildren returns the direct children of a span, optionally filtered by name.
+func (t *MockTracer) FindChildren(parent *MockSpan, names ...string) []*MockSpan {
+	if parent == nil {
+		return nil
+	}
+
+	parent.mu.Lock()
+	defer parent.mu.Unlock()
+
+	var children []*MockSpan
+	for _, child := range parent.Children {
+		if len(names) == 0 || slices.Contains(names, child.Name) {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// AttributeMatcher checks a span attribute and describes the mismatch, if any.
+type AttributeMatcher func(span *MockSpan) error
+
+// HasAttr matches spans whose attribute key equals value.
+func HasAttr(key string, value any) AttributeMatcher {
+	return func(span *MockSpan) error {
+		actual := span.GetAttribute(key)
+		if !span.HasAttribute(key) || fmt.Sprint(actual) != fmt.Sprint(value) {
+			return fmt.Errorf("attribute %q: expected %v, got %v", key, value, actual)
+		}
+		return nil
+	}
+}
+
+// HasAttrKey matches spans that have the attribute key, whatever its value.
+func HasAttrKey(key string) AttributeMatcher {
+	return func(span *MockSpan) error {
+		if !span.HasAttribute(key) {
+			return fmt.Errorf("attribute %q: expected to be set", key)
+		}
+		return nil
+	}
+}
+
+// AttrContains matches spans whose string attribute key contains substr.
+func AttrContains(key, substr string) AttributeMatcher {
+	return func(span *MockSpan) error {
+		actual := span.GetAttributeString(key)
+		if !strings.Contains(actual, substr) {
+			return fmt.Errorf("attribute %q: expected to contain %q, got %q", key, substr, actual)
+		}
+		return nil
+	}
+}
+
+// SpanNode describes an expected span and its expected children for AssertSpanTree.
+type SpanNode struct {
+	Name       string
+	Attributes []AttributeMatcher
+	// Children are matched in order against the span's children. Children not listed are ignored.
+	Children []SpanNode
+}
+
+// Node is shorthand for building a SpanNode.
+func Node(name string, children ...SpanNode) SpanNode {
+	return SpanNode{Name: name, Children: children}
+}
+
+// WithAttrs returns a copy of the node that also requires the given attribute matchers.
+func (n SpanNode) WithAttrs(matchers ...AttributeMatcher) SpanNode {
+	n.Attributes = append(append([]AttributeMatcher{}, n.Attributes...), matchers...)
+	return n
+}
+
+// AssertSpanTree checks that span matches the expected tree. Each expected child must match a child of the
+// span, in order, but the span may have additional children. It reports every mismatch and returns whether
+// the tree matched.
+func AssertSpanTree(t TestingT, span *MockSpan, expected SpanNode) bool {
+	t.Helper()
+
+	problems := matchSpanTree(span, expected, expected.Name)
+	for _, problem := range problems {
+		t.Errorf("%s", problem)
+	}
+	return len(problems) == 0
+}
+
+func matchSpanTree(span *MockSpan, expected SpanNode, path string) []string {
+	if span == nil {
+		return []string{fmt.Sprintf("%s: span not found", path)}
+	}
+	if span.Name != expected.Name {
+		return []string{fmt.Sprintf("%s: expected span %q, got %q", path, expected.Name, span.Name)}
+	}
+
+	var problems []string
+	for _, matcher := range expected.Attributes {
+		if err := matcher(span); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", path, err))
+		}
+	}
+
+	span.mu.Lock()
+	children := append([]*MockSpan{}, span.Children...)
+	span.mu.Unlock()
+
+	next := 0
+	for _, expectedChild := range expected.Children {
+		childPath := path + " > " + expectedChild.Name
+		found := false
+		for next < len(children) {
+			child := children[next]
+			next++
+			if child.Name == expectedChild.Name {
+				problems = append(problems, matchSpanTree(child, expectedChild, childPath)...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s: span not found", childPath))
+		}
+	}
+	return problems
+}
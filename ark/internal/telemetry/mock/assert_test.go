@@ -0,0 +1,87 @@
+/* Copyright 2025. McKinsey & Company */
+
+package mock
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mckinsey.com/ark/internal/telemetry"
+)
+
+// recordingT captures assertion failures instead of failing the test
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func startTeamTree(tracer *MockTracer) *MockSpan {
+	ctx := context.Background()
+	ctx, team := tracer.Start(ctx, "team.execution", telemetry.WithAttributes(telemetry.String(telemetry.AttrTeamName, "research")))
+	turnCtx, _ := tracer.Start(ctx, "team.turn")
+	agentCtx, agent := tracer.Start(turnCtx, "agent.execution")
+	agent.SetAttributes(telemetry.String(telemetry.AttrAgentName, "researcher"))
+	_, _ = tracer.Start(agentCtx, "llm.call")
+	_, _ = tracer.Start(agentCtx, "tool.execution", telemetry.WithAttributes(telemetry.String(telemetry.AttrToolInput, `{"query":"ark"}`)))
+	return team.(*MockSpan)
+}
+
+func TestMockTracerTracksParents(t *testing.T) {
+	tracer := NewTracer()
+	team := startTeamTree(tracer)
+
+	require.Len(t, tracer.RootSpans(), 1)
+	assert.Same(t, team, tracer.RootSpans()[0])
+
+	turns := tracer.FindChildren(team, "team.turn")
+	require.Len(t, turns, 1)
+	agents := tracer.FindChildren(turns[0])
+	require.Len(t, agents, 1)
+	assert.Len(t, tracer.FindChildren(agents[0]), 2)
+	assert.Len(t, tracer.FindChildren(agents[0], "tool.execution"), 1)
+	assert.Same(t, agents[0], tracer.FindSpans("tool.execution")[0].Parent)
+}
+
+func TestAssertSpanTree(t *testing.T) {
+	tracer := NewTracer()
+	team := startTeamTree(tracer)
+
+	AssertSpanTree(t, team,
+		Node("team.execution",
+			Node("team.turn",
+				Node("agent.execution",
+					Node("tool.execution").WithAttrs(AttrContains(telemetry.AttrToolInput, "ark")),
+				).WithAttrs(HasAttr(telemetry.AttrAgentName, "researcher")),
+			),
+		).WithAttrs(HasAttrKey(telemetry.AttrTeamName)),
+	)
+}
+
+func TestAssertSpanTreeReportsMismatches(t *testing.T) {
+	tracer := NewTracer()
+	team := startTeamTree(tracer)
+
+	recorder := &recordingT{}
+	ok := AssertSpanTree(recorder, team,
+		Node("team.execution",
+			Node("team.turn",
+				Node("agent.execution").WithAttrs(HasAttr(telemetry.AttrAgentName, "writer")),
+				Node("agent.execution"),
+			),
+		),
+	)
+
+	assert.False(t, ok)
+	require.Len(t, recorder.errors, 2)
+	assert.Contains(t, recorder.errors[0], `expected writer, got researcher`)
+	assert.Contains(t, recorder.errors[1], "team.execution > team.turn > agent.execution: span not found")
+}
@@ -33,13 +33,31 @@ func (t *MockTracer) Start(ctx context.Context, spanName string, opts ...telemet
 		Attributes: make(map[string]interface{}),
 		Events:     make([]MockEvent, 0),
 		Config:     cfg,
+		Parent:     spanFromContext(ctx),
+	}
+	for _, attr := range cfg.Attributes {
+		span.Attributes[attr.Key] = attr.Value
+	}
+
+	if span.Parent != nil {
+		span.Parent.mu.Lock()
+		span.Parent.Children = append(span.Parent.Children, span)
+		span.Parent.mu.Unlock()
 	}
 
 	t.mu.Lock()
 	t.Spans = append(t.Spans, span)
 	t.mu.Unlock()
 
-	return ctx, span
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+// spanContextKey is the context key under which the active mock span is stored
+type spanContextKey struct{}
+
+func spanFromContext(ctx context.Context) *MockSpan {
+	span, _ := ctx.Value(spanContextKey{}).(*MockSpan)
+	return span
 }
 
 // Reset clears all captured spans.
@@ -73,6 +91,10 @@ type MockSpan struct {
 	StatusDesc string
 	Ended      bool
 	Config     *telemetry.SpanConfig
+	// Parent is the span that was active in the context the span was started with, nil for root spans
+	Parent *MockSpan
+	// Children are the spans started with this span active, in start order
+	Children []*MockSpan
 }
 
 // MockEvent represents a recorded event.
@@ -256,6 +278,26 @@ func (r *MockQueryRecorder) RecordError(span telemetry.Span, err error) {
 	span.RecordError(err)
 }
 
+func (r *MockQueryRecorder) RecordConfidence(span telemetry.Span, confidence float64, needsReview bool) {
+	span.SetAttributes(
+		telemetry.Float64(telemetry.AttrQueryConfidence, confidence),
+		telemetry.Bool(telemetry.AttrQueryNeedsReview, needsReview),
+	)
+}
+
+func (r *MockQueryRecorder) RecordFeedback(ctx context.Context, queryName, queryNamespace, traceID, rating string, score float64, comment string) {
+	_, span := r.Tracer.Start(ctx, "query.feedback",
+		telemetry.WithAttributes(
+			telemetry.String(telemetry.AttrQueryName, queryName),
+			telemetry.String(telemetry.AttrQueryNamespace, queryNamespace),
+			telemetry.String(telemetry.AttrFeedbackRating, rating),
+			telemetry.Float64(telemetry.AttrFeedbackScore, score),
+			telemetry.String(telemetry.AttrFeedbackComment, comment),
+		),
+	)
+	span.End()
+}
+
 // MockAgentRecorder implements telemetry.AgentRecorder for testing.
 type MockAgentRecorder struct {
 	Tracer *MockTracer
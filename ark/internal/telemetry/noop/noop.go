@@ -4,6 +4,7 @@ package noop
 
 import (
 	"context"
+	"time"
 
 	"mckinsey.com/ark/internal/telemetry"
 )
@@ -58,6 +59,10 @@ func (r *noopQueryRecorder) RecordTokenUsage(span telemetry.Span, promptTokens,
 func (r *noopQueryRecorder) RecordSessionID(span telemetry.Span, sessionID string) {} //nolint:revive
 func (r *noopQueryRecorder) RecordSuccess(span telemetry.Span)                     {} //nolint:revive
 func (r *noopQueryRecorder) RecordError(span telemetry.Span, err error)            {} //nolint:revive
+func (r *noopQueryRecorder) RecordConfidence(span telemetry.Span, confidence float64, needsReview bool) {
+} //nolint:revive
+func (r *noopQueryRecorder) RecordFeedback(ctx context.Context, queryName, queryNamespace, traceID, rating string, score float64, comment string) {
+} //nolint:revive
 
 // noopAgentRecorder is a zero-overhead agent recorder that does nothing.
 // All methods are intentionally empty for zero-overhead no-op behavior.
@@ -105,6 +110,12 @@ func (r *noopModelRecorder) RecordOutput(span telemetry.Span, output any)  {} //
 func (r *noopModelRecorder) RecordTokenUsage(span telemetry.Span, promptTokens, completionTokens, totalTokens int64) {
 } //nolint:revive
 func (r *noopModelRecorder) RecordModelDetails(span telemetry.Span, modelName, modelType string) {
+} //nolint:revive
+func (r *noopModelRecorder) RecordThrottle(span telemetry.Span, retryAfter time.Duration) {
+} //nolint:revive
+func (r *noopModelRecorder) RecordRateLimitWait(span telemetry.Span, waited time.Duration) {
+} //nolint:revive
+func (r *noopModelRecorder) RecordStreamChunk(span telemetry.Span, chunkCount, cumulativeTokens int64) {
 }                                                                       //nolint:revive
 func (r *noopModelRecorder) RecordSuccess(span telemetry.Span)          {} //nolint:revive
 func (r *noopModelRecorder) RecordError(span telemetry.Span, err error) {} //nolint:revive
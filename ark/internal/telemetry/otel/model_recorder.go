@@ -6,19 +6,36 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/openai/openai-go"
 	"mckinsey.com/ark/internal/telemetry"
 )
 
+// StreamChunkEventIntervalEnv configures how often streamed chunks are recorded as span events.
+// A value of N records an event every N chunks; unset or 0 disables chunk events.
+const StreamChunkEventIntervalEnv = "ARK_TELEMETRY_STREAM_CHUNK_EVENT_INTERVAL"
+
 type modelRecorder struct {
-	tracer telemetry.Tracer
+	tracer             telemetry.Tracer
+	chunkEventInterval int64
 }
 
 func NewModelRecorder(tracer telemetry.Tracer) telemetry.ModelRecorder {
 	return &modelRecorder{
-		tracer: tracer,
+		tracer:             tracer,
+		chunkEventInterval: streamChunkEventInterval(),
+	}
+}
+
+func streamChunkEventInterval() int64 {
+	interval, err := strconv.ParseInt(os.Getenv(StreamChunkEventIntervalEnv), 10, 64)
+	if err != nil || interval < 0 {
+		return 0
 	}
+	return interval
 }
 
 func (r *modelRecorder) StartModelExecution(ctx context.Context, modelName, modelType string) (context.Context, telemetry.Span) {
@@ -170,6 +187,24 @@ func (r *modelRecorder) RecordModelDetails(span telemetry.Span, modelName, model
 	)
 }
 
+func (r *modelRecorder) RecordThrottle(span telemetry.Span, retryAfter time.Duration) {
+	span.AddEvent("llm.throttled", telemetry.Int64(telemetry.AttrThrottleRetryAfterMs, retryAfter.Milliseconds()))
+}
+
+func (r *modelRecorder) RecordRateLimitWait(span telemetry.Span, waited time.Duration) {
+	span.AddEvent("llm.rate_limited", telemetry.Int64(telemetry.AttrRateLimitWaitMs, waited.Milliseconds()))
+}
+
+func (r *modelRecorder) RecordStreamChunk(span telemetry.Span, chunkCount, cumulativeTokens int64) {
+	if r.chunkEventInterval == 0 || chunkCount%r.chunkEventInterval != 0 {
+		return
+	}
+	span.AddEvent("llm.stream.chunks",
+		telemetry.Int64(telemetry.AttrStreamChunkCount, chunkCount),
+		telemetry.Int64(telemetry.AttrStreamCumulativeTokens, cumulativeTokens),
+	)
+}
+
 func (r *modelRecorder) RecordSuccess(span telemetry.Span) {
 	span.SetStatus(telemetry.StatusOk, "success")
 }
@@ -0,0 +1,60 @@
+/* Copyright 2025. McKinsey & Company */
+
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mckinsey.com/ark/internal/telemetry"
+	"mckinsey.com/ark/internal/telemetry/mock"
+)
+
+// streamChunks records a stream of chunks on a model span, each chunk carrying one token
+func streamChunks(recorder telemetry.ModelRecorder, tracer *mock.MockTracer, chunks int64) *mock.MockSpan {
+	_, span := tracer.Start(context.Background(), "llm.gpt-4o")
+	for chunk := int64(1); chunk <= chunks; chunk++ {
+		recorder.RecordStreamChunk(span, chunk, chunk)
+	}
+	span.End()
+	return tracer.FindSpan("llm.gpt-4o")
+}
+
+func TestRecordStreamChunkEmitsBatchEvents(t *testing.T) {
+	t.Setenv(StreamChunkEventIntervalEnv, "10")
+	tracer := mock.NewTracer()
+
+	span := streamChunks(NewModelRecorder(tracer), tracer, 25)
+
+	require.Len(t, span.Events, 2)
+	for i, chunks := range []int64{10, 20} {
+		assert.Equal(t, "llm.stream.chunks", span.Events[i].Name)
+		assert.Equal(t, chunks, span.Events[i].Attributes[telemetry.AttrStreamChunkCount])
+		assert.Equal(t, chunks, span.Events[i].Attributes[telemetry.AttrStreamCumulativeTokens])
+	}
+}
+
+func TestRecordStreamChunkDisabledByDefault(t *testing.T) {
+	t.Setenv(StreamChunkEventIntervalEnv, "")
+	tracer := mock.NewTracer()
+
+	span := streamChunks(NewModelRecorder(tracer), tracer, 25)
+
+	assert.Empty(t, span.Events)
+}
+
+func TestRecordStreamChunkIgnoresInvalidInterval(t *testing.T) {
+	for _, interval := range []string{"0", "-5", "often"} {
+		t.Run(interval, func(t *testing.T) {
+			t.Setenv(StreamChunkEventIntervalEnv, interval)
+			tracer := mock.NewTracer()
+
+			span := streamChunks(NewModelRecorder(tracer), tracer, 25)
+
+			assert.Empty(t, span.Events)
+		})
+	}
+}
@@ -5,6 +5,8 @@ package otel
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/trace"
+
 	"mckinsey.com/ark/internal/telemetry"
 )
 
@@ -94,12 +96,52 @@ func (r *queryRecorder) RecordError(span telemetry.Span, err error) {
 	span.RecordError(err)
 }
 
+func (r *queryRecorder) RecordConfidence(span telemetry.Span, confidence float64, needsReview bool) {
+	span.SetAttributes(
+		telemetry.Float64(telemetry.AttrQueryConfidence, confidence),
+		telemetry.Bool(telemetry.AttrQueryNeedsReview, needsReview),
+	)
+}
+
+func (r *queryRecorder) RecordFeedback(ctx context.Context, queryName, queryNamespace, traceID, rating string, score float64, comment string) {
+	ctx = withRemoteTrace(ctx, traceID)
+	_, span := r.tracer.Start(ctx, "feedback."+queryName,
+		telemetry.WithAttributes(
+			telemetry.String(telemetry.AttrQueryName, queryName),
+			telemetry.String(telemetry.AttrQueryNamespace, queryNamespace),
+			telemetry.String(telemetry.AttrFeedbackRating, rating),
+			telemetry.Float64(telemetry.AttrFeedbackScore, score),
+			telemetry.String(telemetry.AttrFeedbackComment, comment),
+			telemetry.String(telemetry.AttrLangfuseType, telemetry.ObservationTypeEvent),
+		),
+	)
+	span.SetStatus(telemetry.StatusOk, "success")
+	span.End()
+}
+
+// withRemoteTrace makes spans started from ctx part of the given trace. The query span has long
+// ended, so the parent span ID is derived from the trace ID rather than referring to a live span.
+func withRemoteTrace(ctx context.Context, traceID string) context.Context {
+	tid, err := trace.TraceIDFromHex(traceID)
+	if err != nil {
+		return ctx
+	}
+	var sid trace.SpanID
+	copy(sid[:], tid[8:])
+	return trace.ContextWithRemoteSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    tid,
+		SpanID:     sid,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+}
+
 // mapTargetToObservationType maps ARK target types to Langfuse observation types.
 func mapTargetToObservationType(targetType string) string {
 	switch targetType {
 	case telemetry.TargetTypeAgent:
 		return telemetry.ObservationTypeAgent
-	case telemetry.TargetTypeModel:
+	case telemetry.TargetTypeModel, telemetry.TargetTypeSession:
 		return telemetry.ObservationTypeGeneration
 	case telemetry.TargetTypeTool:
 		return telemetry.ObservationTypeTool
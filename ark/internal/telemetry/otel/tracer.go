@@ -10,6 +10,7 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 
+	"mckinsey.com/ark/internal/common"
 	"mckinsey.com/ark/internal/telemetry"
 )
 
@@ -19,16 +20,24 @@ const (
 
 // tracer implements telemetry.Tracer using OpenTelemetry.
 type tracer struct {
-	otelTracer trace.Tracer
+	otelTracer    trace.Tracer
+	contentPolicy *telemetry.ContentPolicy
 }
 
 // NewTracer creates a new OTEL-backed tracer.
 func NewTracer(name string) telemetry.Tracer {
+	return NewTracerWithContentPolicy(name, nil)
+}
+
+// NewTracerWithContentPolicy creates an OTEL-backed tracer whose spans record content attributes under the
+// policy. A nil policy records content as is.
+func NewTracerWithContentPolicy(name string, policy *telemetry.ContentPolicy) telemetry.Tracer {
 	if name == "" {
 		name = defaultTracerName
 	}
 	return &tracer{
-		otelTracer: otel.Tracer(name),
+		otelTracer:    otel.Tracer(name),
+		contentPolicy: policy,
 	}
 }
 
@@ -60,24 +69,37 @@ func (t *tracer) Start(ctx context.Context, spanName string, opts ...telemetry.S
 		otelOpts = append(otelOpts, trace.WithTimestamp(cfg.Timestamp))
 	}
 
-	// Add attributes
+	// Add attributes. Under a content policy, content attributes are set once the span has its trace ID.
+	var contentAttrs []telemetry.Attribute
 	if len(cfg.Attributes) > 0 {
-		otelAttrs := make([]attribute.KeyValue, len(cfg.Attributes))
-		for i, attr := range cfg.Attributes {
-			otelAttrs[i] = convertAttribute(attr)
+		otelAttrs := make([]attribute.KeyValue, 0, len(cfg.Attributes))
+		for _, attr := range cfg.Attributes {
+			if t.contentPolicy != nil && telemetry.IsContentAttribute(attr.Key) {
+				contentAttrs = append(contentAttrs, attr)
+				continue
+			}
+			otelAttrs = append(otelAttrs, convertAttribute(attr))
 		}
 		otelOpts = append(otelOpts, trace.WithAttributes(otelAttrs...))
 	}
 
+	// Every span of a query carries its correlation ID
+	if queryID := common.QueryID(ctx); queryID != "" {
+		otelOpts = append(otelOpts, trace.WithAttributes(attribute.String(telemetry.AttrQueryID, queryID)))
+	}
+
 	// Start the span
 	ctx, otelSpan := t.otelTracer.Start(ctx, spanName, otelOpts...)
 
-	return ctx, &span{otelSpan: otelSpan}
+	s := &span{otelSpan: otelSpan, contentPolicy: t.contentPolicy}
+	s.SetAttributes(contentAttrs...)
+	return ctx, s
 }
 
 // span implements telemetry.Span using OpenTelemetry.
 type span struct {
-	otelSpan trace.Span
+	otelSpan      trace.Span
+	contentPolicy *telemetry.ContentPolicy
 }
 
 func (s *span) End() {
@@ -85,6 +107,9 @@ func (s *span) End() {
 }
 
 func (s *span) SetAttributes(attributes ...telemetry.Attribute) {
+	if s.contentPolicy != nil {
+		attributes = s.contentPolicy.Apply(s.otelSpan.SpanContext().TraceID(), attributes)
+	}
 	if len(attributes) == 0 {
 		return
 	}
@@ -98,6 +123,9 @@ func (s *span) SetAttributes(attributes ...telemetry.Attribute) {
 
 func (s *span) RecordError(err error) {
 	s.otelSpan.RecordError(err)
+	if reason := telemetry.ErrorReason(err); reason != "" {
+		s.otelSpan.SetAttributes(attribute.String(telemetry.AttrErrorType, reason))
+	}
 	s.otelSpan.SetStatus(codes.Error, err.Error())
 }
 
@@ -4,6 +4,7 @@ package telemetry
 
 import (
 	"context"
+	"time"
 )
 
 // QueryRecorder provides domain-specific telemetry for query execution.
@@ -38,6 +39,13 @@ type QueryRecorder interface {
 
 	// RecordError marks a span as failed with error details.
 	RecordError(span Span, err error)
+
+	// RecordConfidence records the estimated confidence in a target's response and whether it needs review.
+	RecordConfidence(span Span, confidence float64, needsReview bool)
+
+	// RecordFeedback records user feedback on a query and its score. The feedback is added to the query's trace
+	// when traceID is set.
+	RecordFeedback(ctx context.Context, queryName, queryNamespace, traceID, rating string, score float64, comment string)
 }
 
 // AgentRecorder provides domain-specific telemetry for agent execution.
@@ -87,6 +95,16 @@ type ModelRecorder interface {
 	// RecordModelDetails records model configuration. Provider is extracted from modelType.
 	RecordModelDetails(span Span, modelName, modelType string)
 
+	// RecordThrottle records that the provider rate limited the call and asked to retry after a delay.
+	RecordThrottle(span Span, retryAfter time.Duration)
+
+	// RecordRateLimitWait records that the call waited for the model's rate limit before it was sent.
+	RecordRateLimitWait(span Span, waited time.Duration)
+
+	// RecordStreamChunk is called for every streamed chunk with the number of chunks and tokens received so far.
+	// Implementations may sample chunks into span events for latency analysis.
+	RecordStreamChunk(span Span, chunkCount, cumulativeTokens int64)
+
 	// RecordSuccess marks a span as successfully completed.
 	RecordSuccess(span Span)
 
@@ -172,6 +190,9 @@ const (
 	// Session tracking
 	AttrSessionID = "session.id"
 
+	// Correlation ID of the query a span belongs to, also sent to providers in the X-Ark-Query-Id header
+	AttrQueryID = "query.id"
+
 	// Tool attributes
 	AttrToolName        = "tool.name"
 	AttrToolType        = "tool.type"
@@ -191,6 +212,28 @@ const (
 
 	// Finish reason (aligned with OpenTelemetry GenAI conventions)
 	AttrFinishReason = "gen_ai.completion.finish_reason"
+
+	// Provider throttling
+	AttrThrottleRetryAfterMs = "llm.throttle.retry_after_ms"
+
+	// Model rate limiting
+	AttrRateLimitWaitMs = "llm.rate_limit.wait_ms"
+
+	// Streaming progress
+	AttrStreamChunkCount       = "llm.stream.chunk_count"
+	AttrStreamCumulativeTokens = "llm.stream.cumulative_tokens"
+
+	// Confidence estimation
+	AttrQueryConfidence  = "query.confidence"
+	AttrQueryNeedsReview = "query.needs_review"
+
+	// User feedback
+	AttrFeedbackRating  = "feedback.rating"
+	AttrFeedbackScore   = "feedback.score"
+	AttrFeedbackComment = "feedback.comment"
+
+	// Error classification (aligned with OpenTelemetry semantic conventions)
+	AttrErrorType = "error.type"
 )
 
 // Provider is an interface for telemetry providers that can create recorders.
@@ -210,6 +253,8 @@ const (
 	TargetTypeTeam  = "team"
 	TargetTypeModel = "model"
 	TargetTypeTool  = "tool"
+	// TargetTypeSession summarizes a session with a model
+	TargetTypeSession = "session"
 )
 
 // Langfuse observation types for compatibility
@@ -217,4 +262,5 @@ const (
 	ObservationTypeAgent      = "agent"
 	ObservationTypeGeneration = "generation"
 	ObservationTypeTool       = "tool"
+	ObservationTypeEvent      = "event"
 )
@@ -5,6 +5,9 @@ package v1
 import (
 	"context"
 	"fmt"
+	"path"
+	"slices"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -13,6 +16,7 @@ import (
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/genai"
 )
 
 // SetupAgentWebhookWithManager registers the webhook for Agent in the manager.
@@ -99,6 +103,14 @@ func (v *AgentCustomValidator) validateAgent(ctx context.Context, agent *arkv1al
 		return warnings, err
 	}
 
+	if err := v.validateReview(agent); err != nil {
+		return warnings, err
+	}
+
+	if err := v.validateToolPolicy(agent); err != nil {
+		return warnings, err
+	}
+
 	for i, tool := range agent.Spec.Tools {
 		toolWarnings, err := v.validateTool(i, tool)
 		if err != nil {
@@ -117,12 +129,38 @@ func (v *AgentCustomValidator) validateAgentModel(ctx context.Context, agent *ar
 	return nil
 }
 
+// validateReview validates the reviewer of the agent's answers
+func (v *AgentCustomValidator) validateReview(agent *arkv1alpha1.Agent) error {
+	review := agent.Spec.Review
+	if review == nil {
+		return nil
+	}
+	if review.ModelRef != nil && review.Agent != "" {
+		return fmt.Errorf("review accepts either modelRef or agent, not both")
+	}
+	return nil
+}
+
+// validateToolPolicy validates the glob patterns of the agent's tool policy
+func (v *AgentCustomValidator) validateToolPolicy(agent *arkv1alpha1.Agent) error {
+	policy := agent.Spec.ToolPolicy
+	if policy == nil {
+		return nil
+	}
+	for _, pattern := range slices.Concat(policy.Allow, policy.Deny) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("toolPolicy: invalid tool pattern '%s': %v", pattern, err)
+		}
+	}
+	return nil
+}
+
 func (v *AgentCustomValidator) validateBuiltInTool(tool arkv1alpha1.AgentTool, hasName bool, index int) error {
 	if !hasName {
 		return fmt.Errorf("tool[%d]: built-in tools must specify a name", index)
 	}
 	if !isValidBuiltInTool(tool.Name) {
-		return fmt.Errorf("tool[%d]: unsupported built-in tool '%s': supported built-in tools are: noop, terminate", index, tool.Name)
+		return fmt.Errorf("tool[%d]: unsupported built-in tool '%s': supported built-in tools are: %s", index, tool.Name, strings.Join(genai.BuiltinToolNames, ", "))
 	}
 	return nil
 }
@@ -158,9 +196,5 @@ func (v *AgentCustomValidator) validateTool(index int, tool arkv1alpha1.AgentToo
 }
 
 func isValidBuiltInTool(name string) bool {
-	validBuiltInTools := map[string]bool{
-		"noop":      true,
-		"terminate": true,
-	}
-	return validBuiltInTools[name]
+	return slices.Contains(genai.BuiltinToolNames, name)
 }
@@ -97,6 +97,50 @@ var _ = Describe("Agent Webhook", func() {
 		})
 	})
 
+	Context("When validating agent review", func() {
+		It("Should allow a review by the agent's own model", func() {
+			agent.Spec.Review = &arkv1alpha1.AgentReviewConfig{MaxRounds: 2}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should allow a review by another agent", func() {
+			agent.Spec.Review = &arkv1alpha1.AgentReviewConfig{Agent: "critic"}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject a review by both a model and an agent", func() {
+			agent.Spec.Review = &arkv1alpha1.AgentReviewConfig{
+				ModelRef: &arkv1alpha1.AgentModelRef{Name: "gpt-4o"},
+				Agent:    "critic",
+			}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("review accepts either modelRef or agent, not both"))
+		})
+	})
+
+	Context("When validating agent tool policy", func() {
+		It("Should allow glob patterns", func() {
+			agent.Spec.ToolPolicy = &arkv1alpha1.AgentToolPolicy{Allow: []string{"github_*"}, Deny: []string{"github_delete_?"}}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject a malformed pattern", func() {
+			agent.Spec.ToolPolicy = &arkv1alpha1.AgentToolPolicy{Deny: []string{"github_[delete"}}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid tool pattern 'github_[delete'"))
+		})
+	})
+
 	Context("When defaulting agent model", func() {
 		var defaulter *AgentCustomDefaulter
 
@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
 )
 
 type EvaluationValidator struct {
@@ -158,6 +159,15 @@ func (v *EvaluationValidator) validateQueryMode(evaluation *arkv1alpha1.Evaluati
 		return fmt.Errorf("query mode evaluation cannot specify output in config (will be populated from query)")
 	}
 
+	// Query mode rules are scored by the controller, so they must compile
+	if evaluation.Spec.Config.EventEvaluationConfig != nil {
+		for _, rule := range evaluation.Spec.Config.Rules {
+			if _, err := genai.CompileQueryRule(rule); err != nil {
+				return fmt.Errorf("query mode evaluation has an invalid rule: %w", err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -15,6 +15,7 @@ import (
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	"mckinsey.com/ark/internal/common"
+	"mckinsey.com/ark/internal/genai"
 )
 
 var evaluatorLog = logf.Log.WithName("evaluator-resource")
@@ -56,6 +57,12 @@ func (v *EvaluatorValidator) ValidateCreate(ctx context.Context, obj runtime.Obj
 		return nil, fmt.Errorf("failed to resolve Address: %w", err)
 	}
 
+	for _, rule := range evaluator.Spec.Rules {
+		if _, err := genai.CompileQueryRule(rule); err != nil {
+			return nil, fmt.Errorf("invalid rule: %w", err)
+		}
+	}
+
 	// Validate model reference from parameters - only if explicitly specified
 	var modelName, modelNamespace string
 	modelNamespace = evaluator.GetNamespace()
@@ -5,6 +5,7 @@ package v1
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -15,6 +16,7 @@ import (
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	"mckinsey.com/ark/internal/common"
+	"mckinsey.com/ark/internal/genai"
 )
 
 var mcpserverlog = logf.Log.WithName("mcpserver-resource")
@@ -47,10 +49,20 @@ func (v *MCPServerValidator) ValidateCreate(ctx context.Context, obj runtime.Obj
 
 	mcpserverlog.Info("Validating MCPServer", "name", mcpserver.GetName(), "namespace", mcpserver.GetNamespace())
 
-	_, err := v.Resolver.ResolveValueSource(ctx, mcpserver.Spec.Address, mcpserver.GetNamespace())
-	if err != nil {
-		mcpserverlog.Error(err, "Failed to resolve Address", "mcpserver", mcpserver.GetName())
-		return nil, fmt.Errorf("failed to resolve Address: %w", err)
+	if genai.UsesStdioTransport(mcpserver) {
+		if err := validateStdioMCPServer(mcpserver); err != nil {
+			mcpserverlog.Error(err, "Failed to validate stdio transport", "mcpserver", mcpserver.GetName())
+			return nil, err
+		}
+	} else {
+		if len(mcpserver.Spec.Command) > 0 {
+			return nil, fmt.Errorf("command is only supported with the stdio transport")
+		}
+		_, err := v.Resolver.ResolveValueSource(ctx, mcpserver.Spec.Address, mcpserver.GetNamespace())
+		if err != nil {
+			mcpserverlog.Error(err, "Failed to resolve Address", "mcpserver", mcpserver.GetName())
+			return nil, fmt.Errorf("failed to resolve Address: %w", err)
+		}
 	}
 
 	for i, header := range mcpserver.Spec.Headers {
@@ -61,6 +73,11 @@ func (v *MCPServerValidator) ValidateCreate(ctx context.Context, obj runtime.Obj
 		}
 	}
 
+	if err := v.validateAuth(ctx, mcpserver); err != nil {
+		mcpserverlog.Error(err, "Failed to validate auth", "mcpserver", mcpserver.GetName())
+		return nil, err
+	}
+
 	// Validate PollInterval
 	if err := ValidatePollInterval(mcpserver.Spec.PollInterval.Duration); err != nil {
 		mcpserverlog.Error(err, "Failed to validate pollInterval", "mcpserver", mcpserver.GetName())
@@ -72,6 +89,41 @@ func (v *MCPServerValidator) ValidateCreate(ctx context.Context, obj runtime.Obj
 	return nil, nil
 }
 
+// validateStdioMCPServer checks that a stdio server runs an allowed command and has no address, headers, auth or tls,
+// which only apply to servers reached over HTTP
+func validateStdioMCPServer(mcpserver *arkv1alpha1.MCPServer) error {
+	if err := genai.CheckMCPStdioCommand(mcpserver.Spec.Command); err != nil {
+		return err
+	}
+	if mcpserver.Spec.Address.Value != "" || mcpserver.Spec.Address.ValueFrom != nil {
+		return fmt.Errorf("address is not supported with the stdio transport")
+	}
+	if len(mcpserver.Spec.Headers) > 0 || mcpserver.Spec.Auth != nil || mcpserver.Spec.TLS != nil {
+		return fmt.Errorf("headers, auth and tls are not supported with the stdio transport")
+	}
+	return nil
+}
+
+// validateAuth checks that the OAuth2 token URL is an http(s) URL and that the client credentials resolve
+func (v *MCPServerValidator) validateAuth(ctx context.Context, mcpserver *arkv1alpha1.MCPServer) error {
+	if mcpserver.Spec.Auth == nil || mcpserver.Spec.Auth.OAuth2 == nil {
+		return nil
+	}
+	oauth2 := mcpserver.Spec.Auth.OAuth2
+
+	tokenURL, err := url.Parse(oauth2.TokenURL)
+	if err != nil || (tokenURL.Scheme != "http" && tokenURL.Scheme != "https") || tokenURL.Host == "" {
+		return fmt.Errorf("auth.oauth2.tokenURL must be an http or https URL: %q", oauth2.TokenURL)
+	}
+	if _, err := v.Resolver.ResolveValueSource(ctx, oauth2.ClientID, mcpserver.GetNamespace()); err != nil {
+		return fmt.Errorf("failed to resolve auth.oauth2.clientId: %w", err)
+	}
+	if _, err := v.Resolver.ResolveValueSource(ctx, oauth2.ClientSecret, mcpserver.GetNamespace()); err != nil {
+		return fmt.Errorf("failed to resolve auth.oauth2.clientSecret: %w", err)
+	}
+	return nil
+}
+
 func (v *MCPServerValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	return v.ValidateCreate(ctx, newObj)
 }
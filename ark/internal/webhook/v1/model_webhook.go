@@ -97,6 +97,8 @@ func (v *ModelValidator) validateProviderConfig(ctx context.Context, model *arkv
 		return v.validateOpenAIConfig(ctx, model)
 	case genai.ModelTypeBedrock:
 		return v.validateBedrockConfig(ctx, model)
+	case genai.ModelTypeGateway:
+		return v.validateGatewayConfig(ctx, model)
 	default:
 		return fmt.Errorf("unsupported model type: %s", model.Spec.Type)
 	}
@@ -163,6 +165,36 @@ func (v *ModelValidator) validateOpenAIConfig(ctx context.Context, model *arkv1a
 	return nil
 }
 
+func (v *ModelValidator) validateGatewayConfig(ctx context.Context, model *arkv1alpha1.Model) error {
+	if model.Spec.Config.Gateway == nil {
+		return fmt.Errorf("gateway configuration is required for gateway model type")
+	}
+
+	if err := v.validateValueSource(ctx, &model.Spec.Config.Gateway.BaseURL, model.GetNamespace(), "spec.config.gateway.baseUrl"); err != nil {
+		return err
+	}
+	if model.Spec.Config.Gateway.APIKey != nil {
+		if err := v.validateValueSource(ctx, model.Spec.Config.Gateway.APIKey, model.GetNamespace(), "spec.config.gateway.apiKey"); err != nil {
+			return err
+		}
+	}
+
+	_, err := v.Resolver.ResolveValueSource(ctx, model.Spec.Config.Gateway.BaseURL, model.GetNamespace())
+	if err != nil {
+		modellog.Error(err, "Failed to resolve gateway BaseURL", "model", model.GetName())
+		return fmt.Errorf("failed to resolve gateway BaseURL: %w", err)
+	}
+
+	for i, header := range model.Spec.Config.Gateway.Headers {
+		contextPrefix := fmt.Sprintf("spec.config.gateway.headers[%d]", i)
+		if err := ValidateHeader(header, contextPrefix); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (v *ModelValidator) validateBedrockConfig(ctx context.Context, model *arkv1alpha1.Model) error {
 	if model.Spec.Config.Bedrock == nil {
 		return fmt.Errorf("bedrock configuration is required for bedrock model type")
@@ -103,6 +103,29 @@ var _ = Describe("Model Webhook", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(warnings).To(BeEmpty())
 		})
+
+		It("Should allow valid gateway model without an API key", func() {
+			model.Spec.Type = genai.ModelTypeGateway
+			model.Spec.Model = arkv1alpha1.ValueSource{Value: "team-default"}
+			model.Spec.Config = arkv1alpha1.ModelConfig{
+				Gateway: &arkv1alpha1.GatewayModelConfig{
+					BaseURL: arkv1alpha1.ValueSource{
+						Value: "http://litellm.ark-system:4000/v1",
+					},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, model)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should reject a gateway model without gateway configuration", func() {
+			model.Spec.Type = genai.ModelTypeGateway
+
+			_, err := validator.ValidateCreate(ctx, model)
+			Expect(err).To(MatchError(ContainSubstring("gateway configuration is required")))
+		})
 	})
 
 	Context("When validating models with Secret references", func() {
@@ -4,14 +4,25 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/common"
+	"mckinsey.com/ark/internal/genai"
 )
 
 const (
@@ -19,15 +30,94 @@ const (
 	TargetTypeTeam  = "team"
 	TargetTypeModel = "model"
 	TargetTypeTool  = "tool"
+	// TargetTypeSession summarizes the query's session with the model named by the target
+	TargetTypeSession = "session"
 )
 
 // SetupQueryWebhookWithManager registers the webhook for Query in the manager.
 func SetupQueryWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&arkv1alpha1.Query{}).
+		WithDefaulter(&QueryCustomDefaulter{Client: mgr.GetClient()}).
 		WithValidator(&QueryCustomValidator{ResourceValidator: &ResourceValidator{Client: mgr.GetClient()}}).
 		Complete()
 }
 
+// +kubebuilder:webhook:path=/mutate-ark-mckinsey-com-v1alpha1-query,mutating=true,failurePolicy=fail,sideEffects=None,groups=ark.mckinsey.com,resources=queries,verbs=create;update,versions=v1alpha1,name=mquery-v1.kb.io,admissionReviewVersions=v1
+
+// QueryCustomDefaulter infers the type of a query from its input, normalizes shorthand messages, and sets
+// the ttl and timeout of the namespace on queries that do not set them
+type QueryCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &QueryCustomDefaulter{}
+
+func (d *QueryCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	query, ok := obj.(*arkv1alpha1.Query)
+	if !ok {
+		return fmt.Errorf("expected a Query object but got %T", obj)
+	}
+
+	if err := normalizeQueryInput(&query.Spec); err != nil {
+		return err
+	}
+
+	if query.Spec.TTL == nil || query.Spec.Timeout == nil {
+		ttl, timeout := genai.ResolveQueryDefaults(ctx, d.Client, query.Namespace)
+		if query.Spec.TTL == nil {
+			query.Spec.TTL = &metav1.Duration{Duration: ttl}
+		}
+		if query.Spec.Timeout == nil {
+			query.Spec.Timeout = &metav1.Duration{Duration: timeout}
+		}
+	}
+	return nil
+}
+
+// normalizeQueryInput infers the type of a query without one from its input: a string is a user query and
+// messages are a messages query. Shorthand messages are normalized: a single message becomes a list of one,
+// and strings in a list become user messages. Input that is not JSON is left for validation to reject.
+func normalizeQueryInput(spec *arkv1alpha1.QuerySpec) error {
+	if len(spec.Input.Raw) == 0 {
+		return nil
+	}
+	var input any
+	if err := json.Unmarshal(spec.Input.Raw, &input); err != nil {
+		return nil
+	}
+
+	switch value := input.(type) {
+	case string:
+		if spec.Type == "" {
+			spec.Type = arkv1alpha1.QueryTypeUser
+		}
+		return nil
+	case map[string]any:
+		input = []any{value}
+	case []any:
+		for i, message := range value {
+			if content, ok := message.(string); ok {
+				value[i] = map[string]any{"role": "user", "content": content}
+			}
+		}
+	default:
+		return nil
+	}
+
+	if spec.Type == "" {
+		spec.Type = arkv1alpha1.QueryTypeMessages
+	}
+	if spec.Type != arkv1alpha1.QueryTypeMessages {
+		return nil
+	}
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to normalize query input: %w", err)
+	}
+	spec.Input.Raw = raw
+	return nil
+}
+
 // +kubebuilder:webhook:path=/validate-ark-mckinsey-com-v1alpha1-query,mutating=false,failurePolicy=fail,sideEffects=None,groups=ark.mckinsey.com,resources=queries,verbs=create;update,versions=v1alpha1,name=vquery-v1.kb.io,admissionReviewVersions=v1
 
 // QueryCustomValidator struct is responsible for validating the Query resource
@@ -48,6 +138,16 @@ func (v *QueryCustomValidator) ValidateCreate(ctx context.Context, obj runtime.O
 	}
 	log.V(3).Info("Validate create", "query", query.ObjectMeta)
 
+	// A restored query never runs, and the resources it references may have been deleted since it was snapshotted
+	if _, restored := query.Annotations[annotations.RestoredFrom]; restored {
+		return nil, nil
+	}
+
+	// The input of a replay is cloned from the replayed query
+	if query.Spec.ReplayOf != "" && len(query.Spec.Input.Raw) > 0 {
+		return nil, fmt.Errorf("input cannot be set on a query that replays query %s", query.Spec.ReplayOf)
+	}
+
 	return v.validateQuery(ctx, query)
 }
 
@@ -57,6 +157,13 @@ func (v *QueryCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newOb
 		return nil, fmt.Errorf("expected a Query object for the newObj but got %T", newObj)
 	}
 	log.V(3).Info("Validate update", "query", query.ObjectMeta)
+	oldQuery, ok := oldObj.(*arkv1alpha1.Query)
+	if !ok {
+		return nil, fmt.Errorf("expected a Query object for the oldObj but got %T", oldObj)
+	}
+	if restoredFrom, restored := oldQuery.Annotations[annotations.RestoredFrom]; restored {
+		return nil, validateRestoredQueryUpdate(oldQuery, query, restoredFrom)
+	}
 	if query.DeletionTimestamp.IsZero() {
 		return v.validateQuery(ctx, query)
 	}
@@ -76,10 +183,36 @@ func (v *QueryCustomValidator) ValidateDelete(ctx context.Context, obj runtime.O
 func (v *QueryCustomValidator) validateQuery(ctx context.Context, query *arkv1alpha1.Query) (admission.Warnings, error) {
 	var warnings admission.Warnings
 
+	if err := validateQueryReplay(query); err != nil {
+		return warnings, err
+	}
+
+	if err := validateQueryInput(query); err != nil {
+		return warnings, err
+	}
+
 	if err := v.validateQueryTargets(ctx, query); err != nil {
 		return warnings, err
 	}
 
+	if err := validateSelectorPolicy(query); err != nil {
+		return warnings, err
+	}
+
+	if err := validateFailurePolicy(query); err != nil {
+		return warnings, err
+	}
+
+	if err := v.validateQueryDependencies(ctx, query); err != nil {
+		return warnings, err
+	}
+
+	memoryWarnings, err := v.validateQueryMemory(ctx, query)
+	if err != nil {
+		return warnings, err
+	}
+	warnings = append(warnings, memoryWarnings...)
+
 	if err := v.ValidateParameters(ctx, query.Namespace, query.Spec.Parameters); err != nil {
 		return warnings, err
 	}
@@ -88,10 +221,109 @@ func (v *QueryCustomValidator) validateQuery(ctx context.Context, query *arkv1al
 		return warnings, err
 	}
 
+	warnings = append(warnings, v.validateQueryImpersonation(ctx, query)...)
+
 	return warnings, nil
 }
 
+// validateRestoredQueryUpdate keeps a query restored from a snapshot read-only: its spec cannot change and it
+// cannot stop being a restored query, which would run it
+func validateRestoredQueryUpdate(oldQuery, query *arkv1alpha1.Query, restoredFrom string) error {
+	if query.Annotations[annotations.RestoredFrom] != restoredFrom {
+		return fmt.Errorf("annotation %s cannot be changed on a restored query", annotations.RestoredFrom)
+	}
+	if !equality.Semantic.DeepEqual(oldQuery.Spec, query.Spec) {
+		return fmt.Errorf("query %s was restored from snapshot %s and is read-only", query.Name, restoredFrom)
+	}
+	return nil
+}
+
+// validateQueryReplay requires an input unless the query replays another query, whose input it is cloned from
+func validateQueryReplay(query *arkv1alpha1.Query) error {
+	if query.Spec.ReplayOf == "" {
+		if len(query.Spec.Input.Raw) == 0 {
+			return fmt.Errorf("input is required unless the query sets replayOf")
+		}
+		return nil
+	}
+	if query.Spec.ReplayOf == query.Name {
+		return fmt.Errorf("query cannot replay itself")
+	}
+	return nil
+}
+
+// validateQueryInput checks that the input matches the type of the query, so that an input the controller
+// could not read is rejected when the query is created rather than when it runs
+func validateQueryInput(query *arkv1alpha1.Query) error {
+	if len(query.Spec.Input.Raw) == 0 {
+		return nil
+	}
+
+	switch query.Spec.Type {
+	case arkv1alpha1.QueryTypeMessages:
+		var messages []map[string]any
+		if err := json.Unmarshal(query.Spec.Input.Raw, &messages); err != nil {
+			return fmt.Errorf("input of a messages query must be a list of messages: %v", err)
+		}
+		if len(messages) == 0 {
+			return fmt.Errorf("input of a messages query must have at least one message")
+		}
+		for i, message := range messages {
+			if err := validateInputMessage(message); err != nil {
+				return fmt.Errorf("input message[%d] %v", i, err)
+			}
+		}
+		if _, err := query.Spec.GetInputMessages(); err != nil {
+			return fmt.Errorf("input of a messages query is not a list of messages: %v", err)
+		}
+	default:
+		if _, err := query.Spec.GetInputString(); err != nil {
+			return fmt.Errorf("input of a user query must be a string; set type to messages for a list of messages")
+		}
+	}
+	return nil
+}
+
+// queryMessageRoles are the roles of the messages a messages query can start with
+var queryMessageRoles = []string{"system", "developer", "user", "assistant", "tool"}
+
+// validateInputMessage checks a message of the input of a messages query: its role, its content, which only
+// an assistant message calling tools can leave out, and the tool call a tool message answers
+func validateInputMessage(message map[string]any) error {
+	role, _ := message["role"].(string)
+	if !slices.Contains(queryMessageRoles, role) {
+		return fmt.Errorf("has role %q, expected one of %s", role, strings.Join(queryMessageRoles, ", "))
+	}
+
+	toolCalls, _ := message["tool_calls"].([]any)
+	if !hasMessageContent(message["content"]) && (role != "assistant" || len(toolCalls) == 0) {
+		return fmt.Errorf("of role %s has no content", role)
+	}
+	if role == "tool" {
+		if toolCallID, _ := message["tool_call_id"].(string); toolCallID == "" {
+			return fmt.Errorf("of role tool has no tool_call_id")
+		}
+	}
+	return nil
+}
+
+// hasMessageContent returns whether the content of a message is a non-empty string or list of parts
+func hasMessageContent(content any) bool {
+	switch content := content.(type) {
+	case string:
+		return strings.TrimSpace(content) != ""
+	case []any:
+		return len(content) > 0
+	default:
+		return false
+	}
+}
+
 func (v *QueryCustomValidator) validateQueryTargets(ctx context.Context, query *arkv1alpha1.Query) error {
+	// The targets of a replay without targets are cloned from the replayed query
+	if len(query.Spec.Targets) == 0 && query.Spec.Selector == nil && query.Spec.ReplayOf != "" {
+		return nil
+	}
 	if len(query.Spec.Targets) == 0 && query.Spec.Selector == nil {
 		return fmt.Errorf("at least one target or selector must be specified")
 	}
@@ -114,9 +346,157 @@ func (v *QueryCustomValidator) validateQueryTargets(ctx context.Context, query *
 			if err := v.ValidateLoadTool(ctx, target.Name, query.Namespace); err != nil {
 				return fmt.Errorf("target[%d] references %v", i, err)
 			}
+		case TargetTypeSession:
+			if query.Spec.SessionId == "" {
+				return fmt.Errorf("target[%d]: session targets require sessionId to be set", i)
+			}
+			if err := v.ValidateLoadModel(ctx, target.Name, query.Namespace); err != nil {
+				return fmt.Errorf("target[%d] references %v", i, err)
+			}
 		default:
-			return fmt.Errorf("target[%d]: unsupported type '%s': supported types are: %s, %s, %s, %s", i, target.Type, TargetTypeAgent, TargetTypeTeam, TargetTypeModel, TargetTypeTool)
+			return fmt.Errorf("target[%d]: unsupported type '%s': supported types are: %s, %s, %s, %s, %s", i, target.Type, TargetTypeAgent, TargetTypeTeam, TargetTypeModel, TargetTypeTool, TargetTypeSession)
+		}
+	}
+
+	return nil
+}
+
+// validateSelectorPolicy rejects selector policies whose quorum can never be reached
+func validateSelectorPolicy(query *arkv1alpha1.Query) error {
+	policy := query.Spec.SelectorPolicy
+	if policy == nil {
+		return nil
+	}
+	if query.Spec.Selector == nil {
+		return fmt.Errorf("selectorPolicy requires a selector")
+	}
+	if policy.Quorum == nil {
+		return nil
+	}
+	if policy.Sample != nil && *policy.Quorum > *policy.Sample {
+		return fmt.Errorf("selectorPolicy quorum %d is larger than the sample of %d targets", *policy.Quorum, *policy.Sample)
+	}
+	if policy.StopAfterFirstSuccess && *policy.Quorum > 1 {
+		return fmt.Errorf("selectorPolicy quorum %d cannot be reached when stopping after the first success", *policy.Quorum)
+	}
+	return nil
+}
+
+// validateFailurePolicy rejects failure policies that cannot be met or that conflict with the selector policy
+func validateFailurePolicy(query *arkv1alpha1.Query) error {
+	policy := query.Spec.FailurePolicy
+	if policy == nil {
+		return nil
+	}
+	if policy.Mode == arkv1alpha1.FailurePolicyThreshold {
+		if policy.MinSuccesses == nil {
+			return fmt.Errorf("failurePolicy threshold requires minSuccesses")
 		}
+		if query.Spec.Selector == nil && int(*policy.MinSuccesses) > len(query.Spec.Targets) {
+			return fmt.Errorf("failurePolicy minSuccesses %d is larger than the %d targets", *policy.MinSuccesses, len(query.Spec.Targets))
+		}
+	} else if policy.MinSuccesses != nil {
+		return fmt.Errorf("failurePolicy minSuccesses is only used by the threshold mode")
+	}
+	if selector := query.Spec.SelectorPolicy; selector != nil && (selector.Quorum != nil || selector.StopAfterFirstSuccess) {
+		return fmt.Errorf("failurePolicy cannot be combined with the quorum or stopAfterFirstSuccess of selectorPolicy")
+	}
+	return nil
+}
+
+// validateQueryDependencies rejects dependencies that lead back to the query, which would never run. Queries
+// that do not exist yet are allowed; the query waits for them to be created.
+func (v *QueryCustomValidator) validateQueryDependencies(ctx context.Context, query *arkv1alpha1.Query) error {
+	visited := map[string]bool{}
+	var visit func(names, path []string) error
+	visit = func(names, path []string) error {
+		for _, name := range names {
+			if name == query.Name {
+				return fmt.Errorf("dependsOn creates a cycle: %s", strings.Join(append(path, name), " -> "))
+			}
+			if visited[name] {
+				continue
+			}
+			visited[name] = true
+
+			var dependency arkv1alpha1.Query
+			if err := v.Client.Get(ctx, client.ObjectKey{Name: name, Namespace: query.Namespace}, &dependency); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return fmt.Errorf("failed to get dependency query %s: %w", name, err)
+			}
+			if err := visit(dependency.Spec.DependsOn, append(path, name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return visit(query.Spec.DependsOn, []string{query.Name})
+}
+
+func (v *QueryCustomValidator) validateQueryMemory(ctx context.Context, query *arkv1alpha1.Query) (admission.Warnings, error) {
+	var warnings admission.Warnings
+
+	if query.Spec.Memory == nil {
+		return warnings, nil
+	}
+
+	memoryNamespace := query.Spec.Memory.Namespace
+	if memoryNamespace == "" {
+		memoryNamespace = query.Namespace
+	}
+
+	if err := v.ValidateLoadMemory(ctx, query.Spec.Memory.Name, memoryNamespace); err != nil {
+		return warnings, fmt.Errorf("memory references %v", err)
+	}
+
+	// Cross-namespace memory is resolved with the query's identity at runtime, so the
+	// reference is only admitted if that identity can read the memory in the other namespace
+	if memoryNamespace != query.Namespace {
+		if err := v.validateCrossNamespaceMemoryAccess(ctx, query, memoryNamespace); err != nil {
+			return warnings, err
+		}
+	}
+
+	return warnings, nil
+}
+
+// validateCrossNamespaceMemoryAccess runs a SubjectAccessReview for the query's identity against the
+// referenced memory. The identity is the query's service account, or the requesting user when none is set.
+func (v *QueryCustomValidator) validateCrossNamespaceMemoryAccess(ctx context.Context, query *arkv1alpha1.Query, memoryNamespace string) error {
+	var user, identity string
+	var groups []string
+	if query.Spec.ServiceAccount != "" {
+		user = common.ImpersonationUserName(query.Namespace, query.Spec.ServiceAccount)
+		groups = common.ServiceAccountGroups(query.Namespace)
+		identity = fmt.Sprintf("service account '%s'", query.Spec.ServiceAccount)
+	} else if req, err := admission.RequestFromContext(ctx); err == nil && req.UserInfo.Username != "" {
+		user = req.UserInfo.Username
+		groups = req.UserInfo.Groups
+		identity = fmt.Sprintf("user '%s'", user)
+	} else {
+		return fmt.Errorf("memory '%s' is in namespace '%s', which differs from the query namespace '%s'; a service account is required to verify access",
+			query.Spec.Memory.Name, memoryNamespace, query.Namespace)
+	}
+
+	return v.ValidateMemoryAccess(ctx, "query", query.Namespace, query.Spec.Memory.Name, memoryNamespace, user, groups, identity)
+}
+
+// validateQueryImpersonation warns, without rejecting, when the controller cannot impersonate the
+// query's service account, since RBAC may still be granted before the query starts executing.
+func (v *QueryCustomValidator) validateQueryImpersonation(ctx context.Context, query *arkv1alpha1.Query) admission.Warnings {
+	if query.Spec.ServiceAccount == "" {
+		return nil
+	}
+
+	err := common.CheckImpersonationPermitted(ctx, v.Client, query.Namespace, query.Spec.ServiceAccount)
+	var notPermitted *common.ImpersonationNotPermittedError
+	if errors.As(err, &notPermitted) {
+		return admission.Warnings{err.Error()}
+	}
+	if err != nil {
+		log.V(1).Info("Skipping impersonation check", "query", query.Name, "error", err.Error())
 	}
 
 	return nil
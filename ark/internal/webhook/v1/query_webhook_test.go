@@ -3,10 +3,26 @@
 package v1
 
 import (
+	"context"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/genai"
 	// TODO (user): Add any additional imports if needed
 )
 
@@ -53,4 +69,674 @@ var _ = Describe("Query Webhook", func() {
 		//     Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
 		// })
 	})
+
+	Context("When validating memory references", func() {
+		var ctx context.Context
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			s := runtime.NewScheme()
+			Expect(arkv1alpha1.AddToScheme(s)).To(Succeed())
+
+			fakeClient := fake.NewClientBuilder().WithScheme(s).WithObjects(
+				&arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "assistant", Namespace: "default"}},
+				&arkv1alpha1.Memory{ObjectMeta: metav1.ObjectMeta{Name: "session-memory", Namespace: "default"}},
+				&arkv1alpha1.Memory{ObjectMeta: metav1.ObjectMeta{Name: "shared-memory", Namespace: "shared"}},
+			).Build()
+			validator = QueryCustomValidator{ResourceValidator: &ResourceValidator{Client: fakeClient}}
+
+			obj = &arkv1alpha1.Query{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-query", Namespace: "default"},
+				Spec: arkv1alpha1.QuerySpec{
+					Input:   runtime.RawExtension{Raw: []byte(`"What is the weather?"`)},
+					Targets: []arkv1alpha1.QueryTarget{{Type: TargetTypeAgent, Name: "assistant"}},
+				},
+			}
+		})
+
+		// reviewingClient wraps the validator's client so SubjectAccessReviews are answered with allowed
+		reviewingClient := func(allowed bool, reviewed **authorizationv1.SubjectAccessReview) client.Client {
+			return interceptor.NewClient(validator.Client.(client.WithWatch), interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if review, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+						review.Status.Allowed = allowed
+						*reviewed = review
+						return nil
+					}
+					return c.Create(ctx, obj, opts...)
+				},
+			})
+		}
+
+		It("Should admit a query referencing an existing memory", func() {
+			obj.Spec.Memory = &arkv1alpha1.MemoryRef{Name: "session-memory"}
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should deny a query referencing a missing memory", func() {
+			obj.Spec.Memory = &arkv1alpha1.MemoryRef{Name: "missing-memory"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("memory 'missing-memory' does not exist in namespace 'default'"))
+		})
+
+		It("Should check cross-namespace memory references in the referenced namespace", func() {
+			obj.Spec.Memory = &arkv1alpha1.MemoryRef{Name: "session-memory", Namespace: "shared"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("namespace 'shared'"))
+		})
+
+		It("Should deny a cross-namespace memory when the query has no identity to check", func() {
+			obj.Spec.Memory = &arkv1alpha1.MemoryRef{Name: "shared-memory", Namespace: "shared"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("a service account is required to verify access"))
+		})
+
+		It("Should run a subject access review for the query's service account", func() {
+			var reviewed *authorizationv1.SubjectAccessReview
+			validator.Client = reviewingClient(true, &reviewed)
+			obj.Spec.Memory = &arkv1alpha1.MemoryRef{Name: "shared-memory", Namespace: "shared"}
+			obj.Spec.ServiceAccount = "query-runner"
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+			Expect(reviewed).NotTo(BeNil())
+			Expect(reviewed.Spec.User).To(Equal("system:serviceaccount:default:query-runner"))
+			Expect(reviewed.Spec.Groups).To(ContainElement("system:serviceaccounts:default"))
+			Expect(*reviewed.Spec.ResourceAttributes).To(Equal(authorizationv1.ResourceAttributes{
+				Namespace: "shared",
+				Verb:      "get",
+				Group:     "ark.mckinsey.com",
+				Resource:  "memories",
+				Name:      "shared-memory",
+			}))
+		})
+
+		It("Should check the requesting user when the query has no service account", func() {
+			var reviewed *authorizationv1.SubjectAccessReview
+			validator.Client = reviewingClient(true, &reviewed)
+			obj.Spec.Memory = &arkv1alpha1.MemoryRef{Name: "shared-memory", Namespace: "shared"}
+			requestCtx := admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{Username: "alice", Groups: []string{"team-a"}},
+			}})
+
+			_, err := validator.ValidateCreate(requestCtx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reviewed.Spec.User).To(Equal("alice"))
+			Expect(reviewed.Spec.Groups).To(ConsistOf("team-a"))
+		})
+
+		It("Should deny a cross-namespace memory the query's identity cannot read", func() {
+			var reviewed *authorizationv1.SubjectAccessReview
+			validator.Client = reviewingClient(false, &reviewed)
+			obj.Spec.Memory = &arkv1alpha1.MemoryRef{Name: "shared-memory", Namespace: "shared"}
+			obj.Spec.ServiceAccount = "query-runner"
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("service account 'query-runner' is not permitted to read memories there"))
+		})
+	})
+
+	Context("When checking impersonation", func() {
+		var ctx context.Context
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			s := runtime.NewScheme()
+			Expect(arkv1alpha1.AddToScheme(s)).To(Succeed())
+
+			fakeClient := fake.NewClientBuilder().WithScheme(s).WithObjects(
+				&arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "assistant", Namespace: "default"}},
+			).Build()
+			validator = QueryCustomValidator{ResourceValidator: &ResourceValidator{Client: fakeClient}}
+
+			obj = &arkv1alpha1.Query{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-query", Namespace: "default"},
+				Spec: arkv1alpha1.QuerySpec{
+					Input:          runtime.RawExtension{Raw: []byte(`"What is the weather?"`)},
+					Targets:        []arkv1alpha1.QueryTarget{{Type: TargetTypeAgent, Name: "assistant"}},
+					ServiceAccount: "query-runner",
+				},
+			}
+		})
+
+		// impersonationClient wraps the validator's client so SelfSubjectAccessReviews are answered with allowed
+		impersonationClient := func(allowed bool, reviewed **authorizationv1.SelfSubjectAccessReview) client.Client {
+			return interceptor.NewClient(validator.Client.(client.WithWatch), interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if review, ok := obj.(*authorizationv1.SelfSubjectAccessReview); ok {
+						review.Status.Allowed = allowed
+						*reviewed = review
+						return nil
+					}
+					return c.Create(ctx, obj, opts...)
+				},
+			})
+		}
+
+		It("Should admit without warnings when the controller may impersonate the service account", func() {
+			var reviewed *authorizationv1.SelfSubjectAccessReview
+			validator.Client = impersonationClient(true, &reviewed)
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+			Expect(reviewed).NotTo(BeNil())
+			Expect(*reviewed.Spec.ResourceAttributes).To(Equal(authorizationv1.ResourceAttributes{
+				Namespace: "default",
+				Verb:      "impersonate",
+				Resource:  "serviceaccounts",
+				Name:      "query-runner",
+			}))
+		})
+
+		It("Should warn, without denying, when the controller may not impersonate the service account", func() {
+			var reviewed *authorizationv1.SelfSubjectAccessReview
+			validator.Client = impersonationClient(false, &reviewed)
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("enable rbac.impersonation.enabled")))
+		})
+
+		It("Should skip the check for a query without a service account", func() {
+			var reviewed *authorizationv1.SelfSubjectAccessReview
+			validator.Client = impersonationClient(false, &reviewed)
+			obj.Spec.ServiceAccount = ""
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+			Expect(reviewed).To(BeNil())
+		})
+	})
+
+	Context("When validating session targets", func() {
+		var ctx context.Context
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			s := runtime.NewScheme()
+			Expect(arkv1alpha1.AddToScheme(s)).To(Succeed())
+
+			fakeClient := fake.NewClientBuilder().WithScheme(s).WithObjects(
+				&arkv1alpha1.Model{ObjectMeta: metav1.ObjectMeta{Name: "summarizer", Namespace: "default"}},
+			).Build()
+			validator = QueryCustomValidator{ResourceValidator: &ResourceValidator{Client: fakeClient}}
+
+			obj = &arkv1alpha1.Query{
+				ObjectMeta: metav1.ObjectMeta{Name: "summary-query", Namespace: "default"},
+				Spec: arkv1alpha1.QuerySpec{
+					Input:     runtime.RawExtension{Raw: []byte(`"What is the weather?"`)},
+					SessionId: "session-1",
+					Targets:   []arkv1alpha1.QueryTarget{{Type: TargetTypeSession, Name: "summarizer"}},
+				},
+			}
+		})
+
+		It("Should admit a session target with a model and sessionId", func() {
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a session target without sessionId", func() {
+			obj.Spec.SessionId = ""
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("require sessionId"))
+		})
+
+		It("Should deny a session target referencing a missing model", func() {
+			obj.Spec.Targets[0].Name = "missing-model"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When validating parameter schemas", func() {
+		var ctx context.Context
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			s := runtime.NewScheme()
+			Expect(arkv1alpha1.AddToScheme(s)).To(Succeed())
+
+			fakeClient := fake.NewClientBuilder().WithScheme(s).WithObjects(
+				&arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "assistant", Namespace: "default"}},
+			).Build()
+			validator = QueryCustomValidator{ResourceValidator: &ResourceValidator{Client: fakeClient}}
+
+			obj = &arkv1alpha1.Query{
+				ObjectMeta: metav1.ObjectMeta{Name: "schema-query", Namespace: "default"},
+				Spec: arkv1alpha1.QuerySpec{
+					Input:   runtime.RawExtension{Raw: []byte(`"What is the weather?"`)},
+					Targets: []arkv1alpha1.QueryTarget{{Type: TargetTypeAgent, Name: "assistant"}},
+				},
+			}
+		})
+
+		It("Should admit a parameter with only a default", func() {
+			obj.Spec.Parameters = []arkv1alpha1.Parameter{
+				{Name: "days", Schema: &arkv1alpha1.ParameterSchema{Type: arkv1alpha1.ParameterTypeInteger, Default: "3"}},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a value that does not match the schema", func() {
+			obj.Spec.Parameters = []arkv1alpha1.Parameter{
+				{Name: "days", Value: "three", Schema: &arkv1alpha1.ParameterSchema{Type: arkv1alpha1.ParameterTypeInteger}},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("not an integer"))
+		})
+
+		It("Should deny an enum parameter without allowed values", func() {
+			obj.Spec.Parameters = []arkv1alpha1.Parameter{
+				{Name: "units", Value: "metric", Schema: &arkv1alpha1.ParameterSchema{Type: arkv1alpha1.ParameterTypeEnum}},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must list their allowed values"))
+		})
+	})
+
+	Context("When validating query dependencies", func() {
+		var ctx context.Context
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			s := runtime.NewScheme()
+			Expect(arkv1alpha1.AddToScheme(s)).To(Succeed())
+
+			fakeClient := fake.NewClientBuilder().WithScheme(s).WithObjects(
+				&arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "assistant", Namespace: "default"}},
+				&arkv1alpha1.Query{
+					ObjectMeta: metav1.ObjectMeta{Name: "research", Namespace: "default"},
+					Spec:       arkv1alpha1.QuerySpec{DependsOn: []string{"report"}},
+				},
+			).Build()
+			validator = QueryCustomValidator{ResourceValidator: &ResourceValidator{Client: fakeClient}}
+
+			obj = &arkv1alpha1.Query{
+				ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "default"},
+				Spec: arkv1alpha1.QuerySpec{
+					Input:   runtime.RawExtension{Raw: []byte(`"What is the weather?"`)},
+					Targets: []arkv1alpha1.QueryTarget{{Type: TargetTypeAgent, Name: "assistant"}},
+				},
+			}
+		})
+
+		It("Should admit dependencies on queries that do not exist yet", func() {
+			obj.Spec.DependsOn = []string{"not-created-yet"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a query that depends on itself", func() {
+			obj.Spec.DependsOn = []string{"report"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("report -> report"))
+		})
+
+		It("Should deny a dependency cycle through existing queries", func() {
+			obj.Spec.DependsOn = []string{"research"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("report -> research -> report"))
+		})
+	})
+
+	Context("When validating the selector policy", func() {
+		var ctx context.Context
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			s := runtime.NewScheme()
+			Expect(arkv1alpha1.AddToScheme(s)).To(Succeed())
+			fakeClient := fake.NewClientBuilder().WithScheme(s).WithObjects(
+				&arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "analyst", Namespace: "default"}},
+			).Build()
+			validator = QueryCustomValidator{ResourceValidator: &ResourceValidator{Client: fakeClient}}
+
+			obj = &arkv1alpha1.Query{
+				ObjectMeta: metav1.ObjectMeta{Name: "survey", Namespace: "default"},
+				Spec: arkv1alpha1.QuerySpec{
+					Input:          runtime.RawExtension{Raw: []byte(`"What is the weather?"`)},
+					Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"role": "analyst"}},
+					SelectorPolicy: &arkv1alpha1.SelectorPolicy{Sample: ptr.To(int32(3)), Quorum: ptr.To(int32(2))},
+				},
+			}
+		})
+
+		It("Should admit a quorum within the sample", func() {
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a quorum larger than the sample", func() {
+			obj.Spec.SelectorPolicy.Quorum = ptr.To(int32(4))
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("larger than the sample"))
+		})
+
+		It("Should deny a quorum above one when stopping after the first success", func() {
+			obj.Spec.SelectorPolicy.StopAfterFirstSuccess = true
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("stopping after the first success"))
+		})
+
+		It("Should deny a selector policy without a selector", func() {
+			obj.Spec.Selector = nil
+			obj.Spec.Targets = []arkv1alpha1.QueryTarget{{Type: TargetTypeAgent, Name: "analyst"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("requires a selector"))
+		})
+	})
+
+	Context("When validating replays", func() {
+		var ctx context.Context
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			s := runtime.NewScheme()
+			Expect(arkv1alpha1.AddToScheme(s)).To(Succeed())
+			validator = QueryCustomValidator{ResourceValidator: &ResourceValidator{Client: fake.NewClientBuilder().WithScheme(s).Build()}}
+
+			obj = &arkv1alpha1.Query{
+				ObjectMeta: metav1.ObjectMeta{Name: "weather-replay", Namespace: "default"},
+				Spec:       arkv1alpha1.QuerySpec{ReplayOf: "weather"},
+			}
+		})
+
+		It("Should admit a replay without input or targets", func() {
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a replay with its own input", func() {
+			obj.Spec.Input = runtime.RawExtension{Raw: []byte(`"What is the weather?"`)}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("input cannot be set"))
+		})
+
+		It("Should admit the cloned input of a replay on update", func() {
+			obj.Spec.Input = runtime.RawExtension{Raw: []byte(`"What is the weather?"`)}
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a query that replays itself", func() {
+			obj.Spec.ReplayOf = obj.Name
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot replay itself"))
+		})
+
+		It("Should deny a query without input that replays nothing", func() {
+			obj.Spec.ReplayOf = ""
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("input is required"))
+		})
+	})
+
+	Context("When validating restored queries", func() {
+		var ctx context.Context
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			s := runtime.NewScheme()
+			Expect(arkv1alpha1.AddToScheme(s)).To(Succeed())
+			validator = QueryCustomValidator{ResourceValidator: &ResourceValidator{Client: fake.NewClientBuilder().WithScheme(s).Build()}}
+
+			oldObj = &arkv1alpha1.Query{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "weather",
+					Namespace:   "default",
+					Annotations: map[string]string{annotations.RestoredFrom: "weather-snapshot"},
+				},
+				Spec: arkv1alpha1.QuerySpec{Targets: []arkv1alpha1.QueryTarget{{Type: "agent", Name: "deleted-agent"}}},
+			}
+			Expect(oldObj.Spec.SetInputString("What is the weather?")).To(Succeed())
+			obj = oldObj.DeepCopy()
+		})
+
+		It("Should admit a restored query whose targets no longer exist", func() {
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should admit metadata updates of a restored query", func() {
+			obj.Finalizers = []string{"ark.mckinsey.com/finalizer"}
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny spec updates of a restored query", func() {
+			Expect(obj.Spec.SetInputString("What is the weather tomorrow?")).To(Succeed())
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("restored from snapshot weather-snapshot and is read-only"))
+		})
+
+		It("Should deny removing the restored annotation", func() {
+			obj.Annotations = nil
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot be changed on a restored query"))
+		})
+	})
+
+	Context("When validating the failure policy", func() {
+		var ctx context.Context
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			s := runtime.NewScheme()
+			Expect(arkv1alpha1.AddToScheme(s)).To(Succeed())
+			fakeClient := fake.NewClientBuilder().WithScheme(s).WithObjects(
+				&arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "researcher", Namespace: "default"}},
+				&arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "writer", Namespace: "default"}},
+			).Build()
+			validator = QueryCustomValidator{ResourceValidator: &ResourceValidator{Client: fakeClient}}
+
+			obj = &arkv1alpha1.Query{
+				ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "default"},
+				Spec: arkv1alpha1.QuerySpec{
+					Input: runtime.RawExtension{Raw: []byte(`"What is the weather?"`)},
+					Targets: []arkv1alpha1.QueryTarget{
+						{Type: TargetTypeAgent, Name: "researcher"},
+						{Type: TargetTypeAgent, Name: "writer"},
+					},
+					FailurePolicy: &arkv1alpha1.FailurePolicy{Mode: arkv1alpha1.FailurePolicyThreshold, MinSuccesses: ptr.To(int32(2))},
+				},
+			}
+		})
+
+		It("Should admit a threshold the targets can reach", func() {
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a threshold larger than the targets", func() {
+			obj.Spec.FailurePolicy.MinSuccesses = ptr.To(int32(3))
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("larger than the 2 targets"))
+		})
+
+		It("Should deny a threshold without minSuccesses", func() {
+			obj.Spec.FailurePolicy.MinSuccesses = nil
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("threshold requires minSuccesses"))
+		})
+
+		It("Should deny minSuccesses with another mode", func() {
+			obj.Spec.FailurePolicy.Mode = arkv1alpha1.FailurePolicyBestEffort
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("only used by the threshold mode"))
+		})
+
+		It("Should deny a failure policy combined with a selector quorum", func() {
+			obj.Spec.FailurePolicy = &arkv1alpha1.FailurePolicy{Mode: arkv1alpha1.FailurePolicyBestEffort}
+			obj.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"role": "analyst"}}
+			obj.Spec.SelectorPolicy = &arkv1alpha1.SelectorPolicy{Quorum: ptr.To(int32(1))}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot be combined"))
+		})
+	})
+
+	Context("When defaulting queries", func() {
+		var (
+			ctx       context.Context
+			defaulter QueryCustomDefaulter
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			s := runtime.NewScheme()
+			Expect(corev1.AddToScheme(s)).To(Succeed())
+			fakeClient := fake.NewClientBuilder().WithScheme(s).WithObjects(
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: genai.QueriesConfigMapName, Namespace: "batch"},
+					Data:       map[string]string{"ttl": "24h", "timeout": "30m"},
+				},
+			).Build()
+			defaulter = QueryCustomDefaulter{Client: fakeClient}
+
+			obj = &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: "default"}}
+		})
+
+		It("Should infer a user query from a string input", func() {
+			obj.Spec.Input = runtime.RawExtension{Raw: []byte(`"What is the weather?"`)}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Type).To(Equal(arkv1alpha1.QueryTypeUser))
+		})
+
+		It("Should infer a messages query and normalize shorthand messages", func() {
+			obj.Spec.Input = runtime.RawExtension{Raw: []byte(`[{"role":"system","content":"Answer briefly."},"What is the weather?"]`)}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Type).To(Equal(arkv1alpha1.QueryTypeMessages))
+			Expect(string(obj.Spec.Input.Raw)).To(MatchJSON(`[{"role":"system","content":"Answer briefly."},{"role":"user","content":"What is the weather?"}]`))
+
+			obj.Spec.Type = ""
+			obj.Spec.Input = runtime.RawExtension{Raw: []byte(`{"role":"user","content":"What is the weather?"}`)}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Type).To(Equal(arkv1alpha1.QueryTypeMessages))
+			Expect(string(obj.Spec.Input.Raw)).To(MatchJSON(`[{"role":"user","content":"What is the weather?"}]`))
+		})
+
+		It("Should keep an explicit type", func() {
+			obj.Spec.Type = arkv1alpha1.QueryTypeUser
+			obj.Spec.Input = runtime.RawExtension{Raw: []byte(`["What is the weather?"]`)}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Type).To(Equal(arkv1alpha1.QueryTypeUser))
+			Expect(string(obj.Spec.Input.Raw)).To(Equal(`["What is the weather?"]`))
+		})
+
+		It("Should set the ttl and timeout of the namespace", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.TTL.Duration).To(Equal(genai.DefaultQueryTTL))
+			Expect(obj.Spec.Timeout.Duration).To(Equal(genai.DefaultQueryTimeout))
+
+			batch := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "batch"}}
+			batch.Spec.Timeout = &metav1.Duration{Duration: time.Hour}
+			Expect(defaulter.Default(ctx, batch)).To(Succeed())
+			Expect(batch.Spec.TTL.Duration).To(Equal(24 * time.Hour))
+			Expect(batch.Spec.Timeout.Duration).To(Equal(time.Hour))
+		})
+	})
+
+	Context("When validating the input", func() {
+		var ctx context.Context
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			s := runtime.NewScheme()
+			Expect(arkv1alpha1.AddToScheme(s)).To(Succeed())
+			fakeClient := fake.NewClientBuilder().WithScheme(s).WithObjects(
+				&arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "assistant", Namespace: "default"}},
+			).Build()
+			validator = QueryCustomValidator{ResourceValidator: &ResourceValidator{Client: fakeClient}}
+
+			obj = &arkv1alpha1.Query{
+				ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: "default"},
+				Spec: arkv1alpha1.QuerySpec{
+					Type:    arkv1alpha1.QueryTypeMessages,
+					Input:   runtime.RawExtension{Raw: []byte(`[{"role":"user","content":"What is the weather?"}]`)},
+					Targets: []arkv1alpha1.QueryTarget{{Type: TargetTypeAgent, Name: "assistant"}},
+				},
+			}
+		})
+
+		It("Should admit messages", func() {
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a message of an unknown role", func() {
+			obj.Spec.Input = runtime.RawExtension{Raw: []byte(`[{"role":"customer","content":"What is the weather?"}]`)}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`message[0] has role "customer"`))
+		})
+
+		It("Should deny a message without content", func() {
+			obj.Spec.Input = runtime.RawExtension{Raw: []byte(`[{"role":"user","content":""}]`)}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("message[0] of role user has no content"))
+		})
+
+		It("Should admit an assistant message that only calls tools, answered by a tool message", func() {
+			obj.Spec.Input = runtime.RawExtension{Raw: []byte(`[
+				{"role":"user","content":"What is the weather?"},
+				{"role":"assistant","tool_calls":[{"id":"call-1","type":"function","function":{"name":"get-weather","arguments":"{}"}}]},
+				{"role":"tool","tool_call_id":"call-1","content":"Sunny"}
+			]`)}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a tool message without a tool_call_id", func() {
+			obj.Spec.Input = runtime.RawExtension{Raw: []byte(`[{"role":"user","content":"What is the weather?"},{"role":"tool","content":"Sunny"}]`)}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("message[1] of role tool has no tool_call_id"))
+		})
+
+		It("Should deny a user query whose input is not a string", func() {
+			obj.Spec.Type = arkv1alpha1.QueryTypeUser
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must be a string"))
+		})
+	})
 })
@@ -0,0 +1,84 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// SetupSessionWebhookWithManager registers the webhook for Session in the manager.
+func SetupSessionWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arkv1alpha1.Session{}).
+		WithValidator(&SessionCustomValidator{ResourceValidator: &ResourceValidator{Client: mgr.GetClient()}}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-ark-mckinsey-com-v1alpha1-session,mutating=false,failurePolicy=fail,sideEffects=None,groups=ark.mckinsey.com,resources=sessions,verbs=create;update,versions=v1alpha1,name=vsession-v1.kb.io,admissionReviewVersions=v1
+
+// SessionCustomValidator admits a session whose memory is in another namespace only when the requesting user
+// can read it, since the controller forks and expires the messages of the session in that memory
+type SessionCustomValidator struct {
+	*ResourceValidator
+}
+
+var _ webhook.CustomValidator = &SessionCustomValidator{}
+
+func (v *SessionCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	session, ok := obj.(*arkv1alpha1.Session)
+	if !ok {
+		return nil, fmt.Errorf("expected a Session object but got %T", obj)
+	}
+
+	return nil, v.validateSessionMemory(ctx, session)
+}
+
+func (v *SessionCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldSession, ok := oldObj.(*arkv1alpha1.Session)
+	if !ok {
+		return nil, fmt.Errorf("expected a Session object for the oldObj but got %T", oldObj)
+	}
+	session, ok := newObj.(*arkv1alpha1.Session)
+	if !ok {
+		return nil, fmt.Errorf("expected a Session object for the newObj but got %T", newObj)
+	}
+
+	// Access to the memory was checked when it was set
+	if equality.Semantic.DeepEqual(oldSession.Spec.Memory, session.Spec.Memory) {
+		return nil, nil
+	}
+	return nil, v.validateSessionMemory(ctx, session)
+}
+
+func (v *SessionCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	_, ok := obj.(*arkv1alpha1.Session)
+	if !ok {
+		return nil, fmt.Errorf("expected a Session object but got %T", obj)
+	}
+
+	return nil, nil
+}
+
+// validateSessionMemory runs a SubjectAccessReview for the requesting user against a memory in another namespace
+func (v *SessionCustomValidator) validateSessionMemory(ctx context.Context, session *arkv1alpha1.Session) error {
+	memory := session.Spec.Memory
+	if memory == nil || memory.Namespace == "" || memory.Namespace == session.Namespace {
+		return nil
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || req.UserInfo.Username == "" {
+		return fmt.Errorf("memory '%s' is in namespace '%s', which differs from the session namespace '%s'; a user is required to verify access",
+			memory.Name, memory.Namespace, session.Namespace)
+	}
+	return v.ValidateMemoryAccess(ctx, "session", session.Namespace, memory.Name, memory.Namespace,
+		req.UserInfo.Username, req.UserInfo.Groups, fmt.Sprintf("user '%s'", req.UserInfo.Username))
+}
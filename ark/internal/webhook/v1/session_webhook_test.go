@@ -0,0 +1,105 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+var _ = Describe("Session Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *arkv1alpha1.Session
+		validator SessionCustomValidator
+		reviewed  *authorizationv1.SubjectAccessReview
+	)
+
+	// newValidator answers the SubjectAccessReviews of the validator with allowed
+	newValidator := func(allowed bool) SessionCustomValidator {
+		s := runtime.NewScheme()
+		Expect(arkv1alpha1.AddToScheme(s)).To(Succeed())
+		reviewingClient := interceptor.NewClient(fake.NewClientBuilder().WithScheme(s).Build(), interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if review, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+					review.Status.Allowed = allowed
+					reviewed = review
+					return nil
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		})
+		return SessionCustomValidator{ResourceValidator: &ResourceValidator{Client: reviewingClient}}
+	}
+
+	BeforeEach(func() {
+		reviewed = nil
+		ctx = admission.NewContextWithRequest(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authenticationv1.UserInfo{Username: "alice", Groups: []string{"team-a"}},
+		}})
+		validator = newValidator(true)
+		obj = &arkv1alpha1.Session{
+			ObjectMeta: metav1.ObjectMeta{Name: "chat-42", Namespace: "default"},
+			Spec:       arkv1alpha1.SessionSpec{Memory: &arkv1alpha1.MemoryRef{Name: "shared-memory", Namespace: "shared"}},
+		}
+	})
+
+	It("Should admit a session with memory in its own namespace without a review", func() {
+		obj.Spec.Memory.Namespace = ""
+		Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		obj.Spec.Memory.Namespace = "default"
+		Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		Expect(reviewed).To(BeNil())
+	})
+
+	It("Should run a subject access review for the requesting user against cross-namespace memory", func() {
+		Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		Expect(reviewed).NotTo(BeNil())
+		Expect(reviewed.Spec.User).To(Equal("alice"))
+		Expect(reviewed.Spec.Groups).To(ConsistOf("team-a"))
+		Expect(*reviewed.Spec.ResourceAttributes).To(Equal(authorizationv1.ResourceAttributes{
+			Namespace: "shared",
+			Verb:      "get",
+			Group:     "ark.mckinsey.com",
+			Resource:  "memories",
+			Name:      "shared-memory",
+		}))
+	})
+
+	It("Should deny cross-namespace memory the requesting user cannot read", func() {
+		validator = newValidator(false)
+		_, err := validator.ValidateCreate(ctx, obj)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("user 'alice' is not permitted to read memories there"))
+	})
+
+	It("Should deny cross-namespace memory without a user to check", func() {
+		_, err := validator.ValidateCreate(context.Background(), obj)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("a user is required to verify access"))
+	})
+
+	It("Should only check updates that change the memory", func() {
+		validator = newValidator(false)
+		oldObj := obj.DeepCopy()
+		obj.Spec.ForkFrom = "chat-41"
+		Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		Expect(reviewed).To(BeNil())
+
+		oldObj.Spec.Memory = nil
+		Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(HaveOccurred())
+	})
+})
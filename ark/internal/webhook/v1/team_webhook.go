@@ -4,8 +4,14 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/google/jsonschema-go/jsonschema"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -20,11 +26,18 @@ const (
 	MemberTypeAgent  = "agent"
 	MemberTypeTeam   = "team"
 	StrategySelector = "selector"
+	StrategyParallel = "parallel"
+
+	// DefaultTeamMaxNestingDepth is the number of levels of teams that a team may nest by default
+	DefaultTeamMaxNestingDepth = 10
 )
 
-func SetupTeamWebhookWithManager(mgr ctrl.Manager) error {
+func SetupTeamWebhookWithManager(mgr ctrl.Manager, maxNestingDepth int) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&arkv1alpha1.Team{}).
-		WithValidator(&TeamCustomValidator{ResourceValidator: &ResourceValidator{Client: mgr.GetClient()}}).
+		WithValidator(&TeamCustomValidator{
+			ResourceValidator: &ResourceValidator{Client: mgr.GetClient()},
+			MaxNestingDepth:   maxNestingDepth,
+		}).
 		Complete()
 }
 
@@ -37,6 +50,8 @@ func SetupTeamWebhookWithManager(mgr ctrl.Manager) error {
 // as this struct is used only for temporary operations and does not need to be deeply copied.
 type TeamCustomValidator struct {
 	*ResourceValidator
+	// MaxNestingDepth limits how many levels of teams a team may nest, DefaultTeamMaxNestingDepth when not set
+	MaxNestingDepth int
 }
 
 var _ webhook.CustomValidator = &TeamCustomValidator{}
@@ -71,6 +86,10 @@ func (v *TeamCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Ob
 func (v *TeamCustomValidator) validateTeamMembers(ctx context.Context, team *arkv1alpha1.Team) (admission.Warnings, error) {
 	var warnings admission.Warnings
 
+	if err := v.validateMemberSelector(team); err != nil {
+		return warnings, err
+	}
+
 	if err := v.validateStrategy(ctx, team); err != nil {
 		return warnings, err
 	}
@@ -95,15 +114,69 @@ func (v *TeamCustomValidator) validateTeamMembers(ctx context.Context, team *ark
 		default:
 			return warnings, fmt.Errorf("team member %d has invalid type '%s': must be '%s' or '%s'", i, member.Type, MemberTypeAgent, MemberTypeTeam)
 		}
+
+		if member.History != nil && member.History.ModelRef != nil {
+			namespace := member.History.ModelRef.Namespace
+			if namespace == "" {
+				namespace = team.Namespace
+			}
+			if err := v.ValidateLoadModel(ctx, member.History.ModelRef.Name, namespace); err != nil {
+				return warnings, fmt.Errorf("team member %d history references model: %v", i, err)
+			}
+		}
+	}
+
+	if err := v.validateNestedTeams(ctx, team, []string{team.Name}); err != nil {
+		return warnings, err
 	}
 
 	if err := v.validateNoMixedTeam(ctx, team); err != nil {
 		return warnings, err
 	}
 
+	if err := v.validateFinalizer(ctx, team); err != nil {
+		return warnings, err
+	}
+
 	return warnings, nil
 }
 
+// validateNestedTeams walks the teams nested in the team, so that a team is rejected when it would reach itself
+// through its members or nest more levels of teams than allowed. The path holds the names of the teams walked so far.
+func (v *TeamCustomValidator) validateNestedTeams(ctx context.Context, team *arkv1alpha1.Team, path []string) error {
+	maxDepth := v.MaxNestingDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultTeamMaxNestingDepth
+	}
+
+	for _, member := range team.Spec.Members {
+		if member.Type != MemberTypeTeam {
+			continue
+		}
+		nestedPath := append(slices.Clone(path), member.Name)
+		if slices.Contains(path, member.Name) {
+			return fmt.Errorf("team cycle detected: %s", strings.Join(nestedPath, " -> "))
+		}
+		if len(path) > maxDepth {
+			return fmt.Errorf("team nesting exceeds the maximum depth of %d: %s", maxDepth, strings.Join(nestedPath, " -> "))
+		}
+
+		var nested arkv1alpha1.Team
+		key := types.NamespacedName{Name: member.Name, Namespace: team.Namespace}
+		if err := v.Client.Get(ctx, key, &nested); err != nil {
+			// Members of the team itself are checked to exist, teams deeper down may be created later
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to load nested team '%s': %v", member.Name, err)
+		}
+		if err := v.validateNestedTeams(ctx, &nested, nestedPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (v *TeamCustomValidator) validateNoMixedTeam(ctx context.Context, team *arkv1alpha1.Team) error {
 	var hasInternalAgents, hasExternalAgents bool
 
@@ -130,7 +203,32 @@ func (v *TeamCustomValidator) validateNoMixedTeam(ctx context.Context, team *ark
 	return nil
 }
 
+// validateMemberSelector validates the label selector that adds agents to the team when it executes
+func (v *TeamCustomValidator) validateMemberSelector(team *arkv1alpha1.Team) error {
+	if team.Spec.MemberSelector == nil {
+		if len(team.Spec.Members) == 0 {
+			return fmt.Errorf("team requires members or memberSelector to be specified")
+		}
+		return nil
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(team.Spec.MemberSelector); err != nil {
+		return fmt.Errorf("invalid memberSelector: %v", err)
+	}
+
+	// Graph edges name their members, so they cannot refer to agents that only join the team when it executes
+	if team.Spec.Graph != nil {
+		return fmt.Errorf("memberSelector is not supported with a graph: edges must reference the members of the team")
+	}
+
+	return nil
+}
+
 func (v *TeamCustomValidator) validateStrategy(ctx context.Context, team *arkv1alpha1.Team) error {
+	if team.Spec.Aggregator != nil && team.Spec.Strategy != StrategyParallel {
+		return fmt.Errorf("aggregator is only supported by the parallel strategy")
+	}
+
 	switch team.Spec.Strategy {
 	case "sequential", "round-robin":
 		return nil
@@ -145,11 +243,31 @@ func (v *TeamCustomValidator) validateStrategy(ctx context.Context, team *arkv1a
 		return nil
 	case "graph":
 		return v.validateGraphStrategy(team)
+	case StrategyParallel:
+		return v.validateAggregatorAgent(ctx, team)
 	default:
-		return fmt.Errorf("unsupported strategy '%s': must be 'sequential', 'round-robin', 'selector', or 'graph'", team.Spec.Strategy)
+		return fmt.Errorf("unsupported strategy '%s': must be 'sequential', 'round-robin', 'selector', 'graph', or 'parallel'", team.Spec.Strategy)
 	}
 }
 
+func (v *TeamCustomValidator) validateAggregatorAgent(ctx context.Context, team *arkv1alpha1.Team) error {
+	if team.Spec.Aggregator == nil {
+		return nil
+	}
+	if team.Spec.Aggregator.Agent == "" {
+		return fmt.Errorf("aggregator requires aggregator.agent to be specified")
+	}
+
+	agentName := team.Spec.Aggregator.Agent
+
+	err := v.ValidateLoadAgent(ctx, agentName, team.Namespace)
+	if err != nil {
+		return fmt.Errorf("aggregator agent '%s' not found in namespace %s: %v", agentName, team.Namespace, err)
+	}
+
+	return nil
+}
+
 func (v *TeamCustomValidator) validateSelectorAgent(ctx context.Context, team *arkv1alpha1.Team) error {
 	if team.Spec.Selector == nil || team.Spec.Selector.Agent == "" {
 		return fmt.Errorf("selector strategy requires selector.agent to be specified")
@@ -231,3 +349,33 @@ func (v *TeamCustomValidator) validateGraphForSelector(team *arkv1alpha1.Team) e
 
 	return nil
 }
+
+// validateFinalizer validates the output schema of the team and the finalizer that produces it
+func (v *TeamCustomValidator) validateFinalizer(ctx context.Context, team *arkv1alpha1.Team) error {
+	if team.Spec.OutputSchema == nil {
+		if team.Spec.Finalizer != nil {
+			return fmt.Errorf("finalizer requires outputSchema to be specified")
+		}
+		return nil
+	}
+
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(team.Spec.OutputSchema.Raw, &schema); err != nil {
+		return fmt.Errorf("failed to parse outputSchema as JSON: %v", err)
+	}
+	if _, err := schema.Resolve(nil); err != nil {
+		return fmt.Errorf("invalid outputSchema: %v", err)
+	}
+
+	if team.Spec.Finalizer != nil && team.Spec.Finalizer.ModelRef != nil {
+		namespace := team.Spec.Finalizer.ModelRef.Namespace
+		if namespace == "" {
+			namespace = team.Namespace
+		}
+		if err := v.ValidateLoadModel(ctx, team.Spec.Finalizer.ModelRef.Name, namespace); err != nil {
+			return fmt.Errorf("finalizer references model: %v", err)
+		}
+	}
+
+	return nil
+}
@@ -178,6 +178,227 @@ var _ = Describe("Team Webhook", func() {
 		})
 	})
 
+	Context("Parallel strategy validation", func() {
+		BeforeEach(func() {
+			obj.Spec.Strategy = "parallel"
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent"},
+				{Name: "analyst", Type: "agent"},
+			}
+		})
+
+		It("Should allow parallel strategy without aggregator", func() {
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should allow parallel strategy with an existing aggregator agent", func() {
+			obj.Spec.Aggregator = &arkv1alpha1.TeamAggregatorSpec{Agent: "writer"}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject an aggregator agent that does not exist", func() {
+			obj.Spec.Aggregator = &arkv1alpha1.TeamAggregatorSpec{Agent: "missing"}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("aggregator agent 'missing' not found"))
+		})
+
+		It("Should reject an aggregator for other strategies", func() {
+			obj.Spec.Strategy = "sequential"
+			obj.Spec.Aggregator = &arkv1alpha1.TeamAggregatorSpec{Agent: "writer"}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("aggregator is only supported by the parallel strategy"))
+		})
+	})
+
+	Context("Member history validation", func() {
+		BeforeEach(func() {
+			obj.Spec.Strategy = "sequential"
+		})
+
+		It("Should allow a last-n history policy", func() {
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent", History: &arkv1alpha1.TeamMemberHistory{Policy: arkv1alpha1.TeamMemberHistoryLastN, LastN: 4}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject a summary model that does not exist", func() {
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent", History: &arkv1alpha1.TeamMemberHistory{
+					Policy:   arkv1alpha1.TeamMemberHistorySummary,
+					ModelRef: &arkv1alpha1.AgentModelRef{Name: "missing-model"},
+				}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("team member 0 history references model"))
+		})
+	})
+
+	Context("Output schema validation", func() {
+		BeforeEach(func() {
+			obj.Spec.Strategy = "sequential"
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent"},
+				{Name: "writer", Type: "agent"},
+			}
+		})
+
+		It("Should allow an output schema with the extract finalizer", func() {
+			obj.Spec.OutputSchema = &runtime.RawExtension{Raw: []byte(`{"type": "object", "properties": {"summary": {"type": "string"}}}`)}
+			obj.Spec.Finalizer = &arkv1alpha1.TeamFinalizerSpec{Strategy: arkv1alpha1.TeamFinalizerExtract}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject an invalid output schema", func() {
+			obj.Spec.OutputSchema = &runtime.RawExtension{Raw: []byte(`{"type": 5}`)}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("outputSchema"))
+		})
+
+		It("Should reject a finalizer without an output schema", func() {
+			obj.Spec.Finalizer = &arkv1alpha1.TeamFinalizerSpec{Strategy: arkv1alpha1.TeamFinalizerModel}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("finalizer requires outputSchema"))
+		})
+
+		It("Should reject a finalizer model that does not exist", func() {
+			obj.Spec.OutputSchema = &runtime.RawExtension{Raw: []byte(`{"type": "object"}`)}
+			obj.Spec.Finalizer = &arkv1alpha1.TeamFinalizerSpec{ModelRef: &arkv1alpha1.AgentModelRef{Name: "missing-model"}}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("finalizer references model"))
+		})
+	})
+
+	Context("Member selector validation", func() {
+		BeforeEach(func() {
+			obj.Spec.Strategy = "sequential"
+			obj.Spec.MemberSelector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "research"},
+			}
+		})
+
+		It("Should allow a member selector without members", func() {
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject a team without members or member selector", func() {
+			obj.Spec.MemberSelector = nil
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("team requires members or memberSelector"))
+		})
+
+		It("Should reject an invalid member selector", func() {
+			obj.Spec.MemberSelector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: "Matches", Values: []string{"research"}},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid memberSelector"))
+		})
+
+		It("Should reject a member selector with a graph", func() {
+			obj.Spec.Strategy = "selector"
+			obj.Spec.Selector = &arkv1alpha1.TeamSelectorSpec{Agent: "coordinator"}
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent"},
+				{Name: "analyst", Type: "agent"},
+			}
+			obj.Spec.Graph = &arkv1alpha1.TeamGraphSpec{
+				Edges: []arkv1alpha1.TeamGraphEdge{{From: "researcher", To: "analyst"}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("memberSelector is not supported with a graph"))
+		})
+	})
+
+	Context("Nested team validation", func() {
+		nestedTeam := func(name string, members ...string) *arkv1alpha1.Team {
+			team := &arkv1alpha1.Team{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec:       arkv1alpha1.TeamSpec{Strategy: "sequential"},
+			}
+			for _, member := range members {
+				team.Spec.Members = append(team.Spec.Members, arkv1alpha1.TeamMember{Name: member, Type: "team"})
+			}
+			return team
+		}
+
+		BeforeEach(func() {
+			obj.Spec.Strategy = "sequential"
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent"},
+				{Name: "review-team", Type: "team"},
+			}
+		})
+
+		It("Should reject a team that references itself through a nested team", func() {
+			Expect(validator.Client.Create(ctx, nestedTeam("review-team", "editing-team"))).To(Succeed())
+			Expect(validator.Client.Create(ctx, nestedTeam("editing-team", "test-team"))).To(Succeed())
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("team cycle detected: test-team -> review-team -> editing-team -> test-team"))
+		})
+
+		It("Should reject a cycle between nested teams", func() {
+			Expect(validator.Client.Create(ctx, nestedTeam("review-team", "editing-team"))).To(Succeed())
+			Expect(validator.Client.Create(ctx, nestedTeam("editing-team", "review-team"))).To(Succeed())
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("team cycle detected: test-team -> review-team -> editing-team -> review-team"))
+		})
+
+		It("Should allow the same team nested twice without a cycle", func() {
+			Expect(validator.Client.Create(ctx, nestedTeam("review-team", "editing-team", "editing-team"))).To(Succeed())
+			Expect(validator.Client.Create(ctx, nestedTeam("editing-team"))).To(Succeed())
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject teams nested deeper than the maximum depth", func() {
+			validator.MaxNestingDepth = 1
+			Expect(validator.Client.Create(ctx, nestedTeam("review-team", "editing-team"))).To(Succeed())
+			Expect(validator.Client.Create(ctx, nestedTeam("editing-team"))).To(Succeed())
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("team nesting exceeds the maximum depth of 1: test-team -> review-team -> editing-team"))
+
+			validator.MaxNestingDepth = 2
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
 	Context("Graph strategy validation (should remain strict)", func() {
 		It("Should reject multiple edges from same source for graph strategy", func() {
 			By("creating a graph team with multiple edges from same source")
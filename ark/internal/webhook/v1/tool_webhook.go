@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"slices"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -74,6 +75,9 @@ func (v *ToolCustomValidator) validateTool(_ context.Context, tool *arkv1alpha1.
 
 	switch tool.Spec.Type {
 	case genai.ToolTypeHTTP:
+		if tool.Spec.OpenAPI != nil {
+			return v.validateOpenAPI(tool.Spec)
+		}
 		return v.validateHTTP(tool.Spec.HTTP)
 	case genai.ToolTypeMCP:
 		return v.validateMCPTool(tool.Spec.MCP)
@@ -81,8 +85,10 @@ func (v *ToolCustomValidator) validateTool(_ context.Context, tool *arkv1alpha1.
 		return v.validateAgentTool(tool.Spec.Agent.Name)
 	case genai.ToolTypeBuiltin:
 		return v.validateBuiltinTool(tool.Name)
+	case genai.ToolTypeWasm:
+		return warnings, genai.CheckWasmTool(tool.Spec.Wasm)
 	default:
-		return warnings, fmt.Errorf("unsupported tool type '%s': supported types are: http, mcp, agent, builtin", tool.Spec.Type)
+		return warnings, fmt.Errorf("unsupported tool type '%s': supported types are: http, mcp, agent, builtin, wasm", tool.Spec.Type)
 	}
 }
 
@@ -115,6 +121,34 @@ func (v *ToolCustomValidator) validateHTTP(httpSpec *arkv1alpha1.HTTPSpec) (admi
 	return warnings, nil
 }
 
+// validateOpenAPI validates http tools that generate a tool per operation of an OpenAPI document
+func (v *ToolCustomValidator) validateOpenAPI(spec arkv1alpha1.ToolSpec) (admission.Warnings, error) {
+	var warnings admission.Warnings
+
+	if spec.HTTP != nil {
+		return warnings, fmt.Errorf("http and openapi cannot both be set: the tools generated from the document carry the http configuration")
+	}
+
+	openAPI := spec.OpenAPI
+	if (openAPI.Spec == nil) == (openAPI.URL == "") {
+		return warnings, fmt.Errorf("openapi requires exactly one of spec or url")
+	}
+	if openAPI.URL != "" {
+		if _, err := url.Parse(openAPI.URL); err != nil {
+			return warnings, fmt.Errorf("invalid openapi URL format: %v", err)
+		}
+	}
+
+	// Documents from ConfigMaps and URLs are checked when the tools are generated
+	if openAPI.Spec != nil && openAPI.Spec.Value != "" {
+		if _, err := genai.ParseOpenAPI([]byte(openAPI.Spec.Value)); err != nil {
+			return warnings, fmt.Errorf("invalid openapi spec: %v", err)
+		}
+	}
+
+	return warnings, nil
+}
+
 // validateMCPTool validates MCP-specific configuration
 func (v *ToolCustomValidator) validateMCPTool(mcp *arkv1alpha1.MCPToolRef) (admission.Warnings, error) {
 	var warnings admission.Warnings
@@ -148,14 +182,11 @@ func (v *ToolCustomValidator) validateAgentTool(agent string) (admission.Warning
 func (v *ToolCustomValidator) validateBuiltinTool(toolName string) (admission.Warnings, error) {
 	var warnings admission.Warnings
 
-	supportedBuiltinTools := []string{genai.BuiltinToolNoop, genai.BuiltinToolTerminate}
-	for _, supportedTool := range supportedBuiltinTools {
-		if toolName == supportedTool {
-			return warnings, nil
-		}
+	if slices.Contains(genai.BuiltinToolNames, toolName) {
+		return warnings, nil
 	}
 
-	return warnings, fmt.Errorf("unsupported builtin tool '%s': supported builtin tools are: %v", toolName, supportedBuiltinTools)
+	return warnings, fmt.Errorf("unsupported builtin tool '%s': supported builtin tools are: %v", toolName, genai.BuiltinToolNames)
 }
 
 // validateInputSchema validates the tool's inputSchema using jsonschema
@@ -4,14 +4,18 @@ package v1
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
+	"mckinsey.com/ark/internal/genai"
 )
 
 type ResourceValidator struct {
@@ -54,7 +58,7 @@ func (v *ResourceValidator) ValidateLoadModel(ctx context.Context, name, namespa
 	}
 
 	model := &arkv1alpha1.Model{}
-	key := types.NamespacedName{Name: name, Namespace: namespace}
+	key := types.NamespacedName{Name: genai.ResolveModelAlias(ctx, v.Client, name, namespace), Namespace: namespace}
 
 	if err := v.Client.Get(ctx, key, model); err != nil {
 		return fmt.Errorf("model '%s' does not exist in namespace '%s': %v", name, namespace, err)
@@ -96,6 +100,49 @@ func (v *ResourceValidator) ValidateLoadTool(ctx context.Context, name, namespac
 	return nil
 }
 
+func (v *ResourceValidator) ValidateLoadMemory(ctx context.Context, name, namespace string) error {
+	if name == "" {
+		return nil
+	}
+
+	memory := &arkv1alpha1.Memory{}
+	key := types.NamespacedName{Name: name, Namespace: namespace}
+
+	if err := v.Client.Get(ctx, key, memory); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to get memory '%s' in namespace '%s': %v", name, namespace, err)
+		}
+		return fmt.Errorf("memory '%s' does not exist in namespace '%s'", name, namespace)
+	}
+
+	return nil
+}
+
+// ValidateMemoryAccess runs a SubjectAccessReview for the identity that resolves a memory in another namespace
+// than the resource of the given kind referencing it, and rejects the reference when the identity cannot read it
+func (v *ResourceValidator) ValidateMemoryAccess(ctx context.Context, kind, namespace, name, memoryNamespace, user string, groups []string, identity string) error {
+	allowed, reason, err := common.CheckSubjectAccess(ctx, v.Client, user, groups, authorizationv1.ResourceAttributes{
+		Namespace: memoryNamespace,
+		Verb:      "get",
+		Group:     arkv1alpha1.GroupVersion.Group,
+		Resource:  "memories",
+		Name:      name,
+	})
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		msg := fmt.Sprintf("memory '%s' is in namespace '%s', which differs from the %s namespace '%s'; %s is not permitted to read memories there",
+			name, memoryNamespace, kind, namespace, identity)
+		if reason != "" {
+			msg = fmt.Sprintf("%s (%s)", msg, reason)
+		}
+		return errors.New(msg)
+	}
+
+	return nil
+}
+
 func (v *ResourceValidator) ValidateLoadConfigMap(ctx context.Context, name, namespace string) error {
 	if name == "" {
 		return nil
@@ -178,12 +225,41 @@ func (v *ResourceValidator) validateParameterValueSources(param arkv1alpha1.Para
 	if hasValue && hasValueFrom {
 		return fmt.Errorf("parameter[%d] '%s': cannot specify both value and valueFrom", index, param.Name)
 	}
-	if !hasValue && !hasValueFrom {
+	hasDefault := param.Schema != nil && param.Schema.Default != ""
+	if !hasValue && !hasValueFrom && !hasDefault {
 		return fmt.Errorf("parameter[%d] '%s': must specify either value or valueFrom", index, param.Name)
 	}
 	return nil
 }
 
+// validateParameterSchema checks that the schema is consistent and that the value and default match it.
+// Values from valueFrom are checked when they are resolved.
+func (v *ResourceValidator) validateParameterSchema(param arkv1alpha1.Parameter, index int) error {
+	schema := param.Schema
+	if schema == nil {
+		return nil
+	}
+
+	if schema.Type == arkv1alpha1.ParameterTypeEnum && len(schema.Enum) == 0 {
+		return fmt.Errorf("parameter[%d] '%s': enum parameters must list their allowed values", index, param.Name)
+	}
+	if schema.Type != arkv1alpha1.ParameterTypeEnum && len(schema.Enum) > 0 {
+		return fmt.Errorf("parameter[%d] '%s': enum values are only allowed for enum parameters", index, param.Name)
+	}
+
+	if schema.Default != "" {
+		if err := schema.Validate(schema.Default); err != nil {
+			return fmt.Errorf("parameter[%d] '%s': default %v", index, param.Name, err)
+		}
+	}
+	if param.Value != "" {
+		if err := schema.Validate(param.Value); err != nil {
+			return fmt.Errorf("parameter[%d] '%s': %v", index, param.Name, err)
+		}
+	}
+	return nil
+}
+
 func (v *ResourceValidator) validateValueFromSources(param arkv1alpha1.Parameter, index int) error {
 	if param.ValueFrom == nil {
 		return nil
@@ -254,6 +330,10 @@ func (v *ResourceValidator) validateSingleParameter(ctx context.Context, namespa
 		return err
 	}
 
+	if err := v.validateParameterSchema(param, index); err != nil {
+		return err
+	}
+
 	return v.validateParameterReferences(ctx, namespace, param, index)
 }
 
@@ -96,7 +96,7 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
-	err = SetupTeamWebhookWithManager(mgr)
+	err = SetupTeamWebhookWithManager(mgr, DefaultTeamMaxNestingDepth)
 	Expect(err).NotTo(HaveOccurred())
 
 	err = SetupAgentWebhookWithManager(mgr)
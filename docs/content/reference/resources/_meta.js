@@ -1,10 +1,15 @@
 export default {
   a2aserver: 'A2AServers',
   agent: 'Agents',
+  agenttest: 'AgentTests',
+  arkinstallstatus: 'ArkInstallStatus',
+  cronquery: 'CronQueries',
+  feedback: 'Feedback',
   mcpserver: 'MCPServers',
   memory: 'Memories',
   models: 'Models',
   query: 'Queries',
+  session: 'Sessions',
   team: 'Teams',
   tools: 'Tools'
 }
\ No newline at end of file
@@ -273,7 +273,7 @@ async def list_models():
     """List available models in OpenAI format, including ARK agents, teams, models, and tools."""
     models_list = []
 
-    async with with_ark_client("default", "v1alpha1") as ark_client:
+    async with with_ark_client(get_namespace(), "v1alpha1") as ark_client:
         # Get agents
         try:
             agents = await ark_client.agents.a_list()
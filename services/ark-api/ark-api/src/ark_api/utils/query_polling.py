@@ -11,22 +11,37 @@ from openai.types.completion_usage import CompletionUsage
 logger = logging.getLogger(__name__)
 
 
-def _create_chat_completion_response(
-    query_name: str,
-    model: str,
-    content: str,
-    messages: list,
-    annotations: dict = None
-) -> ChatCompletion:
-    """Create OpenAI-compatible chat completion response with optional Ark metadata."""
-    # Count tokens from messages array
+def _completion_usage(content: str, messages: list, token_usage: dict = None) -> CompletionUsage:
+    """Use the token usage the query recorded, or estimate it from word counts when it has none."""
+    if token_usage and token_usage.get("totalTokens"):
+        return CompletionUsage(
+            prompt_tokens=token_usage.get("promptTokens", 0),
+            completion_tokens=token_usage.get("completionTokens", 0),
+            total_tokens=token_usage["totalTokens"],
+        )
+
     prompt_text = " ".join([
         str(msg.get('content', '')) if isinstance(msg, dict) else str(msg)
         for msg in messages
     ])
     prompt_tokens = len(prompt_text.split())
     completion_tokens = len(content.split())
+    return CompletionUsage(
+        prompt_tokens=prompt_tokens,
+        completion_tokens=completion_tokens,
+        total_tokens=prompt_tokens + completion_tokens,
+    )
+
 
+def _create_chat_completion_response(
+    query_name: str,
+    model: str,
+    content: str,
+    messages: list,
+    annotations: dict = None,
+    token_usage: dict = None
+) -> ChatCompletion:
+    """Create OpenAI-compatible chat completion response with optional Ark metadata."""
     response_data = {
         "id": query_name,
         "object": "chat.completion",
@@ -39,11 +54,7 @@ def _create_chat_completion_response(
                 finish_reason="stop",
             )
         ],
-        "usage": CompletionUsage(
-            prompt_tokens=prompt_tokens,
-            completion_tokens=completion_tokens,
-            total_tokens=prompt_tokens + completion_tokens,
-        ),
+        "usage": _completion_usage(content, messages, token_usage),
     }
 
     # Add Ark metadata if annotations present
@@ -118,7 +129,9 @@ async def poll_query_completion(ark_client, query_name: str, model: str, message
             content = responses[0].get("content", "")
             # Get query annotations
             annotations = query_dict.get("metadata", {}).get("annotations")
-            return _create_chat_completion_response(query_name, model, content, messages, annotations)
+            return _create_chat_completion_response(
+                query_name, model, content, messages, annotations, status.get("tokenUsage")
+            )
 
         elif phase == "error":
             error_detail = _get_error_detail(status)
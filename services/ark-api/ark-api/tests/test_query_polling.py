@@ -0,0 +1,30 @@
+from ark_api.utils.query_polling import _create_chat_completion_response
+
+
+def test_chat_completion_reports_query_token_usage():
+    """The token usage the query recorded is reported as the completion's usage."""
+    completion = _create_chat_completion_response(
+        "openai-query-1234",
+        "agent/weather",
+        "It is sunny in Paris.",
+        [{"role": "user", "content": "What is the weather in Paris?"}],
+        token_usage={"promptTokens": 120, "completionTokens": 8, "totalTokens": 128},
+    )
+
+    assert completion.usage.prompt_tokens == 120
+    assert completion.usage.completion_tokens == 8
+    assert completion.usage.total_tokens == 128
+
+
+def test_chat_completion_estimates_usage_without_query_token_usage():
+    """Without recorded token usage, the usage is estimated from word counts."""
+    completion = _create_chat_completion_response(
+        "openai-query-1234",
+        "agent/weather",
+        "It is sunny.",
+        [{"role": "user", "content": "What is the weather?"}],
+    )
+
+    assert completion.usage.prompt_tokens == 4
+    assert completion.usage.completion_tokens == 3
+    assert completion.usage.total_tokens == 7
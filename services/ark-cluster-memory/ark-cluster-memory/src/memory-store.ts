@@ -1,4 +1,4 @@
-import { Message, StoredMessage } from './types.js';
+import { Message, StoredBlackboard, StoredMessage } from './types.js';
 import { readFileSync, writeFileSync, existsSync } from 'fs';
 import { dirname } from 'path';
 import { mkdirSync } from 'fs';
@@ -7,6 +7,8 @@ import { EventEmitter } from 'events';
 export class MemoryStore {
   // Flat list of all messages with metadata
   private messages: StoredMessage[] = [];
+  // Shared key-value state of executions, by execution ID
+  private blackboards: Map<string, StoredBlackboard> = new Map();
   private readonly maxMessageSize: number;
   private readonly memoryFilePath?: string;
   public eventEmitter: EventEmitter = new EventEmitter();
@@ -154,6 +156,11 @@ export class MemoryStore {
   clearSession(sessionID: string): void {
     this.validateSessionID(sessionID);
     this.messages = this.messages.filter(m => m.session_id !== sessionID);
+    for (const [executionID, blackboard] of this.blackboards) {
+      if (blackboard.session_id === sessionID) {
+        this.blackboards.delete(executionID);
+      }
+    }
     this.saveToFile();
   }
 
@@ -166,6 +173,29 @@ export class MemoryStore {
     this.saveToFile();
   }
 
+  getBlackboard(executionID: string): StoredBlackboard | undefined {
+    if (!executionID) {
+      throw new Error('Execution ID cannot be empty');
+    }
+    return this.blackboards.get(executionID);
+  }
+
+  setBlackboardEntry(executionID: string, sessionID: string, key: string, value: unknown): void {
+    this.validateSessionID(sessionID);
+    if (!executionID) {
+      throw new Error('Execution ID cannot be empty');
+    }
+    if (!key) {
+      throw new Error('Key cannot be empty');
+    }
+    this.validateMessage(value);
+
+    const blackboard = this.blackboards.get(executionID) ?? { session_id: sessionID, entries: {}, updated_at: '' };
+    blackboard.entries[key] = value;
+    blackboard.updated_at = new Date().toISOString();
+    this.blackboards.set(executionID, blackboard);
+  }
+
   getSessions(): string[] {
     // Get unique session IDs from the flat list
     const sessionSet = new Set(this.messages.map(m => m.session_id));
@@ -197,6 +227,7 @@ export class MemoryStore {
 
   purge(): void {
     this.messages = [];
+    this.blackboards.clear();
     this.saveToFile();
     console.log('[MEMORY PURGE] Cleared all messages');
   }
@@ -282,6 +282,119 @@ export function createMemoryRouter(memory: MemoryStore): Router {
     res.json({ status: 'success', message: `Query ${queryId} messages deleted from session ${sessionId}` });
   });
 
+  /**
+   * @swagger
+   * /blackboards/{executionId}:
+   *   get:
+   *     summary: Get the blackboard of an execution
+   *     description: Returns the shared key-value entries that the members of an execution wrote
+   *     tags:
+   *       - Memory
+   *     parameters:
+   *       - in: path
+   *         name: executionId
+   *         required: true
+   *         schema:
+   *           type: string
+   *         description: Execution ID
+   *     responses:
+   *       200:
+   *         description: The blackboard entries
+   *         content:
+   *           application/json:
+   *             schema:
+   *               type: object
+   *               properties:
+   *                 session_id:
+   *                   type: string
+   *                 entries:
+   *                   type: object
+   *                 updated_at:
+   *                   type: string
+   *       404:
+   *         description: The execution has no blackboard
+   */
+  router.get('/blackboards/:executionId', (req, res) => {
+    try {
+      const blackboard = memory.getBlackboard(req.params.executionId);
+      if (!blackboard) {
+        res.status(404).json({ error: `Blackboard ${req.params.executionId} not found` });
+        return;
+      }
+      res.json(blackboard);
+    } catch (error) {
+      console.error('Failed to get blackboard:', error);
+      const err = error as Error;
+      res.status(400).json({ error: err.message });
+    }
+  });
+
+  /**
+   * @swagger
+   * /blackboards/{executionId}/entries/{key}:
+   *   put:
+   *     summary: Write a blackboard entry
+   *     description: Sets an entry of the blackboard of an execution, replacing any previous value of the key
+   *     tags:
+   *       - Memory
+   *     parameters:
+   *       - in: path
+   *         name: executionId
+   *         required: true
+   *         schema:
+   *           type: string
+   *         description: Execution ID
+   *       - in: path
+   *         name: key
+   *         required: true
+   *         schema:
+   *           type: string
+   *         description: Entry key
+   *     requestBody:
+   *       required: true
+   *       content:
+   *         application/json:
+   *           schema:
+   *             type: object
+   *             required:
+   *               - session_id
+   *               - value
+   *             properties:
+   *               session_id:
+   *                 type: string
+   *                 description: Session the execution belongs to
+   *               value:
+   *                 description: Any JSON value
+   *     responses:
+   *       200:
+   *         description: Entry stored successfully
+   *       400:
+   *         description: Invalid request parameters
+   */
+  router.put('/blackboards/:executionId/entries/:key', (req, res) => {
+    try {
+      const { executionId, key } = req.params;
+      const { session_id, value } = req.body;
+
+      if (!session_id) {
+        res.status(400).json({ error: 'session_id is required' });
+        return;
+      }
+
+      if (value === undefined) {
+        res.status(400).json({ error: 'value is required' });
+        return;
+      }
+
+      memory.setBlackboardEntry(executionId, session_id, key, value);
+      res.status(200).send();
+    } catch (error) {
+      console.error('Failed to set blackboard entry:', error);
+      const err = error as Error;
+      res.status(400).json({ error: err.message });
+    }
+  });
+
   /**
    * @swagger
    * /sessions:
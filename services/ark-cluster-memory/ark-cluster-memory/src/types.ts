@@ -8,6 +8,12 @@ export interface StoredMessage {
   sequence: number;
 }
 
+export interface StoredBlackboard {
+  session_id: string;
+  entries: Record<string, unknown>;
+  updated_at: string;
+}
+
 export interface AddMessageRequest {
   message: Message;
 }
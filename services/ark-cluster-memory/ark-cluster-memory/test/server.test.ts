@@ -168,6 +168,53 @@ describe('ARK Cluster Memory API', () => {
     });
   });
 
+  describe('Blackboard Endpoints', () => {
+    test('should return 404 for an execution without blackboard', async () => {
+      const response = await request(app).get('/blackboards/query1-team-research');
+
+      expect(response.status).toBe(404);
+    });
+
+    test('should write and read blackboard entries', async () => {
+      await request(app)
+        .put('/blackboards/query1-team-research/entries/sources')
+        .send({ session_id: 'session1', value: ['report.pdf'] })
+        .expect(200);
+
+      await request(app)
+        .put('/blackboards/query1-team-research/entries/figures')
+        .send({ session_id: 'session1', value: { revenue: 12 } })
+        .expect(200);
+
+      const response = await request(app).get('/blackboards/query1-team-research');
+
+      expect(response.status).toBe(200);
+      expect(response.body.session_id).toBe('session1');
+      expect(response.body.entries).toEqual({ sources: ['report.pdf'], figures: { revenue: 12 } });
+    });
+
+    test('should require a value', async () => {
+      const response = await request(app)
+        .put('/blackboards/query1-team-research/entries/sources')
+        .send({ session_id: 'session1' });
+
+      expect(response.status).toBe(400);
+      expect(response.body.error).toBe('value is required');
+    });
+
+    test('should delete blackboards with their session', async () => {
+      await request(app)
+        .put('/blackboards/query1-team-research/entries/sources')
+        .send({ session_id: 'session1', value: 'report.pdf' })
+        .expect(200);
+
+      await request(app).delete('/sessions/session1').expect(200);
+
+      const response = await request(app).get('/blackboards/query1-team-research');
+      expect(response.status).toBe(404);
+    });
+  });
+
   describe('Error Handling', () => {
     test('should return 404 for unknown routes', async () => {
       const response = await request(app).get('/unknown');
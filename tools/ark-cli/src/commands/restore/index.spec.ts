@@ -0,0 +1,118 @@
+import {jest} from '@jest/globals';
+
+import output from '../../lib/output.js';
+
+const mockExeca = jest.fn() as any;
+jest.unstable_mockModule('execa', () => ({
+  execa: mockExeca,
+}));
+
+const {createRestoreCommand, RESTORED_FROM_ANNOTATION} = await import(
+  './index.js'
+);
+
+describe('restore query command', () => {
+  const snapshottedQuery = {
+    apiVersion: 'ark.mckinsey.com/v1alpha1',
+    kind: 'Query',
+    metadata: {
+      name: 'weather',
+      namespace: 'default',
+      annotations: {'ark.mckinsey.com/soft-delete': 'true'},
+    },
+    spec: {input: 'What is the weather?', targets: []},
+    status: {phase: 'done', responses: [{content: 'Sunny'}]},
+  };
+
+  beforeEach(() => {
+    jest.clearAllMocks();
+    jest.spyOn(output, 'success').mockImplementation(() => {});
+    jest.spyOn(output, 'error').mockImplementation(() => {});
+    jest.spyOn(process, 'exit').mockImplementation(() => undefined as never);
+  });
+
+  it('should recreate the query read-only with its status', async () => {
+    mockExeca
+      .mockResolvedValueOnce({
+        stdout: JSON.stringify({
+          metadata: {name: 'weather-snapshot'},
+          data: {'query.json': JSON.stringify(snapshottedQuery)},
+        }),
+      })
+      .mockResolvedValueOnce({
+        stdout: JSON.stringify({metadata: {name: 'weather'}}),
+      })
+      .mockResolvedValueOnce({stdout: ''});
+
+    const command = createRestoreCommand({});
+    await command.parseAsync(['node', 'test', 'query', 'weather']);
+
+    expect(mockExeca).toHaveBeenNthCalledWith(
+      1,
+      'kubectl',
+      ['get', 'configmap', 'weather-snapshot', '-o', 'json'],
+      {stdio: 'pipe'}
+    );
+
+    const created = JSON.parse(mockExeca.mock.calls[1][2].input);
+    expect(mockExeca.mock.calls[1][1]).toEqual([
+      'create',
+      '-f',
+      '-',
+      '-o',
+      'json',
+    ]);
+    expect(created.status).toBeUndefined();
+    expect(created.metadata.annotations).toEqual({
+      'ark.mckinsey.com/soft-delete': 'true',
+      [RESTORED_FROM_ANNOTATION]: 'weather-snapshot',
+    });
+
+    expect(mockExeca).toHaveBeenNthCalledWith(
+      3,
+      'kubectl',
+      [
+        'patch',
+        'queries',
+        'weather',
+        '--subresource=status',
+        '--type=merge',
+        '-p',
+        JSON.stringify({status: snapshottedQuery.status}),
+      ],
+      {stdio: 'pipe'}
+    );
+    expect(output.success).toHaveBeenCalled();
+    expect(process.exit).not.toHaveBeenCalled();
+  });
+
+  it('should fail when the configmap is not a query snapshot', async () => {
+    mockExeca.mockResolvedValueOnce({
+      stdout: JSON.stringify({metadata: {name: 'weather-snapshot'}, data: {}}),
+    });
+
+    const command = createRestoreCommand({});
+    await command.parseAsync(['node', 'test', 'query', 'weather']);
+
+    expect(output.error).toHaveBeenCalledWith(
+      'restoring query:',
+      'configmap weather-snapshot is not a query snapshot'
+    );
+    expect(process.exit).toHaveBeenCalledWith(1);
+  });
+
+  it('should fail when the query has no snapshot', async () => {
+    mockExeca.mockRejectedValue(
+      new Error('configmaps "weather-snapshot" not found')
+    );
+
+    const command = createRestoreCommand({});
+    await command.parseAsync(['node', 'test', 'query', 'weather']);
+
+    expect(output.error).toHaveBeenCalledWith(
+      'restoring query:',
+      'configmaps "weather-snapshot" not found'
+    );
+    expect(process.exit).toHaveBeenCalledWith(1);
+  });
+});
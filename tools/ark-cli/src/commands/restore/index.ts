@@ -0,0 +1,72 @@
+import {Command} from 'commander';
+import type {ArkConfig} from '../../lib/config.js';
+import output from '../../lib/output.js';
+import type {K8sConfigMap, Query} from '../../lib/types.js';
+import {ExitCodes} from '../../lib/errors.js';
+import {
+  createResource,
+  getResource,
+  patchResourceStatus,
+} from '../../lib/kubectl.js';
+
+export const RESTORED_FROM_ANNOTATION = 'ark.mckinsey.com/restored-from';
+const QUERY_SNAPSHOT_KEY = 'query.json';
+
+// Recreates a soft-deleted query from its snapshot. The controller never runs
+// the restored query and the webhook rejects changes to its spec.
+export async function restoreQuery(name: string) {
+  try {
+    const snapshotName = `${name}-snapshot`;
+    const snapshot = await getResource<K8sConfigMap>(
+      'configmap',
+      snapshotName
+    );
+    const data = snapshot.data?.[QUERY_SNAPSHOT_KEY];
+    if (!data) {
+      throw new Error(`configmap ${snapshotName} is not a query snapshot`);
+    }
+
+    const {status, ...query} = JSON.parse(data) as Query;
+    const restored = await createResource<Query>({
+      ...query,
+      metadata: {
+        ...query.metadata,
+        annotations: {
+          ...query.metadata.annotations,
+          [RESTORED_FROM_ANNOTATION]: snapshotName,
+        },
+      },
+    });
+
+    if (status) {
+      await patchResourceStatus('queries', restored.metadata.name, status);
+    }
+
+    output.success(
+      `Query '${restored.metadata.name}' restored read-only from snapshot '${snapshotName}'`
+    );
+  } catch (error) {
+    output.error(
+      'restoring query:',
+      error instanceof Error ? error.message : error
+    );
+    process.exit(ExitCodes.CliError);
+  }
+}
+
+export function createRestoreCommand(_: ArkConfig): Command {
+  const restoreCommand = new Command('restore');
+  restoreCommand.description('Restore deleted resources from their snapshots');
+
+  const queryCommand = new Command('query');
+  queryCommand
+    .description('Restore a soft-deleted query, read-only, from its snapshot')
+    .argument('<name>', 'Query name')
+    .action(async (name: string) => {
+      await restoreQuery(name);
+    });
+
+  restoreCommand.addCommand(queryCommand);
+
+  return restoreCommand;
+}
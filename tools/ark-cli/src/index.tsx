@@ -24,6 +24,7 @@ import {createMemoryCommand} from './commands/memory/index.js';
 import {createModelsCommand} from './commands/models/index.js';
 import {createQueryCommand} from './commands/query/index.js';
 import {createQueriesCommand} from './commands/queries/index.js';
+import {createRestoreCommand} from './commands/restore/index.js';
 import {createUninstallCommand} from './commands/uninstall/index.js';
 import {createStatusCommand} from './commands/status/index.js';
 import {createConfigCommand} from './commands/config/index.js';
@@ -66,6 +67,7 @@ async function main() {
   program.addCommand(createModelsCommand(config));
   program.addCommand(createQueryCommand(config));
   program.addCommand(createQueriesCommand(config));
+  program.addCommand(createRestoreCommand(config));
   program.addCommand(createUninstallCommand(config));
   program.addCommand(createStatusCommand());
   program.addCommand(createConfigCommand(config));
@@ -5,9 +5,13 @@ jest.unstable_mockModule('execa', () => ({
   execa: mockExeca,
 }));
 
-const {getResource, listResources, deleteResource} = await import(
-  './kubectl.js'
-);
+const {
+  getResource,
+  listResources,
+  deleteResource,
+  createResource,
+  patchResourceStatus,
+} = await import('./kubectl.js');
 
 interface TestResource {
   metadata: {
@@ -261,4 +265,54 @@ describe('kubectl', () => {
       );
     });
   });
+
+  describe('createResource', () => {
+    it('should create a resource from its manifest', async () => {
+      const mockResource: TestResource = {
+        metadata: {
+          name: 'test-query',
+          creationTimestamp: '2024-01-01T00:00:00Z',
+        },
+      };
+      mockExeca.mockResolvedValue({
+        stdout: JSON.stringify(mockResource),
+      });
+
+      const result = await createResource(mockResource);
+
+      expect(result).toEqual(mockResource);
+      expect(mockExeca).toHaveBeenCalledWith(
+        'kubectl',
+        ['create', '-f', '-', '-o', 'json'],
+        {
+          input: JSON.stringify(mockResource),
+          stdio: ['pipe', 'pipe', 'pipe'],
+        }
+      );
+    });
+  });
+
+  describe('patchResourceStatus', () => {
+    it('should merge the status into the status subresource', async () => {
+      mockExeca.mockResolvedValue({
+        stdout: '',
+      });
+
+      await patchResourceStatus('queries', 'test-query', {phase: 'done'});
+
+      expect(mockExeca).toHaveBeenCalledWith(
+        'kubectl',
+        [
+          'patch',
+          'queries',
+          'test-query',
+          '--subresource=status',
+          '--type=merge',
+          '-p',
+          '{"status":{"phase":"done"}}',
+        ],
+        {stdio: 'pipe'}
+      );
+    });
+  });
 });
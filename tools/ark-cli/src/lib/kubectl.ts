@@ -92,3 +92,34 @@ export async function replaceResource<T extends K8sResource>(
 
   return JSON.parse(result.stdout) as T;
 }
+
+export async function createResource<T extends K8sResource>(
+  resource: T
+): Promise<T> {
+  const result = await execa('kubectl', ['create', '-f', '-', '-o', 'json'], {
+    input: JSON.stringify(resource),
+    stdio: ['pipe', 'pipe', 'pipe'],
+  });
+
+  return JSON.parse(result.stdout) as T;
+}
+
+export async function patchResourceStatus(
+  resourceType: string,
+  name: string,
+  status: unknown
+): Promise<void> {
+  await execa(
+    'kubectl',
+    [
+      'patch',
+      resourceType,
+      name,
+      '--subresource=status',
+      '--type=merge',
+      '-p',
+      JSON.stringify({status}),
+    ],
+    {stdio: 'pipe'}
+  );
+}
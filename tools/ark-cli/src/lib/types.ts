@@ -68,6 +68,7 @@ export interface K8sMetadata {
   name: string;
   namespace?: string;
   creationTimestamp?: string;
+  annotations?: Record<string, string>;
 }
 
 export interface K8sCondition {
@@ -76,6 +77,11 @@ export interface K8sCondition {
   message?: string;
 }
 
+export interface K8sConfigMap {
+  metadata: K8sMetadata;
+  data?: Record<string, string>;
+}
+
 export interface K8sListResource<T> {
   items: T[];
 }
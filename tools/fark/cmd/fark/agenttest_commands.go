@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// agentTestPollInterval is how often the status of running tests is read
+const agentTestPollInterval = 2 * time.Second
+
+func createTestCommand(config *Config) *cobra.Command {
+	var namespace string
+	var filenames []string
+	var timeout time.Duration
+	var outputMode string
+
+	cmd := &cobra.Command{
+		Use:   "test [test-name...]",
+		Short: "Run agent tests",
+		Long: `Run AgentTest resources and report their results.
+
+Tests given with -f are (re)created so that they run again. Tests given by name report the result of their
+current run, such as the run started when a GitOps tool applied them. The command exits with an error unless
+every test passes.`,
+		Example: `  fark test -f tests/weather-agent.yaml
+  fark test -f tests/ -n staging --timeout 10m
+  fark test weather-test greeting-test -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && len(filenames) == 0 {
+				return cmd.Help()
+			}
+			runner := &agentTestRunner{
+				config:    config,
+				namespace: getNamespaceOrDefault(namespace, config.Namespace),
+				out:       cmd.OutOrStdout(),
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			return runner.run(ctx, args, filenames, outputMode == "json")
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			ns := getNamespaceOrDefault(namespace, config.Namespace)
+			names, _ := NewResourceManager(config).GetResourceNames(ResourceAgentTest, ns)
+			return names, cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace of the tests")
+	cmd.Flags().StringSliceVarP(&filenames, "filename", "f", nil, "File or directory of AgentTest manifests to run")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "How long to wait for the tests to complete")
+	cmd.Flags().StringVarP(&outputMode, "output", "o", "text", "Output mode: text or json")
+
+	return cmd
+}
+
+type agentTestRunner struct {
+	config    *Config
+	namespace string
+	out       io.Writer
+}
+
+func (r *agentTestRunner) run(ctx context.Context, names, filenames []string, jsonOutput bool) error {
+	for _, filename := range filenames {
+		applied, err := r.applyFile(ctx, filename)
+		if err != nil {
+			return err
+		}
+		names = append(names, applied...)
+	}
+
+	tests := make([]*arkv1alpha1.AgentTest, 0, len(names))
+	for _, name := range names {
+		test, err := r.waitForResult(ctx, name)
+		if err != nil {
+			return err
+		}
+		tests = append(tests, test)
+	}
+
+	if jsonOutput {
+		statuses := make(map[string]arkv1alpha1.AgentTestStatus, len(tests))
+		for _, test := range tests {
+			statuses[test.Name] = test.Status
+		}
+		encoder := json.NewEncoder(r.out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(statuses); err != nil {
+			return err
+		}
+	} else {
+		for _, test := range tests {
+			printAgentTestResult(r.out, test)
+		}
+	}
+
+	passed := 0
+	for _, test := range tests {
+		if test.Status.Phase == arkv1alpha1.AgentTestPhasePassed {
+			passed++
+		}
+	}
+	if passed < len(tests) {
+		return fmt.Errorf("%d of %d tests did not pass", len(tests)-passed, len(tests))
+	}
+	return nil
+}
+
+// applyFile recreates the tests in a file, or in the YAML files of a directory, and returns their names
+func (r *agentTestRunner) applyFile(ctx context.Context, filename string) ([]string, error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		entries, err := os.ReadDir(filename)
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, entry := range entries {
+			if entry.IsDir() || !isYAMLFile(entry.Name()) {
+				continue
+			}
+			applied, err := r.applyFile(ctx, filepath.Join(filename, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, applied...)
+		}
+		return names, nil
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file '%s': %v", filename, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var names []string
+	decoder := k8syaml.NewYAMLOrJSONDecoder(file, 4096)
+	for {
+		var resource unstructured.Unstructured
+		if err := decoder.Decode(&resource.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return names, nil
+			}
+			return nil, fmt.Errorf("failed to parse '%s': %v", filename, err)
+		}
+		if resource.GetKind() != "AgentTest" {
+			continue
+		}
+		resource.SetNamespace(r.namespace)
+		if err := r.recreate(ctx, &resource); err != nil {
+			return nil, err
+		}
+		names = append(names, resource.GetName())
+	}
+}
+
+// recreate deletes the test if it exists and creates it again, so that it runs even if it did not change
+func (r *agentTestRunner) recreate(ctx context.Context, resource *unstructured.Unstructured) error {
+	tests := r.config.DynamicClient.Resource(GetGVR(ResourceAgentTest)).Namespace(r.namespace)
+	propagation := metav1.DeletePropagationForeground
+	err := tests.Delete(ctx, resource.GetName(), metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete agent test '%s': %v", resource.GetName(), err)
+	}
+	for err == nil {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out deleting agent test '%s'", resource.GetName())
+		case <-time.After(agentTestPollInterval):
+		}
+		_, err = tests.Get(ctx, resource.GetName(), metav1.GetOptions{})
+	}
+
+	if _, err := tests.Create(ctx, resource, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create agent test '%s': %v", resource.GetName(), err)
+	}
+	fmt.Fprintf(os.Stderr, "agent test '%s' started\n", resource.GetName())
+	return nil
+}
+
+// waitForResult waits for the current run of the test to complete
+func (r *agentTestRunner) waitForResult(ctx context.Context, name string) (*arkv1alpha1.AgentTest, error) {
+	tests := r.config.DynamicClient.Resource(GetGVR(ResourceAgentTest)).Namespace(r.namespace)
+	for {
+		resource, err := tests.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get agent test '%s': %v", name, err)
+		}
+		var test arkv1alpha1.AgentTest
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(resource.Object, &test); err != nil {
+			return nil, fmt.Errorf("failed to convert agent test '%s': %v", name, err)
+		}
+		if test.Status.ObservedGeneration == test.Generation && test.Status.Phase != "" && test.Status.Phase != arkv1alpha1.AgentTestPhaseRunning {
+			return &test, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for agent test '%s'", name)
+		case <-time.After(agentTestPollInterval):
+		}
+	}
+}
+
+func printAgentTestResult(out io.Writer, test *arkv1alpha1.AgentTest) {
+	switch test.Status.Phase {
+	case arkv1alpha1.AgentTestPhasePassed:
+		fmt.Fprintf(out, "PASS  %s (%s)\n", test.Name, test.Status.Message)
+	case arkv1alpha1.AgentTestPhaseFailed:
+		fmt.Fprintf(out, "FAIL  %s (%s)\n", test.Name, test.Status.Message)
+	default:
+		fmt.Fprintf(out, "ERROR %s: %s\n", test.Name, test.Status.Message)
+	}
+	for _, assertion := range test.Status.Assertions {
+		if !assertion.Passed {
+			fmt.Fprintf(out, "      %s %s: %s\n", assertion.Name, assertion.Expression, assertion.Message)
+		}
+	}
+	if test.Status.Phase == arkv1alpha1.AgentTestPhaseFailed {
+		fmt.Fprintf(out, "      response: %s\n", test.Status.Response)
+	}
+}
+
+func isYAMLFile(name string) bool {
+	switch filepath.Ext(name) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
@@ -81,6 +81,7 @@ agentic workloads across Kubernetes namespaces.`,
 	rootCmd.AddCommand(cf.CreateTargetCommand(ResourceModel, "model [model-name] [query...]", "Query models"))
 	rootCmd.AddCommand(cf.CreateTargetCommand(ResourceTool, "tool [tool-name] [request...]", "Query tools"))
 	rootCmd.AddCommand(createQueryCommand(config))
+	rootCmd.AddCommand(createTestCommand(config))
 
 	// Add CRUD commands
 	rootCmd.AddCommand(createGetCommand(config))
@@ -25,6 +25,8 @@ const (
 	ResourceModel ResourceType = "models"
 	ResourceTool  ResourceType = "tools"
 	ResourceEvent ResourceType = "events"
+
+	ResourceAgentTest ResourceType = "agenttests"
 )
 
 var resourceGVRMap = map[ResourceType]schema.GroupVersionResource{
@@ -34,6 +36,8 @@ var resourceGVRMap = map[ResourceType]schema.GroupVersionResource{
 	ResourceModel: {Group: "ark.mckinsey.com", Version: "v1alpha1", Resource: "models"},
 	ResourceTool:  {Group: "ark.mckinsey.com", Version: "v1alpha1", Resource: "tools"},
 	ResourceEvent: {Group: "", Version: "v1", Resource: "events"},
+
+	ResourceAgentTest: {Group: "ark.mckinsey.com", Version: "v1alpha1", Resource: "agenttests"},
 }
 
 func GetGVR(resourceType ResourceType) schema.GroupVersionResource {